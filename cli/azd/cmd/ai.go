@@ -0,0 +1,269 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/ai"
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/prompt"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func aiActions(root *actions.ActionDescriptor) *actions.ActionDescriptor {
+	group := root.Add("ai", &actions.ActionDescriptorOptions{
+		Command: &cobra.Command{
+			Short: "Explore Azure AI models.",
+		},
+		GroupingOptions: actions.CommandGroupOptions{
+			RootLevelHelp: actions.CmdGroupBeta,
+		},
+	})
+
+	group.Add("report", &actions.ActionDescriptorOptions{
+		Command:        newAiReportCmd(),
+		FlagsResolver:  newAiReportFlags,
+		ActionResolver: newAiReportAction,
+		OutputFormats:  []output.Format{output.JsonFormat, output.TableFormat},
+		DefaultFormat:  output.TableFormat,
+	})
+
+	return group
+}
+
+type aiReportFlags struct {
+	subscription string
+	locations    []string
+	global       *internal.GlobalCommandOptions
+}
+
+func (f *aiReportFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
+	local.StringVar(
+		&f.subscription,
+		"subscription",
+		"",
+		"ID of the Azure subscription to report on (prompted for when not set)",
+	)
+	local.StringArrayVarP(
+		&f.locations,
+		"location",
+		"l",
+		nil,
+		"Location to report on, e.g. eastus (repeatable; every AI Services location when not set)",
+	)
+
+	f.global = global
+}
+
+func newAiReportFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *aiReportFlags {
+	flags := &aiReportFlags{}
+	flags.Bind(cmd.Flags(), global)
+
+	return flags
+}
+
+func newAiReportCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "report <model>",
+		Short: "Report where a model is offered, with its versions, SKUs and remaining quota per location.",
+		Args:  cobra.ExactArgs(1),
+	}
+}
+
+// aiReportRow is the display struct for report table rendering; quota values are pre-formatted since a location
+// without usage data has none.
+type aiReportRow struct {
+	Location        string
+	Version         string
+	Sku             string
+	DeploymentKind  string
+	DefaultCapacity int32
+	Usage           string
+	Remaining       string
+	Deployable      string
+}
+
+type aiReportAction struct {
+	flags          *aiReportFlags
+	args           []string
+	aiModelService *ai.AiModelService
+	prompter       prompt.PromptService
+	console        input.Console
+	formatter      output.Formatter
+	writer         io.Writer
+}
+
+func newAiReportAction(
+	flags *aiReportFlags,
+	args []string,
+	aiModelService *ai.AiModelService,
+	prompter prompt.PromptService,
+	console input.Console,
+	formatter output.Formatter,
+	writer io.Writer,
+) actions.Action {
+	return &aiReportAction{
+		flags:          flags,
+		args:           args,
+		aiModelService: aiModelService,
+		prompter:       prompter,
+		console:        console,
+		formatter:      formatter,
+		writer:         writer,
+	}
+}
+
+func (a *aiReportAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	modelName := a.args[0]
+
+	subscriptionId := a.flags.subscription
+	if subscriptionId == "" {
+		subscription, err := a.prompter.PromptSubscription(ctx, &prompt.SelectOptions{
+			Message: "Select the subscription to report on",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("prompting for subscription: %w", err)
+		}
+		subscriptionId = subscription.Id
+	}
+
+	spinnerMessage := fmt.Sprintf("Building the availability and quota report for %s", modelName)
+	a.console.ShowSpinner(ctx, spinnerMessage, input.Step)
+	report, err := a.aiModelService.BuildModelReport(ctx, subscriptionId, modelName, a.flags.locations)
+	if err != nil {
+		a.console.StopSpinner(ctx, spinnerMessage, input.StepFailed)
+		return nil, fmt.Errorf("building model report: %w", err)
+	}
+	a.console.StopSpinner(ctx, "", input.StepDone)
+
+	result := aiModelReportContract(report)
+	if a.formatter.Kind() != output.TableFormat {
+		return nil, a.formatter.Format(result, a.writer, nil)
+	}
+
+	if len(result.Offers) == 0 {
+		a.console.Message(ctx, output.WithWarningFormat("%s is not offered in any of the checked locations.", result.Model))
+	} else {
+		err := a.formatter.Format(aiReportRows(result.Offers), a.writer, output.TableFormatterOptions{
+			Columns: []output.Column{
+				{Heading: "LOCATION", ValueTemplate: "{{.Location}}"},
+				{Heading: "VERSION", ValueTemplate: "{{.Version}}"},
+				{Heading: "SKU", ValueTemplate: "{{.Sku}}"},
+				{Heading: "KIND", ValueTemplate: "{{.DeploymentKind}}"},
+				{Heading: "DEFAULT CAPACITY", ValueTemplate: "{{.DefaultCapacity}}"},
+				{Heading: "USED/LIMIT", ValueTemplate: "{{.Usage}}"},
+				{Heading: "REMAINING", ValueTemplate: "{{.Remaining}}"},
+				{Heading: "DEPLOYABLE", ValueTemplate: "{{.Deployable}}"},
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if result.MostAvailableLocation != "" {
+		a.console.Message(ctx, fmt.Sprintf("\nMost remaining quota: %s",
+			output.WithHighLightFormat(result.MostAvailableLocation)))
+	}
+	if len(result.UnavailableLocations) > 0 {
+		a.console.Message(ctx, fmt.Sprintf("\nNot offered in: %s", strings.Join(result.UnavailableLocations, ", ")))
+	}
+	if len(result.FailedLocations) > 0 {
+		a.console.Message(ctx, output.WithErrorFormat(
+			"\nThe report may be incomplete; these locations could not be checked:"))
+		for _, failure := range result.FailedLocations {
+			if failure.Throttled {
+				a.console.Message(ctx,
+					fmt.Sprintf("  %s: throttled, try again later (%s)", failure.Location, failure.Reason))
+				continue
+			}
+			a.console.Message(ctx, fmt.Sprintf("  %s: %s", failure.Location, failure.Reason))
+		}
+	}
+
+	return nil, nil
+}
+
+// aiModelReportContract flattens report into one offer per location, version and SKU, in report order.
+func aiModelReportContract(report *ai.ModelReport) contracts.AiModelReport {
+	result := contracts.AiModelReport{
+		Model:                 report.ModelName,
+		MostAvailableLocation: report.MostAvailableLocation,
+		Offers:                []contracts.AiModelOffer{},
+		UnavailableLocations:  report.UnavailableLocations,
+	}
+
+	for _, location := range report.Locations {
+		for _, version := range location.Versions {
+			for _, sku := range version.Skus {
+				offer := contracts.AiModelOffer{
+					Location:        location.Location,
+					Version:         version.Version,
+					IsDefault:       version.IsDefault,
+					LifecycleStatus: version.LifecycleStatus,
+					Sku:             sku.Name,
+					DeploymentKind:  string(sku.DeploymentKind),
+					UsageName:       sku.UsageName,
+					DefaultCapacity: sku.DefaultCapacity,
+					CurrentUsage:    sku.CurrentUsage,
+					Limit:           sku.Limit,
+					Deployable:      sku.Deployable(),
+				}
+				if sku.RemainingQuota != ai.QuotaRemainingUnknown {
+					offer.RemainingQuota = new(sku.RemainingQuota)
+				}
+				result.Offers = append(result.Offers, offer)
+			}
+		}
+	}
+
+	for _, failure := range report.FailedLocations {
+		result.FailedLocations = append(result.FailedLocations, contracts.AiLocationFailure{
+			Location:  failure.Location,
+			Reason:    failure.Err.Error(),
+			Throttled: failure.Throttled,
+		})
+	}
+
+	return result
+}
+
+// aiReportRows returns the table rows of offers, marking the default version and formatting the quota.
+func aiReportRows(offers []contracts.AiModelOffer) []aiReportRow {
+	rows := make([]aiReportRow, 0, len(offers))
+	for _, offer := range offers {
+		row := aiReportRow{
+			Location:        offer.Location,
+			Version:         offer.Version,
+			Sku:             offer.Sku,
+			DeploymentKind:  offer.DeploymentKind,
+			DefaultCapacity: offer.DefaultCapacity,
+			Usage:           "-",
+			Remaining:       "unknown",
+			Deployable:      "no",
+		}
+		if offer.IsDefault {
+			row.Version += " (default)"
+		}
+		if offer.RemainingQuota != nil {
+			row.Usage = fmt.Sprintf("%.0f/%.0f", offer.CurrentUsage, offer.Limit)
+			row.Remaining = fmt.Sprintf("%.0f", *offer.RemainingQuota)
+		}
+		if offer.Deployable {
+			row.Deployable = "yes"
+		}
+		rows = append(rows, row)
+	}
+
+	return rows
+}
@@ -0,0 +1,111 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/ai"
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_aiModelReportContract(t *testing.T) {
+	globalSku := ai.AiModelSku{
+		Name:            "GlobalStandard",
+		UsageName:       "OpenAI.GlobalStandard.gpt-4o",
+		DefaultCapacity: 10,
+		DeploymentKind:  ai.SkuDeploymentKindGlobal,
+	}
+	standardSku := ai.AiModelSku{Name: "Standard", UsageName: "OpenAI.Standard.gpt-4o", DefaultCapacity: 10}
+
+	report := &ai.ModelReport{
+		ModelName: "gpt-4o",
+		Locations: []ai.ModelReportLocation{
+			{
+				Location: "eastus",
+				Versions: []ai.ModelReportVersion{{
+					Version:         "2024-08-06",
+					IsDefault:       true,
+					LifecycleStatus: "GenerallyAvailable",
+					Skus: []ai.ModelReportSku{
+						{AiModelSku: globalSku, CurrentUsage: 50, Limit: 100, RemainingQuota: 50},
+					},
+				}},
+			},
+			{
+				Location:     "westus",
+				QuotaUnknown: true,
+				Versions: []ai.ModelReportVersion{{
+					Version: "2024-05-13",
+					Skus: []ai.ModelReportSku{
+						{AiModelSku: standardSku, RemainingQuota: ai.QuotaRemainingUnknown},
+					},
+				}},
+			},
+		},
+		UnavailableLocations: []string{"northeurope"},
+		FailedLocations: []ai.LocationError{
+			{Location: "swedencentral", Err: errors.New("listing usages: 429"), Throttled: true},
+		},
+		MostAvailableLocation: "eastus",
+	}
+
+	result := aiModelReportContract(report)
+	require.Equal(t, contracts.AiModelReport{
+		Model:                 "gpt-4o",
+		MostAvailableLocation: "eastus",
+		Offers: []contracts.AiModelOffer{
+			{
+				Location:        "eastus",
+				Version:         "2024-08-06",
+				IsDefault:       true,
+				LifecycleStatus: "GenerallyAvailable",
+				Sku:             "GlobalStandard",
+				DeploymentKind:  "Global",
+				UsageName:       "OpenAI.GlobalStandard.gpt-4o",
+				DefaultCapacity: 10,
+				CurrentUsage:    50,
+				Limit:           100,
+				RemainingQuota:  new(float64(50)),
+				Deployable:      true,
+			},
+			{
+				Location:        "westus",
+				Version:         "2024-05-13",
+				Sku:             "Standard",
+				UsageName:       "OpenAI.Standard.gpt-4o",
+				DefaultCapacity: 10,
+				Deployable:      true,
+			},
+		},
+		UnavailableLocations: []string{"northeurope"},
+		FailedLocations: []contracts.AiLocationFailure{
+			{Location: "swedencentral", Reason: "listing usages: 429", Throttled: true},
+		},
+	}, result)
+
+	require.Equal(t, []aiReportRow{
+		{
+			Location:        "eastus",
+			Version:         "2024-08-06 (default)",
+			Sku:             "GlobalStandard",
+			DeploymentKind:  "Global",
+			DefaultCapacity: 10,
+			Usage:           "50/100",
+			Remaining:       "50",
+			Deployable:      "yes",
+		},
+		{
+			Location:        "westus",
+			Version:         "2024-05-13",
+			Sku:             "Standard",
+			DefaultCapacity: 10,
+			Usage:           "-",
+			Remaining:       "unknown",
+			Deployable:      "yes",
+		},
+	}, aiReportRows(result.Offers))
+}
@@ -181,7 +181,7 @@ func (ba *buildAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 
 			buildResult, err := async.RunWithProgress(
 				func(buildProgress project.ServiceProgress) {
-					progressMessage := fmt.Sprintf("Building service %s (%s)", svc.Name, buildProgress.Message)
+					progressMessage := fmt.Sprintf("Building service %s (%s)", svc.Name, buildProgress)
 					ba.console.ShowSpinner(ctx, progressMessage, input.Step)
 				},
 				func(progress *async.Progress[project.ServiceProgress]) (*project.ServiceBuildResult, error) {
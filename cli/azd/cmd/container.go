@@ -26,6 +26,7 @@ import (
 	agentcopilot "github.com/azure/azure-dev/cli/azd/internal/agent/copilot"
 	"github.com/azure/azure-dev/cli/azd/internal/agent/security"
 	"github.com/azure/azure-dev/cli/azd/internal/cmd"
+	"github.com/azure/azure-dev/cli/azd/internal/cmd/add"
 	"github.com/azure/azure-dev/cli/azd/internal/grpcserver"
 	"github.com/azure/azure-dev/cli/azd/internal/repository"
 	"github.com/azure/azure-dev/cli/azd/internal/terminal"
@@ -678,7 +679,28 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 	container.MustRegisterSingleton(containerregistry.NewRemoteBuildManager)
 	container.MustRegisterSingleton(keyvault.NewKeyVaultService)
 	container.MustRegisterSingleton(storage.NewFileShareService)
-	container.MustRegisterSingleton(ai.NewAiModelService)
+	container.MustRegisterSingleton(func(
+		azureClient *azapi.AzureClient,
+		subManager *account.SubscriptionsManager,
+		lazyProjectConfig *lazy.Lazy[*project.ProjectConfig],
+		userConfigManager config.UserConfigManager,
+	) *ai.AiModelService {
+		aiModelService := ai.NewAiModelService(azureClient, subManager)
+
+		// Preferred SKUs come from `azure.yaml` first, then from the global user configuration.
+		var projectSkus []string
+		if projectConfig, err := lazyProjectConfig.GetValue(); err == nil && projectConfig != nil {
+			projectSkus = projectConfig.PreferredAiSkus
+		}
+		userConfig, err := userConfigManager.Load()
+		if err != nil {
+			log.Printf("loading user config for AI model settings: %v", err)
+		}
+		aiModelService.SetPreferredSkus(ai.ConfiguredPreferredSkus(projectSkus, userConfig))
+		aiModelService.SetCatalogRetries(ai.ConfiguredCatalogRetries(userConfig))
+
+		return aiModelService
+	})
 	container.MustRegisterSingleton(func(serviceLocator ioc.ServiceLocator) *errorhandler.ErrorHandlerPipeline {
 		resolver := func(name string) (errorhandler.ErrorHandler, error) {
 			var handler errorhandler.ErrorHandler
@@ -958,6 +980,9 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 	container.MustRegisterSingleton(extensions.NewSourceManager)
 	container.MustRegisterSingleton(extensions.NewRunner)
 	container.MustRegisterScoped(middleware.NewExtensionActivator)
+	container.MustRegisterScoped(func(activator *middleware.ExtensionActivator) add.MenuContributor {
+		return activator
+	})
 	container.MustRegisterSingleton(func(serviceLocator ioc.ServiceLocator) *lazy.Lazy[*extensions.Runner] {
 		return lazy.NewLazy(func() (*extensions.Runner, error) {
 			var runner *extensions.Runner
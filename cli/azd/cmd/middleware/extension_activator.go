@@ -8,12 +8,14 @@ import (
 	"fmt"
 	"log"
 	"maps"
+	"os"
 	"slices"
 	"strings"
 	"sync"
 
 	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/internal/grpcserver"
+	"github.com/azure/azure-dev/cli/azd/internal/tracing"
 	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
 	"github.com/azure/azure-dev/cli/azd/pkg/ioc"
@@ -170,6 +172,65 @@ func (a *ExtensionActivator) SuggestExtensionForProvider(ctx context.Context, pr
 	return matches[0].Id
 }
 
+// AddMenuEntries returns the entries installed extensions contribute to the `azd add` resource type menu.
+func (a *ExtensionActivator) AddMenuEntries() ([]extensions.AddMenuEntry, error) {
+	return a.extensionManager.AddMenuEntries()
+}
+
+// InvokeAddMenuEntry runs the extension that contributed entry interactively, as `<extension> add-menu <name>`, with
+// the extension host started so the extension can prompt and add its resource through the azd gRPC services.
+func (a *ExtensionActivator) InvokeAddMenuEntry(ctx context.Context, entry extensions.AddMenuEntry) error {
+	var grpcServer *grpcserver.Server
+	if err := a.serviceLocator.Resolve(&grpcServer); err != nil {
+		return err
+	}
+
+	serverInfo, err := grpcServer.Start()
+	if err != nil {
+		return fmt.Errorf("failed to start gRPC server: %w", err)
+	}
+
+	defer func() {
+		if err := grpcServer.Stop(); err != nil {
+			log.Printf("failed to stop gRPC server after add menu entry: %v", err)
+		}
+	}()
+
+	jwtToken, err := grpcserver.GenerateExtensionToken(entry.Extension, serverInfo)
+	if err != nil {
+		return fmt.Errorf("generating extension token: %w", internal.ErrExtensionTokenFailed)
+	}
+
+	env := append(os.Environ(),
+		fmt.Sprintf("AZD_SERVER=%s", serverInfo.Address),
+		fmt.Sprintf("AZD_ACCESS_TOKEN=%s", jwtToken),
+	)
+	if !color.NoColor {
+		env = append(env, "FORCE_COLOR=1")
+	}
+	if traceEnv := tracing.Environ(ctx); len(traceEnv) > 0 {
+		env = append(env, traceEnv...)
+	}
+
+	_, err = a.extensionRunner.Invoke(ctx, entry.Extension, &extensions.InvokeOptions{
+		Args:        []string{"add-menu", entry.Name},
+		Env:         env,
+		Interactive: true,
+		Debug:       a.globalOptions.EnableDebugLogging,
+		NoPrompt:    a.globalOptions.NoPrompt,
+		Cwd:         a.globalOptions.Cwd,
+		Environment: a.globalOptions.EnvironmentName,
+	})
+	if err != nil {
+		if reported := entry.Extension.GetReportedError(); reported != nil {
+			return fmt.Errorf("%w: %w", reported, err)
+		}
+		return err
+	}
+
+	return nil
+}
+
 // providerResolvable reports whether the named provisioning provider already resolves from the
 // IoC container, meaning the extension registering it is already running.
 func (a *ExtensionActivator) providerResolvable(providerName string) bool {
@@ -6,11 +6,14 @@ package middleware
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
 	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/pkg/alpha"
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
 	"github.com/azure/azure-dev/cli/azd/test/mocks"
 	"github.com/stretchr/testify/require"
 )
@@ -61,6 +64,30 @@ func TestUxMiddleware_OtherErrors_NotSwallowed(t *testing.T) {
 	require.ErrorIs(t, err, someErr)
 }
 
+func TestUxMiddleware_ExtensionErrorSuggestion_Displayed(t *testing.T) {
+	t.Parallel()
+	mockContext := mocks.NewMockContext(t.Context())
+	featureManager := &alpha.FeatureManager{}
+	ux := NewUxMiddleware(&Options{}, mockContext.Console, featureManager)
+
+	// Mirrors the error returned by an external service target after the envelope unwraps the extension's error.
+	extErr := &azdext.LocalError{
+		Message:    "registry is throttling pushes",
+		Code:       azdext.ErrorCodeRetryable,
+		Category:   azdext.LocalErrorCategoryDependency,
+		Suggestion: "Wait a few minutes and run 'azd deploy' again.",
+	}
+
+	_, err := ux.Run(*mockContext.Context, func(ctx context.Context) (*actions.ActionResult, error) {
+		return nil, fmt.Errorf("deploying service 'api': %w", extErr)
+	})
+
+	require.ErrorIs(t, err, extErr)
+	consoleOutput := strings.Join(mockContext.Console.Output(), "\n")
+	require.Contains(t, consoleOutput, "registry is throttling pushes")
+	require.Contains(t, consoleOutput, "Wait a few minutes and run 'azd deploy' again.")
+}
+
 func TestUxMiddleware_Success_ShowsActionResult(t *testing.T) {
 	t.Parallel()
 	mockContext := mocks.NewMockContext(t.Context())
@@ -198,7 +198,7 @@ func (pa *packageAction) Run(ctx context.Context) (*actions.ActionResult, error)
 			options := &project.PackageOptions{OutputPath: pa.flags.outputPath}
 			packageResult, err := async.RunWithProgress(
 				func(packageProgress project.ServiceProgress) {
-					progressMessage := fmt.Sprintf("Packaging service %s (%s)", svc.Name, packageProgress.Message)
+					progressMessage := fmt.Sprintf("Packaging service %s (%s)", svc.Name, packageProgress)
 					pa.console.ShowSpinner(ctx, progressMessage, input.Step)
 				},
 				func(progress *async.Progress[project.ServiceProgress]) (*project.ServicePackageResult, error) {
@@ -175,7 +175,7 @@ func (ra *restoreAction) Run(ctx context.Context) (*actions.ActionResult, error)
 
 			restoreResult, err := async.RunWithProgress(
 				func(buildProgress project.ServiceProgress) {
-					progressMessage := fmt.Sprintf("Building service %s (%s)", svc.Name, buildProgress.Message)
+					progressMessage := fmt.Sprintf("Building service %s (%s)", svc.Name, buildProgress)
 					ra.console.ShowSpinner(ctx, progressMessage, input.Step)
 				},
 				func(progress *async.Progress[project.ServiceProgress]) (*project.ServiceRestoreResult, error) {
@@ -213,6 +213,7 @@ func newRootCmd(
 	mcpActions(root)
 	copilotActions(root)
 	execActions(root)
+	aiActions(root)
 
 	toolActions(root)
 
@@ -440,6 +441,7 @@ func newRootCmd(
 	root.
 		Add("add", &actions.ActionDescriptorOptions{
 			Command:        add.NewAddCmd(),
+			FlagsResolver:  add.NewAddFlags,
 			ActionResolver: add.NewAddAction,
 			GroupingOptions: actions.CommandGroupOptions{
 				RootLevelHelp: actions.CmdGroupBeta,
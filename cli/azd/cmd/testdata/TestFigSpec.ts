@@ -215,10 +215,16 @@ const completionSpec: Fig.Spec = {
 		{
 			name: ['add'],
 			description: 'Add a component to your project.',
+			options: [
+				{
+					name: ['--include-preview-models'],
+					description: 'Includes preview AI models when selecting a model to add.',
+				},
+			],
 		},
 		{
 			name: ['ai'],
-			description: 'Commands for the ai extension namespace.',
+			description: 'Explore Azure AI models.',
 			subcommands: [
 				{
 					name: ['agent'],
@@ -3455,6 +3461,34 @@ const completionSpec: Fig.Spec = {
 						},
 					],
 				},
+				{
+					name: ['report'],
+					description: 'Report where a model is offered, with its versions, SKUs and remaining quota per location.',
+					options: [
+						{
+							name: ['--location', '-l'],
+							description: 'Location to report on, e.g. eastus (repeatable; every AI Services location when not set)',
+							isRepeatable: true,
+							args: [
+								{
+									name: 'location',
+								},
+							],
+						},
+						{
+							name: ['--subscription'],
+							description: 'ID of the Azure subscription to report on (prompted for when not set)',
+							args: [
+								{
+									name: 'subscription',
+								},
+							],
+						},
+					],
+					args: {
+						name: 'model',
+					},
+				},
 				{
 					name: ['routine'],
 					description: 'Manage Microsoft Foundry Routines from your terminal. (Beta)',
@@ -4,12 +4,16 @@
 package cmd
 
 import (
+	"bytes"
 	"cmp"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"slices"
+	"strings"
 
+	"github.com/azure/azure-dev/cli/azd/pkg/ai"
 	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -24,6 +28,9 @@ func newAiCommand() *cobra.Command {
 	aiCmd.AddCommand(newAiModelsCommand())
 	aiCmd.AddCommand(newAiQuotaCommand())
 	aiCmd.AddCommand(newAiDeploymentCommand())
+	aiCmd.AddCommand(newAiPlanCommand())
+	aiCmd.AddCommand(newAiValidateCommand())
+	aiCmd.AddCommand(newAiStatusCommand())
 
 	return aiCmd
 }
@@ -39,21 +46,100 @@ func promptSubscription(ctx context.Context, azdClient *azdext.AzdClient) (strin
 	return resp.Subscription.Id, nil
 }
 
-// promptLocation prompts the user to select an Azure location.
-func promptLocation(ctx context.Context, azdClient *azdext.AzdClient, subId string) (string, error) {
-	resp, err := azdClient.Prompt().PromptLocation(ctx, &azdext.PromptLocationRequest{
+// promptScope prompts the user to select an Azure subscription and location.
+func promptScope(ctx context.Context, azdClient *azdext.AzdClient) (*azdext.AzureScope, error) {
+	resp, err := azdClient.Prompt().PromptAzureScope(ctx, &azdext.PromptAzureScopeRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("selecting subscription and location: %w", err)
+	}
+	return resp.AzureContext.Scope, nil
+}
+
+// aiScopeFlags are the --subscription and --location flags of the ai commands.
+type aiScopeFlags struct {
+	subscription string
+	location     string
+}
+
+func (f *aiScopeFlags) bind(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.subscription, "subscription", "", "Azure subscription ID (prompted for when not set)")
+	cmd.Flags().StringVar(&f.location, "location", "", "Azure location, e.g. eastus (prompted for when not set)")
+}
+
+// complete reports whether both the subscription and the location were supplied.
+func (f *aiScopeFlags) complete() bool {
+	return f.subscription != "" && f.location != ""
+}
+
+// resolve returns the scope selected by the flags, prompting only for the missing pieces. The location is only
+// prompted for when requireLocation is set.
+func (f *aiScopeFlags) resolve(
+	ctx context.Context, azdClient *azdext.AzdClient, requireLocation bool,
+) (*azdext.AzureScope, error) {
+	if f.subscription == "" && f.location == "" && requireLocation {
+		return promptScope(ctx, azdClient)
+	}
+
+	scope := &azdext.AzureScope{SubscriptionId: f.subscription, Location: f.location}
+	if scope.SubscriptionId == "" {
+		subId, err := promptSubscription(ctx, azdClient)
+		if err != nil {
+			return nil, err
+		}
+		scope.SubscriptionId = subId
+	}
+
+	if scope.Location == "" && requireLocation {
+		resp, err := azdClient.Prompt().PromptLocation(ctx, &azdext.PromptLocationRequest{
+			AzureContext: &azdext.AzureContext{Scope: scope},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("selecting location: %w", err)
+		}
+		scope.Location = resp.Location.Name
+	}
+
+	return scope, nil
+}
+
+// findAiModel returns the catalog model named name, restricted to the scope location when it is set.
+func findAiModel(
+	ctx context.Context, azdClient *azdext.AzdClient, scope *azdext.AzureScope, name string,
+) (*azdext.AiModel, error) {
+	filter := &azdext.AiModelFilterOptions{}
+	if scope.Location != "" {
+		filter.Locations = []string{scope.Location}
+	}
+
+	resp, err := azdClient.Ai().ListModels(ctx, &azdext.ListModelsRequest{
 		AzureContext: &azdext.AzureContext{
-			Scope: &azdext.AzureScope{SubscriptionId: subId},
+			Scope: &azdext.AzureScope{SubscriptionId: scope.SubscriptionId},
 		},
+		Filter: filter,
 	})
 	if err != nil {
-		return "", fmt.Errorf("selecting location: %w", err)
+		return nil, fmt.Errorf("listing models: %w", err)
 	}
-	return resp.Location.Name, nil
+
+	idx := slices.IndexFunc(resp.Models, func(model *azdext.AiModel) bool {
+		return model.Name == name
+	})
+	if idx < 0 {
+		if scope.Location != "" {
+			return nil, fmt.Errorf("model %q is not available in %s", name, scope.Location)
+		}
+		return nil, fmt.Errorf("model %q was not found", name)
+	}
+
+	return resp.Models[idx], nil
 }
 
 func newAiModelsCommand() *cobra.Command {
-	return &cobra.Command{
+	var debugDump bool
+	var scopeFlags aiScopeFlags
+	var modelName string
+
+	cmd := &cobra.Command{
 		Use:   "models",
 		Short: "Browse available AI models interactively.",
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -71,22 +157,42 @@ func newAiModelsCommand() *cobra.Command {
 				return fmt.Errorf("failed waiting for debugger: %w", err)
 			}
 
-			subId, err := promptSubscription(ctx, azdClient)
+			if debugDump {
+				scope, err := scopeFlags.resolve(ctx, azdClient, true)
+				if err != nil {
+					return err
+				}
+
+				return dumpRawAiModels(ctx, azdClient, scope)
+			}
+
+			scope, err := scopeFlags.resolve(ctx, azdClient, false)
 			if err != nil {
 				return err
 			}
 
-			azureContext := &azdext.AzureContext{
-				Scope: &azdext.AzureScope{
-					SubscriptionId: subId,
-				},
+			if modelName != "" {
+				model, err := findAiModel(ctx, azdClient, scope, modelName)
+				if err != nil {
+					return err
+				}
+
+				printAiModelDetails(model)
+				return nil
+			}
+
+			filter := &azdext.AiModelFilterOptions{
+				Intent: "chat",
+			}
+			if scope.Location != "" {
+				filter.Locations = []string{scope.Location}
 			}
 
 			modelResp, err := azdClient.Prompt().PromptAiModel(ctx, &azdext.PromptAiModelRequest{
-				AzureContext: azureContext,
-				Filter: &azdext.AiModelFilterOptions{
-					Capabilities: []string{"chatCompletion"},
+				AzureContext: &azdext.AzureContext{
+					Scope: &azdext.AzureScope{SubscriptionId: scope.SubscriptionId},
 				},
+				Filter: filter,
 			})
 			if err != nil {
 				return fmt.Errorf("selecting model: %w", err)
@@ -97,6 +203,33 @@ func newAiModelsCommand() *cobra.Command {
 			return nil
 		},
 	}
+
+	scopeFlags.bind(cmd)
+	cmd.Flags().StringVar(&modelName, "model", "", "Model to show details for, e.g. gpt-4o (prompted for when not set)")
+	cmd.Flags().BoolVar(&debugDump, "debug-dump", false,
+		"Print the raw ARM model list for a selected location as JSON, before any filtering")
+	_ = cmd.Flags().MarkHidden("debug-dump")
+
+	return cmd
+}
+
+// dumpRawAiModels prints the unprocessed ARM model list for the scope location as indented JSON.
+func dumpRawAiModels(ctx context.Context, azdClient *azdext.AzdClient, scope *azdext.AzureScope) error {
+	resp, err := azdClient.Ai().ListRawModels(ctx, &azdext.ListRawModelsRequest{
+		AzureContext: &azdext.AzureContext{Scope: scope},
+		Location:     scope.Location,
+	})
+	if err != nil {
+		return fmt.Errorf("listing raw models: %w", err)
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, []byte(resp.ModelsJson), "", "  "); err != nil {
+		return fmt.Errorf("formatting raw models: %w", err)
+	}
+
+	fmt.Println(indented.String())
+	return nil
 }
 
 func printAiModelDetails(model *azdext.AiModel) {
@@ -126,8 +259,8 @@ func printAiModelDetails(model *azdext.AiModel) {
 	}
 
 	versions := slices.Clone(model.Versions)
-	slices.SortFunc(versions, func(a, b *azdext.AiModelVersion) int {
-		return cmp.Compare(a.Version, b.Version)
+	slices.SortStableFunc(versions, func(a, b *azdext.AiModelVersion) int {
+		return ai.CompareModelVersionsNewestFirst(a.Version, a.IsDefault, b.Version, b.IsDefault)
 	})
 
 	fmt.Printf("  Versions (%d):\n", len(versions))
@@ -159,7 +292,11 @@ func printAiModelDetails(model *azdext.AiModel) {
 }
 
 func newAiQuotaCommand() *cobra.Command {
-	return &cobra.Command{
+	var scopeFlags aiScopeFlags
+	var modelName string
+	var group bool
+
+	cmd := &cobra.Command{
 		Use:   "quota",
 		Short: "View usage meters and limits for a selected location.",
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -177,14 +314,53 @@ func newAiQuotaCommand() *cobra.Command {
 				return fmt.Errorf("failed waiting for debugger: %w", err)
 			}
 
-			subId, err := promptSubscription(ctx, azdClient)
+			scope, err := scopeFlags.resolve(ctx, azdClient, true)
 			if err != nil {
 				return err
 			}
+			subId, location := scope.SubscriptionId, scope.Location
+
+			// With --model, only its meters are shown. With the scope fully supplied by flags, all meters are shown
+			// without asking, so the command can run unattended.
+			showAll := modelName == "" && scopeFlags.complete()
+			if modelName == "" && !showAll {
+				showAllResp, err := azdClient.Prompt().Confirm(ctx, &azdext.ConfirmRequest{
+					Options: &azdext.ConfirmOptions{
+						Message:      "Show all usage meters?",
+						HelpMessage:  "Choose no to select a model and only show the meters it consumes.",
+						DefaultValue: new(false),
+					},
+				})
+				if err != nil {
+					return fmt.Errorf("confirming meter filter: %w", err)
+				}
+				showAll = *showAllResp.Value
+			}
 
-			location, err := promptLocation(ctx, azdClient, subId)
-			if err != nil {
-				return err
+			var usageNames []string
+			if modelName != "" {
+				model, err := findAiModel(ctx, azdClient, scope, modelName)
+				if err != nil {
+					return err
+				}
+
+				usageNames = modelUsageNames(model)
+			} else if !showAll {
+				color.Cyan("Loading models for %s...", location)
+				modelResp, err := azdClient.Prompt().PromptAiModel(ctx, &azdext.PromptAiModelRequest{
+					AzureContext: &azdext.AzureContext{Scope: scope},
+					Filter: &azdext.AiModelFilterOptions{
+						Locations: []string{location},
+					},
+					SelectOptions: &azdext.SelectOptions{
+						Message: "Select an AI model to show usage meters for",
+					},
+				})
+				if err != nil {
+					return fmt.Errorf("selecting model: %w", err)
+				}
+
+				usageNames = modelUsageNames(modelResp.Model)
 			}
 
 			color.Cyan("Listing AI model usages...")
@@ -196,38 +372,288 @@ func newAiQuotaCommand() *cobra.Command {
 					Scope: &azdext.AzureScope{SubscriptionId: subId},
 				},
 				Location: location,
+				// Meters with a limit of 0 do not apply at the location.
+				OmitZeroLimit: true,
 			})
 			if err != nil {
 				return fmt.Errorf("listing usages: %w", err)
 			}
 
-			color.HiWhite("Found %d usage entries:\n", len(resp.Usages))
-			for _, usage := range resp.Usages {
-				remaining := usage.Limit - usage.CurrentValue
-				usageColor := color.HiGreenString
-				if remaining <= 0 {
-					usageColor = color.HiRedString
-				} else if remaining < usage.Limit*0.2 {
-					usageColor = color.HiYellowString
+			usages := resp.Usages
+			if usageNames != nil {
+				usages = filterUsagesByName(usages, usageNames)
+			}
+
+			color.HiWhite("Found %d usage entries:\n", len(usages))
+			if !group {
+				for _, usage := range usages {
+					printUsage("  ", usage.Name, usage.CurrentValue, usage.Limit)
 				}
 
-				fmt.Printf("  %s: %s / %.0f\n",
-					color.CyanString(usage.Name),
-					usageColor("%.0f", usage.CurrentValue),
-					usage.Limit,
-				)
+				return nil
+			}
+
+			for _, g := range groupUsagesByFamily(usages) {
+				fmt.Println()
+				printUsage("  ", fmt.Sprintf("%s (%d meters)", g.Family, len(g.Usages)), g.CurrentValue, g.Limit)
+				for _, usage := range g.Usages {
+					printUsage("    ", usage.Name, usage.CurrentValue, usage.Limit)
+				}
 			}
 
 			return nil
 		},
 	}
+
+	scopeFlags.bind(cmd)
+	cmd.Flags().StringVar(&modelName, "model", "",
+		"Only show the usage meters this model consumes, e.g. gpt-4o (all meters when --subscription and "+
+			"--location are set, otherwise prompted for)")
+	cmd.Flags().BoolVar(&group, "group", false,
+		"Group the meters by family, e.g. OpenAI or AIServices, with a subtotal per family")
+
+	return cmd
+}
+
+// printUsage prints a usage line, colored by how much of limit remains.
+func printUsage(indent string, name string, currentValue float64, limit float64) {
+	remaining := limit - currentValue
+	usageColor := color.HiGreenString
+	if remaining <= 0 {
+		usageColor = color.HiRedString
+	} else if remaining < limit*0.2 {
+		usageColor = color.HiYellowString
+	}
+
+	fmt.Printf("%s%s: %s / %.0f\n", indent, color.CyanString(name), usageColor("%.0f", currentValue), limit)
+}
+
+// otherUsageFamily groups the meters whose name has no family prefix.
+const otherUsageFamily = "Other"
+
+// usageFamily is a group of usage meters sharing a family prefix, with the totals of their values and limits.
+type usageFamily struct {
+	Family       string
+	Usages       []*azdext.AiModelUsage
+	CurrentValue float64
+	Limit        float64
+}
+
+// usageMeterFamily returns the family of a usage meter: the prefix of its name before the first dot, e.g. OpenAI for
+// OpenAI.Standard.gpt-4o, or otherUsageFamily when the name has no such prefix.
+func usageMeterFamily(name string) string {
+	family, rest, found := strings.Cut(name, ".")
+	if !found || strings.TrimSpace(family) == "" || rest == "" {
+		return otherUsageFamily
+	}
+
+	return family
+}
+
+// groupUsagesByFamily groups usages by meter family, sorted by family name with otherUsageFamily last. Usages keep
+// their order within a group.
+func groupUsagesByFamily(usages []*azdext.AiModelUsage) []usageFamily {
+	var groups []usageFamily
+	for _, usage := range usages {
+		family := usageMeterFamily(usage.Name)
+		i := slices.IndexFunc(groups, func(g usageFamily) bool { return g.Family == family })
+		if i < 0 {
+			groups = append(groups, usageFamily{Family: family})
+			i = len(groups) - 1
+		}
+
+		groups[i].Usages = append(groups[i].Usages, usage)
+		groups[i].CurrentValue += usage.CurrentValue
+		groups[i].Limit += usage.Limit
+	}
+
+	slices.SortFunc(groups, func(a, b usageFamily) int {
+		if (a.Family == otherUsageFamily) != (b.Family == otherUsageFamily) {
+			if a.Family == otherUsageFamily {
+				return 1
+			}
+			return -1
+		}
+		return strings.Compare(a.Family, b.Family)
+	})
+
+	return groups
+}
+
+// modelUsageNames returns the usage meters consumed when deploying model: the usage name of every SKU across its
+// versions, plus the meter that counts AI Services accounts.
+func modelUsageNames(model *azdext.AiModel) []string {
+	names := []string{ai.AccountCountUsageName}
+	for _, version := range model.Versions {
+		for _, sku := range version.Skus {
+			if sku.UsageName != "" && !slices.Contains(names, sku.UsageName) {
+				names = append(names, sku.UsageName)
+			}
+		}
+	}
+
+	return names
+}
+
+// filterUsagesByName returns the usages whose name is one of names, preserving their order.
+func filterUsagesByName(usages []*azdext.AiModelUsage, names []string) []*azdext.AiModelUsage {
+	filtered := make([]*azdext.AiModelUsage, 0, len(names))
+	for _, usage := range usages {
+		if slices.Contains(names, usage.Name) {
+			filtered = append(filtered, usage)
+		}
+	}
+
+	return filtered
+}
+
+// aiDeploymentFlags are the flags of the ai deployment command that select the deployment to resolve.
+type aiDeploymentFlags struct {
+	model    string
+	version  string
+	sku      string
+	capacity int32
+}
+
+// complete reports whether every deployment choice was supplied, so that no prompt is needed.
+func (f *aiDeploymentFlags) complete() bool {
+	return f.model != "" && f.version != "" && f.sku != "" && f.capacity > 0
+}
+
+// options returns the deployment options at location restricted to the supplied flags.
+func (f *aiDeploymentFlags) options(location string) *azdext.AiModelDeploymentOptions {
+	options := &azdext.AiModelDeploymentOptions{
+		Locations: []string{location},
+	}
+	if f.version != "" {
+		options.Versions = []string{f.version}
+	}
+	if f.sku != "" {
+		options.Skus = []string{f.sku}
+	}
+	if f.capacity > 0 {
+		options.Capacity = &f.capacity
+	}
+
+	return options
+}
+
+// The orders in which the ai deployment command selects the location and the model.
+const (
+	// aiSelectionModeLocationFirst selects a location, then a model offered there.
+	aiSelectionModeLocationFirst = "location-first"
+	// aiSelectionModeModelFirst selects a model across all locations, then a location with quota for it.
+	aiSelectionModeModelFirst = "model-first"
+)
+
+// selectAiDeploymentLocationFirst selects the subscription and location, then a model with quota at the location.
+// The model prompt is skipped when model is set.
+func selectAiDeploymentLocationFirst(
+	ctx context.Context, azdClient *azdext.AzdClient, scopeFlags *aiScopeFlags, model string,
+) (*azdext.AzureScope, string, error) {
+	scope, err := scopeFlags.resolve(ctx, azdClient, true)
+	if err != nil {
+		return nil, "", err
+	}
+	if model != "" {
+		return scope, model, nil
+	}
+
+	// Use PromptAiModel to let user select a model (scoped to chosen location)
+	color.Cyan("Loading models for %s...", scope.Location)
+	modelResp, err := azdClient.Prompt().PromptAiModel(ctx, &azdext.PromptAiModelRequest{
+		AzureContext: &azdext.AzureContext{Scope: scope},
+		Filter: &azdext.AiModelFilterOptions{
+			Locations: []string{scope.Location},
+		},
+		SelectOptions: &azdext.SelectOptions{
+			Message: "Select an AI model to deploy",
+		},
+		Quota: &azdext.QuotaCheckOptions{
+			MinRemainingCapacity: 1,
+		},
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("selecting model: %w", err)
+	}
+
+	return scope, modelResp.Model.Name, nil
+}
+
+// selectAiDeploymentModelFirst selects the subscription and a model across all of its locations, then a location
+// with quota for the model. The model prompt is skipped when model is set, and the location prompt when --location
+// is set.
+func selectAiDeploymentModelFirst(
+	ctx context.Context, azdClient *azdext.AzdClient, scopeFlags *aiScopeFlags, model string,
+) (*azdext.AzureScope, string, error) {
+	scope, err := scopeFlags.resolve(ctx, azdClient, false)
+	if err != nil {
+		return nil, "", err
+	}
+	azureContext := &azdext.AzureContext{
+		Scope: &azdext.AzureScope{SubscriptionId: scope.SubscriptionId},
+	}
+
+	if model == "" {
+		// Without a location filter, models are offered when any of their locations has quota.
+		color.Cyan("Loading models across all locations...")
+		modelResp, err := azdClient.Prompt().PromptAiModel(ctx, &azdext.PromptAiModelRequest{
+			AzureContext: azureContext,
+			SelectOptions: &azdext.SelectOptions{
+				Message: "Select an AI model to deploy",
+			},
+			Quota: &azdext.QuotaCheckOptions{
+				MinRemainingCapacity: 1,
+			},
+		})
+		if err != nil {
+			return nil, "", fmt.Errorf("selecting model: %w", err)
+		}
+		model = modelResp.Model.Name
+	}
+
+	if scope.Location == "" {
+		locationResp, err := azdClient.Prompt().PromptAiModelLocationWithQuota(
+			ctx, &azdext.PromptAiModelLocationWithQuotaRequest{
+				AzureContext: azureContext,
+				ModelName:    model,
+				Quota: &azdext.QuotaCheckOptions{
+					MinRemainingCapacity: 1,
+				},
+				SelectOptions: &azdext.SelectOptions{
+					Message: fmt.Sprintf("Select a location for %s", model),
+				},
+			})
+		if err != nil {
+			return nil, "", fmt.Errorf("selecting location: %w", err)
+		}
+		scope.Location = locationResp.Location.Name
+	}
+
+	return scope, model, nil
 }
 
 func newAiDeploymentCommand() *cobra.Command {
-	return &cobra.Command{
+	var emit string
+	var mode string
+	var scopeFlags aiScopeFlags
+	var deploymentFlags aiDeploymentFlags
+
+	cmd := &cobra.Command{
 		Use:   "deployment",
 		Short: "Select model/version/SKU/capacity and resolve a valid deployment configuration.",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if emit != "" && emit != "bicep" {
+				return fmt.Errorf("unsupported --emit value %q, supported values: bicep", emit)
+			}
+			if mode != aiSelectionModeLocationFirst && mode != aiSelectionModeModelFirst {
+				return fmt.Errorf("unsupported --mode value %q, supported values: %s, %s",
+					mode, aiSelectionModeLocationFirst, aiSelectionModeModelFirst)
+			}
+			if deploymentFlags.capacity < 0 {
+				return fmt.Errorf("--capacity must be greater than 0, got %d", deploymentFlags.capacity)
+			}
+
 			ctx := azdext.WithAccessToken(cmd.Context())
 			azdClient, err := azdext.NewAzdClient()
 			if err != nil {
@@ -242,60 +668,74 @@ func newAiDeploymentCommand() *cobra.Command {
 				return fmt.Errorf("failed waiting for debugger: %w", err)
 			}
 
-			subId, err := promptSubscription(ctx, azdClient)
-			if err != nil {
-				return err
+			selectTarget := selectAiDeploymentLocationFirst
+			if mode == aiSelectionModeModelFirst {
+				selectTarget = selectAiDeploymentModelFirst
 			}
 
-			location, err := promptLocation(ctx, azdClient, subId)
+			scope, modelName, err := selectTarget(ctx, azdClient, &scopeFlags, deploymentFlags.model)
 			if err != nil {
 				return err
 			}
+			location := scope.Location
+			azureContext := &azdext.AzureContext{Scope: scope}
 
-			azureContext := &azdext.AzureContext{
-				Scope: &azdext.AzureScope{
-					SubscriptionId: subId,
-					Location:       location,
-				},
-			}
-
-			// Use PromptAiModel to let user select a model (scoped to chosen location)
-			color.Cyan("Loading models for %s...", location)
-			modelResp, err := azdClient.Prompt().PromptAiModel(ctx, &azdext.PromptAiModelRequest{
-				AzureContext: azureContext,
-				Filter: &azdext.AiModelFilterOptions{
-					Locations: []string{location},
-				},
-				SelectOptions: &azdext.SelectOptions{
-					Message: "Select an AI model to deploy",
-				},
-				Quota: &azdext.QuotaCheckOptions{
-					MinRemainingCapacity: 1,
-				},
-			})
-			if err != nil {
-				return fmt.Errorf("selecting model: %w", err)
-			}
-
-			modelName := modelResp.Model.Name
 			color.Cyan("\nResolving deployment for %s...", modelName)
 
-			deployResp, err := azdClient.Prompt().PromptAiDeployment(ctx, &azdext.PromptAiDeploymentRequest{
-				AzureContext: azureContext,
-				ModelName:    modelName,
-				Options: &azdext.AiModelDeploymentOptions{
-					Locations: []string{location},
-					// Skus:      []string{"GlobalStandard", "Standard"},
-				},
-				Quota: &azdext.QuotaCheckOptions{
-					MinRemainingCapacity: 1,
-				},
-			})
-			if err != nil {
-				return fmt.Errorf("resolving deployment: %w", err)
+			var d *azdext.AiModelDeployment
+			switch {
+			case deploymentFlags.complete():
+				// Every choice was supplied: resolve the deployment directly instead of prompting, once the
+				// capacity is known to be within the SKU's bounds.
+				if err := checkFlagCapacity(ctx, azdClient, scope, modelName, &deploymentFlags); err != nil {
+					return err
+				}
+				resolveResp, err := azdClient.Ai().ResolveModelDeployments(ctx, &azdext.ResolveModelDeploymentsRequest{
+					AzureContext: azureContext,
+					ModelName:    modelName,
+					Options:      deploymentFlags.options(location),
+					Quota: &azdext.QuotaCheckOptions{
+						MinRemainingCapacity: float64(deploymentFlags.capacity),
+					},
+				})
+				if err != nil {
+					if hint := aiModelErrorHint(modelName, err); hint != "" {
+						return fmt.Errorf("%s: %w", hint, err)
+					}
+					return fmt.Errorf("resolving deployment: %w", err)
+				}
+				if len(resolveResp.Deployments) == 0 {
+					return deploymentMisfitError(ctx, azdClient, azureContext, modelName, &deploymentFlags, location)
+				}
+				d = resolveResp.Deployments[0]
+			case deploymentFlags.version != "" && deploymentFlags.capacity == 0:
+				// The version is known, so the SKU and its capacity are selected together.
+				d, err = promptAiSkuForVersion(ctx, azdClient, azureContext, modelName, &deploymentFlags)
+				if err != nil {
+					if hint := aiModelErrorHint(modelName, err); hint != "" {
+						return fmt.Errorf("%s: %w", hint, err)
+					}
+					return fmt.Errorf("resolving deployment: %w", err)
+				}
+			default:
+				deployResp, err := azdClient.Prompt().PromptAiDeployment(ctx, &azdext.PromptAiDeploymentRequest{
+					AzureContext:    azureContext,
+					ModelName:       modelName,
+					Options:         deploymentFlags.options(location),
+					DesiredCapacity: deploymentFlags.capacity,
+					Quota: &azdext.QuotaCheckOptions{
+						MinRemainingCapacity: 1,
+					},
+				})
+				if err != nil {
+					if hint := aiModelErrorHint(modelName, err); hint != "" {
+						return fmt.Errorf("%s: %w", hint, err)
+					}
+					return fmt.Errorf("resolving deployment: %w", err)
+				}
+				d = deployResp.Deployment
 			}
 
-			d := deployResp.Deployment
 			fmt.Println()
 			color.HiWhite("Deployment Configuration:\n")
 			fmt.Printf("  Model:      %s\n", color.CyanString(d.ModelName))
@@ -309,7 +749,173 @@ func newAiDeploymentCommand() *cobra.Command {
 				fmt.Printf("  Remaining:  %.0f\n", *d.RemainingQuota)
 			}
 
+			if emit == "bicep" {
+				fmt.Println()
+				color.HiWhite("Bicep deployments entry:\n")
+				fmt.Print(ai.DeploymentBicepParam(ai.AiModelDeployment{
+					ModelName: d.ModelName,
+					Format:    d.Format,
+					Version:   d.Version,
+					Location:  d.Location,
+					Sku:       ai.AiModelSku{Name: d.Sku.Name, UsageName: d.Sku.UsageName},
+					Capacity:  d.Capacity,
+				}))
+			}
+
 			return nil
 		},
 	}
+
+	scopeFlags.bind(cmd)
+	cmd.Flags().StringVar(&deploymentFlags.model, "model", "", "Model to deploy, e.g. gpt-4o (prompted for when not set)")
+	cmd.Flags().StringVar(&deploymentFlags.version, "version", "", "Model version (prompted for when not set)")
+	cmd.Flags().StringVar(&deploymentFlags.sku, "sku", "", "Deployment SKU, e.g. GlobalStandard (prompted for when not set)")
+	cmd.Flags().Int32Var(&deploymentFlags.capacity, "capacity", 0, "Deployment capacity (prompted for when not set)")
+	cmd.Flags().StringVar(&emit, "emit", "", "Also print the resolved deployment in another format (bicep)")
+	cmd.Flags().StringVar(&mode, "mode", aiSelectionModeLocationFirst,
+		"Selection order: location-first (location, then model) or model-first (model, then location)")
+
+	return cmd
+}
+
+// promptAiSkuForVersion selects the SKU and capacity of flags.version of modelName at the scope location with
+// PromptAiSku, offering only flags.sku when it is set.
+func promptAiSkuForVersion(
+	ctx context.Context,
+	azdClient *azdext.AzdClient,
+	azureContext *azdext.AzureContext,
+	modelName string,
+	flags *aiDeploymentFlags,
+) (*azdext.AiModelDeployment, error) {
+	location := azureContext.Scope.Location
+	model, err := findAiModel(ctx, azdClient, azureContext.Scope, modelName)
+	if err != nil {
+		return nil, err
+	}
+
+	format, skus := aiVersionSkus(model, flags.version, flags.sku)
+	if len(skus) == 0 {
+		if flags.sku != "" {
+			return nil, fmt.Errorf("SKU %q is not available for %s %s in %s", flags.sku, modelName, flags.version, location)
+		}
+		return nil, fmt.Errorf("version %q of %s is not available in %s", flags.version, modelName, location)
+	}
+
+	skuResp, err := azdClient.Prompt().PromptAiSku(ctx, &azdext.PromptAiSkuRequest{
+		AzureContext: azureContext,
+		ModelName:    modelName,
+		Version:      flags.version,
+		Skus:         skus,
+		PreferredSku: flags.sku,
+		Quota: &azdext.QuotaCheckOptions{
+			MinRemainingCapacity: 1,
+		},
+		Location: location,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &azdext.AiModelDeployment{
+		ModelName:      modelName,
+		Format:         format,
+		Version:        flags.version,
+		Location:       location,
+		Sku:            skuResp.Sku,
+		Capacity:       skuResp.Capacity,
+		RemainingQuota: skuResp.RemainingQuota,
+	}, nil
+}
+
+// aiVersionSkus returns the format and the SKUs of version of model, leaving out fine-tune SKUs and, when sku is set,
+// SKUs with another name. Only the first format offering version is considered.
+func aiVersionSkus(model *azdext.AiModel, version string, sku string) (string, []*azdext.AiModelSku) {
+	idx := slices.IndexFunc(model.Versions, func(v *azdext.AiModelVersion) bool {
+		return v.Version == version
+	})
+	if idx < 0 {
+		return "", nil
+	}
+
+	modelVersion := model.Versions[idx]
+	skus := slices.DeleteFunc(slices.Clone(modelVersion.Skus), func(s *azdext.AiModelSku) bool {
+		return ai.IsFinetuneUsageName(s.UsageName) || (sku != "" && !strings.EqualFold(s.Name, sku))
+	})
+
+	return cmp.Or(modelVersion.Format, model.Format), skus
+}
+
+// checkFlagCapacity fails when flags.capacity is outside the capacity bounds of flags.sku for flags.version of
+// modelName at the scope location, so a capacity Azure would reject is reported before resolving. It passes when the
+// SKU is not offered, leaving that to the resolution.
+func checkFlagCapacity(
+	ctx context.Context,
+	azdClient *azdext.AzdClient,
+	scope *azdext.AzureScope,
+	modelName string,
+	flags *aiDeploymentFlags,
+) error {
+	model, err := findAiModel(ctx, azdClient, scope, modelName)
+	if err != nil {
+		return err
+	}
+
+	_, skus := aiVersionSkus(model, flags.version, flags.sku)
+	if len(skus) == 0 {
+		return nil
+	}
+	if err := skuCapacityError(skus[0], flags.capacity); err != nil {
+		return fmt.Errorf("--capacity %d is not valid for %s %s (%s): %w",
+			flags.capacity, modelName, flags.version, skus[0].Name, err)
+	}
+
+	return nil
+}
+
+// skuCapacityError returns an error when capacity is outside the min, max and step constraints of sku, naming the
+// maximum when capacity exceeds it, e.g. "max 300 for this SKU", or nil when capacity is valid.
+func skuCapacityError(sku *azdext.AiModelSku, capacity int32) error {
+	if sku.MaxCapacity > 0 && capacity > sku.MaxCapacity {
+		return fmt.Errorf("capacity exceeds the SKU maximum (max %d for this SKU)", sku.MaxCapacity)
+	}
+
+	return ai.ValidateCapacity(ai.AiModelSku{
+		MinCapacity:  sku.MinCapacity,
+		MaxCapacity:  sku.MaxCapacity,
+		CapacityStep: sku.CapacityStep,
+	}, capacity)
+}
+
+// deploymentMisfitError explains why a fully specified deployment did not resolve, using CheckDeploymentQuota to tell
+// a capacity the SKU does not allow apart from one that exceeds the remaining quota.
+func deploymentMisfitError(
+	ctx context.Context,
+	azdClient *azdext.AzdClient,
+	azureContext *azdext.AzureContext,
+	modelName string,
+	flags *aiDeploymentFlags,
+	location string,
+) error {
+	deployment := fmt.Sprintf("%s %s (%s)", modelName, flags.version, flags.sku)
+
+	check, err := azdClient.Ai().CheckDeploymentQuota(ctx, &azdext.CheckDeploymentQuotaRequest{
+		AzureContext: azureContext,
+		ModelName:    modelName,
+		Version:      flags.version,
+		SkuName:      flags.sku,
+		Location:     location,
+		Capacity:     flags.capacity,
+	})
+	switch {
+	case err != nil:
+		return fmt.Errorf("no deployment of %s with capacity %d fits in %s: %w", deployment, flags.capacity, location, err)
+	case !check.CapacityValid:
+		return fmt.Errorf("capacity %d is not valid for %s; check the SKU minimum, maximum and step with "+
+			"'azd demo ai models --model %s'", check.Capacity, deployment, modelName)
+	case check.RemainingQuota != nil && !check.Fits:
+		return fmt.Errorf("capacity %d of %s exceeds the %.0f units of quota remaining in %s",
+			check.Capacity, deployment, *check.RemainingQuota, location)
+	default:
+		return fmt.Errorf("no deployment of %s with capacity %d fits in %s", deployment, flags.capacity, location)
+	}
 }
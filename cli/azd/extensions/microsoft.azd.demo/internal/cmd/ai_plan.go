@@ -0,0 +1,273 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/ai"
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
+)
+
+// aiDeploymentPlan is the deployment configuration resolved for a set of models at a single location.
+type aiDeploymentPlan struct {
+	Location    string                `json:"location"`
+	Deployments []aiPlannedDeployment `json:"deployments"`
+	// Unresolved are the models without a deployable configuration at the location.
+	Unresolved []aiUnresolvedModel `json:"unresolved,omitempty"`
+}
+
+// aiPlannedDeployment is the deployment chosen for one model of an aiDeploymentPlan.
+type aiPlannedDeployment struct {
+	Model          string   `json:"model"`
+	Format         string   `json:"format"`
+	Version        string   `json:"version"`
+	Sku            string   `json:"sku"`
+	UsageName      string   `json:"usageName"`
+	Capacity       int32    `json:"capacity"`
+	RemainingQuota *float64 `json:"remainingQuota,omitempty"`
+}
+
+// aiUnresolvedModel is a model of an aiDeploymentPlan that could not be resolved, and why.
+type aiUnresolvedModel struct {
+	Model  string `json:"model"`
+	Reason string `json:"reason"`
+}
+
+// aiDeploymentResolver resolves the deployment of a single model, returning nil when none fits.
+type aiDeploymentResolver func(ctx context.Context, model string) (*azdext.AiModelDeployment, error)
+
+func newAiPlanCommand() *cobra.Command {
+	var format string
+	var models []string
+	var capacity int32
+	var scopeFlags aiScopeFlags
+
+	cmd := &cobra.Command{
+		Use:   "plan",
+		Short: "Resolve deployment configurations for several models at once and print a combined plan.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !slices.Contains([]string{"table", "json", "bicep"}, format) {
+				return fmt.Errorf("unsupported --format value %q, supported values: table, json, bicep", format)
+			}
+			if len(models) == 0 {
+				return errors.New("at least one --model is required")
+			}
+			if capacity < 0 {
+				return fmt.Errorf("--capacity must be greater than 0, got %d", capacity)
+			}
+
+			ctx := azdext.WithAccessToken(cmd.Context())
+			azdClient, err := azdext.NewAzdClient()
+			if err != nil {
+				return fmt.Errorf("failed to create azd client: %w", err)
+			}
+			defer azdClient.Close()
+
+			if err := azdext.WaitForDebugger(ctx, azdClient); err != nil {
+				if errors.Is(err, context.Canceled) || errors.Is(err, azdext.ErrDebuggerAborted) {
+					return nil
+				}
+				return fmt.Errorf("failed waiting for debugger: %w", err)
+			}
+
+			scope, err := scopeFlags.resolve(ctx, azdClient, true)
+			if err != nil {
+				return err
+			}
+
+			if format == "table" {
+				color.Cyan("Resolving %d model(s) in %s...", len(models), scope.Location)
+			}
+
+			plan := planAiDeployments(ctx, scope.Location, models, func(
+				ctx context.Context, model string,
+			) (*azdext.AiModelDeployment, error) {
+				return resolvePlannedDeployment(ctx, azdClient, scope, model, capacity)
+			})
+
+			switch format {
+			case "json":
+				data, err := json.MarshalIndent(plan, "", "  ")
+				if err != nil {
+					return fmt.Errorf("formatting plan: %w", err)
+				}
+				fmt.Println(string(data))
+			case "bicep":
+				fmt.Print(aiDeploymentPlanBicep(plan))
+			default:
+				fmt.Println()
+				printAiDeploymentPlan(plan)
+			}
+
+			return nil
+		},
+	}
+
+	scopeFlags.bind(cmd)
+	cmd.Flags().StringArrayVar(&models, "model", nil, "Model to include in the plan, e.g. gpt-4o (repeatable)")
+	cmd.Flags().Int32Var(&capacity, "capacity", 0, "Deployment capacity for every model (SKU default when not set)")
+	cmd.Flags().StringVar(&format, "format", "table", "Output format (table, json, bicep)")
+
+	return cmd
+}
+
+// resolvePlannedDeployment resolves the first deployment of model at the scope location that has enough quota,
+// preferring generally available versions.
+func resolvePlannedDeployment(
+	ctx context.Context,
+	azdClient *azdext.AzdClient,
+	scope *azdext.AzureScope,
+	model string,
+	capacity int32,
+) (*azdext.AiModelDeployment, error) {
+	options := &azdext.AiModelDeploymentOptions{
+		Locations:    []string{scope.Location},
+		PreferStable: true,
+	}
+	minRemaining := float64(1)
+	if capacity > 0 {
+		options.Capacity = &capacity
+		minRemaining = float64(capacity)
+	}
+
+	resp, err := azdClient.Ai().ResolveModelDeployments(ctx, &azdext.ResolveModelDeploymentsRequest{
+		AzureContext: &azdext.AzureContext{Scope: scope},
+		ModelName:    model,
+		Options:      options,
+		Quota:        &azdext.QuotaCheckOptions{MinRemainingCapacity: minRemaining},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Deployments) == 0 {
+		return nil, nil
+	}
+
+	return resp.Deployments[0], nil
+}
+
+// planAiDeployments resolves each distinct model in order and collects the results into a plan. Models that fail
+// to resolve are reported as unresolved rather than failing the whole plan.
+func planAiDeployments(
+	ctx context.Context, location string, models []string, resolve aiDeploymentResolver,
+) *aiDeploymentPlan {
+	plan := &aiDeploymentPlan{
+		Location:    location,
+		Deployments: []aiPlannedDeployment{},
+	}
+
+	seen := map[string]bool{}
+	for _, model := range models {
+		if seen[model] {
+			continue
+		}
+		seen[model] = true
+
+		d, err := resolve(ctx, model)
+		switch {
+		case err != nil:
+			plan.Unresolved = append(plan.Unresolved, aiUnresolvedModel{
+				Model:  model,
+				Reason: cmp.Or(aiModelErrorHint(model, err), status.Convert(err).Message()),
+			})
+		case d == nil:
+			plan.Unresolved = append(plan.Unresolved, aiUnresolvedModel{
+				Model:  model,
+				Reason: fmt.Sprintf("no deployment with enough quota in %s", location),
+			})
+		default:
+			planned := aiPlannedDeployment{
+				Model:          d.ModelName,
+				Format:         d.Format,
+				Version:        d.Version,
+				Capacity:       d.Capacity,
+				RemainingQuota: d.RemainingQuota,
+			}
+			if d.Sku != nil {
+				planned.Sku = d.Sku.Name
+				planned.UsageName = d.Sku.UsageName
+			}
+			plan.Deployments = append(plan.Deployments, planned)
+		}
+	}
+
+	return plan
+}
+
+// aiModelErrorHint explains an error about model itself, telling a model missing from the catalog apart from one
+// that is listed but can no longer be deployed. A missing model names the closest catalog model when azd suggests
+// one. It returns "" for any other error.
+func aiModelErrorHint(model string, err error) string {
+	st := status.Convert(err)
+	switch azdext.AiErrorReason(st) {
+	case azdext.AiErrorReasonModelNotFound:
+		if suggestion := azdext.AiErrorMetadata(st)["suggested_model_name"]; suggestion != "" {
+			return fmt.Sprintf("%s is not in the model catalog; did you mean %s?", model, suggestion)
+		}
+		return fmt.Sprintf("%s is not in the model catalog; check the model name", model)
+	case azdext.AiErrorReasonModelNotDeployable:
+		return fmt.Sprintf("%s has no deployable version; its versions are retired or deprecated", model)
+	default:
+		return ""
+	}
+}
+
+// aiDeploymentPlanBicep formats the plan as the `deployments` parameter of the AI project bicep module. Unresolved
+// models are listed as comments.
+func aiDeploymentPlanBicep(plan *aiDeploymentPlan) string {
+	var sb strings.Builder
+	sb.WriteString("deployments: [\n")
+	for _, d := range plan.Deployments {
+		entry := ai.DeploymentBicepParam(ai.AiModelDeployment{
+			ModelName: d.Model,
+			Format:    d.Format,
+			Version:   d.Version,
+			Sku:       ai.AiModelSku{Name: d.Sku, UsageName: d.UsageName},
+			Capacity:  d.Capacity,
+		})
+		for line := range strings.Lines(entry) {
+			sb.WriteString("  " + line)
+		}
+	}
+	sb.WriteString("]\n")
+
+	for _, u := range plan.Unresolved {
+		fmt.Fprintf(&sb, "// %s was not resolved in %s: %s\n", u.Model, plan.Location, u.Reason)
+	}
+
+	return sb.String()
+}
+
+func printAiDeploymentPlan(plan *aiDeploymentPlan) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "MODEL\tVERSION\tSKU\tCAPACITY\tREMAINING")
+	for _, d := range plan.Deployments {
+		remaining := "-"
+		if d.RemainingQuota != nil {
+			remaining = fmt.Sprintf("%.0f", *d.RemainingQuota)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n", d.Model, d.Version, d.Sku, d.Capacity, remaining)
+	}
+	w.Flush()
+
+	if len(plan.Unresolved) > 0 {
+		fmt.Println()
+		color.HiRed("Unresolved models in %s:", plan.Location)
+		for _, u := range plan.Unresolved {
+			fmt.Printf("  %s: %s\n", u.Model, u.Reason)
+		}
+	}
+}
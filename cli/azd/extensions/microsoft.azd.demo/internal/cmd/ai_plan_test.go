@@ -0,0 +1,135 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPlanAiDeployments(t *testing.T) {
+	remaining := float64(90)
+	deployments := map[string]*azdext.AiModelDeployment{
+		"gpt-4o": {
+			ModelName:      "gpt-4o",
+			Format:         "OpenAI",
+			Version:        "2024-11-20",
+			Sku:            &azdext.AiModelSku{Name: "GlobalStandard", UsageName: "OpenAI.GlobalStandard.gpt-4o"},
+			Capacity:       10,
+			RemainingQuota: &remaining,
+		},
+		"text-embedding-3-small": {
+			ModelName: "text-embedding-3-small",
+			Format:    "OpenAI",
+			Version:   "1",
+			Sku:       &azdext.AiModelSku{Name: "Standard", UsageName: "OpenAI.Standard.text-embedding-3-small"},
+			Capacity:  20,
+		},
+	}
+
+	var resolved []string
+	resolve := func(ctx context.Context, model string) (*azdext.AiModelDeployment, error) {
+		resolved = append(resolved, model)
+		if model == "gpt-unknown" {
+			return nil, status.Error(codes.NotFound, `model "gpt-unknown" not found`)
+		}
+		return deployments[model], nil
+	}
+
+	plan := planAiDeployments(t.Context(), "eastus", []string{
+		"gpt-4o", "text-embedding-3-small", "gpt-4o", "gpt-unknown", "dall-e-3",
+	}, resolve)
+
+	require.Equal(t, []string{"gpt-4o", "text-embedding-3-small", "gpt-unknown", "dall-e-3"}, resolved)
+	require.Equal(t, &aiDeploymentPlan{
+		Location: "eastus",
+		Deployments: []aiPlannedDeployment{
+			{
+				Model:          "gpt-4o",
+				Format:         "OpenAI",
+				Version:        "2024-11-20",
+				Sku:            "GlobalStandard",
+				UsageName:      "OpenAI.GlobalStandard.gpt-4o",
+				Capacity:       10,
+				RemainingQuota: &remaining,
+			},
+			{
+				Model:     "text-embedding-3-small",
+				Format:    "OpenAI",
+				Version:   "1",
+				Sku:       "Standard",
+				UsageName: "OpenAI.Standard.text-embedding-3-small",
+				Capacity:  20,
+			},
+		},
+		Unresolved: []aiUnresolvedModel{
+			{Model: "gpt-unknown", Reason: `model "gpt-unknown" not found`},
+			{Model: "dall-e-3", Reason: "no deployment with enough quota in eastus"},
+		},
+	}, plan)
+}
+
+func TestAiDeploymentPlanBicep(t *testing.T) {
+	plan := &aiDeploymentPlan{
+		Location: "eastus",
+		Deployments: []aiPlannedDeployment{
+			{Model: "gpt-4o", Format: "OpenAI", Version: "2024-11-20", Sku: "GlobalStandard", Capacity: 10},
+		},
+		Unresolved: []aiUnresolvedModel{
+			{Model: "dall-e-3", Reason: "no deployment with enough quota in eastus"},
+		},
+	}
+
+	require.Equal(t, `deployments: [
+  {
+    name: 'gpt4oDeployment'
+    model: {
+      name: 'gpt-4o'
+      format: 'OpenAI'
+      version: '2024-11-20'
+    }
+    sku: {
+      name: 'GlobalStandard'
+      capacity: 10
+    }
+  }
+]
+// dall-e-3 was not resolved in eastus: no deployment with enough quota in eastus
+`, aiDeploymentPlanBicep(plan))
+}
+
+func TestAiModelErrorHint(t *testing.T) {
+	aiError := func(code codes.Code, reason string, metadata ...string) error {
+		info := &errdetails.ErrorInfo{
+			Reason:   reason,
+			Domain:   azdext.AiErrorDomain,
+			Metadata: map[string]string{},
+		}
+		for i := 0; i+1 < len(metadata); i += 2 {
+			info.Metadata[metadata[i]] = metadata[i+1]
+		}
+		st, err := status.New(code, "model error").WithDetails(info)
+		require.NoError(t, err)
+		return st.Err()
+	}
+
+	require.Equal(t,
+		"gpt-unknown is not in the model catalog; check the model name",
+		aiModelErrorHint("gpt-unknown", aiError(codes.NotFound, azdext.AiErrorReasonModelNotFound)))
+	require.Equal(t,
+		"gpt-4x is not in the model catalog; did you mean gpt-4o?",
+		aiModelErrorHint("gpt-4x", aiError(codes.NotFound, azdext.AiErrorReasonModelNotFound,
+			"model_name", "gpt-4x", "suggested_model_name", "gpt-4o")))
+	require.Equal(t,
+		"gpt-35-turbo has no deployable version; its versions are retired or deprecated",
+		aiModelErrorHint("gpt-35-turbo", aiError(codes.FailedPrecondition, azdext.AiErrorReasonModelNotDeployable)))
+	require.Empty(t, aiModelErrorHint("gpt-4o", aiError(codes.FailedPrecondition, azdext.AiErrorReasonNoValidSkus)))
+	require.Empty(t, aiModelErrorHint("gpt-4o", status.Error(codes.NotFound, "not found")))
+}
@@ -0,0 +1,213 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/ai"
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// aiStatusFlags are the flags of the ai status command.
+type aiStatusFlags struct {
+	subscription  string
+	resourceGroup string
+	account       string
+	strict        bool
+}
+
+func newAiStatusCommand() *cobra.Command {
+	flags := &aiStatusFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Compare the AI models deployed in the environment with the project's AI model resources.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := azdext.WithAccessToken(cmd.Context())
+			azdClient, err := azdext.NewAzdClient()
+			if err != nil {
+				return fmt.Errorf("failed to create azd client: %w", err)
+			}
+			defer azdClient.Close()
+
+			if err := azdext.WaitForDebugger(ctx, azdClient); err != nil {
+				if errors.Is(err, context.Canceled) || errors.Is(err, azdext.ErrDebuggerAborted) {
+					return nil
+				}
+				return fmt.Errorf("failed waiting for debugger: %w", err)
+			}
+
+			resourcesResp, err := azdClient.Project().GetConfigSection(ctx, &azdext.GetProjectConfigSectionRequest{
+				Path: "resources",
+			})
+			if err != nil {
+				return fmt.Errorf("reading project resources: %w", err)
+			}
+
+			var requirements []aiModelRequirement
+			if resourcesResp.Found {
+				requirements = aiModelRequirementsFromResources(resourcesResp.Section.AsMap())
+			}
+
+			scope, err := statusScope(ctx, azdClient, flags)
+			if err != nil {
+				return err
+			}
+
+			color.Cyan("Listing AI model deployments in resource group %s...", scope.ResourceGroup)
+			deploymentsResp, err := azdClient.Ai().ListAccountDeployments(ctx, &azdext.ListAccountDeploymentsRequest{
+				AzureContext: &azdext.AzureContext{Scope: scope},
+				AccountName:  flags.account,
+			})
+			if err != nil {
+				return fmt.Errorf("listing deployments: %w", err)
+			}
+
+			if len(requirements) == 0 && len(deploymentsResp.Deployments) == 0 {
+				color.Yellow("No AI model resources found in azure.yaml and no AI model deployments found in Azure.")
+				return nil
+			}
+
+			results := ai.ReconcileDeployments(
+				configuredDeployments(requirements), accountDeploymentsFromProto(deploymentsResp.Deployments))
+
+			fmt.Println()
+			printAiDeploymentDrift(results)
+
+			if ai.HasDeploymentDrift(results) && flags.strict {
+				return errors.New("the AI model deployments in Azure differ from the project's AI model resources")
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.subscription, "subscription", "",
+		"Azure subscription ID (defaults to the environment's AZURE_SUBSCRIPTION_ID, prompted for when not set)")
+	cmd.Flags().StringVar(&flags.resourceGroup, "resource-group", "",
+		"Resource group of the AI Services accounts (defaults to the environment's AZURE_RESOURCE_GROUP)")
+	cmd.Flags().StringVar(&flags.account, "account", "",
+		"Only compare the deployments of this AI Services account (defaults to every account in the resource group)")
+	cmd.Flags().BoolVar(&flags.strict, "strict", false,
+		"Exit with a non-zero code when any deployment is changed, not deployed or unmanaged")
+
+	return cmd
+}
+
+// statusScope returns the subscription and resource group to list deployments in: the flags when set, otherwise the
+// environment's AZURE_SUBSCRIPTION_ID and AZURE_RESOURCE_GROUP. A missing subscription is prompted for; a missing
+// resource group fails, since there is nothing deployed to compare with.
+func statusScope(
+	ctx context.Context, azdClient *azdext.AzdClient, flags *aiStatusFlags,
+) (*azdext.AzureScope, error) {
+	scope := &azdext.AzureScope{SubscriptionId: flags.subscription, ResourceGroup: flags.resourceGroup}
+
+	if valuesResp, err := azdClient.Environment().GetValues(ctx, &azdext.GetEnvironmentRequest{}); err == nil {
+		for _, value := range valuesResp.KeyValues {
+			switch value.Key {
+			case "AZURE_SUBSCRIPTION_ID":
+				scope.SubscriptionId = cmp.Or(scope.SubscriptionId, value.Value)
+			case "AZURE_RESOURCE_GROUP":
+				scope.ResourceGroup = cmp.Or(scope.ResourceGroup, value.Value)
+			}
+		}
+	}
+
+	if scope.ResourceGroup == "" {
+		return nil, errors.New(
+			"no resource group to compare with: provision the environment or set --resource-group")
+	}
+
+	if scope.SubscriptionId == "" {
+		subId, err := promptSubscription(ctx, azdClient)
+		if err != nil {
+			return nil, err
+		}
+		scope.SubscriptionId = subId
+	}
+
+	return scope, nil
+}
+
+// configuredDeployments converts AI model requirements to the deployments ai.ReconcileDeployments compares.
+func configuredDeployments(requirements []aiModelRequirement) []ai.AiModelDeployment {
+	deployments := make([]ai.AiModelDeployment, len(requirements))
+	for i, requirement := range requirements {
+		deployments[i] = ai.AiModelDeployment{
+			ModelName: requirement.Name,
+			Format:    requirement.Format,
+			Version:   requirement.Version,
+			Sku:       ai.AiModelSku{Name: requirement.Sku},
+			Capacity:  requirement.Capacity,
+		}
+	}
+	return deployments
+}
+
+func accountDeploymentsFromProto(deployments []*azdext.AiAccountDeployment) []ai.AiAccountDeployment {
+	result := make([]ai.AiAccountDeployment, len(deployments))
+	for i, deployment := range deployments {
+		result[i] = ai.AiAccountDeployment{
+			Name:              deployment.Name,
+			AccountName:       deployment.AccountName,
+			ProvisioningState: deployment.ProvisioningState,
+			Deployment: ai.AiModelDeployment{
+				ModelName: deployment.GetDeployment().GetModelName(),
+				Format:    deployment.GetDeployment().GetFormat(),
+				Version:   deployment.GetDeployment().GetVersion(),
+				Location:  deployment.GetDeployment().GetLocation(),
+				Sku:       ai.AiModelSku{Name: deployment.GetDeployment().GetSku().GetName()},
+				Capacity:  deployment.GetDeployment().GetCapacity(),
+			},
+		}
+	}
+	return result
+}
+
+func printAiDeploymentDrift(results []ai.DeploymentDrift) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "MODEL\tVERSION\tSKU\tCAPACITY\tDEPLOYMENT\tSTATUS\tDETAILS")
+	for _, result := range results {
+		statusColor := color.HiGreenString
+		switch result.Kind {
+		case ai.DeploymentChanged, ai.DeploymentUnmanaged:
+			statusColor = color.HiYellowString
+		case ai.DeploymentNotDeployed:
+			statusColor = color.HiRedString
+		}
+
+		// Show what is deployed when there is a deployment, otherwise what is configured.
+		model := result.Configured
+		deployment := "-"
+		if result.Deployed != nil {
+			model = &result.Deployed.Deployment
+			deployment = result.Deployed.AccountName + "/" + result.Deployed.Name
+		}
+
+		capacity := "-"
+		if model.Capacity > 0 {
+			capacity = fmt.Sprint(model.Capacity)
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			model.ModelName,
+			cmp.Or(model.Version, "(default)"),
+			cmp.Or(model.Sku.Name, "(any)"),
+			capacity,
+			deployment,
+			statusColor("%s", result.Kind),
+			strings.Join(result.Differences, ", "),
+		)
+	}
+	w.Flush()
+}
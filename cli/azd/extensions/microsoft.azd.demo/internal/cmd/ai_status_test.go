@@ -0,0 +1,51 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/ai"
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAiStatusReconciliation(t *testing.T) {
+	requirements := []aiModelRequirement{
+		{Resource: "chat", Name: "gpt-4o", Version: "2024-08-06", Format: "OpenAI"},
+		{Resource: "project", Name: "text-embedding-3-small", Sku: "Standard", Capacity: 20},
+	}
+	deployments := []*azdext.AiAccountDeployment{
+		{
+			Name:        "gpt-4o",
+			AccountName: "ai-account",
+			Deployment: &azdext.AiModelDeployment{
+				ModelName: "gpt-4o",
+				Format:    "OpenAI",
+				Version:   "2024-08-06",
+				Sku:       &azdext.AiModelSku{Name: "GlobalStandard"},
+				Capacity:  10,
+			},
+		},
+		{
+			Name:        "embeddings",
+			AccountName: "ai-account",
+			Deployment: &azdext.AiModelDeployment{
+				ModelName: "text-embedding-3-small",
+				Format:    "OpenAI",
+				Version:   "1",
+				Sku:       &azdext.AiModelSku{Name: "Standard"},
+				Capacity:  10,
+			},
+		},
+	}
+
+	results := ai.ReconcileDeployments(configuredDeployments(requirements), accountDeploymentsFromProto(deployments))
+
+	require.Len(t, results, 2)
+	require.Equal(t, ai.DeploymentInSync, results[0].Kind)
+	require.Equal(t, ai.DeploymentChanged, results[1].Kind)
+	require.Equal(t, []string{"capacity: 20 -> 10"}, results[1].Differences)
+	require.True(t, ai.HasDeploymentDrift(results))
+}
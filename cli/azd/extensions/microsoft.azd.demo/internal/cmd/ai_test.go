@@ -0,0 +1,284 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/ai"
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModelUsageNames(t *testing.T) {
+	model := &azdext.AiModel{
+		Name: "gpt-4o",
+		Versions: []*azdext.AiModelVersion{
+			{
+				Version: "2024-05-13",
+				Skus: []*azdext.AiModelSku{
+					{Name: "GlobalStandard", UsageName: "OpenAI.GlobalStandard.gpt-4o"},
+					{Name: "Standard", UsageName: "OpenAI.Standard.gpt-4o"},
+				},
+			},
+			{
+				Version: "2024-08-06",
+				Skus: []*azdext.AiModelSku{
+					{Name: "GlobalStandard", UsageName: "OpenAI.GlobalStandard.gpt-4o"},
+					{Name: "Custom"},
+				},
+			},
+		},
+	}
+
+	require.Equal(t, []string{
+		ai.AccountCountUsageName,
+		"OpenAI.GlobalStandard.gpt-4o",
+		"OpenAI.Standard.gpt-4o",
+	}, modelUsageNames(model))
+}
+
+func TestFilterUsagesByName(t *testing.T) {
+	usages := []*azdext.AiModelUsage{
+		{Name: "OpenAI.Standard.gpt-4o"},
+		{Name: ai.AccountCountUsageName},
+		{Name: "OpenAI.Standard.gpt-35-turbo"},
+		{Name: "ContentSafety.S0.Calls"},
+	}
+
+	tests := []struct {
+		name     string
+		names    []string
+		expected []string
+	}{
+		{
+			"ModelMeters",
+			[]string{ai.AccountCountUsageName, "OpenAI.Standard.gpt-4o"},
+			[]string{"OpenAI.Standard.gpt-4o", ai.AccountCountUsageName},
+		},
+		{"NoMatch", []string{"Speech.S0.Calls"}, []string{}},
+		{"NoNames", nil, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names := []string{}
+			for _, usage := range filterUsagesByName(usages, tt.names) {
+				names = append(names, usage.Name)
+			}
+			require.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestUsageMeterFamily(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"OpenAI.Standard.gpt-4o", "OpenAI"},
+		{ai.AccountCountUsageName, "OpenAI"},
+		{"AIServices.GlobalStandard.MaaS", "AIServices"},
+		{"SpeechServices.S0.Calls", "SpeechServices"},
+		{"AccountCount", otherUsageFamily},
+		{".Standard", otherUsageFamily},
+		{"OpenAI.", otherUsageFamily},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, usageMeterFamily(tt.name))
+		})
+	}
+}
+
+func TestGroupUsagesByFamily(t *testing.T) {
+	usages := []*azdext.AiModelUsage{
+		{Name: "OpenAI.Standard.gpt-4o", CurrentValue: 10, Limit: 100},
+		{Name: "AccountCount", CurrentValue: 1, Limit: 30},
+		{Name: "SpeechServices.S0.Calls", CurrentValue: 0, Limit: 50},
+		{Name: "OpenAI.GlobalStandard.gpt-4o", CurrentValue: 20, Limit: 200},
+		{Name: "AIServices.GlobalStandard.MaaS", CurrentValue: 5, Limit: 10},
+	}
+
+	type group struct {
+		Family       string
+		Names        []string
+		CurrentValue float64
+		Limit        float64
+	}
+	var groups []group
+	for _, g := range groupUsagesByFamily(usages) {
+		names := []string{}
+		for _, usage := range g.Usages {
+			names = append(names, usage.Name)
+		}
+		groups = append(groups, group{g.Family, names, g.CurrentValue, g.Limit})
+	}
+
+	require.Equal(t, []group{
+		{"AIServices", []string{"AIServices.GlobalStandard.MaaS"}, 5, 10},
+		{"OpenAI", []string{"OpenAI.Standard.gpt-4o", "OpenAI.GlobalStandard.gpt-4o"}, 30, 300},
+		{"SpeechServices", []string{"SpeechServices.S0.Calls"}, 0, 50},
+		{otherUsageFamily, []string{"AccountCount"}, 1, 30},
+	}, groups)
+
+	require.Empty(t, groupUsagesByFamily(nil))
+}
+
+func TestAiDeploymentFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		flags    aiDeploymentFlags
+		complete bool
+		expected *azdext.AiModelDeploymentOptions
+	}{
+		{
+			name:     "NoFlags",
+			expected: &azdext.AiModelDeploymentOptions{Locations: []string{"eastus"}},
+		},
+		{
+			name:  "Partial",
+			flags: aiDeploymentFlags{model: "gpt-4o", sku: "GlobalStandard"},
+			expected: &azdext.AiModelDeploymentOptions{
+				Locations: []string{"eastus"},
+				Skus:      []string{"GlobalStandard"},
+			},
+		},
+		{
+			name:     "Complete",
+			flags:    aiDeploymentFlags{model: "gpt-4o", version: "2024-08-06", sku: "GlobalStandard", capacity: 10},
+			complete: true,
+			expected: &azdext.AiModelDeploymentOptions{
+				Locations: []string{"eastus"},
+				Versions:  []string{"2024-08-06"},
+				Skus:      []string{"GlobalStandard"},
+				Capacity:  new(int32(10)),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.complete, tt.flags.complete())
+			require.Equal(t, tt.expected, tt.flags.options("eastus"))
+		})
+	}
+}
+
+func TestAiVersionSkus(t *testing.T) {
+	model := &azdext.AiModel{
+		Name:   "gpt-4o",
+		Format: "OpenAI",
+		Versions: []*azdext.AiModelVersion{
+			{
+				Version: "2024-08-06",
+				Skus: []*azdext.AiModelSku{
+					{Name: "GlobalStandard", UsageName: "OpenAI.GlobalStandard.gpt-4o"},
+					{Name: "Standard", UsageName: "OpenAI.Standard.gpt-4o"},
+					{Name: "Standard", UsageName: "OpenAI.Standard.gpt-4o-finetune"},
+				},
+			},
+			{
+				Version: "2024-11-20",
+				Format:  "AzureOpenAI",
+				Skus:    []*azdext.AiModelSku{{Name: "GlobalStandard", UsageName: "OpenAI.GlobalStandard.gpt-4o"}},
+			},
+		},
+	}
+
+	skuUsageNames := func(skus []*azdext.AiModelSku) []string {
+		names := []string{}
+		for _, sku := range skus {
+			names = append(names, sku.UsageName)
+		}
+		return names
+	}
+
+	tests := []struct {
+		name           string
+		version        string
+		sku            string
+		expectedFormat string
+		expected       []string
+	}{
+		{
+			name:           "AllSkus",
+			version:        "2024-08-06",
+			expectedFormat: "OpenAI",
+			expected:       []string{"OpenAI.GlobalStandard.gpt-4o", "OpenAI.Standard.gpt-4o"},
+		},
+		{
+			name:           "SkuFlag",
+			version:        "2024-08-06",
+			sku:            "standard",
+			expectedFormat: "OpenAI",
+			expected:       []string{"OpenAI.Standard.gpt-4o"},
+		},
+		{
+			name:           "VersionFormat",
+			version:        "2024-11-20",
+			expectedFormat: "AzureOpenAI",
+			expected:       []string{"OpenAI.GlobalStandard.gpt-4o"},
+		},
+		{
+			name:     "UnknownVersion",
+			version:  "2023-01-01",
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, skus := aiVersionSkus(model, tt.version, tt.sku)
+			require.Equal(t, tt.expectedFormat, format)
+			require.Equal(t, tt.expected, skuUsageNames(skus))
+		})
+	}
+
+	require.Len(t, model.Versions[0].Skus, 3)
+}
+
+func TestSkuCapacityError(t *testing.T) {
+	sku := &azdext.AiModelSku{Name: "GlobalStandard", MinCapacity: 10, MaxCapacity: 300, CapacityStep: 10}
+
+	tests := []struct {
+		name     string
+		capacity int32
+		expected string
+	}{
+		{name: "WithinBounds", capacity: 50},
+		{name: "AtMaximum", capacity: 300},
+		{name: "AboveMaximum", capacity: 310, expected: "capacity exceeds the SKU maximum (max 300 for this SKU)"},
+		{name: "BelowMinimum", capacity: 5, expected: "capacity must be between 10 and 300 in steps of 10"},
+		{name: "OffStep", capacity: 15, expected: "capacity must be between 10 and 300 in steps of 10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := skuCapacityError(sku, tt.capacity)
+			if tt.expected == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.expected)
+		})
+	}
+
+	require.NoError(t, skuCapacityError(&azdext.AiModelSku{Name: "Standard"}, 1000), "unbounded SKU")
+}
+
+func TestAiDeploymentCommand_Mode(t *testing.T) {
+	cmd := newAiDeploymentCommand()
+
+	mode := cmd.Flags().Lookup("mode")
+	require.NotNil(t, mode)
+	require.Equal(t, aiSelectionModeLocationFirst, mode.DefValue)
+
+	cmd.SetArgs([]string{"--mode", "region-first"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	err := cmd.ExecuteContext(t.Context())
+	require.ErrorContains(t, err, `unsupported --mode value "region-first"`)
+}
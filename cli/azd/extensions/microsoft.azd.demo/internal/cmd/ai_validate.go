@@ -0,0 +1,391 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// aiModelStatus is the availability of a configured AI model deployment at the target location.
+type aiModelStatus string
+
+const (
+	// aiModelAvailable means the model version and SKU are offered and have enough remaining quota.
+	aiModelAvailable aiModelStatus = "Available"
+	// aiModelDeprecated means the model version is deprecated but can still be deployed.
+	aiModelDeprecated aiModelStatus = "Deprecated"
+	// aiModelRemoved means the model, version, format or SKU is no longer offered at the location.
+	aiModelRemoved aiModelStatus = "Removed"
+	// aiModelNoQuota means the model is offered, but no matching SKU has enough remaining quota.
+	aiModelNoQuota aiModelStatus = "NoQuota"
+)
+
+// unavailable reports whether a deployment with this status would fail.
+func (s aiModelStatus) unavailable() bool {
+	return s == aiModelRemoved || s == aiModelNoQuota
+}
+
+// aiModelRequirement is an AI model deployment configured in azure.yaml.
+type aiModelRequirement struct {
+	// Resource is the azure.yaml resource that configures the model.
+	Resource string
+	Name     string
+	Version  string
+	Format   string
+	Sku      string
+	Capacity int32
+}
+
+// aiModelValidation is the outcome of validating an aiModelRequirement against the live catalog.
+type aiModelValidation struct {
+	Requirement aiModelRequirement
+	Status      aiModelStatus
+	Detail      string
+}
+
+// aiModelCatalog is the live catalog and quota data for a single location.
+type aiModelCatalog struct {
+	Location string
+	// Models are the models offered for new deployments.
+	Models []*azdext.AiModel
+	// DeprecatedModels are the models with deprecated versions, which are excluded from Models.
+	DeprecatedModels []*azdext.AiModel
+	// Usages are the subscription usages at the location. Quota is not checked when empty.
+	Usages []*azdext.AiModelUsage
+}
+
+func newAiValidateCommand() *cobra.Command {
+	var strict bool
+
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check the project's AI models against live catalog availability and quota.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := azdext.WithAccessToken(cmd.Context())
+			azdClient, err := azdext.NewAzdClient()
+			if err != nil {
+				return fmt.Errorf("failed to create azd client: %w", err)
+			}
+			defer azdClient.Close()
+
+			if err := azdext.WaitForDebugger(ctx, azdClient); err != nil {
+				if errors.Is(err, context.Canceled) || errors.Is(err, azdext.ErrDebuggerAborted) {
+					return nil
+				}
+				return fmt.Errorf("failed waiting for debugger: %w", err)
+			}
+
+			resourcesResp, err := azdClient.Project().GetConfigSection(ctx, &azdext.GetProjectConfigSectionRequest{
+				Path: "resources",
+			})
+			if err != nil {
+				return fmt.Errorf("reading project resources: %w", err)
+			}
+
+			var requirements []aiModelRequirement
+			if resourcesResp.Found {
+				requirements = aiModelRequirementsFromResources(resourcesResp.Section.AsMap())
+			}
+			if len(requirements) == 0 {
+				color.Yellow("No AI model resources found in azure.yaml.")
+				return nil
+			}
+
+			scope, err := validationScope(ctx, azdClient)
+			if err != nil {
+				return err
+			}
+
+			color.Cyan("Checking %d AI model(s) in %s...", len(requirements), scope.Location)
+			catalog, err := loadAiModelCatalog(ctx, azdClient, scope)
+			if err != nil {
+				return err
+			}
+
+			results := make([]aiModelValidation, len(requirements))
+			for i, requirement := range requirements {
+				results[i] = validateAiModelRequirement(requirement, catalog)
+			}
+
+			fmt.Println()
+			printAiModelValidations(results)
+
+			unavailable := 0
+			for _, result := range results {
+				if result.Status.unavailable() {
+					unavailable++
+				}
+			}
+			if unavailable > 0 && strict {
+				return fmt.Errorf("%d AI model resource(s) cannot be deployed in %s", unavailable, scope.Location)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&strict, "strict", false,
+		"Exit with a non-zero code when any AI model resource is removed or out of quota")
+
+	return cmd
+}
+
+// validationScope returns the subscription and location of the current environment, prompting for them when the
+// environment does not set AZURE_SUBSCRIPTION_ID and AZURE_LOCATION.
+func validationScope(ctx context.Context, azdClient *azdext.AzdClient) (*azdext.AzureScope, error) {
+	valuesResp, err := azdClient.Environment().GetValues(ctx, &azdext.GetEnvironmentRequest{})
+	if err == nil {
+		scope := &azdext.AzureScope{}
+		for _, value := range valuesResp.KeyValues {
+			switch value.Key {
+			case "AZURE_SUBSCRIPTION_ID":
+				scope.SubscriptionId = value.Value
+			case "AZURE_LOCATION":
+				scope.Location = value.Value
+			}
+		}
+
+		if scope.SubscriptionId != "" && scope.Location != "" {
+			return scope, nil
+		}
+	}
+
+	return promptScope(ctx, azdClient)
+}
+
+// loadAiModelCatalog fetches the models offered at the scope location, including deprecated versions, and the
+// subscription usages there.
+func loadAiModelCatalog(
+	ctx context.Context,
+	azdClient *azdext.AzdClient,
+	scope *azdext.AzureScope,
+) (*aiModelCatalog, error) {
+	azureContext := &azdext.AzureContext{Scope: scope}
+
+	modelsResp, err := azdClient.Ai().ListModels(ctx, &azdext.ListModelsRequest{
+		AzureContext: azureContext,
+		Filter: &azdext.AiModelFilterOptions{
+			Locations: []string{scope.Location},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing models: %w", err)
+	}
+
+	deprecatedResp, err := azdClient.Ai().ListModels(ctx, &azdext.ListModelsRequest{
+		AzureContext: azureContext,
+		Filter: &azdext.AiModelFilterOptions{
+			Locations: []string{scope.Location},
+			Statuses:  []string{"Deprecating", "Deprecated"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing deprecated models: %w", err)
+	}
+
+	usagesResp, err := azdClient.Ai().ListUsages(ctx, &azdext.ListUsagesRequest{
+		AzureContext: azureContext,
+		Location:     scope.Location,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing usages: %w", err)
+	}
+
+	return &aiModelCatalog{
+		Location:         scope.Location,
+		Models:           modelsResp.Models,
+		DeprecatedModels: deprecatedResp.Models,
+		Usages:           usagesResp.Usages,
+	}, nil
+}
+
+// aiModelRequirementsFromResources returns the AI model deployments configured by the azure.yaml resources section:
+// the model of each ai.openai.model resource and the models of each ai.project resource, ordered by resource name.
+func aiModelRequirementsFromResources(resources map[string]any) []aiModelRequirement {
+	requirements := []aiModelRequirement{}
+	for _, name := range slices.Sorted(maps.Keys(resources)) {
+		resource, ok := resources[name].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		switch resource["type"] {
+		case "ai.openai.model":
+			model, ok := resource["model"].(map[string]any)
+			if !ok {
+				continue
+			}
+			requirements = append(requirements, aiModelRequirement{
+				Resource: name,
+				Name:     stringValue(model, "name"),
+				Version:  stringValue(model, "version"),
+				Format:   cmp.Or(stringValue(model, "format"), "OpenAI"),
+			})
+		case "ai.project":
+			models, _ := resource["models"].([]any)
+			for _, entry := range models {
+				model, ok := entry.(map[string]any)
+				if !ok {
+					continue
+				}
+
+				requirement := aiModelRequirement{
+					Resource: name,
+					Name:     stringValue(model, "name"),
+					Version:  stringValue(model, "version"),
+					Format:   stringValue(model, "format"),
+				}
+				if sku, ok := model["sku"].(map[string]any); ok {
+					requirement.Sku = stringValue(sku, "name")
+					if capacity, ok := sku["capacity"].(float64); ok {
+						requirement.Capacity = int32(capacity)
+					}
+				}
+				requirements = append(requirements, requirement)
+			}
+		}
+	}
+
+	return requirements
+}
+
+func stringValue(values map[string]any, key string) string {
+	value, _ := values[key].(string)
+	return value
+}
+
+// validateAiModelRequirement checks whether requirement can be deployed with the catalog's models and usages.
+// An empty version matches the model's default version, and an empty SKU matches any SKU of the version. The
+// capacity required of a SKU is the configured capacity, or the SKU default when none is configured.
+func validateAiModelRequirement(requirement aiModelRequirement, catalog *aiModelCatalog) aiModelValidation {
+	result := aiModelValidation{Requirement: requirement}
+
+	status := aiModelAvailable
+	version := findAiModelVersion(catalog.Models, requirement)
+	if version == nil {
+		status = aiModelDeprecated
+		version = findAiModelVersion(catalog.DeprecatedModels, requirement)
+	}
+	if version == nil {
+		result.Status = aiModelRemoved
+		result.Detail = fmt.Sprintf("%s is not offered in %s", describeAiModel(requirement), catalog.Location)
+		return result
+	}
+
+	skus := slices.DeleteFunc(slices.Clone(version.Skus), func(sku *azdext.AiModelSku) bool {
+		return requirement.Sku != "" && !strings.EqualFold(sku.Name, requirement.Sku)
+	})
+	if len(skus) == 0 {
+		result.Status = aiModelRemoved
+		result.Detail = fmt.Sprintf("SKU %s is not offered for version %s in %s",
+			requirement.Sku, version.Version, catalog.Location)
+		return result
+	}
+
+	if len(catalog.Usages) > 0 {
+		remaining, hasQuota := maxRemainingForSkus(skus, requirement.Capacity, catalog.Usages)
+		if !hasQuota {
+			result.Status = aiModelNoQuota
+			result.Detail = fmt.Sprintf("%.0f quota remaining in %s", remaining, catalog.Location)
+			return result
+		}
+	}
+
+	result.Status = status
+	if status == aiModelDeprecated {
+		result.Detail = fmt.Sprintf("version %s is %s", version.Version, version.LifecycleStatus)
+	}
+	return result
+}
+
+// findAiModelVersion returns the model version matching requirement, or nil when models do not offer it.
+func findAiModelVersion(models []*azdext.AiModel, requirement aiModelRequirement) *azdext.AiModelVersion {
+	for _, model := range models {
+		if model.Name != requirement.Name {
+			continue
+		}
+
+		for _, version := range model.Versions {
+			format := cmp.Or(version.Format, model.Format)
+			if requirement.Format != "" && !strings.EqualFold(format, requirement.Format) {
+				continue
+			}
+			if (requirement.Version == "" && version.IsDefault) || version.Version == requirement.Version {
+				return version
+			}
+		}
+	}
+
+	return nil
+}
+
+// maxRemainingForSkus reports whether any of skus has enough remaining quota for capacity (the SKU default when
+// capacity is zero), along with the largest remaining quota across the SKUs. SKUs without usage data are skipped.
+func maxRemainingForSkus(skus []*azdext.AiModelSku, capacity int32, usages []*azdext.AiModelUsage) (float64, bool) {
+	var maxRemaining float64
+	for _, sku := range skus {
+		usageIdx := slices.IndexFunc(usages, func(usage *azdext.AiModelUsage) bool {
+			return usage.Name == sku.UsageName
+		})
+		if usageIdx < 0 {
+			continue
+		}
+
+		remaining := usages[usageIdx].Limit - usages[usageIdx].CurrentValue
+		maxRemaining = max(maxRemaining, remaining)
+		required := max(cmp.Or(capacity, sku.DefaultCapacity, sku.MinCapacity), 1)
+		if remaining >= float64(required) {
+			return remaining, true
+		}
+	}
+
+	return maxRemaining, false
+}
+
+func describeAiModel(requirement aiModelRequirement) string {
+	description := requirement.Name
+	if requirement.Version != "" {
+		description += " version " + requirement.Version
+	}
+	if requirement.Format != "" {
+		description += " (" + requirement.Format + ")"
+	}
+	return description
+}
+
+func printAiModelValidations(results []aiModelValidation) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RESOURCE\tMODEL\tVERSION\tSKU\tSTATUS\tDETAILS")
+	for _, result := range results {
+		statusColor := color.HiGreenString
+		switch result.Status {
+		case aiModelDeprecated:
+			statusColor = color.HiYellowString
+		case aiModelRemoved, aiModelNoQuota:
+			statusColor = color.HiRedString
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			result.Requirement.Resource,
+			result.Requirement.Name,
+			cmp.Or(result.Requirement.Version, "(default)"),
+			cmp.Or(result.Requirement.Sku, "(any)"),
+			statusColor("%s", result.Status),
+			result.Detail,
+		)
+	}
+	w.Flush()
+}
@@ -0,0 +1,148 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAiModelRequirementsFromResources(t *testing.T) {
+	resources := map[string]any{
+		"storage": map[string]any{"type": "storage"},
+		"chat": map[string]any{
+			"type":  "ai.openai.model",
+			"model": map[string]any{"name": "gpt-4o", "version": "2024-08-06"},
+		},
+		"project": map[string]any{
+			"type": "ai.project",
+			"models": []any{
+				map[string]any{
+					"name":    "gpt-4o-mini",
+					"version": "2024-07-18",
+					"format":  "OpenAI",
+					"sku":     map[string]any{"name": "GlobalStandard", "capacity": float64(20)},
+				},
+			},
+		},
+	}
+
+	require.Equal(t, []aiModelRequirement{
+		{Resource: "chat", Name: "gpt-4o", Version: "2024-08-06", Format: "OpenAI"},
+		{
+			Resource: "project",
+			Name:     "gpt-4o-mini",
+			Version:  "2024-07-18",
+			Format:   "OpenAI",
+			Sku:      "GlobalStandard",
+			Capacity: 20,
+		},
+	}, aiModelRequirementsFromResources(resources))
+}
+
+func TestValidateAiModelRequirement(t *testing.T) {
+	catalog := &aiModelCatalog{
+		Location: "eastus",
+		Models: []*azdext.AiModel{
+			{
+				Name:   "gpt-4o",
+				Format: "OpenAI",
+				Versions: []*azdext.AiModelVersion{
+					{
+						Version:   "2024-08-06",
+						IsDefault: true,
+						Skus: []*azdext.AiModelSku{
+							{Name: "GlobalStandard", UsageName: "OpenAI.GlobalStandard.gpt-4o", DefaultCapacity: 10},
+							{Name: "Standard", UsageName: "OpenAI.Standard.gpt-4o", DefaultCapacity: 10},
+						},
+					},
+				},
+			},
+		},
+		DeprecatedModels: []*azdext.AiModel{
+			{
+				Name:   "gpt-4o",
+				Format: "OpenAI",
+				Versions: []*azdext.AiModelVersion{
+					{
+						Version:         "2024-05-13",
+						LifecycleStatus: "Deprecating",
+						Skus: []*azdext.AiModelSku{
+							{Name: "GlobalStandard", UsageName: "OpenAI.GlobalStandard.gpt-4o", DefaultCapacity: 10},
+						},
+					},
+				},
+			},
+		},
+		Usages: []*azdext.AiModelUsage{
+			{Name: "OpenAI.GlobalStandard.gpt-4o", CurrentValue: 20, Limit: 50},
+			{Name: "OpenAI.Standard.gpt-4o", CurrentValue: 50, Limit: 50},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		requirement aiModelRequirement
+		expected    aiModelStatus
+	}{
+		{
+			name:        "default version",
+			requirement: aiModelRequirement{Name: "gpt-4o", Format: "OpenAI"},
+			expected:    aiModelAvailable,
+		},
+		{
+			name:        "deprecated version",
+			requirement: aiModelRequirement{Name: "gpt-4o", Version: "2024-05-13"},
+			expected:    aiModelDeprecated,
+		},
+		{
+			name:        "unknown model",
+			requirement: aiModelRequirement{Name: "gpt-35-turbo"},
+			expected:    aiModelRemoved,
+		},
+		{
+			name:        "unknown version",
+			requirement: aiModelRequirement{Name: "gpt-4o", Version: "2023-01-01"},
+			expected:    aiModelRemoved,
+		},
+		{
+			name:        "format mismatch",
+			requirement: aiModelRequirement{Name: "gpt-4o", Version: "2024-08-06", Format: "Microsoft"},
+			expected:    aiModelRemoved,
+		},
+		{
+			name:        "unknown SKU",
+			requirement: aiModelRequirement{Name: "gpt-4o", Version: "2024-08-06", Sku: "ProvisionedManaged"},
+			expected:    aiModelRemoved,
+		},
+		{
+			name:        "SKU without quota",
+			requirement: aiModelRequirement{Name: "gpt-4o", Version: "2024-08-06", Sku: "Standard"},
+			expected:    aiModelNoQuota,
+		},
+		{
+			name: "capacity exceeds quota",
+			requirement: aiModelRequirement{
+				Name: "gpt-4o", Version: "2024-08-06", Sku: "GlobalStandard", Capacity: 40,
+			},
+			expected: aiModelNoQuota,
+		},
+		{
+			name: "capacity within quota",
+			requirement: aiModelRequirement{
+				Name: "gpt-4o", Version: "2024-08-06", Sku: "GlobalStandard", Capacity: 30,
+			},
+			expected: aiModelAvailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validateAiModelRequirement(tt.requirement, catalog)
+			require.Equal(t, tt.expected, result.Status, result.Detail)
+		})
+	}
+}
@@ -70,7 +70,7 @@ func packSelfContainedBundle(
 
 	if !isExtensionPack(extensionMetadata) {
 		artifactsDir := filepath.Join(stagingDir, bundleArtifactsDir)
-		if err := packExtensionBinaries(extensionMetadata, artifactsDir); err != nil {
+		if _, err := packExtensionBinaries(extensionMetadata, artifactsDir); err != nil {
 			return fmt.Errorf("failed to package extension binaries: %w", err)
 		}
 
@@ -122,7 +122,7 @@ func packSelfContainedBundle(
 	addOrUpdateExtension(registry, extensionMetadata, artifactMap)
 
 	registryPath := filepath.Join(stagingDir, extensions.BundleRegistryFileName)
-	if err := saveRegistry(registryPath, registry); err != nil {
+	if err := saveRegistry(ctx, registryPath, registry); err != nil {
 		return fmt.Errorf("failed to write bundle registry: %w", err)
 	}
 
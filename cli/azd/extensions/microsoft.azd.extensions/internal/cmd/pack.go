@@ -5,17 +5,22 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"time"
 
 	"github.com/azure/azure-dev/cli/azd/extensions/microsoft.azd.extensions/internal"
 	"github.com/azure/azure-dev/cli/azd/extensions/microsoft.azd.extensions/internal/models"
 	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
 	"github.com/azure/azure-dev/cli/azd/pkg/common"
+	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
 	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 	"github.com/azure/azure-dev/cli/azd/pkg/ux"
@@ -23,11 +28,22 @@ import (
 )
 
 type packageFlags struct {
-	inputPath  string
-	outputPath string
-	rebuild    bool
-	bundle     bool
-	zip        bool
+	inputPath    string
+	outputPath   string
+	rebuild      bool
+	bundle       bool
+	zip          bool
+	allPlatforms bool
+	// skipVersionCheck disables probing the current platform's binary for its version before packaging.
+	skipVersionCheck bool
+	// skipValidate disables validating extension.yaml against the manifest schema before packaging.
+	skipValidate bool
+	// force clears the recorded pack state so that every task runs again.
+	force bool
+	// noRegistryUpdate disables adding the packed archives to the local registry index.
+	noRegistryUpdate bool
+	// commandsOnly builds only the current platform's binary and writes its command spec, without packaging.
+	commandsOnly bool
 }
 
 func newPackCommand(outputPath *string) *cobra.Command {
@@ -53,7 +69,9 @@ func newPackCommand(outputPath *string) *cobra.Command {
 				return err
 			}
 
-			if extensionPack {
+			if flags.commandsOnly {
+				internal.WriteCommandSuccess("Command spec written to " + commandSpecFileName)
+			} else if extensionPack {
 				internal.WriteCommandSuccess("Extension pack contains no artifacts to package")
 			} else {
 				internal.WriteCommandSuccess("Extension packaged successfully")
@@ -88,6 +106,31 @@ func newPackCommand(outputPath *string) *cobra.Command {
 			"extension artifacts, installable via 'azd extension install <bundle.zip>'.",
 	)
 
+	packageCmd.Flags().BoolVar(
+		&flags.allPlatforms,
+		"all-platforms", false,
+		"Also produce a single <id>-<version>-all.zip containing extension.yaml, commands.json when present and "+
+			"the binaries for every platform, for manual installs (e.g. air-gapped environments).",
+	)
+
+	packageCmd.Flags().BoolVar(
+		&flags.skipVersionCheck,
+		"skip-version-check", false,
+		"Skip verifying that the extension binary for the current platform reports the version in extension.yaml.",
+	)
+
+	packageCmd.Flags().BoolVar(
+		&flags.skipValidate,
+		"skip-validate", false,
+		"Skip validating extension.yaml against the extension manifest schema.",
+	)
+
+	packageCmd.Flags().BoolVar(
+		&flags.force,
+		"force", false,
+		"Clear the recorded pack state and package again even when the inputs are unchanged.",
+	)
+
 	// --zip is a hidden alias for --bundle.
 	packageCmd.Flags().BoolVar(
 		&flags.zip,
@@ -96,6 +139,19 @@ func newPackCommand(outputPath *string) *cobra.Command {
 	)
 	_ = packageCmd.Flags().MarkHidden("zip")
 
+	packageCmd.Flags().BoolVar(
+		&flags.noRegistryUpdate,
+		"no-registry-update", false,
+		"Do not add the packed archives to the local extension source registry.",
+	)
+
+	packageCmd.Flags().BoolVar(
+		&flags.commandsOnly,
+		"commands-only", false,
+		"Only build the binary for the current platform and write its command spec to "+commandSpecFileName+
+			", without packaging. Speeds up iterating on command definitions of Go extensions.",
+	)
+
 	return packageCmd
 }
 
@@ -130,9 +186,27 @@ func runPackageAction(ctx context.Context, flags *packageFlags) (bool, error) {
 
 	extensionPack := isExtensionPack(extensionMetadata)
 
+	if flags.commandsOnly {
+		if extensionPack {
+			return false, errors.New("--commands-only does not apply to extension packs, which have no binaries")
+		}
+		if flags.bundle || flags.allPlatforms {
+			return false, errors.New("--commands-only cannot be combined with --bundle or --all-platforms")
+		}
+
+		return false, runCommandSpecAction(ctx, extensionMetadata, flags)
+	}
+
+	if flags.allPlatforms && flags.bundle {
+		return false, errors.New("--all-platforms cannot be combined with --bundle")
+	}
+
 	// For self-contained bundles the output is a single .zip file rather than a
 	// directory of artifacts. Resolve the destination bundle path up front.
 	var bundleOutputPath string
+	// localRegistryOutput is set when the archives are written under the local registry artifacts path, which is
+	// when the local registry index is updated to point at them.
+	localRegistryOutput := false
 	if flags.bundle {
 		bundleOutputPath, err = resolveBundleOutputPath(flags.outputPath, extensionMetadata)
 		if err != nil {
@@ -145,6 +219,7 @@ func runPackageAction(ctx context.Context, flags *packageFlags) (bool, error) {
 		}
 
 		flags.outputPath = filepath.Join(localRegistryArtifactsPath, extensionMetadata.Id, extensionMetadata.Version)
+		localRegistryOutput = true
 	}
 
 	fmt.Println()
@@ -166,7 +241,61 @@ func runPackageAction(ctx context.Context, flags *packageFlags) (bool, error) {
 		fmt.Printf("%s: %s\n", output.WithBold("Output Path"), output.WithHyperlink(absOutputPath, absOutputPath))
 	}
 
+	// Completed tasks are recorded so that a re-run can skip them while their inputs and destination are unchanged.
+	stateDir := packStateDir(flags.outputPath, bundleOutputPath)
+	packMode := "default"
+	packTarget := flags.outputPath
+	if flags.bundle {
+		packMode = "bundle"
+		packTarget = bundleOutputPath
+	} else if flags.allPlatforms {
+		packMode = "all-platforms"
+	}
+
+	if flags.force {
+		if err := clearPackState(stateDir); err != nil {
+			return false, err
+		}
+	}
+
+	state := loadPackState(stateDir)
+	recordTask := func(task string, inputHash string, outputs []string) {
+		state.markComplete(task, inputHash, outputs)
+		if err := state.save(stateDir); err != nil {
+			log.Printf("failed to record pack state: %v", err)
+		}
+	}
+
 	taskList := ux.NewTaskList(nil).
+		AddTask(ux.TaskOptions{
+			Title: "Validating extension manifest",
+			Action: func(spf ux.SetProgressFunc) (ux.TaskState, error) {
+				if flags.skipValidate {
+					return ux.Skipped, nil
+				}
+
+				inputHash, err := validateInputHash(extensionMetadata)
+				if err != nil {
+					return ux.Error, fmt.Errorf("failed to hash extension.yaml: %w", err)
+				}
+
+				if state.isComplete(validateTaskName, inputHash) {
+					spf("extension.yaml is unchanged since it was validated")
+					return ux.Skipped, nil
+				}
+
+				if err := models.ValidateExtensionManifest(extensionMetadata.Path); err != nil {
+					return ux.Error, common.NewDetailedError(
+						"Invalid extension.yaml",
+						fmt.Errorf("%w\nFix the listed fields or pass --skip-validate", err),
+					)
+				}
+
+				recordTask(validateTaskName, inputHash, nil)
+
+				return ux.Success, nil
+			},
+		}).
 		AddTask(ux.TaskOptions{
 			Title: "Building extension",
 			Action: func(spf ux.SetProgressFunc) (ux.TaskState, error) {
@@ -175,35 +304,23 @@ func runPackageAction(ctx context.Context, flags *packageFlags) (bool, error) {
 					return ux.Skipped, nil
 				}
 
+				inputHash, err := buildInputHash(extensionMetadata, flags.inputPath)
+				if err != nil {
+					return ux.Error, common.NewDetailedError("Build failed", err)
+				}
+
+				// A build that completed with the same sources is not repeated, even with --rebuild.
+				if state.isComplete(buildTaskName, inputHash) {
+					spf("Sources are unchanged since the last build; pass --force to build again")
+					return ux.Skipped, nil
+				}
+
 				// Verify if we have any existing binaries
+				absInputPath := filepath.Join(extensionMetadata.Path, flags.inputPath)
 				if !flags.rebuild {
-					absInputPath := filepath.Join(extensionMetadata.Path, flags.inputPath)
-					entires, err := os.ReadDir(absInputPath)
-					if err == nil {
-						binaries := []string{}
-
-						for _, entry := range entires {
-							if entry.IsDir() {
-								continue
-							}
-
-							// Only process files that match the extension ID
-							artifactName := entry.Name()
-							if !strings.HasPrefix(artifactName, extensionMetadata.SafeDashId()) {
-								continue
-							}
-
-							ext := filepath.Ext(artifactName)
-							if ext != ".exe" && ext != "" {
-								continue
-							}
-
-							binaries = append(binaries, entry.Name())
-						}
-
-						if len(binaries) > 0 {
-							return ux.Skipped, nil
-						}
+					binaries, err := extensionBinaries(extensionMetadata, absInputPath)
+					if err == nil && len(binaries) > 0 {
+						return ux.Skipped, nil
 					}
 				}
 
@@ -218,12 +335,52 @@ func runPackageAction(ctx context.Context, flags *packageFlags) (bool, error) {
 					)
 				}
 
+				binaries, err := extensionBinaries(extensionMetadata, absInputPath)
+				if err != nil {
+					log.Printf("not recording the build in the pack state: %v", err)
+					return ux.Success, nil
+				}
+
+				outputs := make([]string, 0, len(binaries))
+				for _, binary := range binaries {
+					outputs = append(outputs, filepath.Join(absInputPath, binary))
+				}
+				recordTask(buildTaskName, inputHash, outputs)
+
 				return ux.Success, nil
 			},
 		}).
 		AddTask(ux.TaskOptions{
 			Title: "Packaging extension",
 			Action: func(spf ux.SetProgressFunc) (ux.TaskState, error) {
+				if extensionPack && !flags.bundle {
+					spf("Extension packs contain no artifacts; nothing to package")
+					return ux.Skipped, nil
+				}
+
+				inputHash, err := packInputHash(extensionMetadata, packMode, packTarget)
+				if err != nil {
+					return ux.Error, common.NewDetailedError(
+						"Packaging failed",
+						fmt.Errorf("failed to hash package inputs: %w", err),
+					)
+				}
+
+				if state.isComplete(packTaskName, inputHash) {
+					spf("Artifacts are up to date; pass --force to package again")
+					return ux.Skipped, nil
+				}
+
+				if !extensionPack && !flags.skipVersionCheck {
+					if err := verifyBinaryVersion(ctx, extensionMetadata); err != nil {
+						return ux.Error, common.NewDetailedError(
+							"Version check failed",
+							fmt.Errorf("%w. Rebuild the extension or pass --skip-version-check", err),
+						)
+					}
+				}
+
+				var outputs []string
 				if flags.bundle {
 					if err := packSelfContainedBundle(ctx, extensionMetadata, bundleOutputPath); err != nil {
 						return ux.Error, common.NewDetailedError(
@@ -232,21 +389,80 @@ func runPackageAction(ctx context.Context, flags *packageFlags) (bool, error) {
 						)
 					}
 
-					return ux.Success, nil
+					outputs = append(outputs, bundleOutputPath)
+				} else {
+					archives, err := packExtensionBinaries(extensionMetadata, flags.outputPath)
+					if err != nil {
+						return ux.Error, common.NewDetailedError(
+							"Packaging failed",
+							fmt.Errorf("failed to package extension: %w", err),
+						)
+					}
+
+					outputs = append(outputs, archives...)
+
+					if flags.allPlatforms {
+						archive, err := packAllPlatformsArchive(extensionMetadata, flags.outputPath)
+						if err != nil {
+							return ux.Error, common.NewDetailedError(
+								"Packaging failed",
+								fmt.Errorf("failed to create all-platforms archive: %w", err),
+							)
+						}
+
+						outputs = append(outputs, archive)
+					}
 				}
 
-				if extensionPack {
-					spf("Extension packs contain no artifacts; nothing to package")
+				recordTask(packTaskName, inputHash, outputs)
+
+				return ux.Success, nil
+			},
+		}).
+		AddTask(ux.TaskOptions{
+			Title: "Updating local extension source registry",
+			Action: func(spf ux.SetProgressFunc) (ux.TaskState, error) {
+				if flags.noRegistryUpdate || flags.bundle || extensionPack {
+					return ux.Skipped, nil
+				}
+				if !localRegistryOutput {
+					spf("Artifacts were written outside the local registry")
+					return ux.Skipped, nil
+				}
+
+				registryPath, err := localRegistryPath()
+				if err != nil {
+					return ux.Error, err
+				}
+
+				// Pack only updates the index of an existing local source; adding the source changes the user's azd
+				// config, so it is left to the user. The index itself is created by updateRegistryIndex when missing,
+				// so a failed lookup does not skip the update.
+				if has, err := internal.HasLocalRegistry(); err == nil && !has {
+					spf(fmt.Sprintf(
+						"No local extension source. Add one with 'azd extension source add -n local -t file -l %s'",
+						registryPath,
+					))
 					return ux.Skipped, nil
 				}
 
-				if err := packExtensionBinaries(extensionMetadata, flags.outputPath); err != nil {
+				packageTask := state.Tasks[packTaskName]
+				inputHash := registryInputHash(registryPath, packageTask.InputHash)
+				if state.isComplete(registryTaskName, inputHash) {
+					spf("Registry is up to date")
+					return ux.Skipped, nil
+				}
+
+				archives := platformArchives(extensionMetadata, packageTask.Outputs)
+				if err := updateRegistryIndex(ctx, registryPath, extensionMetadata, archives); err != nil {
 					return ux.Error, common.NewDetailedError(
-						"Packaging failed",
-						fmt.Errorf("failed to package extension: %w", err),
+						"Failed to update registry",
+						fmt.Errorf("%w. Pass --no-registry-update to skip updating the registry", err),
 					)
 				}
 
+				recordTask(registryTaskName, inputHash, []string{registryPath})
+
 				return ux.Success, nil
 			},
 		})
@@ -260,25 +476,85 @@ func runPackageAction(ctx context.Context, flags *packageFlags) (bool, error) {
 	return extensionPack && !flags.bundle, nil
 }
 
+// packExtensionBinaries archives extension.yaml with each extension binary into outputPath and returns the paths of
+// the created archives.
 func packExtensionBinaries(
 	extensionMetadata *models.ExtensionSchema,
 	outputPath string,
-) error {
+) ([]string, error) {
 	// Prepare artifacts for registry
 	buildPath := filepath.Join(extensionMetadata.Path, "bin")
-	entries, err := os.ReadDir(buildPath)
+	binaries, err := extensionBinaries(extensionMetadata, buildPath)
 	if err != nil {
-		return fmt.Errorf("failed to read artifacts directory: %w", err)
+		return nil, err
 	}
 
 	extensionYamlSourcePath := filepath.Join(extensionMetadata.Path, "extension.yaml")
 
 	// Ensure target directory exists
 	if err := os.MkdirAll(outputPath, osutil.PermissionDirectory); err != nil {
-		return fmt.Errorf("failed to create target directory: %w", err)
+		return nil, fmt.Errorf("failed to create target directory: %w", err)
 	}
 
+	archives := []string{}
+
 	// Map and copy artifacts
+	for _, artifactName := range binaries {
+		fileWithoutExt := internal.GetFileNameWithoutExt(artifactName)
+		artifactSourcePath := filepath.Join(buildPath, artifactName)
+		sourceFiles := []string{extensionYamlSourcePath, artifactSourcePath}
+
+		archive, err := createArchive(artifactName, fileWithoutExt, outputPath, sourceFiles)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create archive for %s: %w", artifactName, err)
+		}
+
+		archives = append(archives, archive)
+	}
+
+	return archives, nil
+}
+
+// binaryProbeTimeout bounds how long running the extension binary, to probe its version or command spec, may take.
+const binaryProbeTimeout = 30 * time.Second
+
+// commandSpecFileName is the file, in the extension directory, that --commands-only writes the command spec to.
+const commandSpecFileName = "commands.json"
+
+// verifyBinaryVersion runs the "version" command of the extension binary built for the current platform and checks
+// that it reports the version declared in extension.yaml, so that a stale binary from a previous build is not packaged
+// under a new version. Binaries for other platforms cannot be executed and are not checked.
+func verifyBinaryVersion(ctx context.Context, extensionMetadata *models.ExtensionSchema) error {
+	binaryPath, err := findCurrentPlatformBinary(extensionMetadata, filepath.Join(extensionMetadata.Path, "bin"))
+	if err != nil || binaryPath == "" {
+		return err
+	}
+
+	artifactName := filepath.Base(binaryPath)
+	versionOutput, err := probeBinaryVersion(ctx, binaryPath, extensionMetadata.Path)
+	if err != nil {
+		return fmt.Errorf("failed to run '%s version': %w", artifactName, err)
+	}
+
+	if !versionOutputMatches(versionOutput, extensionMetadata.Version) {
+		return fmt.Errorf(
+			"%s reports version %q but extension.yaml declares %s",
+			artifactName, strings.TrimSpace(versionOutput), extensionMetadata.Version,
+		)
+	}
+
+	return nil
+}
+
+// extensionBinaries returns the sorted names of the extension binaries in dir: the files whose names
+// start with the extension's dash-separated ID and that are either .exe or have no extension.
+func extensionBinaries(extensionMetadata *models.ExtensionSchema, dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read artifacts directory: %w", err)
+	}
+
+	binaries := []string{}
 	for _, entry := range entries {
 		if entry.IsDir() {
 			continue
@@ -295,19 +571,219 @@ func packExtensionBinaries(
 			continue
 		}
 
-		fileWithoutExt := internal.GetFileNameWithoutExt(artifactName)
-		artifactSourcePath := filepath.Join(buildPath, entry.Name())
-		sourceFiles := []string{extensionYamlSourcePath, artifactSourcePath}
+		binaries = append(binaries, artifactName)
+	}
 
-		_, err := createArchive(artifactName, fileWithoutExt, outputPath, sourceFiles)
-		if err != nil {
-			return fmt.Errorf("failed to create archive for %s: %w", entry.Name(), err)
+	return binaries, nil
+}
+
+// findCurrentPlatformBinary returns the path of the extension binary in buildPath built for the current platform, or
+// an empty path when there is none.
+func findCurrentPlatformBinary(extensionMetadata *models.ExtensionSchema, buildPath string) (string, error) {
+	binaries, err := extensionBinaries(extensionMetadata, buildPath)
+	if err != nil {
+		return "", err
+	}
+
+	currentOSArch := fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH)
+	for _, artifactName := range binaries {
+		osArch, err := internal.InferOSArch(artifactName)
+		if err != nil || osArch != currentOSArch {
+			continue
 		}
+
+		return filepath.Join(buildPath, artifactName), nil
+	}
+
+	return "", nil
+}
+
+// runCommandSpecAction builds the extension binary for the current platform only and writes the command spec it
+// reports to commands.json in the extension directory. It skips the multi-platform build and packaging, so that
+// command definitions can be iterated on quickly.
+func runCommandSpecAction(
+	ctx context.Context,
+	extensionMetadata *models.ExtensionSchema,
+	flags *packageFlags,
+) error {
+	absInputPath := filepath.Join(extensionMetadata.Path, flags.inputPath)
+	specPath := filepath.Join(extensionMetadata.Path, commandSpecFileName)
+
+	fmt.Println()
+	fmt.Printf("%s: %s\n", output.WithBold("Command Spec"), output.WithHyperlink(specPath, specPath))
+
+	var binaryPath string
+	return ux.NewTaskList(nil).
+		AddTask(ux.TaskOptions{
+			Title: "Building extension for the current platform",
+			Action: func(spf ux.SetProgressFunc) (ux.TaskState, error) {
+				path, err := buildCurrentPlatformBinary(ctx, extensionMetadata, absInputPath)
+				if err != nil {
+					return ux.Error, common.NewDetailedError("Build failed", err)
+				}
+
+				binaryPath = path
+				return ux.Success, nil
+			},
+		}).
+		AddTask(ux.TaskOptions{
+			Title: "Generating command spec",
+			Action: func(spf ux.SetProgressFunc) (ux.TaskState, error) {
+				if binaryPath == "" {
+					return ux.Skipped, nil
+				}
+
+				if err := writeCommandSpec(ctx, binaryPath, extensionMetadata.Path, specPath); err != nil {
+					return ux.Error, common.NewDetailedError(
+						"Command spec generation failed",
+						fmt.Errorf("%w. Make sure the extension has the metadata capability", err),
+					)
+				}
+
+				return ux.Success, nil
+			},
+		}).
+		Run()
+}
+
+// buildCurrentPlatformBinary builds the Go extension for the current platform into buildPath, named like the
+// binaries of the full build, and returns the path of the built binary.
+func buildCurrentPlatformBinary(
+	ctx context.Context,
+	extensionMetadata *models.ExtensionSchema,
+	buildPath string,
+) (string, error) {
+	if extensionMetadata.Language != "go" {
+		return "", fmt.Errorf("only Go extensions can be built on the fly, but the language is %q",
+			extensionMetadata.Language)
+	}
+
+	if err := os.MkdirAll(buildPath, osutil.PermissionDirectory); err != nil {
+		return "", fmt.Errorf("failed to create build directory: %w", err)
+	}
+
+	binaryName := fmt.Sprintf("%s-%s-%s", extensionMetadata.SafeDashId(), runtime.GOOS, runtime.GOARCH)
+	if runtime.GOOS == "windows" {
+		binaryName += ".exe"
+	}
+
+	buildCmd := exec.CommandContext(ctx, "go", "build", "-o", filepath.Join(buildPath, binaryName), ".")
+	buildCmd.Dir = extensionMetadata.Path
+	if resultBytes, err := buildCmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("failed to run go build: %w, Command output: %s", err, string(resultBytes))
+	}
+
+	binaryPath, err := findCurrentPlatformBinary(extensionMetadata, buildPath)
+	if err != nil {
+		return "", err
+	}
+	if binaryPath == "" {
+		return "", fmt.Errorf("go build produced no %s binary in %s", binaryName, buildPath)
+	}
+
+	return binaryPath, nil
+}
+
+// writeCommandSpec runs the hidden "metadata" command of the extension binary in dir and writes the command spec it
+// prints to specPath, after checking that it is a command spec.
+func writeCommandSpec(ctx context.Context, binaryPath string, dir string, specPath string) error {
+	ctx, cancel := context.WithTimeout(ctx, binaryProbeTimeout)
+	defer cancel()
+
+	//nolint:gosec // G204: the binary is the extension's own build output
+	metadataCmd := exec.CommandContext(ctx, binaryPath, "metadata")
+	metadataCmd.Dir = dir
+	outputBytes, err := metadataCmd.Output()
+	if err != nil {
+		return fmt.Errorf("failed to run '%s metadata': %w", filepath.Base(binaryPath), err)
+	}
+
+	var spec extensions.ExtensionCommandMetadata
+	if err := json.Unmarshal(outputBytes, &spec); err != nil {
+		return fmt.Errorf("'%s metadata' did not print a command spec: %w", filepath.Base(binaryPath), err)
+	}
+	if spec.SchemaVersion == "" || spec.ID == "" {
+		return fmt.Errorf("'%s metadata' printed a command spec without schemaVersion or id", filepath.Base(binaryPath))
+	}
+
+	specBytes, err := json.MarshalIndent(spec, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal command spec: %w", err)
+	}
+
+	if err := os.WriteFile(specPath, append(specBytes, '\n'), osutil.PermissionFile); err != nil {
+		return fmt.Errorf("failed to write command spec: %w", err)
 	}
 
 	return nil
 }
 
+// probeBinaryVersion runs "<binaryPath> version" in dir and returns its standard output.
+func probeBinaryVersion(ctx context.Context, binaryPath string, dir string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, binaryProbeTimeout)
+	defer cancel()
+
+	//nolint:gosec // G204: the binary is the extension's own build output
+	probeCmd := exec.CommandContext(ctx, binaryPath, "version")
+	probeCmd.Dir = dir
+	outputBytes, err := probeCmd.Output()
+
+	return string(outputBytes), err
+}
+
+// versionOutputMatches reports whether the output of an extension's "version" command contains version as a
+// whitespace-separated token, e.g. "microsoft.azd.demo 1.2.3".
+func versionOutputMatches(output string, version string) bool {
+	for field := range strings.FieldsSeq(output) {
+		if strings.TrimPrefix(field, "v") == version {
+			return true
+		}
+	}
+
+	return false
+}
+
+// packAllPlatformsArchive creates <id>-<version>-all.zip in outputPath containing extension.yaml, the commands.json
+// command spec when the extension has one, and every extension binary in the bin directory, so users can extract the
+// binary for their platform manually.
+func packAllPlatformsArchive(extensionMetadata *models.ExtensionSchema, outputPath string) (string, error) {
+	buildPath := filepath.Join(extensionMetadata.Path, "bin")
+	binaries, err := extensionBinaries(extensionMetadata, buildPath)
+	if err != nil {
+		return "", err
+	}
+
+	if len(binaries) == 0 {
+		return "", fmt.Errorf("no extension binaries found in %s", buildPath)
+	}
+
+	sourceFiles := []string{filepath.Join(extensionMetadata.Path, "extension.yaml")}
+
+	commandSpecPath := filepath.Join(extensionMetadata.Path, commandSpecFileName)
+	if _, err := os.Stat(commandSpecPath); err == nil {
+		sourceFiles = append(sourceFiles, commandSpecPath)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("failed to read %s: %w", commandSpecFileName, err)
+	}
+
+	for _, artifactName := range binaries {
+		sourceFiles = append(sourceFiles, filepath.Join(buildPath, artifactName))
+	}
+
+	if err := os.MkdirAll(outputPath, osutil.PermissionDirectory); err != nil {
+		return "", fmt.Errorf("failed to create target directory: %w", err)
+	}
+
+	targetFilePath := filepath.Join(outputPath, allPlatformsArchiveName(extensionMetadata))
+
+	return targetFilePath, internal.ZipSource(sourceFiles, targetFilePath)
+}
+
+// allPlatformsArchiveName returns the file name of the all-platforms archive, <id>-<version>-all.zip.
+func allPlatformsArchiveName(extensionMetadata *models.ExtensionSchema) string {
+	return fmt.Sprintf("%s-%s-all.zip", extensionMetadata.SafeDashId(), extensionMetadata.Version)
+}
+
 func defaultPackageFlags(flags *packageFlags) {
 	if flags.inputPath == "" {
 		flags.inputPath = "bin"
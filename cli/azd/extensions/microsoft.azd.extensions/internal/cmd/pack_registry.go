@@ -0,0 +1,141 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/extensions/microsoft.azd.extensions/internal"
+	"github.com/azure/azure-dev/cli/azd/extensions/microsoft.azd.extensions/internal/models"
+	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/gofrs/flock"
+)
+
+// registryLockRetryDelay is the polling interval used while waiting for another pack to release the registry lock.
+const registryLockRetryDelay = 50 * time.Millisecond
+
+// updateRegistryIndex adds or replaces the entry for the extension version in the registry index at registryPath,
+// pointing its artifacts at archives and recording the OS/architecture combinations of those archives as the platforms
+// it supports. A missing index is created.
+//
+// The read-modify-write runs under a file lock next to the index, so concurrent packs of different extensions do not
+// drop each other's entries, and the index is replaced atomically by saveRegistry.
+func updateRegistryIndex(
+	ctx context.Context,
+	registryPath string,
+	extensionMetadata *models.ExtensionSchema,
+	archives []string,
+) error {
+	artifacts, err := registryArtifacts(extensionMetadata, archives)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(registryPath), osutil.PermissionDirectory); err != nil {
+		return fmt.Errorf("failed to create registry directory: %w", err)
+	}
+
+	lock := flock.New(registryPath + ".lock")
+	locked, err := lock.TryLockContext(ctx, registryLockRetryDelay)
+	if err != nil {
+		return fmt.Errorf("failed to lock registry: %w", err)
+	}
+	if !locked {
+		return errors.New("failed to lock registry")
+	}
+	defer func() {
+		if err := lock.Unlock(); err != nil {
+			log.Printf("failed to release registry lock: %v", err)
+		}
+	}()
+
+	registry := &extensions.Registry{}
+	if _, err := os.Stat(registryPath); err == nil {
+		registry, err = models.LoadRegistry(registryPath)
+		if err != nil {
+			return err
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read registry file: %w", err)
+	}
+
+	addOrUpdateExtension(registry, extensionMetadata, artifacts)
+	setVersionPlatforms(registry, extensionMetadata, slices.Sorted(maps.Keys(artifacts)))
+
+	return saveRegistry(ctx, registryPath, registry)
+}
+
+// setVersionPlatforms records platforms on the registry entry for the extension version.
+func setVersionPlatforms(registry *extensions.Registry, extensionMetadata *models.ExtensionSchema, platforms []string) {
+	for _, ext := range registry.Extensions {
+		if ext.Id != extensionMetadata.Id {
+			continue
+		}
+
+		for i := range ext.Versions {
+			if ext.Versions[i].Version == extensionMetadata.Version {
+				ext.Versions[i].Platforms = platforms
+			}
+		}
+	}
+}
+
+// registryArtifacts describes archives as registry artifacts keyed by the OS/architecture inferred from their names.
+func registryArtifacts(
+	extensionMetadata *models.ExtensionSchema,
+	archives []string,
+) (map[string]extensions.ExtensionArtifact, error) {
+	artifacts := map[string]extensions.ExtensionArtifact{}
+	for _, archive := range archives {
+		archiveName := filepath.Base(archive)
+		osArch, err := internal.InferOSArch(archiveName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to infer OS and architecture from %s: %w", archiveName, err)
+		}
+
+		checksum, err := internal.ComputeChecksum(archive)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute checksum of %s: %w", archiveName, err)
+		}
+
+		artifactMetadata, err := createPlatformMetadata(extensionMetadata, osArch, archiveName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create platform metadata for %s: %w", archiveName, err)
+		}
+
+		absArchivePath, err := filepath.Abs(archive)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get absolute path of %s: %w", archiveName, err)
+		}
+
+		artifacts[osArch] = extensions.ExtensionArtifact{
+			URL: absArchivePath,
+			Checksum: extensions.ExtensionChecksum{
+				Algorithm: "sha256",
+				Value:     checksum,
+			},
+			AdditionalMetadata: artifactMetadata,
+		}
+	}
+
+	return artifacts, nil
+}
+
+// platformArchives returns the per-platform archives among the outputs of a pack, leaving out the all-platforms
+// archive, which is not installable through a registry.
+func platformArchives(extensionMetadata *models.ExtensionSchema, outputs []string) []string {
+	allPlatforms := allPlatformsArchiveName(extensionMetadata)
+	return slices.DeleteFunc(slices.Clone(outputs), func(output string) bool {
+		return filepath.Base(output) == allPlatforms
+	})
+}
@@ -0,0 +1,124 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/extensions/microsoft.azd.extensions/internal"
+	"github.com/azure/azure-dev/cli/azd/extensions/microsoft.azd.extensions/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+// writeArchives creates a fake archive per platform for the extension in dir and returns their paths.
+func writeArchives(t *testing.T, dir string, extensionMetadata *models.ExtensionSchema, platforms ...string) []string {
+	t.Helper()
+
+	archives := []string{}
+	for _, platform := range platforms {
+		archive := filepath.Join(dir, extensionMetadata.SafeDashId()+"-"+platform)
+		require.NoError(t, os.WriteFile(archive, []byte(archive+extensionMetadata.Version), 0600))
+		archives = append(archives, archive)
+	}
+
+	return archives
+}
+
+func TestUpdateRegistryIndex_SequentialPacks(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	registryPath := filepath.Join(dir, "registry.json")
+
+	demo := &models.ExtensionSchema{Id: "microsoft.azd.demo", Namespace: "demo", Version: "0.1.0"}
+	ai := &models.ExtensionSchema{Id: "azure.ai.agents", Namespace: "ai.agent", Version: "1.0.0"}
+
+	demoArchives := writeArchives(t, dir, demo, "linux-amd64.tar.gz", "windows-amd64.zip")
+	require.NoError(t, updateRegistryIndex(t.Context(), registryPath, demo, demoArchives))
+
+	aiArchives := writeArchives(t, dir, ai, "darwin-arm64.zip")
+	require.NoError(t, updateRegistryIndex(t.Context(), registryPath, ai, aiArchives))
+
+	registry, err := models.LoadRegistry(registryPath)
+	require.NoError(t, err)
+	require.Len(t, registry.Extensions, 2)
+
+	require.Equal(t, "microsoft.azd.demo", registry.Extensions[0].Id)
+	require.Len(t, registry.Extensions[0].Versions, 1)
+	demoArtifacts := registry.Extensions[0].Versions[0].Artifacts
+	require.Len(t, demoArtifacts, 2)
+
+	checksum, err := internal.ComputeChecksum(demoArchives[0])
+	require.NoError(t, err)
+	require.Equal(t, demoArchives[0], demoArtifacts["linux/amd64"].URL)
+	require.Equal(t, "sha256", demoArtifacts["linux/amd64"].Checksum.Algorithm)
+	require.Equal(t, checksum, demoArtifacts["linux/amd64"].Checksum.Value)
+	require.Equal(t, demoArchives[1], demoArtifacts["windows/amd64"].URL)
+	require.Equal(t, []string{"linux/amd64", "windows/amd64"}, registry.Extensions[0].Versions[0].Platforms)
+
+	require.Equal(t, "azure.ai.agents", registry.Extensions[1].Id)
+	require.Equal(t, aiArchives[0], registry.Extensions[1].Versions[0].Artifacts["darwin/arm64"].URL)
+	require.Equal(t, []string{"darwin/arm64"}, registry.Extensions[1].Versions[0].Platforms)
+
+	// Packing the same version again replaces its entry instead of adding another.
+	repacked := writeArchives(t, t.TempDir(), demo, "linux-arm64.tar.gz")
+	require.NoError(t, updateRegistryIndex(t.Context(), registryPath, demo, repacked))
+
+	registry, err = models.LoadRegistry(registryPath)
+	require.NoError(t, err)
+	require.Len(t, registry.Extensions, 2)
+	require.Len(t, registry.Extensions[0].Versions, 1)
+	require.Len(t, registry.Extensions[0].Versions[0].Artifacts, 1)
+	require.Contains(t, registry.Extensions[0].Versions[0].Artifacts, "linux/arm64")
+	require.Equal(t, []string{"linux/arm64"}, registry.Extensions[0].Versions[0].Platforms)
+
+	// No temporary files are left next to the index.
+	matches, err := filepath.Glob(filepath.Join(dir, "registry.json.tmp-*"))
+	require.NoError(t, err)
+	require.Empty(t, matches)
+}
+
+func TestUpdateRegistryIndex_ConcurrentPacks(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	registryPath := filepath.Join(dir, "registry.json")
+
+	ids := []string{"ext.one", "ext.two", "ext.three", "ext.four"}
+	var wg sync.WaitGroup
+	for _, id := range ids {
+		extensionMetadata := &models.ExtensionSchema{Id: id, Version: "1.0.0"}
+		archives := writeArchives(t, dir, extensionMetadata, "linux-amd64.tar.gz")
+		wg.Go(func() {
+			require.NoError(t, updateRegistryIndex(t.Context(), registryPath, extensionMetadata, archives))
+		})
+	}
+	wg.Wait()
+
+	registry, err := models.LoadRegistry(registryPath)
+	require.NoError(t, err)
+
+	got := []string{}
+	for _, ext := range registry.Extensions {
+		got = append(got, ext.Id)
+	}
+	require.ElementsMatch(t, ids, got)
+}
+
+func TestPlatformArchives(t *testing.T) {
+	t.Parallel()
+
+	extensionMetadata := &models.ExtensionSchema{Id: "microsoft.azd.demo", Version: "0.1.0"}
+	outputs := []string{
+		filepath.Join("out", "microsoft-azd-demo-linux-amd64.tar.gz"),
+		filepath.Join("out", "microsoft-azd-demo-0.1.0-all.zip"),
+		filepath.Join("out", "microsoft-azd-demo-windows-amd64.zip"),
+	}
+
+	require.Equal(t, []string{outputs[0], outputs[2]}, platformArchives(extensionMetadata, outputs))
+	require.Len(t, outputs, 3)
+}
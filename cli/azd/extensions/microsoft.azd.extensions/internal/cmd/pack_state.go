@@ -0,0 +1,243 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/extensions/microsoft.azd.extensions/internal"
+	"github.com/azure/azure-dev/cli/azd/extensions/microsoft.azd.extensions/internal/models"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+)
+
+// packStateFileName is the name of the file, in the pack output directory (see packStateDir), that records which
+// pack tasks completed and the inputs they completed with.
+const packStateFileName = ".azd-pack-state"
+
+// Names of the pack tasks recorded in the pack state.
+const (
+	validateTaskName = "validate"
+	buildTaskName    = "build"
+	packTaskName     = "package"
+	registryTaskName = "registry"
+)
+
+// packState records the pack tasks that completed in a previous run of azd x pack.
+type packState struct {
+	Tasks map[string]packTaskState `json:"tasks"`
+}
+
+// packTaskState records a completed pack task.
+type packTaskState struct {
+	// InputHash identifies the inputs the task completed with.
+	InputHash string `json:"inputHash"`
+	// Outputs are the files the task produced.
+	Outputs []string `json:"outputs"`
+}
+
+// packStateDir returns the directory that holds the pack state: the directory of bundleOutputPath when packing a
+// bundle, otherwise outputPath. Keeping the state with the artifacts means that each output directory, and each
+// checkout packing to its own output directory, has its own state. It is empty when pack writes no artifacts, e.g. for
+// an extension pack without --bundle, in which case no state is kept.
+func packStateDir(outputPath string, bundleOutputPath string) string {
+	if bundleOutputPath != "" {
+		return filepath.Dir(bundleOutputPath)
+	}
+
+	return outputPath
+}
+
+// loadPackState reads the pack state from dir. A missing or unreadable state file, or an empty dir, yields an empty
+// state, so that every task runs.
+func loadPackState(dir string) *packState {
+	state := &packState{Tasks: map[string]packTaskState{}}
+	if dir == "" {
+		return state
+	}
+
+	stateBytes, err := os.ReadFile(filepath.Join(dir, packStateFileName))
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Printf("ignoring pack state: %v", err)
+		}
+		return state
+	}
+
+	if err := json.Unmarshal(stateBytes, state); err != nil || state.Tasks == nil {
+		log.Printf("ignoring invalid pack state in %s: %v", dir, err)
+		return &packState{Tasks: map[string]packTaskState{}}
+	}
+
+	return state
+}
+
+// save writes the pack state to dir. Nothing is written when dir is empty.
+func (s *packState) save(dir string) error {
+	if dir == "" {
+		return nil
+	}
+
+	stateBytes, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal pack state: %w", err)
+	}
+
+	if err := os.MkdirAll(dir, osutil.PermissionDirectory); err != nil {
+		return fmt.Errorf("failed to create pack state directory: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, packStateFileName), stateBytes, osutil.PermissionFile); err != nil {
+		return fmt.Errorf("failed to write pack state: %w", err)
+	}
+
+	return nil
+}
+
+// isComplete reports whether task completed with the inputs identified by inputHash and all of its outputs still
+// exist.
+func (s *packState) isComplete(task string, inputHash string) bool {
+	taskState, has := s.Tasks[task]
+	if !has || taskState.InputHash != inputHash {
+		return false
+	}
+
+	for _, output := range taskState.Outputs {
+		if _, err := os.Stat(output); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// markComplete records that task completed with the inputs identified by inputHash, producing outputs.
+func (s *packState) markComplete(task string, inputHash string, outputs []string) {
+	s.Tasks[task] = packTaskState{
+		InputHash: inputHash,
+		Outputs:   outputs,
+	}
+}
+
+// clearPackState removes the pack state from dir.
+func clearPackState(dir string) error {
+	if dir == "" {
+		return nil
+	}
+
+	if err := os.Remove(filepath.Join(dir, packStateFileName)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to clear pack state: %w", err)
+	}
+
+	return nil
+}
+
+// packInputHash hashes the inputs of the packaging task: extension.yaml, commands.json when present, the extension
+// binaries in the bin directory, the packaging mode, and target, the output directory or bundle path. Including target
+// makes packing to another destination run again rather than being treated as up to date.
+func packInputHash(extensionMetadata *models.ExtensionSchema, mode string, target string) (string, error) {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "mode=%s\n", mode)
+
+	absTarget, err := filepath.Abs(target)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve pack target: %w", err)
+	}
+	fmt.Fprintf(hash, "target=%s\n", absTarget)
+
+	extensionYamlPath := filepath.Join(extensionMetadata.Path, "extension.yaml")
+	extensionYamlChecksum, err := internal.ComputeChecksum(extensionYamlPath)
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(hash, "extension.yaml=%s\n", extensionYamlChecksum)
+
+	// The all-platforms archive includes the command spec when there is one.
+	commandSpecChecksum, err := internal.ComputeChecksum(filepath.Join(extensionMetadata.Path, commandSpecFileName))
+	if err == nil {
+		fmt.Fprintf(hash, "%s=%s\n", commandSpecFileName, commandSpecChecksum)
+	}
+
+	buildPath := filepath.Join(extensionMetadata.Path, "bin")
+	artifactNames, err := extensionBinaries(extensionMetadata, buildPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+
+	for _, artifactName := range artifactNames {
+		checksum, err := internal.ComputeChecksum(filepath.Join(buildPath, artifactName))
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(hash, "%s=%s\n", artifactName, checksum)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// validateInputHash hashes the input of the manifest validation task, extension.yaml.
+func validateInputHash(extensionMetadata *models.ExtensionSchema) (string, error) {
+	return internal.ComputeChecksum(filepath.Join(extensionMetadata.Path, "extension.yaml"))
+}
+
+// buildInputHash hashes the inputs of the build task: the path, size and modification time of each source file in
+// the extension directory. Hidden files and directories, node_modules, archives and inputPath, where the binaries are
+// built to, are not sources and are skipped.
+func buildInputHash(extensionMetadata *models.ExtensionSchema, inputPath string) (string, error) {
+	hash := sha256.New()
+	binPath := filepath.Join(extensionMetadata.Path, inputPath)
+
+	err := filepath.WalkDir(extensionMetadata.Path, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == extensionMetadata.Path {
+			return nil
+		}
+
+		name := entry.Name()
+		if entry.IsDir() {
+			if strings.HasPrefix(name, ".") || name == "node_modules" || path == binPath {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasPrefix(name, ".") || strings.HasSuffix(name, ".zip") || strings.HasSuffix(name, ".tar.gz") {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(extensionMetadata.Path, path)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(hash, "%s=%d:%d\n", filepath.ToSlash(relPath), info.Size(), info.ModTime().UnixNano())
+
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to hash extension sources: %w", err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// registryInputHash hashes the inputs of the registry update task: the registry index at registryPath and
+// packageHash, the input hash of the packaging task that produced the archives it points at.
+func registryInputHash(registryPath string, packageHash string) string {
+	hash := sha256.Sum256(fmt.Appendf(nil, "registry=%s\npackage=%s\n", registryPath, packageHash))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,148 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/extensions/microsoft.azd.extensions/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackState_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	outputDir := t.TempDir()
+	archive := filepath.Join(outputDir, "microsoft-test-linux-amd64.tar.gz")
+	require.NoError(t, os.WriteFile(archive, []byte("archive"), 0600))
+
+	state := loadPackState(outputDir)
+	require.False(t, state.isComplete(packTaskName, "hash"))
+
+	state.markComplete(packTaskName, "hash", []string{archive})
+	require.NoError(t, state.save(outputDir))
+
+	reloaded := loadPackState(outputDir)
+	require.True(t, reloaded.isComplete(packTaskName, "hash"))
+	require.False(t, reloaded.isComplete(packTaskName, "other-hash"), "changed inputs")
+
+	require.NoError(t, os.Remove(archive))
+	require.False(t, reloaded.isComplete(packTaskName, "hash"), "missing output")
+
+	require.NoError(t, clearPackState(outputDir))
+	require.NoFileExists(t, filepath.Join(outputDir, packStateFileName))
+	require.NoError(t, clearPackState(outputDir))
+}
+
+func TestPackStateDir(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, filepath.Join("out", "1.2.3"), packStateDir(filepath.Join("out", "1.2.3"), ""))
+	require.Equal(t, "dist", packStateDir("", filepath.Join("dist", "microsoft-test_1.2.3.zip")))
+	require.Empty(t, packStateDir("", ""))
+}
+
+func TestPackState_NoDir(t *testing.T) {
+	t.Parallel()
+
+	state := loadPackState("")
+	state.markComplete(validateTaskName, "hash", nil)
+	require.NoError(t, state.save(""))
+	require.NoError(t, clearPackState(""))
+
+	// A task without outputs, such as validation, is complete while its inputs are unchanged.
+	require.True(t, state.isComplete(validateTaskName, "hash"))
+	require.False(t, state.isComplete(validateTaskName, "other-hash"))
+}
+
+func TestLoadPackState_Invalid(t *testing.T) {
+	t.Parallel()
+
+	outputDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(outputDir, packStateFileName), []byte("{not json"), 0600))
+
+	state := loadPackState(outputDir)
+	require.Empty(t, state.Tasks)
+	require.False(t, state.isComplete(packTaskName, "hash"))
+}
+
+func TestPackInputHash(t *testing.T) {
+	t.Parallel()
+
+	extensionDir := t.TempDir()
+	binDir := filepath.Join(extensionDir, "bin")
+	require.NoError(t, os.MkdirAll(binDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(extensionDir, "extension.yaml"), []byte("id: microsoft.test"), 0600))
+	binaryPath := filepath.Join(binDir, "microsoft-test-linux-amd64")
+	require.NoError(t, os.WriteFile(binaryPath, []byte("v1"), 0600))
+
+	ext := &models.ExtensionSchema{Id: "microsoft.test", Version: "1.2.3", Path: extensionDir}
+
+	hash, err := packInputHash(ext, "default", "out")
+	require.NoError(t, err)
+
+	same, err := packInputHash(ext, "default", "out")
+	require.NoError(t, err)
+	require.Equal(t, hash, same)
+
+	otherMode, err := packInputHash(ext, "bundle", "out")
+	require.NoError(t, err)
+	require.NotEqual(t, hash, otherMode)
+
+	// Packing to another destination is not up to date, e.g. dist/b.zip after dist/a.zip.
+	otherTarget, err := packInputHash(ext, "default", "other-out")
+	require.NoError(t, err)
+	require.NotEqual(t, hash, otherTarget)
+
+	// Files that are not extension binaries do not affect the hash.
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, "other-tool"), []byte("x"), 0600))
+	unrelated, err := packInputHash(ext, "default", "out")
+	require.NoError(t, err)
+	require.Equal(t, hash, unrelated)
+
+	require.NoError(t, os.WriteFile(binaryPath, []byte("v2"), 0600))
+	rebuilt, err := packInputHash(ext, "default", "out")
+	require.NoError(t, err)
+	require.NotEqual(t, hash, rebuilt)
+}
+
+func TestBuildInputHash(t *testing.T) {
+	t.Parallel()
+
+	extensionDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(extensionDir, "bin"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(extensionDir, "internal"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(extensionDir, "extension.yaml"), []byte("id: microsoft.test"), 0600))
+	sourcePath := filepath.Join(extensionDir, "internal", "main.go")
+	require.NoError(t, os.WriteFile(sourcePath, []byte("package main"), 0600))
+
+	ext := &models.ExtensionSchema{Id: "microsoft.test", Version: "1.2.3", Path: extensionDir}
+
+	hash, err := buildInputHash(ext, "bin")
+	require.NoError(t, err)
+
+	// Build outputs, archives and hidden files such as the pack state are not sources.
+	require.NoError(t, os.WriteFile(filepath.Join(extensionDir, "bin", "microsoft-test-linux-amd64"), []byte("x"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(extensionDir, "microsoft-test_1.2.3.zip"), []byte("x"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(extensionDir, packStateFileName), []byte("{}"), 0600))
+	unrelated, err := buildInputHash(ext, "bin")
+	require.NoError(t, err)
+	require.Equal(t, hash, unrelated)
+
+	require.NoError(t, os.WriteFile(sourcePath, []byte("package main // changed"), 0600))
+	changed, err := buildInputHash(ext, "bin")
+	require.NoError(t, err)
+	require.NotEqual(t, hash, changed)
+}
+
+func TestRegistryInputHash(t *testing.T) {
+	t.Parallel()
+
+	hash := registryInputHash("registry.json", "package-hash")
+	require.Equal(t, hash, registryInputHash("registry.json", "package-hash"))
+	require.NotEqual(t, hash, registryInputHash("registry.json", "repackaged-hash"))
+	require.NotEqual(t, hash, registryInputHash("other-registry.json", "package-hash"))
+}
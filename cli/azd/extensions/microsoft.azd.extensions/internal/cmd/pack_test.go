@@ -0,0 +1,251 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/extensions/microsoft.azd.extensions/internal/models"
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackAllPlatformsArchive(t *testing.T) {
+	t.Parallel()
+
+	extensionDir := t.TempDir()
+	binDir := filepath.Join(extensionDir, "bin")
+	require.NoError(t, os.MkdirAll(binDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(extensionDir, "extension.yaml"), []byte("id: microsoft.test"), 0600))
+
+	binaries := []string{
+		"microsoft-test-windows-amd64.exe",
+		"microsoft-test-windows-arm64.exe",
+		"microsoft-test-darwin-amd64",
+		"microsoft-test-darwin-arm64",
+		"microsoft-test-linux-amd64",
+		"microsoft-test-linux-arm64",
+	}
+	for _, name := range binaries {
+		require.NoError(t, os.WriteFile(filepath.Join(binDir, name), []byte(name), 0600))
+	}
+
+	// Files that are not extension binaries are left out.
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, "microsoft-test-linux-amd64.sha256"), []byte("x"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, "other-tool"), []byte("x"), 0600))
+
+	ext := &models.ExtensionSchema{Id: "microsoft.test", Version: "1.2.3", Path: extensionDir}
+	outputDir := t.TempDir()
+
+	archivePath, err := packAllPlatformsArchive(ext, outputDir)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(outputDir, "microsoft-test-1.2.3-all.zip"), archivePath)
+
+	reader, err := zip.OpenReader(archivePath)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	var names []string
+	for _, f := range reader.File {
+		names = append(names, f.Name)
+	}
+
+	require.ElementsMatch(t, append([]string{"extension.yaml"}, binaries...), names)
+
+	// The command spec is bundled when the extension has one.
+	require.NoError(t, os.WriteFile(filepath.Join(extensionDir, commandSpecFileName), []byte("{}"), 0600))
+	archivePath, err = packAllPlatformsArchive(ext, outputDir)
+	require.NoError(t, err)
+
+	withSpec, err := zip.OpenReader(archivePath)
+	require.NoError(t, err)
+	defer withSpec.Close()
+
+	names = nil
+	for _, f := range withSpec.File {
+		names = append(names, f.Name)
+	}
+
+	require.ElementsMatch(t, append([]string{"extension.yaml", commandSpecFileName}, binaries...), names)
+}
+
+func TestPackAllPlatformsArchive_NoBinaries(t *testing.T) {
+	t.Parallel()
+
+	extensionDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(extensionDir, "bin"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(extensionDir, "extension.yaml"), []byte("id: microsoft.test"), 0600))
+
+	ext := &models.ExtensionSchema{Id: "microsoft.test", Version: "1.2.3", Path: extensionDir}
+
+	_, err := packAllPlatformsArchive(ext, t.TempDir())
+	require.ErrorContains(t, err, "no extension binaries found")
+}
+
+func TestExtensionBinaries(t *testing.T) {
+	t.Parallel()
+
+	binDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(binDir, "microsoft-test-dir"), 0755))
+	for _, name := range []string{
+		"microsoft-test-windows-arm64.exe",
+		"microsoft-test-linux-amd64",
+		"microsoft-test-linux-amd64.sha256",
+		"other-tool",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(binDir, name), []byte(name), 0600))
+	}
+
+	ext := &models.ExtensionSchema{Id: "microsoft.test", Version: "1.2.3"}
+
+	binaries, err := extensionBinaries(ext, binDir)
+	require.NoError(t, err)
+	require.Equal(t, []string{"microsoft-test-linux-amd64", "microsoft-test-windows-arm64.exe"}, binaries)
+
+	_, err = extensionBinaries(ext, filepath.Join(binDir, "missing"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestVersionOutputMatches(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		output string
+		want   bool
+	}{
+		{name: "id and version", output: "microsoft.test 1.2.3\n", want: true},
+		{name: "v prefix", output: "microsoft.test v1.2.3", want: true},
+		{name: "stale version", output: "microsoft.test 1.2.2", want: false},
+		{name: "version as substring", output: "microsoft.test 1.2.30", want: false},
+		{name: "empty output", output: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.want, versionOutputMatches(tt.output, "1.2.3"))
+		})
+	}
+}
+
+func TestVerifyBinaryVersion(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the extension binary")
+	}
+	t.Parallel()
+
+	newExtension := func(t *testing.T, reportedVersion string) *models.ExtensionSchema {
+		extensionDir := t.TempDir()
+		binDir := filepath.Join(extensionDir, "bin")
+		require.NoError(t, os.MkdirAll(binDir, 0755))
+
+		script := fmt.Sprintf("#!/bin/sh\necho \"microsoft.test %s\"\n", reportedVersion)
+		binaryName := fmt.Sprintf("microsoft-test-%s-%s", runtime.GOOS, runtime.GOARCH)
+		//nolint:gosec // G306: the test binary must be executable
+		require.NoError(t, os.WriteFile(filepath.Join(binDir, binaryName), []byte(script), 0700))
+
+		// Binaries for other platforms are not executed.
+		require.NoError(t, os.WriteFile(filepath.Join(binDir, "microsoft-test-plan9-amd64"), []byte("x"), 0600))
+
+		return &models.ExtensionSchema{Id: "microsoft.test", Version: "1.2.3", Path: extensionDir}
+	}
+
+	t.Run("matching version", func(t *testing.T) {
+		t.Parallel()
+		require.NoError(t, verifyBinaryVersion(t.Context(), newExtension(t, "1.2.3")))
+	})
+
+	t.Run("stale binary", func(t *testing.T) {
+		t.Parallel()
+		err := verifyBinaryVersion(t.Context(), newExtension(t, "1.2.2"))
+		require.ErrorContains(t, err, "extension.yaml declares 1.2.3")
+	})
+}
+
+func TestFindCurrentPlatformBinary(t *testing.T) {
+	t.Parallel()
+
+	binDir := t.TempDir()
+	current := fmt.Sprintf("microsoft-test-%s-%s", runtime.GOOS, runtime.GOARCH)
+	if runtime.GOOS == "windows" {
+		current += ".exe"
+	}
+	for _, name := range []string{"microsoft-test-plan9-amd64", current, "other-" + current} {
+		require.NoError(t, os.WriteFile(filepath.Join(binDir, name), []byte("x"), 0600))
+	}
+	extension := &models.ExtensionSchema{Id: "microsoft.test", Path: t.TempDir()}
+
+	binaryPath, err := findCurrentPlatformBinary(extension, binDir)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(binDir, current), binaryPath)
+
+	binaryPath, err = findCurrentPlatformBinary(extension, t.TempDir())
+	require.NoError(t, err)
+	require.Empty(t, binaryPath)
+}
+
+func TestWriteCommandSpec(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the extension binary")
+	}
+	t.Parallel()
+
+	// newBinary writes a script that prints output for its "metadata" command.
+	newBinary := func(t *testing.T, output string) string {
+		binaryPath := filepath.Join(t.TempDir(), fmt.Sprintf("microsoft-test-%s-%s", runtime.GOOS, runtime.GOARCH))
+		script := fmt.Sprintf("#!/bin/sh\ncat <<'SPEC'\n%s\nSPEC\n", output)
+		//nolint:gosec // G306: the test binary must be executable
+		require.NoError(t, os.WriteFile(binaryPath, []byte(script), 0700))
+		return binaryPath
+	}
+
+	t.Run("writes the command spec", func(t *testing.T) {
+		t.Parallel()
+
+		root := &cobra.Command{Use: "test"}
+		root.AddCommand(&cobra.Command{Use: "hello", Short: "Say hello", Run: func(*cobra.Command, []string) {}})
+		metadata, err := json.Marshal(azdext.GenerateExtensionMetadata("1.0", "microsoft.test", root))
+		require.NoError(t, err)
+
+		dir := t.TempDir()
+		specPath := filepath.Join(dir, commandSpecFileName)
+		require.NoError(t, writeCommandSpec(t.Context(), newBinary(t, string(metadata)), dir, specPath))
+
+		specBytes, err := os.ReadFile(specPath)
+		require.NoError(t, err)
+		require.True(t, json.Valid(specBytes))
+
+		var spec map[string]any
+		require.NoError(t, json.Unmarshal(specBytes, &spec))
+		require.Equal(t, "1.0", spec["schemaVersion"])
+		require.Equal(t, "microsoft.test", spec["id"])
+		commands, ok := spec["commands"].([]any)
+		require.True(t, ok, "commands must be an array")
+		require.Len(t, commands, 1)
+		require.Equal(t, []any{"hello"}, commands[0].(map[string]any)["name"])
+	})
+
+	t.Run("rejects output that is not a command spec", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		specPath := filepath.Join(dir, commandSpecFileName)
+
+		err := writeCommandSpec(t.Context(), newBinary(t, "microsoft.test 1.2.3"), dir, specPath)
+		require.ErrorContains(t, err, "did not print a command spec")
+
+		err = writeCommandSpec(t.Context(), newBinary(t, `{"commands": []}`), dir, specPath)
+		require.ErrorContains(t, err, "without schemaVersion or id")
+
+		require.NoFileExists(t, specPath)
+	})
+}
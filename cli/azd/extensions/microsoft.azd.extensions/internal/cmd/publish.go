@@ -388,7 +388,7 @@ func runPublishAction(ctx context.Context, flags *publishFlags, defaultRegistryU
 				}
 
 				addOrUpdateExtension(registry, extensionMetadata, artifactMap)
-				if err := saveRegistry(flags.registryPath, registry); err != nil {
+				if err := saveRegistry(ctx, flags.registryPath, registry); err != nil {
 					return ux.Error, common.NewDetailedError(
 						"Failed to save registry",
 						fmt.Errorf("failed to save registry: %w", err),
@@ -494,7 +494,9 @@ func addOrUpdateExtension(
 	})
 }
 
-func saveRegistry(path string, registry *extensions.Registry) error {
+// saveRegistry writes registry to path. The file is written to a temporary file next to path and renamed over it, so
+// readers never see a partially written registry.
+func saveRegistry(ctx context.Context, path string, registry *extensions.Registry) error {
 	var buf bytes.Buffer
 	encoder := json.NewEncoder(&buf)
 	encoder.SetEscapeHTML(false)
@@ -503,7 +505,31 @@ func saveRegistry(path string, registry *extensions.Registry) error {
 		return err
 	}
 
-	return os.WriteFile(path, buf.Bytes(), osutil.PermissionFile)
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary registry file: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+
+	if _, err := tmpFile.Write(buf.Bytes()); err != nil {
+		tmpFile.Close()
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to write temporary registry file: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to close temporary registry file: %w", err)
+	}
+	if err := os.Chmod(tmpPath, osutil.PermissionFile); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to set registry file permissions: %w", err)
+	}
+	if err := osutil.Rename(ctx, tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to replace registry file: %w", err)
+	}
+
+	return nil
 }
 
 func createPlatformMetadata(
@@ -543,18 +569,28 @@ func createPlatformMetadata(
 // Returns true when that default was applied.
 func defaultPublishFlags(flags *publishFlags) (bool, error) {
 	if flags.registryPath == "" {
-		azdConfigDir, err := internal.AzdConfigDir()
+		registryPath, err := localRegistryPath()
 		if err != nil {
 			return false, err
 		}
 
-		flags.registryPath = filepath.Join(azdConfigDir, "registry.json")
+		flags.registryPath = registryPath
 		return true, nil
 	}
 
 	return false, nil
 }
 
+// localRegistryPath returns the path of the local extension source registry index, <azd config>/registry.json.
+func localRegistryPath() (string, error) {
+	azdConfigDir, err := internal.AzdConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(azdConfigDir, "registry.json"), nil
+}
+
 var (
 	operatingSystems = []string{"windows", "linux", "darwin"}
 	architectures    = []string{"amd64", "arm64"}
@@ -25,7 +25,7 @@ func TestSaveRegistryDoesNotHTMLEscape(t *testing.T) {
 	}
 
 	path := filepath.Join(t.TempDir(), "registry.json")
-	require.NoError(t, saveRegistry(path, registry))
+	require.NoError(t, saveRegistry(t.Context(), path, registry))
 
 	data, err := os.ReadFile(path)
 	require.NoError(t, err)
@@ -5,9 +5,11 @@ package models
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
@@ -158,6 +160,125 @@ Example: %s`,
 	return &extensionMetadata, nil
 }
 
+// manifestKeys is the set of top-level keys accepted in extension.yaml, matching the properties defined in
+// extension.schema.json plus the keys understood by the extension tooling.
+var manifestKeys = map[string]struct{}{
+	"id":                 {},
+	"namespace":          {},
+	"language":           {},
+	"entryPoint":         {},
+	"version":            {},
+	"requiredAzdVersion": {},
+	"capabilities":       {},
+	"providers":          {},
+	"displayName":        {},
+	"description":        {},
+	"usage":              {},
+	"examples":           {},
+	"tags":               {},
+	"dependencies":       {},
+	"platforms":          {},
+	"mcp":                {},
+}
+
+// FieldError describes a problem with a single field of extension.yaml.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// Validate checks the extension metadata against the rules of the extension manifest schema and returns the
+// joined field errors, or nil when the metadata is valid.
+func (e *ExtensionSchema) Validate() error {
+	var errs []error
+	required := []struct {
+		field string
+		value string
+	}{
+		{"id", e.Id},
+		{"version", e.Version},
+		{"displayName", e.DisplayName},
+		{"description", e.Description},
+	}
+	for _, r := range required {
+		if strings.TrimSpace(r.value) == "" {
+			errs = append(errs, &FieldError{Field: r.field, Message: "is required"})
+		}
+	}
+
+	// Extensions with capabilities ship an executable, which is located through its entry point or produced by
+	// building the project for its language.
+	if len(e.Capabilities) > 0 && e.EntryPoint == "" && e.Language == "" {
+		errs = append(errs, &FieldError{
+			Field:   "entryPoint",
+			Message: "is required when the extension declares capabilities and no language",
+		})
+	}
+
+	seen := map[extensions.CapabilityType]bool{}
+	for i, capability := range e.Capabilities {
+		field := fmt.Sprintf("capabilities[%d]", i)
+		if !slices.Contains(extensions.ValidCapabilities, capability) {
+			errs = append(errs, &FieldError{Field: field, Message: fmt.Sprintf("unsupported capability %q", capability)})
+			continue
+		}
+
+		if seen[capability] {
+			errs = append(errs, &FieldError{Field: field, Message: fmt.Sprintf("duplicate capability %q", capability)})
+		}
+		seen[capability] = true
+	}
+
+	return errors.Join(errs...)
+}
+
+// ValidateExtensionManifest validates the extension.yaml file in extensionPath. Unlike LoadExtension, it rejects
+// top-level keys that are not part of the manifest schema and reports every invalid field rather than the first.
+func ValidateExtensionManifest(extensionPath string) error {
+	metadataPath := filepath.Join(extensionPath, "extension.yaml")
+	metadataBytes, err := os.ReadFile(metadataPath)
+	if err != nil {
+		return fmt.Errorf("failed to read metadata: %w", err)
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(metadataBytes, &document); err != nil {
+		return fmt.Errorf("failed to parse metadata: %w", err)
+	}
+
+	if len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+		return fmt.Errorf("failed to parse metadata: extension.yaml must contain a mapping")
+	}
+
+	root := document.Content[0]
+
+	var errs []error
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key := root.Content[i]
+		if _, has := manifestKeys[key.Value]; !has {
+			errs = append(errs, &FieldError{
+				Field:   key.Value,
+				Message: fmt.Sprintf("unknown field (line %d)", key.Line),
+			})
+		}
+	}
+
+	var extensionMetadata ExtensionSchema
+	if err := root.Decode(&extensionMetadata); err != nil {
+		return fmt.Errorf("failed to parse metadata: %w", err)
+	}
+
+	if err := extensionMetadata.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
 func LoadRegistry(registryPath string) (*extensions.Registry, error) {
 	registryBytes, err := os.ReadFile(registryPath)
 	if err != nil {
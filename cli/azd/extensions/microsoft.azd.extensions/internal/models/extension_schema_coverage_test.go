@@ -230,3 +230,124 @@ func TestLoadRegistry_EmptyRegistry(t *testing.T) {
 	require.NotNil(t, loaded)
 	require.Empty(t, loaded.Extensions)
 }
+
+func TestValidateExtensionManifest(t *testing.T) {
+	tests := []struct {
+		name     string
+		yaml     string
+		expected []string
+	}{
+		{
+			name: "Valid",
+			yaml: `id: test.extension
+version: "1.0.0"
+displayName: Test
+description: desc
+language: go
+capabilities:
+  - custom-commands
+`,
+		},
+		{
+			name: "ValidExtensionPack",
+			yaml: `id: test.pack
+version: "1.0.0"
+displayName: Test Pack
+description: desc
+dependencies:
+  - id: test.extension
+`,
+		},
+		{
+			name: "MissingRequiredFields",
+			yaml: `namespace: test
+`,
+			expected: []string{
+				"id: is required",
+				"version: is required",
+				"displayName: is required",
+				"description: is required",
+			},
+		},
+		{
+			name: "UnknownTopLevelKeys",
+			yaml: `id: test.extension
+version: "1.0.0"
+displayName: Test
+description: desc
+entrypoint: test
+displayname: Test
+`,
+			expected: []string{
+				"entrypoint: unknown field (line 5)",
+				"displayname: unknown field (line 6)",
+			},
+		},
+		{
+			name: "MissingEntryPoint",
+			yaml: `id: test.extension
+version: "1.0.0"
+displayName: Test
+description: desc
+capabilities:
+  - custom-commands
+`,
+			expected: []string{"entryPoint: is required"},
+		},
+		{
+			name: "InvalidCapabilities",
+			yaml: `id: test.extension
+version: "1.0.0"
+displayName: Test
+description: desc
+entryPoint: test
+capabilities:
+  - custom-commands
+  - custom-commands
+  - unknown
+`,
+			expected: []string{
+				`capabilities[1]: duplicate capability "custom-commands"`,
+				`capabilities[2]: unsupported capability "unknown"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			require.NoError(t, os.WriteFile(
+				filepath.Join(tempDir, "extension.yaml"),
+				[]byte(tt.yaml),
+				0600,
+			))
+
+			err := ValidateExtensionManifest(tempDir)
+			if len(tt.expected) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			for _, expected := range tt.expected {
+				require.Contains(t, err.Error(), expected)
+			}
+
+			var fieldErr *FieldError
+			require.True(t, errors.As(err, &fieldErr))
+		})
+	}
+}
+
+func TestValidateExtensionManifest_NotMapping(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.WriteFile(
+		filepath.Join(tempDir, "extension.yaml"),
+		[]byte("- id: test.extension\n"),
+		0600,
+	))
+
+	err := ValidateExtensionManifest(tempDir)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "must contain a mapping")
+}
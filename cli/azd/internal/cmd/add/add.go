@@ -20,6 +20,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/account"
 	"github.com/azure/azure-dev/cli/azd/pkg/alpha"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra"
@@ -34,8 +35,29 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/yamlnode"
 	"github.com/braydonk/yaml"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
+type addFlags struct {
+	includePreviewModels bool
+}
+
+func (f *addFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
+	local.BoolVar(
+		&f.includePreviewModels,
+		"include-preview-models",
+		false,
+		"Includes preview AI models when selecting a model to add.",
+	)
+}
+
+func NewAddFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *addFlags {
+	flags := &addFlags{}
+	flags.Bind(cmd.Flags(), global)
+
+	return flags
+}
+
 func NewAddCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "add",
@@ -44,21 +66,24 @@ func NewAddCmd() *cobra.Command {
 }
 
 type AddAction struct {
-	azd              workflow.AzdCommandRunner
-	azdCtx           *azdcontext.AzdContext
-	env              *environment.Environment
-	envManager       environment.Manager
-	subManager       *account.SubscriptionsManager
-	alphaManager     *alpha.FeatureManager
-	creds            account.SubscriptionCredentialProvider
-	rm               infra.ResourceManager
-	resourceService  *azapi.ResourceService
-	armClientOptions *arm.ClientOptions
-	prompter         prompt.Prompter
-	console          input.Console
-	accountManager   account.Manager
-	azureClient      *azapi.AzureClient
-	importManager    *project.ImportManager
+	flags             *addFlags
+	azd               workflow.AzdCommandRunner
+	azdCtx            *azdcontext.AzdContext
+	env               *environment.Environment
+	envManager        environment.Manager
+	subManager        *account.SubscriptionsManager
+	alphaManager      *alpha.FeatureManager
+	creds             account.SubscriptionCredentialProvider
+	rm                infra.ResourceManager
+	resourceService   *azapi.ResourceService
+	armClientOptions  *arm.ClientOptions
+	prompter          prompt.Prompter
+	console           input.Console
+	accountManager    account.Manager
+	azureClient       *azapi.AzureClient
+	importManager     *project.ImportManager
+	userConfigManager config.UserConfigManager
+	menuContributor   MenuContributor
 }
 
 func (a *AddAction) Run(ctx context.Context) (*actions.ActionResult, error) {
@@ -78,7 +103,7 @@ func (a *AddAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 		return nil, err
 	}
 
-	selectMenu := a.selectMenu()
+	selectMenu := append(a.selectMenu(), a.contributedMenu()...)
 	slices.SortFunc(selectMenu, func(a, b Menu) int {
 		return strings.Compare(a.Label, b.Label)
 	})
@@ -87,38 +112,35 @@ func (a *AddAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 	for _, menu := range selectMenu {
 		selections = append(selections, menu.Label)
 	}
-	idx, err := a.console.Select(ctx, input.ConsoleOptions{
-		Message: "What would you like to add?",
-		Options: selections,
-	})
-	if err != nil {
-		return nil, err
-	}
 
-	selected := selectMenu[idx]
+	promptOpts := PromptOptions{PrjConfig: prjConfig}
 
-	resourceToAdd := &project.ResourceConfig{}
+	var resourceToAdd *project.ResourceConfig
 	var serviceToAdd *project.ServiceConfig
+	for {
+		idx, err := a.console.Select(ctx, input.ConsoleOptions{
+			Message: "What would you like to add?",
+			Options: selections,
+		})
+		if err != nil {
+			return nil, err
+		}
 
-	promptOpts := PromptOptions{PrjConfig: prjConfig}
-	r, err := selected.SelectResource(a.console, ctx, promptOpts)
-	if err != nil {
-		return nil, err
-	}
-	resourceToAdd = r
+		if entry := selectMenu[idx].Contribution; entry != nil {
+			// The contributing extension prompts for and adds its resource itself.
+			return nil, a.invokeContribution(ctx, *entry)
+		}
 
-	if strings.EqualFold(selected.Namespace, "host") {
-		svc, r, err := a.configureHost(a.console, ctx, promptOpts, r.Type)
+		resourceToAdd, serviceToAdd, err = a.selectAndConfigureLive(ctx, selectMenu[idx], promptOpts)
+		if errors.Is(err, errCatalogFetchCancelled) {
+			// the user cancelled a long-running catalog fetch, return to the menu.
+			continue
+		}
 		if err != nil {
 			return nil, err
 		}
-		serviceToAdd = svc
-		resourceToAdd = r
-	}
 
-	resourceToAdd, err = a.ConfigureLive(ctx, resourceToAdd, a.console, promptOpts)
-	if err != nil {
-		return nil, err
+		break
 	}
 
 	resourceToAdd, err = Configure(ctx, resourceToAdd, a.console, promptOpts)
@@ -385,6 +407,34 @@ func (a *AddAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 	}, err
 }
 
+// selectAndConfigureLive prompts for the resource of the selected menu entry, along with the hosting service for host
+// resources, and fills in the fields that require querying live Azure.
+func (a *AddAction) selectAndConfigureLive(
+	ctx context.Context,
+	selected Menu,
+	promptOpts PromptOptions,
+) (*project.ResourceConfig, *project.ServiceConfig, error) {
+	resourceToAdd, err := selected.SelectResource(a.console, ctx, promptOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var serviceToAdd *project.ServiceConfig
+	if strings.EqualFold(selected.Namespace, "host") {
+		serviceToAdd, resourceToAdd, err = a.configureHost(a.console, ctx, promptOpts, resourceToAdd.Type)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	resourceToAdd, err = a.ConfigureLive(ctx, resourceToAdd, a.console, promptOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resourceToAdd, serviceToAdd, nil
+}
+
 // ensureCompatibleProject checks if the project is compatible with the add command.
 // A project is incompatible if:
 // - It has an Aspire app host
@@ -471,6 +521,7 @@ func selectProvisionOptions(
 }
 
 func NewAddAction(
+	flags *addFlags,
 	azdCtx *azdcontext.AzdContext,
 	envManager environment.Manager,
 	subManager *account.SubscriptionsManager,
@@ -485,22 +536,27 @@ func NewAddAction(
 	accountManager account.Manager,
 	console input.Console,
 	azureClient *azapi.AzureClient,
-	importManager *project.ImportManager) actions.Action {
+	importManager *project.ImportManager,
+	userConfigManager config.UserConfigManager,
+	menuContributor MenuContributor) actions.Action {
 	return &AddAction{
-		azdCtx:           azdCtx,
-		console:          console,
-		envManager:       envManager,
-		subManager:       subManager,
-		alphaManager:     alphaManager,
-		env:              env,
-		prompter:         prompter,
-		rm:               rm,
-		resourceService:  resourceService,
-		armClientOptions: armClientOptions,
-		creds:            creds,
-		azd:              azd,
-		accountManager:   accountManager,
-		azureClient:      azureClient,
-		importManager:    importManager,
+		flags:             flags,
+		azdCtx:            azdCtx,
+		console:           console,
+		envManager:        envManager,
+		subManager:        subManager,
+		alphaManager:      alphaManager,
+		env:               env,
+		prompter:          prompter,
+		rm:                rm,
+		resourceService:   resourceService,
+		armClientOptions:  armClientOptions,
+		creds:             creds,
+		azd:               azd,
+		accountManager:    accountManager,
+		azureClient:       azureClient,
+		importManager:     importManager,
+		userConfigManager: userConfigManager,
+		menuContributor:   menuContributor,
 	}
 }
@@ -6,6 +6,7 @@ package add
 import (
 	"context"
 	"fmt"
+	"log"
 	"maps"
 	"slices"
 	"strings"
@@ -14,6 +15,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
 	"github.com/azure/azure-dev/cli/azd/internal/scaffold"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
+	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
 	"github.com/azure/azure-dev/cli/azd/pkg/project"
@@ -31,6 +33,19 @@ type Menu struct {
 
 	// SelectResource is the continuation that returns the resource with type filled in.
 	SelectResource resourceSelection
+
+	// Contribution is the extension entry this menu runs instead of SelectResource. It is set for entries contributed
+	// by installed extensions, which add their resources to azure.yaml themselves.
+	Contribution *extensions.AddMenuEntry
+}
+
+// MenuContributor provides the menu entries contributed by installed extensions and runs the extension behind the
+// entry the user chooses.
+type MenuContributor interface {
+	// AddMenuEntries returns the entries installed extensions contribute to the resource type menu.
+	AddMenuEntries() ([]extensions.AddMenuEntry, error)
+	// InvokeAddMenuEntry runs the extension that contributed entry, interactively, to add its resource.
+	InvokeAddMenuEntry(ctx context.Context, entry extensions.AddMenuEntry) error
 }
 
 func (a *AddAction) selectMenu() []Menu {
@@ -45,35 +60,78 @@ func (a *AddAction) selectMenu() []Menu {
 	}
 }
 
+// contributedMenu returns the menus for the entries installed extensions contribute. Entries are best effort: when
+// they cannot be listed, only the built-in menus are shown.
+func (a *AddAction) contributedMenu() []Menu {
+	if a.menuContributor == nil {
+		return nil
+	}
+
+	entries, err := a.menuContributor.AddMenuEntries()
+	if err != nil {
+		log.Printf("listing add menu entries contributed by extensions: %v", err)
+		return nil
+	}
+
+	menus := make([]Menu, 0, len(entries))
+	for _, entry := range entries {
+		menus = append(menus, Menu{
+			Namespace:    entry.Extension.Namespace,
+			Label:        entry.Label,
+			Contribution: &entry,
+		})
+	}
+
+	return menus
+}
+
+// invokeContribution runs the extension behind a contributed menu entry.
+func (a *AddAction) invokeContribution(ctx context.Context, entry extensions.AddMenuEntry) error {
+	if err := a.menuContributor.InvokeAddMenuEntry(ctx, entry); err != nil {
+		return fmt.Errorf("running extension '%s': %w", entry.Extension.Id, err)
+	}
+
+	return nil
+}
+
+// aiSelector maps an entry of the AI resource menu to the selector that produces its resource type.
+type aiSelector struct {
+	// Label displayed in the menu.
+	Label string
+	// ResourceType is the type of the resource returned by Select.
+	ResourceType project.ResourceType
+	// Select is the continuation that returns the resource with type filled in.
+	Select func(a *AddAction, console input.Console, ctx context.Context, p PromptOptions) (*project.ResourceConfig, error)
+}
+
+// aiSelectors lists the AI resource menu entries in display order. The first entry is the default selection.
+var aiSelectors = []aiSelector{
+	{Label: "Azure OpenAI model", ResourceType: project.ResourceTypeOpenAiModel, Select: (*AddAction).selectOpenAi},
+	{Label: "Azure AI services model", ResourceType: project.ResourceTypeAiProject, Select: (*AddAction).selectAiModel},
+	{Label: "Azure AI Search", ResourceType: project.ResourceTypeAiSearch, Select: (*AddAction).selectSearch},
+}
+
 func (a *AddAction) selectAiType(
 	console input.Console, ctx context.Context, p PromptOptions) (*project.ResourceConfig, error) {
-	openAiOption := "Azure OpenAI model"
-	otherAiModels := "Azure AI services model"
-	aiSearch := "Azure AI Search"
-	options := []string{
-		openAiOption,
-		otherAiModels,
-		aiSearch,
+	options := make([]string, 0, len(aiSelectors))
+	for _, selector := range aiSelectors {
+		options = append(options, selector.Label)
 	}
+
 	aiOptionIndex, err := console.Select(ctx, input.ConsoleOptions{
 		Message:      "Which type of AI resource?",
-		DefaultValue: openAiOption,
+		DefaultValue: options[0],
 		Options:      options,
 	})
 	if err != nil {
 		return nil, err
 	}
-	selectedOption := options[aiOptionIndex]
-	switch selectedOption {
-	case openAiOption:
-		return a.selectOpenAi(console, ctx, p)
-	case otherAiModels:
-		return a.selectAiModel(console, ctx, p)
-	case aiSearch:
-		return a.selectSearch(console, ctx, p)
-	default:
-		return nil, fmt.Errorf("invalid option %q", selectedOption)
+
+	if aiOptionIndex < 0 || aiOptionIndex >= len(aiSelectors) {
+		return nil, fmt.Errorf("invalid option index %d", aiOptionIndex)
 	}
+
+	return aiSelectors[aiOptionIndex].Select(a, console, ctx, p)
 }
 
 func selectDatabase(
@@ -4,6 +4,7 @@
 package add
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
@@ -13,8 +14,14 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cognitiveservices/armcognitiveservices/v2"
+	"github.com/azure/azure-dev/cli/azd/pkg/account"
+	"github.com/azure/azure-dev/cli/azd/pkg/ai"
+	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
 	"github.com/azure/azure-dev/cli/azd/pkg/azureutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
 	"github.com/azure/azure-dev/cli/azd/pkg/convert"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
@@ -23,6 +30,42 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/syncmap"
 )
 
+// aiAllowedLocationsConfigKey is the environment config path of the list of locations AI resources may be added in.
+// When set, the add flow only offers and queries those locations.
+const aiAllowedLocationsConfigKey = "ai.allowedLocations"
+
+// openAiModelSkuName and openAiModelCapacity are the SKU and capacity the scaffolded infrastructure deploys
+// ai.openai.model resources with.
+const (
+	openAiModelSkuName  = "Standard"
+	openAiModelCapacity = 20
+)
+
+// errCatalogFetchCancelled is returned when the user cancels an in-progress model catalog fetch with Ctrl+C.
+// It is not fatal: the add flow returns to the resource menu instead of exiting.
+var errCatalogFetchCancelled = errors.New("model catalog fetch cancelled")
+
+// cancellableFetchContext returns a context that is cancelled when the user presses Ctrl+C, replacing the default
+// interrupt behavior of exiting azd. The returned func restores the previous interrupt behavior and must be called
+// once the fetch completes.
+func cancellableFetchContext(ctx context.Context) (context.Context, func()) {
+	fetchCtx, cancel := context.WithCancel(ctx)
+	pop := input.PushInterruptHandler(func() bool {
+		cancel()
+		return true
+	})
+
+	return fetchCtx, func() {
+		pop()
+		cancel()
+	}
+}
+
+// fetchCancelled reports whether fetchCtx was cancelled by the user rather than by its parent.
+func fetchCancelled(ctx context.Context, fetchCtx context.Context) bool {
+	return fetchCtx.Err() != nil && ctx.Err() == nil
+}
+
 func (a *AddAction) selectSearch(
 	console input.Console,
 	ctx context.Context,
@@ -45,7 +88,7 @@ func (a *AddAction) promptOpenAi(
 	console input.Console,
 	ctx context.Context,
 	r *project.ResourceConfig,
-	_ PromptOptions) (*project.ResourceConfig, error) {
+	p PromptOptions) (*project.ResourceConfig, error) {
 	aiOption, err := console.Select(ctx, input.ConsoleOptions{
 		Message: "Which type of Azure OpenAI service?",
 		Options: []string{
@@ -55,113 +98,600 @@ func (a *AddAction) promptOpenAi(
 	if err != nil {
 		return nil, err
 	}
+	intent := []ai.ModelIntent{ai.ModelIntentChat, ai.ModelIntentEmbeddings}[aiOption]
+
+	allowedLocations, err := aiAllowedLocations(a.env)
+	if err != nil {
+		return nil, err
+	}
 
-	var allModels []ModelList
+	ensureOptions := provisioning.EnsureSubscriptionAndLocationOptions{}
+	if len(allowedLocations) > 0 {
+		ensureOptions.LocationFiler = func(loc account.Location) bool {
+			return slices.Contains(allowedLocations, loc.Name)
+		}
+
+		if location := a.env.GetLocation(); location != "" && !slices.Contains(allowedLocations, location) {
+			console.MessageUxItem(ctx, &ux.WarningMessage{
+				Description: fmt.Sprintf(
+					"%s is not in the allowed AI locations (%s) set in %s",
+					location, strings.Join(allowedLocations, ", "), aiAllowedLocationsConfigKey),
+			})
+			a.env.SetLocation("")
+		}
+	}
+
+	deployed := deployedAiModels(p.PrjConfig, project.ResourceTypeOpenAiModel)
+	statusFilter := a.modelStatusFilter()
 	for {
-		err = provisioning.EnsureSubscriptionAndLocation(
-			ctx, a.envManager, a.env, a.prompter, provisioning.EnsureSubscriptionAndLocationOptions{})
+		var allModels []ModelList
+		for {
+			err = provisioning.EnsureSubscriptionAndLocation(ctx, a.envManager, a.env, a.prompter, ensureOptions)
+			if err != nil {
+				return nil, err
+			}
+			ensureOptions.SelectDefaultLocation = nil
+
+			spinnerMessage := fmt.Sprintf("Fetching available models in %s...", a.env.GetLocation())
+			console.ShowSpinner(ctx, spinnerMessage, input.Step)
+
+			fetchCtx, done := cancellableFetchContext(ctx)
+			supportedModels, err := a.supportedModelsInLocation(
+				fetchCtx, a.env.GetSubscriptionId(), a.env.GetLocation(), statusFilter)
+			done()
+			if fetchCancelled(ctx, fetchCtx) {
+				console.StopSpinner(ctx, spinnerMessage+" cancelled", input.StepSkipped)
+				return nil, errCatalogFetchCancelled
+			}
+			if err != nil {
+				return nil, err
+			}
+			console.StopSpinner(ctx, "", input.Step)
+
+			var counts openAiModelFilterCounts
+			allModels, counts = filterOpenAiModels(supportedModels, intent, deployed)
+			log.Printf("OpenAI %s models in %s: %s", intent, a.env.GetLocation(), counts)
+			if len(allModels) > 0 {
+				break
+			}
+
+			if statusFilter == generallyAvailableModels {
+				includePreview, err := console.Confirm(ctx, input.ConsoleOptions{
+					Message: fmt.Sprintf(
+						"No generally available models found in %s. Include preview models?", a.env.GetLocation()),
+					DefaultValue: false,
+				})
+				if err != nil {
+					return nil, err
+				}
+				if includePreview {
+					statusFilter = includePreviewModels
+					continue
+				}
+			}
+
+			_, err = a.rm.FindResourceGroupForEnvironment(
+				ctx, a.env.GetSubscriptionId(), a.env.Name())
+			if _, ok := errors.AsType[*azureutil.ResourceNotFoundError](err); ok { // not yet provisioned, we're safe here
+				console.MessageUxItem(ctx, &ux.WarningMessage{
+					Description: fmt.Sprintf("No models found in %s", a.env.GetLocation()),
+				})
+				confirm, err := console.Confirm(ctx, input.ConsoleOptions{
+					Message: "Try a different location?",
+				})
+				if err != nil {
+					return nil, err
+				}
+				if confirm {
+					suggested := a.suggestAiLocation(ctx, console, "Finding a location that offers models...",
+						func(ctx context.Context, location string) (float64, error) {
+							models, err := a.supportedModelsInLocation(
+								ctx, a.env.GetSubscriptionId(), location, statusFilter)
+							if err != nil {
+								return 0, err
+							}
+							models, _ = filterOpenAiModels(models, intent, deployed)
+							return float64(len(models)), nil
+						})
+					a.env.SetLocation("")
+					if suggested != "" {
+						ensureOptions.SelectDefaultLocation = &suggested
+					}
+					continue
+				}
+			} else if err != nil {
+				return nil, fmt.Errorf("finding resource group: %w", err)
+			}
+
+			return nil, fmt.Errorf("no models found in %s", a.env.GetLocation())
+		}
+
+		allModels, err = selectModelFormat(ctx, console, allModels)
 		if err != nil {
 			return nil, err
 		}
 
-		console.ShowSpinner(
-			ctx,
-			fmt.Sprintf("Fetching available models in %s...", a.env.GetLocation()),
-			input.Step)
+		slices.SortFunc(allModels, func(a ModelList, b ModelList) int {
+			return strings.Compare(b.Model.SystemData.CreatedAt, a.Model.SystemData.CreatedAt)
+		})
+
+		displayModels := make([]string, 0, len(allModels))
+		for _, model := range allModels {
+			display := fmt.Sprintf("%s\t%s", model.Model.Name, model.Model.Version)
+			if model.Model.IsPreview() {
+				display += "\t(preview)"
+			}
+			displayModels = append(displayModels, display)
+		}
+
+		if console.IsSpinnerInteractive() {
+			displayModels, err = output.TabAlign(displayModels, 5)
+			if err != nil {
+				return nil, fmt.Errorf("writing models: %w", err)
+			}
+		}
 
-		supportedModels, err := a.supportedModelsInLocation(ctx, a.env.GetSubscriptionId(), a.env.GetLocation())
+		sel, err := console.Select(ctx, input.ConsoleOptions{
+			Message: "Select the model",
+			Options: displayModels,
+		})
 		if err != nil {
 			return nil, err
 		}
-		console.StopSpinner(ctx, "", input.Step)
 
-		for _, model := range supportedModels {
-			if model.Kind == "OpenAI" && slices.ContainsFunc(model.Model.Skus, func(sku ModelSku) bool {
-				return sku.Name == "Standard"
-			}) {
-				switch aiOption {
-				case 0:
-					if model.Model.Name == "gpt-4o" || model.Model.Name == "gpt-4" {
-						allModels = append(allModels, model)
-					}
-				case 1:
-					if strings.HasPrefix(model.Model.Name, "text-embedding") {
-						allModels = append(allModels, model)
-					}
-				}
+		selected := allModels[sel]
+		if selected.Model.IsPreview() {
+			console.MessageUxItem(ctx, &ux.WarningMessage{Description: previewModelNote(selected.Model)})
+		}
+
+		usageName := ""
+		if idx := slices.IndexFunc(selected.Model.Skus, func(sku ModelSku) bool {
+			return sku.Name == openAiModelSkuName
+		}); idx >= 0 {
+			usageName = selected.Model.Skus[idx].UsageName
+		}
+
+		chooseLocation, err := a.confirmAiModelQuota(
+			ctx, console, selected.Model.Name, usageName, openAiModelCapacity, true)
+		if err != nil {
+			return nil, err
+		}
+		if chooseLocation {
+			suggested := a.suggestAiLocation(ctx, console, "Finding a location with quota...",
+				a.remainingQuotaScore(usageName, openAiModelCapacity))
+			a.env.SetLocation("")
+			if suggested != "" {
+				ensureOptions.SelectDefaultLocation = &suggested
 			}
+			continue
+		}
 
+		r.Props = project.AIModelProps{
+			Model: project.AIModelPropsModel{
+				Name:    selected.Model.Name,
+				Version: selected.Model.Version,
+				Format:  selected.Model.Format,
+			},
 		}
-		if len(allModels) > 0 {
-			break
+
+		return r, nil
+	}
+}
+
+// openAiModelFilterCounts records how many catalog models were left after each filter filterOpenAiModels applies, in
+// order, so an empty model list can be traced back to the filter that emptied it.
+type openAiModelFilterCounts struct {
+	Catalog  int
+	Kind     int
+	Intent   int
+	Sku      int
+	Deployed int
+}
+
+// String formats the counts for debug logs, e.g. "40 in catalog, 12 of kind OpenAI, 8 with matching capabilities, 6
+// with SKU Standard, 5 not yet in the project".
+func (c openAiModelFilterCounts) String() string {
+	return fmt.Sprintf(
+		"%d in catalog, %d of kind OpenAI, %d with matching capabilities, %d with SKU %s, %d not yet in the project",
+		c.Catalog, c.Kind, c.Intent, c.Sku, openAiModelSkuName, c.Deployed)
+}
+
+// filterOpenAiModels returns the models the OpenAI add flow offers: models of kind OpenAI suited to intent, with the
+// openAiModelSkuName SKU, that are not already deployed. Azure OpenAI resources are only ever deployed with that SKU,
+// so a deployed model version is dropped whatever other SKUs it offers. It also returns how many models each filter
+// left.
+func filterOpenAiModels(
+	models []ModelList,
+	intent ai.ModelIntent,
+	deployed []deployedModel,
+) ([]ModelList, openAiModelFilterCounts) {
+	counts := openAiModelFilterCounts{Catalog: len(models)}
+
+	models = slices.DeleteFunc(slices.Clone(models), func(model ModelList) bool {
+		return model.Kind != "OpenAI"
+	})
+	counts.Kind = len(models)
+
+	models = slices.DeleteFunc(models, func(model ModelList) bool {
+		return !model.Model.MatchesIntent(intent)
+	})
+	counts.Intent = len(models)
+
+	models = slices.DeleteFunc(models, func(model ModelList) bool {
+		return !slices.ContainsFunc(model.Model.Skus, func(sku ModelSku) bool {
+			return sku.Name == openAiModelSkuName
+		})
+	})
+	counts.Sku = len(models)
+
+	models = excludeDeployedModels(models, deployed)
+	counts.Deployed = len(models)
+
+	return models, counts
+}
+
+// selectModelFormat prompts for a model format when models span more than one, and returns the models of the
+// selected format. When all models share a format, they are returned without prompting.
+func selectModelFormat(ctx context.Context, console input.Console, models []ModelList) ([]ModelList, error) {
+	var formats []string
+	for _, model := range models {
+		if !slices.Contains(formats, model.Model.Format) {
+			formats = append(formats, model.Model.Format)
 		}
+	}
 
-		_, err = a.rm.FindResourceGroupForEnvironment(
-			ctx, a.env.GetSubscriptionId(), a.env.Name())
-		if _, ok := errors.AsType[*azureutil.ResourceNotFoundError](err); ok { // not yet provisioned, we're safe here
+	if len(formats) <= 1 {
+		return models, nil
+	}
+
+	slices.Sort(formats)
+	defaultFormat := formats[0]
+	if slices.Contains(formats, "OpenAI") {
+		defaultFormat = "OpenAI"
+	}
+
+	sel, err := console.Select(ctx, input.ConsoleOptions{
+		Message:      "Which model format?",
+		Options:      formats,
+		DefaultValue: defaultFormat,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(slices.Clone(models), func(model ModelList) bool {
+		return model.Model.Format != formats[sel]
+	}), nil
+}
+
+// aiAllowedLocations returns the locations listed under aiAllowedLocationsConfigKey in the environment config, or
+// nil when the list is not set.
+func aiAllowedLocations(env *environment.Environment) ([]string, error) {
+	values, has := env.Config.GetSlice(aiAllowedLocationsConfigKey)
+	if !has {
+		return nil, nil
+	}
+
+	locations := make([]string, 0, len(values))
+	for i, value := range values {
+		location, ok := value.(string)
+		if !ok || location == "" {
+			return nil, fmt.Errorf("%s[%d] must be a location name", aiAllowedLocationsConfigKey, i)
+		}
+		locations = append(locations, location)
+	}
+
+	return locations, nil
+}
+
+// confirmAiModelQuota checks that the environment location has remaining quota for capacity under usageName. When it
+// does not, it warns and, if canChangeLocation is set and the environment is not provisioned yet, asks whether to
+// continue anyway or choose a different location. It reports whether the user chose a different location. Quota is
+// only advisory here, since it can be requested before provisioning, so failures to read it are logged and ignored;
+// only throttling is reported to the user, since rerunning may get the quota checked.
+func (a *AddAction) confirmAiModelQuota(
+	ctx context.Context,
+	console input.Console,
+	modelName string,
+	usageName string,
+	capacity int32,
+	canChangeLocation bool,
+) (bool, error) {
+	location := a.env.GetLocation()
+	if location == "" || usageName == "" {
+		return false, nil
+	}
+
+	usages, err := a.azureClient.GetAiUsages(ctx, a.env.GetSubscriptionId(), location)
+	if err != nil {
+		log.Printf("skipping quota check for %s in %s: %v", modelName, location, err)
+		if azapi.IsThrottledError(err) {
 			console.MessageUxItem(ctx, &ux.WarningMessage{
-				Description: fmt.Sprintf("No models found in %s", a.env.GetLocation()),
+				Description: fmt.Sprintf(
+					"Couldn't check quota for %s in %s due to rate limiting. Rerun to retry.", modelName, location),
 			})
-			confirm, err := console.Confirm(ctx, input.ConsoleOptions{
-				Message: "Try a different location?",
-			})
-			if err != nil {
-				return nil, err
-			}
-			if confirm {
-				a.env.SetLocation("")
-				continue
-			}
-		} else if err != nil {
-			return nil, fmt.Errorf("finding resource group: %w", err)
 		}
+		return false, nil
+	}
 
-		return nil, fmt.Errorf("no models found in %s", a.env.GetLocation())
+	remaining, ok := hasRemainingQuota(usages, usageName, capacity)
+	if ok {
+		return false, nil
 	}
 
-	slices.SortFunc(allModels, func(a ModelList, b ModelList) int {
-		return strings.Compare(b.Model.SystemData.CreatedAt, a.Model.SystemData.CreatedAt)
+	console.MessageUxItem(ctx, &ux.WarningMessage{
+		Description: fmt.Sprintf(
+			"%s has %.0f quota remaining for %s in %s, but %d is needed. Provisioning may fail until more quota "+
+				"is requested.",
+			usageName, remaining, modelName, location, capacity),
 	})
 
-	displayModels := make([]string, 0, len(allModels))
-	models := make([]Model, 0, len(allModels))
-	for _, model := range allModels {
-		models = append(models, model.Model)
-		displayModels = append(displayModels, fmt.Sprintf("%s\t%s", model.Model.Name, model.Model.Version))
+	if !canChangeLocation {
+		return false, nil
 	}
 
-	if console.IsSpinnerInteractive() {
-		displayModels, err = output.TabAlign(displayModels, 5)
-		if err != nil {
-			return nil, fmt.Errorf("writing models: %w", err)
-		}
+	provisioned, err := a.environmentProvisioned(ctx)
+	if err != nil || provisioned {
+		// An environment that is already provisioned cannot change its location.
+		return false, err
 	}
 
+	continueOption := fmt.Sprintf("Continue with %s", location)
 	sel, err := console.Select(ctx, input.ConsoleOptions{
-		Message: "Select the model",
-		Options: displayModels,
+		Message:      "How do you want to proceed?",
+		Options:      []string{continueOption, "Choose a different location"},
+		DefaultValue: continueOption,
 	})
 	if err != nil {
-		return nil, err
+		return false, err
 	}
 
-	r.Props = project.AIModelProps{
-		Model: project.AIModelPropsModel{
-			Name:    models[sel].Name,
-			Version: models[sel].Version,
-		},
+	return sel == 1, nil
+}
+
+// confirmAiModelRegion warns when modelLocations, the locations that offer modelName at modelVersion, do not include
+// the environment location the project provisions into. If the environment is not provisioned yet, it offers to align
+// the environment with one of modelLocations, saving the environment when the user does. The mismatch is only a
+// warning, so the user can keep the environment location.
+func (a *AddAction) confirmAiModelRegion(
+	ctx context.Context,
+	console input.Console,
+	modelName string,
+	modelVersion string,
+	modelLocations []string,
+) error {
+	location := a.env.GetLocation()
+	if !aiModelRegionMismatch(modelLocations, location) {
+		return nil
 	}
 
-	return r, nil
+	console.MessageUxItem(ctx, &ux.WarningMessage{
+		Description: fmt.Sprintf(
+			"%s %s is not offered in %s, where this project provisions its resources. Provisioning may fail "+
+				"unless the model is deployed to a location that offers it.",
+			modelName, modelVersion, location),
+	})
+
+	provisioned, err := a.environmentProvisioned(ctx)
+	if err != nil || provisioned {
+		return err
+	}
+
+	continueOption := fmt.Sprintf("Continue with %s", location)
+	sel, err := console.Select(ctx, input.ConsoleOptions{
+		Message:      "How do you want to proceed?",
+		Options:      []string{continueOption, "Change the environment location to match the model"},
+		DefaultValue: continueOption,
+	})
+	if err != nil || sel == 0 {
+		return err
+	}
+
+	return a.selectAiModelLocation(ctx, console, modelName, modelVersion, modelLocations)
+}
+
+// selectAiModelLocation asks the user to select one of locations, which offer modelName at modelVersion, and saves it
+// as the environment location.
+func (a *AddAction) selectAiModelLocation(
+	ctx context.Context,
+	console input.Console,
+	modelName string,
+	modelVersion string,
+	locations []string,
+) error {
+	locations = slices.Sorted(slices.Values(locations))
+	sel, err := console.Select(ctx, input.ConsoleOptions{
+		Message: fmt.Sprintf("Select a location that offers %s %s", modelName, modelVersion),
+		Options: locations,
+	})
+	if err != nil {
+		return err
+	}
+
+	a.env.SetLocation(locations[sel])
+	if err := a.envManager.Save(ctx, a.env); err != nil {
+		return fmt.Errorf("saving environment: %w", err)
+	}
+
+	return nil
+}
+
+// aiModelRegionMismatch reports whether a model offered in modelLocations is unavailable in envLocation. An unset
+// environment location, or a model without location data, is not a mismatch.
+func aiModelRegionMismatch(modelLocations []string, envLocation string) bool {
+	return envLocation != "" && len(modelLocations) > 0 && !slices.Contains(modelLocations, envLocation)
+}
+
+// environmentProvisioned reports whether the environment already has a resource group in its subscription.
+func (a *AddAction) environmentProvisioned(ctx context.Context) (bool, error) {
+	_, err := a.rm.FindResourceGroupForEnvironment(ctx, a.env.GetSubscriptionId(), a.env.Name())
+	if _, notProvisioned := errors.AsType[*azureutil.ResourceNotFoundError](err); notProvisioned {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("finding resource group: %w", err)
+	}
+
+	return true, nil
+}
+
+// hasRemainingQuota reports whether usages leave at least capacity remaining under usageName, along with the remaining
+// quota. A usage name without usage data is treated as having enough quota.
+func hasRemainingQuota(usages []*armcognitiveservices.Usage, usageName string, capacity int32) (float64, bool) {
+	for _, usage := range usages {
+		if usage.Name == nil || usage.Name.Value == nil || *usage.Name.Value != usageName {
+			continue
+		}
+
+		remaining := convert.ToValueWithDefault(usage.Limit, 0) - convert.ToValueWithDefault(usage.CurrentValue, 0)
+		return remaining, remaining >= float64(capacity)
+	}
+
+	return 0, true
+}
+
+// maxConcurrentLocationScores caps the locations suggestAiLocation scores at once.
+const maxConcurrentLocationScores = 8
+
+// suggestAiLocation returns the AI catalog location that supports AI Services, other than the environment location,
+// with the highest score, or an empty string when no location scores above zero. Ties go to the first location by
+// name. Locations are scored concurrently, at most maxConcurrentLocationScores at a time, while a spinner shows
+// message; locations that fail to score are skipped. Suggestions are only advisory, so failures are logged and yield no
+// suggestion.
+func (a *AddAction) suggestAiLocation(
+	ctx context.Context,
+	console input.Console,
+	message string,
+	score func(ctx context.Context, location string) (float64, error),
+) string {
+	fetchCtx, done := cancellableFetchContext(ctx)
+	defer done()
+
+	console.ShowSpinner(ctx, message, input.Step)
+	defer console.StopSpinner(ctx, "", input.Step)
+
+	locations, err := a.aiCatalogLocations(fetchCtx, a.env.GetSubscriptionId())
+	if err != nil {
+		log.Printf("listing locations to suggest: %v", err)
+		return ""
+	}
+	aiServicesLocations, err := a.azureClient.GetResourceSkuLocations(
+		fetchCtx, a.env.GetSubscriptionId(), "AIServices", "S0", "Standard", "accounts")
+	if err != nil {
+		log.Printf("listing AI Services locations to suggest: %v", err)
+		return ""
+	}
+	locations = slices.DeleteFunc(locations, func(location string) bool {
+		return location == a.env.GetLocation() || !slices.Contains(aiServicesLocations, location)
+	})
+	if len(locations) == 0 {
+		return ""
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentLocationScores)
+	scores := map[string]float64{}
+	for _, location := range locations {
+		wg.Go(func() {
+			select {
+			case sem <- struct{}{}:
+			case <-fetchCtx.Done():
+				return
+			}
+			defer func() { <-sem }()
+
+			value, err := score(fetchCtx, location)
+			if err != nil {
+				log.Printf("scoring location %s: %v", location, err)
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			scores[location] = value
+		})
+	}
+	wg.Wait()
+
+	return bestAiLocation(scores)
+}
+
+// bestAiLocation returns the location with the highest score above zero, preferring the first by name on ties, or an
+// empty string when no location scores above zero.
+func bestAiLocation(scores map[string]float64) string {
+	best := ""
+	for _, location := range slices.Sorted(maps.Keys(scores)) {
+		if scores[location] > 0 && (best == "" || scores[location] > scores[best]) {
+			best = location
+		}
+	}
+
+	return best
 }
 
-func (a *AddAction) supportedModelsInLocation(ctx context.Context, subId, location string) ([]ModelList, error) {
+// remainingQuotaScore scores a location by its remaining quota under usageName, or zero when it cannot fit capacity or
+// reports no usage for usageName.
+func (a *AddAction) remainingQuotaScore(
+	usageName string,
+	capacity int32,
+) func(ctx context.Context, location string) (float64, error) {
+	return func(ctx context.Context, location string) (float64, error) {
+		usages, err := a.azureClient.GetAiUsages(ctx, a.env.GetSubscriptionId(), location)
+		if err != nil {
+			return 0, err
+		}
+
+		if remaining, ok := hasRemainingQuota(usages, usageName, capacity); ok {
+			return remaining, nil
+		}
+		return 0, nil
+	}
+}
+
+// aiModelStatusFilter selects the lifecycle statuses of the model versions the add flow offers.
+type aiModelStatusFilter int
+
+const (
+	// generallyAvailableModels leaves out model versions in preview.
+	generallyAvailableModels aiModelStatusFilter = iota
+	// includePreviewModels offers model versions in preview as well.
+	includePreviewModels
+)
+
+// modelStatusFilter returns the status filter the add flow starts with, based on --include-preview-models.
+func (a *AddAction) modelStatusFilter() aiModelStatusFilter {
+	if a.flags != nil && a.flags.includePreviewModels {
+		return includePreviewModels
+	}
+
+	return generallyAvailableModels
+}
+
+func (a *AddAction) supportedModelsInLocation(
+	ctx context.Context, subId, location string, statusFilter aiModelStatusFilter,
+) ([]ModelList, error) {
 	models, err := a.azureClient.GetAiModels(ctx, subId, location)
 	if err != nil {
 		return nil, fmt.Errorf("getting models: %w", err)
 	}
+
+	return modelListFromArm(models, statusFilter), nil
+}
+
+// modelListFromArm converts the catalog models of a location, leaving out preview model versions unless statusFilter
+// includes them.
+func modelListFromArm(models []*armcognitiveservices.Model, statusFilter aiModelStatusFilter) []ModelList {
 	var modelList []ModelList
 	for _, model := range models {
+		lifecycleStatus := ""
+		if model.Model.LifecycleStatus != nil {
+			lifecycleStatus = string(*model.Model.LifecycleStatus)
+		}
+		if statusFilter == generallyAvailableModels && (Model{LifecycleStatus: lifecycleStatus}).IsPreview() {
+			continue
+		}
+
 		var skus []ModelSku
 		for _, sku := range model.Model.SKUs {
 			skus = append(skus, ModelSku{
@@ -186,10 +716,12 @@ func (a *AddAction) supportedModelsInLocation(ctx context.Context, subId, locati
 				},
 				Format:           *model.Model.Format,
 				IsDefaultVersion: *model.Model.IsDefaultVersion,
+				Capabilities:     slices.Sorted(maps.Keys(model.Model.Capabilities)),
+				LifecycleStatus:  lifecycleStatus,
 			},
 		})
 	}
-	return modelList, nil
+	return modelList
 }
 
 type ModelResponse struct {
@@ -209,6 +741,23 @@ type Model struct {
 	SystemData       ModelSystemData `json:"systemData"`
 	Format           string          `json:"format"`
 	IsDefaultVersion bool            `json:"isDefaultVersion"`
+	Capabilities     []string        `json:"capabilities"`
+	LifecycleStatus  string          `json:"lifecycleStatus"`
+}
+
+// IsPreview reports whether the model version is in preview.
+func (m Model) IsPreview() bool {
+	return strings.EqualFold(m.LifecycleStatus, string(armcognitiveservices.ModelLifecycleStatusPreview))
+}
+
+// previewModelNote returns the note printed when a preview model version is selected.
+func previewModelNote(m Model) string {
+	return fmt.Sprintf("%s %s is a preview model. It may change or be removed.", m.Name, m.Version)
+}
+
+// MatchesIntent reports whether the model is suited to the given use.
+func (m Model) MatchesIntent(intent ai.ModelIntent) bool {
+	return intent.Matches(m.Capabilities)
 }
 
 type ModelSku struct {
@@ -217,6 +766,18 @@ type ModelSku struct {
 	Capacity  ModelSkuCapacity `json:"capacity"`
 }
 
+// aiSku returns the SKU with the capacity constraints the ai package validates capacities against.
+func (s ModelSku) aiSku() ai.AiModelSku {
+	return ai.AiModelSku{
+		Name:            s.Name,
+		UsageName:       s.UsageName,
+		DefaultCapacity: s.Capacity.Default,
+		MinCapacity:     s.Capacity.Minimum,
+		MaxCapacity:     s.Capacity.Maximum,
+		CapacityStep:    s.Capacity.Step,
+	}
+}
+
 type ModelSkuCapacity struct {
 	Maximum int32 `json:"maximum"`
 	Minimum int32 `json:"minimum"`
@@ -257,7 +818,12 @@ func (a *AddAction) promptAiModel(
 		}
 	}
 
-	modelCatalog, err := a.aiDeploymentCatalog(ctx, a.env.GetSubscriptionId(), aiProject.Models)
+	modelCatalog, err := a.aiDeploymentCatalog(
+		ctx,
+		a.env.GetSubscriptionId(),
+		deployedAiModels(p.PrjConfig, project.ResourceTypeAiProject),
+		a.modelStatusFilter(),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -271,32 +837,129 @@ func (a *AddAction) promptAiModel(
 		return nil, err
 	}
 
-	modelVersionSelection, modelDefinition, err := selectFromMap(
-		ctx, console, "Which model version do you want to use?", k.Versions, nil /*defVersion*/)
+	var defaultVersionOption *string
+	defaultVersion := defaultCatalogVersion(k.Versions)
+	if defaultVersion != "" {
+		defaultVersionOption = &defaultVersion
+	}
+	var modelVersionSelection string
+	var modelDefinition ModelCatalog
+	for {
+		modelVersionSelection, modelDefinition, err = selectFromMapWithDetails(
+			ctx, console, "Which model version do you want to use?", k.Versions, defaultVersionOption,
+			modelCatalogDetails)
+		if err != nil {
+			return nil, err
+		}
+
+		confirmed, err := confirmNonDefaultVersion(
+			ctx, console, modelNameSelection, modelVersionSelection, defaultVersion)
+		if err != nil {
+			return nil, err
+		}
+		if confirmed {
+			break
+		}
+	}
+	if modelDefinition.Model.IsPreview() {
+		console.MessageUxItem(ctx, &ux.WarningMessage{Description: previewModelNote(modelDefinition.Model)})
+	}
+	skus := slices.Clone(modelDefinition.Model.Skus)
+	ai.SortSkusByPreference(skus, a.preferredAiSkus(p.PrjConfig), func(sku ModelSku) string { return sku.Name })
+	skuSelection, capacity, err := selectSkuAndCapacity(ctx, console, modelNameSelection, skus)
 	if err != nil {
 		return nil, err
 	}
-	skuSelection, err := selectFromSkus(ctx, console, "Select model SKU", modelDefinition.Model.Skus)
+
+	err = a.confirmAiModelRegion(ctx, console, modelNameSelection, modelVersionSelection, modelDefinition.Locations)
 	if err != nil {
 		return nil, err
 	}
 
-	aiProject.Models = append(aiProject.Models, project.AiServicesModel{
-		Name:    modelNameSelection,
-		Version: modelVersionSelection,
-		Format:  modelDefinition.Model.Format,
-		Sku: project.AiServicesModelSku{
+	for {
+		otherLocations := slices.DeleteFunc(slices.Clone(modelDefinition.Locations), func(location string) bool {
+			return location == a.env.GetLocation()
+		})
+		chooseLocation, err := a.confirmAiModelQuota(
+			ctx,
+			console,
+			modelNameSelection,
+			skuSelection.UsageName,
+			capacity,
+			len(otherLocations) > 0,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if !chooseLocation {
+			break
+		}
+
+		err = a.selectAiModelLocation(ctx, console, modelNameSelection, modelVersionSelection, otherLocations)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	aiProject.Models = append(aiProject.Models, project.NewAiServicesModel(ai.AiModelDeployment{
+		ModelName: modelNameSelection,
+		Format:    modelDefinition.Model.Format,
+		Version:   modelVersionSelection,
+		Location:  a.env.GetLocation(),
+		Sku: ai.AiModelSku{
 			Name:      skuSelection.Name,
 			UsageName: skuSelection.UsageName,
-			Capacity:  skuSelection.Capacity.Default,
 		},
-	})
+		Capacity: capacity,
+	}))
 	r.Props = aiProject
 	return r, nil
 }
 
+// defaultCatalogVersion returns the version marked as default among versions, or "" when none is.
+func defaultCatalogVersion(versions map[string]ModelCatalog) string {
+	for _, version := range slices.Sorted(maps.Keys(versions)) {
+		if versions[version].Model.IsDefaultVersion {
+			return version
+		}
+	}
+
+	return ""
+}
+
+// confirmNonDefaultVersion warns when version of model was selected rather than its default version and asks whether
+// to continue with it, defaulting to yes so that pinning an older version stays a single keypress. It reports true
+// without asking when version is the default or there is no default version.
+func confirmNonDefaultVersion(
+	ctx context.Context, console input.Console, model string, version string, defaultVersion string,
+) (bool, error) {
+	warning := ai.NonDefaultVersionWarning(model, version, defaultVersion)
+	if warning == "" {
+		return true, nil
+	}
+
+	console.MessageUxItem(ctx, &ux.WarningMessage{Description: warning})
+	return console.Confirm(ctx, input.ConsoleOptions{
+		Message:      fmt.Sprintf("Continue with version %s?", version),
+		Help:         "Choose no to select a different version.",
+		DefaultValue: true,
+	})
+}
+
 func selectFromMap[T any](
 	ctx context.Context, console input.Console, q string, m map[string]T, defaultOpt *string) (string, T, error) {
+	return selectFromMapWithDetails(ctx, console, q, m, defaultOpt, nil)
+}
+
+// selectFromMapWithDetails is selectFromMap, showing details(value) next to each option when details is not nil.
+func selectFromMapWithDetails[T any](
+	ctx context.Context,
+	console input.Console,
+	q string,
+	m map[string]T,
+	defaultOpt *string,
+	details func(T) string,
+) (string, T, error) {
 	mIterator := maps.Keys(m)
 	var options []string
 	var value T
@@ -312,10 +975,17 @@ func selectFromMap[T any](
 		defOpt = *defaultOpt
 	}
 	slices.Sort(options)
+	var optionDetails []string
+	if details != nil {
+		for _, option := range options {
+			optionDetails = append(optionDetails, details(m[option]))
+		}
+	}
 	selectedIndex, err := console.Select(ctx, input.ConsoleOptions{
-		Message:      q,
-		Options:      options,
-		DefaultValue: defOpt,
+		Message:       q,
+		Options:       options,
+		OptionDetails: optionDetails,
+		DefaultValue:  defOpt,
 	})
 	if err != nil {
 		return "", value, err
@@ -324,6 +994,36 @@ func selectFromMap[T any](
 	return key, m[key], nil
 }
 
+// modelCatalogDetails labels preview model versions in the model version selection.
+func modelCatalogDetails(model ModelCatalog) string {
+	if model.Model.IsPreview() {
+		return "preview"
+	}
+
+	return ""
+}
+
+// preferredAiSkus returns the SKU order the add flow offers model SKUs in: preferredAiSkus from azure.yaml, then the
+// ai.preferredSkus user config, then GlobalStandard and Standard.
+func (a *AddAction) preferredAiSkus(prjConfig *project.ProjectConfig) []string {
+	var userConfig config.Config
+	if a.userConfigManager != nil {
+		loaded, err := a.userConfigManager.Load()
+		if err != nil {
+			log.Printf("loading user config for preferred AI SKUs: %v", err)
+		} else {
+			userConfig = loaded
+		}
+	}
+
+	var projectSkus []string
+	if prjConfig != nil {
+		projectSkus = prjConfig.PreferredAiSkus
+	}
+
+	return ai.PreferredSkus(projectSkus, userConfig)
+}
+
 func selectFromSkus(ctx context.Context, console input.Console, q string, s []ModelSku) (ModelSku, error) {
 	var sku ModelSku
 	if len(s) == 0 {
@@ -333,13 +1033,17 @@ func selectFromSkus(ctx context.Context, console input.Console, q string, s []Mo
 		return s[0], nil
 	}
 	var options []string
+	var details []string
 	for _, option := range s {
 		options = append(options, option.Name)
+		details = append(details, skuDescription(option.Name))
 	}
 	selectedIndex, err := console.Select(ctx, input.ConsoleOptions{
-		Message:      q,
-		Options:      options,
-		DefaultValue: options[0],
+		Message:       q,
+		Help:          skuHelp(s),
+		Options:       options,
+		OptionDetails: details,
+		DefaultValue:  options[0],
 	})
 	if err != nil {
 		return sku, err
@@ -347,22 +1051,155 @@ func selectFromSkus(ctx context.Context, console input.Console, q string, s []Mo
 	return s[selectedIndex], nil
 }
 
-func (a *AddAction) aiDeploymentCatalog(
-	ctx context.Context, subId string, excludeModels []project.AiServicesModel) (map[string]ModelCatalogKind, error) {
+// selectSkuAndCapacity prompts for one of skus, then for a deployment capacity of modelName that satisfies the
+// selected SKU's min, max and step constraints, defaulting to the SKU default.
+func selectSkuAndCapacity(
+	ctx context.Context,
+	console input.Console,
+	modelName string,
+	skus []ModelSku,
+) (ModelSku, int32, error) {
+	sku, err := selectFromSkus(ctx, console, "Select model SKU", skus)
+	if err != nil {
+		return sku, 0, err
+	}
+
+	capacity, err := promptSkuCapacity(ctx, console, modelName, sku)
+	if err != nil {
+		return sku, 0, err
+	}
+
+	return sku, capacity, nil
+}
+
+// promptSkuCapacity prompts for a deployment capacity of modelName until the value satisfies the constraints of sku.
+// The SKU default is offered when it is itself valid.
+func promptSkuCapacity(ctx context.Context, console input.Console, modelName string, sku ModelSku) (int32, error) {
+	aiSku := sku.aiSku()
+
+	defaultValue := ""
+	if ai.ValidateCapacity(aiSku, sku.Capacity.Default) == nil {
+		defaultValue = fmt.Sprint(sku.Capacity.Default)
+	}
+
+	help := ""
+	if description := ai.CapacityConstraintDescription(aiSku); description != "" {
+		help = fmt.Sprintf("Capacity must be %s.", description)
+	}
+
+	for {
+		value, err := console.Prompt(ctx, input.ConsoleOptions{
+			Message:      fmt.Sprintf("Enter deployment capacity for %s (%s)", modelName, sku.Name),
+			Help:         help,
+			DefaultValue: defaultValue,
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		capacity, err := ai.ParseCapacity(aiSku, value)
+		if err != nil {
+			console.Message(ctx, err.Error())
+			continue
+		}
+
+		return capacity, nil
+	}
+}
+
+// skuDescriptions explains the deployment SKUs offered when adding a model, keyed by SKU name.
+var skuDescriptions = map[string]string{
+	"GlobalStandard":             "pay per token, processed in any Azure region with capacity",
+	"GlobalBatch":                "discounted pay per token for asynchronous jobs, processed in any Azure region",
+	"GlobalProvisionedManaged":   "reserved throughput (PTU), processed in any Azure region with capacity",
+	"DataZoneStandard":           "pay per token, processed within the data zone (US or EU) of the resource",
+	"DataZoneBatch":              "discounted pay per token for asynchronous jobs, processed within the data zone",
+	"DataZoneProvisionedManaged": "reserved throughput (PTU), processed within the data zone of the resource",
+	"Standard":                   "pay per token, processed in the region of the resource",
+	"ProvisionedManaged":         "reserved throughput (PTU), processed in the region of the resource",
+	"DeveloperTier":              "low-cost pay per token for evaluating fine-tuned models, without an SLA",
+}
+
+// skuDescription returns the one-line explanation of the SKU named skuName, or an empty string when the SKU is not
+// known.
+func skuDescription(skuName string) string {
+	return skuDescriptions[skuName]
+}
+
+// skuHelp returns a help message explaining each known SKU of skus, one per line, or an empty string when none of
+// them is known.
+func skuHelp(skus []ModelSku) string {
+	var lines []string
+	for _, sku := range skus {
+		if description := skuDescription(sku.Name); description != "" {
+			lines = append(lines, fmt.Sprintf("%s: %s", sku.Name, description))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// aiCatalogLocations returns the locations aiDeploymentCatalog queries: the subscription locations, narrowed to the
+// allowed locations when they are configured. A single allowed location is returned as-is, without listing the
+// subscription locations.
+func (a *AddAction) aiCatalogLocations(ctx context.Context, subId string) ([]string, error) {
+	allowedLocations, err := aiAllowedLocations(a.env)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(allowedLocations) == 1 {
+		return allowedLocations, nil
+	}
+
 	allLocations, err := a.accountManager.GetLocations(ctx, subId)
 	if err != nil {
 		return nil, fmt.Errorf("getting locations: %w", err)
 	}
 
+	var locations []string
+	for _, location := range allLocations {
+		if len(allowedLocations) == 0 || slices.Contains(allowedLocations, location.Name) {
+			locations = append(locations, location.Name)
+		}
+	}
+
+	return locations, nil
+}
+
+// aiDeploymentCatalog returns the models offered to an AI project across the allowed locations, keyed by model name,
+// kind and version. Model versions in deployed are excluded.
+func (a *AddAction) aiDeploymentCatalog(
+	ctx context.Context,
+	subId string,
+	deployed []deployedModel,
+	statusFilter aiModelStatusFilter,
+) (map[string]ModelCatalogKind, error) {
+	fetchCtx, done := cancellableFetchContext(ctx)
+	defer done()
+
+	locations, err := a.aiCatalogLocations(fetchCtx, subId)
+	if fetchCancelled(ctx, fetchCtx) {
+		return nil, errCatalogFetchCancelled
+	}
+	if err != nil {
+		return nil, err
+	}
+
 	var sharedResults syncmap.Map[string, []ModelList]
 	var wg sync.WaitGroup
+	// With a single location there is nothing to fall back to, so its error is reported instead of skipped.
+	var singleLocationErr error
 
 	a.console.ShowSpinner(ctx, "Retrieving available models...", input.Step)
 
-	for _, location := range allLocations {
-		location := location.Name
+	for _, location := range locations {
 		wg.Go(func() {
-			results, err := a.supportedModelsInLocation(ctx, subId, location)
+			results, err := a.supportedModelsInLocation(fetchCtx, subId, location, statusFilter)
+			if err != nil && len(locations) == 1 {
+				singleLocationErr = fmt.Errorf("getting models in location %s: %w", location, err)
+				return
+			}
 			if err != nil {
 				// log the error and continue. Do not fail the entire operation when pulling location error
 				log.Println("error getting models in location", location, ":", err, "skipping")
@@ -389,28 +1226,31 @@ func (a *AddAction) aiDeploymentCatalog(
 		})
 	}
 	wg.Wait()
+
+	if fetchCancelled(ctx, fetchCtx) {
+		a.console.StopSpinner(ctx, "Retrieving available models... cancelled", input.StepSkipped)
+		return nil, errCatalogFetchCancelled
+	}
+	if singleLocationErr == nil && len(locations) == 1 {
+		if models, _ := sharedResults.Load(locations[0]); len(models) == 0 {
+			singleLocationErr = fmt.Errorf("no models are available in location %s", locations[0])
+		}
+	}
+	if singleLocationErr != nil {
+		a.console.StopSpinner(ctx, "Retrieving available models", input.StepFailed)
+		return nil, singleLocationErr
+	}
 	a.console.StopSpinner(ctx, "", input.StepDone)
 
 	combinedResults := map[string]ModelCatalogKind{}
 	sharedResults.Range(func(locationNameKey string, models []ModelList) bool {
-		for _, model := range models {
+		// remove models which have been added to the project already
+		for _, model := range excludeDeployedModels(models, deployed) {
 			if model.Kind == "OpenAI" {
 				// OpenAI kind is part of the `Add OpenAI` where clients connect directly to the service w/o an AIProject
 				continue
 			}
 			nameKey := model.Model.Name
-			// check if model is in the exclude list
-			if slices.ContainsFunc(excludeModels, func(m project.AiServicesModel) bool {
-				return nameKey == m.Name &&
-					model.Model.Format == m.Format &&
-					model.Model.Version == m.Version &&
-					slices.ContainsFunc(model.Model.Skus, func(sku ModelSku) bool { return sku.Name == m.Sku.Name })
-			}) {
-				// skip this model as it is in the exclude list
-				// exclude list is used to remove models which might have been added to the project already
-				// This validation is also blocking adding same model with different sku
-				continue
-			}
 			kindKey := model.Kind
 			versionKey := model.Model.Version
 			modelKey, exists := combinedResults[nameKey]
@@ -465,6 +1305,71 @@ func (a *AddAction) aiDeploymentCatalog(
 	return combinedResults, nil
 }
 
+// deployedModel is a model version that is already configured in the project.
+type deployedModel struct {
+	Name    string
+	Format  string
+	Version string
+}
+
+// matches reports whether model is the same model version as d. A deployed model without a format is an OpenAI
+// model, as in the project configuration.
+func (d deployedModel) matches(model Model) bool {
+	return d.Name == model.Name && d.Version == model.Version && cmp.Or(d.Format, "OpenAI") == model.Format
+}
+
+// deployedAiModels returns the model versions configured by the resources of resourceType in prjConfig: the models
+// of the AI project for ResourceTypeAiProject, and the models of the Azure OpenAI resources for
+// ResourceTypeOpenAiModel.
+func deployedAiModels(prjConfig *project.ProjectConfig, resourceType project.ResourceType) []deployedModel {
+	if prjConfig == nil {
+		return nil
+	}
+
+	var deployed []deployedModel
+	for _, resource := range prjConfig.Resources {
+		if resource.Type != resourceType {
+			continue
+		}
+
+		switch props := resource.Props.(type) {
+		case project.AiFoundryModelProps:
+			for _, m := range props.Models {
+				deployed = append(deployed, deployedModel{
+					Name:    m.Name,
+					Format:  m.Format,
+					Version: m.Version,
+				})
+			}
+		case project.AIModelProps:
+			deployed = append(deployed, deployedModel{
+				Name:    props.Model.Name,
+				Format:  props.Model.Format,
+				Version: props.Model.Version,
+			})
+		}
+	}
+
+	return deployed
+}
+
+// excludeDeployedModels returns models without the model versions in deployed, matched by name, format and version
+// whatever the SKU they are deployed with. models is not modified.
+func excludeDeployedModels(models []ModelList, deployed []deployedModel) []ModelList {
+	if len(deployed) == 0 {
+		return models
+	}
+
+	result := make([]ModelList, 0, len(models))
+	for _, model := range models {
+		if !slices.ContainsFunc(deployed, func(d deployedModel) bool { return d.matches(model.Model) }) {
+			result = append(result, model)
+		}
+	}
+
+	return result
+}
+
 type ModelCatalog struct {
 	ModelList
 	Locations []string
@@ -4,12 +4,29 @@
 package add
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cognitiveservices/armcognitiveservices/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/azure/azure-dev/cli/azd/pkg/account"
+	"github.com/azure/azure-dev/cli/azd/pkg/ai"
+	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockaccount"
 )
 
 func TestSelectFromMap_MultipleOptions(t *testing.T) {
@@ -52,6 +69,56 @@ func TestSelectFromMap_SelectError(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestDefaultCatalogVersion(t *testing.T) {
+	t.Parallel()
+
+	versions := map[string]ModelCatalog{
+		"2024-05-13": {},
+		"2024-08-06": {ModelList: ModelList{Model: Model{IsDefaultVersion: true}}},
+	}
+	assert.Equal(t, "2024-08-06", defaultCatalogVersion(versions))
+	assert.Equal(t, "", defaultCatalogVersion(map[string]ModelCatalog{"2024-05-13": {}}))
+}
+
+func TestConfirmNonDefaultVersion(t *testing.T) {
+	t.Parallel()
+
+	t.Run("DefaultVersion", func(t *testing.T) {
+		t.Parallel()
+		c := newTestConsole()
+		confirmed, err := confirmNonDefaultVersion(t.Context(), c, "gpt-4o", "2024-08-06", "2024-08-06")
+		require.NoError(t, err)
+		assert.True(t, confirmed)
+		assert.Empty(t, c.Output())
+	})
+
+	t.Run("NoDefaultVersion", func(t *testing.T) {
+		t.Parallel()
+		c := newTestConsole()
+		confirmed, err := confirmNonDefaultVersion(t.Context(), c, "gpt-4o", "2024-05-13", "")
+		require.NoError(t, err)
+		assert.True(t, confirmed)
+	})
+
+	for _, answer := range []bool{true, false} {
+		t.Run(fmt.Sprintf("NonDefaultVersion_%t", answer), func(t *testing.T) {
+			t.Parallel()
+			c := newTestConsole()
+			c.WhenConfirm(func(opts input.ConsoleOptions) bool {
+				return opts.Message == "Continue with version 2024-05-13?"
+			}).RespondFn(func(opts input.ConsoleOptions) (any, error) {
+				assert.Equal(t, true, opts.DefaultValue)
+				return answer, nil
+			})
+
+			confirmed, err := confirmNonDefaultVersion(t.Context(), c, "gpt-4o", "2024-05-13", "2024-08-06")
+			require.NoError(t, err)
+			assert.Equal(t, answer, confirmed)
+			assert.Contains(t, strings.Join(c.Output(), "\n"), "the default version is 2024-08-06")
+		})
+	}
+}
+
 func TestSelectFromSkus_Multiple(t *testing.T) {
 	t.Parallel()
 	c := newTestConsole()
@@ -65,6 +132,103 @@ func TestSelectFromSkus_Multiple(t *testing.T) {
 	assert.Equal(t, "Premium", got.Name)
 }
 
+func TestSelectFromSkus_Descriptions(t *testing.T) {
+	t.Parallel()
+	c := newTestConsole()
+	var got input.ConsoleOptions
+	c.WhenSelect(func(options input.ConsoleOptions) bool {
+		got = options
+		return true
+	}).Respond(0)
+	skus := []ModelSku{
+		{Name: "GlobalStandard"},
+		{Name: "CustomSku"},
+	}
+	_, err := selectFromSkus(t.Context(), c, "q", skus)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"GlobalStandard", "CustomSku"}, got.Options)
+	assert.Equal(t, []string{skuDescriptions["GlobalStandard"], ""}, got.OptionDetails)
+	assert.Equal(t, "GlobalStandard: "+skuDescriptions["GlobalStandard"], got.Help)
+}
+
+func TestSelectSkuAndCapacity(t *testing.T) {
+	t.Parallel()
+	skus := []ModelSku{
+		{Name: "GlobalStandard", Capacity: ModelSkuCapacity{Minimum: 1, Maximum: 1000, Step: 1, Default: 10}},
+		{Name: "ProvisionedManaged", Capacity: ModelSkuCapacity{Minimum: 15, Maximum: 100, Step: 5, Default: 15}},
+	}
+
+	c := newTestConsole()
+	c.WhenSelect(func(input.ConsoleOptions) bool { return true }).Respond(1)
+	var prompts []input.ConsoleOptions
+	answers := []string{"10", "17", "120", "20"}
+	c.WhenPrompt(func(input.ConsoleOptions) bool { return true }).
+		RespondFn(func(options input.ConsoleOptions) (any, error) {
+			prompts = append(prompts, options)
+			return answers[len(prompts)-1], nil
+		})
+
+	sku, capacity, err := selectSkuAndCapacity(t.Context(), c, "gpt-4o", skus)
+	require.NoError(t, err)
+	assert.Equal(t, "ProvisionedManaged", sku.Name)
+	assert.Equal(t, int32(20), capacity)
+
+	require.Len(t, prompts, 4)
+	assert.Equal(t, "Enter deployment capacity for gpt-4o (ProvisionedManaged)", prompts[0].Message)
+	assert.Equal(t, "15", prompts[0].DefaultValue)
+	assert.Equal(t, "Capacity must be between 15 and 100 in steps of 5.", prompts[0].Help)
+	// Below the minimum, off step and above the maximum are each rejected with the SKU's constraints.
+	rejections := slices.DeleteFunc(c.Output(), func(line string) bool {
+		return line != "capacity must be between 15 and 100 in steps of 5"
+	})
+	assert.Len(t, rejections, 3)
+}
+
+func TestPromptSkuCapacity_InvalidDefault(t *testing.T) {
+	t.Parallel()
+	c := newTestConsole()
+	var got input.ConsoleOptions
+	c.WhenPrompt(func(options input.ConsoleOptions) bool {
+		got = options
+		return true
+	}).Respond("50")
+
+	// A default below the minimum is not offered.
+	sku := ModelSku{Name: "ProvisionedManaged", Capacity: ModelSkuCapacity{Minimum: 15, Step: 5}}
+	capacity, err := promptSkuCapacity(t.Context(), c, "gpt-4o", sku)
+	require.NoError(t, err)
+	assert.Equal(t, int32(50), capacity)
+	assert.Equal(t, "", got.DefaultValue)
+}
+
+func TestSkuDescription(t *testing.T) {
+	tests := []struct {
+		sku   string
+		known bool
+	}{
+		{sku: "GlobalStandard", known: true},
+		{sku: "DataZoneStandard", known: true},
+		{sku: "Standard", known: true},
+		{sku: "ProvisionedManaged", known: true},
+		{sku: "GlobalProvisionedManaged", known: true},
+		{sku: "standard", known: false},
+		{sku: "CustomSku", known: false},
+		{sku: "", known: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sku, func(t *testing.T) {
+			assert.Equal(t, tt.known, skuDescription(tt.sku) != "")
+		})
+	}
+
+	assert.Empty(t, skuHelp([]ModelSku{{Name: "CustomSku"}}))
+	assert.Equal(t,
+		"Standard: "+skuDescriptions["Standard"]+"\nDataZoneStandard: "+skuDescriptions["DataZoneStandard"],
+		skuHelp([]ModelSku{{Name: "Standard"}, {Name: "CustomSku"}, {Name: "DataZoneStandard"}}))
+}
+
 func TestSelectFromSkus_MultipleError(t *testing.T) {
 	t.Parallel()
 	c := newTestConsole()
@@ -74,3 +238,553 @@ func TestSelectFromSkus_MultipleError(t *testing.T) {
 	_, err := selectFromSkus(t.Context(), c, "q", skus)
 	require.Error(t, err)
 }
+
+func TestSelectModelFormat_SingleFormat(t *testing.T) {
+	t.Parallel()
+	c := newTestConsole()
+	models := []ModelList{
+		{Model: Model{Name: "text-embedding-3-small", Format: "OpenAI"}},
+		{Model: Model{Name: "text-embedding-3-large", Format: "OpenAI"}},
+	}
+	got, err := selectModelFormat(t.Context(), c, models)
+	require.NoError(t, err)
+	assert.Equal(t, models, got)
+}
+
+func TestSelectModelFormat_MultipleFormats(t *testing.T) {
+	t.Parallel()
+	c := newTestConsole()
+	c.WhenSelect(func(input.ConsoleOptions) bool { return true }).
+		RespondFn(func(opts input.ConsoleOptions) (any, error) {
+			assert.Equal(t, []string{"Cohere", "OpenAI"}, opts.Options)
+			assert.Equal(t, "OpenAI", opts.DefaultValue)
+			return 0, nil
+		})
+	models := []ModelList{
+		{Model: Model{Name: "text-embedding-3-small", Format: "OpenAI"}},
+		{Model: Model{Name: "embed-v-4-0", Format: "Cohere"}},
+		{Model: Model{Name: "text-embedding-3-large", Format: "OpenAI"}},
+	}
+	got, err := selectModelFormat(t.Context(), c, models)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "embed-v-4-0", got[0].Model.Name)
+	assert.Len(t, models, 3)
+}
+
+func TestSelectModelFormat_SelectError(t *testing.T) {
+	t.Parallel()
+	c := newTestConsole()
+	c.WhenSelect(func(input.ConsoleOptions) bool { return true }).
+		RespondFn(func(input.ConsoleOptions) (any, error) { return 0, assertErr() })
+	models := []ModelList{
+		{Model: Model{Format: "OpenAI"}},
+		{Model: Model{Format: "Cohere"}},
+	}
+	_, err := selectModelFormat(t.Context(), c, models)
+	require.Error(t, err)
+}
+
+func TestAiAllowedLocations(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		value    any
+		expected []string
+		wantErr  bool
+	}{
+		{name: "NotSet"},
+		{name: "Locations", value: []any{"eastus", "swedencentral"}, expected: []string{"eastus", "swedencentral"}},
+		{name: "NotAString", value: []any{"eastus", 1}, wantErr: true},
+		{name: "Empty", value: []any{""}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			env := environment.New("test")
+			if tt.value != nil {
+				require.NoError(t, env.Config.Set(aiAllowedLocationsConfigKey, tt.value))
+			}
+
+			locations, err := aiAllowedLocations(env)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, locations)
+		})
+	}
+}
+
+func TestFilterOpenAiModels(t *testing.T) {
+	t.Parallel()
+
+	standard := []ModelSku{{Name: openAiModelSkuName}}
+	models := []ModelList{
+		{Kind: "AIServices", Model: Model{Name: "Phi-4", Capabilities: []string{"chatCompletion"}, Skus: standard}},
+		{Kind: "OpenAI", Model: Model{Name: "text-embedding-3-small", Capabilities: []string{"embeddings"}, Skus: standard}},
+		{Kind: "OpenAI", Model: Model{
+			Name: "gpt-4o-mini", Capabilities: []string{"chatCompletion"}, Skus: []ModelSku{{Name: "GlobalStandard"}},
+		}},
+		{Kind: "OpenAI", Model: Model{
+			Name: "gpt-4o", Version: "2024-08-06", Format: "OpenAI",
+			Capabilities: []string{"chatCompletion"}, Skus: standard,
+		}},
+		// Deployed with Standard; offering GlobalStandard as well does not make it addable again.
+		{Kind: "OpenAI", Model: Model{
+			Name: "gpt-4o", Version: "2024-11-20", Format: "OpenAI", Capabilities: []string{"chatCompletion"},
+			Skus: []ModelSku{{Name: openAiModelSkuName}, {Name: "GlobalStandard"}},
+		}},
+		{Kind: "OpenAI", Model: Model{
+			Name: "gpt-4.1", Version: "2025-04-14", Format: "OpenAI",
+			Capabilities: []string{"chatCompletion"}, Skus: standard,
+		}},
+	}
+	deployed := []deployedModel{
+		{Name: "gpt-4o", Format: "OpenAI", Version: "2024-08-06"},
+		{Name: "gpt-4o", Format: "OpenAI", Version: "2024-11-20"},
+	}
+
+	got, counts := filterOpenAiModels(models, ai.ModelIntentChat, deployed)
+	require.Len(t, got, 1)
+	assert.Equal(t, "gpt-4.1", got[0].Model.Name)
+	assert.Equal(t, openAiModelFilterCounts{Catalog: 6, Kind: 5, Intent: 4, Sku: 3, Deployed: 1}, counts)
+	assert.Equal(t,
+		"6 in catalog, 5 of kind OpenAI, 4 with matching capabilities, 3 with SKU Standard, 1 not yet in the project",
+		counts.String())
+}
+
+func TestAddAction_AiCatalogLocations(t *testing.T) {
+	t.Parallel()
+
+	manager := &mockaccount.MockAccountManager{
+		Locations: []account.Location{{Name: "eastus"}, {Name: "swedencentral"}, {Name: "westus"}},
+	}
+
+	tests := []struct {
+		name     string
+		allowed  []any
+		manager  account.Manager
+		expected []string
+	}{
+		{name: "NotSet", manager: manager, expected: []string{"eastus", "swedencentral", "westus"}},
+		{name: "Several", allowed: []any{"westus", "eastus"}, manager: manager, expected: []string{"eastus", "westus"}},
+		// A single allowed location is queried directly; the subscription locations are not listed.
+		{name: "Single", allowed: []any{"northcentralus"}, expected: []string{"northcentralus"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			env := environment.New("test")
+			if tt.allowed != nil {
+				require.NoError(t, env.Config.Set(aiAllowedLocationsConfigKey, tt.allowed))
+			}
+
+			action := &AddAction{env: env, accountManager: tt.manager}
+			locations, err := action.aiCatalogLocations(t.Context(), "sub")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, locations)
+		})
+	}
+}
+
+// newAiServicesAzureClient returns an AzureClient whose resource SKU listing offers AI Services in locations.
+func newAiServicesAzureClient(t *testing.T, locations ...string) *azapi.AzureClient {
+	t.Helper()
+
+	mockCtx := mocks.NewMockContext(t.Context())
+	mockCtx.HttpClient.When(func(req *http.Request) bool {
+		return req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, "/skus")
+	}).RespondFn(func(req *http.Request) (*http.Response, error) {
+		skuLocations := make([]*string, 0, len(locations))
+		for _, location := range locations {
+			skuLocations = append(skuLocations, new(location))
+		}
+		return mocks.CreateHttpResponseWithBody(req, http.StatusOK, armcognitiveservices.ResourceSKUListResult{
+			Value: []*armcognitiveservices.ResourceSKU{{
+				Kind:         new("AIServices"),
+				Name:         new("S0"),
+				Tier:         new("Standard"),
+				ResourceType: new("accounts"),
+				Locations:    skuLocations,
+			}},
+		})
+	})
+
+	return azapi.NewAzureClient(
+		mockaccount.SubscriptionCredentialProviderFunc(func(context.Context, string) (azcore.TokenCredential, error) {
+			return mockCtx.Credentials, nil
+		}),
+		mockCtx.ArmClientOptions,
+	)
+}
+
+func TestAddAction_SuggestAiLocation(t *testing.T) {
+	t.Parallel()
+
+	env := environment.New("test")
+	env.SetLocation("eastus")
+	env.SetSubscriptionId("sub")
+	action := &AddAction{
+		env: env,
+		accountManager: &mockaccount.MockAccountManager{
+			Locations: []account.Location{
+				{Name: "eastus"}, {Name: "northeurope"}, {Name: "swedencentral"}, {Name: "westus"},
+			},
+		},
+		azureClient: newAiServicesAzureClient(t, "eastus", "swedencentral", "westus"),
+	}
+
+	// eastus has no models and westus cannot be queried, while swedencentral offers plenty. northeurope offers even
+	// more but does not support AI Services.
+	modelCounts := map[string]float64{"eastus": 0, "northeurope": 50, "swedencentral": 12}
+	var scored []string
+	var mu sync.Mutex
+	suggested := action.suggestAiLocation(t.Context(), newTestConsole(), "Finding a location...",
+		func(_ context.Context, location string) (float64, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			scored = append(scored, location)
+			if location == "westus" {
+				return 0, errors.New("catalog unavailable")
+			}
+			return modelCounts[location], nil
+		})
+
+	assert.Equal(t, "swedencentral", suggested)
+	// Neither the environment location nor locations without AI Services are candidates.
+	assert.ElementsMatch(t, []string{"swedencentral", "westus"}, scored)
+}
+
+func TestAddAction_SuggestAiLocation_CapsConcurrency(t *testing.T) {
+	t.Parallel()
+
+	var locations []account.Location
+	var names []string
+	for i := range 20 {
+		name := fmt.Sprintf("location%02d", i)
+		locations = append(locations, account.Location{Name: name})
+		names = append(names, name)
+	}
+
+	env := environment.New("test")
+	env.SetLocation("eastus")
+	env.SetSubscriptionId("sub")
+	action := &AddAction{
+		env:            env,
+		accountManager: &mockaccount.MockAccountManager{Locations: locations},
+		azureClient:    newAiServicesAzureClient(t, names...),
+	}
+
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	suggested := action.suggestAiLocation(t.Context(), newTestConsole(), "Finding a location...",
+		func(_ context.Context, location string) (float64, error) {
+			mu.Lock()
+			inFlight++
+			maxInFlight = max(maxInFlight, inFlight)
+			mu.Unlock()
+
+			time.Sleep(5 * time.Millisecond)
+
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+			return 1, nil
+		})
+
+	assert.Equal(t, "location00", suggested)
+	assert.LessOrEqual(t, maxInFlight, maxConcurrentLocationScores)
+}
+
+func TestBestAiLocation(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "swedencentral", bestAiLocation(map[string]float64{"eastus": 5, "swedencentral": 40, "westus": 40}))
+	assert.Equal(t, "", bestAiLocation(map[string]float64{"eastus": 0, "westus": 0}))
+	assert.Equal(t, "", bestAiLocation(nil))
+}
+
+func TestHasRemainingQuota(t *testing.T) {
+	t.Parallel()
+
+	usages := []*armcognitiveservices.Usage{
+		{Name: &armcognitiveservices.MetricName{}},
+		{
+			Name:         &armcognitiveservices.MetricName{Value: new("OpenAI.Standard.gpt-4o")},
+			CurrentValue: new(float64(90)),
+			Limit:        new(float64(100)),
+		},
+	}
+
+	remaining, ok := hasRemainingQuota(usages, "OpenAI.Standard.gpt-4o", 10)
+	assert.True(t, ok)
+	assert.Equal(t, float64(10), remaining)
+
+	remaining, ok = hasRemainingQuota(usages, "OpenAI.Standard.gpt-4o", 20)
+	assert.False(t, ok)
+	assert.Equal(t, float64(10), remaining)
+
+	_, ok = hasRemainingQuota(usages, "OpenAI.Standard.gpt-4", 20)
+	assert.True(t, ok, "missing usage data is treated as enough quota")
+}
+
+func TestCancellableFetchContext(t *testing.T) {
+	ctx := t.Context()
+	fetchCtx, done := cancellableFetchContext(ctx)
+
+	stack := input.SnapshotInterruptStack()
+	require.NotEmpty(t, stack)
+
+	// Ctrl+C cancels the fetch and is handled, so azd does not exit.
+	require.True(t, stack[len(stack)-1]())
+	require.Error(t, fetchCtx.Err())
+	require.True(t, fetchCancelled(ctx, fetchCtx))
+
+	done()
+	require.Len(t, input.SnapshotInterruptStack(), len(stack)-1)
+}
+
+func TestFetchCancelled_ParentCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	fetchCtx, done := cancellableFetchContext(ctx)
+	defer done()
+
+	cancel()
+	require.Error(t, fetchCtx.Err())
+	require.False(t, fetchCancelled(ctx, fetchCtx))
+}
+
+func TestModel_MatchesIntent(t *testing.T) {
+	chat := Model{Name: "gpt-4o-mini", Capabilities: []string{"chatCompletion"}}
+	embeddings := Model{Name: "text-embedding-3-small", Capabilities: []string{"embeddings"}}
+
+	require.True(t, chat.MatchesIntent(ai.ModelIntentChat))
+	require.False(t, chat.MatchesIntent(ai.ModelIntentEmbeddings))
+	require.True(t, embeddings.MatchesIntent(ai.ModelIntentEmbeddings))
+	require.False(t, embeddings.MatchesIntent(ai.ModelIntentChat))
+}
+
+func TestModelListFromArm(t *testing.T) {
+	t.Parallel()
+
+	armModel := func(
+		name string, version string, status *armcognitiveservices.ModelLifecycleStatus,
+	) *armcognitiveservices.Model {
+		return &armcognitiveservices.Model{
+			Kind: new("OpenAI"),
+			Model: &armcognitiveservices.AccountModel{
+				Name:             new(name),
+				Version:          new(version),
+				Format:           new("OpenAI"),
+				IsDefaultVersion: new(false),
+				LifecycleStatus:  status,
+				SystemData:       &armcognitiveservices.SystemData{CreatedAt: new(time.Time{})},
+				SKUs: []*armcognitiveservices.ModelSKU{
+					{
+						Name:      new("Standard"),
+						UsageName: new("OpenAI.Standard." + name),
+						Capacity:  &armcognitiveservices.CapacityConfig{Default: new(int32(10))},
+					},
+				},
+			},
+		}
+	}
+	models := []*armcognitiveservices.Model{
+		armModel("gpt-4o", "2024-08-06", new(armcognitiveservices.ModelLifecycleStatusGenerallyAvailable)),
+		armModel("gpt-4o-mini", "2024-07-18", nil),
+		armModel("gpt-5", "2025-08-07", new(armcognitiveservices.ModelLifecycleStatusPreview)),
+	}
+
+	modelNames := func(models []ModelList) []string {
+		var names []string
+		for _, model := range models {
+			names = append(names, model.Model.Name)
+		}
+		return names
+	}
+
+	t.Run("GeneralAvailabilityOnly", func(t *testing.T) {
+		t.Parallel()
+		list := modelListFromArm(models, generallyAvailableModels)
+		assert.Equal(t, []string{"gpt-4o", "gpt-4o-mini"}, modelNames(list))
+		assert.False(t, slices.ContainsFunc(list, func(model ModelList) bool { return model.Model.IsPreview() }))
+	})
+
+	t.Run("PreviewIncluded", func(t *testing.T) {
+		t.Parallel()
+		list := modelListFromArm(models, includePreviewModels)
+		require.Equal(t, []string{"gpt-4o", "gpt-4o-mini", "gpt-5"}, modelNames(list))
+		assert.False(t, list[0].Model.IsPreview())
+		assert.False(t, list[1].Model.IsPreview())
+		assert.True(t, list[2].Model.IsPreview())
+		assert.Equal(t, "GenerallyAvailable", list[0].Model.LifecycleStatus)
+		assert.Equal(t, "Preview", list[2].Model.LifecycleStatus)
+		assert.Contains(t, previewModelNote(list[2].Model), "gpt-5 2025-08-07 is a preview model")
+	})
+}
+
+func TestAddAction_ModelStatusFilter(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, generallyAvailableModels, (&AddAction{}).modelStatusFilter())
+	assert.Equal(t, generallyAvailableModels, (&AddAction{flags: &addFlags{}}).modelStatusFilter())
+	assert.Equal(t, includePreviewModels,
+		(&AddAction{flags: &addFlags{includePreviewModels: true}}).modelStatusFilter())
+}
+
+func TestSelectFromMapWithDetails(t *testing.T) {
+	t.Parallel()
+	c := newTestConsole()
+	c.WhenSelect(func(input.ConsoleOptions) bool { return true }).
+		RespondFn(func(opts input.ConsoleOptions) (any, error) {
+			assert.Equal(t, []string{"2024-08-06", "2025-01-01"}, opts.Options)
+			assert.Equal(t, []string{"", "preview"}, opts.OptionDetails)
+			return 1, nil
+		})
+	m := map[string]ModelCatalog{
+		"2024-08-06": {ModelList: ModelList{Model: Model{Version: "2024-08-06"}}},
+		"2025-01-01": {ModelList: ModelList{Model: Model{Version: "2025-01-01", LifecycleStatus: "Preview"}}},
+	}
+	key, _, err := selectFromMapWithDetails(t.Context(), c, "q", m, nil, modelCatalogDetails)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-01-01", key)
+}
+
+func TestExcludeDeployedModels(t *testing.T) {
+	modelList := func(name, format, version string, skus ...string) ModelList {
+		model := ModelList{Kind: "AIServices", Model: Model{Name: name, Format: format, Version: version}}
+		for _, sku := range skus {
+			model.Model.Skus = append(model.Model.Skus, ModelSku{Name: sku})
+		}
+		return model
+	}
+	models := []ModelList{
+		modelList("gpt-4o", "OpenAI", "2024-05-13", "Standard", "GlobalStandard"),
+		modelList("gpt-4o", "OpenAI", "2024-08-06", "Standard", "GlobalStandard"),
+		modelList("Phi-4", "Microsoft", "7", "GlobalStandard"),
+	}
+	deployed := []deployedModel{
+		{Name: "gpt-4o", Format: "OpenAI", Version: "2024-05-13"},
+		// A different format does not match the catalog model of the same name.
+		{Name: "Phi-4", Format: "OpenAI", Version: "7"},
+	}
+
+	type offered struct {
+		version string
+		skus    []string
+	}
+	offeredModels := func(models []ModelList) []offered {
+		var result []offered
+		for _, m := range models {
+			var skus []string
+			for _, sku := range m.Model.Skus {
+				skus = append(skus, sku.Name)
+			}
+			result = append(result, offered{version: m.Model.Name + "@" + m.Model.Version, skus: skus})
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		deployed []deployedModel
+		want     []offered
+	}{
+		{
+			name: "excludes the deployed version with every sku",
+			want: []offered{
+				{version: "gpt-4o@2024-08-06", skus: []string{"Standard", "GlobalStandard"}},
+				{version: "Phi-4@7", skus: []string{"GlobalStandard"}},
+			},
+		},
+		{
+			name:     "deployed model without a format is an OpenAI model",
+			deployed: []deployedModel{{Name: "gpt-4o", Version: "2024-08-06"}},
+			want: []offered{
+				{version: "gpt-4o@2024-05-13", skus: []string{"Standard", "GlobalStandard"}},
+				{version: "Phi-4@7", skus: []string{"GlobalStandard"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deployedModels := deployed
+			if tt.deployed != nil {
+				deployedModels = tt.deployed
+			}
+			require.Equal(t, tt.want, offeredModels(excludeDeployedModels(models, deployedModels)))
+		})
+	}
+
+	// The input models are not modified.
+	require.Len(t, models[0].Model.Skus, 2)
+}
+
+func TestDeployedAiModels(t *testing.T) {
+	prjConfig := &project.ProjectConfig{
+		Resources: map[string]*project.ResourceConfig{
+			"ai-project": {
+				Type: project.ResourceTypeAiProject,
+				Name: "ai-project",
+				Props: project.AiFoundryModelProps{
+					Models: []project.AiServicesModel{{
+						Name:    "Phi-4",
+						Format:  "Microsoft",
+						Version: "7",
+						Sku:     project.AiServicesModelSku{Name: "GlobalStandard"},
+					}},
+				},
+			},
+			"chat": {
+				Type:  project.ResourceTypeOpenAiModel,
+				Name:  "chat",
+				Props: project.AIModelProps{Model: project.AIModelPropsModel{Name: "gpt-4o", Version: "2024-08-06"}},
+			},
+		},
+	}
+
+	require.Equal(t, []deployedModel{{Name: "Phi-4", Format: "Microsoft", Version: "7"}},
+		deployedAiModels(prjConfig, project.ResourceTypeAiProject))
+	require.Equal(t, []deployedModel{{Name: "gpt-4o", Version: "2024-08-06"}},
+		deployedAiModels(prjConfig, project.ResourceTypeOpenAiModel))
+	require.Empty(t, deployedAiModels(nil, project.ResourceTypeAiProject))
+}
+
+func TestAddAction_PreferredAiSkus(t *testing.T) {
+	t.Parallel()
+	a := &AddAction{}
+
+	require.Equal(t, []string{"GlobalStandard", "Standard"}, a.preferredAiSkus(&project.ProjectConfig{}))
+	require.Equal(t, []string{"DataZoneStandard", "Standard"},
+		a.preferredAiSkus(&project.ProjectConfig{PreferredAiSkus: []string{"DataZoneStandard", "Standard"}}))
+}
+
+func TestAiModelRegionMismatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		modelLocations []string
+		envLocation    string
+		want           bool
+	}{
+		{name: "offered", modelLocations: []string{"eastus", "westus"}, envLocation: "westus", want: false},
+		{name: "not offered", modelLocations: []string{"eastus", "westus"}, envLocation: "swedencentral", want: true},
+		{name: "no environment location", modelLocations: []string{"eastus"}, envLocation: "", want: false},
+		{name: "no model locations", modelLocations: nil, envLocation: "eastus", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, aiModelRegionMismatch(tt.modelLocations, tt.envLocation))
+		})
+	}
+}
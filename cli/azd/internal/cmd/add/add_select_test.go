@@ -0,0 +1,72 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package add
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMenuContributor stands in for an installed extension that contributes add menu entries.
+type fakeMenuContributor struct {
+	entries   []extensions.AddMenuEntry
+	listErr   error
+	invokeErr error
+	invoked   []extensions.AddMenuEntry
+}
+
+func (f *fakeMenuContributor) AddMenuEntries() ([]extensions.AddMenuEntry, error) {
+	return f.entries, f.listErr
+}
+
+func (f *fakeMenuContributor) InvokeAddMenuEntry(_ context.Context, entry extensions.AddMenuEntry) error {
+	f.invoked = append(f.invoked, entry)
+	return f.invokeErr
+}
+
+func TestAddAction_ContributedMenu(t *testing.T) {
+	t.Parallel()
+
+	ext := &extensions.Extension{Id: "contoso.vectors", Namespace: "vectors"}
+	entry := extensions.AddMenuEntry{Extension: ext, Name: "vector-store", Label: "Vector store"}
+
+	t.Run("entries are merged and invoke the extension", func(t *testing.T) {
+		t.Parallel()
+		contributor := &fakeMenuContributor{entries: []extensions.AddMenuEntry{entry}}
+		action := &AddAction{menuContributor: contributor}
+
+		menus := action.contributedMenu()
+		require.Len(t, menus, 1)
+		require.Equal(t, "vectors", menus[0].Namespace)
+		require.Equal(t, "Vector store", menus[0].Label)
+		require.NotNil(t, menus[0].Contribution)
+
+		require.NoError(t, action.invokeContribution(t.Context(), *menus[0].Contribution))
+		require.Equal(t, []extensions.AddMenuEntry{entry}, contributor.invoked)
+	})
+
+	t.Run("invoke errors name the extension", func(t *testing.T) {
+		t.Parallel()
+		contributor := &fakeMenuContributor{invokeErr: errors.New("exit status 1")}
+		action := &AddAction{menuContributor: contributor}
+
+		err := action.invokeContribution(t.Context(), entry)
+		require.ErrorContains(t, err, "running extension 'contoso.vectors': exit status 1")
+	})
+
+	t.Run("list errors leave only the built-in menu", func(t *testing.T) {
+		t.Parallel()
+		action := &AddAction{menuContributor: &fakeMenuContributor{listErr: errors.New("corrupt config")}}
+		require.Empty(t, action.contributedMenu())
+	})
+
+	t.Run("no contributor", func(t *testing.T) {
+		t.Parallel()
+		require.Empty(t, (&AddAction{}).contributedMenu())
+	})
+}
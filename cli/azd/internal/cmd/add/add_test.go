@@ -1213,11 +1213,32 @@ func TestSelectAiType_Branches(t *testing.T) {
 	}
 }
 
+func TestAiSelectors_ResourceTypes(t *testing.T) {
+	t.Parallel()
+	require.NotEmpty(t, aiSelectors)
+
+	labels := make(map[string]bool, len(aiSelectors))
+	for _, selector := range aiSelectors {
+		t.Run(selector.Label, func(t *testing.T) {
+			t.Parallel()
+			require.NotNil(t, selector.Select, "selector %q should have a Select func", selector.Label)
+
+			a := &AddAction{}
+			r, err := selector.Select(a, newTestConsole(), t.Context(), PromptOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, selector.ResourceType, r.Type)
+		})
+
+		assert.False(t, labels[selector.Label], "duplicate label %q", selector.Label)
+		labels[selector.Label] = true
+	}
+}
+
 func TestNewAddAction_Constructs(t *testing.T) {
 	t.Parallel()
 	// Pass nils for all deps — this is a no-op constructor that only
 	// assigns fields; no methods are invoked.
-	a := NewAddAction(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	a := NewAddAction(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	require.NotNil(t, a)
 }
 
@@ -31,6 +31,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
 	"github.com/azure/azure-dev/cli/azd/pkg/exec"
 	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
+	"github.com/azure/azure-dev/cli/azd/pkg/grpcbroker"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
 	"github.com/azure/azure-dev/cli/azd/pkg/pipeline"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools"
@@ -348,6 +349,8 @@ func classifySentinel(err error) string {
 		return "internal.unsupported_operation"
 	case errors.Is(err, internal.ErrExtensionTokenFailed):
 		return "internal.extension_error"
+	case errors.Is(err, grpcbroker.ErrBrokerClosed):
+		return "internal.extension_disconnected"
 	case errors.Is(err, internal.ErrMcpToolsLoadFailed):
 		return "internal.mcp_error"
 	case errors.Is(err, internal.ErrResourceNotConfigured):
@@ -32,6 +32,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
 	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/grpcbroker"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
 	"github.com/azure/azure-dev/cli/azd/pkg/pipeline"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/git"
@@ -771,6 +772,13 @@ func Test_MapError(t *testing.T) {
 				internal.ErrExtensionTokenFailed),
 			wantErrReason: "internal.extension_error",
 		},
+		{
+			name: "WithErrBrokerClosed",
+			err: fmt.Errorf(
+				"channel closed by broker: %w",
+				grpcbroker.ErrBrokerClosed),
+			wantErrReason: "internal.extension_disconnected",
+		},
 		{
 			name: "WithErrServiceNotFound",
 			err: &internal.ErrorWithSuggestion{
@@ -1240,7 +1248,9 @@ func Test_PackageLevelErrorsMapped(t *testing.T) {
 		// AI model/quota errors caught in extension callers
 		"ErrQuotaLocationRequired": "pkg/ai: caught in AI extension callers before reaching telemetry",
 		"ErrModelNotFound":         "pkg/ai: caught in AI extension callers before reaching telemetry",
+		"ErrModelNotDeployable":    "pkg/ai: caught in AI extension callers before reaching telemetry",
 		"ErrNoDeploymentMatch":     "pkg/ai: caught in AI extension callers before reaching telemetry",
+		"ErrLocationTimeout":       "pkg/ai: recorded per location in quota results, never returned to commands",
 
 		// Auth errors that could propagate but are rare edge cases
 		"ErrAzCliNotLoggedIn":         "pkg/azapi: az CLI auth delegation, wrapped by auth.Manager",
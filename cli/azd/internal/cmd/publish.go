@@ -301,7 +301,7 @@ func (pa *PublishAction) Run(ctx context.Context) (*actions.ActionResult, error)
 				//  --from-package not set, automatically package the application
 				packageResult, err := async.RunWithProgress(
 					func(packageProgress project.ServiceProgress) {
-						progressMessage := fmt.Sprintf("Packaging service %s (%s)", svc.Name, packageProgress.Message)
+						progressMessage := fmt.Sprintf("Packaging service %s (%s)", svc.Name, packageProgress)
 						pa.console.ShowSpinner(ctx, progressMessage, input.Step)
 					},
 					func(progress *async.Progress[project.ServiceProgress]) (*project.ServicePackageResult, error) {
@@ -323,7 +323,7 @@ func (pa *PublishAction) Run(ctx context.Context) (*actions.ActionResult, error)
 
 			publishResult, err := async.RunWithProgress(
 				func(publishProgress project.ServiceProgress) {
-					progressMessage := fmt.Sprintf("Publishing service %s (%s)", svc.Name, publishProgress.Message)
+					progressMessage := fmt.Sprintf("Publishing service %s (%s)", svc.Name, publishProgress)
 					pa.console.ShowSpinner(ctx, progressMessage, input.Step)
 				},
 				func(progress *async.Progress[project.ServiceProgress]) (*project.ServicePublishResult, error) {
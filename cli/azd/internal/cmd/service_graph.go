@@ -328,7 +328,7 @@ func addServiceStepsToGraph(g *exegraph.Graph, opts serviceGraphOptions) (*servi
 	}
 
 	// newPhaseProgress returns a phaseProgress whose channel is drained
-	// by a background goroutine that forwards each ServiceProgress.Message
+	// by a background goroutine that forwards each ServiceProgress text
 	// to opts.onPhaseProgress (if non-nil). Callers MUST call Done() —
 	// typically deferred — to terminate the goroutine, then call Wait()
 	// to block until the goroutine exits. When onPhaseProgress is nil the
@@ -341,8 +341,8 @@ func addServiceStepsToGraph(g *exegraph.Graph, opts serviceGraphOptions) (*servi
 		go func() {
 			defer close(done)
 			for sp := range p.Progress() {
-				if opts.onPhaseProgress != nil && sp.Message != "" {
-					opts.onPhaseProgress(serviceName, phase, sp.Message)
+				if text := sp.String(); opts.onPhaseProgress != nil && text != "" {
+					opts.onPhaseProgress(serviceName, phase, text)
 				}
 			}
 		}()
@@ -38,10 +38,21 @@ func mapAiResolveError(err error, modelName string) error {
 			nil,
 		)
 	case errors.Is(err, ai.ErrModelNotFound):
+		metadata := map[string]string{"model_name": modelName}
+		if notFound, ok := errors.AsType[*ai.ModelNotFoundError](err); ok && notFound.Suggestion != "" {
+			metadata["suggested_model_name"] = notFound.Suggestion
+		}
 		return aiStatusError(
 			codes.NotFound,
 			azdext.AiErrorReasonModelNotFound,
 			err.Error(),
+			metadata,
+		)
+	case errors.Is(err, ai.ErrModelNotDeployable):
+		return aiStatusError(
+			codes.FailedPrecondition,
+			azdext.AiErrorReasonModelNotDeployable,
+			err.Error(),
 			map[string]string{"model_name": modelName},
 		)
 	case errors.Is(err, ai.ErrNoDeploymentMatch):
@@ -118,6 +118,14 @@ func TestMapAiResolveError(t *testing.T) {
 			modelName:    "gpt-5-turbo",
 			expectedCode: codes.NotFound,
 		},
+		{
+			name: "wrapped model not deployable",
+			err: fmt.Errorf(
+				"%w: %q", ai.ErrModelNotDeployable, "gpt-35-turbo",
+			),
+			modelName:    "gpt-35-turbo",
+			expectedCode: codes.FailedPrecondition,
+		},
 		{
 			name:         "no deployment match",
 			err:          ai.ErrNoDeploymentMatch,
@@ -180,6 +188,12 @@ func TestMapAiResolveError_ModelNameInMetadata(t *testing.T) {
 			modelName: "gpt-4o-mini",
 			reason:    azdext.AiErrorReasonModelNotFound,
 		},
+		{
+			name:      "model not deployable includes model_name",
+			err:       ai.ErrModelNotDeployable,
+			modelName: "gpt-35-turbo",
+			reason:    azdext.AiErrorReasonModelNotDeployable,
+		},
 		{
 			name:      "no deployment match includes model_name",
 			err:       ai.ErrNoDeploymentMatch,
@@ -369,3 +383,61 @@ func TestProtoToQuotaCheckOptions(t *testing.T) {
 		assert.Equal(t, float64(0), result.MinRemainingCapacity)
 	})
 }
+
+func TestNoModelLocationsWithQuotaError(t *testing.T) {
+	err := noModelLocationsWithQuotaError("gpt-4o", &ai.ModelLocationQuotaResult{
+		ModelUnavailable:  []string{"brazilsouth", "westus3"},
+		InsufficientQuota: []string{"eastus"},
+		FailedLocations: []ai.LocationError{
+			{Location: "northeurope", Err: errors.New("forbidden")},
+			{Location: "westus", Err: errors.New("too many requests"), Throttled: true},
+		},
+	})
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.NotFound, st.Code())
+	assert.Contains(t, st.Message(), `model "gpt-4o" is not offered in: brazilsouth, westus3`)
+	assert.Contains(t, st.Message(), "insufficient quota in: eastus")
+
+	details := st.Details()
+	require.Len(t, details, 1)
+	errInfo, ok := details[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, azdext.AiErrorReasonNoLocationsWithQuota, errInfo.Reason)
+	assert.Equal(t, "brazilsouth, westus3", errInfo.Metadata["model_unavailable_locations"])
+	assert.Equal(t, "eastus", errInfo.Metadata["insufficient_quota_locations"])
+	assert.Equal(t, "westus", errInfo.Metadata["throttled_locations"])
+	assert.Contains(t, st.Message(), "could not check quota due to rate limiting in: westus (rerun to retry)")
+}
+
+func TestValidateFilterIntent(t *testing.T) {
+	require.NoError(t, validateFilterIntent(&ai.FilterOptions{}))
+	require.NoError(t, validateFilterIntent(&ai.FilterOptions{Intent: ai.ModelIntentEmbeddings}))
+
+	err := validateFilterIntent(&ai.FilterOptions{Intent: "speech"})
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+
+	details := st.Details()
+	require.Len(t, details, 1)
+	errInfo, ok := details[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, azdext.AiErrorReasonInvalidIntent, errInfo.Reason)
+	assert.Equal(t, "speech", errInfo.Metadata["intent"])
+}
+
+func TestMapAiResolveError_SuggestedModelName(t *testing.T) {
+	err := fmt.Errorf("%w at %q", &ai.ModelNotFoundError{Name: "gpt4x", Suggestion: "gpt-4o"}, "eastus")
+
+	st, ok := status.FromError(mapAiResolveError(err, "gpt4x"))
+	require.True(t, ok)
+	require.Equal(t, codes.NotFound, st.Code())
+	require.Equal(t, azdext.AiErrorReasonModelNotFound, azdext.AiErrorReason(st))
+	require.Equal(t, map[string]string{
+		"model_name":           "gpt4x",
+		"suggested_model_name": "gpt-4o",
+	}, azdext.AiErrorMetadata(st))
+	require.Contains(t, st.Message(), `did you mean "gpt-4o"?`)
+}
@@ -5,7 +5,10 @@ package grpcserver
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
 
 	"github.com/azure/azure-dev/cli/azd/internal/mapper"
 	"github.com/azure/azure-dev/cli/azd/pkg/ai"
@@ -40,27 +43,28 @@ func (s *aiModelService) ListModels(
 	var filterOpts *ai.FilterOptions
 	if req.Filter != nil {
 		filterOpts = protoToFilterOptions(req.Filter)
+		if err := validateFilterIntent(filterOpts); err != nil {
+			return nil, err
+		}
 	}
 
-	// Both paths fetch canonical model data across subscription locations.
-	var models []ai.AiModel
-	if filterOpts != nil {
-		models, err = s.modelService.ListFilteredModels(ctx, subscriptionId, filterOpts)
-	} else {
-		models, err = s.modelService.ListModels(ctx, subscriptionId, nil)
-	}
+	result, err := s.modelService.ListModelCatalog(ctx, subscriptionId, filterOpts)
 	if err != nil {
 		return nil, fmt.Errorf("listing models: %w", err)
 	}
 
-	protoModels := make([]*azdext.AiModel, len(models))
-	for i := range models {
-		if err := mapper.Convert(&models[i], &protoModels[i]); err != nil {
+	protoModels := make([]*azdext.AiModel, len(result.Models))
+	for i := range result.Models {
+		if err := mapper.Convert(&result.Models[i], &protoModels[i]); err != nil {
 			return nil, fmt.Errorf("converting model to proto: %w", err)
 		}
 	}
 
-	return &azdext.ListModelsResponse{Models: protoModels}, nil
+	return &azdext.ListModelsResponse{
+		Models:                protoModels,
+		FailedLocations:       locationErrorsToProto(result.FailedLocations),
+		AvailableCapabilities: result.AvailableCapabilities,
+	}, nil
 }
 
 func (s *aiModelService) ResolveModelDeployments(
@@ -122,6 +126,10 @@ func (s *aiModelService) ListUsages(
 	if err != nil {
 		return nil, fmt.Errorf("listing usages: %w", err)
 	}
+	usages = ai.FilterUsagesByNamePrefixes(usages, req.NamePrefixes)
+	if req.OmitZeroLimit {
+		usages = ai.FilterUsagesWithLimit(usages)
+	}
 
 	protoUsages := make([]*azdext.AiModelUsage, len(usages))
 	for i := range usages {
@@ -133,6 +141,103 @@ func (s *aiModelService) ListUsages(
 	return &azdext.ListUsagesResponse{Usages: protoUsages}, nil
 }
 
+func (s *aiModelService) ListRawModels(
+	ctx context.Context, req *azdext.ListRawModelsRequest,
+) (*azdext.ListRawModelsResponse, error) {
+	subscriptionId, err := requireSubscriptionID(req.AzureContext)
+	if err != nil {
+		return nil, err
+	}
+	if req.Location == "" {
+		return nil, aiStatusError(
+			codes.InvalidArgument,
+			azdext.AiErrorReasonLocationRequired,
+			"location is required for listing raw models",
+			nil,
+		)
+	}
+
+	models, err := s.modelService.ListRawModels(ctx, subscriptionId, req.Location)
+	if err != nil {
+		return nil, fmt.Errorf("listing raw models: %w", err)
+	}
+
+	modelsJSON, err := json.Marshal(models)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling raw models: %w", err)
+	}
+
+	return &azdext.ListRawModelsResponse{ModelsJson: string(modelsJSON)}, nil
+}
+
+func (s *aiModelService) BuildModelReport(
+	ctx context.Context, req *azdext.BuildModelReportRequest,
+) (*azdext.BuildModelReportResponse, error) {
+	subscriptionId, err := requireSubscriptionID(req.AzureContext)
+	if err != nil {
+		return nil, err
+	}
+	if req.ModelName == "" {
+		return nil, fmt.Errorf("model_name is required")
+	}
+
+	report, err := s.modelService.BuildModelReport(ctx, subscriptionId, req.ModelName, req.Locations)
+	if err != nil {
+		return nil, mapAiResolveError(err, req.ModelName)
+	}
+
+	protoLocations := make([]*azdext.AiModelReportLocation, len(report.Locations))
+	for i := range report.Locations {
+		if err := mapper.Convert(&report.Locations[i], &protoLocations[i]); err != nil {
+			return nil, fmt.Errorf("converting report location to proto: %w", err)
+		}
+	}
+
+	return &azdext.BuildModelReportResponse{
+		ModelName:             report.ModelName,
+		Locations:             protoLocations,
+		UnavailableLocations:  report.UnavailableLocations,
+		FailedLocations:       locationErrorsToProto(report.FailedLocations),
+		MostAvailableLocation: report.MostAvailableLocation,
+	}, nil
+}
+
+func (s *aiModelService) ListUsagesBatch(
+	ctx context.Context, req *azdext.ListUsagesBatchRequest,
+) (*azdext.ListUsagesBatchResponse, error) {
+	subscriptionId, err := requireSubscriptionID(req.AzureContext)
+	if err != nil {
+		return nil, err
+	}
+
+	usagesByLocation, err := s.modelService.ListUsagesForLocations(ctx, subscriptionId, req.Locations)
+	if err != nil {
+		return nil, fmt.Errorf("listing usages: %w", err)
+	}
+
+	locations := slices.Sorted(maps.Keys(usagesByLocation))
+	protoLocations := make([]*azdext.LocationUsages, len(locations))
+	for i, location := range locations {
+		usages := ai.FilterUsagesByNamePrefixes(usagesByLocation[location], req.NamePrefixes)
+		if req.OmitZeroLimit {
+			usages = ai.FilterUsagesWithLimit(usages)
+		}
+		protoUsages := make([]*azdext.AiModelUsage, len(usages))
+		for j := range usages {
+			if err := mapper.Convert(&usages[j], &protoUsages[j]); err != nil {
+				return nil, fmt.Errorf("converting usage to proto: %w", err)
+			}
+		}
+
+		protoLocations[i] = &azdext.LocationUsages{
+			Location: location,
+			Usages:   protoUsages,
+		}
+	}
+
+	return &azdext.ListUsagesBatchResponse{Locations: protoLocations}, nil
+}
+
 func (s *aiModelService) ListLocationsWithQuota(
 	ctx context.Context, req *azdext.ListLocationsWithQuotaRequest,
 ) (*azdext.ListLocationsWithQuotaResponse, error) {
@@ -149,18 +254,24 @@ func (s *aiModelService) ListLocationsWithQuota(
 		}
 	}
 
-	locations, err := s.modelService.ListLocationsWithQuota(
-		ctx, subscriptionId, req.AllowedLocations, requirements)
+	result, err := s.modelService.EvaluateLocationsWithQuota(
+		ctx, subscriptionId, req.AllowedLocations, requirements,
+		ai.WithMaxLocations(int(req.MaxLocations), req.PreferredLocations...))
 	if err != nil {
 		return nil, fmt.Errorf("listing locations with quota: %w", err)
 	}
 
-	protoLocations := make([]*azdext.Location, len(locations))
-	for i, loc := range locations {
+	protoLocations := make([]*azdext.Location, len(result.Locations))
+	for i, loc := range result.Locations {
 		protoLocations[i] = &azdext.Location{Name: loc}
 	}
 
-	return &azdext.ListLocationsWithQuotaResponse{Locations: protoLocations}, nil
+	return &azdext.ListLocationsWithQuotaResponse{
+		Locations:            protoLocations,
+		UnsupportedLocations: result.UnsupportedLocations,
+		FailedLocations:      locationErrorsToProto(result.FailedLocations),
+		SkippedLocations:     result.SkippedLocations,
+	}, nil
 }
 
 func (s *aiModelService) ListModelLocationsWithQuota(
@@ -179,21 +290,174 @@ func (s *aiModelService) ListModelLocationsWithQuota(
 		minRemaining = req.Quota.MinRemainingCapacity
 	}
 
-	locations, err := s.modelService.ListModelLocationsWithQuota(
-		ctx, subscriptionId, req.ModelName, req.AllowedLocations, minRemaining)
+	minAccountQuota := resolveMinAccountQuota(req.RequireAccountQuota, req.MinimumAccountQuota)
+
+	var opts []ai.QuotaCheckOption
+	if req.IncludeSuggestedAlternatives {
+		opts = append(opts, ai.WithSuggestedAlternatives())
+	}
+	if len(req.Capabilities) > 0 {
+		opts = append(opts, ai.WithRequiredCapabilities(req.Capabilities...))
+	}
+	if len(req.Formats) > 0 {
+		opts = append(opts, ai.WithFormats(req.Formats...))
+	}
+	if req.AccountKind != "" {
+		opts = append(opts, ai.WithAccountKind(req.AccountKind))
+	}
+	if req.IncludeRegionPairs {
+		opts = append(opts, ai.WithRegionPairs())
+	}
+
+	result, err := s.modelService.EvaluateModelLocationsWithQuota(
+		ctx, subscriptionId, req.ModelName, req.AllowedLocations, minRemaining, minAccountQuota, opts...)
 	if err != nil {
 		return nil, mapAiResolveError(err, req.ModelName)
 	}
 
-	protoLocations := make([]*azdext.ModelLocationQuota, len(locations))
-	for i, loc := range locations {
+	protoLocations := make([]*azdext.ModelLocationQuota, len(result.Locations))
+	for i, loc := range result.Locations {
 		protoLocations[i] = &azdext.ModelLocationQuota{
 			Location:          &azdext.Location{Name: loc.Location},
 			MaxRemainingQuota: loc.MaxRemainingQuota,
+			RequiredQuota:     loc.RequiredQuota,
+		}
+		if headroom, utilization, ok := loc.Headroom(); ok {
+			protoLocations[i].HeadroomQuota = &headroom
+			protoLocations[i].UtilizationPercent = &utilization
+		}
+	}
+
+	return &azdext.ListModelLocationsWithQuotaResponse{
+		Locations:                  protoLocations,
+		ModelUnavailableLocations:  result.ModelUnavailable,
+		InsufficientQuotaLocations: result.InsufficientQuota,
+		SuggestedAlternatives:      result.SuggestedAlternatives,
+		FailedLocations:            locationErrorsToProto(result.FailedLocations),
+		RegionPairs:                regionPairsToProto(result.RegionPairs),
+	}, nil
+}
+
+func regionPairsToProto(pairs []ai.RegionPairQuota) []*azdext.AiRegionPairQuota {
+	if pairs == nil {
+		return nil
+	}
+
+	protoPairs := make([]*azdext.AiRegionPairQuota, len(pairs))
+	for i, pair := range pairs {
+		protoPairs[i] = &azdext.AiRegionPairQuota{
+			Locations:              pair.Locations,
+			CombinedRemainingQuota: pair.CombinedRemainingQuota,
+		}
+	}
+
+	return protoPairs
+}
+
+func (s *aiModelService) RecommendCapacity(
+	ctx context.Context, req *azdext.RecommendCapacityRequest,
+) (*azdext.RecommendCapacityResponse, error) {
+	subscriptionId, err := requireSubscriptionID(req.AzureContext)
+	if err != nil {
+		return nil, err
+	}
+	if req.Location == "" {
+		return nil, aiStatusError(
+			codes.InvalidArgument,
+			azdext.AiErrorReasonLocationRequired,
+			"location is required for recommending capacity",
+			nil,
+		)
+	}
+	if req.ModelName == "" || req.Version == "" || req.SkuName == "" {
+		return nil, fmt.Errorf("model_name, version and sku_name are required")
+	}
+
+	recommendation, err := s.modelService.RecommendCapacity(
+		ctx, subscriptionId, req.ModelName, req.Version, req.SkuName, req.Location)
+	if err != nil {
+		return nil, mapAiResolveError(err, req.ModelName)
+	}
+
+	return &azdext.RecommendCapacityResponse{
+		Capacity:         recommendation.Capacity,
+		QuotaConstrained: recommendation.QuotaConstrained,
+		RemainingQuota:   recommendation.RemainingQuota,
+	}, nil
+}
+
+func (s *aiModelService) CheckDeploymentQuota(
+	ctx context.Context, req *azdext.CheckDeploymentQuotaRequest,
+) (*azdext.CheckDeploymentQuotaResponse, error) {
+	subscriptionId, err := requireSubscriptionID(req.AzureContext)
+	if err != nil {
+		return nil, err
+	}
+	if req.Location == "" {
+		return nil, aiStatusError(
+			codes.InvalidArgument,
+			azdext.AiErrorReasonLocationRequired,
+			"location is required for checking deployment quota",
+			nil,
+		)
+	}
+	if req.ModelName == "" || req.Version == "" || req.SkuName == "" {
+		return nil, fmt.Errorf("model_name, version and sku_name are required")
+	}
+	if req.Capacity < 0 {
+		return nil, aiStatusError(
+			codes.InvalidArgument,
+			azdext.AiErrorReasonInvalidCapacity,
+			fmt.Sprintf("capacity must not be negative, got %d", req.Capacity),
+			nil,
+		)
+	}
+
+	check, err := s.modelService.CheckDeploymentQuota(
+		ctx, subscriptionId, req.ModelName, req.Version, req.SkuName, req.Location, req.Capacity)
+	if err != nil {
+		return nil, mapAiResolveError(err, req.ModelName)
+	}
+
+	return &azdext.CheckDeploymentQuotaResponse{
+		Fits:                  check.Fits,
+		Capacity:              check.Capacity,
+		CapacityValid:         check.CapacityValid,
+		RemainingQuota:        check.RemainingQuota,
+		AccountQuotaRemaining: check.AccountQuotaRemaining,
+	}, nil
+}
+
+func (s *aiModelService) ListAccountDeployments(
+	ctx context.Context, req *azdext.ListAccountDeploymentsRequest,
+) (*azdext.ListAccountDeploymentsResponse, error) {
+	subscriptionId, err := requireSubscriptionID(req.AzureContext)
+	if err != nil {
+		return nil, err
+	}
+	resourceGroup := req.AzureContext.Scope.ResourceGroup
+	if resourceGroup == "" {
+		return nil, fmt.Errorf("azure_context.scope.resource_group is required")
+	}
+
+	deployments, err := s.modelService.ListAccountDeployments(ctx, subscriptionId, resourceGroup, req.AccountName)
+	if err != nil {
+		return nil, fmt.Errorf("listing account deployments: %w", err)
+	}
+
+	protoDeployments := make([]*azdext.AiAccountDeployment, len(deployments))
+	for i := range deployments {
+		protoDeployments[i] = &azdext.AiAccountDeployment{
+			Name:              deployments[i].Name,
+			AccountName:       deployments[i].AccountName,
+			ProvisioningState: deployments[i].ProvisioningState,
+		}
+		if err := mapper.Convert(&deployments[i].Deployment, &protoDeployments[i].Deployment); err != nil {
+			return nil, fmt.Errorf("converting deployment to proto: %w", err)
 		}
 	}
 
-	return &azdext.ListModelLocationsWithQuotaResponse{Locations: protoLocations}, nil
+	return &azdext.ListAccountDeploymentsResponse{Deployments: protoDeployments}, nil
 }
 
 func requireSubscriptionID(azureContext *azdext.AzureContext) (string, error) {
@@ -209,27 +473,61 @@ func requireSubscriptionID(azureContext *azdext.AzureContext) (string, error) {
 	return azureContext.Scope.SubscriptionId, nil
 }
 
+// resolveMinAccountQuota returns the minimum remaining account-count quota to require,
+// or 0 when no account quota baseline was requested.
+func resolveMinAccountQuota(require *bool, minimum *float64) float64 {
+	if require == nil || !*require {
+		return 0
+	}
+
+	if minimum == nil || *minimum <= 0 {
+		return ai.DefaultMinAccountQuota
+	}
+
+	return *minimum
+}
+
 func protoToFilterOptions(f *azdext.AiModelFilterOptions) *ai.FilterOptions {
 	if f == nil {
 		return nil
 	}
 	return &ai.FilterOptions{
-		Locations:         f.Locations,
-		Capabilities:      f.Capabilities,
-		Formats:           f.Formats,
-		Statuses:          f.Statuses,
-		ExcludeModelNames: f.ExcludeModelNames,
+		Locations:            f.Locations,
+		Capabilities:         f.Capabilities,
+		Formats:              f.Formats,
+		Statuses:             f.Statuses,
+		ExcludeModelNames:    f.ExcludeModelNames,
+		Intent:               ai.ModelIntent(f.Intent),
+		MinAvailableCapacity: f.MinAvailableCapacity,
+		DefaultVersionsOnly:  f.DefaultVersionsOnly,
 	}
 }
 
+// validateFilterIntent rejects filter intents that are not known [ai.ModelIntent] values.
+func validateFilterIntent(f *ai.FilterOptions) error {
+	if err := f.Intent.Validate(); err != nil {
+		return aiStatusError(
+			codes.InvalidArgument,
+			azdext.AiErrorReasonInvalidIntent,
+			err.Error(),
+			map[string]string{"intent": string(f.Intent)},
+		)
+	}
+
+	return nil
+}
+
 func protoToDeploymentOptions(o *azdext.AiModelDeploymentOptions) *ai.DeploymentOptions {
 	if o == nil {
 		return nil
 	}
 	opts := &ai.DeploymentOptions{
-		Locations: o.Locations,
-		Versions:  o.Versions,
-		Skus:      o.Skus,
+		Locations:                o.Locations,
+		Versions:                 o.Versions,
+		Skus:                     o.Skus,
+		FallbackToDefaultVersion: o.FallbackToDefaultVersion,
+		PreferSkusWithQuota:      o.PreferSkusWithQuota,
+		PreferStable:             o.PreferStable,
 	}
 	if o.Capacity != nil {
 		cap := *o.Capacity
@@ -246,3 +544,82 @@ func protoToQuotaCheckOptions(q *azdext.QuotaCheckOptions) *ai.QuotaCheckOptions
 		MinRemainingCapacity: q.MinRemainingCapacity,
 	}
 }
+
+func (s *aiModelService) SummarizeDeployableModels(
+	req *azdext.SummarizeDeployableModelsRequest,
+	stream azdext.AiModelService_SummarizeDeployableModelsServer,
+) error {
+	subscriptionId, err := requireSubscriptionID(req.AzureContext)
+	if err != nil {
+		return err
+	}
+
+	kinds, err := parseSkuDeploymentKinds(req.DeploymentKinds)
+	if err != nil {
+		return err
+	}
+
+	summaries, err := s.modelService.SummarizeDeployableModels(
+		stream.Context(), subscriptionId, req.Locations, kinds)
+	if err != nil {
+		return fmt.Errorf("summarizing deployable models: %w", err)
+	}
+
+	// One message per model keeps each response small regardless of catalog size.
+	for _, summary := range summaries {
+		protoLocations := make([]*azdext.ModelLocationQuota, len(summary.Locations))
+		for i, loc := range summary.Locations {
+			protoLocations[i] = &azdext.ModelLocationQuota{
+				Location:          &azdext.Location{Name: loc.Location},
+				MaxRemainingQuota: loc.MaxRemainingQuota,
+			}
+		}
+
+		if err := stream.Send(&azdext.DeployableModelSummary{
+			ModelName:             summary.ModelName,
+			Format:                summary.Format,
+			Locations:             protoLocations,
+			MostAvailableLocation: summary.MostAvailableLocation,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseSkuDeploymentKinds converts deployment kind names to ai.SkuDeploymentKind, rejecting unknown kinds.
+func parseSkuDeploymentKinds(values []string) ([]ai.SkuDeploymentKind, error) {
+	known := []ai.SkuDeploymentKind{
+		ai.SkuDeploymentKindGlobal,
+		ai.SkuDeploymentKindDataZone,
+		ai.SkuDeploymentKindRegional,
+		ai.SkuDeploymentKindProvisioned,
+	}
+
+	kinds := make([]ai.SkuDeploymentKind, 0, len(values))
+	for _, value := range values {
+		kind := ai.SkuDeploymentKind(value)
+		if !slices.Contains(known, kind) {
+			return nil, fmt.Errorf(
+				"unknown deployment kind %q, expected one of Global, DataZone, Regional or Provisioned", value)
+		}
+		kinds = append(kinds, kind)
+	}
+
+	return kinds, nil
+}
+
+// locationErrorsToProto converts per-location failures to their proto representation.
+func locationErrorsToProto(failures []ai.LocationError) []*azdext.AiLocationError {
+	protoFailures := make([]*azdext.AiLocationError, 0, len(failures))
+	for _, failure := range failures {
+		protoFailures = append(protoFailures, &azdext.AiLocationError{
+			Location:  failure.Location,
+			Message:   failure.Err.Error(),
+			Throttled: failure.Throttled,
+		})
+	}
+
+	return protoFailures
+}
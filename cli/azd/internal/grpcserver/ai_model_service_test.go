@@ -4,11 +4,19 @@
 package grpcserver
 
 import (
+	"context"
 	"errors"
+	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cognitiveservices/armcognitiveservices/v2"
 	"github.com/azure/azure-dev/cli/azd/pkg/ai"
+	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
 	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockaccount"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -75,6 +83,72 @@ func TestAiModelService_ListUsages_NilAzureContext(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestAiModelService_ListUsages_OmitZeroLimit(t *testing.T) {
+	t.Parallel()
+	mockCtx := mocks.NewMockContext(t.Context())
+	mockCtx.HttpClient.When(func(req *http.Request) bool {
+		return req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, "/usages")
+	}).RespondFn(func(req *http.Request) (*http.Response, error) {
+		usage := func(name string, current, limit float64) *armcognitiveservices.Usage {
+			return &armcognitiveservices.Usage{
+				Name:         &armcognitiveservices.MetricName{Value: &name},
+				CurrentValue: &current,
+				Limit:        &limit,
+			}
+		}
+		return mocks.CreateHttpResponseWithBody(req, http.StatusOK, armcognitiveservices.UsageListResult{
+			Value: []*armcognitiveservices.Usage{
+				usage("OpenAI.Standard.gpt-4o", 10, 100),
+				usage("OpenAI.ProvisionedManaged.gpt-4o", 0, 0),
+				usage("OpenAI.GlobalStandard.gpt-4o", 0, 50),
+			},
+		})
+	})
+
+	azureClient := azapi.NewAzureClient(
+		mockaccount.SubscriptionCredentialProviderFunc(func(context.Context, string) (azcore.TokenCredential, error) {
+			return mockCtx.Credentials, nil
+		}),
+		mockCtx.ArmClientOptions,
+	)
+	svc := NewAiModelService(ai.NewAiModelService(azureClient, nil))
+
+	tests := []struct {
+		name          string
+		omitZeroLimit bool
+		expected      []string
+	}{
+		{
+			name:     "AllByDefault",
+			expected: []string{"OpenAI.Standard.gpt-4o", "OpenAI.ProvisionedManaged.gpt-4o", "OpenAI.GlobalStandard.gpt-4o"},
+		},
+		{
+			name:          "OmitZeroLimit",
+			omitZeroLimit: true,
+			expected:      []string{"OpenAI.Standard.gpt-4o", "OpenAI.GlobalStandard.gpt-4o"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := svc.ListUsages(*mockCtx.Context, &azdext.ListUsagesRequest{
+				AzureContext: &azdext.AzureContext{
+					Scope: &azdext.AzureScope{SubscriptionId: "sub-123"},
+				},
+				Location:      "eastus",
+				OmitZeroLimit: tt.omitZeroLimit,
+			})
+			require.NoError(t, err)
+
+			var names []string
+			for _, usage := range resp.Usages {
+				names = append(names, usage.Name)
+			}
+			require.Equal(t, tt.expected, names)
+		})
+	}
+}
+
 func TestAiModelService_ListUsages_EmptyLocation(t *testing.T) {
 	t.Parallel()
 	svc := NewAiModelService(ai.NewAiModelService(nil, nil))
@@ -90,6 +164,35 @@ func TestAiModelService_ListUsages_EmptyLocation(t *testing.T) {
 	require.Equal(t, codes.InvalidArgument, st.Code())
 }
 
+func TestAiModelService_ListRawModels_EmptyLocation(t *testing.T) {
+	t.Parallel()
+	svc := NewAiModelService(ai.NewAiModelService(nil, nil))
+	_, err := svc.ListRawModels(t.Context(), &azdext.ListRawModelsRequest{
+		AzureContext: &azdext.AzureContext{
+			Scope: &azdext.AzureScope{SubscriptionId: "sub-123"},
+		},
+	})
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+}
+
+// --- ListUsagesBatch validation ---
+
+func TestAiModelService_ListUsagesBatch_NilAzureContext(t *testing.T) {
+	t.Parallel()
+	svc := NewAiModelService(ai.NewAiModelService(nil, nil))
+	_, err := svc.ListUsagesBatch(t.Context(), &azdext.ListUsagesBatchRequest{
+		AzureContext: nil,
+		Locations:    []string{"eastus"},
+	})
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+}
+
 // --- ListLocationsWithQuota validation ---
 
 func TestAiModelService_ListLocationsWithQuota_NilAzureContext(t *testing.T) {
@@ -136,6 +239,183 @@ func TestAiModelService_ListModelLocationsWithQuota_EmptyModelName(t *testing.T)
 	require.Contains(t, err.Error(), "model_name is required")
 }
 
+func TestAiModelService_BuildModelReport_NilAzureContext(t *testing.T) {
+	t.Parallel()
+	svc := NewAiModelService(ai.NewAiModelService(nil, nil))
+	_, err := svc.BuildModelReport(t.Context(), &azdext.BuildModelReportRequest{ModelName: "gpt-4o"})
+	require.Error(t, err)
+}
+
+func TestAiModelService_BuildModelReport_EmptyModelName(t *testing.T) {
+	t.Parallel()
+	svc := NewAiModelService(ai.NewAiModelService(nil, nil))
+	_, err := svc.BuildModelReport(t.Context(), &azdext.BuildModelReportRequest{
+		AzureContext: &azdext.AzureContext{
+			Scope: &azdext.AzureScope{SubscriptionId: "sub-123"},
+		},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "model_name is required")
+}
+
+// --- RecommendCapacity validation ---
+
+func TestAiModelService_RecommendCapacity_NilAzureContext(t *testing.T) {
+	t.Parallel()
+	svc := NewAiModelService(ai.NewAiModelService(nil, nil))
+	_, err := svc.RecommendCapacity(t.Context(), &azdext.RecommendCapacityRequest{
+		AzureContext: nil,
+	})
+	require.Error(t, err)
+}
+
+func TestAiModelService_RecommendCapacity_EmptyLocation(t *testing.T) {
+	t.Parallel()
+	svc := NewAiModelService(ai.NewAiModelService(nil, nil))
+	_, err := svc.RecommendCapacity(t.Context(), &azdext.RecommendCapacityRequest{
+		AzureContext: &azdext.AzureContext{
+			Scope: &azdext.AzureScope{SubscriptionId: "sub-123"},
+		},
+		ModelName: "gpt-4o",
+		Version:   "2024-08-06",
+		SkuName:   "GlobalStandard",
+	})
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+}
+
+func TestAiModelService_RecommendCapacity_MissingSku(t *testing.T) {
+	t.Parallel()
+	svc := NewAiModelService(ai.NewAiModelService(nil, nil))
+	_, err := svc.RecommendCapacity(t.Context(), &azdext.RecommendCapacityRequest{
+		AzureContext: &azdext.AzureContext{
+			Scope: &azdext.AzureScope{SubscriptionId: "sub-123"},
+		},
+		ModelName: "gpt-4o",
+		Version:   "2024-08-06",
+		Location:  "eastus",
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "sku_name are required")
+}
+
+// --- ListAccountDeployments validation ---
+
+func TestAiModelService_ListAccountDeployments_MissingSubscription(t *testing.T) {
+	t.Parallel()
+	svc := NewAiModelService(ai.NewAiModelService(nil, nil))
+	_, err := svc.ListAccountDeployments(t.Context(), &azdext.ListAccountDeploymentsRequest{})
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+	require.Equal(t, azdext.AiErrorReasonMissingSubscription, azdext.AiErrorReason(st))
+}
+
+func TestAiModelService_ListAccountDeployments_MissingResourceGroup(t *testing.T) {
+	t.Parallel()
+	svc := NewAiModelService(ai.NewAiModelService(nil, nil))
+	_, err := svc.ListAccountDeployments(t.Context(), &azdext.ListAccountDeploymentsRequest{
+		AzureContext: &azdext.AzureContext{Scope: &azdext.AzureScope{SubscriptionId: "sub-123"}},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "resource_group is required")
+}
+
+// --- CheckDeploymentQuota validation ---
+
+func TestAiModelService_CheckDeploymentQuota_Validation(t *testing.T) {
+	t.Parallel()
+	scope := &azdext.AzureContext{Scope: &azdext.AzureScope{SubscriptionId: "sub-123"}}
+
+	tests := []struct {
+		name       string
+		req        *azdext.CheckDeploymentQuotaRequest
+		wantReason string
+	}{
+		{
+			name:       "missing subscription",
+			req:        &azdext.CheckDeploymentQuotaRequest{},
+			wantReason: azdext.AiErrorReasonMissingSubscription,
+		},
+		{
+			name: "missing location",
+			req: &azdext.CheckDeploymentQuotaRequest{
+				AzureContext: scope, ModelName: "gpt-4o", Version: "2024-08-06", SkuName: "GlobalStandard",
+			},
+			wantReason: azdext.AiErrorReasonLocationRequired,
+		},
+		{
+			name: "negative capacity",
+			req: &azdext.CheckDeploymentQuotaRequest{
+				AzureContext: scope, ModelName: "gpt-4o", Version: "2024-08-06", SkuName: "GlobalStandard",
+				Location: "eastus", Capacity: -1,
+			},
+			wantReason: azdext.AiErrorReasonInvalidCapacity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			svc := NewAiModelService(ai.NewAiModelService(nil, nil))
+			_, err := svc.CheckDeploymentQuota(t.Context(), tt.req)
+			require.Error(t, err)
+			st, ok := status.FromError(err)
+			require.True(t, ok)
+			require.Equal(t, codes.InvalidArgument, st.Code())
+			require.Equal(t, tt.wantReason, azdext.AiErrorReason(st))
+		})
+	}
+
+	t.Run("missing sku", func(t *testing.T) {
+		t.Parallel()
+		svc := NewAiModelService(ai.NewAiModelService(nil, nil))
+		_, err := svc.CheckDeploymentQuota(t.Context(), &azdext.CheckDeploymentQuotaRequest{
+			AzureContext: scope, ModelName: "gpt-4o", Version: "2024-08-06", Location: "eastus",
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "sku_name are required")
+	})
+}
+
+// --- SummarizeDeployableModels validation ---
+
+func TestAiModelService_SummarizeDeployableModels_NilAzureContext(t *testing.T) {
+	t.Parallel()
+	svc := NewAiModelService(ai.NewAiModelService(nil, nil))
+	err := svc.SummarizeDeployableModels(&azdext.SummarizeDeployableModelsRequest{
+		AzureContext: nil,
+	}, nil)
+	require.Error(t, err)
+}
+
+func TestAiModelService_SummarizeDeployableModels_UnknownDeploymentKind(t *testing.T) {
+	t.Parallel()
+	svc := NewAiModelService(ai.NewAiModelService(nil, nil))
+	err := svc.SummarizeDeployableModels(&azdext.SummarizeDeployableModelsRequest{
+		AzureContext: &azdext.AzureContext{
+			Scope: &azdext.AzureScope{SubscriptionId: "sub-123"},
+		},
+		DeploymentKinds: []string{"Global", "Batch"},
+	}, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `unknown deployment kind "Batch"`)
+}
+
+func TestParseSkuDeploymentKinds(t *testing.T) {
+	t.Parallel()
+	kinds, err := parseSkuDeploymentKinds([]string{"Regional", "DataZone"})
+	require.NoError(t, err)
+	require.Equal(t, []ai.SkuDeploymentKind{ai.SkuDeploymentKindRegional, ai.SkuDeploymentKindDataZone}, kinds)
+
+	kinds, err = parseSkuDeploymentKinds(nil)
+	require.NoError(t, err)
+	require.Empty(t, kinds)
+}
+
 // --- mapAiResolveError tests ---
 
 func TestMapAiResolveError_QuotaLocationRequired(t *testing.T) {
@@ -186,3 +466,29 @@ func TestAiStatusError_NilMetadata(t *testing.T) {
 	err := aiStatusError(codes.Internal, "test", "msg", nil)
 	require.Error(t, err)
 }
+
+func TestResolveMinAccountQuota(t *testing.T) {
+	t.Parallel()
+
+	require.Zero(t, resolveMinAccountQuota(nil, nil))
+	require.Zero(t, resolveMinAccountQuota(new(false), new(float64(5))))
+	require.Equal(t, ai.DefaultMinAccountQuota, resolveMinAccountQuota(new(true), nil))
+	require.Equal(t, ai.DefaultMinAccountQuota, resolveMinAccountQuota(new(true), new(float64(0))))
+	require.Equal(t, float64(2), resolveMinAccountQuota(new(true), new(float64(2))))
+}
+
+func TestLocationErrorsToProto(t *testing.T) {
+	t.Parallel()
+
+	require.Empty(t, locationErrorsToProto(nil))
+
+	protoFailures := locationErrorsToProto([]ai.LocationError{
+		{Location: "eastus", Err: errors.New("throttled")},
+		{Location: "westus", Err: errors.New("forbidden")},
+	})
+	require.Len(t, protoFailures, 2)
+	require.Equal(t, "eastus", protoFailures[0].Location)
+	require.Equal(t, "throttled", protoFailures[0].Message)
+	require.Equal(t, "westus", protoFailures[1].Location)
+	require.Equal(t, "forbidden", protoFailures[1].Message)
+}
@@ -0,0 +1,107 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package grpcserver
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/azsdk"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// correlatedServices are the services whose handlers forward an extension-supplied correlation id to ARM.
+var correlatedServices = []string{
+	azdext.AiModelService_ServiceDesc.ServiceName,
+	azdext.PromptService_ServiceDesc.ServiceName,
+}
+
+// withIncomingCorrelationId reads the correlation id an extension attached to the incoming gRPC metadata
+// (see [azdext.WithCorrelationId]) and stamps it onto ctx as the `x-ms-correlation-request-id` header for any
+// azcore pipeline that uses ctx. The per-call header overrides the value azd derives from the trace id.
+// Missing or malformed ids leave ctx unchanged.
+func withIncomingCorrelationId(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+
+	values := md.Get(azdext.CorrelationIdKey)
+	if len(values) == 0 {
+		return ctx
+	}
+
+	correlationId, err := uuid.Parse(values[0])
+	if err != nil {
+		log.Printf("ignoring invalid correlation id %q: %v", values[0], err)
+		return ctx
+	}
+
+	header := http.Header{}
+	header.Set(azsdk.MsCorrelationIdHeader, correlationId.String())
+	return policy.WithHTTPHeader(ctx, header)
+}
+
+// isCorrelatedMethod reports whether fullMethod belongs to one of the correlatedServices.
+func isCorrelatedMethod(fullMethod string) bool {
+	for _, service := range correlatedServices {
+		if strings.HasPrefix(fullMethod, "/"+service+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// correlationInterceptor propagates extension-supplied correlation ids into the handler context for the
+// AI and prompt services, which make ARM calls on the extension's behalf.
+func (s *Server) correlationInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		if isCorrelatedMethod(info.FullMethod) {
+			ctx = withIncomingCorrelationId(ctx)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// correlationStreamInterceptor is the streaming counterpart of correlationInterceptor.
+func (s *Server) correlationStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if !isCorrelatedMethod(info.FullMethod) {
+			return handler(srv, ss)
+		}
+
+		return handler(srv, &correlatedStream{
+			ServerStream: ss,
+			ctx:          withIncomingCorrelationId(ss.Context()),
+		})
+	}
+}
+
+// correlatedStream wraps a grpc.ServerStream to provide a context carrying the extension's correlation id.
+type correlatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *correlatedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,179 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package grpcserver
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/azsdk"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type headerCapturingTransport struct {
+	header http.Header
+}
+
+func (t *headerCapturingTransport) Do(req *http.Request) (*http.Response, error) {
+	t.header = req.Header.Clone()
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+}
+
+// sentCorrelationId sends a request through an azcore pipeline configured like azd's ARM clients and returns
+// the correlation header that reached the wire.
+func sentCorrelationId(t *testing.T, ctx context.Context) string {
+	transport := &headerCapturingTransport{}
+	pipeline := runtime.NewPipeline("test", "1.0.0", runtime.PipelineOptions{}, &policy.ClientOptions{
+		Transport:       transport,
+		PerCallPolicies: []policy.Policy{azsdk.NewMsCorrelationPolicy()},
+		Retry:           policy.RetryOptions{MaxRetries: -1},
+	})
+
+	req, err := runtime.NewRequest(ctx, http.MethodGet, "https://management.azure.com/subscriptions")
+	require.NoError(t, err)
+	_, err = pipeline.Do(req)
+	require.NoError(t, err)
+
+	return transport.header.Get(azsdk.MsCorrelationIdHeader)
+}
+
+func TestWithIncomingCorrelationId(t *testing.T) {
+	traceId := trace.TraceID{0x01, 0x02, 0x03}
+	spanCtx := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceId, SpanID: trace.SpanID{0x01}})
+	traceCorrelationId := azsdk.CorrelationIDFromTraceID(traceId)
+
+	tests := []struct {
+		name     string
+		md       metadata.MD
+		expected string
+	}{
+		{
+			name:     "NoMetadata",
+			expected: traceCorrelationId,
+		},
+		{
+			name:     "MissingKey",
+			md:       metadata.Pairs("authorization", "token"),
+			expected: traceCorrelationId,
+		},
+		{
+			name:     "InvalidId",
+			md:       metadata.Pairs(azdext.CorrelationIdKey, "not-a-guid"),
+			expected: traceCorrelationId,
+		},
+		{
+			name:     "OverridesTraceId",
+			md:       metadata.Pairs(azdext.CorrelationIdKey, "0F8FAD5B-D9CB-469F-A165-70867728950E"),
+			expected: "0f8fad5b-d9cb-469f-a165-70867728950e",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := trace.ContextWithSpanContext(t.Context(), spanCtx)
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+
+			require.Equal(t, tt.expected, sentCorrelationId(t, withIncomingCorrelationId(ctx)))
+		})
+	}
+}
+
+func TestCorrelationInterceptor(t *testing.T) {
+	const correlationId = "0f8fad5b-d9cb-469f-a165-70867728950e"
+
+	tests := []struct {
+		name       string
+		fullMethod string
+		expected   string
+	}{
+		{
+			name:       "AiModelService",
+			fullMethod: "/" + azdext.AiModelService_ServiceDesc.ServiceName + "/ListModels",
+			expected:   correlationId,
+		},
+		{
+			name:       "PromptService",
+			fullMethod: "/" + azdext.PromptService_ServiceDesc.ServiceName + "/PromptAiModel",
+			expected:   correlationId,
+		},
+		{
+			name:       "OtherService",
+			fullMethod: "/" + azdext.ProjectService_ServiceDesc.ServiceName + "/Get",
+			expected:   "",
+		},
+	}
+
+	interceptor := (&Server{}).correlationInterceptor()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(t.Context(), metadata.Pairs(azdext.CorrelationIdKey, correlationId))
+
+			var sent string
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.fullMethod},
+				func(ctx context.Context, req any) (any, error) {
+					sent = sentCorrelationId(t, ctx)
+					return nil, nil
+				})
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, sent)
+		})
+	}
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestCorrelationStreamInterceptor(t *testing.T) {
+	const correlationId = "0f8fad5b-d9cb-469f-a165-70867728950e"
+
+	tests := []struct {
+		name       string
+		fullMethod string
+		expected   string
+	}{
+		{
+			name:       "AiModelService",
+			fullMethod: "/" + azdext.AiModelService_ServiceDesc.ServiceName + "/ListModels",
+			expected:   correlationId,
+		},
+		{
+			name:       "OtherService",
+			fullMethod: "/" + azdext.EventService_ServiceDesc.ServiceName + "/EventStream",
+			expected:   "",
+		},
+	}
+
+	interceptor := (&Server{}).correlationStreamInterceptor()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(t.Context(), metadata.Pairs(azdext.CorrelationIdKey, correlationId))
+
+			var sent string
+			err := interceptor(nil, &fakeServerStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: tt.fullMethod},
+				func(srv any, ss grpc.ServerStream) error {
+					sent = sentCorrelationId(t, ss.Context())
+					return nil
+				})
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, sent)
+		})
+	}
+}
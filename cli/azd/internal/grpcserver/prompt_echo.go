@@ -0,0 +1,58 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package grpcserver
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+)
+
+// noPromptEchoEnvVar turns on echoing of the values prompt RPCs resolve without asking in no-prompt mode.
+const noPromptEchoEnvVar = "AZD_NO_PROMPT_ECHO"
+
+// hiddenValue replaces the value of a secret prompt when it is echoed.
+const hiddenValue = "<hidden>"
+
+// noPromptEchoWriter returns where no-prompt resolutions are echoed: stderr when AZD_NO_PROMPT_ECHO is true, and
+// nil, which disables echoing, otherwise.
+func noPromptEchoWriter() io.Writer {
+	if echo, err := strconv.ParseBool(os.Getenv(noPromptEchoEnvVar)); err == nil && echo {
+		return os.Stderr
+	}
+
+	return nil
+}
+
+// echoResolved records the value a prompt resolved to in no-prompt mode, e.g.
+// `auto-selected location: eastus (from azure context)`, so non-interactive logs show what azd chose.
+func (s *promptService) echoResolved(what string, value string, source string) {
+	if s.echo == nil {
+		return
+	}
+
+	fmt.Fprintf(s.echo, "auto-selected %s: %s (%s)\n", cmp.Or(what, "value"), value, source)
+}
+
+// promptLabel names a generic prompt in echoed lines by its message.
+func promptLabel(message string) string {
+	if message == "" {
+		return ""
+	}
+
+	return strconv.Quote(message)
+}
+
+// selectedChoiceValue returns the value of the select prompt choice at index.
+func selectedChoiceValue(options *azdext.SelectOptions, index int32) string {
+	if index < 0 || int(index) >= len(options.Choices) {
+		return fmt.Sprintf("choice %d", index)
+	}
+
+	return options.Choices[index].Value
+}
@@ -0,0 +1,107 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package grpcserver
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/stretchr/testify/require"
+)
+
+func newEchoingPromptService(t *testing.T) (*promptService, *bytes.Buffer) {
+	t.Helper()
+
+	var echo bytes.Buffer
+	service := NewPromptService(
+		nil, nil, nil, &internal.GlobalCommandOptions{NoPrompt: true}, nil, nil, nil, nil, nil,
+	).(*promptService)
+	service.echo = &echo
+
+	return service, &echo
+}
+
+func Test_PromptService_NoPromptEcho(t *testing.T) {
+	t.Run("confirm", func(t *testing.T) {
+		service, echo := newEchoingPromptService(t)
+		_, err := service.Confirm(t.Context(), &azdext.ConfirmRequest{
+			Options: &azdext.ConfirmOptions{Message: "Continue?", DefaultValue: new(true)},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "auto-selected \"Continue?\": true (default)\n", echo.String())
+	})
+
+	t.Run("select", func(t *testing.T) {
+		service, echo := newEchoingPromptService(t)
+		_, err := service.Select(t.Context(), &azdext.SelectRequest{
+			Options: &azdext.SelectOptions{
+				Message:       "Pick a region",
+				Choices:       []*azdext.SelectChoice{{Value: "westus"}, {Value: "eastus"}},
+				SelectedIndex: new(int32(1)),
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "auto-selected \"Pick a region\": eastus (default)\n", echo.String())
+	})
+
+	t.Run("prompt", func(t *testing.T) {
+		service, echo := newEchoingPromptService(t)
+		_, err := service.Prompt(t.Context(), &azdext.PromptRequest{
+			Options: &azdext.PromptOptions{Message: "Name", DefaultValue: "app"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "auto-selected \"Name\": \"app\" (default)\n", echo.String())
+	})
+
+	t.Run("secret prompt is hidden", func(t *testing.T) {
+		service, echo := newEchoingPromptService(t)
+		resp, err := service.Prompt(t.Context(), &azdext.PromptRequest{
+			Options: &azdext.PromptOptions{Message: "Password", DefaultValue: "hunter2", Secret: true},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "hunter2", resp.Value)
+		require.Equal(t, "auto-selected \"Password\": <hidden> (default)\n", echo.String())
+		require.NotContains(t, echo.String(), "hunter2")
+	})
+
+	t.Run("key values echo only keys", func(t *testing.T) {
+		service, echo := newEchoingPromptService(t)
+		_, err := service.PromptKeyValues(t.Context(), &azdext.PromptKeyValuesRequest{
+			Options: &azdext.PromptKeyValuesOptions{
+				Message:       "Tags",
+				DefaultValues: map[string]string{"owner": "team", "env": "s3cret"},
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "auto-selected \"Tags\": keys env, owner (default)\n", echo.String())
+	})
+
+	t.Run("no echo by default", func(t *testing.T) {
+		t.Setenv(noPromptEchoEnvVar, "")
+		service := NewPromptService(
+			nil, nil, nil, &internal.GlobalCommandOptions{NoPrompt: true}, nil, nil, nil, nil, nil,
+		).(*promptService)
+		require.Nil(t, service.echo)
+	})
+}
+
+func Test_noPromptEchoWriter(t *testing.T) {
+	t.Setenv(noPromptEchoEnvVar, "true")
+	require.Equal(t, os.Stderr, noPromptEchoWriter())
+
+	t.Setenv(noPromptEchoEnvVar, "false")
+	require.Nil(t, noPromptEchoWriter())
+
+	t.Setenv(noPromptEchoEnvVar, "")
+	require.Nil(t, noPromptEchoWriter())
+}
+
+func Test_scopeSource(t *testing.T) {
+	require.Equal(t, "from azure context", scopeSource("eastus", "eastus"))
+	require.Equal(t, "from azd environment", scopeSource("", "eastus"))
+	require.Equal(t, "resolved by azd", scopeSource("", ""))
+}
@@ -0,0 +1,95 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package grpcserver
+
+import (
+	"log"
+	"slices"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+)
+
+// lastChoicesConfigPath is the user config path of the values remembered for select prompts with a persist key.
+// Entries are keyed by persist key, or by persist key and project directory for per-project keys.
+const lastChoicesConfigPath = "prompt.lastChoices"
+
+// choiceMemoryKey returns the entry a select prompt's choice is remembered under, or "" when it is not remembered.
+func (s *promptService) choiceMemoryKey(options *azdext.SelectOptions) string {
+	if options.PersistKey == "" {
+		return ""
+	}
+	if !options.PersistPerProject {
+		return options.PersistKey
+	}
+
+	if s.lazyAzdContext == nil {
+		return options.PersistKey
+	}
+
+	azdCtx, err := s.lazyAzdContext.GetValue()
+	if err != nil || azdCtx == nil {
+		// Outside a project the choice is shared, as documented on persist_per_project.
+		return options.PersistKey
+	}
+
+	return options.PersistKey + "@" + azdCtx.ProjectDirectory()
+}
+
+// rememberedChoiceIndex returns the index of the choice remembered for the select prompt, or -1 when nothing is
+// remembered or the remembered value is no longer one of the choices.
+func (s *promptService) rememberedChoiceIndex(options *azdext.SelectOptions) int {
+	key := s.choiceMemoryKey(options)
+	if key == "" || s.userConfigManager == nil {
+		return -1
+	}
+
+	userConfig, err := s.userConfigManager.Load()
+	if err != nil {
+		log.Printf("loading remembered choice for %q: %v", options.PersistKey, err)
+		return -1
+	}
+
+	choices, _ := userConfig.GetMap(lastChoicesConfigPath)
+	value, ok := choices[key].(string)
+	if !ok {
+		return -1
+	}
+
+	return slices.IndexFunc(options.Choices, func(choice *azdext.SelectChoice) bool {
+		return choice.Value == value
+	})
+}
+
+// rememberChoice records the value chosen in the select prompt for its persist key. Failures are logged and
+// otherwise ignored so they never fail the prompt.
+func (s *promptService) rememberChoice(options *azdext.SelectOptions, index int32) {
+	key := s.choiceMemoryKey(options)
+	if key == "" || s.userConfigManager == nil || index < 0 || int(index) >= len(options.Choices) {
+		return
+	}
+
+	userConfig, err := s.userConfigManager.Load()
+	if err != nil {
+		log.Printf("remembering choice for %q: %v", options.PersistKey, err)
+		return
+	}
+
+	choices, _ := userConfig.GetMap(lastChoicesConfigPath)
+	if choices == nil {
+		choices = map[string]any{}
+	}
+	value := options.Choices[index].Value
+	if choices[key] == value {
+		return
+	}
+	choices[key] = value
+
+	if err := userConfig.Set(lastChoicesConfigPath, choices); err != nil {
+		log.Printf("remembering choice for %q: %v", options.PersistKey, err)
+		return
+	}
+	if err := s.userConfigManager.Save(userConfig); err != nil {
+		log.Printf("remembering choice for %q: %v", options.PersistKey, err)
+	}
+}
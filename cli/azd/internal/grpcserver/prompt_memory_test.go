@@ -0,0 +1,117 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package grpcserver
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
+	"github.com/stretchr/testify/require"
+)
+
+func newRememberingPromptService(t *testing.T, userConfig config.Config, projectDir string) *promptService {
+	t.Helper()
+
+	lazyAzdContext := lazy.NewLazy(func() (*azdcontext.AzdContext, error) {
+		if projectDir == "" {
+			return nil, azdcontext.ErrNoProject
+		}
+		return azdcontext.NewAzdContextWithDirectory(projectDir), nil
+	})
+	service := NewPromptService(
+		nil, nil, nil, &internal.GlobalCommandOptions{NoPrompt: true}, nil,
+		lazyAzdContext, nil, &mockUserConfigManager{cfg: userConfig}, nil,
+	).(*promptService)
+	service.echo = nil
+
+	return service
+}
+
+func regionSelect(selectedIndex *int32, perProject bool) *azdext.SelectRequest {
+	return &azdext.SelectRequest{
+		Options: &azdext.SelectOptions{
+			Message: "Pick a region",
+			Choices: []*azdext.SelectChoice{
+				{Value: "westus"}, {Value: "eastus"}, {Value: "swedencentral"},
+			},
+			SelectedIndex:     selectedIndex,
+			PersistKey:        "demo.region",
+			PersistPerProject: perProject,
+		},
+	}
+}
+
+func Test_PromptService_Select_PersistKey(t *testing.T) {
+	t.Parallel()
+
+	t.Run("remembered value becomes the default", func(t *testing.T) {
+		t.Parallel()
+		userConfig := config.NewEmptyConfig()
+		service := newRememberingPromptService(t, userConfig, "")
+
+		resp, err := service.Select(t.Context(), regionSelect(new(int32(2)), false))
+		require.NoError(t, err)
+		require.Equal(t, int32(2), resp.GetValue())
+
+		value, ok := userConfig.Get(lastChoicesConfigPath)
+		require.True(t, ok)
+		require.Equal(t, map[string]any{"demo.region": "swedencentral"}, value)
+
+		// Without a default the remembered value resolves the prompt, and it wins over a different default.
+		resp, err = service.Select(t.Context(), regionSelect(nil, false))
+		require.NoError(t, err)
+		require.Equal(t, int32(2), resp.GetValue())
+
+		resp, err = service.Select(t.Context(), regionSelect(new(int32(0)), false))
+		require.NoError(t, err)
+		require.Equal(t, int32(2), resp.GetValue())
+	})
+
+	t.Run("per project", func(t *testing.T) {
+		t.Parallel()
+		userConfig := config.NewEmptyConfig()
+		projectA := newRememberingPromptService(t, userConfig, "/src/a")
+		projectB := newRememberingPromptService(t, userConfig, "/src/b")
+
+		_, err := projectA.Select(t.Context(), regionSelect(new(int32(1)), true))
+		require.NoError(t, err)
+
+		resp, err := projectA.Select(t.Context(), regionSelect(nil, true))
+		require.NoError(t, err)
+		require.Equal(t, int32(1), resp.GetValue())
+
+		_, err = projectB.Select(t.Context(), regionSelect(nil, true))
+		require.Error(t, err)
+	})
+
+	t.Run("stale value is ignored", func(t *testing.T) {
+		t.Parallel()
+		userConfig := config.NewConfig(map[string]any{
+			"prompt": map[string]any{"lastChoices": map[string]any{"demo.region": "centralus"}},
+		})
+		service := newRememberingPromptService(t, userConfig, "")
+
+		resp, err := service.Select(t.Context(), regionSelect(new(int32(0)), false))
+		require.NoError(t, err)
+		require.Equal(t, int32(0), resp.GetValue())
+	})
+
+	t.Run("save failures do not fail the prompt", func(t *testing.T) {
+		t.Parallel()
+		service := newRememberingPromptService(t, config.NewEmptyConfig(), "")
+		service.userConfigManager = &mockUserConfigManager{
+			cfg:    config.NewEmptyConfig(),
+			saveFn: func(config.Config) error { return errors.New("read-only config") },
+		}
+
+		resp, err := service.Select(t.Context(), regionSelect(new(int32(1)), false))
+		require.NoError(t, err)
+		require.Equal(t, int32(1), resp.GetValue())
+	})
+}
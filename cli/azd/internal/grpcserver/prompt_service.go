@@ -4,22 +4,43 @@
 package grpcserver
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/internal/mapper"
+	"github.com/azure/azure-dev/cli/azd/internal/tracing"
+	"github.com/azure/azure-dev/cli/azd/internal/tracing/fields"
+	"github.com/azure/azure-dev/cli/azd/pkg/account"
 	"github.com/azure/azure-dev/cli/azd/pkg/ai"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
 	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 	"github.com/azure/azure-dev/cli/azd/pkg/prompt"
 	"github.com/azure/azure-dev/cli/azd/pkg/ux"
+	"github.com/kballard/go-shellquote"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -30,7 +51,20 @@ type promptService struct {
 	resourceService *azapi.ResourceService
 	aiModelService  *ai.AiModelService
 	globalOptions   *internal.GlobalCommandOptions
+	lazyEnv         *lazy.Lazy[*environment.Environment]
 	lock            *promptLock
+	// lazyAzdContext locates the current project, which scopes per-project remembered choices.
+	lazyAzdContext *lazy.Lazy[*azdcontext.AzdContext]
+	// commandRunner runs the external editor for PromptEditor.
+	commandRunner exec.CommandRunner
+	// userConfigManager stores the choices remembered for select prompts with a persist key.
+	userConfigManager config.UserConfigManager
+	// console writes the messages shown around prompts, such as summaries, warnings and validation errors.
+	console input.Console
+	// echo receives the values resolved without prompting in no-prompt mode; nil disables echoing.
+	echo io.Writer
+	// stdin is read by the terminal fallback of PromptEditor; nil reads os.Stdin.
+	stdin io.Reader
 }
 
 func NewPromptService(
@@ -38,13 +72,24 @@ func NewPromptService(
 	resourceService *azapi.ResourceService,
 	aiModelService *ai.AiModelService,
 	globalOptions *internal.GlobalCommandOptions,
+	lazyEnv *lazy.Lazy[*environment.Environment],
+	lazyAzdContext *lazy.Lazy[*azdcontext.AzdContext],
+	commandRunner exec.CommandRunner,
+	userConfigManager config.UserConfigManager,
+	console input.Console,
 ) azdext.PromptServiceServer {
 	return &promptService{
-		prompter:        prompter,
-		resourceService: resourceService,
-		aiModelService:  aiModelService,
-		globalOptions:   globalOptions,
-		lock:            newPromptLock(),
+		prompter:          prompter,
+		resourceService:   resourceService,
+		aiModelService:    aiModelService,
+		globalOptions:     globalOptions,
+		lazyEnv:           lazyEnv,
+		lock:              newPromptLock(),
+		lazyAzdContext:    lazyAzdContext,
+		commandRunner:     commandRunner,
+		userConfigManager: userConfigManager,
+		console:           console,
+		echo:              noPromptEchoWriter(),
 	}
 }
 
@@ -59,6 +104,7 @@ func (s *promptService) Confirm(ctx context.Context, req *azdext.ConfirmRequest)
 				PromptMessage: req.Options.Message,
 			}
 		} else {
+			s.echoResolved(promptLabel(req.Options.Message), strconv.FormatBool(*req.Options.DefaultValue), "default")
 			return &azdext.ConfirmResponse{
 				Value: req.Options.DefaultValue,
 			}, nil
@@ -87,19 +133,195 @@ func (s *promptService) Confirm(ctx context.Context, req *azdext.ConfirmRequest)
 	}, err
 }
 
+func (s *promptService) PromptSummaryConfirm(
+	ctx context.Context,
+	req *azdext.PromptSummaryConfirmRequest,
+) (*azdext.PromptSummaryConfirmResponse, error) {
+	if req == nil || req.Options == nil {
+		return nil, status.Error(codes.InvalidArgument, "request and options are required")
+	}
+
+	summary, err := formatSummary(req.Title, req.Rows)
+	if err != nil {
+		return nil, fmt.Errorf("formatting summary: %w", err)
+	}
+
+	if s.globalOptions.NoPrompt {
+		if req.Options.DefaultValue == nil {
+			return nil, &input.PromptRequiredError{
+				PromptMessage: req.Options.Message,
+			}
+		}
+
+		// Still show the summary so non-interactive logs record what was confirmed.
+		s.console.Message(ctx, strings.TrimSuffix(summary, "\n"))
+		s.echoResolved(promptLabel(req.Options.Message), strconv.FormatBool(*req.Options.DefaultValue), "default")
+		return &azdext.PromptSummaryConfirmResponse{
+			Value: req.Options.DefaultValue,
+		}, nil
+	}
+
+	release, err := s.acquirePromptLock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	s.console.Message(ctx, strings.TrimSuffix(summary, "\n"))
+
+	confirm := ux.NewConfirm(&ux.ConfirmOptions{
+		DefaultValue: req.Options.DefaultValue,
+		Message:      req.Options.Message,
+		HelpMessage:  req.Options.HelpMessage,
+		Hint:         req.Options.Hint,
+		PlaceHolder:  req.Options.Placeholder,
+	})
+	value, err := confirm.Ask(ctx)
+
+	return &azdext.PromptSummaryConfirmResponse{
+		Value: value,
+	}, err
+}
+
+// defaultDestructiveConfirmAttempts is how many attempts PromptDestructiveConfirm gives the user by default.
+const defaultDestructiveConfirmAttempts = 3
+
+func (s *promptService) PromptDestructiveConfirm(
+	ctx context.Context,
+	req *azdext.PromptDestructiveConfirmRequest,
+) (*azdext.PromptDestructiveConfirmResponse, error) {
+	if req == nil || req.Options == nil {
+		return nil, status.Error(codes.InvalidArgument, "request and options are required")
+	}
+
+	opts := req.Options
+	if opts.ConfirmationPhrase == "" {
+		return nil, status.Error(codes.InvalidArgument, "confirmation_phrase is required")
+	}
+	if opts.MaxAttempts < 0 {
+		return nil, status.Error(codes.InvalidArgument, "max_attempts must not be negative")
+	}
+
+	if opts.Force {
+		s.echoResolved(promptLabel(opts.Message), "confirmed", "forced")
+		return &azdext.PromptDestructiveConfirmResponse{Confirmed: true}, nil
+	}
+
+	if s.globalOptions.NoPrompt {
+		return nil, &input.PromptRequiredError{PromptMessage: opts.Message}
+	}
+
+	release, err := s.acquirePromptLock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if opts.Message != "" {
+		s.console.Message(ctx, output.WithWarningFormat("%s", opts.Message))
+	}
+
+	confirmed, err := confirmPhrase(ctx, s.console, opts, func(ctx context.Context, message string) (string, error) {
+		return ux.NewPrompt(&ux.PromptOptions{
+			Message:     message,
+			HelpMessage: opts.HelpMessage,
+			// '?' must be typeable when the phrase contains it.
+			IgnoreHintKeys: opts.HelpMessage == "" || strings.Contains(opts.ConfirmationPhrase, "?"),
+		}).Ask(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &azdext.PromptDestructiveConfirmResponse{Confirmed: confirmed}, nil
+}
+
+// confirmPhrase asks for the confirmation phrase of opts with ask until it is typed exactly or the attempts run out,
+// reporting each mismatch.
+func confirmPhrase(
+	ctx context.Context,
+	console input.Console,
+	opts *azdext.PromptDestructiveConfirmOptions,
+	ask func(ctx context.Context, message string) (string, error),
+) (bool, error) {
+	attempts := int(cmp.Or(opts.MaxAttempts, defaultDestructiveConfirmAttempts))
+	message := fmt.Sprintf("Type %s to confirm", output.WithHighLightFormat("%s", opts.ConfirmationPhrase))
+
+	for attempt := 1; attempt <= attempts; attempt++ {
+		value, err := ask(ctx, message)
+		if err != nil {
+			return false, err
+		}
+		if value == opts.ConfirmationPhrase {
+			return true, nil
+		}
+
+		if remaining := attempts - attempt; remaining > 0 {
+			console.Message(ctx, output.WithErrorFormat("The text does not match. Attempts left: %d", remaining))
+		} else {
+			console.Message(ctx, output.WithErrorFormat("The text does not match. The action was not confirmed."))
+		}
+	}
+
+	return false, nil
+}
+
+// formatSummary renders an optional title and key/value rows as an aligned block followed by a blank line.
+// Returns an empty string when there is nothing to display.
+func formatSummary(title string, rows []*azdext.SummaryRow) (string, error) {
+	var sb strings.Builder
+	if title != "" {
+		sb.WriteString(output.WithBold("%s", title))
+		sb.WriteString("\n")
+	}
+
+	lines := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if row == nil {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("  %s:\t%s", row.Key, row.Value))
+	}
+
+	if len(lines) > 0 {
+		aligned, err := output.TabAlign(lines, 2)
+		if err != nil {
+			return "", err
+		}
+		for _, line := range aligned {
+			sb.WriteString(line)
+			sb.WriteString("\n")
+		}
+	}
+
+	if sb.Len() > 0 {
+		sb.WriteString("\n")
+	}
+
+	return sb.String(), nil
+}
+
 func (s *promptService) Select(ctx context.Context, req *azdext.SelectRequest) (*azdext.SelectResponse, error) {
 	if req == nil || req.Options == nil {
 		return nil, status.Error(codes.InvalidArgument, "request and options are required")
 	}
 
+	// A remembered choice takes precedence over the default index; the request is not modified.
+	selectedIndex, source := req.Options.SelectedIndex, "default"
+	if remembered := s.rememberedChoiceIndex(req.Options); remembered >= 0 {
+		selectedIndex, source = new(int32(remembered)), "remembered"
+	}
+
 	if s.globalOptions.NoPrompt {
-		if req.Options.SelectedIndex == nil {
+		if selectedIndex == nil {
 			return nil, &input.PromptRequiredError{
 				PromptMessage: req.Options.Message,
 			}
 		} else {
+			s.echoResolved(promptLabel(req.Options.Message), selectedChoiceValue(req.Options, *selectedIndex), source)
+			s.rememberChoice(req.Options, *selectedIndex)
 			return &azdext.SelectResponse{
-				Value: req.Options.SelectedIndex,
+				Value: selectedIndex,
 			}, nil
 		}
 	}
@@ -113,23 +335,28 @@ func (s *promptService) Select(ctx context.Context, req *azdext.SelectRequest) (
 	choices := make([]*ux.SelectChoice, len(req.Options.Choices))
 	for i, choice := range req.Options.Choices {
 		choices[i] = &ux.SelectChoice{
-			Value: choice.Value,
-			Label: choice.Label,
+			Value:       choice.Value,
+			Label:       choice.Label,
+			Description: choice.Description,
 		}
 	}
 
 	options := &ux.SelectOptions{
-		SelectedIndex:   convertToInt(req.Options.SelectedIndex),
-		Message:         req.Options.Message,
-		Choices:         choices,
-		HelpMessage:     req.Options.HelpMessage,
-		DisplayCount:    int(req.Options.DisplayCount),
-		DisplayNumbers:  req.Options.DisplayNumbers,
-		EnableFiltering: req.Options.EnableFiltering,
+		SelectedIndex:      convertToInt(selectedIndex),
+		Message:            req.Options.Message,
+		Choices:            choices,
+		HelpMessage:        req.Options.HelpMessage,
+		DisplayCount:       int(req.Options.DisplayCount),
+		DisplayNumbers:     req.Options.DisplayNumbers,
+		EnableFiltering:    req.Options.EnableFiltering,
+		AnnounceAutoSelect: req.Options.AnnounceAutoSelect,
 	}
 
 	selectPrompt := ux.NewSelect(options)
 	value, err := selectPrompt.Ask(ctx)
+	if err == nil && value != nil {
+		s.rememberChoice(req.Options, int32(*value))
+	}
 
 	return &azdext.SelectResponse{
 		Value: convertToInt32(value),
@@ -146,12 +373,16 @@ func (s *promptService) MultiSelect(
 
 	if s.globalOptions.NoPrompt {
 		var selectedChoices []*azdext.MultiSelectChoice
+		var selectedValues []string
 		for _, choice := range req.Options.Choices {
 			if choice.Selected {
 				selectedChoices = append(selectedChoices, choice)
+				selectedValues = append(selectedValues, choice.Value)
 			}
 		}
 
+		s.echoResolved(promptLabel(req.Options.Message), strings.Join(selectedValues, ", "), "default")
+
 		return &azdext.MultiSelectResponse{
 			Values: selectedChoices,
 		}, nil
@@ -198,6 +429,155 @@ func (s *promptService) MultiSelect(
 	}, err
 }
 
+func (s *promptService) PromptTree(
+	ctx context.Context,
+	req *azdext.PromptTreeRequest,
+) (*azdext.PromptTreeResponse, error) {
+	if req == nil || req.Options == nil {
+		return nil, status.Error(codes.InvalidArgument, "request and options are required")
+	}
+
+	if len(req.Options.Choices) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one choice is required")
+	}
+
+	if s.globalOptions.NoPrompt {
+		if len(req.Options.SelectedPath) == 0 {
+			return nil, &input.PromptRequiredError{
+				PromptMessage: req.Options.Message,
+			}
+		}
+
+		if _, err := resolveTreePath(req.Options.Choices, req.Options.SelectedPath); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		s.echoResolved(promptLabel(req.Options.Message), strings.Join(req.Options.SelectedPath, " > "), "default")
+		return &azdext.PromptTreeResponse{
+			Path: req.Options.SelectedPath,
+		}, nil
+	}
+
+	release, err := s.acquirePromptLock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	// selected holds the choices picked so far, from the root level down.
+	var selected []*azdext.TreeChoice
+	for {
+		level := req.Options.Choices
+		if len(selected) > 0 {
+			level = selected[len(selected)-1].Children
+		}
+
+		choices := make([]*ux.SelectChoice, 0, len(level)+1)
+		for _, choice := range level {
+			choices = append(choices, &ux.SelectChoice{
+				Value:       choice.Value,
+				Label:       choice.Label,
+				Description: choice.Description,
+			})
+		}
+
+		// The "Back" choice is always last and is identified by its index rather than its value,
+		// so it cannot collide with a value supplied by the extension.
+		if len(selected) > 0 {
+			choices = append(choices, &ux.SelectChoice{
+				Label:       "Back",
+				Description: fmt.Sprintf("Return to %s", selected[len(selected)-1].Label),
+			})
+		}
+
+		selectPrompt := ux.NewSelect(&ux.SelectOptions{
+			SelectedIndex:   findDefaultIndex(choices, treeDefaultValue(req.Options.SelectedPath, selected)),
+			Message:         treeLevelMessage(req.Options.Message, selected),
+			Choices:         choices,
+			HelpMessage:     req.Options.HelpMessage,
+			DisplayCount:    int(req.Options.DisplayCount),
+			DisplayNumbers:  req.Options.DisplayNumbers,
+			EnableFiltering: req.Options.EnableFiltering,
+		})
+
+		index, err := selectPrompt.Ask(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if *index == len(level) {
+			selected = selected[:len(selected)-1]
+			continue
+		}
+
+		selected = append(selected, level[*index])
+		if len(level[*index].Children) == 0 {
+			break
+		}
+	}
+
+	return &azdext.PromptTreeResponse{
+		Path: treePathValues(selected),
+	}, nil
+}
+
+// resolveTreePath walks choices along path and returns the choice matched at each level.
+// The path must end at a leaf.
+func resolveTreePath(choices []*azdext.TreeChoice, path []string) ([]*azdext.TreeChoice, error) {
+	resolved := make([]*azdext.TreeChoice, 0, len(path))
+	level := choices
+	for i, value := range path {
+		idx := slices.IndexFunc(level, func(choice *azdext.TreeChoice) bool {
+			return choice.Value == value
+		})
+		if idx < 0 {
+			return nil, fmt.Errorf("selected path: no choice with value %q at level %d", value, i+1)
+		}
+
+		resolved = append(resolved, level[idx])
+		level = level[idx].Children
+	}
+
+	if len(level) > 0 {
+		return nil, fmt.Errorf("selected path must end at a choice without children, %q has children", path[len(path)-1])
+	}
+
+	return resolved, nil
+}
+
+// treeDefaultValue returns the value from selectedPath to highlight at the next level, as long as the choices picked
+// so far still follow selectedPath.
+func treeDefaultValue(selectedPath []string, selected []*azdext.TreeChoice) string {
+	if len(selected) >= len(selectedPath) || !slices.Equal(selectedPath[:len(selected)], treePathValues(selected)) {
+		return ""
+	}
+
+	return selectedPath[len(selected)]
+}
+
+// treeLevelMessage appends the labels of the choices picked so far to message, e.g. "Select a subscription (Contoso)".
+func treeLevelMessage(message string, selected []*azdext.TreeChoice) string {
+	if len(selected) == 0 {
+		return message
+	}
+
+	labels := make([]string, len(selected))
+	for i, choice := range selected {
+		labels[i] = choice.Label
+	}
+
+	return fmt.Sprintf("%s (%s)", message, strings.Join(labels, " > "))
+}
+
+func treePathValues(choices []*azdext.TreeChoice) []string {
+	values := make([]string, len(choices))
+	for i, choice := range choices {
+		values[i] = choice.Value
+	}
+
+	return values
+}
+
 func (s *promptService) Prompt(ctx context.Context, req *azdext.PromptRequest) (*azdext.PromptResponse, error) {
 	if req == nil || req.Options == nil {
 		return nil, status.Error(codes.InvalidArgument, "request and options are required")
@@ -209,6 +589,11 @@ func (s *promptService) Prompt(ctx context.Context, req *azdext.PromptRequest) (
 				PromptMessage: req.Options.Message,
 			}
 		} else {
+			value := strconv.Quote(req.Options.DefaultValue)
+			if req.Options.Secret {
+				value = hiddenValue
+			}
+			s.echoResolved(promptLabel(req.Options.Message), value, "default")
 			return &azdext.PromptResponse{
 				Value: req.Options.DefaultValue,
 			}, nil
@@ -243,12 +628,37 @@ func (s *promptService) Prompt(ctx context.Context, req *azdext.PromptRequest) (
 	}, err
 }
 
-func (s *promptService) PromptSubscription(
+func (s *promptService) PromptPath(
 	ctx context.Context,
-	req *azdext.PromptSubscriptionRequest,
-) (*azdext.PromptSubscriptionResponse, error) {
+	req *azdext.PromptPathRequest,
+) (*azdext.PromptPathResponse, error) {
+	if req == nil || req.Options == nil {
+		return nil, status.Error(codes.InvalidArgument, "request and options are required")
+	}
+
+	opts := req.Options
+	if opts.MustBeDir && opts.MustBeFile {
+		return nil, status.Error(codes.InvalidArgument, "must_be_dir and must_be_file are mutually exclusive")
+	}
+	if opts.CreateIfMissing && !opts.MustBeDir {
+		return nil, status.Error(codes.InvalidArgument, "create_if_missing requires must_be_dir")
+	}
+
 	if s.globalOptions.NoPrompt {
-		return nil, &input.PromptRequiredError{PromptMessage: promptSubscriptionMessage(req)}
+		if opts.DefaultValue == "" {
+			return nil, &input.PromptRequiredError{PromptMessage: opts.Message}
+		}
+
+		if err := validatePromptPath(opts.DefaultValue, opts); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "default path is invalid: %v", err)
+		}
+
+		if err := createPromptPath(opts.DefaultValue, opts); err != nil {
+			return nil, err
+		}
+
+		s.echoResolved(promptLabel(opts.Message), opts.DefaultValue, "default")
+		return &azdext.PromptPathResponse{Path: opts.DefaultValue}, nil
 	}
 
 	release, err := s.acquirePromptLock(ctx)
@@ -257,85 +667,970 @@ func (s *promptService) PromptSubscription(
 	}
 	defer release()
 
-	selectedSubscription, err := s.prompter.PromptSubscription(ctx, &prompt.SelectOptions{
-		Message:     req.Message,
-		HelpMessage: req.HelpMessage,
+	pathPrompt := ux.NewPrompt(&ux.PromptOptions{
+		DefaultValue:    opts.DefaultValue,
+		Message:         opts.Message,
+		HelpMessage:     opts.HelpMessage,
+		PlaceHolder:     opts.Placeholder,
+		Required:        true,
+		RequiredMessage: "A path is required",
+		ValidationFn: func(value string) (bool, string) {
+			if err := validatePromptPath(value, opts); err != nil {
+				return false, err.Error()
+			}
+			return true, ""
+		},
+		CompletionFn: func(value string) string {
+			return completePath(value, opts.MustBeDir)
+		},
 	})
+
+	value, err := pathPrompt.Ask(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	subscription := &azdext.Subscription{
-		Id:           selectedSubscription.Id,
-		Name:         selectedSubscription.Name,
-		TenantId:     selectedSubscription.TenantId,
-		UserTenantId: selectedSubscription.UserAccessTenantId,
-		IsDefault:    selectedSubscription.IsDefault,
+	if err := createPromptPath(value, opts); err != nil {
+		return nil, err
 	}
 
-	return &azdext.PromptSubscriptionResponse{
-		Subscription: subscription,
-	}, nil
+	return &azdext.PromptPathResponse{Path: value}, nil
 }
 
-func (s *promptService) PromptLocation(
-	ctx context.Context,
-	req *azdext.PromptLocationRequest,
-) (*azdext.PromptLocationResponse, error) {
-	if s.globalOptions.NoPrompt {
-		return nil, &input.PromptRequiredError{PromptMessage: "Select location"}
+// validatePromptPath checks path against the existence constraints of opts. A missing path is accepted when
+// create_if_missing is set, since it is created once the prompt completes.
+func validatePromptPath(path string, opts *azdext.PromptPathOptions) error {
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		if opts.MustExist && !opts.CreateIfMissing {
+			return fmt.Errorf("'%s' does not exist", path)
+		}
+		return nil
+	} else if err != nil {
+		return err
 	}
 
-	release, err := s.acquirePromptLock(ctx)
-	if err != nil {
-		return nil, err
+	if opts.MustBeDir && !info.IsDir() {
+		return fmt.Errorf("'%s' is not a directory", path)
 	}
-	defer release()
-
-	azureContext, err := s.createAzureContext(req.AzureContext)
-	if err != nil {
-		return nil, err
+	if opts.MustBeFile && info.IsDir() {
+		return fmt.Errorf("'%s' is not a file", path)
 	}
 
-	var selectorOptions *prompt.SelectOptions
-	if len(req.AllowedLocations) > 0 {
-		selectorOptions = &prompt.SelectOptions{
-			AllowedValues: req.AllowedLocations,
-		}
-	}
+	return nil
+}
 
-	selectedLocation, err := s.prompter.PromptLocation(ctx, azureContext, selectorOptions)
-	if err != nil {
-		return nil, err
+// createPromptPath creates the directory at path, including any parents, when create_if_missing is set.
+func createPromptPath(path string, opts *azdext.PromptPathOptions) error {
+	if !opts.CreateIfMissing {
+		return nil
 	}
 
-	location := &azdext.Location{
-		Name:                selectedLocation.Name,
-		DisplayName:         selectedLocation.DisplayName,
-		RegionalDisplayName: selectedLocation.RegionalDisplayName,
+	if err := os.MkdirAll(path, osutil.PermissionDirectory); err != nil {
+		return fmt.Errorf("creating directory '%s': %w", path, err)
 	}
 
-	return &azdext.PromptLocationResponse{
-		Location: location,
-	}, nil
+	return nil
 }
 
-func (s *promptService) PromptResourceGroup(
-	ctx context.Context,
-	req *azdext.PromptResourceGroupRequest,
-) (*azdext.PromptResourceGroupResponse, error) {
-	if s.globalOptions.NoPrompt {
-		return nil, &input.PromptRequiredError{PromptMessage: promptResourceGroupMessage(req)}
+// completePath completes the last element of value to the longest prefix shared by the matching entries of its
+// directory. Directories are completed with a trailing separator; hidden entries are only matched when the typed
+// element starts with a dot. value is returned unchanged when nothing matches.
+func completePath(value string, dirsOnly bool) string {
+	dir, prefix := filepath.Split(value)
+
+	readDir := dir
+	if readDir == "" {
+		readDir = "."
 	}
 
-	release, err := s.acquirePromptLock(ctx)
+	entries, err := os.ReadDir(readDir)
 	if err != nil {
-		return nil, err
+		return value
 	}
-	defer release()
 
-	azureContext, err := s.createAzureContext(req.AzureContext)
-	if err != nil {
+	var completion string
+	matched := false
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if strings.HasPrefix(name, ".") && !strings.HasPrefix(prefix, ".") {
+			continue
+		}
+
+		// Follow symlinks so that links to directories complete like directories.
+		isDir := entry.IsDir()
+		if entry.Type()&os.ModeSymlink != 0 {
+			if info, err := os.Stat(filepath.Join(readDir, name)); err == nil {
+				isDir = info.IsDir()
+			}
+		}
+
+		if dirsOnly && !isDir {
+			continue
+		}
+		if isDir {
+			name += string(filepath.Separator)
+		}
+
+		if !matched {
+			completion = name
+			matched = true
+		} else {
+			completion = commonPrefix(completion, name)
+		}
+	}
+
+	if !matched {
+		return value
+	}
+
+	return dir + completion
+}
+
+// commonPrefix returns the longest common prefix of a and b, without splitting multi-byte characters.
+func commonPrefix(a, b string) string {
+	for i, r := range a {
+		if !strings.HasPrefix(b[min(i, len(b)):], string(r)) {
+			return a[:i]
+		}
+	}
+
+	return a
+}
+
+func (s *promptService) PromptDuration(
+	ctx context.Context,
+	req *azdext.PromptDurationRequest,
+) (*azdext.PromptDurationResponse, error) {
+	if req == nil || req.Options == nil {
+		return nil, status.Error(codes.InvalidArgument, "request and options are required")
+	}
+
+	opts := req.Options
+	minimum, err := parseDurationBound("min_value", opts.MinValue)
+	if err != nil {
+		return nil, err
+	}
+
+	maximum, err := parseDurationBound("max_value", opts.MaxValue)
+	if err != nil {
+		return nil, err
+	}
+
+	if minimum > 0 && maximum > 0 && minimum > maximum {
+		return nil, status.Error(codes.InvalidArgument, "min_value must not be greater than max_value")
+	}
+
+	if s.globalOptions.NoPrompt {
+		if opts.DefaultValue == "" {
+			return nil, &input.PromptRequiredError{PromptMessage: opts.Message}
+		}
+
+		duration, err := ux.ParseDuration(opts.DefaultValue, minimum, maximum)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "default duration is invalid: %v", err)
+		}
+
+		s.echoResolved(promptLabel(opts.Message), duration.String(), "default")
+		return durationResponse(duration), nil
+	}
+
+	release, err := s.acquirePromptLock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	durationPrompt := ux.NewDurationPrompt(&ux.DurationPromptOptions{
+		DefaultValue: opts.DefaultValue,
+		Message:      opts.Message,
+		HelpMessage:  opts.HelpMessage,
+		Min:          minimum,
+		Max:          maximum,
+	})
+
+	duration, err := durationPrompt.Ask(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return durationResponse(duration), nil
+}
+
+// parseDurationBound parses an optional duration bound of PromptDurationOptions. An empty value means no bound.
+func parseDurationBound(field string, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "%s must be a non-negative duration such as 30s or 5m", field)
+	}
+
+	return duration, nil
+}
+
+func durationResponse(duration time.Duration) *azdext.PromptDurationResponse {
+	return &azdext.PromptDurationResponse{
+		Nanoseconds: int64(duration),
+		Value:       duration.String(),
+	}
+}
+
+func (s *promptService) PromptKeyValues(
+	ctx context.Context,
+	req *azdext.PromptKeyValuesRequest,
+) (*azdext.PromptKeyValuesResponse, error) {
+	if req == nil || req.Options == nil {
+		return nil, status.Error(codes.InvalidArgument, "request and options are required")
+	}
+
+	opts := req.Options
+	if opts.MinEntries < 0 {
+		return nil, status.Error(codes.InvalidArgument, "min_entries must not be negative")
+	}
+
+	var keyPattern *regexp.Regexp
+	if opts.KeyPattern != "" {
+		// Keys must match the whole pattern, so a pattern without anchors does not accept keys that merely contain a match.
+		pattern, err := regexp.Compile("^(?:" + opts.KeyPattern + ")$")
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "key_pattern is not a valid regular expression: %v", err)
+		}
+		keyPattern = pattern
+	}
+
+	if s.globalOptions.NoPrompt {
+		if len(opts.DefaultValues) < int(opts.MinEntries) {
+			return nil, &input.PromptRequiredError{PromptMessage: opts.Message}
+		}
+
+		for _, key := range slices.Sorted(maps.Keys(opts.DefaultValues)) {
+			if err := validateKeyValueKey(key, keyPattern, opts); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "default key is invalid: %v", err)
+			}
+		}
+
+		// Only the keys are echoed: the values may hold secrets.
+		s.echoResolved(
+			promptLabel(opts.Message),
+			"keys "+strings.Join(slices.Sorted(maps.Keys(opts.DefaultValues)), ", "),
+			"default",
+		)
+		return &azdext.PromptKeyValuesResponse{Values: maps.Clone(opts.DefaultValues)}, nil
+	}
+
+	release, err := s.acquirePromptLock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if opts.Message != "" {
+		s.console.Message(ctx, output.WithHighLightFormat("? ")+ux.BoldString("%s:", opts.Message))
+	}
+
+	values := map[string]string{}
+	maps.Copy(values, opts.DefaultValues)
+
+	for {
+		if len(values) >= int(opts.MinEntries) {
+			confirmMessage := "Add an entry?"
+			if len(values) > 0 {
+				confirmMessage = fmt.Sprintf("Add another entry? (%d so far)", len(values))
+			}
+
+			addAnother, err := ux.NewConfirm(&ux.ConfirmOptions{
+				Message:      confirmMessage,
+				HelpMessage:  opts.HelpMessage,
+				DefaultValue: new(len(values) == 0),
+			}).Ask(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if addAnother == nil || !*addAnother {
+				break
+			}
+		}
+
+		keyMessage := cmp.Or(opts.KeyMessage, "Enter a key")
+		key, err := ux.NewPrompt(&ux.PromptOptions{
+			Message:         keyMessage,
+			HelpMessage:     opts.HelpMessage,
+			Required:        true,
+			RequiredMessage: "A key is required",
+			ValidationFn: func(value string) (bool, string) {
+				if err := validateKeyValueKey(value, keyPattern, opts); err != nil {
+					return false, err.Error()
+				}
+				if _, has := values[value]; has && opts.DisallowDuplicateKeys {
+					return false, fmt.Sprintf("'%s' has already been entered", value)
+				}
+				return true, ""
+			},
+		}).Ask(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		valueMessage := cmp.Or(opts.ValueMessage, fmt.Sprintf("Enter a value for %s", key))
+		value, err := ux.NewPrompt(&ux.PromptOptions{
+			Message:      valueMessage,
+			HelpMessage:  opts.HelpMessage,
+			DefaultValue: values[key],
+		}).Ask(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		values[key] = value
+	}
+
+	return &azdext.PromptKeyValuesResponse{Values: values}, nil
+}
+
+// validateKeyValueKey checks a key entered for PromptKeyValues against keyPattern, the anchored form of
+// opts.KeyPattern when set, reporting opts.KeyValidationMessage when it does not match.
+func validateKeyValueKey(key string, keyPattern *regexp.Regexp, opts *azdext.PromptKeyValuesOptions) error {
+	if strings.TrimSpace(key) == "" {
+		return errors.New("a key is required")
+	}
+
+	if keyPattern != nil && !keyPattern.MatchString(key) {
+		if opts.KeyValidationMessage != "" {
+			return errors.New(opts.KeyValidationMessage)
+		}
+		return fmt.Errorf("'%s' must match %s", key, opts.KeyPattern)
+	}
+
+	return nil
+}
+
+func (s *promptService) PromptOrderedSelect(
+	ctx context.Context,
+	req *azdext.PromptOrderedSelectRequest,
+) (*azdext.PromptOrderedSelectResponse, error) {
+	if req == nil || req.Options == nil {
+		return nil, status.Error(codes.InvalidArgument, "request and options are required")
+	}
+
+	opts := req.Options
+	if len(opts.Choices) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "choices are required")
+	}
+	if opts.MinSelections < 0 || opts.MaxSelections < 0 {
+		return nil, status.Error(codes.InvalidArgument, "min_selections and max_selections must not be negative")
+	}
+	if int(opts.MinSelections) > len(opts.Choices) ||
+		(opts.MaxSelections > 0 && opts.MinSelections > opts.MaxSelections) {
+		return nil, status.Error(
+			codes.InvalidArgument, "min_selections must not exceed max_selections or the number of choices")
+	}
+
+	if s.globalOptions.NoPrompt {
+		values, err := orderedSelectDefaults(opts)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "default_values is invalid: %v", err)
+		}
+		if len(values) < int(opts.MinSelections) {
+			return nil, &input.PromptRequiredError{PromptMessage: opts.Message}
+		}
+
+		s.echoResolved(promptLabel(opts.Message), strings.Join(opts.DefaultValues, ", "), "default")
+		return &azdext.PromptOrderedSelectResponse{Values: values}, nil
+	}
+
+	release, err := s.acquirePromptLock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if opts.Message != "" {
+		s.console.Message(ctx, output.WithHighLightFormat("? ")+ux.BoldString("%s:", opts.Message))
+	}
+
+	pick := func(remaining []*azdext.SelectChoice, picked int, canStop bool) (int, error) {
+		if canStop {
+			confirmMessage := "Pick a choice?"
+			if picked > 0 {
+				confirmMessage = fmt.Sprintf("Pick another choice? (%d so far)", picked)
+			}
+
+			pickAnother, err := ux.NewConfirm(&ux.ConfirmOptions{
+				Message:      confirmMessage,
+				HelpMessage:  opts.HelpMessage,
+				DefaultValue: new(picked == 0),
+			}).Ask(ctx)
+			if err != nil {
+				return 0, err
+			}
+			if pickAnother == nil || !*pickAnother {
+				return -1, nil
+			}
+		}
+
+		choices := make([]*ux.SelectChoice, len(remaining))
+		for i, choice := range remaining {
+			choices[i] = &ux.SelectChoice{
+				Value:       choice.Value,
+				Label:       choice.Label,
+				Description: choice.Description,
+			}
+		}
+
+		selected, err := ux.NewSelect(&ux.SelectOptions{
+			Message:         fmt.Sprintf("Choice %d", picked+1),
+			Choices:         choices,
+			HelpMessage:     opts.HelpMessage,
+			DisplayCount:    int(opts.DisplayCount),
+			EnableFiltering: opts.EnableFiltering,
+		}).Ask(ctx)
+		if err != nil {
+			return 0, err
+		}
+		if selected == nil {
+			return -1, nil
+		}
+
+		return *selected, nil
+	}
+
+	values, err := collectOrderedChoices(opts, pick)
+	if err != nil {
+		return nil, err
+	}
+
+	return &azdext.PromptOrderedSelectResponse{Values: values}, nil
+}
+
+// orderedSelectDefaults resolves the default values of a PromptOrderedSelect to their choices, keeping their order.
+// Every default must name a choice, at most once, and there must be no more than max_selections of them.
+func orderedSelectDefaults(opts *azdext.PromptOrderedSelectOptions) ([]*azdext.SelectChoice, error) {
+	if opts.MaxSelections > 0 && len(opts.DefaultValues) > int(opts.MaxSelections) {
+		return nil, fmt.Errorf("%d values exceed max_selections of %d", len(opts.DefaultValues), opts.MaxSelections)
+	}
+
+	values := make([]*azdext.SelectChoice, 0, len(opts.DefaultValues))
+	for _, value := range opts.DefaultValues {
+		i := slices.IndexFunc(opts.Choices, func(choice *azdext.SelectChoice) bool { return choice.Value == value })
+		if i < 0 {
+			return nil, fmt.Errorf("'%s' is not one of the choices", value)
+		}
+		if slices.Contains(values, opts.Choices[i]) {
+			return nil, fmt.Errorf("'%s' is listed more than once", value)
+		}
+		values = append(values, opts.Choices[i])
+	}
+
+	return values, nil
+}
+
+// collectOrderedChoices runs the pick loop of a PromptOrderedSelect. pick is called with the choices not picked yet,
+// the number picked so far and whether min_selections is reached, and returns the index of the next pick in
+// remaining, or a negative index when the user is done. The loop also ends once max_selections, or every choice, is
+// picked. The picks are returned in order.
+func collectOrderedChoices(
+	opts *azdext.PromptOrderedSelectOptions,
+	pick func(remaining []*azdext.SelectChoice, picked int, canStop bool) (int, error),
+) ([]*azdext.SelectChoice, error) {
+	limit := len(opts.Choices)
+	if opts.MaxSelections > 0 {
+		limit = min(limit, int(opts.MaxSelections))
+	}
+
+	remaining := slices.Clone(opts.Choices)
+	var values []*azdext.SelectChoice
+	for len(values) < limit {
+		canStop := len(values) >= int(opts.MinSelections)
+		i, err := pick(remaining, len(values), canStop)
+		if err != nil {
+			return nil, err
+		}
+		if i < 0 && canStop {
+			break
+		}
+		if i < 0 || i >= len(remaining) {
+			return nil, fmt.Errorf("pick %d is out of range", i)
+		}
+
+		values = append(values, remaining[i])
+		remaining = slices.Delete(remaining, i, i+1)
+	}
+
+	return values, nil
+}
+
+func (s *promptService) PromptSearchable(
+	stream azdext.PromptService_PromptSearchableServer,
+) error {
+	ctx := stream.Context()
+
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	opts := first.GetOptions()
+	if opts == nil {
+		return status.Error(codes.InvalidArgument, "the first message must carry the prompt options")
+	}
+
+	if s.globalOptions.NoPrompt {
+		return &input.PromptRequiredError{PromptMessage: opts.Message}
+	}
+
+	release, err := s.acquirePromptLock(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	bridge := &searchableBridge{stream: stream}
+	selectPrompt := ux.NewSelect(&ux.SelectOptions{
+		Message:            opts.Message,
+		HelpMessage:        opts.HelpMessage,
+		Hint:               opts.Hint,
+		DisplayCount:       int(opts.DisplayCount),
+		DisplayNumbers:     opts.DisplayNumbers,
+		AnnounceAutoSelect: opts.AnnounceAutoSelect,
+		Search:             bridge.search,
+	})
+
+	index, err := selectPrompt.Ask(ctx)
+	if err != nil {
+		return err
+	}
+	if index == nil || *index < 0 || *index >= len(bridge.last) {
+		return status.Error(codes.FailedPrecondition, "no choice was selected")
+	}
+
+	return stream.Send(&azdext.PromptSearchableServerMessage{
+		MessageType: &azdext.PromptSearchableServerMessage_Response{
+			Response: &azdext.PromptSearchableResponse{Value: bridge.last[*index]},
+		},
+	})
+}
+
+// searchableBridge forwards the filter text of a PromptSearchable prompt to the extension and keeps the choices of
+// the latest answer, which the index selected by the user refers to.
+type searchableBridge struct {
+	stream azdext.PromptService_PromptSearchableServer
+	nextId int32
+	last   []*azdext.SelectChoice
+}
+
+// search sends a query for filter and waits for the matching results.
+func (b *searchableBridge) search(ctx context.Context, filter string) ([]*ux.SelectChoice, error) {
+	b.nextId++
+	id := b.nextId
+
+	err := b.stream.Send(&azdext.PromptSearchableServerMessage{
+		MessageType: &azdext.PromptSearchableServerMessage_Query{
+			Query: &azdext.PromptSearchableQuery{Id: id, Filter: filter},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := b.stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+
+	results := msg.GetResults()
+	if results == nil {
+		return nil, status.Error(codes.InvalidArgument, "expected search results after a query")
+	}
+	if results.QueryId != id {
+		return nil, status.Errorf(codes.InvalidArgument, "search results answer query %d, expected %d", results.QueryId, id)
+	}
+
+	b.last = results.Choices
+	choices := make([]*ux.SelectChoice, len(results.Choices))
+	for i, choice := range results.Choices {
+		choices[i] = &ux.SelectChoice{
+			Value:       choice.Value,
+			Label:       choice.Label,
+			Description: choice.Description,
+		}
+	}
+
+	return choices, nil
+}
+
+func (s *promptService) PromptValidated(
+	stream azdext.PromptService_PromptValidatedServer,
+) error {
+	ctx := stream.Context()
+
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	opts := first.GetOptions()
+	if opts == nil {
+		return status.Error(codes.InvalidArgument, "the first message must carry the prompt options")
+	}
+
+	bridge := &validationBridge{stream: stream}
+
+	if s.globalOptions.NoPrompt {
+		if opts.Required && opts.DefaultValue == "" {
+			return &input.PromptRequiredError{PromptMessage: opts.Message}
+		}
+
+		// The default is returned as the value, so the extension validates it like a submitted one.
+		valid, message, err := bridge.validate(ctx, opts.DefaultValue)
+		if err != nil {
+			return err
+		}
+		if !valid {
+			return status.Errorf(
+				codes.FailedPrecondition, "the default value was rejected: %s", rejectionMessage(opts, message))
+		}
+
+		value := strconv.Quote(opts.DefaultValue)
+		if opts.Secret {
+			value = hiddenValue
+		}
+		s.echoResolved(promptLabel(opts.Message), value, "default")
+		return bridge.respond(opts.DefaultValue)
+	}
+
+	release, err := s.acquirePromptLock(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	ask := func(defaultValue string) (string, error) {
+		return ux.NewPrompt(&ux.PromptOptions{
+			DefaultValue:      defaultValue,
+			Message:           opts.Message,
+			HelpMessage:       opts.HelpMessage,
+			Hint:              opts.Hint,
+			PlaceHolder:       opts.Placeholder,
+			ValidationMessage: opts.ValidationMessage,
+			RequiredMessage:   opts.RequiredMessage,
+			Required:          opts.Required,
+			ClearOnCompletion: opts.ClearOnCompletion,
+			IgnoreHintKeys:    opts.IgnoreHintKeys,
+			Secret:            opts.Secret,
+		}).Ask(ctx)
+	}
+	reject := func(message string) {
+		s.console.Message(ctx, output.WithErrorFormat("%s", message))
+	}
+
+	value, err := promptUntilValid(ctx, opts, ask, bridge.validate, reject)
+	if err != nil {
+		return err
+	}
+
+	return bridge.respond(value)
+}
+
+// promptUntilValid asks for a value, starting from the default value of opts, until validate accepts it. A rejected
+// value is reported with reject and offered as the default of the next ask, so the user can correct it.
+func promptUntilValid(
+	ctx context.Context,
+	opts *azdext.PromptOptions,
+	ask func(defaultValue string) (string, error),
+	validate func(ctx context.Context, value string) (bool, string, error),
+	reject func(message string),
+) (string, error) {
+	value := opts.DefaultValue
+	for {
+		var err error
+		value, err = ask(value)
+		if err != nil {
+			return "", err
+		}
+
+		valid, message, err := validate(ctx, value)
+		if err != nil {
+			return "", err
+		}
+		if valid {
+			return value, nil
+		}
+
+		reject(rejectionMessage(opts, message))
+	}
+}
+
+// rejectionMessage returns the message shown for a rejected value: the one from the extension, else the validation
+// message of the prompt options.
+func rejectionMessage(opts *azdext.PromptOptions, message string) string {
+	return cmp.Or(message, opts.ValidationMessage, "The value is not valid")
+}
+
+// validationBridge sends the values submitted to a PromptValidated prompt to the extension for validation.
+type validationBridge struct {
+	stream azdext.PromptService_PromptValidatedServer
+	nextId int32
+}
+
+// validate sends a validation for value and waits for its result, returning whether the value is valid and the
+// message to show when it is not.
+func (b *validationBridge) validate(ctx context.Context, value string) (bool, string, error) {
+	b.nextId++
+	id := b.nextId
+
+	err := b.stream.Send(&azdext.PromptValidatedServerMessage{
+		MessageType: &azdext.PromptValidatedServerMessage_Validation{
+			Validation: &azdext.PromptValidation{Id: id, Value: value},
+		},
+	})
+	if err != nil {
+		return false, "", err
+	}
+
+	msg, err := b.stream.Recv()
+	if err != nil {
+		return false, "", err
+	}
+
+	result := msg.GetResult()
+	if result == nil {
+		return false, "", status.Error(codes.InvalidArgument, "expected a validation result after a validation")
+	}
+	if result.ValidationId != id {
+		return false, "", status.Errorf(
+			codes.InvalidArgument, "validation result answers validation %d, expected %d", result.ValidationId, id)
+	}
+
+	return result.Valid, result.Message, nil
+}
+
+// respond sends the accepted value, which ends the stream.
+func (b *validationBridge) respond(value string) error {
+	return b.stream.Send(&azdext.PromptValidatedServerMessage{
+		MessageType: &azdext.PromptValidatedServerMessage_Response{
+			Response: &azdext.PromptResponse{Value: value},
+		},
+	})
+}
+
+func (s *promptService) PromptEditor(
+	ctx context.Context,
+	req *azdext.PromptEditorRequest,
+) (*azdext.PromptEditorResponse, error) {
+	if req == nil || req.Options == nil {
+		return nil, status.Error(codes.InvalidArgument, "request and options are required")
+	}
+
+	opts := req.Options
+	if s.globalOptions.NoPrompt {
+		s.echoResolved(promptLabel(opts.Message), fmt.Sprintf("%d characters", len(opts.DefaultValue)), "default")
+		return &azdext.PromptEditorResponse{Value: opts.DefaultValue}, nil
+	}
+
+	release, err := s.acquirePromptLock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if editorArgs := editorCommand(); len(editorArgs) > 0 {
+		if err := s.commandRunner.ToolInPath(editorArgs[0]); err == nil {
+			value, err := s.editInEditor(ctx, editorArgs, opts)
+			if err != nil {
+				return nil, err
+			}
+
+			return &azdext.PromptEditorResponse{Value: value}, nil
+		}
+
+		log.Printf("editor '%s' was not found, falling back to terminal input", editorArgs[0])
+	}
+
+	value, err := ux.NewMultilinePrompt(&ux.MultilinePromptOptions{
+		Message:      opts.Message,
+		HelpMessage:  opts.HelpMessage,
+		DefaultValue: opts.DefaultValue,
+		Reader:       s.stdin,
+	}).Ask(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &azdext.PromptEditorResponse{Value: value}, nil
+}
+
+// editorCommand returns the editor command configured by $VISUAL or $EDITOR split into its arguments with shell
+// quoting rules, e.g. ["code", "--wait"], or nil when neither is set. Quoting keeps an editor path with spaces whole.
+func editorCommand() []string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		args, err := shellquote.Split(os.Getenv(name))
+		if err != nil {
+			log.Printf("ignoring $%s: %v", name, err)
+			continue
+		}
+		if len(args) > 0 {
+			return args
+		}
+	}
+
+	return nil
+}
+
+// editInEditor writes the default value of opts to a temporary file, opens it in the editor and returns the file
+// content once the editor exits.
+func (s *promptService) editInEditor(
+	ctx context.Context,
+	editorArgs []string,
+	opts *azdext.PromptEditorOptions,
+) (string, error) {
+	extension := opts.FileExtension
+	if extension != "" && !strings.HasPrefix(extension, ".") {
+		extension = "." + extension
+	}
+
+	file, err := os.CreateTemp("", "azd-edit-*"+extension)
+	if err != nil {
+		return "", fmt.Errorf("creating file to edit: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.WriteString(opts.DefaultValue)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("writing file to edit: %w", err)
+	}
+
+	if opts.Message != "" {
+		s.console.Message(ctx, fmt.Sprintf("%s%s %s",
+			output.WithHighLightFormat("? "),
+			ux.BoldString("%s:", opts.Message),
+			output.WithGrayFormat("[Waiting for the editor to close]"),
+		))
+	}
+
+	args := append(editorArgs[1:], file.Name())
+	runArgs := exec.NewRunArgs(editorArgs[0], args...).WithInteractive(true)
+	if _, err := s.commandRunner.Run(ctx, runArgs); err != nil {
+		return "", fmt.Errorf("running editor '%s': %w", editorArgs[0], err)
+	}
+
+	content, err := os.ReadFile(file.Name())
+	if err != nil {
+		return "", fmt.Errorf("reading edited file: %w", err)
+	}
+
+	return string(content), nil
+}
+
+func (s *promptService) PromptSubscription(
+	ctx context.Context,
+	req *azdext.PromptSubscriptionRequest,
+) (*azdext.PromptSubscriptionResponse, error) {
+	if s.globalOptions.NoPrompt {
+		return nil, &input.PromptRequiredError{PromptMessage: promptSubscriptionMessage(req)}
+	}
+
+	release, err := s.acquirePromptLock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	selectedSubscription, err := s.prompter.PromptSubscription(ctx, &prompt.SelectOptions{
+		Message:     req.Message,
+		HelpMessage: req.HelpMessage,
+	})
+	if errors.Is(err, internal.ErrNoSubscriptionsFound) {
+		return nil, &internal.ErrorWithSuggestion{
+			Err:     err,
+			Message: "No Azure subscriptions were found for the current account.",
+			Suggestion: "Run 'azd auth login' to sign in with an account that has access to an Azure subscription, " +
+				"or ask your administrator to grant you access to one.",
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	subscription := &azdext.Subscription{
+		Id:           selectedSubscription.Id,
+		Name:         selectedSubscription.Name,
+		TenantId:     selectedSubscription.TenantId,
+		UserTenantId: selectedSubscription.UserAccessTenantId,
+		IsDefault:    selectedSubscription.IsDefault,
+	}
+
+	return &azdext.PromptSubscriptionResponse{
+		Subscription: subscription,
+	}, nil
+}
+
+func (s *promptService) PromptLocation(
+	ctx context.Context,
+	req *azdext.PromptLocationRequest,
+) (*azdext.PromptLocationResponse, error) {
+	if s.globalOptions.NoPrompt {
+		return nil, &input.PromptRequiredError{PromptMessage: "Select location"}
+	}
+
+	release, err := s.acquirePromptLock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	azureContext, err := s.createAzureContext(req.AzureContext)
+	if err != nil {
+		return nil, err
+	}
+
+	var selectorOptions *prompt.SelectOptions
+	if len(req.AllowedLocations) > 0 {
+		selectorOptions = &prompt.SelectOptions{
+			AllowedValues: req.AllowedLocations,
+		}
+	}
+
+	selectedLocation, err := s.prompter.PromptLocation(ctx, azureContext, selectorOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	location := &azdext.Location{
+		Name:                selectedLocation.Name,
+		DisplayName:         selectedLocation.DisplayName,
+		RegionalDisplayName: selectedLocation.RegionalDisplayName,
+	}
+
+	return &azdext.PromptLocationResponse{
+		Location: location,
+	}, nil
+}
+
+func (s *promptService) PromptResourceGroup(
+	ctx context.Context,
+	req *azdext.PromptResourceGroupRequest,
+) (*azdext.PromptResourceGroupResponse, error) {
+	if s.globalOptions.NoPrompt {
+		return nil, &input.PromptRequiredError{PromptMessage: promptResourceGroupMessage(req)}
+	}
+
+	release, err := s.acquirePromptLock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	azureContext, err := s.createAzureContext(req.AzureContext)
+	if err != nil {
 		return nil, err
 	}
 
@@ -357,6 +1652,155 @@ func (s *promptService) PromptResourceGroup(
 	}, nil
 }
 
+func (s *promptService) PromptAzureScope(
+	ctx context.Context,
+	req *azdext.PromptAzureScopeRequest,
+) (*azdext.PromptAzureScopeResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is required")
+	}
+
+	// Work on a copy so the caller's context is never mutated.
+	wire := &azdext.AzureContext{Scope: &azdext.AzureScope{}}
+	if req.AzureContext != nil {
+		wire.Resources = req.AzureContext.Resources
+		if req.AzureContext.Scope != nil {
+			wire.Scope = &azdext.AzureScope{
+				TenantId:       req.AzureContext.Scope.TenantId,
+				SubscriptionId: req.AzureContext.Scope.SubscriptionId,
+				Location:       req.AzureContext.Scope.Location,
+				ResourceGroup:  req.AzureContext.Scope.ResourceGroup,
+			}
+		}
+	}
+
+	azureContext, err := s.createAzureContext(wire)
+	if err != nil {
+		return nil, err
+	}
+
+	supplied, seeded := azureContext.Scope, azureContext.Scope
+	if s.globalOptions.NoPrompt {
+		s.seedScopeFromEnvironment(&azureContext.Scope)
+		seeded = azureContext.Scope
+	} else {
+		release, err := s.acquirePromptLock(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+
+	if err := azureContext.EnsureSubscription(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := azureContext.EnsureLocation(ctx); err != nil {
+		return nil, err
+	}
+
+	if req.IncludeResourceGroup {
+		if err := azureContext.EnsureResourceGroup(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.globalOptions.NoPrompt {
+		scope := azureContext.Scope
+		s.echoResolved("subscription", scope.SubscriptionId, scopeSource(supplied.SubscriptionId, seeded.SubscriptionId))
+		s.echoResolved("location", scope.Location, scopeSource(supplied.Location, seeded.Location))
+		if req.IncludeResourceGroup {
+			s.echoResolved("resource group", scope.ResourceGroup, scopeSource(supplied.ResourceGroup, seeded.ResourceGroup))
+		}
+	}
+
+	return &azdext.PromptAzureScopeResponse{
+		AzureContext: &azdext.AzureContext{
+			Scope: &azdext.AzureScope{
+				TenantId:       azureContext.Scope.TenantId,
+				SubscriptionId: azureContext.Scope.SubscriptionId,
+				Location:       azureContext.Scope.Location,
+				ResourceGroup:  azureContext.Scope.ResourceGroup,
+			},
+			Resources: wire.Resources,
+		},
+	}, nil
+}
+
+// defaultAiLocation returns the location AI prompts check quota in when the request names none: the location of the
+// azure context, or else the location of the active azd environment. It returns "" when neither is set.
+func (s *promptService) defaultAiLocation(azureContext *azdext.AzureContext) string {
+	if location := azureContext.GetScope().GetLocation(); location != "" {
+		return location
+	}
+
+	var scope prompt.AzureScope
+	s.seedScopeFromEnvironment(&scope)
+	return scope.Location
+}
+
+// GetAzureContext returns the scope of the active azd environment without prompting. Values the environment does not
+// set, or all of them when no environment is selected, are left empty.
+func (s *promptService) GetAzureContext(
+	ctx context.Context,
+	req *azdext.GetAzureContextRequest,
+) (*azdext.GetAzureContextResponse, error) {
+	var scope prompt.AzureScope
+	s.seedScopeFromEnvironment(&scope)
+
+	return &azdext.GetAzureContextResponse{
+		AzureContext: &azdext.AzureContext{
+			Scope: &azdext.AzureScope{
+				TenantId:       scope.TenantId,
+				SubscriptionId: scope.SubscriptionId,
+				Location:       scope.Location,
+				ResourceGroup:  scope.ResourceGroup,
+			},
+		},
+	}, nil
+}
+
+// scopeSource describes where a no-prompt scope value came from, given the value the caller supplied and the value
+// after seeding from the azd environment.
+func scopeSource(supplied string, seeded string) string {
+	switch {
+	case supplied != "":
+		return "from azure context"
+	case seeded != "":
+		return "from azd environment"
+	default:
+		return "resolved by azd"
+	}
+}
+
+// seedScopeFromEnvironment fills any empty scope values from the active azd environment.
+// It is a no-op when no environment is available.
+func (s *promptService) seedScopeFromEnvironment(scope *prompt.AzureScope) {
+	if s.lazyEnv == nil {
+		return
+	}
+
+	env, err := s.lazyEnv.GetValue()
+	if err != nil || env == nil {
+		return
+	}
+
+	if scope.SubscriptionId == "" {
+		scope.SubscriptionId = env.GetSubscriptionId()
+		if scope.TenantId == "" {
+			scope.TenantId = env.GetTenantId()
+		}
+	}
+
+	if scope.Location == "" {
+		scope.Location = env.GetLocation()
+	}
+
+	if scope.ResourceGroup == "" {
+		scope.ResourceGroup = env.Getenv(environment.ResourceGroupEnvVarName)
+	}
+}
+
 func (s *promptService) PromptSubscriptionResource(
 	ctx context.Context,
 	req *azdext.PromptSubscriptionResourceRequest,
@@ -590,6 +2034,9 @@ func (s *promptService) PromptAiModel(
 	var locations []string
 	if req.Filter != nil {
 		filterOpts = protoToFilterOptions(req.Filter)
+		if err := validateFilterIntent(filterOpts); err != nil {
+			return nil, err
+		}
 		locations = filterOpts.Locations
 	}
 	var effectiveFilter *ai.FilterOptions
@@ -603,9 +2050,20 @@ func (s *promptService) PromptAiModel(
 		}
 		effectiveFilter.Locations = locations
 	}
+	if req.IncludeDeprecated {
+		if effectiveFilter == nil {
+			effectiveFilter = &ai.FilterOptions{}
+		}
+		effectiveFilter.IncludeDeprecated = true
+	}
+
+	if err := s.requireAiModelService(); err != nil {
+		return nil, err
+	}
 
 	var models []ai.AiModel
 	var usageMap map[string]ai.AiModelUsage
+	var availableCapabilities []string
 	loadModels := func(ctx context.Context, onProgress func(string)) error {
 		if onProgress != nil {
 			onProgress("Loading AI model catalog...")
@@ -614,7 +2072,11 @@ func (s *promptService) PromptAiModel(
 		var err error
 		// Both paths fetch canonical model data across subscription locations.
 		if effectiveFilter != nil {
-			models, err = s.aiModelService.ListFilteredModels(ctx, subscriptionId, effectiveFilter)
+			var catalog *ai.ModelCatalogResult
+			catalog, err = s.aiModelService.ListModelCatalog(ctx, subscriptionId, effectiveFilter)
+			if catalog != nil {
+				models, availableCapabilities = catalog.Models, catalog.AvailableCapabilities
+			}
 		} else {
 			models, err = s.aiModelService.ListModels(ctx, subscriptionId, nil)
 		}
@@ -658,7 +2120,11 @@ func (s *promptService) PromptAiModel(
 			}
 		}
 
-		if len(models) == 0 {
+		return nil
+	}
+
+	noModelsError := func() error {
+		if len(availableCapabilities) == 0 {
 			return aiStatusError(
 				codes.NotFound,
 				azdext.AiErrorReasonNoModelsMatch,
@@ -667,27 +2133,48 @@ func (s *promptService) PromptAiModel(
 			)
 		}
 
-		return nil
+		// The capability filter excluded every model, most likely because of a misspelled capability.
+		return aiStatusError(
+			codes.NotFound,
+			azdext.AiErrorReasonNoModelsMatch,
+			fmt.Sprintf(
+				"no models found with capabilities %s; available capabilities: %s",
+				strings.Join(effectiveFilter.Capabilities, ", "),
+				strings.Join(availableCapabilities, ", "),
+			),
+			map[string]string{"available_capabilities": strings.Join(availableCapabilities, ",")},
+		)
 	}
 
 	if s.globalOptions.NoPrompt {
 		if err := loadModels(ctx, nil); err != nil {
 			return nil, err
 		}
-	} else {
+		if len(models) == 0 {
+			return nil, noModelsError()
+		}
+
+		resp, err := selectModelNoPrompt(models, req.DefaultValue)
+		if err != nil {
+			return nil, err
+		}
+
+		s.echoResolved("model", resp.Model.Name, "default")
+		return resp, nil
+	}
+
+	runLoadModels := func() error {
 		spinner := ux.NewSpinner(&ux.SpinnerOptions{
 			Text: "Loading AI model catalog...",
 		})
 
-		if err := spinner.Run(ctx, func(ctx context.Context) error {
+		return spinner.Run(ctx, func(ctx context.Context) error {
 			return loadModels(ctx, spinner.UpdateText)
-		}); err != nil {
-			return nil, err
-		}
+		})
 	}
 
-	if s.globalOptions.NoPrompt {
-		return selectModelNoPrompt(models, req.DefaultValue)
+	if err := runLoadModels(); err != nil {
+		return nil, err
 	}
 
 	release, err := s.acquirePromptLock(ctx)
@@ -696,6 +2183,40 @@ func (s *promptService) PromptAiModel(
 	}
 	defer release()
 
+	// Offer to widen the search beyond the requested locations, keeping every other filter.
+	searchedOtherLocations := false
+	if len(models) == 0 && req.SearchOtherLocations && len(locations) > 0 {
+		searchOther, err := ux.NewConfirm(&ux.ConfirmOptions{
+			Message: fmt.Sprintf(
+				"No matching models in %s. Search other regions?", strings.Join(locations, ", ")),
+			DefaultValue: new(true),
+		}).Ask(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("prompting to search other regions: %w", err)
+		}
+
+		if searchOther != nil && *searchOther {
+			searchedOtherLocations = true
+			locations = nil
+			effectiveFilter.Locations = nil
+			usageMap = nil
+			if err := runLoadModels(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(models) == 0 {
+		return nil, noModelsError()
+	}
+
+	if req.GroupByFamily && modelFamiliesShared(models) {
+		models, err = promptAiModelFamily(ctx, models)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	message := "Select an AI model"
 	if req.SelectOptions != nil && req.SelectOptions.Message != "" {
 		message = req.SelectOptions.Message
@@ -718,7 +2239,9 @@ func (s *promptService) PromptAiModel(
 	}
 
 	if req.DefaultValue != "" {
-		selectOpts.SelectedIndex = findDefaultIndex(selectOpts.Choices, req.DefaultValue)
+		if name, ok := ai.ResolveModelName(modelNames(models), req.DefaultValue); ok {
+			selectOpts.SelectedIndex = findDefaultIndex(selectOpts.Choices, name)
+		}
 	}
 
 	selected, err := ux.NewSelect(selectOpts).Ask(ctx)
@@ -731,9 +2254,85 @@ func (s *promptService) PromptAiModel(
 		return nil, fmt.Errorf("converting selected model to proto: %w", err)
 	}
 
-	return &azdext.PromptAiModelResponse{
-		Model: protoModel,
-	}, nil
+	response := &azdext.PromptAiModelResponse{
+		Model: protoModel,
+	}
+
+	if searchedOtherLocations {
+		location, err := s.promptAiModelFallbackLocation(
+			ctx, subscriptionId, models[*selected], req.Quota, effectiveFilter.Capabilities, effectiveFilter.Formats)
+		if err != nil {
+			return nil, err
+		}
+		response.Location = &azdext.Location{Name: location}
+	}
+
+	return response, nil
+}
+
+// promptAiModelFallbackLocation prompts for a location offering model after PromptAiModel widened its search beyond
+// the requested locations. When quota is set, only locations with sufficient quota for a version with one of
+// capabilities and in one of formats, when any, are offered.
+func (s *promptService) promptAiModelFallbackLocation(
+	ctx context.Context,
+	subscriptionId string,
+	model ai.AiModel,
+	quota *azdext.QuotaCheckOptions,
+	capabilities []string,
+	formats []string,
+) (string, error) {
+	locations := slices.Sorted(slices.Values(model.Locations))
+	if quota != nil {
+		var quotaOpts []ai.QuotaCheckOption
+		if len(capabilities) > 0 {
+			quotaOpts = append(quotaOpts, ai.WithRequiredCapabilities(capabilities...))
+		}
+		if len(formats) > 0 {
+			quotaOpts = append(quotaOpts, ai.WithFormats(formats...))
+		}
+
+		result, err := s.aiModelService.EvaluateModelLocationsWithQuota(
+			ctx, subscriptionId, model.Name, nil, quota.MinRemainingCapacity, 0, quotaOpts...)
+		if err != nil {
+			return "", fmt.Errorf("checking quota for %s: %w", model.Name, err)
+		}
+		if len(result.Locations) == 0 {
+			return "", noModelLocationsWithQuotaError(model.Name, result)
+		}
+
+		locations = make([]string, len(result.Locations))
+		for i, location := range result.Locations {
+			locations[i] = location.Location
+		}
+	}
+
+	if len(locations) == 0 {
+		return "", aiStatusError(
+			codes.NotFound,
+			azdext.AiErrorReasonModelNotFound,
+			fmt.Sprintf("model %q is not offered in any location", model.Name),
+			map[string]string{"model_name": model.Name},
+		)
+	}
+	if len(locations) == 1 {
+		return locations[0], nil
+	}
+
+	choices := make([]*ux.SelectChoice, len(locations))
+	for i, location := range locations {
+		choices[i] = &ux.SelectChoice{Value: location, Label: location}
+	}
+
+	selected, err := ux.NewSelect(&ux.SelectOptions{
+		Message:         fmt.Sprintf("Select a location for %s", model.Name),
+		Choices:         choices,
+		EnableFiltering: new(true),
+	}).Ask(ctx)
+	if err != nil {
+		return "", fmt.Errorf("prompting for location: %w", err)
+	}
+
+	return locations[*selected], nil
 }
 
 func (s *promptService) PromptAiDeployment(
@@ -749,6 +2348,42 @@ func (s *promptService) PromptAiDeployment(
 		options = &ai.DeploymentOptions{}
 	}
 
+	if req.DesiredCapacity < 0 {
+		return nil, aiStatusError(
+			codes.InvalidArgument,
+			azdext.AiErrorReasonInvalidCapacity,
+			fmt.Sprintf("desired capacity must be greater than 0, got %d", req.DesiredCapacity),
+			map[string]string{"model_name": req.ModelName},
+		)
+	}
+
+	// A desired capacity replaces the preferred capacity, so quota checks require the SKU usage to cover it.
+	var desiredCapacity *int32
+	if req.DesiredCapacity > 0 {
+		desiredCapacity = &req.DesiredCapacity
+		options.Capacity = desiredCapacity
+	}
+
+	// Quota is checked in a single location, which defaults to the one of the azure context or azd environment.
+	if req.Quota != nil && len(options.Locations) == 0 {
+		if location := s.defaultAiLocation(req.AzureContext); location != "" {
+			options.Locations = []string{location}
+		}
+	}
+
+	// Accepting defaults settles on the location with the most quota rather than requiring a single one.
+	if req.AcceptDefaults && req.Quota != nil && len(options.Locations) != 1 {
+		if err := s.requireAiModelService(); err != nil {
+			return nil, err
+		}
+
+		location, err := s.bestQuotaAiLocation(ctx, subscriptionId, req, options.Locations)
+		if err != nil {
+			return nil, err
+		}
+		options.Locations = []string{location}
+	}
+
 	// Fail explicitly if quota is requested without exactly one location.
 	if req.Quota != nil && len(options.Locations) != 1 {
 		return nil, aiStatusError(
@@ -762,26 +2397,16 @@ func (s *promptService) PromptAiDeployment(
 		)
 	}
 
-	// Fetch the model catalog
-	models, err := s.aiModelService.ListModels(ctx, subscriptionId, options.Locations)
-	if err != nil {
-		return nil, fmt.Errorf("listing models: %w", err)
+	if err := s.requireAiModelService(); err != nil {
+		return nil, err
 	}
 
-	var targetModel *ai.AiModel
-	for i := range models {
-		if models[i].Name == req.ModelName {
-			targetModel = &models[i]
-			break
-		}
+	targetModel, err := s.aiModelService.FindModel(ctx, subscriptionId, options.Locations, req.ModelName)
+	if errors.Is(err, ai.ErrModelNotFound) || errors.Is(err, ai.ErrModelNotDeployable) {
+		return nil, mapAiResolveError(err, req.ModelName)
 	}
-	if targetModel == nil {
-		return nil, aiStatusError(
-			codes.NotFound,
-			azdext.AiErrorReasonModelNotFound,
-			fmt.Sprintf("model %q not found", req.ModelName),
-			map[string]string{"model_name": req.ModelName},
-		)
+	if err != nil {
+		return nil, fmt.Errorf("listing models: %w", err)
 	}
 
 	// Fetch quota data (guaranteed single location by check above)
@@ -795,9 +2420,27 @@ func (s *promptService) PromptAiDeployment(
 		for _, u := range usages {
 			usageMap[u.Name] = u
 		}
+
+		minAccountQuota := resolveMinAccountQuota(req.RequireAccountQuota, req.MinimumAccountQuota)
+		accountUsageName := ai.AccountCountUsageNameForKind(req.AccountKind)
+		if minAccountQuota > 0 && !ai.HasAccountQuota(usageMap, accountUsageName, minAccountQuota) {
+			return nil, aiStatusError(
+				codes.FailedPrecondition,
+				azdext.AiErrorReasonNoAccountQuota,
+				fmt.Sprintf(
+					"insufficient AI Services account quota in %s (requires %.0f remaining)",
+					options.Locations[0],
+					minAccountQuota,
+				),
+				map[string]string{
+					"location":   options.Locations[0],
+					"usage_name": accountUsageName,
+				},
+			)
+		}
 	}
 
-	if s.globalOptions.NoPrompt {
+	if s.globalOptions.NoPrompt && !req.AcceptDefaults {
 		return nil, aiStatusError(
 			codes.FailedPrecondition,
 			azdext.AiErrorReasonInteractiveRequired,
@@ -806,236 +2449,627 @@ func (s *promptService) PromptAiDeployment(
 		)
 	}
 
-	release, err := s.acquirePromptLock(ctx)
-	if err != nil {
-		return nil, err
+	if !s.globalOptions.NoPrompt {
+		release, err := s.acquirePromptLock(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
 	}
-	defer release()
 
 	// --- Step 1: Select version ---
 	// Collect available versions (filtered by options.versions if provided), along with
 	// precomputed valid SKU candidates so version and SKU steps stay consistent.
-	type versionCandidate struct {
-		version       ai.AiModelVersion
-		skuCandidates []skuCandidate
-		label         string
-	}
-	var availableVersions []versionCandidate
-	for _, v := range targetModel.Versions {
-		if len(options.Versions) > 0 && !slices.Contains(options.Versions, v.Version) {
-			continue
-		}
+	collectVersions := func(includeVersion func(ai.AiModelVersion) bool) []versionCandidate {
+		var candidates []versionCandidate
+		for _, v := range targetModel.Versions {
+			if !includeVersion(v) {
+				continue
+			}
 
-		skuCandidates := buildSkuCandidatesForVersion(
-			v,
-			options,
-			req.Quota,
-			usageMap,
-			req.IncludeFinetuneSkus,
-		)
-		if len(skuCandidates) == 0 {
-			continue
-		}
+			skuCandidates := buildSkuCandidatesForVersion(
+				v,
+				options,
+				req.Quota,
+				usageMap,
+				req.IncludeFinetuneSkus,
+			)
+			if desiredCapacity != nil {
+				skuCandidates = skuCandidatesSupportingCapacity(skuCandidates, *desiredCapacity)
+			}
+			if len(skuCandidates) == 0 {
+				continue
+			}
 
-		label := v.Version
-		if v.IsDefault {
-			label += " (default)"
-		}
-		if maxRemaining, ok := maxSkuCandidateRemaining(skuCandidates); ok {
-			label += " " + output.WithGrayFormat("[up to %.0f quota available]", maxRemaining)
+			label := v.Version
+			if v.IsDefault {
+				label += " (default)"
+			}
+			if maxRemaining, ok := maxSkuCandidateRemaining(skuCandidates); ok {
+				label += " " + output.WithGrayFormat("[up to %.0f quota available]", maxRemaining)
+			}
+
+			candidates = append(candidates, versionCandidate{
+				version:       v,
+				skuCandidates: skuCandidates,
+				label:         label,
+			})
 		}
 
-		availableVersions = append(availableVersions, versionCandidate{
-			version:       v,
-			skuCandidates: skuCandidates,
-			label:         label,
+		return candidates
+	}
+
+	availableVersions := collectVersions(func(v ai.AiModelVersion) bool {
+		return len(options.Versions) == 0 || slices.Contains(options.Versions, v.Version)
+	})
+	if len(availableVersions) == 0 && options.FallbackToDefaultVersion && len(options.Versions) > 0 {
+		availableVersions = collectVersions(func(v ai.AiModelVersion) bool {
+			return v.IsDefault
 		})
 	}
 	if len(availableVersions) == 0 {
-		return nil, aiStatusError(
-			codes.FailedPrecondition,
-			azdext.AiErrorReasonNoValidSkus,
-			fmt.Sprintf("no valid versions/SKUs found for model %q with the specified options", req.ModelName),
-			map[string]string{"model_name": req.ModelName},
-		)
+		message := fmt.Sprintf("no valid versions/SKUs found for model %q with the specified options", req.ModelName)
+		metadata := map[string]string{"model_name": req.ModelName}
+		if desiredCapacity != nil {
+			message = fmt.Sprintf(
+				"no valid versions/SKUs found for model %q that support a capacity of %d with the specified options",
+				req.ModelName,
+				*desiredCapacity,
+			)
+			metadata["desired_capacity"] = fmt.Sprintf("%d", *desiredCapacity)
+		}
+
+		return nil, aiStatusError(codes.FailedPrecondition, azdext.AiErrorReasonNoValidSkus, message, metadata)
+	}
+
+	// Offer the default version first, then the others from newest to oldest.
+	slices.SortStableFunc(availableVersions, func(a, b versionCandidate) int {
+		return ai.CompareModelVersionsNewestFirst(
+			a.version.Version, a.version.IsDefault, b.version.Version, b.version.IsDefault)
+	})
+
+	deployLocation := ""
+	if len(options.Locations) == 1 {
+		deployLocation = options.Locations[0]
+	}
+
+	if req.AcceptDefaults {
+		preferredSkus := options.Skus
+		if len(preferredSkus) == 0 {
+			preferredSkus = s.aiModelService.PreferredSkuOrder()
+		}
+
+		deployment, ok := defaultAiDeployment(
+			req.ModelName, targetModel.Format, availableVersions, preferredSkus, options.Capacity)
+		if !ok {
+			return nil, aiStatusError(
+				codes.FailedPrecondition,
+				azdext.AiErrorReasonNoDeploymentMatch,
+				fmt.Sprintf("no deployment match for model %q with its default SKU and capacity", req.ModelName),
+				map[string]string{"model_name": req.ModelName},
+			)
+		}
+		deployment.Location = deployLocation
+
+		accepted, err := s.confirmDefaultAiDeployment(ctx, deployment)
+		if err != nil {
+			return nil, err
+		}
+		if accepted {
+			return aiDeploymentResponse(deployment)
+		}
 	}
 
-	selectedVersionCandidate := availableVersions[0]
-	selectedVersionChosen := false
-	if req.UseDefaultVersion {
+	// The format, version and SKU are selected in a wizard, so the user can step back to change an earlier choice.
+	var selectedFormat string
+	var formatVersions []versionCandidate
+	var selectedVersionCandidate versionCandidate
+	var selectedSku skuCandidate
+
+	// --- Step 0: Select format ---
+	// The same model (and even the same version) can be offered in several formats at one location.
+	// Always make the format an explicit choice so their versions and SKUs are never presented together.
+	selectFormat := func(ctx context.Context, canGoBack bool) (bool, error) {
+		formats := []string{}
 		for _, v := range availableVersions {
-			if v.version.IsDefault {
-				selectedVersionCandidate = v
-				selectedVersionChosen = true
-				break
+			format := cmp.Or(v.version.Format, targetModel.Format)
+			if !slices.Contains(formats, format) {
+				formats = append(formats, format)
+			}
+		}
+		slices.Sort(formats)
+
+		prompted := false
+		selectedFormat = formats[0]
+		if len(formats) > 1 {
+			formatChoices := make([]*ux.SelectChoice, len(formats))
+			for i, format := range formats {
+				formatChoices[i] = &ux.SelectChoice{Value: format, Label: format}
+			}
+			fIdx, err := selectWizardStep(ctx, askSelect, &ux.SelectOptions{
+				Message: fmt.Sprintf("Select a format for %s", req.ModelName),
+				Choices: formatChoices,
+			}, canGoBack)
+			if err != nil {
+				return false, fmt.Errorf("prompting for format: %w", err)
 			}
+			selectedFormat = formats[fIdx]
+			prompted = true
 		}
+
+		formatVersions = slices.DeleteFunc(slices.Clone(availableVersions), func(v versionCandidate) bool {
+			return cmp.Or(v.version.Format, targetModel.Format) != selectedFormat
+		})
+
+		return prompted, nil
 	}
 
-	if !selectedVersionChosen {
-		versionChoices := make([]*ux.SelectChoice, len(availableVersions))
-		for i, v := range availableVersions {
-			versionChoices[i] = &ux.SelectChoice{Value: v.label, Label: v.label}
+	// --- Step 1: Select version ---
+	selectVersion := func(ctx context.Context, canGoBack bool) (bool, error) {
+		selectedVersionCandidate = formatVersions[0]
+		if req.UseDefaultVersion {
+			for _, v := range formatVersions {
+				if v.version.IsDefault {
+					selectedVersionCandidate = v
+					return false, nil
+				}
+			}
+		}
+
+		shownVersions := formatVersions
+		if !req.ShowAllVersions {
+			shownVersions = defaultVersionCandidates(formatVersions, targetModel.Format)
 		}
-		vIdx, err := ux.NewSelect(&ux.SelectOptions{
-			Message: fmt.Sprintf("Select a version for %s", req.ModelName),
-			Choices: versionChoices,
-		}).Ask(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("prompting for version: %w", err)
+
+		for {
+			versionChoices := make([]*ux.SelectChoice, len(shownVersions))
+			for i, v := range shownVersions {
+				versionChoices[i] = &ux.SelectChoice{Value: v.label, Label: v.label}
+			}
+			collapsed := len(shownVersions) < len(formatVersions)
+			if collapsed {
+				versionChoices = append(versionChoices, &ux.SelectChoice{
+					Value: showAllVersionsLabel,
+					Label: fmt.Sprintf("%s (%d more)", showAllVersionsLabel, len(formatVersions)-len(shownVersions)),
+				})
+			}
+
+			vIdx, err := selectWizardStep(ctx, askSelect, &ux.SelectOptions{
+				Message: fmt.Sprintf("Select a version for %s", req.ModelName),
+				Choices: versionChoices,
+			}, canGoBack)
+			if err != nil {
+				return false, fmt.Errorf("prompting for version: %w", err)
+			}
+			if collapsed && vIdx == len(shownVersions) {
+				shownVersions = formatVersions
+				continue
+			}
+			selectedVersionCandidate = shownVersions[vIdx]
+
+			confirmed, err := confirmNonDefaultVersion(
+				ctx, s.console, req.ModelName, selectedVersionCandidate, formatVersions)
+			if err != nil {
+				return false, err
+			}
+			if !confirmed {
+				continue
+			}
+
+			return true, nil
 		}
-		selectedVersionCandidate = availableVersions[*vIdx]
 	}
-	selectedVersion := selectedVersionCandidate.version
 
 	// --- Step 2: Select SKU ---
-	// Use precomputed candidates for the selected version to keep behavior consistent.
-	skuCandidates := slices.Clone(selectedVersionCandidate.skuCandidates)
+	selectSku := func(ctx context.Context, canGoBack bool) (bool, error) {
+		// Use precomputed candidates for the selected version to keep behavior consistent.
+		skuCandidates := slices.Clone(selectedVersionCandidate.skuCandidates)
+		selectedVersion := selectedVersionCandidate.version
 
-	if len(skuCandidates) == 0 {
+		if len(skuCandidates) == 0 {
+			return false, aiStatusError(
+				codes.FailedPrecondition,
+				azdext.AiErrorReasonNoValidSkus,
+				fmt.Sprintf("no valid SKUs found for model %q version %q", req.ModelName, selectedVersion.Version),
+				map[string]string{
+					"model_name": req.ModelName,
+					"version":    selectedVersion.Version,
+				},
+			)
+		}
+
+		labelSkuCandidates(skuCandidates, false)
+		sIdx, err := selectWizardStep(ctx, askSelect, &ux.SelectOptions{
+			Message: fmt.Sprintf("Select a SKU for %s v%s", req.ModelName, selectedVersion.Version),
+			Choices: skuCandidateChoices(skuCandidates),
+		}, canGoBack)
+		if err != nil {
+			return false, fmt.Errorf("prompting for SKU: %w", err)
+		}
+		selectedSku = skuCandidates[sIdx]
+
+		return true, nil
+	}
+
+	if err := runWizard(ctx, []wizardStep{selectFormat, selectVersion, selectSku}); err != nil {
+		return nil, err
+	}
+	selectedVersion := selectedVersionCandidate.version
+
+	// --- Step 3: Resolve capacity, optionally prompting ---
+	capacity, ok := resolveSkuCandidateCapacity(selectedSku, options.Capacity)
+	if !ok {
 		return nil, aiStatusError(
 			codes.FailedPrecondition,
-			azdext.AiErrorReasonNoValidSkus,
-			fmt.Sprintf("no valid SKUs found for model %q version %q", req.ModelName, selectedVersion.Version),
-			map[string]string{
-				"model_name": req.ModelName,
-				"version":    selectedVersion.Version,
-			},
+			azdext.AiErrorReasonNoDeploymentMatch,
+			fmt.Sprintf("no deployment match for model %q with the selected SKU and quota", req.ModelName),
+			map[string]string{"model_name": req.ModelName},
 		)
 	}
 
-	// Build labels: only include usage_name when SKU names are ambiguous.
-	skuNameCount := make(map[string]int, len(skuCandidates))
-	for _, c := range skuCandidates {
-		skuNameCount[c.sku.Name]++
+	if !req.UseDefaultCapacity && desiredCapacity == nil {
+		capacity, err = promptAiCapacity(ctx, req.ModelName, selectedSku, capacity)
+		if err != nil {
+			return nil, err
+		}
 	}
-	for i, c := range skuCandidates {
-		label := c.sku.Name
-		if skuNameCount[c.sku.Name] > 1 {
-			label += fmt.Sprintf(" (%s)", c.sku.UsageName)
+
+	deployment := &ai.AiModelDeployment{
+		ModelName:      req.ModelName,
+		Format:         selectedFormat,
+		Version:        selectedVersion.Version,
+		Location:       deployLocation,
+		Sku:            selectedSku.sku,
+		Capacity:       capacity,
+		RemainingQuota: selectedSku.remaining,
+	}
+
+	return aiDeploymentResponse(deployment)
+}
+
+// versionCandidate is a model version offered by PromptAiDeployment, with its valid SKU candidates precomputed so
+// the version and SKU steps stay consistent.
+type versionCandidate struct {
+	version       ai.AiModelVersion
+	skuCandidates []skuCandidate
+	label         string
+}
+
+// confirmNonDefaultVersion warns when selected is not the default version among candidates and asks whether to
+// continue with it, defaulting to yes so that pinning an older version stays a single keypress. It reports true
+// without asking when selected is the default or no candidate is.
+func confirmNonDefaultVersion(
+	ctx context.Context,
+	console input.Console,
+	modelName string,
+	selected versionCandidate,
+	candidates []versionCandidate,
+) (bool, error) {
+	defaultVersion := ""
+	if i := slices.IndexFunc(candidates, func(c versionCandidate) bool { return c.version.IsDefault }); i >= 0 {
+		defaultVersion = candidates[i].version.Version
+	}
+	warning := ai.NonDefaultVersionWarning(modelName, selected.version.Version, defaultVersion)
+	if warning == "" {
+		return true, nil
+	}
+
+	console.Message(ctx, output.WithWarningFormat("%s", warning))
+	confirmed, err := ux.NewConfirm(&ux.ConfirmOptions{
+		Message:      fmt.Sprintf("Continue with version %s?", selected.version.Version),
+		DefaultValue: new(true),
+		HelpMessage:  "Choose no to select a different version.",
+	}).Ask(ctx)
+	if err != nil {
+		return false, fmt.Errorf("prompting to confirm version: %w", err)
+	}
+
+	return confirmed != nil && *confirmed, nil
+}
+
+// showAllVersionsLabel is the label of the choice that expands a collapsed version prompt to every version.
+const showAllVersionsLabel = "Show all versions"
+
+// defaultVersionCandidates returns the candidates a collapsed version prompt lists, as chosen by
+// [ai.DefaultModelVersions] with modelFormat as the format of candidates that have none. candidates are ordered default
+// version first, then newest first, and keep that order.
+func defaultVersionCandidates(candidates []versionCandidate, modelFormat string) []versionCandidate {
+	versions := make([]ai.AiModelVersion, len(candidates))
+	for i, c := range candidates {
+		versions[i] = c.version
+	}
+	defaults := ai.DefaultModelVersions(versions, modelFormat)
+
+	return slices.DeleteFunc(slices.Clone(candidates), func(c versionCandidate) bool {
+		return !slices.ContainsFunc(defaults, func(v ai.AiModelVersion) bool {
+			return v.Version == c.version.Version && v.Format == c.version.Format
+		})
+	})
+}
+
+// defaultAiDeployment picks the deployment PromptAiDeployment accepts by default from versions, which are ordered
+// default version first, then newest first. It takes the first version in the format of the first version that has a
+// SKU with a valid capacity, the SKU ranked first by preferredSkus and that SKU's resolved capacity. The location is
+// left unset.
+func defaultAiDeployment(
+	modelName string,
+	modelFormat string,
+	versions []versionCandidate,
+	preferredSkus []string,
+	preferredCapacity *int32,
+) (*ai.AiModelDeployment, bool) {
+	if len(versions) == 0 {
+		return nil, false
+	}
+
+	format := cmp.Or(versions[0].version.Format, modelFormat)
+
+	for _, v := range versions {
+		if cmp.Or(v.version.Format, modelFormat) != format {
+			continue
 		}
-		if c.remaining != nil {
-			label += " " + output.WithGrayFormat("[%.0f quota available]", *c.remaining)
+
+		skuCandidates := slices.Clone(v.skuCandidates)
+		ai.SortSkusByPreference(skuCandidates, preferredSkus, func(c skuCandidate) string { return c.sku.Name })
+		for _, c := range skuCandidates {
+			capacity, ok := resolveSkuCandidateCapacity(c, preferredCapacity)
+			if !ok {
+				continue
+			}
+
+			return &ai.AiModelDeployment{
+				ModelName:      modelName,
+				Format:         format,
+				Version:        v.version.Version,
+				Sku:            c.sku,
+				Capacity:       capacity,
+				RemainingQuota: c.remaining,
+			}, true
 		}
-		skuCandidates[i].label = label
 	}
 
-	skuChoices := make([]*ux.SelectChoice, len(skuCandidates))
-	for i, c := range skuCandidates {
-		skuChoices[i] = &ux.SelectChoice{Value: c.label, Label: c.label}
+	return nil, false
+}
+
+// confirmDefaultAiDeployment shows the deployment picked by accepting defaults and asks to confirm it. In no-prompt
+// mode the deployment is accepted without asking.
+func (s *promptService) confirmDefaultAiDeployment(ctx context.Context, deployment *ai.AiModelDeployment) (bool, error) {
+	if s.globalOptions.NoPrompt {
+		s.echoResolved("deployment", defaultAiDeploymentLabel(deployment), "default")
+		return true, nil
+	}
+
+	rows := []*azdext.SummaryRow{
+		{Key: "Version", Value: deployment.Version},
+		{Key: "Format", Value: deployment.Format},
+		{Key: "SKU", Value: deployment.Sku.Name},
+		{Key: "Capacity", Value: strconv.Itoa(int(deployment.Capacity))},
+	}
+	if deployment.Location != "" {
+		rows = append(rows, &azdext.SummaryRow{Key: "Location", Value: deployment.Location})
+	}
+	if deployment.RemainingQuota != nil {
+		rows = append(rows, &azdext.SummaryRow{
+			Key:   "Quota available",
+			Value: strconv.FormatFloat(*deployment.RemainingQuota, 'f', 0, 64),
+		})
+	}
+
+	summary, err := formatSummary(fmt.Sprintf("Default deployment for %s", deployment.ModelName), rows)
+	if err != nil {
+		return false, fmt.Errorf("formatting summary: %w", err)
 	}
-	sIdx, err := ux.NewSelect(&ux.SelectOptions{
-		Message: fmt.Sprintf("Select a SKU for %s v%s", req.ModelName, selectedVersion.Version),
-		Choices: skuChoices,
+	s.console.Message(ctx, strings.TrimSuffix(summary, "\n"))
+
+	accepted, err := ux.NewConfirm(&ux.ConfirmOptions{
+		Message:      "Use this deployment?",
+		DefaultValue: new(true),
+		HelpMessage:  "Choose no to select the version, SKU and capacity yourself.",
 	}).Ask(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("prompting for SKU: %w", err)
+		return false, fmt.Errorf("prompting to confirm deployment: %w", err)
 	}
-	selectedSku := skuCandidates[*sIdx]
 
-	// --- Step 3: Resolve capacity, optionally prompting ---
-	capacity := ai.ResolveCapacity(selectedSku.sku, options.Capacity)
-	if req.Quota != nil && selectedSku.remaining != nil {
-		resolvedCapacity, ok := ai.ResolveCapacityWithQuota(selectedSku.sku, options.Capacity, *selectedSku.remaining)
-		if !ok {
-			return nil, aiStatusError(
-				codes.FailedPrecondition,
-				azdext.AiErrorReasonNoDeploymentMatch,
-				fmt.Sprintf("no deployment match for model %q with the selected SKU and quota", req.ModelName),
-				map[string]string{"model_name": req.ModelName},
-			)
+	return accepted != nil && *accepted, nil
+}
+
+// defaultAiDeploymentLabel describes deployment on one line, e.g. "gpt-4o 2024-08-06 (GlobalStandard, capacity 10)".
+func defaultAiDeploymentLabel(deployment *ai.AiModelDeployment) string {
+	label := fmt.Sprintf("%s %s (%s, capacity %d)",
+		deployment.ModelName, deployment.Version, deployment.Sku.Name, deployment.Capacity)
+	if deployment.Location != "" {
+		label += " in " + deployment.Location
+	}
+
+	return label
+}
+
+// bestQuotaAiLocation returns the location among allowedLocations with the most remaining quota for the model of req.
+// Empty allowedLocations allows every location offering the model.
+func (s *promptService) bestQuotaAiLocation(
+	ctx context.Context,
+	subscriptionId string,
+	req *azdext.PromptAiDeploymentRequest,
+	allowedLocations []string,
+) (string, error) {
+	result, err := s.aiModelService.EvaluateModelLocationsWithQuota(
+		ctx,
+		subscriptionId,
+		req.ModelName,
+		allowedLocations,
+		req.Quota.MinRemainingCapacity,
+		resolveMinAccountQuota(req.RequireAccountQuota, req.MinimumAccountQuota),
+		ai.WithAccountKind(req.AccountKind),
+	)
+	if err != nil {
+		return "", mapAiResolveError(err, req.ModelName)
+	}
+	if len(result.Locations) == 0 {
+		return "", noModelLocationsWithQuotaError(req.ModelName, result)
+	}
+
+	// Locations are sorted by name, so ties go to the first name.
+	best := result.Locations[0]
+	for _, location := range result.Locations[1:] {
+		if location.MaxRemainingQuota > best.MaxRemainingQuota {
+			best = location
+		}
+	}
+
+	return best.Location, nil
+}
+
+// aiDeploymentResponse converts deployment to the PromptAiDeployment response.
+func aiDeploymentResponse(deployment *ai.AiModelDeployment) (*azdext.PromptAiDeploymentResponse, error) {
+	var protoDeployment *azdext.AiModelDeployment
+	if err := mapper.Convert(deployment, &protoDeployment); err != nil {
+		return nil, fmt.Errorf("converting deployment to proto: %w", err)
+	}
+
+	return &azdext.PromptAiDeploymentResponse{
+		Deployment: protoDeployment,
+	}, nil
+}
+
+func (s *promptService) PromptAiSku(
+	ctx context.Context, req *azdext.PromptAiSkuRequest,
+) (*azdext.PromptAiSkuResponse, error) {
+	if len(req.Skus) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "skus is required")
+	}
+
+	location := req.Location
+	if req.Quota != nil && location == "" {
+		location = s.defaultAiLocation(req.AzureContext)
+	}
+
+	if req.Quota != nil && location == "" {
+		return nil, aiStatusError(
+			codes.InvalidArgument,
+			azdext.AiErrorReasonQuotaLocation,
+			"quota checking requires a location",
+			nil,
+		)
+	}
+
+	skus := make([]ai.AiModelSku, 0, len(req.Skus))
+	for _, protoSku := range req.Skus {
+		var sku *ai.AiModelSku
+		if err := mapper.Convert(protoSku, &sku); err != nil {
+			return nil, fmt.Errorf("converting SKU from proto: %w", err)
 		}
-		capacity = resolvedCapacity
+		skus = append(skus, *sku)
 	}
 
-	if !req.UseDefaultCapacity {
-		sku := selectedSku.sku
-		defaultVal := fmt.Sprintf("%d", capacity)
-		if capacity == 0 && sku.DefaultCapacity > 0 {
-			defaultVal = fmt.Sprintf("%d", sku.DefaultCapacity)
+	var usageMap map[string]ai.AiModelUsage
+	if req.Quota != nil {
+		subscriptionId, err := requirePromptSubscriptionID(req.AzureContext)
+		if err != nil {
+			return nil, err
 		}
 
-		hint := ""
-		if sku.MinCapacity > 0 || sku.MaxCapacity > 0 {
-			hint = fmt.Sprintf("min: %d, max: %d, step: %d", sku.MinCapacity, sku.MaxCapacity, sku.CapacityStep)
+		if err := s.requireAiModelService(); err != nil {
+			return nil, err
 		}
 
-		prompt := ux.NewPrompt(&ux.PromptOptions{
-			Message:      fmt.Sprintf("Enter deployment capacity for %s (%s)", req.ModelName, sku.Name),
-			DefaultValue: defaultVal,
-			HelpMessage:  hint,
-			Required:     true,
-			ValidationFn: func(value string) (bool, string) {
-				parsed, err := validateDeploymentCapacity(value, sku)
-				if err != nil {
-					return false, err.Error()
-				}
+		usages, err := s.aiModelService.ListUsages(ctx, subscriptionId, location)
+		if err != nil {
+			return nil, fmt.Errorf("getting usages: %w", err)
+		}
+		usageMap = make(map[string]ai.AiModelUsage, len(usages))
+		for _, u := range usages {
+			usageMap[u.Name] = u
+		}
+	}
 
-				if err := validateCapacityAgainstRemainingQuota(parsed, selectedSku.remaining); err != nil {
-					return false, err.Error()
-				}
+	// The SKUs are given explicitly, so fine-tune SKUs are only left out when the caller leaves them out.
+	skuCandidates := buildSkuCandidatesForVersion(ai.AiModelVersion{Skus: skus}, nil, req.Quota, usageMap, true)
+	if len(skuCandidates) == 0 {
+		return nil, aiStatusError(
+			codes.FailedPrecondition,
+			azdext.AiErrorReasonNoValidSkus,
+			fmt.Sprintf("no valid SKUs found for model %q with the specified options", req.ModelName),
+			map[string]string{"model_name": req.ModelName},
+		)
+	}
 
-				return true, ""
-			},
+	preferredIdx := 0
+	if req.PreferredSku != "" {
+		preferredIdx = slices.IndexFunc(skuCandidates, func(c skuCandidate) bool {
+			return strings.EqualFold(c.sku.Name, req.PreferredSku)
 		})
-		capStr, err := prompt.Ask(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("prompting for capacity: %w", err)
-		}
+	}
 
-		parsed, err := validateDeploymentCapacity(capStr, sku)
-		if err != nil {
+	var selectedSku skuCandidate
+	var capacity int32
+	if s.globalOptions.NoPrompt {
+		if preferredIdx < 0 {
 			return nil, aiStatusError(
-				codes.InvalidArgument,
-				azdext.AiErrorReasonInvalidCapacity,
-				fmt.Sprintf("invalid capacity %q: %v", capStr, err),
+				codes.FailedPrecondition,
+				azdext.AiErrorReasonNoValidSkus,
+				fmt.Sprintf("SKU %q is not available for model %q", req.PreferredSku, req.ModelName),
 				map[string]string{
 					"model_name": req.ModelName,
-					"sku":        sku.Name,
+					"sku":        req.PreferredSku,
 				},
 			)
 		}
 
-		if err := validateCapacityAgainstRemainingQuota(parsed, selectedSku.remaining); err != nil {
-			metadata := map[string]string{
-				"model_name": req.ModelName,
-				"sku":        sku.Name,
-			}
-			if selectedSku.remaining != nil {
-				metadata["remaining_quota"] = fmt.Sprintf("%.0f", *selectedSku.remaining)
-			}
+		selectedSku = skuCandidates[preferredIdx]
+		resolved, ok := resolveSkuCandidateCapacity(selectedSku, nil)
+		if !ok || resolved <= 0 {
 			return nil, aiStatusError(
-				codes.InvalidArgument,
-				azdext.AiErrorReasonInvalidCapacity,
-				fmt.Sprintf("invalid capacity %q: %v", capStr, err),
-				metadata,
+				codes.FailedPrecondition,
+				azdext.AiErrorReasonNoDeploymentMatch,
+				fmt.Sprintf(
+					"no default capacity available for model %q with SKU %q", req.ModelName, selectedSku.sku.Name),
+				map[string]string{
+					"model_name": req.ModelName,
+					"sku":        selectedSku.sku.Name,
+				},
 			)
 		}
-		capacity = parsed
-	}
+		capacity = resolved
+	} else {
+		release, err := s.acquirePromptLock(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
 
-	deployLocation := ""
-	if len(options.Locations) == 1 {
-		deployLocation = options.Locations[0]
-	}
+		message := fmt.Sprintf("Select a SKU for %s", req.ModelName)
+		if req.Version != "" {
+			message += fmt.Sprintf(" v%s", req.Version)
+		}
 
-	deployment := &ai.AiModelDeployment{
-		ModelName:      req.ModelName,
-		Format:         targetModel.Format,
-		Version:        selectedVersion.Version,
-		Location:       deployLocation,
-		Sku:            selectedSku.sku,
-		Capacity:       capacity,
-		RemainingQuota: selectedSku.remaining,
+		labelSkuCandidates(skuCandidates, true)
+		sIdx, err := askSelect(ctx, &ux.SelectOptions{
+			Message:       message,
+			Choices:       skuCandidateChoices(skuCandidates),
+			SelectedIndex: new(max(preferredIdx, 0)),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("prompting for SKU: %w", err)
+		}
+		selectedSku = skuCandidates[*sIdx]
+
+		// The resolved default is only a suggestion here, so an unresolved default falls back to the SKU default.
+		resolved, _ := resolveSkuCandidateCapacity(selectedSku, nil)
+		capacity, err = promptAiCapacity(ctx, req.ModelName, selectedSku, resolved)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	var protoDeployment *azdext.AiModelDeployment
-	if err := mapper.Convert(deployment, &protoDeployment); err != nil {
-		return nil, fmt.Errorf("converting deployment to proto: %w", err)
+	var protoSku *azdext.AiModelSku
+	if err := mapper.Convert(&selectedSku.sku, &protoSku); err != nil {
+		return nil, fmt.Errorf("converting SKU to proto: %w", err)
 	}
 
-	return &azdext.PromptAiDeploymentResponse{
-		Deployment: protoDeployment,
+	return &azdext.PromptAiSkuResponse{
+		Sku:            protoSku,
+		Capacity:       capacity,
+		RemainingQuota: selectedSku.remaining,
 	}, nil
 }
 
@@ -1055,13 +3089,17 @@ func (s *promptService) PromptAiLocationWithQuota(
 		}
 	}
 
-	locations, err := s.aiModelService.ListLocationsWithQuota(
+	if err := s.requireAiModelService(); err != nil {
+		return nil, err
+	}
+
+	locationNames, err := s.aiModelService.ListLocationsWithQuota(
 		ctx, subscriptionId, req.AllowedLocations, requirements)
 	if err != nil {
 		return nil, fmt.Errorf("listing locations with quota: %w", err)
 	}
 
-	if len(locations) == 0 {
+	if len(locationNames) == 0 {
 		return nil, aiStatusError(
 			codes.NotFound,
 			azdext.AiErrorReasonNoLocationsWithQuota,
@@ -1085,6 +3123,9 @@ func (s *promptService) PromptAiLocationWithQuota(
 	}
 	defer release()
 
+	locations := s.aiModelService.ResolveLocationDisplayNames(ctx, subscriptionId, locationNames)
+	ai.SortLocationsByProximity(locations, req.HomeLocation)
+
 	message := "Select a location"
 	if req.SelectOptions != nil && req.SelectOptions.Message != "" {
 		message = req.SelectOptions.Message
@@ -1097,8 +3138,8 @@ func (s *promptService) PromptAiLocationWithQuota(
 	}
 	for i, loc := range locations {
 		selectOpts.Choices[i] = &ux.SelectChoice{
-			Value: loc,
-			Label: loc,
+			Value: loc.Name,
+			Label: aiLocationLabel(loc) + aiLocationProximityLabel(loc, locations[0], req.HomeLocation),
 		}
 	}
 
@@ -1112,7 +3153,7 @@ func (s *promptService) PromptAiLocationWithQuota(
 	}
 
 	return &azdext.PromptAiLocationWithQuotaResponse{
-		Location: &azdext.Location{Name: locations[*selected]},
+		Location: toProtoLocation(locations[*selected]),
 	}, nil
 }
 
@@ -1132,27 +3173,39 @@ func (s *promptService) PromptAiModelLocationWithQuota(
 		minRemaining = req.Quota.MinRemainingCapacity
 	}
 
+	if err := s.requireAiModelService(); err != nil {
+		return nil, err
+	}
+
 	var locations []ai.ModelLocationQuota
 	loadLocations := func(ctx context.Context, onProgress func(string)) error {
 		if onProgress != nil {
 			onProgress(fmt.Sprintf("Checking quota availability for %s...", req.ModelName))
 		}
 
-		var err error
-		locations, err = s.aiModelService.ListModelLocationsWithQuota(
-			ctx, subscriptionId, req.ModelName, req.AllowedLocations, minRemaining)
+		var quotaOpts []ai.QuotaCheckOption
+		if onProgress != nil {
+			quotaOpts = append(quotaOpts, ai.WithQuotaProgress(func(progress ai.QuotaProgress) {
+				onProgress(fmt.Sprintf(
+					"Checking quota availability for %s (%d/%d regions, %d matched so far)...",
+					req.ModelName, progress.Checked, progress.Total, progress.Matched,
+				))
+			}))
+		}
+		if len(req.Capabilities) > 0 {
+			quotaOpts = append(quotaOpts, ai.WithRequiredCapabilities(req.Capabilities...))
+		}
+
+		result, err := s.aiModelService.EvaluateModelLocationsWithQuota(
+			ctx, subscriptionId, req.ModelName, req.AllowedLocations, minRemaining, 0, quotaOpts...)
 		if err != nil {
 			return mapAiResolveError(err, req.ModelName)
 		}
-		if len(locations) == 0 {
-			return aiStatusError(
-				codes.NotFound,
-				azdext.AiErrorReasonNoLocationsWithQuota,
-				"no locations found with sufficient quota",
-				nil,
-			)
+		if len(result.Locations) == 0 {
+			return noModelLocationsWithQuotaError(req.ModelName, result)
 		}
 
+		locations = result.Locations
 		return nil
 	}
 
@@ -1185,6 +3238,12 @@ func (s *promptService) PromptAiModelLocationWithQuota(
 	}
 	defer release()
 
+	locationNames := make([]string, len(locations))
+	for i, loc := range locations {
+		locationNames[i] = loc.Location
+	}
+	resolvedLocations := s.aiModelService.ResolveLocationDisplayNames(ctx, subscriptionId, locationNames)
+
 	message := "Select a location"
 	if req.SelectOptions != nil && req.SelectOptions.Message != "" {
 		message = req.SelectOptions.Message
@@ -1195,15 +3254,11 @@ func (s *promptService) PromptAiModelLocationWithQuota(
 		Choices:         make([]*ux.SelectChoice, len(locations)),
 		EnableFiltering: new(true),
 	}
+	showHeadroom := req.Quota != nil && req.Quota.MinRemainingCapacity > 0
 	for i, loc := range locations {
-		var label string
-		if loc.MaxRemainingQuota == ai.QuotaRemainingUnknown {
-			label = loc.Location
-		} else {
-			quotaLabel := output.WithGrayFormat(
-				"[up to %.0f quota available]",
-				loc.MaxRemainingQuota)
-			label = fmt.Sprintf("%s %s", loc.Location, quotaLabel)
+		label := aiLocationLabel(resolvedLocations[i])
+		if loc.MaxRemainingQuota != ai.QuotaRemainingUnknown {
+			label = fmt.Sprintf("%s %s", label, output.WithGrayFormat("[%s]", locationQuotaSummary(loc, showHeadroom)))
 		}
 		selectOpts.Choices[i] = &ux.SelectChoice{
 			Value: loc.Location,
@@ -1221,11 +3276,104 @@ func (s *promptService) PromptAiModelLocationWithQuota(
 	}
 
 	return &azdext.PromptAiModelLocationWithQuotaResponse{
-		Location:          &azdext.Location{Name: locations[*selected].Location},
+		Location:          toProtoLocation(resolvedLocations[*selected]),
 		MaxRemainingQuota: locations[*selected].MaxRemainingQuota,
 	}, nil
 }
 
+// locationQuotaSummary describes the quota available at a location, e.g. "up to 120 quota available". With
+// showHeadroom, it also describes the quota left once the required quota is deployed, e.g.
+// "up to 120 quota available, 70 left after deployment (42% used)".
+func locationQuotaSummary(loc ai.ModelLocationQuota, showHeadroom bool) string {
+	summary := fmt.Sprintf("up to %.0f quota available", loc.MaxRemainingQuota)
+	if headroom, utilization, ok := loc.Headroom(); ok && showHeadroom {
+		summary += fmt.Sprintf(", %.0f left after deployment (%.0f%% used)", headroom, utilization)
+	}
+
+	return summary
+}
+
+// noModelLocationsWithQuotaError reports that no location matched, distinguishing requested locations where the model
+// is not offered from locations where the model is offered but quota is short, and from locations that could not be
+// checked because of rate limiting.
+func noModelLocationsWithQuotaError(modelName string, result *ai.ModelLocationQuotaResult) error {
+	message := "no locations found with sufficient quota"
+	metadata := map[string]string{"model_name": modelName}
+
+	if len(result.ModelUnavailable) > 0 {
+		unavailable := strings.Join(result.ModelUnavailable, ", ")
+		message += fmt.Sprintf("; model %q is not offered in: %s", modelName, unavailable)
+		metadata["model_unavailable_locations"] = unavailable
+	}
+	if len(result.InsufficientQuota) > 0 {
+		insufficient := strings.Join(result.InsufficientQuota, ", ")
+		message += fmt.Sprintf("; insufficient quota in: %s", insufficient)
+		metadata["insufficient_quota_locations"] = insufficient
+	}
+	if throttled := ai.ThrottledLocations(result.FailedLocations); len(throttled) > 0 {
+		throttledList := strings.Join(throttled, ", ")
+		message += fmt.Sprintf("; could not check quota due to rate limiting in: %s (rerun to retry)", throttledList)
+		metadata["throttled_locations"] = throttledList
+	}
+
+	return aiStatusError(codes.NotFound, azdext.AiErrorReasonNoLocationsWithQuota, message, metadata)
+}
+
+// aiLocationProximityLabel annotates a location choice with its proximity to homeLocation, e.g. " [home]" or
+// " [same geography]". first is the first choice, which is the home location when it is among the choices.
+func aiLocationProximityLabel(location account.Location, first account.Location, homeLocation string) string {
+	if homeLocation == "" || !strings.EqualFold(first.Name, homeLocation) {
+		return ""
+	}
+
+	if strings.EqualFold(location.Name, homeLocation) {
+		return " " + output.WithGrayFormat("[home]")
+	}
+
+	if cluster := ai.LocationGeoCluster(first); cluster != "" && ai.LocationGeoCluster(location) == cluster {
+		return " " + output.WithGrayFormat("[same geography]")
+	}
+
+	return ""
+}
+
+// aiLocationLabel formats a location choice the same way as the standard location prompt, e.g. "(US) East US (eastus)".
+// Locations without display metadata are shown by region name only.
+func aiLocationLabel(location account.Location) string {
+	if location.RegionalDisplayName == "" || location.RegionalDisplayName == location.Name {
+		return location.Name
+	}
+
+	return fmt.Sprintf("%s %s", location.RegionalDisplayName, output.WithGrayFormat("(%s)", location.Name))
+}
+
+func toProtoLocation(location account.Location) *azdext.Location {
+	return &azdext.Location{
+		Name:                location.Name,
+		DisplayName:         location.DisplayName,
+		RegionalDisplayName: location.RegionalDisplayName,
+	}
+}
+
+// errAiServiceUnavailable reports that the host was started without an AI model service.
+var errAiServiceUnavailable = errors.New("ai service is unavailable")
+
+// requireAiModelService fails AI prompt methods with guidance when the host has no AI model service, as happens in
+// clouds that lack Azure AI Services, rather than with an opaque error.
+func (s *promptService) requireAiModelService() error {
+	if s.aiModelService != nil {
+		return nil
+	}
+
+	return &internal.ErrorWithSuggestion{
+		Err:     errAiServiceUnavailable,
+		Message: "Azure AI model features are not available in this azd session.",
+		Suggestion: "AI model prompts require a cloud with an endpoint that supports Azure AI Services. " +
+			"Run 'azd config get cloud.name' to check the configured cloud, and switch to one that offers " +
+			"Azure AI Services with 'azd config set cloud.name <name>'.",
+	}
+}
+
 func requirePromptSubscriptionID(azureContext *azdext.AzureContext) (string, error) {
 	if azureContext == nil || azureContext.Scope == nil || azureContext.Scope.SubscriptionId == "" {
 		return "", aiStatusError(
@@ -1240,27 +3388,34 @@ func requirePromptSubscriptionID(azureContext *azdext.AzureContext) (string, err
 }
 
 // selectModelNoPrompt handles model selection in non-interactive mode.
-// If defaultValue matches a model name (case-insensitive), it returns that model.
-// Returns NotFound if defaultValue doesn't match, or InteractiveRequired if no default is set.
+// If defaultValue resolves to a model name with [ai.ResolveModelName], it returns that model.
+// Returns NotFound, suggesting the closest model name, if defaultValue doesn't resolve,
+// or InteractiveRequired if no default is set.
 func selectModelNoPrompt(
 	models []ai.AiModel, defaultValue string,
 ) (*azdext.PromptAiModelResponse, error) {
 	if defaultValue != "" {
-		for i, m := range models {
-			if strings.EqualFold(m.Name, defaultValue) {
-				var protoModel *azdext.AiModel
-				if err := mapper.Convert(&models[i], &protoModel); err != nil {
-					return nil, fmt.Errorf("converting selected model to proto: %w", err)
-				}
-				return &azdext.PromptAiModelResponse{Model: protoModel}, nil
+		names := modelNames(models)
+		if name, ok := ai.ResolveModelName(names, defaultValue); ok {
+			var protoModel *azdext.AiModel
+			if err := mapper.Convert(&models[slices.Index(names, name)], &protoModel); err != nil {
+				return nil, fmt.Errorf("converting selected model to proto: %w", err)
 			}
+			return &azdext.PromptAiModelResponse{Model: protoModel}, nil
+		}
+
+		message := fmt.Sprintf("default model %q not found in available models", defaultValue)
+		metadata := map[string]string{"model_name": defaultValue}
+		if suggestion := ai.SuggestModelName(names, defaultValue); suggestion != "" {
+			message += fmt.Sprintf("; did you mean %q?", suggestion)
+			metadata["suggested_model_name"] = suggestion
 		}
 
 		return nil, aiStatusError(
 			codes.NotFound,
 			azdext.AiErrorReasonModelNotFound,
-			fmt.Sprintf("default model %q not found in available models", defaultValue),
-			map[string]string{"model_name": defaultValue},
+			message,
+			metadata,
 		)
 	}
 
@@ -1272,6 +3427,62 @@ func selectModelNoPrompt(
 	)
 }
 
+// modelNames returns the names of models, in order.
+func modelNames(models []ai.AiModel) []string {
+	names := make([]string, len(models))
+	for i, m := range models {
+		names[i] = m.Name
+	}
+
+	return names
+}
+
+// modelFamiliesShared reports whether at least two models belong to the same family,
+// which is when a family-first selection step is worthwhile.
+func modelFamiliesShared(models []ai.AiModel) bool {
+	seen := make(map[string]struct{}, len(models))
+	for _, m := range models {
+		if _, ok := seen[m.Family]; ok {
+			return true
+		}
+		seen[m.Family] = struct{}{}
+	}
+
+	return false
+}
+
+// promptAiModelFamily prompts for a model family and returns the models belonging to it.
+func promptAiModelFamily(ctx context.Context, models []ai.AiModel) ([]ai.AiModel, error) {
+	familyCounts := map[string]int{}
+	for _, m := range models {
+		familyCounts[m.Family]++
+	}
+
+	families := slices.Sorted(maps.Keys(familyCounts))
+	choices := make([]*ux.SelectChoice, len(families))
+	for i, family := range families {
+		label := family
+		if familyCounts[family] > 1 {
+			label += " " + output.WithGrayFormat("(%d models)", familyCounts[family])
+		}
+		choices[i] = &ux.SelectChoice{Value: family, Label: label}
+	}
+
+	selected, err := ux.NewSelect(&ux.SelectOptions{
+		Message:         "Select an AI model family",
+		Choices:         choices,
+		EnableFiltering: new(true),
+	}).Ask(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("prompting for model family selection: %w", err)
+	}
+
+	family := families[*selected]
+	return slices.DeleteFunc(slices.Clone(models), func(m ai.AiModel) bool {
+		return m.Family != family
+	}), nil
+}
+
 // findDefaultIndex returns a pointer to the index of the first choice whose value
 // matches defaultValue (case-insensitive), or nil if no match is found.
 func findDefaultIndex(choices []*ux.SelectChoice, defaultValue string) *int {
@@ -1371,6 +3582,13 @@ func buildSkuCandidatesForVersion(
 	return skuCandidates
 }
 
+// skuCandidatesSupportingCapacity returns the candidates whose SKU constraints accept capacity.
+func skuCandidatesSupportingCapacity(skuCandidates []skuCandidate, capacity int32) []skuCandidate {
+	return slices.DeleteFunc(skuCandidates, func(c skuCandidate) bool {
+		return ai.ValidateCapacity(c.sku, capacity) != nil
+	})
+}
+
 func maxSkuCandidateRemaining(skuCandidates []skuCandidate) (float64, bool) {
 	var maxRemaining float64
 	found := false
@@ -1389,44 +3607,213 @@ func maxSkuCandidateRemaining(skuCandidates []skuCandidate) (float64, bool) {
 	return maxRemaining, found
 }
 
-func validateDeploymentCapacity(value string, sku ai.AiModelSku) (int32, error) {
-	parsed, err := strconv.ParseInt(strings.TrimSpace(value), 10, 32)
-	if err != nil {
-		return 0, fmt.Errorf("capacity must be a whole number")
+func validateCapacityAgainstRemainingQuota(capacity int32, remaining *float64) error {
+	if remaining == nil {
+		return nil
 	}
 
-	capacity := int32(parsed)
-	if capacity <= 0 {
-		return 0, fmt.Errorf("capacity must be greater than 0")
+	if float64(capacity) > *remaining {
+		return fmt.Errorf("capacity must be at most %.0f due to available quota", *remaining)
 	}
 
-	if sku.MinCapacity > 0 && capacity < sku.MinCapacity {
-		return 0, fmt.Errorf("capacity must be at least %d", sku.MinCapacity)
+	return nil
+}
+
+// labelSkuCandidates sets the select label of each candidate. The usage name is only included when SKU names are
+// ambiguous. When showCapacityRange is set, the capacity range of each SKU is included as well.
+func labelSkuCandidates(skuCandidates []skuCandidate, showCapacityRange bool) {
+	skuNameCount := make(map[string]int, len(skuCandidates))
+	for _, c := range skuCandidates {
+		skuNameCount[c.sku.Name]++
 	}
 
-	if sku.MaxCapacity > 0 && capacity > sku.MaxCapacity {
-		return 0, fmt.Errorf("capacity must be at most %d", sku.MaxCapacity)
+	for i, c := range skuCandidates {
+		label := c.sku.Name
+		if skuNameCount[c.sku.Name] > 1 {
+			label += fmt.Sprintf(" (%s)", c.sku.UsageName)
+		}
+
+		var details []string
+		if showCapacityRange {
+			if description := ai.CapacityConstraintDescription(c.sku); description != "" {
+				details = append(details, "capacity "+description)
+			}
+		}
+		if c.remaining != nil {
+			details = append(details, fmt.Sprintf("%.0f quota available", *c.remaining))
+		}
+		if len(details) > 0 {
+			label += " " + output.WithGrayFormat("[%s]", strings.Join(details, ", "))
+		}
+
+		skuCandidates[i].label = label
 	}
+}
 
-	if sku.CapacityStep > 0 && capacity%sku.CapacityStep != 0 {
-		return 0, fmt.Errorf("capacity must be a multiple of %d", sku.CapacityStep)
+// skuCandidateChoices returns the select choices for labeled SKU candidates.
+func skuCandidateChoices(skuCandidates []skuCandidate) []*ux.SelectChoice {
+	choices := make([]*ux.SelectChoice, len(skuCandidates))
+	for i, c := range skuCandidates {
+		choices[i] = &ux.SelectChoice{
+			Value:       c.label,
+			Label:       c.label,
+			Description: ai.SkuDeploymentKindDescription(c.sku.DeploymentKind),
+		}
 	}
 
-	return capacity, nil
+	return choices
 }
 
-func validateCapacityAgainstRemainingQuota(capacity int32, remaining *float64) error {
-	if remaining == nil {
-		return nil
+// resolveSkuCandidateCapacity resolves the capacity to deploy candidate with, preferring preferred when the SKU
+// accepts it. When the remaining quota of the candidate is known, the capacity must fit within it.
+func resolveSkuCandidateCapacity(candidate skuCandidate, preferred *int32) (int32, bool) {
+	if candidate.remaining == nil {
+		return ai.ResolveCapacity(candidate.sku, preferred), true
 	}
 
-	if float64(capacity) > *remaining {
-		return fmt.Errorf("capacity must be at most %.0f due to available quota", *remaining)
+	return ai.ResolveCapacityWithQuota(candidate.sku, preferred, *candidate.remaining)
+}
+
+// promptAiCapacity prompts for a deployment capacity of candidate that satisfies its SKU constraints and remaining
+// quota, defaulting to capacity (or the SKU default when capacity is 0).
+func promptAiCapacity(ctx context.Context, modelName string, candidate skuCandidate, capacity int32) (int32, error) {
+	sku := candidate.sku
+	defaultVal := fmt.Sprintf("%d", capacity)
+	if capacity == 0 && sku.DefaultCapacity > 0 {
+		defaultVal = fmt.Sprintf("%d", sku.DefaultCapacity)
+	}
+
+	hint := ""
+	if description := ai.CapacityConstraintDescription(sku); description != "" {
+		hint = fmt.Sprintf("Capacity must be %s.", description)
+	}
+
+	prompt := ux.NewPrompt(&ux.PromptOptions{
+		Message:      fmt.Sprintf("Enter deployment capacity for %s (%s)", modelName, sku.Name),
+		DefaultValue: defaultVal,
+		HelpMessage:  hint,
+		Required:     true,
+		ValidationFn: func(value string) (bool, string) {
+			parsed, err := ai.ParseCapacity(sku, value)
+			if err != nil {
+				return false, err.Error()
+			}
+
+			if err := validateCapacityAgainstRemainingQuota(parsed, candidate.remaining); err != nil {
+				return false, err.Error()
+			}
+
+			return true, ""
+		},
+	})
+	capStr, err := prompt.Ask(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("prompting for capacity: %w", err)
+	}
+
+	parsed, err := ai.ParseCapacity(sku, capStr)
+	if err != nil {
+		return 0, aiStatusError(
+			codes.InvalidArgument,
+			azdext.AiErrorReasonInvalidCapacity,
+			fmt.Sprintf("invalid capacity %q: %v", capStr, err),
+			map[string]string{
+				"model_name": modelName,
+				"sku":        sku.Name,
+			},
+		)
+	}
+
+	if err := validateCapacityAgainstRemainingQuota(parsed, candidate.remaining); err != nil {
+		metadata := map[string]string{
+			"model_name": modelName,
+			"sku":        sku.Name,
+		}
+		if candidate.remaining != nil {
+			metadata["remaining_quota"] = fmt.Sprintf("%.0f", *candidate.remaining)
+		}
+		return 0, aiStatusError(
+			codes.InvalidArgument,
+			azdext.AiErrorReasonInvalidCapacity,
+			fmt.Sprintf("invalid capacity %q: %v", capStr, err),
+			metadata,
+		)
+	}
+
+	return parsed, nil
+}
+
+// errWizardBack is returned by a wizard step when the user chooses to return to the previous step.
+var errWizardBack = errors.New("back to previous step")
+
+// wizardBackLabel is the label of the choice that returns to the previous wizard step.
+const wizardBackLabel = "← Back"
+
+// wizardStep is one step of a multi-step prompt. canGoBack reports whether an earlier step prompted the user, so the
+// step may offer to go back to it. A step reports whether it prompted the user; steps that resolve without prompting
+// are skipped when going back. A step returns errWizardBack, possibly wrapped, to return to the previous step.
+type wizardStep func(ctx context.Context, canGoBack bool) (bool, error)
+
+// runWizard runs steps in order. When a step returns errWizardBack, the most recent step that prompted the user is
+// run again, followed by every step after it.
+func runWizard(ctx context.Context, steps []wizardStep) error {
+	var prompted []int
+	for i := 0; i < len(steps); {
+		didPrompt, err := steps[i](ctx, len(prompted) > 0)
+		if errors.Is(err, errWizardBack) && len(prompted) > 0 {
+			i = prompted[len(prompted)-1]
+			prompted = prompted[:len(prompted)-1]
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if didPrompt {
+			prompted = append(prompted, i)
+		}
+		i++
 	}
 
 	return nil
 }
 
+// wizardSelect asks a select prompt and returns the selected index.
+type wizardSelect func(ctx context.Context, options *ux.SelectOptions) (*int, error)
+
+func askSelect(ctx context.Context, options *ux.SelectOptions) (*int, error) {
+	return ux.NewSelect(options).Ask(ctx)
+}
+
+// selectWizardStep asks a select prompt for a wizard step. When canGoBack is set, a back choice is appended to the
+// choices, and choosing it returns errWizardBack.
+func selectWizardStep(
+	ctx context.Context,
+	ask wizardSelect,
+	options *ux.SelectOptions,
+	canGoBack bool,
+) (int, error) {
+	choiceCount := len(options.Choices)
+	if canGoBack {
+		withBack := *options
+		withBack.Choices = append(slices.Clone(options.Choices), &ux.SelectChoice{
+			Value: wizardBackLabel,
+			Label: wizardBackLabel,
+		})
+		options = &withBack
+	}
+
+	selected, err := ask(ctx, options)
+	if err != nil {
+		return 0, err
+	}
+	if *selected >= choiceCount {
+		return 0, errWizardBack
+	}
+
+	return *selected, nil
+}
+
 // promptLock is a context-aware mutual exclusion mechanism for serializing interactive prompts.
 // It prevents concurrent prompt access which could cause prompts to freeze up when multiple
 // extensions with "listen" capability are installed and running simultaneously.
@@ -1442,13 +3829,58 @@ func newPromptLock() *promptLock {
 // acquirePromptLock acquires the prompt lock, blocking until available or context is cancelled.
 // Returns a release function that must be called to release the lock (typically via defer).
 // Returns an error if the context is cancelled while waiting for the lock.
+//
+// The incoming gRPC context is checked first so that a request whose deadline already passed never renders a prompt,
+// even when the lock is free. Prompts pass the same context to Ask, so a client deadline that expires while the user
+// is answering cancels the pending terminal prompt.
+//
+// Time spent waiting for another prompt to release the lock is added to the usage telemetry, and waits of at least
+// promptLockWaitThreshold are logged with the RPC and extension that waited, to diagnose contention between extensions.
 func (s *promptService) acquirePromptLock(ctx context.Context) (func(), error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	release := func() {
+		<-s.lock.ch
+	}
+
 	select {
 	case s.lock.ch <- struct{}{}:
-		return func() {
-			<-s.lock.ch
-		}, nil
+		return release, nil
+	default:
+	}
+
+	start := time.Now()
+	defer func() {
+		recordPromptLockWait(ctx, time.Since(start))
+	}()
+
+	select {
+	case s.lock.ch <- struct{}{}:
+		return release, nil
 	case <-ctx.Done():
 		return nil, ctx.Err()
 	}
 }
+
+// promptLockWaitThreshold is how long a prompt waits for the prompt lock before the wait is logged.
+const promptLockWaitThreshold = 2 * time.Second
+
+// recordPromptLockWait records that the prompt RPC in ctx waited for the prompt lock.
+func recordPromptLockWait(ctx context.Context, wait time.Duration) {
+	tracing.IncrementUsageAttribute(fields.ExtensionPromptLockWaitCount.Int(1))
+	tracing.IncrementUsageAttribute(fields.ExtensionPromptLockWaitMs.Int64(wait.Milliseconds()))
+
+	if wait < promptLockWaitThreshold {
+		return
+	}
+
+	method, _ := grpc.Method(ctx)
+	extensionId := "unknown"
+	if claims, err := extensions.GetClaimsFromContext(ctx); err == nil {
+		extensionId = claims.Subject
+	}
+	log.Printf("prompt %s from extension '%s' waited %s for another prompt to finish",
+		cmp.Or(method, "(unknown)"), extensionId, wait.Round(time.Millisecond))
+}
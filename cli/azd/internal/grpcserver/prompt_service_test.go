@@ -6,35 +6,55 @@ package grpcserver
 import (
 	"context"
 	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
 	"slices"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cognitiveservices/armcognitiveservices/v2"
 	copilot "github.com/github/copilot-sdk/go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/internal/agent"
+	"github.com/azure/azure-dev/cli/azd/internal/tracing"
+	"github.com/azure/azure-dev/cli/azd/internal/tracing/fields"
 	"github.com/azure/azure-dev/cli/azd/pkg/account"
 	"github.com/azure/azure-dev/cli/azd/pkg/ai"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
 	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
 	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 	"github.com/azure/azure-dev/cli/azd/pkg/prompt"
 	"github.com/azure/azure-dev/cli/azd/pkg/ux"
 	"github.com/azure/azure-dev/cli/azd/pkg/watch"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockaccount"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockexec"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockinput"
 	"github.com/azure/azure-dev/cli/azd/test/mocks/mockprompt"
 )
 
 func Test_PromptService_Confirm_NoPromptWithDefault(t *testing.T) {
 	globalOptions := &internal.GlobalCommandOptions{NoPrompt: true}
-	service := NewPromptService(nil, nil, nil, globalOptions)
+	service := NewPromptService(nil, nil, nil, globalOptions, nil, nil, nil, nil, nil)
 
 	resp, err := service.Confirm(t.Context(), &azdext.ConfirmRequest{
 		Options: &azdext.ConfirmOptions{
@@ -50,7 +70,7 @@ func Test_PromptService_Confirm_NoPromptWithDefault(t *testing.T) {
 
 func Test_PromptService_Confirm_NoPromptWithoutDefault(t *testing.T) {
 	globalOptions := &internal.GlobalCommandOptions{NoPrompt: true}
-	service := NewPromptService(nil, nil, nil, globalOptions)
+	service := NewPromptService(nil, nil, nil, globalOptions, nil, nil, nil, nil, nil)
 
 	_, err := service.Confirm(t.Context(), &azdext.ConfirmRequest{
 		Options: &azdext.ConfirmOptions{
@@ -64,7 +84,7 @@ func Test_PromptService_Confirm_NoPromptWithoutDefault(t *testing.T) {
 
 func Test_PromptService_Select_NoPromptWithDefault(t *testing.T) {
 	globalOptions := &internal.GlobalCommandOptions{NoPrompt: true}
-	service := NewPromptService(nil, nil, nil, globalOptions)
+	service := NewPromptService(nil, nil, nil, globalOptions, nil, nil, nil, nil, nil)
 
 	resp, err := service.Select(t.Context(), &azdext.SelectRequest{
 		Options: &azdext.SelectOptions{
@@ -84,7 +104,7 @@ func Test_PromptService_Select_NoPromptWithDefault(t *testing.T) {
 
 func Test_PromptService_Select_NoPromptWithoutDefault(t *testing.T) {
 	globalOptions := &internal.GlobalCommandOptions{NoPrompt: true}
-	service := NewPromptService(nil, nil, nil, globalOptions)
+	service := NewPromptService(nil, nil, nil, globalOptions, nil, nil, nil, nil, nil)
 
 	_, err := service.Select(t.Context(), &azdext.SelectRequest{
 		Options: &azdext.SelectOptions{
@@ -101,7 +121,7 @@ func Test_PromptService_Select_NoPromptWithoutDefault(t *testing.T) {
 
 func Test_PromptService_MultiSelect_NoPrompt(t *testing.T) {
 	globalOptions := &internal.GlobalCommandOptions{NoPrompt: true}
-	service := NewPromptService(nil, nil, nil, globalOptions)
+	service := NewPromptService(nil, nil, nil, globalOptions, nil, nil, nil, nil, nil)
 
 	resp, err := service.MultiSelect(t.Context(), &azdext.MultiSelectRequest{
 		Options: &azdext.MultiSelectOptions{
@@ -120,9 +140,962 @@ func Test_PromptService_MultiSelect_NoPrompt(t *testing.T) {
 	require.Equal(t, "c", resp.Values[1].Value)
 }
 
+func testTreeChoices() []*azdext.TreeChoice {
+	return []*azdext.TreeChoice{
+		{
+			Value: "mg-contoso",
+			Label: "Contoso",
+			Children: []*azdext.TreeChoice{
+				{Value: "sub-dev", Label: "Dev"},
+				{Value: "sub-prod", Label: "Prod"},
+			},
+		},
+		{Value: "sub-sandbox", Label: "Sandbox"},
+	}
+}
+
+func Test_PromptService_PromptTree_NoPrompt(t *testing.T) {
+	globalOptions := &internal.GlobalCommandOptions{NoPrompt: true}
+	service := NewPromptService(nil, nil, nil, globalOptions, nil, nil, nil, nil, nil)
+
+	t.Run("selected path", func(t *testing.T) {
+		resp, err := service.PromptTree(t.Context(), &azdext.PromptTreeRequest{
+			Options: &azdext.PromptTreeOptions{
+				Message:      "Select a subscription",
+				Choices:      testTreeChoices(),
+				SelectedPath: []string{"mg-contoso", "sub-prod"},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, []string{"mg-contoso", "sub-prod"}, resp.Path)
+	})
+
+	t.Run("without selected path", func(t *testing.T) {
+		_, err := service.PromptTree(t.Context(), &azdext.PromptTreeRequest{
+			Options: &azdext.PromptTreeOptions{
+				Message: "Select a subscription",
+				Choices: testTreeChoices(),
+			},
+		})
+
+		require.Error(t, err)
+		requirePromptRequiredError(t, err, "Select a subscription")
+	})
+
+	t.Run("invalid selected path", func(t *testing.T) {
+		_, err := service.PromptTree(t.Context(), &azdext.PromptTreeRequest{
+			Options: &azdext.PromptTreeOptions{
+				Message:      "Select a subscription",
+				Choices:      testTreeChoices(),
+				SelectedPath: []string{"mg-contoso"},
+			},
+		})
+
+		require.Error(t, err)
+		st, ok := status.FromError(err)
+		require.True(t, ok)
+		require.Equal(t, codes.InvalidArgument, st.Code())
+	})
+}
+
+func Test_PromptService_PromptPath_NoPrompt(t *testing.T) {
+	globalOptions := &internal.GlobalCommandOptions{NoPrompt: true}
+	service := NewPromptService(nil, nil, nil, globalOptions, nil, nil, nil, nil, nil)
+	root := t.TempDir()
+
+	t.Run("valid default", func(t *testing.T) {
+		resp, err := service.PromptPath(t.Context(), &azdext.PromptPathRequest{
+			Options: &azdext.PromptPathOptions{
+				Message:      "Output directory",
+				DefaultValue: root,
+				MustExist:    true,
+				MustBeDir:    true,
+			},
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, root, resp.Path)
+	})
+
+	t.Run("missing default", func(t *testing.T) {
+		_, err := service.PromptPath(t.Context(), &azdext.PromptPathRequest{
+			Options: &azdext.PromptPathOptions{Message: "Output directory"},
+		})
+
+		require.Error(t, err)
+		requirePromptRequiredError(t, err, "Output directory")
+	})
+
+	t.Run("default violates constraints", func(t *testing.T) {
+		_, err := service.PromptPath(t.Context(), &azdext.PromptPathRequest{
+			Options: &azdext.PromptPathOptions{
+				Message:      "Output directory",
+				DefaultValue: filepath.Join(root, "missing"),
+				MustExist:    true,
+			},
+		})
+
+		require.Error(t, err)
+		st, ok := status.FromError(err)
+		require.True(t, ok)
+		require.Equal(t, codes.InvalidArgument, st.Code())
+		require.Contains(t, st.Message(), "does not exist")
+	})
+
+	t.Run("creates missing directory", func(t *testing.T) {
+		target := filepath.Join(root, "nested", "out")
+		resp, err := service.PromptPath(t.Context(), &azdext.PromptPathRequest{
+			Options: &azdext.PromptPathOptions{
+				Message:         "Output directory",
+				DefaultValue:    target,
+				MustExist:       true,
+				MustBeDir:       true,
+				CreateIfMissing: true,
+			},
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, target, resp.Path)
+		require.DirExists(t, target)
+	})
+
+	t.Run("conflicting options", func(t *testing.T) {
+		for _, opts := range []*azdext.PromptPathOptions{
+			{Message: "Path", DefaultValue: root, MustBeDir: true, MustBeFile: true},
+			{Message: "Path", DefaultValue: root, CreateIfMissing: true},
+		} {
+			_, err := service.PromptPath(t.Context(), &azdext.PromptPathRequest{Options: opts})
+
+			require.Error(t, err)
+			st, ok := status.FromError(err)
+			require.True(t, ok)
+			require.Equal(t, codes.InvalidArgument, st.Code())
+		}
+	})
+}
+
+func Test_validatePromptPath(t *testing.T) {
+	root := t.TempDir()
+	file := filepath.Join(root, "main.bicep")
+	require.NoError(t, os.WriteFile(file, []byte{}, osutil.PermissionFile))
+	missing := filepath.Join(root, "missing")
+
+	tests := []struct {
+		name    string
+		path    string
+		opts    *azdext.PromptPathOptions
+		wantErr string
+	}{
+		{name: "no constraints", path: missing, opts: &azdext.PromptPathOptions{}},
+		{name: "must exist", path: missing, opts: &azdext.PromptPathOptions{MustExist: true}, wantErr: "does not exist"},
+		{
+			name: "must exist but created",
+			path: missing,
+			opts: &azdext.PromptPathOptions{MustExist: true, MustBeDir: true, CreateIfMissing: true},
+		},
+		{name: "dir", path: root, opts: &azdext.PromptPathOptions{MustExist: true, MustBeDir: true}},
+		{name: "file as dir", path: file, opts: &azdext.PromptPathOptions{MustBeDir: true}, wantErr: "not a directory"},
+		{name: "file", path: file, opts: &azdext.PromptPathOptions{MustExist: true, MustBeFile: true}},
+		{name: "dir as file", path: root, opts: &azdext.PromptPathOptions{MustBeFile: true}, wantErr: "not a file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePromptPath(tt.path, tt.opts)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_completePath(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "services", "api"), osutil.PermissionDirectory))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "services", "web"), osutil.PermissionDirectory))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".azure"), osutil.PermissionDirectory))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "service.yaml"), []byte{}, osutil.PermissionFile))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "azure.yaml"), []byte{}, osutil.PermissionFile))
+
+	sep := string(filepath.Separator)
+	tests := []struct {
+		name     string
+		value    string
+		dirsOnly bool
+		want     string
+	}{
+		{name: "common prefix of file and dir", value: root + sep + "ser", want: root + sep + "service"},
+		{name: "dirs only", value: root + sep + "ser", dirsOnly: true, want: root + sep + "services" + sep},
+		{name: "nested dir", value: root + sep + "services" + sep + "a", want: root + sep + "services" + sep + "api" + sep},
+		{name: "hidden skipped", value: root + sep + "a", want: root + sep + "azure.yaml"},
+		{name: "hidden matched", value: root + sep + ".a", want: root + sep + ".azure" + sep},
+		{name: "no match", value: root + sep + "zzz", want: root + sep + "zzz"},
+		{name: "missing dir", value: root + sep + "missing" + sep + "x", want: root + sep + "missing" + sep + "x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, completePath(tt.value, tt.dirsOnly))
+		})
+	}
+}
+
+func Test_PromptService_PromptEditor_NoPrompt(t *testing.T) {
+	globalOptions := &internal.GlobalCommandOptions{NoPrompt: true}
+	service := NewPromptService(nil, nil, nil, globalOptions, nil, nil, nil, nil, nil)
+
+	t.Run("returns seed", func(t *testing.T) {
+		resp, err := service.PromptEditor(t.Context(), &azdext.PromptEditorRequest{
+			Options: &azdext.PromptEditorOptions{
+				Message:      "Edit the configuration",
+				DefaultValue: "name: api\nport: 8080\n",
+			},
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, "name: api\nport: 8080\n", resp.Value)
+	})
+
+	t.Run("missing options", func(t *testing.T) {
+		_, err := service.PromptEditor(t.Context(), &azdext.PromptEditorRequest{})
+
+		require.Error(t, err)
+		st, ok := status.FromError(err)
+		require.True(t, ok)
+		require.Equal(t, codes.InvalidArgument, st.Code())
+	})
+}
+
+func Test_PromptService_PromptDuration_NoPrompt(t *testing.T) {
+	globalOptions := &internal.GlobalCommandOptions{NoPrompt: true}
+	service := NewPromptService(nil, nil, nil, globalOptions, nil, nil, nil, nil, nil)
+
+	tests := []struct {
+		name            string
+		options         *azdext.PromptDurationOptions
+		wantNanoseconds int64
+		wantValue       string
+		wantCode        codes.Code
+	}{
+		{
+			name:            "returns default",
+			options:         &azdext.PromptDurationOptions{DefaultValue: "1h30m"},
+			wantNanoseconds: int64(90 * time.Minute),
+			wantValue:       "1h30m0s",
+		},
+		{
+			name:     "invalid default",
+			options:  &azdext.PromptDurationOptions{DefaultValue: "5 minutes"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "default below min",
+			options:  &azdext.PromptDurationOptions{DefaultValue: "10s", MinValue: "1m"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "invalid max",
+			options:  &azdext.PromptDurationOptions{DefaultValue: "10s", MaxValue: "soon"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "min greater than max",
+			options:  &azdext.PromptDurationOptions{DefaultValue: "10s", MinValue: "1h", MaxValue: "1m"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "missing options",
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := service.PromptDuration(t.Context(), &azdext.PromptDurationRequest{Options: tt.options})
+			if tt.wantCode != codes.OK {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.wantCode, st.Code())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantNanoseconds, resp.Nanoseconds)
+			require.Equal(t, tt.wantValue, resp.Value)
+		})
+	}
+
+	t.Run("no default", func(t *testing.T) {
+		_, err := service.PromptDuration(t.Context(), &azdext.PromptDurationRequest{
+			Options: &azdext.PromptDurationOptions{Message: "Polling interval"},
+		})
+
+		var promptRequiredErr *input.PromptRequiredError
+		require.ErrorAs(t, err, &promptRequiredErr)
+	})
+}
+
+func Test_PromptService_PromptKeyValues_NoPrompt(t *testing.T) {
+	globalOptions := &internal.GlobalCommandOptions{NoPrompt: true}
+	service := NewPromptService(nil, nil, nil, globalOptions, nil, nil, nil, nil, nil)
+
+	tests := []struct {
+		name       string
+		options    *azdext.PromptKeyValuesOptions
+		wantValues map[string]string
+		wantCode   codes.Code
+	}{
+		{
+			name: "returns defaults",
+			options: &azdext.PromptKeyValuesOptions{
+				DefaultValues: map[string]string{"env": "dev", "owner": "team"},
+				KeyPattern:    "^[a-z]+$",
+			},
+			wantValues: map[string]string{"env": "dev", "owner": "team"},
+		},
+		{
+			name:       "no defaults without minimum",
+			options:    &azdext.PromptKeyValuesOptions{},
+			wantValues: nil,
+		},
+		{
+			name: "default key does not match pattern",
+			options: &azdext.PromptKeyValuesOptions{
+				DefaultValues: map[string]string{"Env": "dev"},
+				KeyPattern:    "^[a-z]+$",
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "invalid pattern",
+			options:  &azdext.PromptKeyValuesOptions{KeyPattern: "["},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "negative minimum",
+			options:  &azdext.PromptKeyValuesOptions{MinEntries: -1},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "missing options",
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := service.PromptKeyValues(t.Context(), &azdext.PromptKeyValuesRequest{Options: tt.options})
+			if tt.wantCode != codes.OK {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.wantCode, st.Code())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantValues, resp.Values)
+		})
+	}
+
+	t.Run("fewer defaults than minimum", func(t *testing.T) {
+		_, err := service.PromptKeyValues(t.Context(), &azdext.PromptKeyValuesRequest{
+			Options: &azdext.PromptKeyValuesOptions{
+				Message:       "Resource tags",
+				MinEntries:    2,
+				DefaultValues: map[string]string{"env": "dev"},
+			},
+		})
+
+		var promptRequiredErr *input.PromptRequiredError
+		require.ErrorAs(t, err, &promptRequiredErr)
+	})
+}
+
+func Test_PromptService_PromptOrderedSelect_NoPrompt(t *testing.T) {
+	globalOptions := &internal.GlobalCommandOptions{NoPrompt: true}
+	service := NewPromptService(nil, nil, nil, globalOptions, nil, nil, nil, nil, nil)
+
+	choices := []*azdext.SelectChoice{
+		{Value: "eastus", Label: "East US"},
+		{Value: "westus", Label: "West US"},
+		{Value: "swedencentral", Label: "Sweden Central"},
+	}
+
+	tests := []struct {
+		name       string
+		options    *azdext.PromptOrderedSelectOptions
+		wantValues []string
+		wantCode   codes.Code
+	}{
+		{
+			name: "returns defaults in order",
+			options: &azdext.PromptOrderedSelectOptions{
+				Choices:       choices,
+				DefaultValues: []string{"swedencentral", "eastus"},
+			},
+			wantValues: []string{"swedencentral", "eastus"},
+		},
+		{
+			name:       "no defaults without minimum",
+			options:    &azdext.PromptOrderedSelectOptions{Choices: choices},
+			wantValues: []string{},
+		},
+		{
+			name: "default is not a choice",
+			options: &azdext.PromptOrderedSelectOptions{
+				Choices:       choices,
+				DefaultValues: []string{"centralus"},
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "duplicate default",
+			options: &azdext.PromptOrderedSelectOptions{
+				Choices:       choices,
+				DefaultValues: []string{"eastus", "eastus"},
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "more defaults than maximum",
+			options: &azdext.PromptOrderedSelectOptions{
+				Choices:       choices,
+				DefaultValues: []string{"eastus", "westus"},
+				MaxSelections: 1,
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "minimum above maximum",
+			options:  &azdext.PromptOrderedSelectOptions{Choices: choices, MinSelections: 2, MaxSelections: 1},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "no choices",
+			options:  &azdext.PromptOrderedSelectOptions{},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "missing options",
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := service.PromptOrderedSelect(t.Context(), &azdext.PromptOrderedSelectRequest{Options: tt.options})
+			if tt.wantCode != codes.OK {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.wantCode, st.Code())
+				return
+			}
+
+			require.NoError(t, err)
+			values := []string{}
+			for _, value := range resp.Values {
+				values = append(values, value.Value)
+			}
+			require.Equal(t, tt.wantValues, values)
+		})
+	}
+
+	t.Run("fewer defaults than minimum", func(t *testing.T) {
+		_, err := service.PromptOrderedSelect(t.Context(), &azdext.PromptOrderedSelectRequest{
+			Options: &azdext.PromptOrderedSelectOptions{
+				Message:       "Order the fallback regions",
+				Choices:       choices,
+				MinSelections: 2,
+				DefaultValues: []string{"eastus"},
+			},
+		})
+
+		var promptRequiredErr *input.PromptRequiredError
+		require.ErrorAs(t, err, &promptRequiredErr)
+	})
+}
+
+func Test_CollectOrderedChoices(t *testing.T) {
+	choices := []*azdext.SelectChoice{{Value: "a"}, {Value: "b"}, {Value: "c"}, {Value: "d"}}
+
+	// scriptedPicks picks the given values in order, then stops, recording what each pick was offered.
+	scriptedPicks := func(picks []string, offered *[][]string, canStops *[]bool) func(
+		[]*azdext.SelectChoice, int, bool) (int, error) {
+		return func(remaining []*azdext.SelectChoice, picked int, canStop bool) (int, error) {
+			var values []string
+			for _, choice := range remaining {
+				values = append(values, choice.Value)
+			}
+			*offered = append(*offered, values)
+			*canStops = append(*canStops, canStop)
+
+			if picked == len(picks) {
+				return -1, nil
+			}
+			return slices.Index(values, picks[picked]), nil
+		}
+	}
+
+	t.Run("preserves pick order", func(t *testing.T) {
+		var offered [][]string
+		var canStops []bool
+		values, err := collectOrderedChoices(
+			&azdext.PromptOrderedSelectOptions{Choices: choices, MinSelections: 1},
+			scriptedPicks([]string{"c", "a", "d"}, &offered, &canStops),
+		)
+		require.NoError(t, err)
+
+		require.Equal(t, []*azdext.SelectChoice{choices[2], choices[0], choices[3]}, values)
+		// Picked choices are not offered again.
+		require.Equal(t, [][]string{{"a", "b", "c", "d"}, {"a", "b", "d"}, {"b", "d"}, {"b"}}, offered)
+		require.Equal(t, []bool{false, true, true, true}, canStops)
+	})
+
+	t.Run("ends at maximum", func(t *testing.T) {
+		var offered [][]string
+		var canStops []bool
+		values, err := collectOrderedChoices(
+			&azdext.PromptOrderedSelectOptions{Choices: choices, MaxSelections: 2},
+			scriptedPicks([]string{"b", "a", "c"}, &offered, &canStops),
+		)
+		require.NoError(t, err)
+		require.Equal(t, []*azdext.SelectChoice{choices[1], choices[0]}, values)
+		require.Len(t, offered, 2)
+	})
+
+	t.Run("cannot stop before minimum", func(t *testing.T) {
+		_, err := collectOrderedChoices(
+			&azdext.PromptOrderedSelectOptions{Choices: choices, MinSelections: 1},
+			func([]*azdext.SelectChoice, int, bool) (int, error) { return -1, nil },
+		)
+		require.Error(t, err)
+	})
+}
+
+// scriptedSearchableStream is a PromptSearchable stream that replays the extension messages and records what the
+// host sends.
+type scriptedSearchableStream struct {
+	grpc.ServerStream
+
+	ctx  context.Context
+	recv []*azdext.PromptSearchableClientMessage
+	sent []*azdext.PromptSearchableServerMessage
+}
+
+func (s *scriptedSearchableStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *scriptedSearchableStream) Send(msg *azdext.PromptSearchableServerMessage) error {
+	s.sent = append(s.sent, msg)
+	return nil
+}
+
+func (s *scriptedSearchableStream) Recv() (*azdext.PromptSearchableClientMessage, error) {
+	if len(s.recv) == 0 {
+		return nil, errors.New("no more messages")
+	}
+	msg := s.recv[0]
+	s.recv = s.recv[1:]
+	return msg, nil
+}
+
+func searchableResults(queryId int32, values ...string) *azdext.PromptSearchableClientMessage {
+	choices := make([]*azdext.SelectChoice, len(values))
+	for i, value := range values {
+		choices[i] = &azdext.SelectChoice{Value: value, Label: value}
+	}
+	return &azdext.PromptSearchableClientMessage{
+		MessageType: &azdext.PromptSearchableClientMessage_Results{
+			Results: &azdext.PromptSearchableResults{QueryId: queryId, Choices: choices},
+		},
+	}
+}
+
+func Test_PromptService_PromptSearchable_NoPrompt(t *testing.T) {
+	globalOptions := &internal.GlobalCommandOptions{NoPrompt: true}
+	service := NewPromptService(nil, nil, nil, globalOptions, nil, nil, nil, nil, nil)
+
+	t.Run("requires a prompt", func(t *testing.T) {
+		stream := &scriptedSearchableStream{
+			ctx: t.Context(),
+			recv: []*azdext.PromptSearchableClientMessage{{
+				MessageType: &azdext.PromptSearchableClientMessage_Options{
+					Options: &azdext.PromptSearchableOptions{Message: "Pick a model"},
+				},
+			}},
+		}
+
+		err := service.PromptSearchable(stream)
+		requirePromptRequiredError(t, err, "Pick a model")
+		require.Empty(t, stream.sent)
+	})
+
+	t.Run("missing options", func(t *testing.T) {
+		stream := &scriptedSearchableStream{
+			ctx:  t.Context(),
+			recv: []*azdext.PromptSearchableClientMessage{searchableResults(1, "gpt-4o")},
+		}
+
+		err := service.PromptSearchable(stream)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func Test_searchableBridge_search(t *testing.T) {
+	t.Run("echoes the filter", func(t *testing.T) {
+		stream := &scriptedSearchableStream{
+			ctx: t.Context(),
+			recv: []*azdext.PromptSearchableClientMessage{
+				searchableResults(1, "", "all"),
+				searchableResults(2, "gp"),
+			},
+		}
+		bridge := &searchableBridge{stream: stream}
+
+		choices, err := bridge.search(t.Context(), "")
+		require.NoError(t, err)
+		require.Len(t, choices, 2)
+
+		choices, err = bridge.search(t.Context(), "gp")
+		require.NoError(t, err)
+		require.Len(t, choices, 1)
+		require.Equal(t, "gp", choices[0].Value)
+		require.Equal(t, "gp", bridge.last[0].Value)
+
+		require.Len(t, stream.sent, 2)
+		require.Equal(t, int32(1), stream.sent[0].GetQuery().GetId())
+		require.Equal(t, "", stream.sent[0].GetQuery().GetFilter())
+		require.Equal(t, int32(2), stream.sent[1].GetQuery().GetId())
+		require.Equal(t, "gp", stream.sent[1].GetQuery().GetFilter())
+	})
+
+	t.Run("rejects results for another query", func(t *testing.T) {
+		stream := &scriptedSearchableStream{
+			ctx:  t.Context(),
+			recv: []*azdext.PromptSearchableClientMessage{searchableResults(7, "gpt-4o")},
+		}
+		bridge := &searchableBridge{stream: stream}
+
+		_, err := bridge.search(t.Context(), "gpt")
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+// scriptedValidatedStream is a PromptValidated stream that plays an extension validating with validate, and records
+// what the host sends.
+type scriptedValidatedStream struct {
+	grpc.ServerStream
+
+	ctx      context.Context
+	options  *azdext.PromptOptions
+	validate func(value string) (bool, string)
+	sent     []*azdext.PromptValidatedServerMessage
+}
+
+func (s *scriptedValidatedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *scriptedValidatedStream) Send(msg *azdext.PromptValidatedServerMessage) error {
+	s.sent = append(s.sent, msg)
+	return nil
+}
+
+func (s *scriptedValidatedStream) Recv() (*azdext.PromptValidatedClientMessage, error) {
+	if s.options != nil {
+		options := s.options
+		s.options = nil
+		return &azdext.PromptValidatedClientMessage{
+			MessageType: &azdext.PromptValidatedClientMessage_Options{Options: options},
+		}, nil
+	}
+
+	if len(s.sent) == 0 || s.sent[len(s.sent)-1].GetValidation() == nil {
+		return nil, errors.New("no more messages")
+	}
+
+	validation := s.sent[len(s.sent)-1].GetValidation()
+	valid, message := s.validate(validation.Value)
+	return &azdext.PromptValidatedClientMessage{
+		MessageType: &azdext.PromptValidatedClientMessage_Result{
+			Result: &azdext.PromptValidationResult{ValidationId: validation.Id, Valid: valid, Message: message},
+		},
+	}, nil
+}
+
+// echoValidate rejects "taken", echoing it in the message, and accepts anything else.
+func echoValidate(value string) (bool, string) {
+	if value == "taken" {
+		return false, "rejected: " + value
+	}
+	return true, ""
+}
+
+func Test_PromptService_PromptValidated_NoPrompt(t *testing.T) {
+	globalOptions := &internal.GlobalCommandOptions{NoPrompt: true}
+	service := NewPromptService(nil, nil, nil, globalOptions, nil, nil, nil, nil, nil)
+
+	t.Run("returns the accepted default", func(t *testing.T) {
+		stream := &scriptedValidatedStream{
+			ctx:      t.Context(),
+			options:  &azdext.PromptOptions{Message: "Enter a name", DefaultValue: "free"},
+			validate: echoValidate,
+		}
+
+		require.NoError(t, service.PromptValidated(stream))
+		require.Len(t, stream.sent, 2)
+		require.Equal(t, "free", stream.sent[0].GetValidation().GetValue())
+		require.Equal(t, "free", stream.sent[1].GetResponse().GetValue())
+	})
+
+	t.Run("fails on a rejected default", func(t *testing.T) {
+		stream := &scriptedValidatedStream{
+			ctx:      t.Context(),
+			options:  &azdext.PromptOptions{Message: "Enter a name", DefaultValue: "taken"},
+			validate: echoValidate,
+		}
+
+		err := service.PromptValidated(stream)
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+		require.Contains(t, err.Error(), "rejected: taken")
+		require.Nil(t, stream.sent[len(stream.sent)-1].GetResponse())
+	})
+
+	t.Run("requires a prompt without a default", func(t *testing.T) {
+		stream := &scriptedValidatedStream{
+			ctx:      t.Context(),
+			options:  &azdext.PromptOptions{Message: "Enter a name", Required: true},
+			validate: echoValidate,
+		}
+
+		err := service.PromptValidated(stream)
+		requirePromptRequiredError(t, err, "Enter a name")
+		require.Empty(t, stream.sent)
+	})
+
+	t.Run("missing options", func(t *testing.T) {
+		stream := &scriptedValidatedStream{ctx: t.Context(), validate: echoValidate}
+
+		err := service.PromptValidated(stream)
+		require.Error(t, err)
+		require.Empty(t, stream.sent)
+	})
+}
+
+func Test_PromptUntilValid(t *testing.T) {
+	stream := &scriptedValidatedStream{ctx: t.Context(), validate: echoValidate}
+	bridge := &validationBridge{stream: stream}
+	opts := &azdext.PromptOptions{DefaultValue: "web"}
+
+	// The user first submits "taken", then edits the offered rejected value into "free".
+	answers := []string{"taken", "free"}
+	var defaults []string
+	ask := func(defaultValue string) (string, error) {
+		defaults = append(defaults, defaultValue)
+		answer := answers[0]
+		answers = answers[1:]
+		return answer, nil
+	}
+	var rejections []string
+	reject := func(message string) { rejections = append(rejections, message) }
+
+	value, err := promptUntilValid(t.Context(), opts, ask, bridge.validate, reject)
+	require.NoError(t, err)
+	require.Equal(t, "free", value)
+
+	require.Equal(t, []string{"web", "taken"}, defaults)
+	require.Equal(t, []string{"rejected: taken"}, rejections)
+	require.Len(t, stream.sent, 2)
+	require.Equal(t, int32(1), stream.sent[0].GetValidation().GetId())
+	require.Equal(t, int32(2), stream.sent[1].GetValidation().GetId())
+}
+
+func Test_RejectionMessage(t *testing.T) {
+	require.Equal(t, "taken", rejectionMessage(&azdext.PromptOptions{ValidationMessage: "invalid"}, "taken"))
+	require.Equal(t, "invalid", rejectionMessage(&azdext.PromptOptions{ValidationMessage: "invalid"}, ""))
+	require.Equal(t, "The value is not valid", rejectionMessage(&azdext.PromptOptions{}, ""))
+}
+
+func Test_ValidateKeyValueKey(t *testing.T) {
+	opts := &azdext.PromptKeyValuesOptions{KeyPattern: "^[A-Z_][A-Z0-9_]*$"}
+	pattern := regexp.MustCompile("^(?:" + opts.KeyPattern + ")$")
+
+	require.NoError(t, validateKeyValueKey("API_KEY", pattern, opts))
+	require.NoError(t, validateKeyValueKey("anything", nil, &azdext.PromptKeyValuesOptions{}))
+	require.EqualError(t, validateKeyValueKey(" ", nil, opts), "a key is required")
+	require.EqualError(t, validateKeyValueKey("api-key", pattern, opts), "'api-key' must match ^[A-Z_][A-Z0-9_]*$")
+
+	opts.KeyValidationMessage = "Use upper-case letters"
+	require.EqualError(t, validateKeyValueKey("api-key", pattern, opts), "Use upper-case letters")
+}
+
+func Test_PromptService_PromptKeyValues_KeyPatternMatchesWholeKey(t *testing.T) {
+	service := NewPromptService(
+		nil, nil, nil, &internal.GlobalCommandOptions{NoPrompt: true}, nil, nil, nil, nil, nil,
+	)
+
+	tests := []struct {
+		name    string
+		pattern string
+		key     string
+		valid   bool
+	}{
+		{name: "FullMatch", pattern: "[A-Z_]+", key: "API_KEY", valid: true},
+		{name: "PartialMatch", pattern: "[A-Z_]+", key: "api-KEY"},
+		{name: "Alternation", pattern: "FOO|BAR", key: "FOOD"},
+		{name: "AlreadyAnchored", pattern: "^[A-Z_]+$", key: "API_KEY", valid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.PromptKeyValues(t.Context(), &azdext.PromptKeyValuesRequest{
+				Options: &azdext.PromptKeyValuesOptions{
+					KeyPattern:    tt.pattern,
+					DefaultValues: map[string]string{tt.key: "value"},
+				},
+			})
+			if tt.valid {
+				require.NoError(t, err)
+				return
+			}
+			require.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
+
+func Test_PromptService_PromptEditor_Editor(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "fake-editor --wait")
+
+	commandRunner := mockexec.NewMockCommandRunner()
+	commandRunner.MockToolInPath("fake-editor", nil)
+
+	var editedPath string
+	commandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "fake-editor"
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		require.True(t, args.Interactive)
+		require.Len(t, args.Args, 2)
+		require.Equal(t, "--wait", args.Args[0])
+
+		editedPath = args.Args[1]
+		content, err := os.ReadFile(editedPath)
+		require.NoError(t, err)
+		require.Equal(t, "name: api\n", string(content))
+
+		return exec.RunResult{}, os.WriteFile(editedPath, []byte("name: web\n"), osutil.PermissionFile)
+	})
+
+	console := mockinput.NewMockConsole()
+	service := NewPromptService(
+		nil, nil, nil, &internal.GlobalCommandOptions{}, nil, nil, commandRunner, nil, console,
+	)
+
+	resp, err := service.PromptEditor(t.Context(), &azdext.PromptEditorRequest{
+		Options: &azdext.PromptEditorOptions{
+			Message:       "Edit the configuration",
+			DefaultValue:  "name: api\n",
+			FileExtension: "yaml",
+		},
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, "name: web\n", resp.Value)
+	require.Len(t, console.Output(), 1)
+	require.Contains(t, console.Output()[0], "Edit the configuration")
+	require.Equal(t, ".yaml", filepath.Ext(editedPath))
+	require.NoFileExists(t, editedPath)
+}
+
+func Test_editorCommand(t *testing.T) {
+	tests := []struct {
+		name   string
+		visual string
+		editor string
+		want   []string
+	}{
+		{name: "none"},
+		{name: "editor", editor: "vim", want: []string{"vim"}},
+		{name: "editor with args", editor: "code --wait", want: []string{"code", "--wait"}},
+		{name: "visual preferred", visual: "code -w", editor: "vim", want: []string{"code", "-w"}},
+		{name: "blank visual ignored", visual: "  ", editor: "nano", want: []string{"nano"}},
+		{
+			name:   "quoted path with spaces",
+			editor: `"/Applications/Sublime Text.app/bin/subl" -w`,
+			want:   []string{"/Applications/Sublime Text.app/bin/subl", "-w"},
+		},
+		{name: "unbalanced quotes ignored", visual: `"code --wait`, editor: "vim", want: []string{"vim"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("VISUAL", tt.visual)
+			t.Setenv("EDITOR", tt.editor)
+
+			require.Equal(t, tt.want, editorCommand())
+		})
+	}
+}
+
+func Test_resolveTreePath(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    []string
+		want    []string
+		wantErr string
+	}{
+		{name: "root leaf", path: []string{"sub-sandbox"}, want: []string{"sub-sandbox"}},
+		{name: "nested leaf", path: []string{"mg-contoso", "sub-dev"}, want: []string{"mg-contoso", "sub-dev"}},
+		{name: "ends at a node with children", path: []string{"mg-contoso"}, wantErr: "has children"},
+		{name: "unknown value", path: []string{"mg-contoso", "sub-test"}, wantErr: `"sub-test" at level 2`},
+		{name: "too deep", path: []string{"sub-sandbox", "child"}, wantErr: `"child" at level 2`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved, err := resolveTreePath(testTreeChoices(), tt.path)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, treePathValues(resolved))
+		})
+	}
+}
+
+func Test_treeDefaultValue(t *testing.T) {
+	choices := testTreeChoices()
+	contoso := choices[0]
+
+	require.Equal(t, "mg-contoso", treeDefaultValue([]string{"mg-contoso", "sub-prod"}, nil))
+	require.Equal(t, "sub-prod", treeDefaultValue([]string{"mg-contoso", "sub-prod"}, []*azdext.TreeChoice{contoso}))
+	require.Empty(t, treeDefaultValue([]string{"sub-sandbox"}, []*azdext.TreeChoice{contoso}))
+	require.Empty(t, treeDefaultValue(nil, nil))
+}
+
+func Test_treeLevelMessage(t *testing.T) {
+	choices := testTreeChoices()
+
+	require.Equal(t, "Select a subscription", treeLevelMessage("Select a subscription", nil))
+	require.Equal(t,
+		"Select a subscription (Contoso > Dev)",
+		treeLevelMessage("Select a subscription", []*azdext.TreeChoice{choices[0], choices[0].Children[0]}),
+	)
+}
+
 func Test_PromptService_Prompt_NoPromptWithDefault(t *testing.T) {
 	globalOptions := &internal.GlobalCommandOptions{NoPrompt: true}
-	service := NewPromptService(nil, nil, nil, globalOptions)
+	service := NewPromptService(nil, nil, nil, globalOptions, nil, nil, nil, nil, nil)
 
 	resp, err := service.Prompt(t.Context(), &azdext.PromptRequest{
 		Options: &azdext.PromptOptions{
@@ -138,7 +1111,7 @@ func Test_PromptService_Prompt_NoPromptWithDefault(t *testing.T) {
 
 func Test_PromptService_Prompt_NoPromptRequiredWithoutDefault(t *testing.T) {
 	globalOptions := &internal.GlobalCommandOptions{NoPrompt: true}
-	service := NewPromptService(nil, nil, nil, globalOptions)
+	service := NewPromptService(nil, nil, nil, globalOptions, nil, nil, nil, nil, nil)
 
 	_, err := service.Prompt(t.Context(), &azdext.PromptRequest{
 		Options: &azdext.PromptOptions{
@@ -153,7 +1126,7 @@ func Test_PromptService_Prompt_NoPromptRequiredWithoutDefault(t *testing.T) {
 
 func Test_PromptService_Prompt_NoPromptNotRequiredWithoutDefault(t *testing.T) {
 	globalOptions := &internal.GlobalCommandOptions{NoPrompt: true}
-	service := NewPromptService(nil, nil, nil, globalOptions)
+	service := NewPromptService(nil, nil, nil, globalOptions, nil, nil, nil, nil, nil)
 
 	resp, err := service.Prompt(t.Context(), &azdext.PromptRequest{
 		Options: &azdext.PromptOptions{
@@ -177,6 +1150,165 @@ func requirePromptRequiredError(t *testing.T, err error, expectedPromptMessage s
 	return promptErr
 }
 
+func Test_PromptService_PromptSummaryConfirm_NoPrompt(t *testing.T) {
+	globalOptions := &internal.GlobalCommandOptions{NoPrompt: true}
+	console := mockinput.NewMockConsole()
+	service := NewPromptService(nil, nil, nil, globalOptions, nil, nil, nil, nil, console)
+
+	rows := []*azdext.SummaryRow{{Key: "Region", Value: "eastus"}}
+
+	t.Run("WithDefault", func(t *testing.T) {
+		resp, err := service.PromptSummaryConfirm(t.Context(), &azdext.PromptSummaryConfirmRequest{
+			Title:   "Review deployment",
+			Rows:    rows,
+			Options: &azdext.ConfirmOptions{Message: "Deploy?", DefaultValue: new(true)},
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, resp.Value)
+		require.True(t, *resp.Value)
+		// The summary is still shown so non-interactive logs record what was confirmed.
+		require.Contains(t, strings.Join(console.Output(), "\n"), "eastus")
+	})
+
+	t.Run("WithoutDefault", func(t *testing.T) {
+		_, err := service.PromptSummaryConfirm(t.Context(), &azdext.PromptSummaryConfirmRequest{
+			Rows:    rows,
+			Options: &azdext.ConfirmOptions{Message: "Deploy?"},
+		})
+
+		var promptErr *input.PromptRequiredError
+		require.ErrorAs(t, err, &promptErr)
+		require.Equal(t, "Deploy?", promptErr.PromptMessage)
+	})
+
+	t.Run("MissingOptions", func(t *testing.T) {
+		_, err := service.PromptSummaryConfirm(t.Context(), &azdext.PromptSummaryConfirmRequest{Rows: rows})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func Test_PromptService_PromptDestructiveConfirm_NoPrompt(t *testing.T) {
+	globalOptions := &internal.GlobalCommandOptions{NoPrompt: true}
+	service := NewPromptService(nil, nil, nil, globalOptions, nil, nil, nil, nil, nil)
+
+	t.Run("Force", func(t *testing.T) {
+		resp, err := service.PromptDestructiveConfirm(t.Context(), &azdext.PromptDestructiveConfirmRequest{
+			Options: &azdext.PromptDestructiveConfirmOptions{
+				Message:            "Delete my-ai?",
+				ConfirmationPhrase: "my-ai",
+				Force:              true,
+			},
+		})
+
+		require.NoError(t, err)
+		require.True(t, resp.Confirmed)
+	})
+
+	t.Run("WithoutForce", func(t *testing.T) {
+		_, err := service.PromptDestructiveConfirm(t.Context(), &azdext.PromptDestructiveConfirmRequest{
+			Options: &azdext.PromptDestructiveConfirmOptions{Message: "Delete my-ai?", ConfirmationPhrase: "my-ai"},
+		})
+
+		var promptErr *input.PromptRequiredError
+		require.ErrorAs(t, err, &promptErr)
+		require.Equal(t, "Delete my-ai?", promptErr.PromptMessage)
+	})
+
+	t.Run("InvalidOptions", func(t *testing.T) {
+		for _, options := range []*azdext.PromptDestructiveConfirmOptions{
+			nil,
+			{Force: true},
+			{ConfirmationPhrase: "my-ai", MaxAttempts: -1, Force: true},
+		} {
+			_, err := service.PromptDestructiveConfirm(t.Context(), &azdext.PromptDestructiveConfirmRequest{
+				Options: options,
+			})
+			require.Equal(t, codes.InvalidArgument, status.Code(err))
+		}
+	})
+}
+
+func Test_confirmPhrase(t *testing.T) {
+	// answers returns an ask function that answers with values in order and records the number of asks.
+	answers := func(asked *int, values ...string) func(context.Context, string) (string, error) {
+		return func(context.Context, string) (string, error) {
+			value := values[*asked]
+			*asked++
+			return value, nil
+		}
+	}
+
+	tests := []struct {
+		name        string
+		maxAttempts int32
+		values      []string
+		confirmed   bool
+		asked       int
+		messages    int
+	}{
+		{name: "exact match", values: []string{"my-ai"}, confirmed: true, asked: 1},
+		{name: "match after a mismatch", values: []string{"my-AI", "my-ai"}, confirmed: true, asked: 2, messages: 1},
+		{
+			name: "default attempts run out", values: []string{"my", "my-a", "my-ai "}, confirmed: false, asked: 3,
+			messages: 3,
+		},
+		{name: "custom attempts", maxAttempts: 1, values: []string{"yes"}, confirmed: false, asked: 1, messages: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			asked := 0
+			console := mockinput.NewMockConsole()
+			confirmed, err := confirmPhrase(t.Context(), console, &azdext.PromptDestructiveConfirmOptions{
+				ConfirmationPhrase: "my-ai",
+				MaxAttempts:        tt.maxAttempts,
+			}, answers(&asked, tt.values...))
+
+			require.NoError(t, err)
+			require.Equal(t, tt.confirmed, confirmed)
+			require.Equal(t, tt.asked, asked)
+			require.Len(t, console.Output(), tt.messages)
+		})
+	}
+
+	t.Run("ask errors are returned", func(t *testing.T) {
+		opts := &azdext.PromptDestructiveConfirmOptions{ConfirmationPhrase: "my-ai"}
+		_, err := confirmPhrase(t.Context(), mockinput.NewMockConsole(), opts,
+			func(context.Context, string) (string, error) { return "", context.Canceled })
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func Test_formatSummary(t *testing.T) {
+	t.Run("AlignsRows", func(t *testing.T) {
+		summary, err := formatSummary("", []*azdext.SummaryRow{
+			{Key: "Name", Value: "my-model"},
+			{Key: "Region", Value: "eastus"},
+			nil,
+			{Key: "SKU", Value: "GlobalStandard"},
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, "  Name:    my-model\n  Region:  eastus\n  SKU:     GlobalStandard\n\n", summary)
+	})
+
+	t.Run("TitleOnly", func(t *testing.T) {
+		summary, err := formatSummary("Review", nil)
+
+		require.NoError(t, err)
+		require.Contains(t, summary, "Review")
+		require.True(t, strings.HasSuffix(summary, "\n\n"))
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		summary, err := formatSummary("", nil)
+
+		require.NoError(t, err)
+		require.Empty(t, summary)
+	})
+}
+
 func Test_PromptService_PromptSubscription(t *testing.T) {
 	mockPrompter := &mockprompt.MockPromptService{}
 	globalOptions := &internal.GlobalCommandOptions{NoPrompt: false}
@@ -191,7 +1323,7 @@ func Test_PromptService_PromptSubscription(t *testing.T) {
 		On("PromptSubscription", mock.Anything, mock.Anything).
 		Return(expectedSub, nil)
 
-	service := NewPromptService(mockPrompter, nil, nil, globalOptions)
+	service := NewPromptService(mockPrompter, nil, nil, globalOptions, nil, nil, nil, nil, nil)
 
 	resp, err := service.PromptSubscription(t.Context(), &azdext.PromptSubscriptionRequest{
 		Message:     "Select subscription:",
@@ -210,32 +1342,178 @@ func Test_PromptService_PromptLocation(t *testing.T) {
 	mockPrompter := &mockprompt.MockPromptService{}
 	globalOptions := &internal.GlobalCommandOptions{NoPrompt: false}
 
-	expectedLocation := &account.Location{
-		Name:                "eastus",
-		DisplayName:         "East US",
-		RegionalDisplayName: "(US) East US",
-	}
+	expectedLocation := &account.Location{
+		Name:                "eastus",
+		DisplayName:         "East US",
+		RegionalDisplayName: "(US) East US",
+	}
+
+	mockPrompter.
+		On("PromptLocation", mock.Anything, mock.Anything, mock.Anything).
+		Return(expectedLocation, nil)
+
+	service := NewPromptService(mockPrompter, nil, nil, globalOptions, nil, nil, nil, nil, nil)
+
+	resp, err := service.PromptLocation(t.Context(), &azdext.PromptLocationRequest{
+		AzureContext: &azdext.AzureContext{
+			Scope: &azdext.AzureScope{
+				SubscriptionId: "sub-123",
+			},
+		},
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp.Location)
+	require.Equal(t, expectedLocation.Name, resp.Location.Name)
+	require.Equal(t, expectedLocation.DisplayName, resp.Location.DisplayName)
+	require.Equal(t, expectedLocation.RegionalDisplayName, resp.Location.RegionalDisplayName)
+	mockPrompter.AssertExpectations(t)
+}
+
+func Test_PromptService_PromptAzureScope(t *testing.T) {
+	mockPrompter := &mockprompt.MockPromptService{}
+	globalOptions := &internal.GlobalCommandOptions{NoPrompt: false}
+
+	mockPrompter.
+		On("PromptSubscription", mock.Anything, mock.Anything).
+		Return(&account.Subscription{Id: "sub-123", TenantId: "tenant-123"}, nil)
+	mockPrompter.
+		On("PromptLocation", mock.Anything, mock.Anything, mock.Anything).
+		Return(&account.Location{Name: "eastus"}, nil)
+
+	service := NewPromptService(mockPrompter, nil, nil, globalOptions, nil, nil, nil, nil, nil)
+
+	resp, err := service.PromptAzureScope(t.Context(), &azdext.PromptAzureScopeRequest{})
+
+	require.NoError(t, err)
+	require.Equal(t, "tenant-123", resp.AzureContext.Scope.TenantId)
+	require.Equal(t, "sub-123", resp.AzureContext.Scope.SubscriptionId)
+	require.Equal(t, "eastus", resp.AzureContext.Scope.Location)
+	require.Empty(t, resp.AzureContext.Scope.ResourceGroup)
+	mockPrompter.AssertExpectations(t)
+}
+
+func Test_PromptService_PromptAzureScope_PreSeeded(t *testing.T) {
+	mockPrompter := &mockprompt.MockPromptService{}
+	globalOptions := &internal.GlobalCommandOptions{NoPrompt: false}
+
+	mockPrompter.
+		On("PromptResourceGroup", mock.Anything, mock.Anything, mock.Anything).
+		Return(&azapi.ResourceGroup{Name: "rg-test"}, nil)
+
+	service := NewPromptService(mockPrompter, nil, nil, globalOptions, nil, nil, nil, nil, nil)
+
+	req := &azdext.PromptAzureScopeRequest{
+		AzureContext: &azdext.AzureContext{
+			Scope: &azdext.AzureScope{SubscriptionId: "sub-123", Location: "westus"},
+		},
+		IncludeResourceGroup: true,
+	}
+	resp, err := service.PromptAzureScope(t.Context(), req)
+
+	require.NoError(t, err)
+	require.Equal(t, "sub-123", resp.AzureContext.Scope.SubscriptionId)
+	require.Equal(t, "westus", resp.AzureContext.Scope.Location)
+	require.Equal(t, "rg-test", resp.AzureContext.Scope.ResourceGroup)
+	require.Empty(t, req.AzureContext.Scope.ResourceGroup, "request context should not be mutated")
+	mockPrompter.AssertNotCalled(t, "PromptSubscription", mock.Anything, mock.Anything)
+	mockPrompter.AssertNotCalled(t, "PromptLocation", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_PromptService_PromptAzureScope_NoPrompt(t *testing.T) {
+	globalOptions := &internal.GlobalCommandOptions{NoPrompt: true}
+	env := environment.NewWithValues("dev", map[string]string{
+		environment.SubscriptionIdEnvVarName: "sub-env",
+		environment.TenantIdEnvVarName:       "tenant-env",
+		environment.LocationEnvVarName:       "eastus2",
+		environment.ResourceGroupEnvVarName:  "rg-env",
+	})
+
+	t.Run("FromEnvironment", func(t *testing.T) {
+		service := NewPromptService(nil, nil, nil, globalOptions, lazy.From(env), nil, nil, nil, nil)
+
+		resp, err := service.PromptAzureScope(t.Context(), &azdext.PromptAzureScopeRequest{
+			AzureContext: &azdext.AzureContext{
+				Scope: &azdext.AzureScope{Location: "westus"},
+			},
+			IncludeResourceGroup: true,
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, "sub-env", resp.AzureContext.Scope.SubscriptionId)
+		require.Equal(t, "tenant-env", resp.AzureContext.Scope.TenantId)
+		require.Equal(t, "westus", resp.AzureContext.Scope.Location)
+		require.Equal(t, "rg-env", resp.AzureContext.Scope.ResourceGroup)
+	})
+
+	t.Run("NoEnvironment", func(t *testing.T) {
+		lazyEnv := lazy.NewLazy(func() (*environment.Environment, error) {
+			return nil, environment.ErrDefaultEnvironmentNotFound
+		})
+		service := NewPromptService(nil, nil, nil, globalOptions, lazyEnv, nil, nil, nil, nil)
 
-	mockPrompter.
-		On("PromptLocation", mock.Anything, mock.Anything, mock.Anything).
-		Return(expectedLocation, nil)
+		_, err := service.PromptAzureScope(t.Context(), &azdext.PromptAzureScopeRequest{})
 
-	service := NewPromptService(mockPrompter, nil, nil, globalOptions)
+		var promptErr *input.PromptRequiredError
+		require.ErrorAs(t, err, &promptErr)
+	})
+}
 
-	resp, err := service.PromptLocation(t.Context(), &azdext.PromptLocationRequest{
-		AzureContext: &azdext.AzureContext{
-			Scope: &azdext.AzureScope{
-				SubscriptionId: "sub-123",
+func Test_PromptService_GetAzureContext(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		lazyEnv *lazy.Lazy[*environment.Environment]
+		want    *azdext.AzureScope
+	}{
+		{
+			name: "FromEnvironment",
+			lazyEnv: lazy.From(environment.NewWithValues("dev", map[string]string{
+				environment.SubscriptionIdEnvVarName: "sub-env",
+				environment.TenantIdEnvVarName:       "tenant-env",
+				environment.LocationEnvVarName:       "eastus2",
+				environment.ResourceGroupEnvVarName:  "rg-env",
+			})),
+			want: &azdext.AzureScope{
+				TenantId:       "tenant-env",
+				SubscriptionId: "sub-env",
+				Location:       "eastus2",
+				ResourceGroup:  "rg-env",
 			},
 		},
-	})
+		{
+			name: "NoResourceGroup",
+			lazyEnv: lazy.From(environment.NewWithValues("dev", map[string]string{
+				environment.SubscriptionIdEnvVarName: "sub-env",
+				environment.LocationEnvVarName:       "eastus2",
+			})),
+			want: &azdext.AzureScope{SubscriptionId: "sub-env", Location: "eastus2"},
+		},
+		{
+			name: "NoEnvironment",
+			lazyEnv: lazy.NewLazy(func() (*environment.Environment, error) {
+				return nil, environment.ErrDefaultEnvironmentNotFound
+			}),
+			want: &azdext.AzureScope{},
+		},
+	}
 
-	require.NoError(t, err)
-	require.NotNil(t, resp.Location)
-	require.Equal(t, expectedLocation.Name, resp.Location.Name)
-	require.Equal(t, expectedLocation.DisplayName, resp.Location.DisplayName)
-	require.Equal(t, expectedLocation.RegionalDisplayName, resp.Location.RegionalDisplayName)
-	mockPrompter.AssertExpectations(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// No prompter is set, so any attempt to prompt would fail.
+			service := NewPromptService(nil, nil, nil, &internal.GlobalCommandOptions{}, tt.lazyEnv, nil, nil, nil, nil)
+
+			resp, err := service.GetAzureContext(t.Context(), &azdext.GetAzureContextRequest{})
+			require.NoError(t, err)
+			require.Equal(t, tt.want.TenantId, resp.AzureContext.Scope.TenantId)
+			require.Equal(t, tt.want.SubscriptionId, resp.AzureContext.Scope.SubscriptionId)
+			require.Equal(t, tt.want.Location, resp.AzureContext.Scope.Location)
+			require.Equal(t, tt.want.ResourceGroup, resp.AzureContext.Scope.ResourceGroup)
+		})
+	}
 }
 
 func Test_PromptService_PromptLocation_WithAllowedLocations(t *testing.T) {
@@ -254,7 +1532,7 @@ func Test_PromptService_PromptLocation_WithAllowedLocations(t *testing.T) {
 		})).
 		Return(expectedLocation, nil)
 
-	service := NewPromptService(mockPrompter, nil, nil, globalOptions)
+	service := NewPromptService(mockPrompter, nil, nil, globalOptions, nil, nil, nil, nil, nil)
 
 	resp, err := service.PromptLocation(t.Context(), &azdext.PromptLocationRequest{
 		AzureContext: &azdext.AzureContext{
@@ -293,7 +1571,7 @@ func Test_PromptService_PromptResourceGroup(t *testing.T) {
 		})).
 		Return(expectedRg, nil)
 
-	service := NewPromptService(mockPrompter, nil, nil, globalOptions)
+	service := NewPromptService(mockPrompter, nil, nil, globalOptions, nil, nil, nil, nil, nil)
 
 	resp, err := service.PromptResourceGroup(t.Context(), &azdext.PromptResourceGroupRequest{
 		AzureContext: &azdext.AzureContext{
@@ -332,7 +1610,7 @@ func Test_PromptService_PromptResourceGroup_NilOptions(t *testing.T) {
 		On("PromptResourceGroup", mock.Anything, mock.Anything, (*prompt.ResourceGroupOptions)(nil)).
 		Return(expectedRg, nil)
 
-	service := NewPromptService(mockPrompter, nil, nil, globalOptions)
+	service := NewPromptService(mockPrompter, nil, nil, globalOptions, nil, nil, nil, nil, nil)
 
 	resp, err := service.PromptResourceGroup(t.Context(), &azdext.PromptResourceGroupRequest{
 		AzureContext: &azdext.AzureContext{
@@ -379,7 +1657,7 @@ func Test_PromptService_PromptSubscriptionResource(t *testing.T) {
 		).
 		Return(expectedResource, nil)
 
-	service := NewPromptService(mockPrompter, nil, nil, globalOptions)
+	service := NewPromptService(mockPrompter, nil, nil, globalOptions, nil, nil, nil, nil, nil)
 
 	resp, err := service.PromptSubscriptionResource(t.Context(), &azdext.PromptSubscriptionResourceRequest{
 		AzureContext: &azdext.AzureContext{
@@ -437,7 +1715,7 @@ func Test_PromptService_PromptResourceGroupResource(t *testing.T) {
 		).
 		Return(expectedResource, nil)
 
-	service := NewPromptService(mockPrompter, nil, nil, globalOptions)
+	service := NewPromptService(mockPrompter, nil, nil, globalOptions, nil, nil, nil, nil, nil)
 
 	resp, err := service.PromptResourceGroupResource(t.Context(), &azdext.PromptResourceGroupResourceRequest{
 		AzureContext: &azdext.AzureContext{
@@ -664,7 +1942,7 @@ func Test_PromptService_PromptSubscription_ErrorWithSuggestion(t *testing.T) {
 		On("PromptSubscription", mock.Anything, mock.Anything).
 		Return(nil, authErr)
 
-	promptSvc := NewPromptService(mockPrompter, nil, nil, globalOptions)
+	promptSvc := NewPromptService(mockPrompter, nil, nil, globalOptions, nil, nil, nil, nil, nil)
 	_, ctx, client, cleanup := setupTestServer(t, promptSvc)
 	defer cleanup()
 
@@ -685,6 +1963,30 @@ func Test_PromptService_PromptSubscription_ErrorWithSuggestion(t *testing.T) {
 	mockPrompter.AssertExpectations(t)
 }
 
+func Test_PromptService_PromptSubscription_NoSubscriptions(t *testing.T) {
+	mockPrompter := &mockprompt.MockPromptService{}
+	globalOptions := &internal.GlobalCommandOptions{NoPrompt: false}
+
+	mockPrompter.
+		On("PromptSubscription", mock.Anything, mock.Anything).
+		Return(nil, internal.ErrNoSubscriptionsFound)
+
+	promptSvc := NewPromptService(mockPrompter, nil, nil, globalOptions, nil, nil, nil, nil, nil)
+	_, ctx, client, cleanup := setupTestServer(t, promptSvc)
+	defer cleanup()
+
+	_, err := client.Prompt().PromptSubscription(ctx, &azdext.PromptSubscriptionRequest{})
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "No Azure subscriptions were found")
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	actionable := azdext.ActionableErrorDetailFromStatus(st)
+	require.NotNil(t, actionable)
+	require.Contains(t, actionable.GetSuggestion(), "azd auth login")
+	mockPrompter.AssertExpectations(t)
+}
+
 func Test_PromptService_PromptResourceGroup_ErrorWithSuggestion(t *testing.T) {
 	mockPrompter := &mockprompt.MockPromptService{}
 	globalOptions := &internal.GlobalCommandOptions{NoPrompt: false}
@@ -698,7 +2000,7 @@ func Test_PromptService_PromptResourceGroup_ErrorWithSuggestion(t *testing.T) {
 		On("PromptResourceGroup", mock.Anything, mock.Anything, mock.Anything).
 		Return(nil, authErr)
 
-	promptSvc := NewPromptService(mockPrompter, nil, nil, globalOptions)
+	promptSvc := NewPromptService(mockPrompter, nil, nil, globalOptions, nil, nil, nil, nil, nil)
 	_, ctx, client, cleanup := setupTestServer(t, promptSvc)
 	defer cleanup()
 
@@ -723,79 +2025,6 @@ func Test_PromptService_PromptResourceGroup_ErrorWithSuggestion(t *testing.T) {
 	mockPrompter.AssertExpectations(t)
 }
 
-func Test_validateDeploymentCapacity(t *testing.T) {
-	tests := []struct {
-		name        string
-		value       string
-		sku         ai.AiModelSku
-		want        int32
-		errContains string
-	}{
-		{
-			name:  "valid capacity with constraints",
-			value: "20",
-			sku: ai.AiModelSku{
-				MinCapacity:  10,
-				MaxCapacity:  100,
-				CapacityStep: 10,
-			},
-			want: 20,
-		},
-		{
-			name:        "non-numeric value",
-			value:       "abc",
-			sku:         ai.AiModelSku{},
-			errContains: "whole number",
-		},
-		{
-			name:  "below minimum",
-			value: "5",
-			sku: ai.AiModelSku{
-				MinCapacity: 10,
-			},
-			errContains: "at least 10",
-		},
-		{
-			name:  "above maximum",
-			value: "120",
-			sku: ai.AiModelSku{
-				MaxCapacity: 100,
-			},
-			errContains: "at most 100",
-		},
-		{
-			name:  "step mismatch",
-			value: "25",
-			sku: ai.AiModelSku{
-				CapacityStep: 10,
-			},
-			errContains: "multiple of 10",
-		},
-		{
-			name:  "trimmed input is accepted",
-			value: " 30 ",
-			sku: ai.AiModelSku{
-				MinCapacity: 10,
-			},
-			want: 30,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got, err := validateDeploymentCapacity(tt.value, tt.sku)
-			if tt.errContains != "" {
-				require.Error(t, err)
-				require.Contains(t, err.Error(), tt.errContains)
-				return
-			}
-
-			require.NoError(t, err)
-			require.Equal(t, tt.want, got)
-		})
-	}
-}
-
 func Test_validateCapacityAgainstRemainingQuota(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -1058,6 +2287,24 @@ func Test_selectModelNoPrompt(t *testing.T) {
 			defaultValue: "Gpt-35-Turbo",
 			wantModel:    "gpt-35-turbo",
 		},
+		{
+			name:         "variant without hyphen resolves",
+			models:       models,
+			defaultValue: "gpt4o",
+			wantModel:    "gpt-4o",
+		},
+		{
+			name:         "alias resolves",
+			models:       models,
+			defaultValue: "gpt-3.5-turbo",
+			wantModel:    "gpt-35-turbo",
+		},
+		{
+			name:         "near miss suggests closest model",
+			models:       models,
+			defaultValue: "gpt-4x",
+			errContains:  `not found in available models; did you mean "gpt-4o"?`,
+		},
 		{
 			name:         "no match returns not found error",
 			models:       models,
@@ -1104,7 +2351,7 @@ func Test_selectModelNoPrompt(t *testing.T) {
 
 func Test_PromptService_NilOptions_Validation(t *testing.T) {
 	globalOptions := &internal.GlobalCommandOptions{NoPrompt: true}
-	service := NewPromptService(nil, nil, nil, globalOptions)
+	service := NewPromptService(nil, nil, nil, globalOptions, nil, nil, nil, nil, nil)
 
 	tests := []struct {
 		name   string
@@ -1113,6 +2360,7 @@ func Test_PromptService_NilOptions_Validation(t *testing.T) {
 		{"Confirm_nil_options", "Confirm"},
 		{"Select_nil_options", "Select"},
 		{"MultiSelect_nil_options", "MultiSelect"},
+		{"PromptTree_nil_options", "PromptTree"},
 	}
 
 	for _, tt := range tests {
@@ -1134,6 +2382,11 @@ func Test_PromptService_NilOptions_Validation(t *testing.T) {
 					t.Context(),
 					&azdext.MultiSelectRequest{Options: nil},
 				)
+			case "PromptTree":
+				_, err = service.PromptTree(
+					t.Context(),
+					&azdext.PromptTreeRequest{Options: nil},
+				)
 			}
 
 			require.Error(t, err)
@@ -1147,7 +2400,7 @@ func Test_PromptService_NilOptions_Validation(t *testing.T) {
 
 func Test_PromptService_CreateAzureContext_NilScope(t *testing.T) {
 	globalOptions := &internal.GlobalCommandOptions{NoPrompt: false}
-	svc := NewPromptService(nil, nil, nil, globalOptions)
+	svc := NewPromptService(nil, nil, nil, globalOptions, nil, nil, nil, nil, nil)
 	ps := svc.(*promptService)
 
 	tests := []struct {
@@ -1335,6 +2588,7 @@ func TestProtoToFilterOptions_WithValues(t *testing.T) {
 		Formats:           []string{"json"},
 		Statuses:          []string{"active"},
 		ExcludeModelNames: []string{"gpt-3"},
+		Intent:            "chat",
 	})
 	require.NotNil(t, opts)
 	require.Equal(t, []string{"eastus", "westus"}, opts.Locations)
@@ -1342,6 +2596,7 @@ func TestProtoToFilterOptions_WithValues(t *testing.T) {
 	require.Equal(t, []string{"json"}, opts.Formats)
 	require.Equal(t, []string{"active"}, opts.Statuses)
 	require.Equal(t, []string{"gpt-3"}, opts.ExcludeModelNames)
+	require.Equal(t, ai.ModelIntentChat, opts.Intent)
 }
 
 // --- protoToDeploymentOptions tests ---
@@ -1574,6 +2829,31 @@ func TestModelQuotaSummary_WithQuota(t *testing.T) {
 	require.Equal(t, output.WithGrayFormat("[up to %.0f quota available]", float64(800)), result)
 }
 
+func TestLocationQuotaSummary(t *testing.T) {
+	t.Parallel()
+	loc := ai.ModelLocationQuota{Location: "eastus", MaxRemainingQuota: 120, RequiredQuota: 50}
+
+	require.Equal(t, "up to 120 quota available", locationQuotaSummary(loc, false))
+	require.Equal(t,
+		"up to 120 quota available, 70 left after deployment (42% used)", locationQuotaSummary(loc, true))
+	require.Equal(t,
+		"up to 120 quota available", locationQuotaSummary(ai.ModelLocationQuota{MaxRemainingQuota: 120}, true))
+}
+
+func TestModelFamiliesShared(t *testing.T) {
+	t.Parallel()
+	require.False(t, modelFamiliesShared(nil))
+	require.False(t, modelFamiliesShared([]ai.AiModel{
+		{Name: "gpt-4o", Family: "gpt-4"},
+		{Name: "o3-mini", Family: "o3"},
+	}))
+	require.True(t, modelFamiliesShared([]ai.AiModel{
+		{Name: "gpt-4o", Family: "gpt-4"},
+		{Name: "gpt-4o-mini", Family: "gpt-4"},
+		{Name: "o3-mini", Family: "o3"},
+	}))
+}
+
 // --- selectModelNoPrompt tests ---
 
 func TestSelectModelNoPrompt_EmptyDefault(t *testing.T) {
@@ -1672,107 +2952,504 @@ func TestMaxSkuCandidateRemaining_WithValues(t *testing.T) {
 		{remaining: &r2},
 		{remaining: &r3},
 	}
-	max, found := maxSkuCandidateRemaining(candidates)
-	require.True(t, found)
-	require.Equal(t, float64(500), max)
+	max, found := maxSkuCandidateRemaining(candidates)
+	require.True(t, found)
+	require.Equal(t, float64(500), max)
+}
+
+func TestMaxSkuCandidateRemaining_MixedNilAndValues(t *testing.T) {
+	t.Parallel()
+	r1 := float64(300)
+	candidates := []skuCandidate{
+		{remaining: nil},
+		{remaining: &r1},
+		{remaining: nil},
+	}
+	max, found := maxSkuCandidateRemaining(candidates)
+	require.True(t, found)
+	require.Equal(t, float64(300), max)
+}
+
+// --- buildSkuCandidatesForVersion tests ---
+
+func TestBuildSkuCandidatesForVersion_EmptySkus(t *testing.T) {
+	t.Parallel()
+	version := ai.AiModelVersion{}
+	result := buildSkuCandidatesForVersion(version, nil, nil, nil, false)
+	require.Empty(t, result)
+}
+
+func TestBuildSkuCandidatesForVersion_NoQuotaCheck(t *testing.T) {
+	t.Parallel()
+	version := ai.AiModelVersion{
+		Skus: []ai.AiModelSku{
+			{Name: "S0", UsageName: "openai-standard"},
+			{Name: "P1", UsageName: "openai-provisioned"},
+		},
+	}
+	result := buildSkuCandidatesForVersion(version, nil, nil, nil, false)
+	require.Len(t, result, 2)
+}
+
+func TestBuildSkuCandidatesForVersion_SkuFilter(t *testing.T) {
+	t.Parallel()
+	version := ai.AiModelVersion{
+		Skus: []ai.AiModelSku{
+			{Name: "S0", UsageName: "standard"},
+			{Name: "P1", UsageName: "provisioned"},
+		},
+	}
+	options := &ai.DeploymentOptions{Skus: []string{"S0"}}
+	result := buildSkuCandidatesForVersion(version, options, nil, nil, false)
+	require.Len(t, result, 1)
+	require.Equal(t, "S0", result[0].sku.Name)
+}
+
+func TestSkuCandidatesSupportingCapacity(t *testing.T) {
+	t.Parallel()
+
+	version := ai.AiModelVersion{
+		Version: "2024-08-06",
+		Skus: []ai.AiModelSku{
+			{Name: "GlobalStandard", UsageName: "OpenAI.GlobalStandard.gpt-4o", MaxCapacity: 1000, CapacityStep: 10},
+			{Name: "Standard", UsageName: "OpenAI.Standard.gpt-4o", MaxCapacity: 100},
+			{Name: "ProvisionedManaged", UsageName: "OpenAI.ProvisionedManaged", MinCapacity: 50, CapacityStep: 50},
+		},
+	}
+
+	skuNames := func(candidates []skuCandidate) []string {
+		names := make([]string, len(candidates))
+		for i, c := range candidates {
+			names[i] = c.sku.Name
+		}
+		return names
+	}
+
+	t.Run("SKU constraints", func(t *testing.T) {
+		candidates := buildSkuCandidatesForVersion(version, nil, nil, nil, false)
+		require.Equal(t, []string{"GlobalStandard", "ProvisionedManaged"},
+			skuNames(skuCandidatesSupportingCapacity(candidates, 150)))
+	})
+
+	t.Run("remaining quota covers capacity", func(t *testing.T) {
+		capacity := int32(150)
+		usageMap := map[string]ai.AiModelUsage{
+			"OpenAI.GlobalStandard.gpt-4o": {Name: "OpenAI.GlobalStandard.gpt-4o", Limit: 200, CurrentValue: 100},
+			"OpenAI.ProvisionedManaged":    {Name: "OpenAI.ProvisionedManaged", Limit: 300},
+		}
+		candidates := buildSkuCandidatesForVersion(
+			version,
+			&ai.DeploymentOptions{Capacity: &capacity},
+			&azdext.QuotaCheckOptions{},
+			usageMap,
+			false,
+		)
+		require.Equal(t, []string{"ProvisionedManaged"}, skuNames(skuCandidatesSupportingCapacity(candidates, capacity)))
+	})
+}
+
+func TestLabelSkuCandidates(t *testing.T) {
+	t.Parallel()
+
+	remaining := float64(450)
+	candidates := []skuCandidate{
+		{sku: ai.AiModelSku{Name: "GlobalStandard", UsageName: "OpenAI.GlobalStandard.gpt-4o", MinCapacity: 10,
+			MaxCapacity: 100, CapacityStep: 10}, remaining: &remaining},
+		{sku: ai.AiModelSku{Name: "Standard", UsageName: "OpenAI.Standard.gpt-4o"}},
+		{sku: ai.AiModelSku{Name: "Standard", UsageName: "OpenAI.Standard.gpt-4o-finetune"}},
+	}
+
+	labelSkuCandidates(candidates, false)
+	require.Contains(t, candidates[0].label, "GlobalStandard")
+	require.Contains(t, candidates[0].label, "450 quota available")
+	require.NotContains(t, candidates[0].label, "capacity")
+	require.Equal(t, "Standard (OpenAI.Standard.gpt-4o)", candidates[1].label)
+
+	labelSkuCandidates(candidates, true)
+	require.Contains(t, candidates[0].label, "capacity between 10 and 100 in steps of 10, 450 quota available")
+	require.Equal(t, "Standard (OpenAI.Standard.gpt-4o-finetune)", candidates[2].label)
+}
+
+func TestResolveSkuCandidateCapacity(t *testing.T) {
+	t.Parallel()
+
+	sku := ai.AiModelSku{Name: "GlobalStandard", DefaultCapacity: 100, MinCapacity: 10, MaxCapacity: 1000,
+		CapacityStep: 10}
+
+	capacity, ok := resolveSkuCandidateCapacity(skuCandidate{sku: sku}, nil)
+	require.True(t, ok)
+	require.Equal(t, int32(100), capacity)
+
+	remaining := float64(55)
+	capacity, ok = resolveSkuCandidateCapacity(skuCandidate{sku: sku, remaining: &remaining}, nil)
+	require.True(t, ok)
+	require.Equal(t, int32(50), capacity)
+
+	remaining = 5
+	_, ok = resolveSkuCandidateCapacity(skuCandidate{sku: sku, remaining: &remaining}, nil)
+	require.False(t, ok)
+}
+
+func TestDefaultAiDeployment(t *testing.T) {
+	t.Parallel()
+
+	standard := ai.AiModelSku{Name: "Standard", UsageName: "OpenAI.Standard.gpt-4o", DefaultCapacity: 10,
+		MaxCapacity: 100}
+	globalStandard := ai.AiModelSku{Name: "GlobalStandard", UsageName: "OpenAI.GlobalStandard.gpt-4o",
+		DefaultCapacity: 50, MaxCapacity: 1000}
+	provisioned := ai.AiModelSku{Name: "ProvisionedManaged", UsageName: "OpenAI.ProvisionedManaged.gpt-4o",
+		MinCapacity: 15}
+	remaining := float64(30)
+	scarce := float64(5)
+
+	// Ordered as PromptAiDeployment offers them: the default version first, then the newest.
+	versions := []versionCandidate{
+		{
+			version:       ai.AiModelVersion{Version: "2024-08-06", IsDefault: true, Format: "OpenAI"},
+			skuCandidates: []skuCandidate{{sku: standard}, {sku: globalStandard}},
+		},
+		{
+			version:       ai.AiModelVersion{Version: "2024-11-20", Format: "OpenAI"},
+			skuCandidates: []skuCandidate{{sku: globalStandard}},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		versions          []versionCandidate
+		preferredSkus     []string
+		preferredCapacity *int32
+		want              *ai.AiModelDeployment
+	}{
+		{
+			name:     "CatalogOrder",
+			versions: versions,
+			want: &ai.AiModelDeployment{
+				ModelName: "gpt-4o", Format: "OpenAI", Version: "2024-08-06", Sku: standard, Capacity: 10,
+			},
+		},
+		{
+			name:          "FirstPreferredSku",
+			versions:      versions,
+			preferredSkus: []string{"DataZoneStandard", "GlobalStandard"},
+			want: &ai.AiModelDeployment{
+				ModelName: "gpt-4o", Format: "OpenAI", Version: "2024-08-06", Sku: globalStandard, Capacity: 50,
+			},
+		},
+		{
+			name:              "PreferredCapacity",
+			versions:          versions,
+			preferredSkus:     []string{"GlobalStandard"},
+			preferredCapacity: new(int32(200)),
+			want: &ai.AiModelDeployment{
+				ModelName: "gpt-4o", Format: "OpenAI", Version: "2024-08-06", Sku: globalStandard, Capacity: 200,
+			},
+		},
+		{
+			name: "CapacityLimitedByQuota",
+			versions: []versionCandidate{{
+				version:       ai.AiModelVersion{Version: "2024-08-06", IsDefault: true},
+				skuCandidates: []skuCandidate{{sku: globalStandard, remaining: &remaining}},
+			}},
+			want: &ai.AiModelDeployment{
+				ModelName: "gpt-4o", Format: "OpenAI", Version: "2024-08-06", Sku: globalStandard, Capacity: 30,
+				RemainingQuota: &remaining,
+			},
+		},
+		{
+			name: "SkipsVersionsWithoutValidCapacity",
+			versions: []versionCandidate{
+				{
+					version:       ai.AiModelVersion{Version: "2024-08-06", IsDefault: true},
+					skuCandidates: []skuCandidate{{sku: provisioned, remaining: &scarce}},
+				},
+				versions[1],
+			},
+			want: &ai.AiModelDeployment{
+				ModelName: "gpt-4o", Format: "OpenAI", Version: "2024-11-20", Sku: globalStandard, Capacity: 50,
+			},
+		},
+		{
+			name: "FirstFormat",
+			versions: []versionCandidate{
+				{
+					version:       ai.AiModelVersion{Version: "2024-08-06", IsDefault: true, Format: "OpenAI"},
+					skuCandidates: []skuCandidate{{sku: standard}},
+				},
+				{
+					version:       ai.AiModelVersion{Version: "1", Format: "Microsoft"},
+					skuCandidates: []skuCandidate{{sku: globalStandard}},
+				},
+			},
+			want: &ai.AiModelDeployment{
+				ModelName: "gpt-4o", Format: "OpenAI", Version: "2024-08-06", Sku: standard, Capacity: 10,
+			},
+		},
+		{
+			name: "NoValidCapacity",
+			versions: []versionCandidate{{
+				version:       ai.AiModelVersion{Version: "2024-08-06", IsDefault: true},
+				skuCandidates: []skuCandidate{{sku: provisioned, remaining: &scarce}},
+			}},
+		},
+		{
+			name: "NoVersions",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := defaultAiDeployment("gpt-4o", "OpenAI", tt.versions, tt.preferredSkus, tt.preferredCapacity)
+			require.Equal(t, tt.want != nil, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
 }
 
-func TestMaxSkuCandidateRemaining_MixedNilAndValues(t *testing.T) {
+func TestDefaultVersionCandidates(t *testing.T) {
 	t.Parallel()
-	r1 := float64(300)
-	candidates := []skuCandidate{
-		{remaining: nil},
-		{remaining: &r1},
-		{remaining: nil},
+
+	candidate := func(version string, isDefault bool) versionCandidate {
+		return versionCandidate{
+			version: ai.AiModelVersion{Version: version, IsDefault: isDefault},
+			label:   version,
+		}
+	}
+	labels := func(candidates []versionCandidate) []string {
+		got := make([]string, len(candidates))
+		for i, c := range candidates {
+			got[i] = c.label
+		}
+		return got
 	}
-	max, found := maxSkuCandidateRemaining(candidates)
-	require.True(t, found)
-	require.Equal(t, float64(300), max)
-}
 
-// --- buildSkuCandidatesForVersion tests ---
+	all := []versionCandidate{
+		candidate("2024-08-06", true), candidate("2024-11-20", false), candidate("2024-05-13", false),
+	}
+	require.Equal(t, []string{"2024-08-06"}, labels(defaultVersionCandidates(all, "OpenAI")))
+	require.Len(t, all, 3)
 
-func TestBuildSkuCandidatesForVersion_EmptySkus(t *testing.T) {
-	t.Parallel()
-	version := ai.AiModelVersion{}
-	result := buildSkuCandidatesForVersion(version, nil, nil, nil, false)
-	require.Empty(t, result)
+	withoutDefault := []versionCandidate{candidate("2024-11-20", false), candidate("2024-05-13", false)}
+	require.Equal(t, []string{"2024-11-20"}, labels(defaultVersionCandidates(withoutDefault, "OpenAI")))
+
+	require.Empty(t, defaultVersionCandidates(nil, "OpenAI"))
 }
 
-func TestBuildSkuCandidatesForVersion_NoQuotaCheck(t *testing.T) {
+func TestConfirmNonDefaultVersion_NoPromptNeeded(t *testing.T) {
 	t.Parallel()
-	version := ai.AiModelVersion{
-		Skus: []ai.AiModelSku{
-			{Name: "S0", UsageName: "openai-standard"},
-			{Name: "P1", UsageName: "openai-provisioned"},
-		},
-	}
-	result := buildSkuCandidatesForVersion(version, nil, nil, nil, false)
-	require.Len(t, result, 2)
+
+	defaultVersion := versionCandidate{version: ai.AiModelVersion{Version: "2024-08-06", IsDefault: true}}
+	older := versionCandidate{version: ai.AiModelVersion{Version: "2024-05-13"}}
+
+	// The default version, or any version of a model without one, is accepted without asking.
+	confirmed, err := confirmNonDefaultVersion(
+		t.Context(), nil, "gpt-4o", defaultVersion, []versionCandidate{defaultVersion, older})
+	require.NoError(t, err)
+	require.True(t, confirmed)
+
+	confirmed, err = confirmNonDefaultVersion(t.Context(), nil, "gpt-4o", older, []versionCandidate{older})
+	require.NoError(t, err)
+	require.True(t, confirmed)
 }
 
-func TestBuildSkuCandidatesForVersion_SkuFilter(t *testing.T) {
+func TestDefaultAiDeploymentLabel(t *testing.T) {
 	t.Parallel()
-	version := ai.AiModelVersion{
-		Skus: []ai.AiModelSku{
-			{Name: "S0", UsageName: "standard"},
-			{Name: "P1", UsageName: "provisioned"},
-		},
-	}
-	options := &ai.DeploymentOptions{Skus: []string{"S0"}}
-	result := buildSkuCandidatesForVersion(version, options, nil, nil, false)
-	require.Len(t, result, 1)
-	require.Equal(t, "S0", result[0].sku.Name)
-}
 
-// --- validateDeploymentCapacity tests ---
+	deployment := &ai.AiModelDeployment{
+		ModelName: "gpt-4o", Version: "2024-08-06", Sku: ai.AiModelSku{Name: "GlobalStandard"}, Capacity: 10,
+	}
+	require.Equal(t, "gpt-4o 2024-08-06 (GlobalStandard, capacity 10)", defaultAiDeploymentLabel(deployment))
 
-func TestValidateDeploymentCapacity_Invalid(t *testing.T) {
-	t.Parallel()
-	sku := ai.AiModelSku{}
-	_, err := validateDeploymentCapacity("abc", sku)
-	require.Error(t, err)
-	require.Contains(t, err.Error(), "whole number")
+	deployment.Location = "eastus"
+	require.Equal(t, "gpt-4o 2024-08-06 (GlobalStandard, capacity 10) in eastus", defaultAiDeploymentLabel(deployment))
 }
 
-func TestValidateDeploymentCapacity_Zero(t *testing.T) {
+func TestPromptService_PromptAiDeployment_AcceptDefaults(t *testing.T) {
 	t.Parallel()
-	sku := ai.AiModelSku{}
-	_, err := validateDeploymentCapacity("0", sku)
-	require.Error(t, err)
-	require.Contains(t, err.Error(), "greater than 0")
+
+	mockCtx := mocks.NewMockContext(t.Context())
+	mockCtx.HttpClient.When(func(req *http.Request) bool {
+		return req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, "/locations/eastus/models")
+	}).RespondFn(func(req *http.Request) (*http.Response, error) {
+		model := func(kind, format, version string, isDefault bool, sku string) *armcognitiveservices.Model {
+			return &armcognitiveservices.Model{
+				Kind: new(kind),
+				Model: &armcognitiveservices.AccountModel{
+					Name:             new("gpt-4o"),
+					Format:           new(format),
+					Version:          new(version),
+					IsDefaultVersion: new(isDefault),
+					SKUs: []*armcognitiveservices.ModelSKU{{
+						Name:      new(sku),
+						UsageName: new(format + "." + sku + ".gpt-4o"),
+						Capacity:  &armcognitiveservices.CapacityConfig{Default: new(int32(10))},
+					}},
+				},
+			}
+		}
+		// The Microsoft format sorts before OpenAI, but only the OpenAI version is the default.
+		return mocks.CreateHttpResponseWithBody(req, http.StatusOK, armcognitiveservices.ModelListResult{
+			Value: []*armcognitiveservices.Model{
+				model("OpenAI", "OpenAI", "2024-08-06", true, "GlobalStandard"),
+				model("AIServices", "Microsoft", "2025-01-01", false, "GlobalStandard"),
+			},
+		})
+	})
+
+	azureClient := azapi.NewAzureClient(
+		mockaccount.SubscriptionCredentialProviderFunc(func(context.Context, string) (azcore.TokenCredential, error) {
+			return mockCtx.Credentials, nil
+		}),
+		mockCtx.ArmClientOptions,
+	)
+	svc := NewPromptService(
+		nil, nil, ai.NewAiModelService(azureClient, nil), &internal.GlobalCommandOptions{NoPrompt: true},
+		nil, nil, nil, nil, nil,
+	)
+
+	resp, err := svc.PromptAiDeployment(*mockCtx.Context, &azdext.PromptAiDeploymentRequest{
+		AzureContext:   &azdext.AzureContext{Scope: &azdext.AzureScope{SubscriptionId: "sub-123"}},
+		ModelName:      "gpt-4o",
+		Options:        &azdext.AiModelDeploymentOptions{Locations: []string{"eastus"}},
+		AcceptDefaults: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "OpenAI", resp.Deployment.Format)
+	require.Equal(t, "2024-08-06", resp.Deployment.Version)
+	require.Equal(t, "GlobalStandard", resp.Deployment.Sku.Name)
+	require.Equal(t, int32(10), resp.Deployment.Capacity)
+	require.Equal(t, "eastus", resp.Deployment.Location)
 }
 
-func TestValidateDeploymentCapacity_BelowMin(t *testing.T) {
+func Test_PromptService_PromptAiSku_NoPrompt(t *testing.T) {
 	t.Parallel()
-	sku := ai.AiModelSku{MinCapacity: 10}
-	_, err := validateDeploymentCapacity("5", sku)
-	require.Error(t, err)
-	require.Contains(t, err.Error(), "at least 10")
+
+	service := NewPromptService(nil, nil, nil, &internal.GlobalCommandOptions{NoPrompt: true}, nil, nil, nil, nil, nil)
+	skus := []*azdext.AiModelSku{
+		{Name: "GlobalStandard", UsageName: "OpenAI.GlobalStandard.gpt-4o", DefaultCapacity: 50, MaxCapacity: 100},
+		{Name: "Standard", UsageName: "OpenAI.Standard.gpt-4o", DefaultCapacity: 10, MaxCapacity: 20},
+		{Name: "Provisioned", UsageName: "OpenAI.Provisioned.gpt-4o", MinCapacity: 50},
+	}
+
+	tests := []struct {
+		name         string
+		preferredSku string
+		wantSku      string
+		wantCapacity int32
+		wantCode     codes.Code
+	}{
+		{name: "first SKU", wantSku: "GlobalStandard", wantCapacity: 50},
+		{name: "preferred SKU", preferredSku: "standard", wantSku: "Standard", wantCapacity: 10},
+		{name: "preferred SKU unavailable", preferredSku: "DataZoneStandard", wantCode: codes.FailedPrecondition},
+		{name: "no default capacity", preferredSku: "Provisioned", wantCode: codes.FailedPrecondition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := service.PromptAiSku(t.Context(), &azdext.PromptAiSkuRequest{
+				ModelName:    "gpt-4o",
+				Skus:         skus,
+				PreferredSku: tt.preferredSku,
+			})
+			if tt.wantCode != codes.OK {
+				require.Error(t, err)
+				require.Equal(t, tt.wantCode, status.Code(err))
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantSku, resp.Sku.Name)
+			require.Equal(t, tt.wantCapacity, resp.Capacity)
+			require.Nil(t, resp.RemainingQuota)
+		})
+	}
 }
 
-func TestValidateDeploymentCapacity_AboveMax(t *testing.T) {
+func Test_PromptService_PromptAiSku_InvalidArguments(t *testing.T) {
 	t.Parallel()
-	sku := ai.AiModelSku{MaxCapacity: 100}
-	_, err := validateDeploymentCapacity("200", sku)
-	require.Error(t, err)
-	require.Contains(t, err.Error(), "at most 100")
+
+	service := NewPromptService(nil, nil, nil, &internal.GlobalCommandOptions{NoPrompt: true}, nil, nil, nil, nil, nil)
+
+	_, err := service.PromptAiSku(t.Context(), &azdext.PromptAiSkuRequest{ModelName: "gpt-4o"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = service.PromptAiSku(t.Context(), &azdext.PromptAiSkuRequest{
+		ModelName: "gpt-4o",
+		Skus:      []*azdext.AiModelSku{{Name: "GlobalStandard", DefaultCapacity: 10}},
+		Quota:     &azdext.QuotaCheckOptions{},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Contains(t, err.Error(), "location")
 }
 
-func TestValidateDeploymentCapacity_WrongStep(t *testing.T) {
+func TestPromptService_DefaultAiLocation(t *testing.T) {
 	t.Parallel()
-	sku := ai.AiModelSku{CapacityStep: 10}
-	_, err := validateDeploymentCapacity("15", sku)
-	require.Error(t, err)
-	require.Contains(t, err.Error(), "multiple of 10")
+
+	env := environment.NewWithValues("dev", map[string]string{environment.LocationEnvVarName: "eastus2"})
+
+	tests := []struct {
+		name         string
+		env          *lazy.Lazy[*environment.Environment]
+		azureContext *azdext.AzureContext
+		want         string
+	}{
+		{
+			name:         "AzureContextWins",
+			env:          lazy.From(env),
+			azureContext: &azdext.AzureContext{Scope: &azdext.AzureScope{Location: "westus"}},
+			want:         "westus",
+		},
+		{
+			name:         "FromEnvironment",
+			env:          lazy.From(env),
+			azureContext: &azdext.AzureContext{Scope: &azdext.AzureScope{SubscriptionId: "sub-123"}},
+			want:         "eastus2",
+		},
+		{
+			name: "NilAzureContext",
+			env:  lazy.From(env),
+			want: "eastus2",
+		},
+		{
+			name: "NoEnvironment",
+			env: lazy.NewLazy(func() (*environment.Environment, error) {
+				return nil, environment.ErrDefaultEnvironmentNotFound
+			}),
+			azureContext: &azdext.AzureContext{Scope: &azdext.AzureScope{}},
+			want:         "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			service := NewPromptService(
+				nil, nil, nil, &internal.GlobalCommandOptions{}, tt.env, nil, nil, nil, nil,
+			).(*promptService)
+			require.Equal(t, tt.want, service.defaultAiLocation(tt.azureContext))
+		})
+	}
 }
 
-func TestValidateDeploymentCapacity_Valid(t *testing.T) {
+func Test_PromptService_PromptAiSku_QuotaLocationFromEnvironment(t *testing.T) {
 	t.Parallel()
-	sku := ai.AiModelSku{MinCapacity: 10, MaxCapacity: 100, CapacityStep: 10}
-	cap, err := validateDeploymentCapacity("50", sku)
-	require.NoError(t, err)
-	require.Equal(t, int32(50), cap)
+
+	env := environment.NewWithValues("dev", map[string]string{environment.LocationEnvVarName: "eastus2"})
+	service := NewPromptService(
+		nil, nil, nil, &internal.GlobalCommandOptions{NoPrompt: true}, lazy.From(env), nil, nil, nil, nil,
+	)
+
+	// The environment supplies the quota location, so the request only fails later for lack of an AI model service.
+	_, err := service.PromptAiSku(t.Context(), &azdext.PromptAiSkuRequest{
+		AzureContext: &azdext.AzureContext{Scope: &azdext.AzureScope{SubscriptionId: "sub-123"}},
+		ModelName:    "gpt-4o",
+		Skus:         []*azdext.AiModelSku{{Name: "GlobalStandard", DefaultCapacity: 10}},
+		Quota:        &azdext.QuotaCheckOptions{},
+	})
+	require.ErrorIs(t, err, errAiServiceUnavailable)
 }
 
 // --- validateCapacityAgainstRemainingQuota tests ---
@@ -1928,20 +3605,151 @@ func TestAcquirePromptLock_CancelledContext(t *testing.T) {
 	release1()
 }
 
+func TestAcquirePromptLock_ExpiredDeadlineWithFreeLock(t *testing.T) {
+	t.Parallel()
+	svc := &promptService{lock: newPromptLock()}
+
+	ctx, cancel := context.WithDeadline(t.Context(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	// Even though the lock is free, an expired deadline must not start a prompt.
+	_, err := svc.acquirePromptLock(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	release, err := svc.acquirePromptLock(t.Context())
+	require.NoError(t, err, "lock must not be held after a rejected acquire")
+	release()
+}
+
+// Not parallel: usage attributes are process-global.
+func TestAcquirePromptLock_RecordsWait(t *testing.T) {
+	tracing.ResetUsageAttributesForTest()
+	t.Cleanup(tracing.ResetUsageAttributesForTest)
+
+	usage := func() map[attribute.Key]int64 {
+		values := map[attribute.Key]int64{}
+		for _, attr := range tracing.GetUsageAttributes() {
+			values[attr.Key] = attr.Value.AsInt64()
+		}
+		return values
+	}
+
+	svc := &promptService{lock: newPromptLock()}
+
+	// A free lock is not a wait.
+	release, err := svc.acquirePromptLock(t.Context())
+	require.NoError(t, err)
+	require.NotContains(t, usage(), fields.ExtensionPromptLockWaitCount.Key)
+
+	time.AfterFunc(20*time.Millisecond, release)
+	release, err = svc.acquirePromptLock(t.Context())
+	require.NoError(t, err)
+	release()
+
+	values := usage()
+	require.Equal(t, int64(1), values[fields.ExtensionPromptLockWaitCount.Key])
+	require.GreaterOrEqual(t, values[fields.ExtensionPromptLockWaitMs.Key], int64(20))
+}
+
+func TestPromptService_DeadlineCancelsPendingPrompt(t *testing.T) {
+	t.Parallel()
+	globalOptions := &internal.GlobalCommandOptions{NoPrompt: false}
+	svc := NewPromptService(nil, nil, nil, globalOptions, nil, nil, nil, nil, nil).(*promptService)
+
+	// Simulate another prompt that is still waiting for user input.
+	release, err := svc.acquirePromptLock(t.Context())
+	require.NoError(t, err)
+	defer release()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := svc.Confirm(ctx, &azdext.ConfirmRequest{
+			Options: &azdext.ConfirmOptions{Message: "Continue?"},
+		})
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Confirm did not return after the request deadline expired")
+	}
+}
+
+func TestPromptService_CancelAbortsPendingPrompt(t *testing.T) {
+	// The editor settings are cleared so PromptEditor falls back to reading terminal input.
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+
+	globalOptions := &internal.GlobalCommandOptions{NoPrompt: false}
+	svc := NewPromptService(nil, nil, nil, globalOptions, nil, nil, nil, nil, nil).(*promptService)
+
+	// Input that never arrives keeps the prompt waiting for the user.
+	stdin, stdinWriter := io.Pipe()
+	defer stdinWriter.Close()
+	svc.stdin = stdin
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := svc.PromptEditor(ctx, &azdext.PromptEditorRequest{
+			Options: &azdext.PromptEditorOptions{Message: "Edit the configuration"},
+		})
+		done <- err
+	}()
+
+	// Wait until the prompt holds the lock, then cancel the request while it waits for input.
+	require.Eventually(t, func() bool { return len(svc.lock.ch) == 1 }, 5*time.Second, 10*time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("PromptEditor did not return after the request was cancelled")
+	}
+
+	// The next prompt can take the lock without waiting.
+	release, err := svc.acquirePromptLock(t.Context())
+	require.NoError(t, err)
+	release()
+}
+
 // --- PromptAi* method tests (validation paths) ---
 
 func TestPromptService_PromptAiModel_NilSubscription(t *testing.T) {
 	t.Parallel()
-	svc := NewPromptService(nil, nil, nil, nil)
+	svc := NewPromptService(nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	_, err := svc.PromptAiModel(t.Context(), &azdext.PromptAiModelRequest{
 		AzureContext: nil,
 	})
 	require.Error(t, err)
 }
 
+func TestPromptService_PromptAiModelFallbackLocation(t *testing.T) {
+	t.Parallel()
+	svc := NewPromptService(nil, nil, nil, nil, nil, nil, nil, nil, nil).(*promptService)
+
+	location, err := svc.promptAiModelFallbackLocation(
+		t.Context(), "sub", ai.AiModel{Name: "gpt-4o", Locations: []string{"swedencentral"}}, nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, "swedencentral", location)
+
+	_, err = svc.promptAiModelFallbackLocation(t.Context(), "sub", ai.AiModel{Name: "gpt-4o"}, nil, nil, nil)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.NotFound, st.Code())
+}
+
 func TestPromptService_PromptAiDeployment_NilSubscription(t *testing.T) {
 	t.Parallel()
-	svc := NewPromptService(nil, nil, nil, nil)
+	svc := NewPromptService(nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	_, err := svc.PromptAiDeployment(t.Context(), &azdext.PromptAiDeploymentRequest{
 		AzureContext: nil,
 	})
@@ -1950,7 +3758,7 @@ func TestPromptService_PromptAiDeployment_NilSubscription(t *testing.T) {
 
 func TestPromptService_PromptAiDeployment_QuotaRequiresOneLocation(t *testing.T) {
 	t.Parallel()
-	svc := NewPromptService(nil, nil, nil, nil)
+	svc := NewPromptService(nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	_, err := svc.PromptAiDeployment(t.Context(), &azdext.PromptAiDeploymentRequest{
 		AzureContext: &azdext.AzureContext{
 			Scope: &azdext.AzureScope{SubscriptionId: "sub-123"},
@@ -1965,7 +3773,7 @@ func TestPromptService_PromptAiDeployment_QuotaRequiresOneLocation(t *testing.T)
 
 func TestPromptService_PromptAiDeployment_QuotaWithMultipleLocations(t *testing.T) {
 	t.Parallel()
-	svc := NewPromptService(nil, nil, nil, nil)
+	svc := NewPromptService(nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	_, err := svc.PromptAiDeployment(t.Context(), &azdext.PromptAiDeploymentRequest{
 		AzureContext: &azdext.AzureContext{
 			Scope: &azdext.AzureScope{SubscriptionId: "sub-123"},
@@ -1978,9 +3786,24 @@ func TestPromptService_PromptAiDeployment_QuotaWithMultipleLocations(t *testing.
 	require.Contains(t, err.Error(), "quota checking requires exactly one effective location")
 }
 
+func TestPromptService_PromptAiDeployment_NegativeDesiredCapacity(t *testing.T) {
+	t.Parallel()
+	svc := NewPromptService(nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	_, err := svc.PromptAiDeployment(t.Context(), &azdext.PromptAiDeploymentRequest{
+		AzureContext: &azdext.AzureContext{
+			Scope: &azdext.AzureScope{SubscriptionId: "sub-123"},
+		},
+		ModelName:       "gpt-4o",
+		DesiredCapacity: -10,
+	})
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Contains(t, err.Error(), "desired capacity must be greater than 0")
+}
+
 func TestPromptService_PromptAiLocationWithQuota_NilSubscription(t *testing.T) {
 	t.Parallel()
-	svc := NewPromptService(nil, nil, nil, nil)
+	svc := NewPromptService(nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	_, err := svc.PromptAiLocationWithQuota(t.Context(), &azdext.PromptAiLocationWithQuotaRequest{
 		AzureContext: nil,
 	})
@@ -1989,7 +3812,7 @@ func TestPromptService_PromptAiLocationWithQuota_NilSubscription(t *testing.T) {
 
 func TestPromptService_PromptAiModelLocationWithQuota_NilSubscription(t *testing.T) {
 	t.Parallel()
-	svc := NewPromptService(nil, nil, nil, nil)
+	svc := NewPromptService(nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	_, err := svc.PromptAiModelLocationWithQuota(t.Context(), &azdext.PromptAiModelLocationWithQuotaRequest{
 		AzureContext: nil,
 	})
@@ -1998,7 +3821,7 @@ func TestPromptService_PromptAiModelLocationWithQuota_NilSubscription(t *testing
 
 func TestPromptService_PromptAiModelLocationWithQuota_EmptyModelName(t *testing.T) {
 	t.Parallel()
-	svc := NewPromptService(nil, nil, nil, nil)
+	svc := NewPromptService(nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	_, err := svc.PromptAiModelLocationWithQuota(t.Context(), &azdext.PromptAiModelLocationWithQuotaRequest{
 		AzureContext: &azdext.AzureContext{
 			Scope: &azdext.AzureScope{SubscriptionId: "sub-123"},
@@ -2009,6 +3832,73 @@ func TestPromptService_PromptAiModelLocationWithQuota_EmptyModelName(t *testing.
 	require.Contains(t, err.Error(), "model_name is required")
 }
 
+func TestPromptService_AiMethods_ServiceUnavailable(t *testing.T) {
+	t.Parallel()
+	azureContext := &azdext.AzureContext{Scope: &azdext.AzureScope{SubscriptionId: "sub-123"}}
+
+	tests := []struct {
+		name string
+		call func(svc azdext.PromptServiceServer) error
+	}{
+		{
+			name: "PromptAiModel",
+			call: func(svc azdext.PromptServiceServer) error {
+				_, err := svc.PromptAiModel(t.Context(), &azdext.PromptAiModelRequest{AzureContext: azureContext})
+				return err
+			},
+		},
+		{
+			name: "PromptAiDeployment",
+			call: func(svc azdext.PromptServiceServer) error {
+				_, err := svc.PromptAiDeployment(t.Context(), &azdext.PromptAiDeploymentRequest{
+					AzureContext: azureContext,
+					ModelName:    "gpt-4o",
+				})
+				return err
+			},
+		},
+		{
+			name: "PromptAiLocationWithQuota",
+			call: func(svc azdext.PromptServiceServer) error {
+				_, err := svc.PromptAiLocationWithQuota(t.Context(), &azdext.PromptAiLocationWithQuotaRequest{
+					AzureContext: azureContext,
+				})
+				return err
+			},
+		},
+		{
+			name: "PromptAiModelLocationWithQuota",
+			call: func(svc azdext.PromptServiceServer) error {
+				_, err := svc.PromptAiModelLocationWithQuota(t.Context(), &azdext.PromptAiModelLocationWithQuotaRequest{
+					AzureContext: azureContext,
+					ModelName:    "gpt-4o",
+				})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.call(NewPromptService(nil, nil, nil, &internal.GlobalCommandOptions{}, nil, nil, nil, nil, nil))
+			require.ErrorIs(t, err, errAiServiceUnavailable)
+
+			suggestionErr, ok := errors.AsType[*internal.ErrorWithSuggestion](err)
+			require.True(t, ok)
+			require.Contains(t, suggestionErr.Suggestion, "Azure AI Services")
+
+			st, ok := status.FromError(mapHostError(err))
+			require.True(t, ok)
+			require.Equal(t, suggestionErr.Message, st.Message())
+			require.Len(t, st.Details(), 1)
+			detail, ok := st.Details()[0].(*azdext.ActionableErrorDetail)
+			require.True(t, ok)
+			require.Equal(t, suggestionErr.Suggestion, detail.Suggestion)
+		})
+	}
+}
+
 func (m *mockPromptService) PromptSubscription(
 	ctx context.Context, opts *prompt.SelectOptions,
 ) (*account.Subscription, error) {
@@ -2055,7 +3945,7 @@ func (m *mockPromptService) PromptResourceGroupResource(
 }
 
 func newTestPromptService(prompter *mockPromptService, noPrompt bool) azdext.PromptServiceServer {
-	return NewPromptService(prompter, nil, nil, &internal.GlobalCommandOptions{NoPrompt: noPrompt})
+	return NewPromptService(prompter, nil, nil, &internal.GlobalCommandOptions{NoPrompt: noPrompt}, nil, nil, nil, nil, nil)
 }
 
 func TestPromptService_Confirm_NilRequest(t *testing.T) {
@@ -2263,3 +4153,119 @@ func TestPromptService_PromptResourceGroupResource_NoPrompt_UsesSelectOptionsMes
 	require.Error(t, err)
 	requirePromptRequiredError(t, err, "Select existing web app")
 }
+
+// scriptedSelect is a wizardSelect that answers each prompt with the next scripted index, recording the prompts.
+type scriptedSelect struct {
+	answers []int
+	asked   []*ux.SelectOptions
+}
+
+func (s *scriptedSelect) ask(_ context.Context, options *ux.SelectOptions) (*int, error) {
+	s.asked = append(s.asked, options)
+	if len(s.answers) == 0 {
+		return nil, errors.New("prompt cancelled")
+	}
+
+	answer := s.answers[0]
+	s.answers = s.answers[1:]
+	return &answer, nil
+}
+
+func TestRunWizard(t *testing.T) {
+	t.Parallel()
+
+	choices := func(values ...string) []*ux.SelectChoice {
+		result := make([]*ux.SelectChoice, len(values))
+		for i, value := range values {
+			result[i] = &ux.SelectChoice{Value: value, Label: value}
+		}
+		return result
+	}
+
+	// newWizard returns a format/version/SKU wizard whose version step resolves without prompting when autoVersion
+	// is set, along with the values it selects.
+	newWizard := func(driver *scriptedSelect, autoVersion bool) ([]wizardStep, *[]string) {
+		selected := make([]string, 3)
+		selectStep := func(i int, message string, values ...string) wizardStep {
+			return func(ctx context.Context, canGoBack bool) (bool, error) {
+				idx, err := selectWizardStep(ctx, driver.ask, &ux.SelectOptions{
+					Message: message,
+					Choices: choices(values...),
+				}, canGoBack)
+				if err != nil {
+					return false, err
+				}
+				selected[i] = values[idx]
+				return true, nil
+			}
+		}
+
+		versionStep := selectStep(1, "version", "v1", "v2")
+		if autoVersion {
+			versionStep = func(context.Context, bool) (bool, error) {
+				selected[1] = "v1"
+				return false, nil
+			}
+		}
+
+		return []wizardStep{
+			selectStep(0, "format", "OpenAI", "Microsoft"),
+			versionStep,
+			selectStep(2, "sku", "Standard", "GlobalStandard"),
+		}, &selected
+	}
+
+	messages := func(driver *scriptedSelect) []string {
+		result := make([]string, len(driver.asked))
+		for i, options := range driver.asked {
+			result[i] = options.Message
+		}
+		return result
+	}
+
+	t.Run("Forward", func(t *testing.T) {
+		t.Parallel()
+		driver := &scriptedSelect{answers: []int{1, 0, 1}}
+		steps, selected := newWizard(driver, false)
+
+		require.NoError(t, runWizard(t.Context(), steps))
+		require.Equal(t, []string{"Microsoft", "v1", "GlobalStandard"}, *selected)
+		require.Equal(t, []string{"format", "version", "sku"}, messages(driver))
+
+		// The first step has no back choice; later steps do.
+		require.Len(t, driver.asked[0].Choices, 2)
+		require.Len(t, driver.asked[1].Choices, 3)
+		require.Equal(t, wizardBackLabel, driver.asked[1].Choices[2].Label)
+	})
+
+	t.Run("BackToPreviousStep", func(t *testing.T) {
+		t.Parallel()
+		// Select a format, go back from the version step, change the format, then continue.
+		driver := &scriptedSelect{answers: []int{0, 2, 1, 1, 0}}
+		steps, selected := newWizard(driver, false)
+
+		require.NoError(t, runWizard(t.Context(), steps))
+		require.Equal(t, []string{"Microsoft", "v2", "Standard"}, *selected)
+		require.Equal(t, []string{"format", "version", "format", "version", "sku"}, messages(driver))
+	})
+
+	t.Run("BackSkipsStepsThatDidNotPrompt", func(t *testing.T) {
+		t.Parallel()
+		// Going back from the SKU step returns to the format step, since the version step did not prompt.
+		driver := &scriptedSelect{answers: []int{0, 2, 1, 0}}
+		steps, selected := newWizard(driver, true)
+
+		require.NoError(t, runWizard(t.Context(), steps))
+		require.Equal(t, []string{"Microsoft", "v1", "Standard"}, *selected)
+		require.Equal(t, []string{"format", "sku", "format", "sku"}, messages(driver))
+	})
+
+	t.Run("CancelStopsWizard", func(t *testing.T) {
+		t.Parallel()
+		driver := &scriptedSelect{answers: []int{0}}
+		steps, _ := newWizard(driver, false)
+
+		require.EqualError(t, runWizard(t.Context(), steps), "prompt cancelled")
+		require.Equal(t, []string{"format", "version"}, messages(driver))
+	})
+}
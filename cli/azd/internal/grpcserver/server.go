@@ -98,10 +98,12 @@ func (s *Server) Start() (*ServerInfo, error) {
 		grpc.ChainUnaryInterceptor(
 			s.errorWrappingInterceptor(),
 			s.tokenAuthInterceptor(&serverInfo),
+			s.correlationInterceptor(),
 		),
 		grpc.ChainStreamInterceptor(
 			s.errorWrappingStreamInterceptor(),
 			s.tokenAuthStreamInterceptor(&serverInfo),
+			s.correlationStreamInterceptor(),
 		),
 	)
 
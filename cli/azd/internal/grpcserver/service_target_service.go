@@ -31,6 +31,15 @@ type ServiceTargetService struct {
 	lazyEnv          *lazy.Lazy[*environment.Environment]
 	providerMap      map[string]*grpcbroker.MessageBroker[azdext.ServiceTargetMessage]
 	providerMapMu    sync.Mutex
+	// targets tracks the service targets created for each host type, so that a target still in use can be
+	// reconnected when its extension re-registers after the stream dropped. Guarded by providerMapMu.
+	targets map[string]serviceTargetRegistration
+}
+
+// serviceTargetRegistration records the external service target created for a host type and the extension owning it.
+type serviceTargetRegistration struct {
+	extensionId string
+	target      *project.ExternalServiceTarget
 }
 
 // NewServiceTargetService creates a new ServiceTargetService instance.
@@ -44,6 +53,7 @@ func NewServiceTargetService(
 		extensionManager: extensionManager,
 		lazyEnv:          lazyEnv,
 		providerMap:      make(map[string]*grpcbroker.MessageBroker[azdext.ServiceTargetMessage]),
+		targets:          make(map[string]serviceTargetRegistration),
 	}
 }
 
@@ -75,6 +85,14 @@ func (s *ServiceTargetService) Stream(stream azdext.ServiceTargetService_StreamS
 	// Track the hostType for cleanup when stream closes
 	var registeredHostType string
 
+	// Unregister the provider however the stream ends, so that the extension can register again on a new stream and
+	// resume the requests that are waiting for it to reconnect.
+	defer func() {
+		s.providerMapMu.Lock()
+		delete(s.providerMap, registeredHostType)
+		s.providerMapMu.Unlock()
+	}()
+
 	// Register handler for RegisterServiceTargetRequest
 	err = broker.On(func(
 		ctx context.Context,
@@ -94,10 +112,6 @@ func (s *ServiceTargetService) Stream(stream azdext.ServiceTargetService_StreamS
 		return fmt.Errorf("broker error: %w", err)
 	}
 
-	s.providerMapMu.Lock()
-	delete(s.providerMap, registeredHostType)
-	s.providerMapMu.Unlock()
-
 	return nil
 }
 
@@ -110,6 +124,19 @@ func (s *ServiceTargetService) onRegisterRequest(
 	registeredHostType *string,
 ) (*azdext.ServiceTargetMessage, error) {
 	hostType := req.GetHost()
+
+	// Extensions advertise the hosts they provide as service-target providers in their manifest, which lets azd
+	// route azure.yaml services to them before they connect. Older installs may not record providers, so the check
+	// only applies when the extension advertises at least one.
+	if len(extension.ServiceTargetKinds()) > 0 && !extension.AdvertisesServiceTarget(hostType) {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"extension %s does not advertise service target %q; add it as a service-target provider in extension.yaml",
+			extension.Id,
+			hostType,
+		)
+	}
+
 	s.providerMapMu.Lock()
 	defer s.providerMapMu.Unlock()
 
@@ -117,12 +144,19 @@ func (s *ServiceTargetService) onRegisterRequest(
 		return nil, status.Errorf(codes.AlreadyExists, "provider %s already registered", hostType)
 	}
 
+	// The extension re-established its stream after a disconnect: hand the new broker to the target that is
+	// already in use so pending requests can be re-issued.
+	if existing, has := s.targets[hostType]; has && existing.extensionId == extension.Id {
+		existing.target.Reconnect(broker)
+		log.Printf("Reconnected service target: %s", hostType)
+	}
+
 	// Register external service target with DI container, passing the broker
 	err := s.container.RegisterNamedSingleton(hostType, func(
 		console input.Console,
 		prompter prompt.Prompter,
 	) project.ServiceTarget {
-		return project.NewExternalServiceTarget(
+		target := project.NewExternalServiceTarget(
 			hostType,
 			project.ServiceTargetKind(hostType),
 			extension,
@@ -131,6 +165,14 @@ func (s *ServiceTargetService) onRegisterRequest(
 			prompter,
 			s.lazyEnv,
 		)
+
+		if externalTarget, ok := target.(*project.ExternalServiceTarget); ok {
+			s.providerMapMu.Lock()
+			s.targets[hostType] = serviceTargetRegistration{extensionId: extension.Id, target: externalTarget}
+			s.providerMapMu.Unlock()
+		}
+
+		return target
 	})
 
 	if err != nil {
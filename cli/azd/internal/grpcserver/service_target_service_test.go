@@ -0,0 +1,235 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/alpha"
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/ioc"
+	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/azure/azure-dev/cli/azd/pkg/prompt"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockinput"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newServiceTargetExtension(kinds ...string) *extensions.Extension {
+	extension := &extensions.Extension{
+		Id:           "test.servicetarget",
+		Capabilities: []extensions.CapabilityType{extensions.ServiceTargetProviderCapability},
+	}
+
+	for _, kind := range kinds {
+		extension.Providers = append(extension.Providers, extensions.Provider{
+			Name: kind,
+			Type: extensions.ServiceTargetProviderType,
+		})
+	}
+
+	return extension
+}
+
+func TestServiceTargetService_onRegisterRequest_AdvertisedKind(t *testing.T) {
+	t.Parallel()
+
+	container := ioc.NewNestedContainer(nil)
+	ioc.RegisterInstance[input.Console](container, mockinput.NewMockConsole())
+	ioc.RegisterInstance[prompt.Prompter](container, &prompt.DefaultPrompter{})
+
+	svc := NewServiceTargetService(container, nil, nil).(*ServiceTargetService)
+
+	var hostType string
+	_, err := svc.onRegisterRequest(
+		t.Context(),
+		&azdext.RegisterServiceTargetRequest{Host: "mykind"},
+		newServiceTargetExtension("mykind"),
+		nil,
+		&hostType,
+	)
+	require.NoError(t, err)
+	require.Equal(t, "mykind", hostType)
+
+	serviceManager := project.NewServiceManager(
+		environment.NewWithValues("test", nil),
+		nil,
+		container,
+		nil,
+		alpha.NewFeaturesManagerWithConfig(config.NewEmptyConfig()),
+	)
+
+	target, err := serviceManager.GetServiceTarget(
+		t.Context(),
+		&project.ServiceConfig{Name: "api", Host: project.ServiceTargetKind("mykind")},
+	)
+	require.NoError(t, err)
+	require.IsType(t, &project.ExternalServiceTarget{}, target)
+}
+
+func TestServiceTargetService_onRegisterRequest_UnadvertisedKind(t *testing.T) {
+	t.Parallel()
+
+	container := ioc.NewNestedContainer(nil)
+	svc := NewServiceTargetService(container, nil, nil).(*ServiceTargetService)
+
+	var hostType string
+	_, err := svc.onRegisterRequest(
+		t.Context(),
+		&azdext.RegisterServiceTargetRequest{Host: "otherkind"},
+		newServiceTargetExtension("mykind"),
+		nil,
+		&hostType,
+	)
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Empty(t, hostType)
+
+	var target project.ServiceTarget
+	require.Error(t, container.ResolveNamed("otherkind", &target))
+}
+
+// fakeServiceTargetServerStream is the extension side of a service target stream as seen by ServiceTargetService.
+// Messages sent to the extension are delivered on toExtension. Sending an error on recvErr ends the stream with it.
+type fakeServiceTargetServerStream struct {
+	grpc.ServerStream
+	ctx           context.Context
+	toExtension   chan *azdext.ServiceTargetMessage
+	fromExtension chan *azdext.ServiceTargetMessage
+	recvErr       chan error
+}
+
+func newFakeServiceTargetServerStream(ctx context.Context) *fakeServiceTargetServerStream {
+	return &fakeServiceTargetServerStream{
+		ctx:           ctx,
+		toExtension:   make(chan *azdext.ServiceTargetMessage, 10),
+		fromExtension: make(chan *azdext.ServiceTargetMessage, 10),
+		recvErr:       make(chan error, 1),
+	}
+}
+
+func (s *fakeServiceTargetServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeServiceTargetServerStream) Send(msg *azdext.ServiceTargetMessage) error {
+	s.toExtension <- msg
+	return nil
+}
+
+func (s *fakeServiceTargetServerStream) Recv() (*azdext.ServiceTargetMessage, error) {
+	select {
+	case msg := <-s.fromExtension:
+		return msg, nil
+	case err := <-s.recvErr:
+		return nil, err
+	}
+}
+
+// register sends a registration for hostType on the stream and waits for its response.
+func (s *fakeServiceTargetServerStream) register(t *testing.T, hostType string) *azdext.ServiceTargetMessage {
+	t.Helper()
+
+	s.fromExtension <- &azdext.ServiceTargetMessage{
+		RequestId: "register-" + hostType,
+		MessageType: &azdext.ServiceTargetMessage_RegisterServiceTargetRequest{
+			RegisterServiceTargetRequest: &azdext.RegisterServiceTargetRequest{Host: hostType},
+		},
+	}
+
+	return <-s.toExtension
+}
+
+func TestServiceTargetService_Stream_ReregistersAfterBrokenStream(t *testing.T) {
+	mockContext := mocks.NewMockContext(t.Context())
+	userConfigManager := config.NewUserConfigManager(mockContext.ConfigManager)
+	userConfig, err := userConfigManager.Load()
+	require.NoError(t, err)
+
+	extension := newServiceTargetExtension("mykind")
+	require.NoError(t, userConfig.Set("extension.installed", map[string]*extensions.Extension{extension.Id: extension}))
+
+	extensionManager, err := extensions.NewManager(
+		userConfigManager,
+		nil,
+		lazy.NewLazy(func() (*extensions.Runner, error) { return nil, nil }),
+		mockContext.HttpClient,
+	)
+	require.NoError(t, err)
+
+	container := ioc.NewNestedContainer(nil)
+	ioc.RegisterInstance[input.Console](container, mockinput.NewMockConsole())
+	ioc.RegisterInstance[prompt.Prompter](container, &prompt.DefaultPrompter{})
+	svc := NewServiceTargetService(container, extensionManager, nil)
+
+	ctx := extensions.WithClaimsContext(t.Context(), &extensions.ExtensionClaims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: extension.Id},
+	})
+
+	// startStream serves stream as ServiceTargetService.Stream does for a connected extension.
+	startStream := func(stream *fakeServiceTargetServerStream) <-chan error {
+		done := make(chan error, 1)
+		go func() {
+			done <- svc.Stream(stream)
+		}()
+		return done
+	}
+
+	firstStream := newFakeServiceTargetServerStream(ctx)
+	firstDone := startStream(firstStream)
+	require.NotNil(t, firstStream.register(t, "mykind").GetRegisterServiceTargetResponse())
+
+	var target project.ServiceTarget
+	require.NoError(t, container.ResolveNamed("mykind", &target))
+
+	type result struct {
+		endpoints []string
+		err       error
+	}
+	endpointsDone := make(chan result, 1)
+	go func() {
+		endpoints, err := target.Endpoints(ctx, nil, nil)
+		endpointsDone <- result{endpoints, err}
+	}()
+
+	// The extension receives the request, then its stream breaks with an error other than EOF.
+	first := <-firstStream.toExtension
+	require.NotNil(t, first.GetEndpointsRequest())
+	firstStream.recvErr <- errors.New("connection reset by peer")
+	require.Error(t, <-firstDone)
+
+	// The extension registers again on a new stream and answers the re-issued request.
+	secondStream := newFakeServiceTargetServerStream(ctx)
+	startStream(secondStream)
+	require.NotNil(t, secondStream.register(t, "mykind").GetRegisterServiceTargetResponse())
+
+	reissued := <-secondStream.toExtension
+	require.Equal(t, first.RequestId, reissued.RequestId)
+	secondStream.fromExtension <- &azdext.ServiceTargetMessage{
+		RequestId: reissued.RequestId,
+		MessageType: &azdext.ServiceTargetMessage_EndpointsResponse{
+			EndpointsResponse: &azdext.ServiceTargetEndpointsResponse{Endpoints: []string{"https://example.com"}},
+		},
+	}
+
+	select {
+	case r := <-endpointsDone:
+		require.NoError(t, r.err)
+		require.Equal(t, []string{"https://example.com"}, r.endpoints)
+	case <-time.After(5 * time.Second):
+		t.Fatal("request was not completed after the extension registered again")
+	}
+}
@@ -135,6 +135,7 @@ func TestExecInfra(t *testing.T) {
 							},
 						},
 					},
+					Location: "eastus2",
 				},
 				DbPostgres: &DatabasePostgres{
 					DatabaseName: "appdb",
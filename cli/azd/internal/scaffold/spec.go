@@ -86,6 +86,8 @@ type AIModel struct {
 type AiFoundrySpec struct {
 	Name   string
 	Models []AiFoundryModel
+	// Location is the location the models were resolved for, when they all agree on one.
+	Location string
 }
 
 type AiFoundryModel struct {
@@ -106,6 +108,8 @@ type AIModelModel struct {
 	Name string
 	// The version of the underlying model.
 	Version string
+	// The format of the underlying model. Empty means OpenAI.
+	Format string
 }
 
 type AISearch struct {
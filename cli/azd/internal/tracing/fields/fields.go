@@ -1222,6 +1222,21 @@ var (
 		Purpose:        FeatureInsight,
 		IsMeasurement:  true,
 	}
+	// ExtensionPromptLockWaitCount is the number of extension prompts that waited for another prompt to finish.
+	ExtensionPromptLockWaitCount = AttributeKey{
+		Key:            attribute.Key("extension.prompt_lock.wait_count"),
+		Classification: SystemMetadata,
+		Purpose:        PerformanceAndHealth,
+		IsMeasurement:  true,
+	}
+	// ExtensionPromptLockWaitMs is the total time in milliseconds extension prompts waited for another prompt to
+	// finish.
+	ExtensionPromptLockWaitMs = AttributeKey{
+		Key:            attribute.Key("extension.prompt_lock.wait_ms"),
+		Classification: SystemMetadata,
+		Purpose:        PerformanceAndHealth,
+		IsMeasurement:  true,
+	}
 )
 
 // Update related fields
@@ -283,6 +283,9 @@ func Test_GetLocations(t *testing.T) {
 
 		require.NoError(t, err)
 		require.Len(t, locations, 4)
+
+		eastus := locations[slices.IndexFunc(locations, func(l Location) bool { return l.Name == "eastus" })]
+		require.Equal(t, []string{"westus"}, eastus.PairedRegions)
 	})
 
 	t.Run("ErrorNoDefaultSubscription", func(t *testing.T) {
@@ -634,7 +637,8 @@ func setupAccountMocks(mockHttp *mockhttp.MockHttpClient) {
 						DisplayName:         new("West US"),
 						RegionalDisplayName: new("(US) West US"),
 						Metadata: &armsubscriptions.LocationMetadata{
-							RegionType: to.Ptr(armsubscriptions.RegionTypePhysical),
+							RegionType:   to.Ptr(armsubscriptions.RegionTypePhysical),
+							PairedRegion: []*armsubscriptions.PairedRegion{{Name: new("eastus")}},
 						},
 					},
 					{
@@ -652,7 +656,8 @@ func setupAccountMocks(mockHttp *mockhttp.MockHttpClient) {
 						DisplayName:         new("East US"),
 						RegionalDisplayName: new("(US) East US"),
 						Metadata: &armsubscriptions.LocationMetadata{
-							RegionType: to.Ptr(armsubscriptions.RegionTypePhysical),
+							RegionType:   to.Ptr(armsubscriptions.RegionTypePhysical),
+							PairedRegion: []*armsubscriptions.PairedRegion{{Name: new("westus")}},
 						},
 					},
 					{
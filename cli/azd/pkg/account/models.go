@@ -26,4 +26,6 @@ type Location struct {
 	// The human friendly name of the location, prefixed with a
 	// region name (e.g "(US) West US 2")
 	RegionalDisplayName string `json:"regionalDisplayName"`
+	// The names of the regions paired with the location for disaster recovery (e.g. "westus" for "eastus")
+	PairedRegions []string `json:"pairedRegions,omitempty"`
 }
@@ -128,10 +128,18 @@ func (s *SubscriptionsService) ListSubscriptionLocations(
 				displayName := convert.ToValueWithDefault(location.DisplayName, *location.Name)
 				regionalDisplayName := convert.ToValueWithDefault(location.RegionalDisplayName, displayName)
 
+				var pairedRegions []string
+				for _, paired := range location.Metadata.PairedRegion {
+					if paired != nil && paired.Name != nil {
+						pairedRegions = append(pairedRegions, *paired.Name)
+					}
+				}
+
 				locations = append(locations, Location{
 					Name:                *location.Name,
 					DisplayName:         displayName,
 					RegionalDisplayName: regionalDisplayName,
+					PairedRegions:       pairedRegions,
 				})
 			}
 		}
@@ -0,0 +1,37 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ai
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/internal/scaffold"
+)
+
+// DeploymentBicepParam formats a resolved deployment as the entry azd generates in the `deployments` parameter of the
+// AI project module (see resources/scaffold/templates/main.bicept), so users hand-editing infrastructure can see
+// exactly what azd would produce. Location and remaining quota are not part of the fragment.
+func DeploymentBicepParam(d AiModelDeployment) string {
+	var sb strings.Builder
+	sb.WriteString("{\n")
+	fmt.Fprintf(&sb, "  name: %s\n", bicepString(scaffold.BicepName(d.ModelName)+"Deployment"))
+	sb.WriteString("  model: {\n")
+	fmt.Fprintf(&sb, "    name: %s\n", bicepString(d.ModelName))
+	fmt.Fprintf(&sb, "    format: %s\n", bicepString(d.Format))
+	fmt.Fprintf(&sb, "    version: %s\n", bicepString(d.Version))
+	sb.WriteString("  }\n")
+	sb.WriteString("  sku: {\n")
+	fmt.Fprintf(&sb, "    name: %s\n", bicepString(d.Sku.Name))
+	fmt.Fprintf(&sb, "    capacity: %d\n", d.Capacity)
+	sb.WriteString("  }\n")
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
+
+// bicepString quotes s as a single-quoted bicep string literal.
+func bicepString(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
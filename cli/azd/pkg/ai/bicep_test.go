@@ -0,0 +1,72 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ai
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeploymentBicepParam(t *testing.T) {
+	tests := []struct {
+		name       string
+		deployment AiModelDeployment
+		want       string
+	}{
+		{
+			name: "openai model",
+			deployment: AiModelDeployment{
+				ModelName: "gpt-4o-mini",
+				Format:    "OpenAI",
+				Version:   "2024-07-18",
+				Location:  "eastus",
+				Sku:       AiModelSku{Name: "GlobalStandard", UsageName: "OpenAI.GlobalStandard.gpt-4o-mini"},
+				Capacity:  50,
+			},
+			want: `{
+  name: 'gpt4oMiniDeployment'
+  model: {
+    name: 'gpt-4o-mini'
+    format: 'OpenAI'
+    version: '2024-07-18'
+  }
+  sku: {
+    name: 'GlobalStandard'
+    capacity: 50
+  }
+}
+`,
+		},
+		{
+			name: "quotes are escaped",
+			deployment: AiModelDeployment{
+				ModelName: "model",
+				Format:    "Vendor's",
+				Version:   "1",
+				Sku:       AiModelSku{Name: "Standard"},
+				Capacity:  1,
+			},
+			want: `{
+  name: 'modelDeployment'
+  model: {
+    name: 'model'
+    format: 'Vendor\'s'
+    version: '1'
+  }
+  sku: {
+    name: 'Standard'
+    capacity: 1
+  }
+}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, DeploymentBicepParam(tt.deployment))
+		})
+	}
+}
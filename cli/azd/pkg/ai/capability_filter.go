@@ -0,0 +1,136 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ai
+
+import (
+	"strconv"
+	"strings"
+)
+
+// capabilityOperators lists the comparisons a capability filter can make, longest first so that ">=" is not read
+// as ">".
+var capabilityOperators = []string{">=", "<=", "==", "!=", ">", "<", "="}
+
+// capabilityPredicate is a numeric comparison against a capability value, e.g. maxContextToken>=128000.
+type capabilityPredicate struct {
+	Name     string
+	Operator string
+	Value    float64
+}
+
+// parseCapabilityPredicate parses filter as a numeric capability predicate such as "maxContextToken>=128000". It
+// returns false when filter is not one, e.g. a plain capability name such as "chatCompletion".
+func parseCapabilityPredicate(filter string) (capabilityPredicate, bool) {
+	for _, operator := range capabilityOperators {
+		name, value, found := strings.Cut(filter, operator)
+		if !found {
+			continue
+		}
+
+		name = strings.TrimSpace(name)
+		number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if name == "" || err != nil {
+			return capabilityPredicate{}, false
+		}
+
+		return capabilityPredicate{Name: name, Operator: operator, Value: number}, true
+	}
+
+	return capabilityPredicate{}, false
+}
+
+// matches reports whether values has a numeric value for the predicate's capability that satisfies it.
+func (p capabilityPredicate) matches(values map[string]string) bool {
+	raw, has := values[p.Name]
+	if !has {
+		return false
+	}
+	value, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+	if err != nil {
+		return false
+	}
+
+	switch p.Operator {
+	case ">=":
+		return value >= p.Value
+	case "<=":
+		return value <= p.Value
+	case ">":
+		return value > p.Value
+	case "<":
+		return value < p.Value
+	case "!=":
+		return value != p.Value
+	default:
+		return value == p.Value
+	}
+}
+
+// splitCapabilityFilter separates capability filters into plain capability names and numeric predicates.
+func splitCapabilityFilter(capabilities []string) ([]string, []capabilityPredicate) {
+	var names []string
+	var predicates []capabilityPredicate
+	for _, capability := range capabilities {
+		if predicate, ok := parseCapabilityPredicate(capability); ok {
+			predicates = append(predicates, predicate)
+		} else {
+			names = append(names, capability)
+		}
+	}
+
+	return names, predicates
+}
+
+// matchesCapabilityPredicates reports whether values satisfy every predicate.
+func matchesCapabilityPredicates(values map[string]string, predicates []capabilityPredicate) bool {
+	for _, predicate := range predicates {
+		if !predicate.matches(values) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchesCapabilities reports whether version satisfies capabilities: it has at least one of the plain capability
+// names, when there are any, and satisfies every numeric predicate.
+func matchesCapabilities(version AiModelVersion, capabilities []string) bool {
+	names, predicates := splitCapabilityFilter(capabilities)
+	if len(names) > 0 && !hasAnyCapability(version.Capabilities, names) {
+		return false
+	}
+
+	return matchesCapabilityPredicates(version.CapabilityValues, predicates)
+}
+
+// capabilityValues returns the values of the raw ARM capabilities, skipping nil ones.
+func capabilityValues(raw map[string]*string) map[string]string {
+	var values map[string]string
+	for name, value := range raw {
+		if value == nil {
+			continue
+		}
+		if values == nil {
+			values = map[string]string{}
+		}
+		values[name] = *value
+	}
+
+	return values
+}
+
+// mergeCapabilityValues adds the values of more missing from values.
+func mergeCapabilityValues(values map[string]string, more map[string]string) map[string]string {
+	for name, value := range more {
+		if _, has := values[name]; has {
+			continue
+		}
+		if values == nil {
+			values = map[string]string{}
+		}
+		values[name] = value
+	}
+
+	return values
+}
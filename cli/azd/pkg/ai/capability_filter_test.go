@@ -0,0 +1,166 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ai
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cognitiveservices/armcognitiveservices/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCapabilityPredicate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		filter string
+		want   capabilityPredicate
+		wantOk bool
+	}{
+		{"maxContextToken>=128000", capabilityPredicate{"maxContextToken", ">=", 128000}, true},
+		{" maxOutputToken <= 4096 ", capabilityPredicate{"maxOutputToken", "<=", 4096}, true},
+		{"maxContextToken>8000", capabilityPredicate{"maxContextToken", ">", 8000}, true},
+		{"maxContextToken=8192", capabilityPredicate{"maxContextToken", "=", 8192}, true},
+		{"maxContextToken==8192", capabilityPredicate{"maxContextToken", "==", 8192}, true},
+		{"maxContextToken!=8192", capabilityPredicate{"maxContextToken", "!=", 8192}, true},
+		{"chatCompletion", capabilityPredicate{}, false},
+		{"maxContextToken>=large", capabilityPredicate{}, false},
+		{">=128000", capabilityPredicate{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := parseCapabilityPredicate(tt.filter)
+			require.Equal(t, tt.wantOk, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMatchesCapabilities(t *testing.T) {
+	t.Parallel()
+
+	version := AiModelVersion{
+		Capabilities:     []string{"chatCompletion", "maxContextToken"},
+		CapabilityValues: map[string]string{"chatCompletion": "true", "maxContextToken": "128000"},
+	}
+
+	tests := []struct {
+		name         string
+		capabilities []string
+		want         bool
+	}{
+		{"Name", []string{"chatCompletion"}, true},
+		{"MissingName", []string{"embeddings"}, false},
+		{"PredicateHolds", []string{"maxContextToken>=128000"}, true},
+		{"PredicateFails", []string{"maxContextToken>128000"}, false},
+		{"NameAndPredicate", []string{"chatCompletion", "maxContextToken>=100000"}, true},
+		{"NameButPredicateFails", []string{"chatCompletion", "maxContextToken>=200000"}, false},
+		{"MissingCapabilityValue", []string{"maxOutputToken>=1"}, false},
+		{"NonNumericValue", []string{"chatCompletion>=1"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, matchesCapabilities(version, tt.capabilities))
+		})
+	}
+}
+
+func TestFilterModels_ContextWindow(t *testing.T) {
+	t.Parallel()
+
+	chat := func(contextWindow string) AiModelVersion {
+		return AiModelVersion{
+			Capabilities:     []string{"chatCompletion", "maxContextToken"},
+			CapabilityValues: map[string]string{"chatCompletion": "true", "maxContextToken": contextWindow},
+		}
+	}
+	withVersion := func(version AiModelVersion, name string, isDefault bool) AiModelVersion {
+		version.Version = name
+		version.IsDefault = isDefault
+		return version
+	}
+
+	models := []AiModel{
+		{
+			Name:         "gpt-4",
+			Format:       "OpenAI",
+			Capabilities: []string{"chatCompletion", "maxContextToken"},
+			Versions: []AiModelVersion{
+				withVersion(chat("8192"), "0613", true),
+				withVersion(chat("128000"), "turbo-2024-04-09", false),
+			},
+		},
+		{
+			Name:         "gpt-4o",
+			Format:       "OpenAI",
+			Capabilities: []string{"chatCompletion", "maxContextToken"},
+			Versions:     []AiModelVersion{withVersion(chat("128000"), "2024-11-20", true)},
+		},
+		{
+			Name:         "gpt-35-turbo",
+			Format:       "OpenAI",
+			Capabilities: []string{"chatCompletion", "maxContextToken"},
+			Versions:     []AiModelVersion{withVersion(chat("16384"), "0125", true)},
+		},
+		{
+			Name:         "text-embedding-3-large",
+			Format:       "OpenAI",
+			Capabilities: []string{"embeddings"},
+			Versions:     []AiModelVersion{{Version: "1", Capabilities: []string{"embeddings"}}},
+		},
+	}
+
+	versionsOf := func(models []AiModel) map[string][]string {
+		versions := map[string][]string{}
+		for _, m := range models {
+			for _, v := range m.Versions {
+				versions[m.Name] = append(versions[m.Name], v.Version)
+			}
+		}
+		return versions
+	}
+
+	require.Equal(t, map[string][]string{
+		"gpt-4":  {"turbo-2024-04-09"},
+		"gpt-4o": {"2024-11-20"},
+	}, versionsOf(FilterModels(models, &FilterOptions{
+		Capabilities: []string{"chatCompletion", "maxContextToken>=128000"},
+	})))
+
+	// The context window applies before collapsing to default versions, so a model whose default version is too small
+	// is still listed with the version that qualifies.
+	require.Equal(t, map[string][]string{
+		"gpt-4":  {"turbo-2024-04-09"},
+		"gpt-4o": {"2024-11-20"},
+	}, versionsOf(FilterModels(models, &FilterOptions{
+		Capabilities:        []string{"maxContextToken>=128000"},
+		DefaultVersionsOnly: true,
+	})))
+
+	require.Equal(t, map[string][]string{
+		"gpt-4":        {"0613"},
+		"gpt-35-turbo": {"0125"},
+	}, versionsOf(FilterModels(models, &FilterOptions{Capabilities: []string{"maxContextToken<100000"}})))
+	require.Len(t, models[0].Versions, 2, "the input models are not modified")
+}
+
+func TestConvertToAiModels_CapabilityValues(t *testing.T) {
+	t.Parallel()
+
+	svc := NewAiModelService(nil, nil)
+	raw := sampleModel("gpt-4o", "2024-11-20", "GlobalStandard", "OpenAI.GlobalStandard.gpt-4o", true)
+	raw.Model.Capabilities = map[string]*string{"chatCompletion": new("true"), "maxContextToken": new("128000")}
+
+	models := svc.convertToAiModels(map[string][]*armcognitiveservices.Model{"eastus": {raw}})
+	require.Len(t, models, 1)
+	require.Equal(t,
+		map[string]string{"chatCompletion": "true", "maxContextToken": "128000"},
+		models[0].Versions[0].CapabilityValues)
+}
@@ -170,3 +170,144 @@ func TestFallbackCapacityWithinQuota(t *testing.T) {
 		})
 	}
 }
+
+func TestParseCapacity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		value       string
+		sku         AiModelSku
+		want        int32
+		errContains string
+	}{
+		{
+			name:  "valid capacity with constraints",
+			value: "20",
+			sku: AiModelSku{
+				MinCapacity:  10,
+				MaxCapacity:  100,
+				CapacityStep: 10,
+			},
+			want: 20,
+		},
+		{
+			name:        "zero",
+			value:       "0",
+			sku:         AiModelSku{},
+			errContains: "greater than 0",
+		},
+		{
+			name:  "step aligned with minimum",
+			value: "17",
+			sku:   AiModelSku{MinCapacity: 7, CapacityStep: 5},
+			want:  17,
+		},
+		{
+			name:        "non-numeric value",
+			value:       "abc",
+			sku:         AiModelSku{},
+			errContains: "whole number",
+		},
+		{
+			name:  "below minimum",
+			value: "5",
+			sku: AiModelSku{
+				MinCapacity: 10,
+			},
+			errContains: "at least 10",
+		},
+		{
+			name:  "above maximum",
+			value: "120",
+			sku: AiModelSku{
+				MaxCapacity: 100,
+			},
+			errContains: "at most 100",
+		},
+		{
+			name:  "step mismatch",
+			value: "25",
+			sku: AiModelSku{
+				CapacityStep: 10,
+			},
+			errContains: "multiple of 10",
+		},
+		{
+			name:  "step mismatch within range",
+			value: "25",
+			sku: AiModelSku{
+				MinCapacity:  10,
+				MaxCapacity:  100,
+				CapacityStep: 10,
+			},
+			errContains: "must be between 10 and 100 in steps of 10",
+		},
+		{
+			name:  "above maximum with step",
+			value: "110",
+			sku: AiModelSku{
+				MinCapacity:  10,
+				MaxCapacity:  100,
+				CapacityStep: 10,
+			},
+			errContains: "must be between 10 and 100 in steps of 10",
+		},
+		{
+			name:  "no sku constraints accepts any positive integer",
+			value: "7",
+			sku:   AiModelSku{},
+			want:  7,
+		},
+		{
+			name:  "trimmed input is accepted",
+			value: " 30 ",
+			sku: AiModelSku{
+				MinCapacity: 10,
+			},
+			want: 30,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseCapacity(tt.sku, tt.value)
+			if tt.errContains != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.errContains)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCapacityConstraintDescription(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		sku  AiModelSku
+		want string
+	}{
+		{"none", AiModelSku{}, ""},
+		{
+			"range and step",
+			AiModelSku{MinCapacity: 10, MaxCapacity: 100, CapacityStep: 10},
+			"between 10 and 100 in steps of 10",
+		},
+		{"min and step", AiModelSku{MinCapacity: 10, CapacityStep: 10}, "at least 10 in steps of 10"},
+		{"max only", AiModelSku{MaxCapacity: 100}, "at most 100"},
+		{"step only", AiModelSku{CapacityStep: 5}, "a multiple of 5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.want, CapacityConstraintDescription(tt.sku))
+		})
+	}
+}
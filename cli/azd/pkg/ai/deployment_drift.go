@@ -0,0 +1,215 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ai
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cognitiveservices/armcognitiveservices/v2"
+)
+
+// deploymentAccountKinds are the cognitive account kinds that host model deployments.
+var deploymentAccountKinds = []string{"AIServices", "OpenAI"}
+
+// ListAccountDeployments lists the model deployments of the AI Services account accountName in resourceGroup, or of
+// every AI Services and Azure OpenAI account in resourceGroup when accountName is empty. Deployments are sorted by
+// account name, then deployment name.
+func (s *AiModelService) ListAccountDeployments(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroup string,
+	accountName string,
+) ([]AiAccountDeployment, error) {
+	var accounts []*armcognitiveservices.Account
+	if accountName != "" {
+		account, err := s.azureClient.GetCognitiveAccount(ctx, subscriptionId, resourceGroup, accountName)
+		if err != nil {
+			return nil, fmt.Errorf("getting AI Services account %q: %w", accountName, err)
+		}
+		accounts = append(accounts, &account)
+	} else {
+		all, err := s.azureClient.ListCognitiveAccounts(ctx, subscriptionId, resourceGroup)
+		if err != nil {
+			return nil, fmt.Errorf("listing AI Services accounts in %q: %w", resourceGroup, err)
+		}
+		for _, account := range all {
+			if slices.ContainsFunc(deploymentAccountKinds, func(kind string) bool {
+				return strings.EqualFold(kind, safeString(account.Kind))
+			}) {
+				accounts = append(accounts, account)
+			}
+		}
+	}
+
+	var result []AiAccountDeployment
+	for _, account := range accounts {
+		name := safeString(account.Name)
+		deployments, err := s.azureClient.GetCognitiveAccountDeployments(ctx, subscriptionId, resourceGroup, name)
+		if err != nil {
+			return nil, fmt.Errorf("listing deployments of AI Services account %q: %w", name, err)
+		}
+
+		for _, deployment := range deployments {
+			result = append(result, convertAccountDeployment(name, safeString(account.Location), deployment))
+		}
+	}
+
+	slices.SortFunc(result, func(a, b AiAccountDeployment) int {
+		return cmp.Or(cmp.Compare(a.AccountName, b.AccountName), cmp.Compare(a.Name, b.Name))
+	})
+
+	return result, nil
+}
+
+// convertAccountDeployment converts an ARM deployment of the account accountName at location.
+func convertAccountDeployment(
+	accountName string,
+	location string,
+	deployment *armcognitiveservices.Deployment,
+) AiAccountDeployment {
+	result := AiAccountDeployment{
+		Name:        safeString(deployment.Name),
+		AccountName: accountName,
+		Deployment:  AiModelDeployment{Location: location},
+	}
+
+	if sku := deployment.SKU; sku != nil {
+		result.Deployment.Sku = AiModelSku{
+			Name:           safeString(sku.Name),
+			DeploymentKind: ClassifySkuDeploymentKind(safeString(sku.Name)),
+		}
+		if sku.Capacity != nil {
+			result.Deployment.Capacity = *sku.Capacity
+		}
+	}
+
+	if props := deployment.Properties; props != nil {
+		if model := props.Model; model != nil {
+			result.Deployment.ModelName = safeString(model.Name)
+			result.Deployment.Format = safeString(model.Format)
+			result.Deployment.Version = safeString(model.Version)
+		}
+		if props.ProvisioningState != nil {
+			result.ProvisioningState = string(*props.ProvisioningState)
+		}
+	}
+
+	return result
+}
+
+// DeploymentDriftKind classifies a configured or deployed model deployment when comparing the two.
+type DeploymentDriftKind string
+
+const (
+	// DeploymentInSync means the deployment matches its configuration.
+	DeploymentInSync DeploymentDriftKind = "InSync"
+	// DeploymentChanged means the model is deployed, but with a different version, SKU or capacity than configured.
+	DeploymentChanged DeploymentDriftKind = "Changed"
+	// DeploymentNotDeployed means the configured model has no deployment yet.
+	DeploymentNotDeployed DeploymentDriftKind = "NotDeployed"
+	// DeploymentUnmanaged means the deployment exists in Azure but is not in the configuration.
+	DeploymentUnmanaged DeploymentDriftKind = "Unmanaged"
+)
+
+// DeploymentDrift is the outcome of comparing one configured deployment with what is deployed, or a deployment
+// that no configuration accounts for.
+type DeploymentDrift struct {
+	Kind DeploymentDriftKind
+	// Configured is the configured deployment; nil for DeploymentUnmanaged.
+	Configured *AiModelDeployment
+	// Deployed is the deployment in Azure; nil for DeploymentNotDeployed.
+	Deployed *AiAccountDeployment
+	// Differences describe each configured property the deployment does not match, e.g. "sku: Standard ->
+	// GlobalStandard". Only set for DeploymentChanged.
+	Differences []string
+}
+
+// ReconcileDeployments compares the configured model deployments with the deployments in Azure.
+//
+// A configured deployment matches a deployment of the same model name and, when configured, format. Its empty
+// version, SKU name and zero capacity match any deployed value, so configurations that leave them to the defaults do
+// not report drift. Each deployment is matched at most once, and exact matches are preferred, so two configurations
+// of one model pair with the two deployments that fit them best.
+//
+// Results follow the order of configured, then list the unmanaged deployments in the order of deployed.
+func ReconcileDeployments(configured []AiModelDeployment, deployed []AiAccountDeployment) []DeploymentDrift {
+	results := make([]DeploymentDrift, len(configured))
+	used := make([]bool, len(deployed))
+
+	// pair matches each unpaired configuration to the first unused deployment that accept returns true for.
+	pair := func(accept func(AiModelDeployment, AiModelDeployment) bool) {
+		for i := range configured {
+			if results[i].Deployed != nil {
+				continue
+			}
+			for j := range deployed {
+				if !used[j] && accept(configured[i], deployed[j].Deployment) {
+					used[j] = true
+					results[i].Deployed = &deployed[j]
+					break
+				}
+			}
+		}
+	}
+
+	pair(func(want, got AiModelDeployment) bool {
+		return sameDeployedModel(want, got) && len(deploymentDifferences(want, got)) == 0
+	})
+	pair(sameDeployedModel)
+
+	for i := range configured {
+		results[i].Configured = &configured[i]
+		if results[i].Deployed == nil {
+			results[i].Kind = DeploymentNotDeployed
+			continue
+		}
+
+		results[i].Differences = deploymentDifferences(configured[i], results[i].Deployed.Deployment)
+		results[i].Kind = DeploymentInSync
+		if len(results[i].Differences) > 0 {
+			results[i].Kind = DeploymentChanged
+		}
+	}
+
+	for j := range deployed {
+		if !used[j] {
+			results = append(results, DeploymentDrift{Kind: DeploymentUnmanaged, Deployed: &deployed[j]})
+		}
+	}
+
+	return results
+}
+
+// HasDeploymentDrift reports whether any result is not DeploymentInSync.
+func HasDeploymentDrift(results []DeploymentDrift) bool {
+	return slices.ContainsFunc(results, func(result DeploymentDrift) bool {
+		return result.Kind != DeploymentInSync
+	})
+}
+
+// sameDeployedModel reports whether got deploys the model that want configures.
+func sameDeployedModel(want AiModelDeployment, got AiModelDeployment) bool {
+	return strings.EqualFold(want.ModelName, got.ModelName) &&
+		(want.Format == "" || strings.EqualFold(want.Format, got.Format))
+}
+
+// deploymentDifferences describes the configured properties of want that got does not match.
+func deploymentDifferences(want AiModelDeployment, got AiModelDeployment) []string {
+	var differences []string
+	if want.Version != "" && want.Version != got.Version {
+		differences = append(differences, fmt.Sprintf("version: %s -> %s", want.Version, got.Version))
+	}
+	if want.Sku.Name != "" && !strings.EqualFold(want.Sku.Name, got.Sku.Name) {
+		differences = append(differences, fmt.Sprintf("sku: %s -> %s", want.Sku.Name, got.Sku.Name))
+	}
+	if want.Capacity != 0 && want.Capacity != got.Capacity {
+		differences = append(differences, fmt.Sprintf("capacity: %d -> %d", want.Capacity, got.Capacity))
+	}
+
+	return differences
+}
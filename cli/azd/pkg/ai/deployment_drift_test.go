@@ -0,0 +1,175 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ai
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cognitiveservices/armcognitiveservices/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func configuredDeployment(name, version, sku string, capacity int32) AiModelDeployment {
+	return AiModelDeployment{ModelName: name, Version: version, Sku: AiModelSku{Name: sku}, Capacity: capacity}
+}
+
+func deployedModel(deployment, name, version, sku string, capacity int32) AiAccountDeployment {
+	return AiAccountDeployment{
+		Name:        deployment,
+		AccountName: "ai-account",
+		Deployment: AiModelDeployment{
+			ModelName: name,
+			Format:    "OpenAI",
+			Version:   version,
+			Sku:       AiModelSku{Name: sku},
+			Capacity:  capacity,
+		},
+	}
+}
+
+func TestReconcileDeployments(t *testing.T) {
+	type outcome struct {
+		kind        DeploymentDriftKind
+		configured  string
+		deployed    string
+		differences []string
+	}
+
+	tests := []struct {
+		name       string
+		configured []AiModelDeployment
+		deployed   []AiAccountDeployment
+		want       []outcome
+	}{
+		{
+			name:       "in sync",
+			configured: []AiModelDeployment{configuredDeployment("gpt-4o", "2024-08-06", "GlobalStandard", 10)},
+			deployed:   []AiAccountDeployment{deployedModel("chat", "gpt-4o", "2024-08-06", "GlobalStandard", 10)},
+			want:       []outcome{{kind: DeploymentInSync, configured: "gpt-4o", deployed: "chat"}},
+		},
+		{
+			name:       "unset properties match any deployed value",
+			configured: []AiModelDeployment{configuredDeployment("GPT-4o", "", "", 0)},
+			deployed:   []AiAccountDeployment{deployedModel("chat", "gpt-4o", "2024-08-06", "Standard", 30)},
+			want:       []outcome{{kind: DeploymentInSync, configured: "GPT-4o", deployed: "chat"}},
+		},
+		{
+			name:       "changed",
+			configured: []AiModelDeployment{configuredDeployment("gpt-4o", "2024-11-20", "GlobalStandard", 10)},
+			deployed:   []AiAccountDeployment{deployedModel("chat", "gpt-4o", "2024-08-06", "Standard", 20)},
+			want: []outcome{{
+				kind:       DeploymentChanged,
+				configured: "gpt-4o",
+				deployed:   "chat",
+				differences: []string{
+					"version: 2024-11-20 -> 2024-08-06",
+					"sku: GlobalStandard -> Standard",
+					"capacity: 10 -> 20",
+				},
+			}},
+		},
+		{
+			name: "not deployed and unmanaged",
+			configured: []AiModelDeployment{
+				configuredDeployment("gpt-4o", "", "", 0),
+				configuredDeployment("text-embedding-3-small", "", "", 0),
+			},
+			deployed: []AiAccountDeployment{
+				deployedModel("chat", "gpt-4o", "2024-08-06", "GlobalStandard", 10),
+				deployedModel("legacy", "gpt-35-turbo", "0125", "Standard", 10),
+			},
+			want: []outcome{
+				{kind: DeploymentInSync, configured: "gpt-4o", deployed: "chat"},
+				{kind: DeploymentNotDeployed, configured: "text-embedding-3-small"},
+				{kind: DeploymentUnmanaged, deployed: "legacy"},
+			},
+		},
+		{
+			name: "exact matches are paired first",
+			configured: []AiModelDeployment{
+				configuredDeployment("gpt-4o", "", "Standard", 0),
+				configuredDeployment("gpt-4o", "", "GlobalStandard", 0),
+			},
+			deployed: []AiAccountDeployment{
+				deployedModel("global", "gpt-4o", "2024-08-06", "GlobalStandard", 10),
+				deployedModel("regional", "gpt-4o", "2024-08-06", "Standard", 10),
+			},
+			want: []outcome{
+				{kind: DeploymentInSync, configured: "gpt-4o", deployed: "regional"},
+				{kind: DeploymentInSync, configured: "gpt-4o", deployed: "global"},
+			},
+		},
+		{
+			name: "configured format must match",
+			configured: []AiModelDeployment{
+				{ModelName: "gpt-4o", Format: "Microsoft"},
+			},
+			deployed: []AiAccountDeployment{deployedModel("chat", "gpt-4o", "2024-08-06", "GlobalStandard", 10)},
+			want: []outcome{
+				{kind: DeploymentNotDeployed, configured: "gpt-4o"},
+				{kind: DeploymentUnmanaged, deployed: "chat"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := ReconcileDeployments(tt.configured, tt.deployed)
+
+			got := make([]outcome, len(results))
+			for i, result := range results {
+				got[i] = outcome{kind: result.Kind, differences: result.Differences}
+				if result.Configured != nil {
+					got[i].configured = result.Configured.ModelName
+				}
+				if result.Deployed != nil {
+					got[i].deployed = result.Deployed.Name
+				}
+			}
+			require.Equal(t, tt.want, got)
+
+			wantDrift := false
+			for _, want := range tt.want {
+				wantDrift = wantDrift || want.kind != DeploymentInSync
+			}
+			require.Equal(t, wantDrift, HasDeploymentDrift(results))
+		})
+	}
+}
+
+func TestConvertAccountDeployment(t *testing.T) {
+	state := armcognitiveservices.DeploymentProvisioningStateSucceeded
+	deployment := &armcognitiveservices.Deployment{
+		Name: new("chat"),
+		SKU:  &armcognitiveservices.SKU{Name: new("GlobalStandard"), Capacity: new(int32(50))},
+		Properties: &armcognitiveservices.DeploymentProperties{
+			Model: &armcognitiveservices.DeploymentModel{
+				Name:    new("gpt-4o"),
+				Format:  new("OpenAI"),
+				Version: new("2024-08-06"),
+			},
+			ProvisioningState: &state,
+		},
+	}
+
+	require.Equal(t, AiAccountDeployment{
+		Name:        "chat",
+		AccountName: "ai-account",
+		Deployment: AiModelDeployment{
+			ModelName: "gpt-4o",
+			Format:    "OpenAI",
+			Version:   "2024-08-06",
+			Location:  "eastus2",
+			Sku:       AiModelSku{Name: "GlobalStandard", DeploymentKind: SkuDeploymentKindGlobal},
+			Capacity:  50,
+		},
+		ProvisioningState: "Succeeded",
+	}, convertAccountDeployment("ai-account", "eastus2", deployment))
+
+	require.Equal(t, AiAccountDeployment{
+		Name:        "empty",
+		AccountName: "ai-account",
+		Deployment:  AiModelDeployment{Location: "eastus2"},
+	}, convertAccountDeployment("ai-account", "eastus2", &armcognitiveservices.Deployment{Name: new("empty")}))
+}
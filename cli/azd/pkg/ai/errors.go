@@ -3,13 +3,43 @@
 
 package ai
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	// ErrQuotaLocationRequired indicates quota checks were requested without exactly one location.
 	ErrQuotaLocationRequired = errors.New("quota checking requires exactly one location")
 	// ErrModelNotFound indicates the requested model was not found in the effective model catalog.
 	ErrModelNotFound = errors.New("model not found")
+	// ErrModelNotDeployable indicates the requested model is in the catalog, but none of its versions has a SKU
+	// that can still be deployed.
+	ErrModelNotDeployable = errors.New("model has no deployable versions")
 	// ErrNoDeploymentMatch indicates no deployment candidate matched provided filters/constraints.
 	ErrNoDeploymentMatch = errors.New("no deployment match")
+	// ErrLocationTimeout indicates a location did not answer a quota query within the location timeout.
+	ErrLocationTimeout = errors.New("location timed out")
 )
+
+// ModelNotFoundError reports a model name that the model catalog does not list, with the closest catalog name as a
+// suggestion when one is close enough. It matches [ErrModelNotFound] with errors.Is.
+type ModelNotFoundError struct {
+	// Name is the model name as requested.
+	Name string
+	// Suggestion is the closest catalog model name, or empty when none is close.
+	Suggestion string
+}
+
+func (e *ModelNotFoundError) Error() string {
+	if e.Suggestion != "" {
+		return fmt.Sprintf("%s: %q; did you mean %q?", ErrModelNotFound, e.Name, e.Suggestion)
+	}
+
+	return fmt.Sprintf("%s: %q", ErrModelNotFound, e.Name)
+}
+
+// Is reports whether target is [ErrModelNotFound].
+func (e *ModelNotFoundError) Is(target error) bool {
+	return target == ErrModelNotFound
+}
@@ -0,0 +1,103 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ai
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cognitiveservices/armcognitiveservices/v2"
+)
+
+// Names of the catalog filters tracked by filterRemovals, in the order they are applied.
+const (
+	filterStatuses            = "statuses"
+	filterExcludeModelNames   = "excludeModelNames"
+	filterFormats             = "formats"
+	filterCapabilityValues    = "capabilityValues"
+	filterDefaultVersionsOnly = "defaultVersionsOnly"
+	filterCapabilities        = "capabilities"
+	filterIntent              = "intent"
+	filterLocations           = "locations"
+)
+
+var filterOrder = []string{
+	filterStatuses,
+	filterExcludeModelNames,
+	filterFormats,
+	filterCapabilityValues,
+	filterDefaultVersionsOnly,
+	filterCapabilities,
+	filterIntent,
+	filterLocations,
+}
+
+// filterRemovals counts the model versions each catalog filter removed. It is only collected for debug logging;
+// a nil filterRemovals records nothing.
+type filterRemovals map[string]int
+
+func (r filterRemovals) add(filter string, versions int) {
+	if r != nil && versions > 0 {
+		r[filter] += versions
+	}
+}
+
+// String lists the count of every filter in the order the filters are applied.
+func (r filterRemovals) String() string {
+	counts := make([]string, 0, len(filterOrder))
+	for _, filter := range filterOrder {
+		counts = append(counts, fmt.Sprintf("%s=%d", filter, r[filter]))
+	}
+
+	return strings.Join(counts, " ")
+}
+
+// countStatusExcluded counts the raw catalog entries, one per model version and location, that the lifecycle status
+// filter drops during aggregation. Versions whose inference endpoint has retired are always dropped and not counted.
+func countStatusExcluded(
+	rawByLocation map[string][]*armcognitiveservices.Model,
+	now time.Time,
+	statuses []string,
+	includeDeprecated bool,
+) int {
+	count := 0
+	for _, models := range rawByLocation {
+		for _, m := range models {
+			if m.Model == nil || m.Model.Name == nil || modelInferenceRetired(m.Model.Deprecation, now) {
+				continue
+			}
+			if modelStatusExcluded(m.Model, now, statuses, includeDeprecated) {
+				count++
+			}
+		}
+	}
+
+	return count
+}
+
+// debugLogEnabled reports whether log output is kept, which azd only does when --debug is set.
+func debugLogEnabled() bool {
+	return log.Writer() != io.Discard
+}
+
+// describeFilterOptions formats the filters ListModelCatalog applies, after capability filters are split into names
+// and numeric predicates.
+func describeFilterOptions(options *FilterOptions) string {
+	names, predicates := splitCapabilityFilter(options.Capabilities)
+	formattedPredicates := make([]string, 0, len(predicates))
+	for _, predicate := range predicates {
+		formattedPredicates = append(formattedPredicates,
+			fmt.Sprintf("%s%s%g", predicate.Name, predicate.Operator, predicate.Value))
+	}
+
+	return fmt.Sprintf(
+		"statuses=%q includeDeprecated=%t excludeModelNames=%q formats=%q capabilities=%q capabilityValues=%q "+
+			"intent=%q locations=%q minAvailableCapacity=%g defaultVersionsOnly=%t",
+		options.Statuses, options.IncludeDeprecated, options.ExcludeModelNames, options.Formats, names,
+		formattedPredicates, options.Intent, options.Locations, options.MinAvailableCapacity,
+		options.DefaultVersionsOnly)
+}
@@ -0,0 +1,111 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ai
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cognitiveservices/armcognitiveservices/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterModels_RecordsRemovals(t *testing.T) {
+	models := []AiModel{
+		{
+			Name:         "gpt-4o",
+			Format:       "OpenAI",
+			Capabilities: []string{"chatCompletion"},
+			Locations:    []string{"eastus"},
+			Versions: []AiModelVersion{
+				{Version: "1", IsDefault: true, CapabilityValues: map[string]string{"maxContextToken": "128000"}},
+				{Version: "2", CapabilityValues: map[string]string{"maxContextToken": "128000"}},
+				{Version: "3", CapabilityValues: map[string]string{"maxContextToken": "8192"}},
+			},
+		},
+		{
+			Name:         "text-embedding-3-small",
+			Format:       "OpenAI",
+			Capabilities: []string{"embeddings"},
+			Locations:    []string{"eastus"},
+			Versions: []AiModelVersion{
+				{Version: "1", CapabilityValues: map[string]string{"maxContextToken": "128000"}},
+			},
+		},
+		{
+			Name:      "Phi-4",
+			Format:    "Microsoft",
+			Locations: []string{"westus"},
+			Versions:  []AiModelVersion{{Version: "1"}, {Version: "2"}},
+		},
+		{
+			Name:      "excluded",
+			Format:    "OpenAI",
+			Locations: []string{"eastus"},
+			Versions:  []AiModelVersion{{Version: "1"}},
+		},
+	}
+
+	removals := filterRemovals{}
+	filtered := filterModels(models, &FilterOptions{
+		ExcludeModelNames:   []string{"excluded"},
+		Formats:             []string{"OpenAI"},
+		Capabilities:        []string{"chatCompletion", "maxContextToken>=100000"},
+		DefaultVersionsOnly: true,
+	}, removals)
+
+	require.Len(t, filtered, 1)
+	require.Equal(t, "gpt-4o", filtered[0].Name)
+	require.Equal(t, filterRemovals{
+		filterExcludeModelNames:   1,
+		filterFormats:             2,
+		filterCapabilityValues:    1,
+		filterDefaultVersionsOnly: 1,
+		filterCapabilities:        1,
+	}, removals)
+	require.Equal(t,
+		"statuses=0 excludeModelNames=1 formats=2 capabilityValues=1 defaultVersionsOnly=1 capabilities=1 "+
+			"intent=0 locations=0",
+		removals.String())
+
+	// FilterModels records nothing and returns the same result.
+	require.Equal(t, filtered, FilterModels(models, &FilterOptions{
+		ExcludeModelNames:   []string{"excluded"},
+		Formats:             []string{"OpenAI"},
+		Capabilities:        []string{"chatCompletion", "maxContextToken>=100000"},
+		DefaultVersionsOnly: true,
+	}))
+}
+
+func TestCountStatusExcluded(t *testing.T) {
+	now := time.Now().UTC()
+	preview := sampleModel("gpt-4o", "2", "Standard", "OpenAI.Standard.gpt-4o", false)
+	preview.Model.LifecycleStatus = new(armcognitiveservices.ModelLifecycleStatus("Preview"))
+	deprecating := sampleModel("gpt-4o", "0", "Standard", "OpenAI.Standard.gpt-4o", false)
+	deprecating.Model.LifecycleStatus = new(armcognitiveservices.ModelLifecycleStatus("Deprecating"))
+
+	raw := map[string][]*armcognitiveservices.Model{
+		"eastus": {sampleModel("gpt-4o", "1", "Standard", "OpenAI.Standard.gpt-4o", true), preview, deprecating},
+		"westus": {preview},
+	}
+
+	require.Equal(t, 1, countStatusExcluded(raw, now, nil, false))
+	require.Equal(t, 0, countStatusExcluded(raw, now, nil, true))
+	require.Equal(t, 2, countStatusExcluded(raw, now, []string{"Preview"}, false))
+}
+
+func TestDescribeFilterOptions(t *testing.T) {
+	description := describeFilterOptions(&FilterOptions{
+		Statuses:     []string{"GenerallyAvailable"},
+		Formats:      []string{"OpenAI"},
+		Capabilities: []string{"chatCompletion", "maxContextToken >= 128000"},
+		Intent:       ModelIntentChat,
+	})
+
+	require.Contains(t, description, `statuses=["GenerallyAvailable"]`)
+	require.Contains(t, description, `formats=["OpenAI"]`)
+	require.Contains(t, description, `capabilities=["chatCompletion"]`)
+	require.Contains(t, description, `capabilityValues=["maxContextToken>=128000"]`)
+	require.Contains(t, description, `intent="chat"`)
+}
@@ -34,6 +34,7 @@ func registerAiModelMappings() {
 			Capabilities: src.Capabilities,
 			Versions:     versions,
 			Locations:    src.Locations,
+			Family:       src.Family,
 		}, nil
 	})
 
@@ -51,6 +52,7 @@ func registerAiModelMappings() {
 			Capabilities: src.Capabilities,
 			Versions:     versions,
 			Locations:    src.Locations,
+			Family:       src.Family,
 		}, nil
 	})
 
@@ -111,6 +113,65 @@ func registerAiModelMappings() {
 			Limit:        src.Limit,
 		}, nil
 	})
+
+	// ModelReportLocation -> proto AiModelReportLocation
+	mapper.MustRegister(func(_ context.Context, src *ModelReportLocation) (*azdext.AiModelReportLocation, error) {
+		versions := make([]*azdext.AiModelReportVersion, len(src.Versions))
+		for i, v := range src.Versions {
+			skus := make([]*azdext.AiModelReportSku, len(v.Skus))
+			for j, sku := range v.Skus {
+				skus[j] = &azdext.AiModelReportSku{
+					Sku:            aiModelSkuToProto(&sku.AiModelSku),
+					CurrentUsage:   sku.CurrentUsage,
+					Limit:          sku.Limit,
+					RemainingQuota: sku.RemainingQuota,
+					Deployable:     sku.Deployable(),
+				}
+			}
+			versions[i] = &azdext.AiModelReportVersion{
+				Version:         v.Version,
+				IsDefault:       v.IsDefault,
+				LifecycleStatus: v.LifecycleStatus,
+				Skus:            skus,
+			}
+		}
+
+		return &azdext.AiModelReportLocation{
+			Location:     src.Location,
+			Versions:     versions,
+			QuotaUnknown: src.QuotaUnknown,
+		}, nil
+	})
+
+	// proto AiModelReportLocation -> ModelReportLocation
+	mapper.MustRegister(func(_ context.Context, src *azdext.AiModelReportLocation) (*ModelReportLocation, error) {
+		versions := make([]ModelReportVersion, len(src.Versions))
+		for i, v := range src.Versions {
+			skus := make([]ModelReportSku, len(v.Skus))
+			for j, sku := range v.Skus {
+				skus[j] = ModelReportSku{
+					CurrentUsage:   sku.CurrentUsage,
+					Limit:          sku.Limit,
+					RemainingQuota: sku.RemainingQuota,
+				}
+				if sku.Sku != nil {
+					skus[j].AiModelSku = *protoToAiModelSku(sku.Sku)
+				}
+			}
+			versions[i] = ModelReportVersion{
+				Version:         v.Version,
+				IsDefault:       v.IsDefault,
+				LifecycleStatus: v.LifecycleStatus,
+				Skus:            skus,
+			}
+		}
+
+		return &ModelReportLocation{
+			Location:     src.Location,
+			Versions:     versions,
+			QuotaUnknown: src.QuotaUnknown,
+		}, nil
+	})
 }
 
 func aiModelVersionToProto(src *AiModelVersion) (*azdext.AiModelVersion, error) {
@@ -120,10 +181,13 @@ func aiModelVersionToProto(src *AiModelVersion) (*azdext.AiModelVersion, error)
 	}
 
 	return &azdext.AiModelVersion{
-		Version:         src.Version,
-		IsDefault:       src.IsDefault,
-		Skus:            skus,
-		LifecycleStatus: src.LifecycleStatus,
+		Version:          src.Version,
+		IsDefault:        src.IsDefault,
+		Skus:             skus,
+		LifecycleStatus:  src.LifecycleStatus,
+		Format:           src.Format,
+		Capabilities:     src.Capabilities,
+		CapabilityValues: src.CapabilityValues,
 	}, nil
 }
 
@@ -134,10 +198,13 @@ func protoToAiModelVersion(src *azdext.AiModelVersion) AiModelVersion {
 	}
 
 	return AiModelVersion{
-		Version:         src.Version,
-		IsDefault:       src.IsDefault,
-		Skus:            skus,
-		LifecycleStatus: src.LifecycleStatus,
+		Version:          src.Version,
+		IsDefault:        src.IsDefault,
+		Skus:             skus,
+		LifecycleStatus:  src.LifecycleStatus,
+		Format:           src.Format,
+		Capabilities:     src.Capabilities,
+		CapabilityValues: src.CapabilityValues,
 	}
 }
 
@@ -149,6 +216,7 @@ func aiModelSkuToProto(src *AiModelSku) *azdext.AiModelSku {
 		MinCapacity:     src.MinCapacity,
 		MaxCapacity:     src.MaxCapacity,
 		CapacityStep:    src.CapacityStep,
+		DeploymentKind:  string(src.DeploymentKind),
 	}
 }
 
@@ -160,5 +228,6 @@ func protoToAiModelSku(src *azdext.AiModelSku) *AiModelSku {
 		MinCapacity:     src.MinCapacity,
 		MaxCapacity:     src.MaxCapacity,
 		CapacityStep:    src.CapacityStep,
+		DeploymentKind:  SkuDeploymentKind(src.DeploymentKind),
 	}
 }
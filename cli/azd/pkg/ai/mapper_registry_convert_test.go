@@ -29,6 +29,12 @@ func TestMapper_AiModel_RoundTrip(t *testing.T) {
 				Version:         "2024-05-13",
 				IsDefault:       true,
 				LifecycleStatus: "GenerallyAvailable",
+				Format:          "OpenAI",
+				Capabilities:    []string{"chatCompletion", "maxContextToken"},
+				CapabilityValues: map[string]string{
+					"chatCompletion":  "true",
+					"maxContextToken": "128000",
+				},
 				Skus: []AiModelSku{
 					{
 						Name:            "Standard",
@@ -60,6 +66,9 @@ func TestMapper_AiModel_RoundTrip(t *testing.T) {
 	require.Equal(t, src.Versions[0].Version, proto.Versions[0].Version)
 	require.Equal(t, src.Versions[0].IsDefault, proto.Versions[0].IsDefault)
 	require.Equal(t, src.Versions[0].LifecycleStatus, proto.Versions[0].LifecycleStatus)
+	require.Equal(t, src.Versions[0].Format, proto.Versions[0].Format)
+	require.Equal(t, src.Versions[0].Capabilities, proto.Versions[0].Capabilities)
+	require.Equal(t, src.Versions[0].CapabilityValues, proto.Versions[0].CapabilityValues)
 	require.Len(t, proto.Versions[0].Skus, 1)
 	require.Equal(t, "OpenAI.Standard.gpt-4o", proto.Versions[0].Skus[0].UsageName)
 
@@ -73,6 +82,8 @@ func TestMapper_AiModel_RoundTrip(t *testing.T) {
 	require.Equal(t, src.Locations, back.Locations)
 	require.Len(t, back.Versions, len(src.Versions))
 	require.Equal(t, src.Versions[0].Skus[0], back.Versions[0].Skus[0])
+	require.Equal(t, src.Versions[0].Format, back.Versions[0].Format)
+	require.Equal(t, src.Versions[0].CapabilityValues, back.Versions[0].CapabilityValues)
 }
 
 func TestMapper_AiModelSku_RoundTrip(t *testing.T) {
@@ -185,3 +196,46 @@ func TestMapper_AiModelUsage_RoundTrip(t *testing.T) {
 	require.NotNil(t, back)
 	require.Equal(t, *src, *back)
 }
+
+func TestMapper_ModelReportLocation_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	src := &ModelReportLocation{
+		Location: "eastus",
+		Versions: []ModelReportVersion{
+			{
+				Version:         "2024-08-06",
+				IsDefault:       true,
+				LifecycleStatus: "GenerallyAvailable",
+				Skus: []ModelReportSku{
+					{
+						AiModelSku: AiModelSku{
+							Name:            "GlobalStandard",
+							UsageName:       "OpenAI.GlobalStandard.gpt-4o",
+							DefaultCapacity: 10,
+							DeploymentKind:  SkuDeploymentKindGlobal,
+						},
+						CurrentUsage:   95,
+						Limit:          100,
+						RemainingQuota: 5,
+					},
+				},
+			},
+		},
+	}
+
+	var proto *azdext.AiModelReportLocation
+	require.NoError(t, mapper.Convert(src, &proto))
+	require.NotNil(t, proto)
+	require.Equal(t, "eastus", proto.Location)
+	require.Len(t, proto.Versions, 1)
+	require.Len(t, proto.Versions[0].Skus, 1)
+	require.Equal(t, "GlobalStandard", proto.Versions[0].Skus[0].Sku.Name)
+	require.Equal(t, float64(5), proto.Versions[0].Skus[0].RemainingQuota)
+	require.False(t, proto.Versions[0].Skus[0].Deployable)
+
+	var back *ModelReportLocation
+	require.NoError(t, mapper.Convert(proto, &back))
+	require.NotNil(t, back)
+	require.Equal(t, *src, *back)
+}
@@ -100,6 +100,7 @@ func TestConvertSku(t *testing.T) {
 				MinCapacity:     1,
 				MaxCapacity:     100,
 				CapacityStep:    5,
+				DeploymentKind:  SkuDeploymentKindGlobal,
 			},
 		},
 		{
@@ -116,6 +117,7 @@ func TestConvertSku(t *testing.T) {
 				MinCapacity:     0,
 				MaxCapacity:     0,
 				CapacityStep:    0,
+				DeploymentKind:  SkuDeploymentKindRegional,
 			},
 		},
 		{
@@ -151,10 +153,33 @@ func TestConvertSku(t *testing.T) {
 			expected: AiModelSku{
 				Name:            "ProvisionedManaged",
 				UsageName:       "OpenAI.ProvisionedManaged",
-				DefaultCapacity: 0,
+				DefaultCapacity: 10,
 				MinCapacity:     10,
 				MaxCapacity:     0,
 				CapacityStep:    10,
+				DeploymentKind:  SkuDeploymentKindProvisioned,
+			},
+		},
+		{
+			name: "zero default normalized to min and step",
+			input: &armcognitiveservices.ModelSKU{
+				Name:      new("GlobalStandard"),
+				UsageName: new("OpenAI.GlobalStandard.gpt-4o"),
+				Capacity: &armcognitiveservices.CapacityConfig{
+					Default: new(int32(0)),
+					Minimum: new(int32(10)),
+					Maximum: new(int32(1000)),
+					Step:    new(int32(10)),
+				},
+			},
+			expected: AiModelSku{
+				Name:            "GlobalStandard",
+				UsageName:       "OpenAI.GlobalStandard.gpt-4o",
+				DefaultCapacity: 10,
+				MinCapacity:     10,
+				MaxCapacity:     1000,
+				CapacityStep:    10,
+				DeploymentKind:  SkuDeploymentKindGlobal,
 			},
 		},
 	}
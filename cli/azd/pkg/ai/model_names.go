@@ -0,0 +1,105 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ai
+
+import (
+	"slices"
+	"strings"
+)
+
+// modelNameAliases maps the normalized form of model names users commonly type to the catalog name, for variants
+// that normalization alone does not reconcile.
+var modelNameAliases = map[string]string{
+	"gpt3.5turbo":         "gpt-35-turbo",
+	"gpt3.5turbo16k":      "gpt-35-turbo-16k",
+	"gpt3.5turboinstruct": "gpt-35-turbo-instruct",
+}
+
+// normalizeModelName folds case and drops the separators users vary most in model names: hyphens, underscores and
+// spaces. Dots are kept, so that e.g. gpt-4.1 and gpt-41 stay apart.
+func normalizeModelName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '-', '_', ' ':
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(name)))
+}
+
+// ResolveModelName returns the name in names that name refers to. An exact match wins, then a case-insensitive one,
+// then one with the same normalized form, so that e.g. gpt4o and GPT-4o resolve to gpt-4o, and finally a known
+// alias such as gpt-3.5-turbo for gpt-35-turbo. It returns false when no name matches, or when several match at the
+// same step.
+func ResolveModelName(names []string, name string) (string, bool) {
+	if slices.Contains(names, name) {
+		return name, true
+	}
+
+	normalized := normalizeModelName(name)
+	steps := []func(candidate string) bool{
+		func(candidate string) bool { return strings.EqualFold(candidate, name) },
+		func(candidate string) bool { return normalizeModelName(candidate) == normalized },
+		func(candidate string) bool { return candidate == modelNameAliases[normalized] },
+	}
+	for _, matches := range steps {
+		var resolved []string
+		for _, candidate := range names {
+			if matches(candidate) && !slices.Contains(resolved, candidate) {
+				resolved = append(resolved, candidate)
+			}
+		}
+		if len(resolved) == 1 {
+			return resolved[0], true
+		}
+		if len(resolved) > 1 {
+			return "", false
+		}
+	}
+
+	return "", false
+}
+
+// SuggestModelName returns the name in names closest to name by edit distance between their normalized forms, or ""
+// when none is close enough to be a likely typo. Ties go to the name that sorts first.
+func SuggestModelName(names []string, name string) string {
+	normalized := normalizeModelName(name)
+	if normalized == "" {
+		return ""
+	}
+
+	// Allow about one edit per three characters, and at least two, so short names still get suggestions.
+	best, bestDistance := "", max(2, len(normalized)/3)+1
+	for _, candidate := range slices.Sorted(slices.Values(names)) {
+		if distance := editDistance(normalizeModelName(candidate), normalized); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a string, b string) int {
+	ar, br := []rune(a), []rune(b)
+	previous := make([]int, len(br)+1)
+	current := make([]int, len(br)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := range ar {
+		current[0] = i + 1
+		for j := range br {
+			substitution := previous[j]
+			if ar[i] != br[j] {
+				substitution++
+			}
+			current[j+1] = min(previous[j+1]+1, current[j]+1, substitution)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(br)]
+}
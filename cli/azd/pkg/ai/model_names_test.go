@@ -0,0 +1,72 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ai
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveModelName(t *testing.T) {
+	names := []string{"gpt-4o", "gpt-4o-mini", "gpt-4.1", "gpt-35-turbo", "text-embedding-3-small"}
+
+	tests := []struct {
+		name     string
+		input    string
+		want     string
+		resolved bool
+	}{
+		{name: "exact", input: "gpt-4o", want: "gpt-4o", resolved: true},
+		{name: "case", input: "GPT-4o", want: "gpt-4o", resolved: true},
+		{name: "missing hyphen", input: "gpt4o", want: "gpt-4o", resolved: true},
+		{name: "missing hyphens and case", input: "GPT4o-Mini", want: "gpt-4o-mini", resolved: true},
+		{name: "underscores and spaces", input: " text_embedding 3 small ", want: "text-embedding-3-small", resolved: true},
+		{name: "alias", input: "gpt-3.5-turbo", want: "gpt-35-turbo", resolved: true},
+		{name: "dots are significant", input: "gpt-41", resolved: false},
+		{name: "unknown", input: "gpt-4x", resolved: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ResolveModelName(names, tt.input)
+			require.Equal(t, tt.resolved, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("ambiguous", func(t *testing.T) {
+		_, ok := ResolveModelName([]string{"gpt-4o", "gpt_4o"}, "GPT4O")
+		require.False(t, ok)
+	})
+}
+
+func TestSuggestModelName(t *testing.T) {
+	names := []string{"gpt-4o-mini", "gpt-4o", "o3-mini", "text-embedding-3-small"}
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "gpt-4x", want: "gpt-4o"},
+		{input: "gtp-4o", want: "gpt-4o"},
+		{input: "gpt-4o-mni", want: "gpt-4o-mini"},
+		{input: "text-embeding-3-small", want: "text-embedding-3-small"},
+		{input: "llama-3", want: ""},
+		{input: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			require.Equal(t, tt.want, SuggestModelName(names, tt.input))
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	require.Equal(t, 0, editDistance("gpt4o", "gpt4o"))
+	require.Equal(t, 1, editDistance("gpt4o", "gpt4x"))
+	require.Equal(t, 2, editDistance("gtp4o", "gpt4o"))
+	require.Equal(t, 5, editDistance("", "gpt4o"))
+}
@@ -0,0 +1,183 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ai
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// BuildModelReport reports, for modelName at locations, or at every AI Services location when locations is empty,
+// where the model is offered, the versions and SKUs offered at each location, and the remaining quota of each SKU.
+// modelName is resolved with [ResolveModelName]. It returns a [*ModelNotFoundError] when the catalog does not list
+// the model and [ErrModelNotDeployable] when none of its versions can be deployed. Locations whose catalog or usages
+// cannot be fetched are reported in FailedLocations rather than failing the report. Each location's usages are
+// awaited for at most the location timeout, see [WithLocationTimeout]; other options do not apply.
+func (s *AiModelService) BuildModelReport(
+	ctx context.Context,
+	subscriptionId string,
+	modelName string,
+	locations []string,
+	opts ...QuotaCheckOption,
+) (*ModelReport, error) {
+	config := newQuotaCheckConfig(opts)
+
+	if len(locations) == 0 {
+		resolvedLocations, err := s.ListLocations(ctx, subscriptionId)
+		if err != nil {
+			return nil, err
+		}
+
+		locations = resolvedLocations
+	}
+
+	rawModels, failedLocations, err := s.fetchModelsForLocations(ctx, subscriptionId, locations)
+	if err != nil {
+		return nil, err
+	}
+
+	model, err := findModel(s.convertToAiModels(rawModels), rawModels, modelName)
+	if err != nil {
+		return nil, err
+	}
+
+	modelsByLocation := map[string]*AiModel{}
+	for _, location := range model.Locations {
+		if located := s.modelAtLocation(rawModels, location, model.Name, nil, nil); located != nil {
+			modelsByLocation[location] = located
+		}
+	}
+
+	usagesByLocation, usageFailures := s.listUsagesWithTimeout(ctx, subscriptionId, model.Locations, config.locationTimeout)
+
+	checked := slices.DeleteFunc(slices.Clone(locations), func(location string) bool {
+		return slices.ContainsFunc(failedLocations, func(failed LocationError) bool {
+			return failed.Location == location
+		})
+	})
+
+	report := buildModelReport(model.Name, checked, modelsByLocation, usagesByLocation)
+	report.FailedLocations = append(slices.Clone(failedLocations), usageFailures...)
+	sortLocationErrors(report.FailedLocations)
+
+	return report, nil
+}
+
+// listUsagesWithTimeout fetches the usages of locations, at most maxConcurrentUsageCalls at a time, waiting at most
+// timeout for each location. Unlike listUsagesByLocation, a location whose usages cannot be fetched does not fail
+// the others: it is returned as a failure, flagged when it was throttled.
+func (s *AiModelService) listUsagesWithTimeout(
+	ctx context.Context,
+	subscriptionId string,
+	locations []string,
+	timeout time.Duration,
+) (map[string][]AiModelUsage, []LocationError) {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	sem := make(chan struct{}, maxConcurrentUsageCalls)
+	usagesByLocation := make(map[string][]AiModelUsage, len(locations))
+	var failures []LocationError
+
+	for _, location := range locations {
+		wg.Go(func() {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				mu.Lock()
+				failures = append(failures, newLocationError(location, ctx.Err()))
+				mu.Unlock()
+
+				return
+			}
+			defer func() { <-sem }()
+
+			usages, err := withLocationTimeout(ctx, timeout, func(ctx context.Context) ([]AiModelUsage, error) {
+				return s.ListUsages(ctx, subscriptionId, location)
+			})
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				failures = append(failures, newLocationError(location, err))
+				return
+			}
+			usagesByLocation[location] = usages
+		})
+	}
+
+	wg.Wait()
+
+	sortLocationErrors(failures)
+	return usagesByLocation, failures
+}
+
+// buildModelReport assembles the report of modelName at the checked locations from the model offered at each
+// location and the usages of each location. Checked locations missing from modelsByLocation do not offer the model.
+func buildModelReport(
+	modelName string,
+	checked []string,
+	modelsByLocation map[string]*AiModel,
+	usagesByLocation map[string][]AiModelUsage,
+) *ModelReport {
+	report := &ModelReport{ModelName: modelName}
+
+	mostAvailable := QuotaRemainingUnknown
+	for _, location := range slices.Sorted(slices.Values(checked)) {
+		model, offered := modelsByLocation[location]
+		if !offered {
+			if !slices.Contains(report.UnavailableLocations, location) {
+				report.UnavailableLocations = append(report.UnavailableLocations, location)
+			}
+			continue
+		}
+		if slices.ContainsFunc(report.Locations, func(l ModelReportLocation) bool { return l.Location == location }) {
+			continue
+		}
+
+		usageMap := map[string]AiModelUsage{}
+		for _, usage := range usagesByLocation[location] {
+			usageMap[usage.Name] = usage
+		}
+
+		reportLocation := ModelReportLocation{Location: location, QuotaUnknown: len(usageMap) == 0}
+		deployable := false
+		locationRemaining := QuotaRemainingUnknown
+		for _, version := range model.Versions {
+			reportVersion := ModelReportVersion{
+				Version:         version.Version,
+				IsDefault:       version.IsDefault,
+				LifecycleStatus: version.LifecycleStatus,
+			}
+			for _, sku := range version.Skus {
+				reportSku := ModelReportSku{AiModelSku: sku, RemainingQuota: QuotaRemainingUnknown}
+				if !reportLocation.QuotaUnknown {
+					// A meter missing from the location's usage data has no quota there.
+					usage := usageMap[sku.UsageName]
+					reportSku.CurrentUsage = usage.CurrentValue
+					reportSku.Limit = usage.Limit
+					// Clamped so that a meter used beyond its limit is not read as QuotaRemainingUnknown.
+					reportSku.RemainingQuota = max(usage.Limit-usage.CurrentValue, 0)
+				}
+				if reportSku.Deployable() {
+					deployable = true
+					locationRemaining = max(locationRemaining, reportSku.RemainingQuota)
+				}
+				reportVersion.Skus = append(reportVersion.Skus, reportSku)
+			}
+			reportLocation.Versions = append(reportLocation.Versions, reportVersion)
+		}
+		report.Locations = append(report.Locations, reportLocation)
+
+		// Locations are visited in name order, so the first location wins ties.
+		if deployable && (report.MostAvailableLocation == "" || locationRemaining > mostAvailable) {
+			report.MostAvailableLocation = location
+			mostAvailable = locationRemaining
+		}
+	}
+
+	return report
+}
@@ -0,0 +1,188 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ai
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cognitiveservices/armcognitiveservices/v2"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildModelReport(t *testing.T) {
+	globalSku := AiModelSku{Name: "GlobalStandard", UsageName: "OpenAI.GlobalStandard.gpt-4o", DefaultCapacity: 10}
+	standardSku := AiModelSku{Name: "Standard", UsageName: "OpenAI.Standard.gpt-4o", DefaultCapacity: 10}
+	modelsByLocation := map[string]*AiModel{
+		"eastus": {
+			Name: "gpt-4o",
+			Versions: []AiModelVersion{
+				{
+					Version:         "2024-08-06",
+					IsDefault:       true,
+					LifecycleStatus: "GenerallyAvailable",
+					Skus:            []AiModelSku{globalSku, standardSku},
+				},
+			},
+		},
+		"westus": {
+			Name:     "gpt-4o",
+			Versions: []AiModelVersion{{Version: "2024-08-06", IsDefault: true, Skus: []AiModelSku{standardSku}}},
+		},
+		"swedencentral": {
+			Name:     "gpt-4o",
+			Versions: []AiModelVersion{{Version: "2024-08-06", IsDefault: true, Skus: []AiModelSku{standardSku}}},
+		},
+	}
+
+	t.Run("Quota", func(t *testing.T) {
+		report := buildModelReport("gpt-4o",
+			[]string{"westus", "northeurope", "eastus", "swedencentral"},
+			modelsByLocation,
+			map[string][]AiModelUsage{
+				"eastus": {
+					{Name: globalSku.UsageName, CurrentValue: 50, Limit: 100},
+					{Name: standardSku.UsageName, CurrentValue: 95, Limit: 100},
+				},
+				"swedencentral": {{Name: standardSku.UsageName, CurrentValue: 70, Limit: 100}},
+			})
+
+		require.Equal(t, "gpt-4o", report.ModelName)
+		require.Equal(t, []string{"northeurope"}, report.UnavailableLocations)
+		require.Len(t, report.Locations, 3)
+		require.Equal(t, "eastus", report.Locations[0].Location)
+		require.Equal(t, "swedencentral", report.Locations[1].Location)
+		require.Equal(t, "westus", report.Locations[2].Location)
+
+		eastus := report.Locations[0]
+		require.False(t, eastus.QuotaUnknown)
+		require.Len(t, eastus.Versions, 1)
+		require.Equal(t, "GenerallyAvailable", eastus.Versions[0].LifecycleStatus)
+		require.Equal(t, []ModelReportSku{
+			{AiModelSku: globalSku, CurrentUsage: 50, Limit: 100, RemainingQuota: 50},
+			{AiModelSku: standardSku, CurrentUsage: 95, Limit: 100, RemainingQuota: 5},
+		}, eastus.Versions[0].Skus)
+		require.True(t, eastus.Versions[0].Skus[0].Deployable())
+		require.False(t, eastus.Versions[0].Skus[1].Deployable())
+
+		westus := report.Locations[2]
+		require.True(t, westus.QuotaUnknown)
+		require.Equal(t, QuotaRemainingUnknown, westus.Versions[0].Skus[0].RemainingQuota)
+		require.True(t, westus.Versions[0].Skus[0].Deployable())
+
+		// eastus has the most remaining quota; westus has no usage data and ranks below known quota.
+		require.Equal(t, "eastus", report.MostAvailableLocation)
+	})
+
+	t.Run("NoDeployableSku", func(t *testing.T) {
+		report := buildModelReport("gpt-4o", []string{"eastus"}, modelsByLocation, map[string][]AiModelUsage{
+			"eastus": {
+				{Name: globalSku.UsageName, CurrentValue: 100, Limit: 100},
+				{Name: standardSku.UsageName, CurrentValue: 100, Limit: 100},
+			},
+		})
+
+		require.Len(t, report.Locations, 1)
+		require.Empty(t, report.UnavailableLocations)
+		require.Empty(t, report.MostAvailableLocation)
+	})
+
+	t.Run("OverQuota", func(t *testing.T) {
+		report := buildModelReport("gpt-4o", []string{"westus"}, modelsByLocation, map[string][]AiModelUsage{
+			"westus": {{Name: standardSku.UsageName, CurrentValue: 11, Limit: 10}},
+		})
+
+		require.Equal(t, []ModelReportSku{
+			{AiModelSku: standardSku, CurrentUsage: 11, Limit: 10, RemainingQuota: 0},
+		}, report.Locations[0].Versions[0].Skus)
+		require.False(t, report.Locations[0].Versions[0].Skus[0].Deployable())
+		require.Empty(t, report.MostAvailableLocation)
+	})
+
+	t.Run("MissingUsageName", func(t *testing.T) {
+		report := buildModelReport("gpt-4o", []string{"eastus", "westus"}, modelsByLocation, map[string][]AiModelUsage{
+			"eastus": {{Name: standardSku.UsageName, CurrentValue: 100, Limit: 100}},
+			"westus": {{Name: standardSku.UsageName, CurrentValue: 98, Limit: 100}},
+		})
+
+		// eastus has usage data, but none for the GlobalStandard meter, so the SKU has no quota there.
+		eastus := report.Locations[0]
+		require.False(t, eastus.QuotaUnknown)
+		require.Equal(t, ModelReportSku{AiModelSku: globalSku}, eastus.Versions[0].Skus[0])
+		require.False(t, eastus.Versions[0].Skus[0].Deployable())
+		require.Empty(t, report.MostAvailableLocation)
+	})
+}
+
+func TestAiModelService_BuildModelReport(t *testing.T) {
+	usageName := "OpenAI.Standard.gpt-4o"
+	catalog := map[string][]*armcognitiveservices.Model{
+		"eastus": {sampleModel("gpt-4o", "2024-08-06", "Standard", usageName, true)},
+		"westus": {sampleModel("gpt-4o-mini", "2024-07-18", "Standard", "OpenAI.Standard.gpt-4o-mini", true)},
+	}
+
+	t.Run("Report", func(t *testing.T) {
+		mockCtx := mocks.NewMockContext(t.Context())
+		svc := newQuotaTestService(t, mockCtx, catalog)
+		registerUsages(mockCtx, &armcognitiveservices.Usage{
+			Name:         &armcognitiveservices.MetricName{Value: new(usageName)},
+			CurrentValue: new(float64(20)),
+			Limit:        new(float64(100)),
+		}, nil)
+
+		report, err := svc.BuildModelReport(*mockCtx.Context, "sub-1", "gpt4o", nil)
+		require.NoError(t, err)
+		require.Equal(t, "gpt-4o", report.ModelName)
+		require.Equal(t, []string{"westus"}, report.UnavailableLocations)
+		require.Empty(t, report.FailedLocations)
+		require.Len(t, report.Locations, 1)
+		require.Equal(t, "eastus", report.Locations[0].Location)
+		require.Equal(t, "2024-08-06", report.Locations[0].Versions[0].Version)
+		require.Equal(t, float64(80), report.Locations[0].Versions[0].Skus[0].RemainingQuota)
+		require.Equal(t, "eastus", report.MostAvailableLocation)
+	})
+
+	t.Run("UsagesFailInOneLocation", func(t *testing.T) {
+		mockCtx := mocks.NewMockContext(t.Context())
+		svc := newQuotaTestService(t, mockCtx, map[string][]*armcognitiveservices.Model{
+			"eastus":        {sampleModel("gpt-4o", "2024-08-06", "Standard", usageName, true)},
+			"swedencentral": {sampleModel("gpt-4o", "2024-08-06", "Standard", usageName, true)},
+		})
+		registerUsages(mockCtx, &armcognitiveservices.Usage{
+			Name:         &armcognitiveservices.MetricName{Value: new(usageName)},
+			CurrentValue: new(float64(20)),
+			Limit:        new(float64(100)),
+		}, nil)
+		mockCtx.HttpClient.When(func(req *http.Request) bool {
+			return req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, "/locations/swedencentral/usages")
+		}).RespondFn(func(req *http.Request) (*http.Response, error) {
+			return mocks.CreateEmptyHttpResponse(req, http.StatusForbidden)
+		})
+
+		report, err := svc.BuildModelReport(*mockCtx.Context, "sub-1", "gpt-4o", nil)
+		require.NoError(t, err)
+		require.Len(t, report.FailedLocations, 1)
+		require.Equal(t, "swedencentral", report.FailedLocations[0].Location)
+		require.False(t, report.FailedLocations[0].Throttled)
+
+		// The location whose usages failed is still reported, with unknown quota.
+		require.Len(t, report.Locations, 2)
+		require.Equal(t, "eastus", report.Locations[0].Location)
+		require.False(t, report.Locations[0].QuotaUnknown)
+		require.Equal(t, "swedencentral", report.Locations[1].Location)
+		require.True(t, report.Locations[1].QuotaUnknown)
+		require.Equal(t, QuotaRemainingUnknown, report.Locations[1].Versions[0].Skus[0].RemainingQuota)
+		require.Equal(t, "eastus", report.MostAvailableLocation)
+	})
+
+	t.Run("ModelNotFound", func(t *testing.T) {
+		mockCtx := mocks.NewMockContext(t.Context())
+		svc := newQuotaTestService(t, mockCtx, catalog)
+
+		_, err := svc.BuildModelReport(*mockCtx.Context, "sub-1", "does-not-exist", nil)
+		require.ErrorIs(t, err, ErrModelNotFound)
+	})
+}
@@ -4,18 +4,23 @@
 package ai
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"log"
+	"maps"
+	"math"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
+	"unicode"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cognitiveservices/armcognitiveservices/v2"
 	"github.com/azure/azure-dev/cli/azd/pkg/account"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
-	"github.com/azure/azure-dev/cli/azd/pkg/syncmap"
 )
 
 // AiModelService provides operations for querying AI model availability,
@@ -25,6 +30,15 @@ type AiModelService struct {
 	subManager     *account.SubscriptionsManager
 	catalogCacheMu sync.RWMutex
 	catalogCache   map[string][]*armcognitiveservices.Model // key: "subscriptionId:location"
+	locationsMu    sync.RWMutex
+	locationsCache map[string][]string // key: subscriptionId
+	// preferredSkus orders resolved deployments when the caller passes no preferred SKUs; see SetPreferredSkus.
+	preferredSkus []string
+	// catalogRetries is how many times fetching a location's catalog is retried after a transient failure; see
+	// SetCatalogRetries.
+	catalogRetries int
+	// retryDelay is the delay before the first catalog retry; zero keeps the pipeline default.
+	retryDelay time.Duration
 }
 
 // NewAiModelService creates a new AiModelService.
@@ -33,12 +47,32 @@ func NewAiModelService(
 	subManager *account.SubscriptionsManager,
 ) *AiModelService {
 	return &AiModelService{
-		azureClient:  azureClient,
-		subManager:   subManager,
-		catalogCache: make(map[string][]*armcognitiveservices.Model),
+		azureClient:    azureClient,
+		subManager:     subManager,
+		catalogCache:   make(map[string][]*armcognitiveservices.Model),
+		locationsCache: make(map[string][]string),
+		catalogRetries: DefaultCatalogRetries,
 	}
 }
 
+// SetCatalogRetries sets how many times the ARM pipeline retries fetching a location's model catalog, with exponential
+// backoff, after a transient failure such as throttling or a server error, typically ConfiguredCatalogRetries. Other
+// failures are not retried. Defaults to DefaultCatalogRetries; 0 or less disables retries.
+func (s *AiModelService) SetCatalogRetries(retries int) {
+	s.catalogRetries = max(retries, 0)
+}
+
+// SetPreferredSkus sets the SKU order applied to the deployments resolved for callers that pass no preferred SKUs,
+// typically ConfiguredPreferredSkus. The SKUs order results without filtering them; nil keeps the catalog order.
+func (s *AiModelService) SetPreferredSkus(skus []string) {
+	s.preferredSkus = slices.Clone(skus)
+}
+
+// PreferredSkuOrder returns the SKU order set with SetPreferredSkus, or nil when none is set.
+func (s *AiModelService) PreferredSkuOrder() []string {
+	return slices.Clone(s.preferredSkus)
+}
+
 // ListModels fetches AI models from the Azure Cognitive Services catalog.
 // If locations is empty, fetches across all subscription locations in parallel.
 func (s *AiModelService) ListModels(
@@ -46,21 +80,81 @@ func (s *AiModelService) ListModels(
 	subscriptionId string,
 	locations []string,
 ) ([]AiModel, error) {
+	models, _, err := s.listModels(ctx, subscriptionId, locations)
+	return models, err
+}
+
+// listModels is ListModels that also returns the raw catalog the models were converted from, for callers that need
+// to tell why a model is missing from the result.
+func (s *AiModelService) listModels(
+	ctx context.Context,
+	subscriptionId string,
+	locations []string,
+) ([]AiModel, map[string][]*armcognitiveservices.Model, error) {
 	if len(locations) == 0 {
 		resolvedLocations, err := s.ListLocations(ctx, subscriptionId)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		locations = resolvedLocations
 	}
 
-	rawModels, err := s.fetchModelsForLocations(ctx, subscriptionId, locations)
+	rawModels, _, err := s.fetchModelsForLocations(ctx, subscriptionId, locations)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.convertToAiModels(rawModels), rawModels, nil
+}
+
+// FindModel returns the model named modelName from the catalog at locations, or across all subscription locations
+// when locations is empty. modelName is resolved with [ResolveModelName], so common variants such as gpt4o find
+// gpt-4o. It returns a [*ModelNotFoundError] when the catalog does not list the model and [ErrModelNotDeployable] when
+// it lists the model but none of its versions can be deployed.
+func (s *AiModelService) FindModel(
+	ctx context.Context,
+	subscriptionId string,
+	locations []string,
+	modelName string,
+) (*AiModel, error) {
+	models, rawModels, err := s.listModels(ctx, subscriptionId, locations)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.convertToAiModels(rawModels), nil
+	return findModel(models, rawModels, modelName)
+}
+
+// findModel returns the model that modelName resolves to with [ResolveModelName]. When it is missing, rawModels tell
+// whether the catalog does not list it at all ([*ModelNotFoundError], suggesting the closest deployable model) or
+// only lists versions that cannot be deployed ([ErrModelNotDeployable]).
+func findModel(
+	models []AiModel,
+	rawModels map[string][]*armcognitiveservices.Model,
+	modelName string,
+) (*AiModel, error) {
+	names := make([]string, len(models))
+	for i, m := range models {
+		names[i] = m.Name
+	}
+	if name, ok := ResolveModelName(names, modelName); ok {
+		return &models[slices.Index(names, name)], nil
+	}
+
+	var rawNames []string
+	for _, raw := range rawModels {
+		for _, m := range raw {
+			if m.Model != nil && m.Model.Name != nil {
+				rawNames = append(rawNames, *m.Model.Name)
+			}
+		}
+	}
+	if name, ok := ResolveModelName(rawNames, modelName); ok {
+		return nil, fmt.Errorf("%w: %q", ErrModelNotDeployable, name)
+	}
+
+	return nil, &ModelNotFoundError{Name: modelName, Suggestion: SuggestModelName(names, modelName)}
 }
 
 // ListLocations returns AI Services-supported location names that can be used for model queries.
@@ -68,45 +162,217 @@ func (s *AiModelService) ListLocations(
 	ctx context.Context,
 	subscriptionId string,
 ) ([]string, error) {
+	// The AI Services SKU locations rarely change, so they are cached per subscription for the lifetime of the
+	// service. Quota and catalog flows ask for them repeatedly within a single command.
+	s.locationsMu.RLock()
+	cached, ok := s.locationsCache[subscriptionId]
+	s.locationsMu.RUnlock()
+	if ok {
+		return slices.Clone(cached), nil
+	}
+
 	locations, err := s.azureClient.GetResourceSkuLocations(
 		ctx, subscriptionId, "AIServices", "S0", "Standard", "accounts")
 	if err != nil {
 		return nil, fmt.Errorf("listing AI Services locations: %w", err)
 	}
 
+	s.locationsMu.Lock()
+	s.locationsCache[subscriptionId] = slices.Clone(locations)
+	s.locationsMu.Unlock()
+
 	return locations, nil
 }
 
+// ResolveLocationDisplayNames returns location metadata for the given region names, in the same order, using the
+// subscription's location list to populate display names. Names without metadata, or all names when the metadata
+// cannot be retrieved, fall back to using the raw region name as display name.
+func (s *AiModelService) ResolveLocationDisplayNames(
+	ctx context.Context,
+	subscriptionId string,
+	names []string,
+) []account.Location {
+	var metadata []account.Location
+	if s.subManager != nil && len(names) > 0 {
+		subscriptionLocations, err := s.subManager.GetLocations(ctx, subscriptionId)
+		if err != nil {
+			log.Printf("failed to list locations for subscription %s, using region names: %v", subscriptionId, err)
+		} else {
+			metadata = subscriptionLocations
+		}
+	}
+
+	return joinLocationMetadata(names, metadata)
+}
+
+// joinLocationMetadata maps each region name to its matching entry in metadata (case-insensitive), falling back to
+// the raw name for any display name that is missing.
+func joinLocationMetadata(names []string, metadata []account.Location) []account.Location {
+	byName := make(map[string]account.Location, len(metadata))
+	for _, location := range metadata {
+		byName[strings.ToLower(location.Name)] = location
+	}
+
+	locations := make([]account.Location, len(names))
+	for i, name := range names {
+		location := account.Location{Name: name}
+		if match, has := byName[strings.ToLower(name)]; has {
+			location.DisplayName = match.DisplayName
+			location.RegionalDisplayName = match.RegionalDisplayName
+			location.PairedRegions = match.PairedRegions
+		}
+		if location.DisplayName == "" {
+			location.DisplayName = name
+		}
+		if location.RegionalDisplayName == "" {
+			location.RegionalDisplayName = location.DisplayName
+		}
+		locations[i] = location
+	}
+
+	return locations
+}
+
+// LocationGeoCluster returns the geography a location belongs to, taken from the prefix of its regional display name,
+// e.g. "US" for "(US) East US 2" or "Asia Pacific" for "(Asia Pacific) Japan East". It returns "" when the display
+// name has no geography prefix.
+func LocationGeoCluster(location account.Location) string {
+	name := location.RegionalDisplayName
+	if !strings.HasPrefix(name, "(") {
+		return ""
+	}
+
+	cluster, _, found := strings.Cut(name[1:], ")")
+	if !found {
+		return ""
+	}
+
+	return strings.TrimSpace(cluster)
+}
+
+// SortLocationsByProximity stably orders locations so that homeLocation comes first, followed by the other locations
+// in its geography (see LocationGeoCluster) and then the remaining locations. locations is left unchanged when
+// homeLocation is empty or not among locations.
+func SortLocationsByProximity(locations []account.Location, homeLocation string) {
+	homeIdx := slices.IndexFunc(locations, func(location account.Location) bool {
+		return strings.EqualFold(location.Name, homeLocation)
+	})
+	if homeLocation == "" || homeIdx < 0 {
+		return
+	}
+
+	homeCluster := LocationGeoCluster(locations[homeIdx])
+	rank := func(location account.Location) int {
+		switch {
+		case strings.EqualFold(location.Name, homeLocation):
+			return 0
+		case homeCluster != "" && LocationGeoCluster(location) == homeCluster:
+			return 1
+		default:
+			return 2
+		}
+	}
+
+	slices.SortStableFunc(locations, func(a, b account.Location) int {
+		return cmp.Compare(rank(a), rank(b))
+	})
+}
+
 // ListFilteredModels fetches and filters AI models based on the provided criteria.
 func (s *AiModelService) ListFilteredModels(
 	ctx context.Context,
 	subscriptionId string,
 	options *FilterOptions,
 ) ([]AiModel, error) {
-	if options == nil {
-		return s.ListModels(ctx, subscriptionId, nil)
+	result, err := s.ListModelCatalog(ctx, subscriptionId, options)
+	if err != nil {
+		return nil, err
 	}
 
-	filteredOptions := *options
+	return result.Models, nil
+}
 
+// ListModelCatalog is like ListFilteredModels, but also reports the locations whose catalog could not be fetched,
+// so callers can tell that the result may be incomplete. Options may be nil to list all models.
+func (s *AiModelService) ListModelCatalog(
+	ctx context.Context,
+	subscriptionId string,
+	options *FilterOptions,
+) (*ModelCatalogResult, error) {
 	// Fetch canonical models and apply filters in-memory so model metadata
 	// remains complete for non-status filters. Status filtering is applied during
 	// aggregation so Versions, derived LifecycleStatus, and Locations reflect only
 	// versions matching the requested statuses.
+	if options != nil {
+		if err := options.Intent.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	locations, err := s.ListLocations(ctx, subscriptionId)
 	if err != nil {
 		return nil, err
 	}
 
-	rawModels, err := s.fetchModelsForLocations(ctx, subscriptionId, locations)
+	rawModels, failedLocations, err := s.fetchModelsForLocations(ctx, subscriptionId, locations)
 	if err != nil {
 		return nil, err
 	}
 
-	models := s.convertToAiModelsAt(rawModels, time.Now().UTC(), filteredOptions.Statuses)
-	filteredOptions.Statuses = nil
+	if options != nil && options.MinAvailableCapacity > 0 {
+		usageLocations := slices.Sorted(maps.Keys(rawModels))
+		if len(options.Locations) > 0 {
+			usageLocations = slices.DeleteFunc(usageLocations, func(location string) bool {
+				return !slices.Contains(options.Locations, location)
+			})
+		}
+
+		usagesByLocation, err := s.listUsagesByLocation(ctx, subscriptionId, usageLocations)
+		if err != nil {
+			return nil, fmt.Errorf("listing usages for available capacity: %w", err)
+		}
 
-	return FilterModels(models, &filteredOptions), nil
+		rawModels = filterRawModelsByAvailableCapacity(rawModels, usagesByLocation, options.MinAvailableCapacity)
+	}
+
+	var models []AiModel
+	var availableCapabilities []string
+	if options == nil {
+		models = s.convertToAiModels(rawModels)
+	} else {
+		now := time.Now().UTC()
+		filteredOptions := *options
+		catalog := s.convertToAiModelsAt(rawModels, now, filteredOptions.Statuses, filteredOptions.IncludeDeprecated)
+		filteredOptions.Statuses = nil
+
+		// Filter-related bug reports are hard to diagnose without knowing what each filter did, so with --debug the
+		// applied filters and the versions each removed are logged.
+		var removals filterRemovals
+		if debugLogEnabled() {
+			removals = filterRemovals{}
+			removals.add(filterStatuses, countStatusExcluded(rawModels, now, options.Statuses, options.IncludeDeprecated))
+		}
+		models = filterModels(catalog, &filteredOptions, removals)
+		if removals != nil {
+			log.Printf("model catalog filters: %s", describeFilterOptions(options))
+			log.Printf("model catalog versions removed by filter: %s (%d models kept)", removals, len(models))
+		}
+
+		// The available capabilities only help when the capability filter is what excluded every model, not when
+		// another filter did.
+		capabilitiesOnly := &FilterOptions{Capabilities: options.Capabilities}
+		if len(models) == 0 && len(options.Capabilities) > 0 && len(filterModels(catalog, capabilitiesOnly, nil)) == 0 {
+			for _, model := range catalog {
+				availableCapabilities = mergeModelCapabilities(availableCapabilities, model.Capabilities)
+			}
+		}
+	}
+
+	return &ModelCatalogResult{
+		Models:                models,
+		FailedLocations:       failedLocations,
+		AvailableCapabilities: availableCapabilities,
+	}, nil
 }
 
 // ListModelVersions returns available versions for a specific model at a location.
@@ -184,6 +450,179 @@ func (s *AiModelService) ResolveModelDeploymentsWithQuota(
 	return s.resolveDeployments(ctx, subscriptionId, modelName, options, quotaOpts)
 }
 
+// ResolveModelDeploymentsInRegions resolves deployments of the model at the first location of preference that yields
+// any, trying preference.Locations strictly in order. Each location is resolved like ResolveModelDeploymentsWithQuota
+// with options.Locations set to that location alone, so quotaOpts, when set, is checked at each location in turn.
+// Locations that do not offer the model or have no matching deployment are skipped. When preference.AllowFallback is
+// set, the other locations offering the model are tried next, in name order.
+//
+// It returns an error wrapping ErrNoDeploymentMatch when no location yields a deployment.
+func (s *AiModelService) ResolveModelDeploymentsInRegions(
+	ctx context.Context,
+	subscriptionId string,
+	modelName string,
+	preference RegionPreference,
+	options *DeploymentOptions,
+	quotaOpts *QuotaCheckOptions,
+) (*RegionalDeployment, error) {
+	var locations []string
+	for _, location := range preference.Locations {
+		if location != "" && !slices.ContainsFunc(locations, func(l string) bool {
+			return strings.EqualFold(l, location)
+		}) {
+			locations = append(locations, location)
+		}
+	}
+	preferred := len(locations)
+
+	if preference.AllowFallback {
+		targetModel, err := s.FindModel(ctx, subscriptionId, nil, modelName)
+		if err != nil {
+			return nil, err
+		}
+
+		fallback := slices.DeleteFunc(slices.Clone(targetModel.Locations), func(location string) bool {
+			return slices.ContainsFunc(locations, func(l string) bool { return strings.EqualFold(l, location) })
+		})
+		slices.Sort(fallback)
+		locations = append(locations, fallback...)
+	}
+
+	if len(locations) == 0 {
+		return nil, fmt.Errorf("at least one preferred location is required unless fallback is allowed")
+	}
+
+	locationOptions := DeploymentOptions{}
+	if options != nil {
+		locationOptions = *options
+	}
+
+	for i, location := range locations {
+		locationOptions.Locations = []string{location}
+		deployments, err := s.resolveDeployments(ctx, subscriptionId, modelName, &locationOptions, quotaOpts)
+		if errors.Is(err, ErrModelNotFound) || errors.Is(err, ErrModelNotDeployable) ||
+			errors.Is(err, ErrNoDeploymentMatch) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("resolving deployments at %q: %w", location, err)
+		}
+
+		return &RegionalDeployment{
+			Location:    location,
+			Rank:        i + 1,
+			Fallback:    i >= preferred,
+			Deployments: deployments,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w for model %q in locations %s",
+		ErrNoDeploymentMatch, modelName, strings.Join(locations, ", "))
+}
+
+// RecommendCapacity recommends a deployment capacity for the given model version and SKU at location, based on the
+// subscription's current usage there. See [RecommendCapacity] for how the recommendation is derived.
+func (s *AiModelService) RecommendCapacity(
+	ctx context.Context,
+	subscriptionId string,
+	modelName string,
+	version string,
+	skuName string,
+	location string,
+) (*CapacityRecommendation, error) {
+	sku, err := s.findDeploymentSku(ctx, subscriptionId, modelName, version, skuName, location)
+	if err != nil {
+		return nil, err
+	}
+
+	usages, err := s.ListUsages(ctx, subscriptionId, location)
+	if err != nil {
+		return nil, fmt.Errorf("getting usages for capacity recommendation: %w", err)
+	}
+
+	var usage *AiModelUsage
+	if i := slices.IndexFunc(usages, func(u AiModelUsage) bool { return u.Name == sku.UsageName }); i >= 0 {
+		usage = &usages[i]
+	}
+
+	recommendation := RecommendCapacity(*sku, usage)
+	return &recommendation, nil
+}
+
+// CheckDeploymentQuota checks whether a deployment of the given model version and SKU with capacity fits in the
+// subscription quota remaining at location, and reports the AI Services account headroom there. A capacity of 0
+// checks the SKU default capacity.
+func (s *AiModelService) CheckDeploymentQuota(
+	ctx context.Context,
+	subscriptionId string,
+	modelName string,
+	version string,
+	skuName string,
+	location string,
+	capacity int32,
+) (*DeploymentQuotaCheck, error) {
+	sku, err := s.findDeploymentSku(ctx, subscriptionId, modelName, version, skuName, location)
+	if err != nil {
+		return nil, err
+	}
+
+	usages, err := s.ListUsages(ctx, subscriptionId, location)
+	if err != nil {
+		return nil, fmt.Errorf("getting usages for deployment quota check: %w", err)
+	}
+
+	check := &DeploymentQuotaCheck{Capacity: cmp.Or(capacity, sku.DefaultCapacity)}
+	check.CapacityValid = capacityValidForSku(*sku, check.Capacity)
+	check.Fits = check.CapacityValid
+	for _, usage := range usages {
+		remaining := usage.Limit - usage.CurrentValue
+		switch usage.Name {
+		case sku.UsageName:
+			check.RemainingQuota = &remaining
+			check.Fits = check.CapacityValid && requiredQuotaForSku(*sku, float64(check.Capacity)) <= remaining
+		case AccountCountUsageName:
+			check.AccountQuotaRemaining = &remaining
+		}
+	}
+
+	return check, nil
+}
+
+// findDeploymentSku returns the SKU named skuName of the model version offered at location.
+func (s *AiModelService) findDeploymentSku(
+	ctx context.Context,
+	subscriptionId string,
+	modelName string,
+	version string,
+	skuName string,
+	location string,
+) (*AiModelSku, error) {
+	models, rawModels, err := s.listModels(ctx, subscriptionId, []string{location})
+	if err != nil {
+		return nil, err
+	}
+
+	model, err := findModel(models, rawModels, modelName)
+	if err != nil {
+		return nil, fmt.Errorf("%w at %q", err, location)
+	}
+
+	for _, v := range model.Versions {
+		if v.Version != version {
+			continue
+		}
+		for i := range v.Skus {
+			if v.Skus[i].Name == skuName {
+				return &v.Skus[i], nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf(
+		"%w for model %q: version %q with SKU %q is not available at %q",
+		ErrNoDeploymentMatch, modelName, version, skuName, location)
+}
+
 // ListUsages returns quota/usage data for a location.
 func (s *AiModelService) ListUsages(
 	ctx context.Context,
@@ -210,161 +649,683 @@ func (s *AiModelService) ListUsages(
 	return usages, nil
 }
 
+// ListUsagesForLocations returns quota/usage data for multiple locations, keyed by location.
+// If locations is empty, all AI Services-supported locations are queried.
+// Usages are fetched concurrently with bounded parallelism; locations whose usage query fails
+// are omitted from the result unless every location fails.
+func (s *AiModelService) ListUsagesForLocations(
+	ctx context.Context,
+	subscriptionId string,
+	locations []string,
+) (map[string][]AiModelUsage, error) {
+	if len(locations) == 0 {
+		resolvedLocations, err := s.ListLocations(ctx, subscriptionId)
+		if err != nil {
+			return nil, err
+		}
+
+		locations = resolvedLocations
+	}
+
+	return s.listUsagesByLocation(ctx, subscriptionId, locations)
+}
+
 // ListLocationsWithQuota returns locations with sufficient quota for all given requirements.
 // When allowedLocations are provided, they are intersected with AI Services-supported locations
 // to avoid querying locations where AI Services are not available.
+// Quota is evaluated at subscription scope: ARM does not expose resource-group-scoped quota for Cognitive Services.
 func (s *AiModelService) ListLocationsWithQuota(
 	ctx context.Context,
 	subscriptionId string,
 	allowedLocations []string,
 	requirements []QuotaRequirement,
 ) ([]string, error) {
-	skuLocations, err := s.azureClient.GetResourceSkuLocations(
-		ctx, subscriptionId, "AIServices", "S0", "Standard", "accounts")
+	result, err := s.EvaluateLocationsWithQuota(ctx, subscriptionId, allowedLocations, requirements)
 	if err != nil {
-		return nil, fmt.Errorf("getting AI Services locations: %w", err)
+		return nil, err
 	}
 
-	if len(allowedLocations) == 0 {
-		allowedLocations = skuLocations
+	return result.Locations, nil
+}
+
+// EvaluateLocationsWithQuota is like ListLocationsWithQuota, but also reports the allowed locations that were
+// ignored because they are not AI Services locations. Use WithQuotaProgress to observe each location's evaluation, and
+// WithMaxLocations for a quick partial scan.
+func (s *AiModelService) EvaluateLocationsWithQuota(
+	ctx context.Context,
+	subscriptionId string,
+	allowedLocations []string,
+	requirements []QuotaRequirement,
+	opts ...QuotaCheckOption,
+) (*LocationQuotaResult, error) {
+	config := newQuotaCheckConfig(opts)
+
+	skuLocations, err := s.ListLocations(ctx, subscriptionId)
+	if err != nil {
+		return nil, err
 	}
 
-	var sharedResults syncmap.Map[string, []*armcognitiveservices.Usage]
+	// Skip locations where AIServices is not available to avoid unnecessary usage API calls.
+	supportedLocations, unsupportedLocations := splitModelLocations(skuLocations, allowedLocations)
+	supportedLocations, skippedLocations := limitLocations(
+		supportedLocations, config.maxLocations, config.preferredLocations)
+
+	var mu sync.Mutex
 	var wg sync.WaitGroup
+	var results []string
+	failedLocations := []LocationError{}
+	checked := 0
 
-	for _, loc := range allowedLocations {
-		// Skip locations where AIServices is not available to avoid unnecessary usage API calls.
-		if !slices.Contains(skuLocations, loc) {
-			continue
-		}
-		loc := loc
+	for _, loc := range supportedLocations {
 		wg.Go(func() {
-			usages, err := s.azureClient.GetAiUsages(ctx, subscriptionId, loc)
+			usages, err := withLocationTimeout(ctx, config.locationTimeout, func(ctx context.Context) (
+				[]*armcognitiveservices.Usage, error,
+			) {
+				return s.azureClient.GetAiUsages(ctx, subscriptionId, loc)
+			})
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			checked++
 			if err != nil {
-				return
+				failedLocations = append(failedLocations, newLocationError(loc, err))
+			} else if meetsQuotaRequirements(usages, requirements) {
+				results = append(results, loc)
 			}
-			sharedResults.Store(loc, usages)
+			config.report(QuotaProgress{Checked: checked, Matched: len(results), Total: len(supportedLocations)})
 		})
 	}
 	wg.Wait()
 
-	var results []string
-	sharedResults.Range(func(loc string, usages []*armcognitiveservices.Usage) bool {
-		// When the /usages API returns an empty list (e.g. free-tier subscriptions
-		// that have not yet provisioned Cognitive Services resources), treat the
-		// location as having full quota available.  The AI Services account SKU
-		// (AIServices/S0) was already confirmed available in this region; empty
-		// usages means no consumption data exists, not that quota is zero.
-		if len(usages) == 0 {
-			results = append(results, loc)
-			return true
+	slices.Sort(results)
+	sortLocationErrors(failedLocations)
+	return &LocationQuotaResult{
+		Locations:            results,
+		UnsupportedLocations: unsupportedLocations,
+		FailedLocations:      failedLocations,
+		SkippedLocations:     skippedLocations,
+	}, nil
+}
+
+// limitLocations keeps at most limit of locations, the preferred ones first in preference order and then the others
+// by name, and returns the rest, sorted, as skipped. A limit of 0 or less keeps every location.
+func limitLocations(locations []string, limit int, preferred []string) ([]string, []string) {
+	if limit <= 0 || len(locations) <= limit {
+		return locations, nil
+	}
+
+	ordered := make([]string, 0, len(locations))
+	for _, location := range preferred {
+		if slices.Contains(locations, location) && !slices.Contains(ordered, location) {
+			ordered = append(ordered, location)
+		}
+	}
+	for _, location := range slices.Sorted(slices.Values(locations)) {
+		if !slices.Contains(ordered, location) {
+			ordered = append(ordered, location)
 		}
+	}
 
-		for _, req := range requirements {
-			minCap := req.MinCapacity
-			if minCap <= 0 {
-				minCap = 1
-			}
-			found := slices.ContainsFunc(usages, func(u *armcognitiveservices.Usage) bool {
-				if u.Name == nil || u.Name.Value == nil || *u.Name.Value != req.UsageName {
-					return false
-				}
-				remaining := safeFloat64(u.Limit) - safeFloat64(u.CurrentValue)
-				return remaining >= minCap
-			})
-			if !found {
-				return true // skip this location
-			}
+	skipped := slices.Clone(ordered[limit:])
+	slices.Sort(skipped)
+
+	return ordered[:limit], skipped
+}
+
+// newLocationError records err as the failure to query location, flagging it when the location was throttled.
+func newLocationError(location string, err error) LocationError {
+	return LocationError{Location: location, Err: err, Throttled: azapi.IsThrottledError(err)}
+}
+
+// withLocationTimeout calls fetch with a context bounded by timeout and stops waiting once the timeout elapses, even
+// when fetch does not observe its context. A call that times out fails with ErrLocationTimeout; fetch keeps running in
+// the background until it returns and its result is discarded.
+func withLocationTimeout[T any](
+	ctx context.Context,
+	timeout time.Duration,
+	fetch func(ctx context.Context) (T, error),
+) (T, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type result struct {
+		value T
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		value, err := fetch(ctx)
+		done <- result{value: value, err: err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.value, r.err
+	case <-ctx.Done():
+		var zero T
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return zero, fmt.Errorf("%w after %s", ErrLocationTimeout, timeout)
 		}
-		results = append(results, loc)
-		return true
+		return zero, ctx.Err()
+	}
+}
+
+// sortLocationErrors sorts errs by location.
+func sortLocationErrors(errs []LocationError) {
+	slices.SortFunc(errs, func(a, b LocationError) int {
+		return strings.Compare(a.Location, b.Location)
 	})
+}
 
-	slices.Sort(results)
-	return results, nil
+// meetsQuotaRequirements reports whether usages leave enough remaining quota for every requirement.
+func meetsQuotaRequirements(usages []*armcognitiveservices.Usage, requirements []QuotaRequirement) bool {
+	// When the /usages API returns an empty list (e.g. free-tier subscriptions
+	// that have not yet provisioned Cognitive Services resources), treat the
+	// location as having full quota available.  The AI Services account SKU
+	// (AIServices/S0) was already confirmed available in this region; empty
+	// usages means no consumption data exists, not that quota is zero.
+	if len(usages) == 0 {
+		return true
+	}
+
+	for _, req := range requirements {
+		minCap := req.MinCapacity
+		if minCap <= 0 {
+			minCap = 1
+		}
+		found := slices.ContainsFunc(usages, func(u *armcognitiveservices.Usage) bool {
+			if u.Name == nil || u.Name.Value == nil || *u.Name.Value != req.UsageName {
+				return false
+			}
+			remaining := safeFloat64(u.Limit) - safeFloat64(u.CurrentValue)
+			return remaining >= minCap
+		})
+		if !found {
+			return false
+		}
+	}
+
+	return true
 }
 
 // ListModelLocationsWithQuota returns model locations that have sufficient remaining quota.
 // MaxRemainingQuota is the max remaining quota across the model's SKU usage names
 // in each location where usage data exists.
+// When minAccountQuota > 0, locations must also have at least that much remaining
+// account-count quota, AccountCountUsageName unless WithAccountKind says otherwise; pass 0
+// when deploying into an existing account.
 func (s *AiModelService) ListModelLocationsWithQuota(
 	ctx context.Context,
 	subscriptionId string,
 	modelName string,
 	allowedLocations []string,
 	minRemaining float64,
+	minAccountQuota float64,
+	opts ...QuotaCheckOption,
 ) ([]ModelLocationQuota, error) {
+	result, err := s.EvaluateModelLocationsWithQuota(
+		ctx, subscriptionId, modelName, allowedLocations, minRemaining, minAccountQuota, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Locations, nil
+}
+
+// EvaluateModelLocationsWithQuota is like ListModelLocationsWithQuota, but also reports why the remaining
+// locations were not matched. Allowed locations where the model is not offered are reported in ModelUnavailable
+// without querying their usages; locations where the model is offered but quota is short are reported in
+// InsufficientQuota. Use WithQuotaProgress to observe each location's evaluation, and WithSuggestedAlternatives to
+// suggest a matched location for each unmatched one.
+func (s *AiModelService) EvaluateModelLocationsWithQuota(
+	ctx context.Context,
+	subscriptionId string,
+	modelName string,
+	allowedLocations []string,
+	minRemaining float64,
+	minAccountQuota float64,
+	opts ...QuotaCheckOption,
+) (*ModelLocationQuotaResult, error) {
+	config := newQuotaCheckConfig(opts)
+
 	if minRemaining <= 0 {
 		minRemaining = 1
 	}
 
-	models, err := s.ListModels(ctx, subscriptionId, nil)
+	models, rawModels, err := s.listModels(ctx, subscriptionId, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	var targetModel *AiModel
-	for i := range models {
-		if models[i].Name == modelName {
-			targetModel = &models[i]
-			break
-		}
-	}
-	if targetModel == nil {
-		return nil, fmt.Errorf("%w: %q", ErrModelNotFound, modelName)
+	targetModel, err := findModel(models, rawModels, modelName)
+	if err != nil {
+		return nil, err
 	}
+	modelName = targetModel.Name
 
-	modelLocations := targetModel.Locations
-	if len(allowedLocations) > 0 {
+	modelLocations, unavailableLocations := splitModelLocations(targetModel.Locations, allowedLocations)
+
+	// With required capabilities or formats, each location is evaluated with only the versions it offers that match
+	// them, so a location is not matched on the quota of a version that does not.
+	candidates := map[string]AiModel{}
+	if len(config.capabilities) > 0 || len(config.formats) > 0 {
 		modelLocations = slices.DeleteFunc(slices.Clone(modelLocations), func(loc string) bool {
-			return !slices.Contains(allowedLocations, loc)
+			candidate := s.modelAtLocation(rawModels, loc, modelName, config.capabilities, config.formats)
+			if candidate == nil {
+				unavailableLocations = append(unavailableLocations, loc)
+				return true
+			}
+			candidates[loc] = *candidate
+			return false
 		})
+		slices.Sort(unavailableLocations)
 	}
 
-	var sharedResults syncmap.Map[string, []AiModelUsage]
+	var mu sync.Mutex
 	var wg sync.WaitGroup
+	results := []ModelLocationQuota{}
+	insufficientLocations := []string{}
+	failedLocations := []LocationError{}
+	checked := 0
 
 	for _, loc := range modelLocations {
 		wg.Go(func() {
-			usages, err := s.ListUsages(ctx, subscriptionId, loc)
+			usages, err := withLocationTimeout(ctx, config.locationTimeout, func(ctx context.Context) (
+				[]AiModelUsage, error,
+			) {
+				return s.ListUsages(ctx, subscriptionId, loc)
+			})
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			checked++
 			if err != nil {
-				return
+				failedLocations = append(failedLocations, newLocationError(loc, err))
+			} else {
+				candidate, ok := candidates[loc]
+				if !ok {
+					candidate = *targetModel
+				}
+				maxRemaining, required, ok := modelLocationHasQuota(
+					candidate, usages, minRemaining, config.accountUsageName, minAccountQuota)
+				if ok {
+					results = append(results, ModelLocationQuota{
+						Location:          loc,
+						MaxRemainingQuota: maxRemaining,
+						RequiredQuota:     required,
+					})
+				} else {
+					insufficientLocations = append(insufficientLocations, loc)
+				}
 			}
-			sharedResults.Store(loc, usages)
+			config.report(QuotaProgress{Checked: checked, Matched: len(results), Total: len(modelLocations)})
 		})
 	}
 	wg.Wait()
 
-	results := []ModelLocationQuota{}
-	sharedResults.Range(func(loc string, usages []AiModelUsage) bool {
-		usageMap := make(map[string]AiModelUsage, len(usages))
-		for _, usage := range usages {
-			usageMap[usage.Name] = usage
+	slices.SortFunc(results, func(a, b ModelLocationQuota) int {
+		return strings.Compare(a.Location, b.Location)
+	})
+	slices.Sort(insufficientLocations)
+	sortLocationErrors(failedLocations)
+
+	result := &ModelLocationQuotaResult{
+		Locations:         results,
+		ModelUnavailable:  unavailableLocations,
+		InsufficientQuota: insufficientLocations,
+		FailedLocations:   failedLocations,
+	}
+	if config.suggestAlternatives {
+		result.SuggestedAlternatives = suggestAlternativeLocations(result)
+	}
+	if config.regionPairs {
+		names := make([]string, len(results))
+		for i, loc := range results {
+			names[i] = loc.Location
 		}
+		result.RegionPairs = aggregateRegionPairs(results, s.ResolveLocationDisplayNames(ctx, subscriptionId, names))
+	}
 
-		maxRemainingAtLocation, found := maxModelRemainingQuota(
-			*targetModel, usageMap)
-		// Include the location when the model has at least one
-		// deployable SKU and either: (a) usage data confirms
-		// sufficient remaining quota, or (b) usage data is
-		// unavailable (e.g. free-tier subscriptions).
-		if found &&
-			(maxRemainingAtLocation == QuotaRemainingUnknown ||
-				maxRemainingAtLocation >= minRemaining) {
-			results = append(results, ModelLocationQuota{
-				Location:          loc,
-				MaxRemainingQuota: maxRemainingAtLocation,
-			})
+	return result, nil
+}
+
+// aggregateRegionPairs groups locations with their paired region, as listed in metadata, and sums their remaining
+// quota. Pairing is treated as symmetric, and each location is in exactly one group: with the first of its paired
+// regions that is also among locations and not grouped yet, or else alone. Groups are ordered by their first location.
+func aggregateRegionPairs(locations []ModelLocationQuota, metadata []account.Location) []RegionPairQuota {
+	paired := map[string][]string{}
+	for _, location := range metadata {
+		name := strings.ToLower(location.Name)
+		for _, pairedRegion := range location.PairedRegions {
+			pairedRegion = strings.ToLower(pairedRegion)
+			paired[name] = append(paired[name], pairedRegion)
+			paired[pairedRegion] = append(paired[pairedRegion], name)
 		}
+	}
 
-		return true
-	})
+	byName := make(map[string]ModelLocationQuota, len(locations))
+	for _, loc := range locations {
+		byName[strings.ToLower(loc.Location)] = loc
+	}
 
-	slices.SortFunc(results, func(a, b ModelLocationQuota) int {
+	sorted := slices.SortedFunc(slices.Values(locations), func(a, b ModelLocationQuota) int {
 		return strings.Compare(a.Location, b.Location)
 	})
 
-	return results, nil
+	grouped := map[string]bool{}
+	pairs := []RegionPairQuota{}
+	for _, loc := range sorted {
+		name := strings.ToLower(loc.Location)
+		if grouped[name] {
+			continue
+		}
+		grouped[name] = true
+
+		group := []ModelLocationQuota{loc}
+		for _, pairedRegion := range paired[name] {
+			if match, has := byName[pairedRegion]; has && !grouped[pairedRegion] {
+				grouped[pairedRegion] = true
+				group = append(group, match)
+				break
+			}
+		}
+
+		pair := RegionPairQuota{}
+		for _, member := range group {
+			pair.Locations = append(pair.Locations, member.Location)
+			if member.MaxRemainingQuota == QuotaRemainingUnknown || pair.CombinedRemainingQuota == QuotaRemainingUnknown {
+				pair.CombinedRemainingQuota = QuotaRemainingUnknown
+				continue
+			}
+			pair.CombinedRemainingQuota += member.MaxRemainingQuota
+		}
+		pairs = append(pairs, pair)
+	}
+
+	return pairs
+}
+
+// modelAtLocation returns the model named modelName as offered at location, keeping only the versions matching
+// capabilities (see matchesCapabilities) and in one of formats, or nil when location offers no such version. Empty
+// capabilities or formats match any version.
+func (s *AiModelService) modelAtLocation(
+	rawModels map[string][]*armcognitiveservices.Model,
+	location string,
+	modelName string,
+	capabilities []string,
+	formats []string,
+) *AiModel {
+	models := s.convertToAiModels(map[string][]*armcognitiveservices.Model{location: rawModels[location]})
+	idx := slices.IndexFunc(models, func(m AiModel) bool { return m.Name == modelName })
+	if idx < 0 {
+		return nil
+	}
+
+	model := models[idx]
+	model.Versions = slices.DeleteFunc(model.Versions, func(version AiModelVersion) bool {
+		if len(capabilities) > 0 && !matchesCapabilities(version, capabilities) {
+			return true
+		}
+		return len(formats) > 0 && !slices.Contains(formats, cmp.Or(version.Format, model.Format))
+	})
+	if len(model.Versions) == 0 {
+		return nil
+	}
+	model.Format = ModelFormats(model)[0]
+
+	return &model
+}
+
+// suggestAlternativeLocations maps each unmatched location of result to the matched location with the most remaining
+// quota, preferring the first by name on ties. Locations without usage data rank last. It returns nil when no location
+// matched.
+func suggestAlternativeLocations(result *ModelLocationQuotaResult) map[string]string {
+	if len(result.Locations) == 0 {
+		return nil
+	}
+
+	best := result.Locations[0]
+	for _, loc := range result.Locations[1:] {
+		if loc.MaxRemainingQuota > best.MaxRemainingQuota {
+			best = loc
+		}
+	}
+
+	alternatives := map[string]string{}
+	for _, loc := range slices.Concat(result.ModelUnavailable, result.InsufficientQuota) {
+		alternatives[loc] = best.Location
+	}
+
+	if len(alternatives) == 0 {
+		return nil
+	}
+
+	return alternatives
+}
+
+// modelLocationHasQuota reports whether usages at a location leave enough quota to deploy model, and returns the
+// max remaining quota across the model's SKU usage names along with the step-aligned quota requirement that was met.
+// When minAccountQuota > 0, the accountUsageName quota must also have that much remaining.
+func modelLocationHasQuota(
+	model AiModel,
+	usages []AiModelUsage,
+	minRemaining float64,
+	accountUsageName string,
+	minAccountQuota float64,
+) (float64, float64, bool) {
+	usageMap := make(map[string]AiModelUsage, len(usages))
+	for _, usage := range usages {
+		usageMap[usage.Name] = usage
+	}
+
+	if minAccountQuota > 0 && !HasAccountQuota(usageMap, accountUsageName, minAccountQuota) {
+		return 0, 0, false
+	}
+
+	maxRemainingAtLocation, found := maxModelRemainingQuota(model, usageMap)
+	if !found {
+		return 0, 0, false
+	}
+
+	// Include the location when usage data is unavailable (e.g. free-tier subscriptions).
+	if maxRemainingAtLocation == QuotaRemainingUnknown {
+		return maxRemainingAtLocation, minRemaining, true
+	}
+
+	// Otherwise, usage data must confirm that at least one deployable SKU has sufficient remaining quota.
+	if required, ok := minModelRequiredQuota(model, usageMap, minRemaining); ok {
+		return maxRemainingAtLocation, required, true
+	}
+
+	return 0, 0, false
+}
+
+// minModelRequiredQuota returns the smallest quota requirement, among the model's deployable SKUs, that the SKU's
+// remaining quota satisfies. See requiredQuotaForSku for how the requirement is derived per SKU.
+func minModelRequiredQuota(model AiModel, usageMap map[string]AiModelUsage, minRemaining float64) (float64, bool) {
+	var minRequired float64
+	found := false
+	for _, version := range model.Versions {
+		for _, sku := range version.Skus {
+			usage, ok := usageMap[sku.UsageName]
+			if !ok {
+				continue
+			}
+
+			remaining := usage.Limit - usage.CurrentValue
+			if _, ok := ResolveCapacityWithQuota(sku, nil, remaining); !ok {
+				continue
+			}
+
+			required := requiredQuotaForSku(sku, minRemaining)
+			if remaining < required {
+				continue
+			}
+			if !found || required < minRequired {
+				minRequired = required
+			}
+			found = true
+		}
+	}
+
+	return minRequired, found
+}
+
+// requiredQuotaForSku returns the remaining quota a deployment of sku needs to provide minRemaining capacity.
+// Provisioned SKUs are deployed in multiples of their capacity step (e.g. 50 PTUs), so the requirement is rounded
+// up to the next step.
+func requiredQuotaForSku(sku AiModelSku, minRemaining float64) float64 {
+	if sku.DeploymentKind != SkuDeploymentKindProvisioned || sku.CapacityStep <= 0 {
+		return minRemaining
+	}
+
+	step := float64(sku.CapacityStep)
+	return math.Ceil(minRemaining/step) * step
+}
+
+// splitModelLocations returns the model locations to evaluate, restricted to allowedLocations when provided, and
+// the allowed locations where the model is not offered (sorted and de-duplicated). It is also used to split an
+// allow-list against the AI Services locations.
+func splitModelLocations(modelLocations []string, allowedLocations []string) (offered []string, unavailable []string) {
+	unavailable = []string{}
+	if len(allowedLocations) == 0 {
+		return modelLocations, unavailable
+	}
+
+	offered = slices.DeleteFunc(slices.Clone(modelLocations), func(loc string) bool {
+		return !slices.Contains(allowedLocations, loc)
+	})
+
+	for _, loc := range allowedLocations {
+		if !slices.Contains(modelLocations, loc) && !slices.Contains(unavailable, loc) {
+			unavailable = append(unavailable, loc)
+		}
+	}
+	slices.Sort(unavailable)
+
+	return offered, unavailable
+}
+
+// SummarizeDeployableModels returns, for each catalog model, the locations where it can be deployed with at least
+// the default capacity of one of its SKUs. When locations is empty, all AI Services-supported locations are
+// considered; when kinds is non-empty, only SKUs of those deployment kinds are considered. Models without any
+// deployable location are omitted.
+// Usages are fetched concurrently with bounded parallelism; locations whose usage query fails are treated as not
+// deployable unless every location fails.
+func (s *AiModelService) SummarizeDeployableModels(
+	ctx context.Context,
+	subscriptionId string,
+	locations []string,
+	kinds []SkuDeploymentKind,
+) ([]DeployableModelSummary, error) {
+	models, err := s.ListModels(ctx, subscriptionId, locations)
+	if err != nil {
+		return nil, err
+	}
+
+	effectiveLocations := locations
+	if len(effectiveLocations) == 0 {
+		effectiveLocations = modelLocations(models)
+	}
+
+	usagesByLocation, err := s.listUsagesByLocation(ctx, subscriptionId, effectiveLocations)
+	if err != nil {
+		return nil, err
+	}
+
+	return summarizeDeployableModels(models, usagesByLocation, kinds), nil
+}
+
+// summarizeDeployableModels evaluates each model at the locations present in usagesByLocation.
+func summarizeDeployableModels(
+	models []AiModel,
+	usagesByLocation map[string][]AiModelUsage,
+	kinds []SkuDeploymentKind,
+) []DeployableModelSummary {
+	summaries := []DeployableModelSummary{}
+	for _, model := range models {
+		summary := DeployableModelSummary{
+			ModelName: model.Name,
+			Format:    model.Format,
+		}
+
+		mostAvailable := QuotaRemainingUnknown
+		for _, loc := range model.Locations {
+			usages, ok := usagesByLocation[loc]
+			if !ok {
+				continue
+			}
+
+			usageMap := make(map[string]AiModelUsage, len(usages))
+			for _, usage := range usages {
+				usageMap[usage.Name] = usage
+			}
+
+			remaining, found := maxDeployableRemainingQuota(model, usageMap, kinds)
+			if !found {
+				continue
+			}
+
+			summary.Locations = append(summary.Locations, ModelLocationQuota{
+				Location:          loc,
+				MaxRemainingQuota: remaining,
+			})
+
+			// model.Locations is sorted, so the first location wins ties.
+			if summary.MostAvailableLocation == "" || remaining > mostAvailable {
+				summary.MostAvailableLocation = loc
+				mostAvailable = remaining
+			}
+		}
+
+		if len(summary.Locations) > 0 {
+			summaries = append(summaries, summary)
+		}
+	}
+
+	return summaries
+}
+
+// maxDeployableRemainingQuota is like maxModelRemainingQuota, but only considers SKUs of the given deployment kinds
+// (all SKUs when kinds is empty) whose remaining quota covers their default capacity.
+func maxDeployableRemainingQuota(
+	model AiModel,
+	usageMap map[string]AiModelUsage,
+	kinds []SkuDeploymentKind,
+) (float64, bool) {
+	var maxRemaining float64
+	found := false
+	for _, version := range model.Versions {
+		for _, sku := range version.Skus {
+			if len(kinds) > 0 && !slices.Contains(kinds, sku.DeploymentKind) {
+				continue
+			}
+
+			// When usage data is empty (e.g. free-tier subscriptions), treat the
+			// SKU as deployable with unknown remaining quota.
+			if len(usageMap) == 0 {
+				return QuotaRemainingUnknown, true
+			}
+
+			usage, ok := usageMap[sku.UsageName]
+			if !ok {
+				continue
+			}
+
+			remaining := usage.Limit - usage.CurrentValue
+			if remaining < float64(max(sku.DefaultCapacity, 1)) {
+				continue
+			}
+			if !found || remaining > maxRemaining {
+				maxRemaining = remaining
+			}
+			found = true
+		}
+	}
+
+	return maxRemaining, found
 }
 
 // FilterModelsByQuota cross-references models' SKU usage names against usage data
@@ -392,6 +1353,33 @@ func FilterModelsByQuota(
 	return filtered
 }
 
+// FilterUsagesByNamePrefixes returns usages whose name starts with any of the given prefixes.
+// When prefixes is empty, usages are returned unchanged.
+func FilterUsagesByNamePrefixes(usages []AiModelUsage, prefixes []string) []AiModelUsage {
+	if len(prefixes) == 0 {
+		return usages
+	}
+
+	filtered := make([]AiModelUsage, 0, len(usages))
+	for _, usage := range usages {
+		if slices.ContainsFunc(prefixes, func(prefix string) bool {
+			return strings.HasPrefix(usage.Name, prefix)
+		}) {
+			filtered = append(filtered, usage)
+		}
+	}
+
+	return filtered
+}
+
+// FilterUsagesWithLimit returns the usages whose limit is not 0. A limit of 0 marks a meter that does not apply at the
+// location, so no quota can be consumed from it.
+func FilterUsagesWithLimit(usages []AiModelUsage) []AiModelUsage {
+	return slices.DeleteFunc(slices.Clone(usages), func(usage AiModelUsage) bool {
+		return usage.Limit == 0
+	})
+}
+
 // FilterModelsByQuotaAcrossLocations filters models to those having sufficient quota in at least one location.
 // When locations is empty, model-declared locations are used.
 func (s *AiModelService) FilterModelsByQuotaAcrossLocations(
@@ -431,31 +1419,21 @@ func (s *AiModelService) resolveDeployments(
 	}
 
 	// Fail explicitly if quota is requested without exactly one location.
-	if quotaOpts != nil && len(options.Locations) != 1 {
+	preferSkusWithQuota := quotaOpts == nil && options.PreferSkusWithQuota
+	if (quotaOpts != nil || preferSkusWithQuota) && len(options.Locations) != 1 {
 		return nil, fmt.Errorf(
 			"%w, got %d", ErrQuotaLocationRequired, len(options.Locations))
 	}
 
-	models, err := s.ListModels(ctx, subscriptionId, options.Locations)
+	targetModel, err := s.FindModel(ctx, subscriptionId, options.Locations, modelName)
 	if err != nil {
 		return nil, err
 	}
-
-	// Find the target model
-	var targetModel *AiModel
-	for i := range models {
-		if models[i].Name == modelName {
-			targetModel = &models[i]
-			break
-		}
-	}
-	if targetModel == nil {
-		return nil, fmt.Errorf("%w: %q", ErrModelNotFound, modelName)
-	}
+	modelName = targetModel.Name
 
 	// Fetch quota data (guaranteed single location by check above)
 	var usageMap map[string]AiModelUsage
-	if quotaOpts != nil {
+	if quotaOpts != nil || preferSkusWithQuota {
 		usages, err := s.ListUsages(ctx, subscriptionId, options.Locations[0])
 		if err != nil {
 			return nil, fmt.Errorf("getting usages for quota check: %w", err)
@@ -468,10 +1446,111 @@ func (s *AiModelService) resolveDeployments(
 
 	// Resolve: iterate versions → SKUs to collect all valid candidates.
 	// No implicit version or SKU filtering — callers must pass explicit filters.
+	results := resolveVersionDeployments(targetModel, options, quotaOpts, usageMap, func(version AiModelVersion) bool {
+		return len(options.Versions) == 0 || slices.Contains(options.Versions, version.Version)
+	})
+
+	if len(results) == 0 && options.FallbackToDefaultVersion && len(options.Versions) > 0 {
+		results = resolveVersionDeployments(targetModel, options, quotaOpts, usageMap, func(version AiModelVersion) bool {
+			return version.IsDefault
+		})
+	}
+
+	if len(results) == 0 {
+		return nil, fmt.Errorf("%w for model %q with the specified options", ErrNoDeploymentMatch, modelName)
+	}
+
+	if options.PreferStable {
+		preferStableDeployments(results, targetModel.Versions)
+	}
+
+	// Callers that pass no SKUs get the configured preferred SKU order, e.g. a team standardizing on
+	// DataZoneStandard; without one, results keep the catalog order.
+	preferredSkus := options.Skus
+	if len(preferredSkus) == 0 {
+		preferredSkus = s.preferredSkus
+	}
+
+	if preferSkusWithQuota {
+		preferDeploymentsWithQuota(results, preferredSkus, usageMap)
+	} else if len(options.Skus) == 0 && len(preferredSkus) > 0 {
+		SortSkusByPreference(results, preferredSkus, func(deployment AiModelDeployment) string {
+			return deployment.Sku.Name
+		})
+	}
+
+	return results, nil
+}
+
+// preferDeploymentsWithQuota stably sorts deployments by the index of their SKU in preferredSkus, with deployments
+// whose SKU lacks remaining quota for their capacity moved after the others. It populates RemainingQuota from
+// usageMap. SKUs without usage data are treated as having quota, and without any usage data only the preference
+// order is applied.
+func preferDeploymentsWithQuota(
+	deployments []AiModelDeployment,
+	preferredSkus []string,
+	usageMap map[string]AiModelUsage,
+) {
+	hasQuota := func(deployment AiModelDeployment) bool {
+		if deployment.RemainingQuota == nil {
+			return true
+		}
+		return *deployment.RemainingQuota >= requiredQuotaForSku(deployment.Sku, float64(max(deployment.Capacity, 1)))
+	}
+
+	for i := range deployments {
+		if usage, ok := usageMap[deployments[i].Sku.UsageName]; ok {
+			remaining := usage.Limit - usage.CurrentValue
+			deployments[i].RemainingQuota = &remaining
+		}
+	}
+
+	slices.SortStableFunc(deployments, func(a, b AiModelDeployment) int {
+		aQuota, bQuota := hasQuota(a), hasQuota(b)
+		if aQuota != bQuota {
+			if aQuota {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Compare(skuPreference(preferredSkus, a.Sku.Name), skuPreference(preferredSkus, b.Sku.Name))
+	})
+}
+
+// preferStableDeployments stably moves deployments of "Preview" versions after the others, keeping the relative
+// order within each group.
+func preferStableDeployments(deployments []AiModelDeployment, versions []AiModelVersion) {
+	preview := make(map[string]bool, len(versions))
+	for _, version := range versions {
+		preview[version.Version] = strings.EqualFold(version.LifecycleStatus, "Preview")
+	}
+
+	slices.SortStableFunc(deployments, func(a, b AiModelDeployment) int {
+		switch aPreview, bPreview := preview[a.Version], preview[b.Version]; {
+		case aPreview == bPreview:
+			return 0
+		case bPreview:
+			return -1
+		default:
+			return 1
+		}
+	})
+}
+
+// resolveVersionDeployments returns the deployment candidates for the versions of targetModel accepted by
+// includeVersion, applying the SKU, fine-tune, capacity and quota options.
+func resolveVersionDeployments(
+	targetModel *AiModel,
+	options *DeploymentOptions,
+	quotaOpts *QuotaCheckOptions,
+	usageMap map[string]AiModelUsage,
+	includeVersion func(AiModelVersion) bool,
+) []AiModelDeployment {
+	modelName := targetModel.Name
 	var results []AiModelDeployment
 
 	for _, version := range targetModel.Versions {
-		if len(options.Versions) > 0 && !slices.Contains(options.Versions, version.Version) {
+		if !includeVersion(version) {
 			continue
 		}
 
@@ -519,7 +1598,7 @@ func (s *AiModelService) resolveDeployments(
 
 			deployment := AiModelDeployment{
 				ModelName: modelName,
-				Format:    targetModel.Format,
+				Format:    cmp.Or(version.Format, targetModel.Format),
 				Version:   version.Version,
 				Location:  deployLocation,
 				Sku:       sku,
@@ -539,24 +1618,37 @@ func (s *AiModelService) resolveDeployments(
 		}
 	}
 
-	if len(results) == 0 {
-		return nil, fmt.Errorf("%w for model %q with the specified options", ErrNoDeploymentMatch, modelName)
+	return results
+}
+
+// ListRawModels returns the ARM models offered at location as returned by the Cognitive Services models API, before
+// any conversion or filtering. It bypasses the catalog cache and is intended for diagnostics.
+func (s *AiModelService) ListRawModels(
+	ctx context.Context,
+	subscriptionId string,
+	location string,
+) ([]*armcognitiveservices.Model, error) {
+	models, err := s.azureClient.GetAiModels(ctx, subscriptionId, location)
+	if err != nil {
+		return nil, fmt.Errorf("getting models at %q: %w", location, err)
 	}
 
-	return results, nil
+	return models, nil
 }
 
-// fetchModelsForLocations fetches models across multiple locations in parallel.
+// fetchModelsForLocations fetches models across multiple locations in parallel. A location whose fetch keeps failing
+// after the transient-failure retries (see SetCatalogRetries) is reported as failed.
 func (s *AiModelService) fetchModelsForLocations(
 	ctx context.Context,
 	subscriptionId string,
 	locations []string,
-) (map[string][]*armcognitiveservices.Model, error) {
+) (map[string][]*armcognitiveservices.Model, []LocationError, error) {
 	result := make(map[string][]*armcognitiveservices.Model)
 	var mu sync.Mutex
 	var errMu sync.Mutex
 	var wg sync.WaitGroup
 	errs := []error{}
+	failedLocations := []LocationError{}
 
 	for _, loc := range locations {
 		// Check cache first
@@ -573,10 +1665,12 @@ func (s *AiModelService) fetchModelsForLocations(
 
 		loc := loc
 		wg.Go(func() {
-			models, err := s.azureClient.GetAiModels(ctx, subscriptionId, loc)
+			retryCtx := withCatalogRetry(ctx, s.catalogRetries, s.retryDelay)
+			models, err := s.azureClient.GetAiModels(retryCtx, subscriptionId, loc)
 			if err != nil {
 				errMu.Lock()
 				errs = append(errs, fmt.Errorf("%s: %w", loc, err))
+				failedLocations = append(failedLocations, newLocationError(loc, err))
 				errMu.Unlock()
 				return
 			}
@@ -595,26 +1689,95 @@ func (s *AiModelService) fetchModelsForLocations(
 	wg.Wait()
 
 	if len(result) == 0 && len(errs) > 0 {
-		return nil, fmt.Errorf("fetching model catalogs: %w", errors.Join(errs...))
+		return nil, nil, fmt.Errorf("fetching model catalogs: %w", errors.Join(errs...))
 	}
 
-	return result, nil
+	sortLocationErrors(failedLocations)
+
+	return result, failedLocations, nil
+}
+
+// filterRawModelsByAvailableCapacity keeps the SKUs of rawByLocation that have at least minCapacity remaining quota at
+// their location, dropping models left without SKUs. Locations missing from usagesByLocation are dropped, since their
+// capacity is unknown. As in FilterModelsByQuota, a location without any usage data keeps all of its SKUs. The raw
+// models are cached, so they are copied rather than modified.
+func filterRawModelsByAvailableCapacity(
+	rawByLocation map[string][]*armcognitiveservices.Model,
+	usagesByLocation map[string][]AiModelUsage,
+	minCapacity float64,
+) map[string][]*armcognitiveservices.Model {
+	filtered := make(map[string][]*armcognitiveservices.Model, len(usagesByLocation))
+	for location, models := range rawByLocation {
+		usages, has := usagesByLocation[location]
+		if !has {
+			continue
+		}
+
+		usageMap := make(map[string]AiModelUsage, len(usages))
+		for _, usage := range usages {
+			usageMap[usage.Name] = usage
+		}
+
+		for _, model := range models {
+			if model == nil || model.Model == nil {
+				continue
+			}
+
+			skus := slices.DeleteFunc(slices.Clone(model.Model.SKUs), func(sku *armcognitiveservices.ModelSKU) bool {
+				return sku == nil || !skuHasAvailableCapacity(convertSku(sku), usageMap, minCapacity)
+			})
+			if len(skus) == 0 {
+				continue
+			}
+
+			accountModel := *model.Model
+			accountModel.SKUs = skus
+			filteredModel := *model
+			filteredModel.Model = &accountModel
+			filtered[location] = append(filtered[location], &filteredModel)
+		}
+	}
+
+	return filtered
+}
+
+// skuHasAvailableCapacity reports whether sku has at least minCapacity remaining quota, enough to deploy it at a
+// valid capacity. An empty usageMap means usage data is unavailable, in which case sku is assumed to have capacity.
+func skuHasAvailableCapacity(sku AiModelSku, usageMap map[string]AiModelUsage, minCapacity float64) bool {
+	if len(usageMap) == 0 {
+		return true
+	}
+
+	usage, has := usageMap[sku.UsageName]
+	if !has {
+		return false
+	}
+
+	remaining := usage.Limit - usage.CurrentValue
+	if remaining < minCapacity {
+		return false
+	}
+
+	_, fits := ResolveCapacityWithQuota(sku, nil, remaining)
+	return fits
 }
 
 // convertToAiModels converts raw ARM models grouped by location into domain AiModel types.
 func (s *AiModelService) convertToAiModels(
 	rawByLocation map[string][]*armcognitiveservices.Model,
 ) []AiModel {
-	return s.convertToAiModelsAt(rawByLocation, time.Now().UTC(), nil)
+	return s.convertToAiModelsAt(rawByLocation, time.Now().UTC(), nil, false)
 }
 
 // convertToAiModelsAt converts raw ARM models grouped by location into domain AiModel types,
-// optionally filtering by version lifecycle status before aggregation. The now parameter
-// makes deprecation filtering deterministic in tests.
+// optionally filtering by version lifecycle status before aggregation. When statuses is empty,
+// includeDeprecated keeps "Deprecating"/"Deprecated" versions that are otherwise excluded by default.
+// The now parameter makes deprecation filtering deterministic in tests.
 func (s *AiModelService) convertToAiModelsAt(
 	rawByLocation map[string][]*armcognitiveservices.Model,
 	now time.Time,
 	statuses []string,
+	includeDeprecated bool,
 ) []AiModel {
 	// Aggregate: model name → location → version → SKUs
 	modelMap := make(map[string]*AiModel)
@@ -629,29 +1792,17 @@ func (s *AiModelService) convertToAiModelsAt(
 			if modelInferenceRetired(m.Model.Deprecation, now) {
 				continue
 			}
-			if len(statuses) > 0 {
-				// Explicit opt-in: return only versions whose lifecycle status was
-				// requested. This includes ARM "Deprecating"/"Deprecated" so callers can
-				// support existing-customer management scenarios.
-				if !slices.Contains(statuses, modelLifecycleStatusValue(m.Model.LifecycleStatus)) {
-					continue
-				}
-			} else if modelVersionExcluded(m.Model, now) {
+			if modelStatusExcluded(m.Model, now, statuses, includeDeprecated) {
 				continue
 			}
 			name := *m.Model.Name
+			format := safeString(m.Model.Format)
 
 			aiModel, exists := modelMap[name]
 			if !exists {
 				aiModel = &AiModel{
 					Name:   name,
-					Format: safeString(m.Model.Format),
-				}
-				if m.Model.Capabilities != nil {
-					for key := range m.Model.Capabilities {
-						aiModel.Capabilities = append(aiModel.Capabilities, key)
-					}
-					slices.Sort(aiModel.Capabilities)
+					Family: ModelFamily(name),
 				}
 				modelMap[name] = aiModel
 			}
@@ -661,66 +1812,94 @@ func (s *AiModelService) convertToAiModelsAt(
 			isDefault := m.Model.IsDefaultVersion != nil && *m.Model.IsDefaultVersion
 			lifecycleStatus := modelLifecycleStatusValue(m.Model.LifecycleStatus)
 
-			hadSkus := len(m.Model.SKUs) > 0
 			var skus []AiModelSku
-			if m.Model.SKUs != nil {
-				for _, sku := range m.Model.SKUs {
-					if modelSkuDeprecated(sku, now) {
-						continue
-					}
-					skus = append(skus, convertSku(sku))
+			for _, sku := range m.Model.SKUs {
+				if sku == nil || modelSkuDeprecated(sku, now) {
+					continue
 				}
+				skus = append(skus, convertSku(sku))
 			}
-			if hadSkus && len(skus) == 0 {
+			// A version without SKUs cannot be deployed, so it is never meaningful to callers. Drop entries that
+			// list no SKUs or only deprecated ones; the version still appears if another entry contributes SKUs.
+			if len(skus) == 0 {
 				continue
 			}
 
-			// Track locations only when this location contributes a surviving version/SKU.
+			// Track locations and capabilities only when this location contributes a surviving version/SKU.
 			if !slices.Contains(aiModel.Locations, loc) {
 				aiModel.Locations = append(aiModel.Locations, loc)
 			}
+			aiModel.Capabilities = mergeCapabilities(aiModel.Capabilities, m.Model.Capabilities)
+
+			aiModel.Versions = mergeModelVersion(aiModel.Versions, AiModelVersion{
+				Version:          ver,
+				IsDefault:        isDefault,
+				LifecycleStatus:  lifecycleStatus,
+				Format:           format,
+				Capabilities:     mergeCapabilities(nil, m.Model.Capabilities),
+				CapabilityValues: capabilityValues(m.Model.Capabilities),
+				Skus:             skus,
+			})
+		}
+	}
 
-			// Find or create version in model
-			versionFound := false
-			for i := range aiModel.Versions {
-				if aiModel.Versions[i].Version == ver {
-					versionFound = true
-					if isDefault {
-						aiModel.Versions[i].IsDefault = true
-					}
-					if aiModel.Versions[i].LifecycleStatus == "" {
-						aiModel.Versions[i].LifecycleStatus = lifecycleStatus
-					}
-					// Merge SKUs (deduplicate by name + usage_name, since the same SKU name
-					// can appear with different usage names representing different quota pools)
-					for _, newSku := range skus {
-						if !slices.ContainsFunc(aiModel.Versions[i].Skus, func(s AiModelSku) bool {
-							return s.Name == newSku.Name && s.UsageName == newSku.UsageName
-						}) {
-							aiModel.Versions[i].Skus = append(aiModel.Versions[i].Skus, newSku)
-						}
-					}
-					break
+	// Convert map to sorted slice
+	result := make([]AiModel, 0, len(modelMap))
+	for _, model := range modelMap {
+		if len(model.Versions) == 0 {
+			continue
+		}
+		slices.Sort(model.Locations)
+		model.Format = ModelFormats(*model)[0]
+		result = append(result, *model)
+	}
+	slices.SortFunc(result, func(a, b AiModel) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return result
+}
+
+// MergeAiModels merges model catalogs listed in several subscriptions into one catalog, sorted by model name.
+// Models are unioned by name: their locations and capabilities are unioned, and versions offered in more than one
+// catalog are merged with their SKUs deduplicated. The catalogs are not modified.
+func MergeAiModels(catalogs ...[]AiModel) []AiModel {
+	modelMap := map[string]*AiModel{}
+	for _, catalog := range catalogs {
+		for _, model := range catalog {
+			merged, has := modelMap[model.Name]
+			if !has {
+				merged = &AiModel{
+					Name:            model.Name,
+					LifecycleStatus: model.LifecycleStatus,
+					Family:          model.Family,
+				}
+				modelMap[model.Name] = merged
+			}
+
+			merged.LifecycleStatus = cmp.Or(merged.LifecycleStatus, model.LifecycleStatus)
+			merged.Capabilities = mergeModelCapabilities(merged.Capabilities, model.Capabilities)
+			for _, location := range model.Locations {
+				if !slices.Contains(merged.Locations, location) {
+					merged.Locations = append(merged.Locations, location)
 				}
 			}
-			if !versionFound {
-				aiModel.Versions = append(aiModel.Versions, AiModelVersion{
-					Version:         ver,
-					IsDefault:       isDefault,
-					LifecycleStatus: lifecycleStatus,
-					Skus:            skus,
-				})
+			for _, version := range model.Versions {
+				version.Capabilities = slices.Clone(version.Capabilities)
+				version.CapabilityValues = maps.Clone(version.CapabilityValues)
+				version.Skus = slices.Clone(version.Skus)
+				merged.Versions = mergeModelVersion(merged.Versions, version)
 			}
 		}
 	}
 
-	// Convert map to sorted slice
 	result := make([]AiModel, 0, len(modelMap))
 	for _, model := range modelMap {
 		if len(model.Versions) == 0 {
 			continue
 		}
 		slices.Sort(model.Locations)
+		model.Format = ModelFormats(*model)[0]
 		result = append(result, *model)
 	}
 	slices.SortFunc(result, func(a, b AiModel) int {
@@ -730,6 +1909,64 @@ func (s *AiModelService) convertToAiModelsAt(
 	return result
 }
 
+// mergeModelVersion adds version to versions. A version already present in the same format absorbs it: it becomes
+// the default when either is, and gains its capabilities and SKUs. The same version can be offered in several formats
+// (even within one location); each format gets its own entry so its SKUs are not merged.
+func mergeModelVersion(versions []AiModelVersion, version AiModelVersion) []AiModelVersion {
+	i := slices.IndexFunc(versions, func(v AiModelVersion) bool {
+		return v.Version == version.Version && v.Format == version.Format
+	})
+	if i < 0 {
+		return append(versions, version)
+	}
+
+	versions[i].IsDefault = versions[i].IsDefault || version.IsDefault
+	versions[i].LifecycleStatus = cmp.Or(versions[i].LifecycleStatus, version.LifecycleStatus)
+	versions[i].Capabilities = mergeModelCapabilities(versions[i].Capabilities, version.Capabilities)
+	versions[i].CapabilityValues = mergeCapabilityValues(versions[i].CapabilityValues, version.CapabilityValues)
+	versions[i].Skus = mergeModelSkus(versions[i].Skus, version.Skus)
+
+	return versions
+}
+
+// mergeModelSkus adds the SKUs of more missing from skus. SKUs are deduplicated by name and usage name, since the same
+// SKU name can appear with different usage names representing different quota pools.
+func mergeModelSkus(skus []AiModelSku, more []AiModelSku) []AiModelSku {
+	for _, sku := range more {
+		if !slices.ContainsFunc(skus, func(s AiModelSku) bool {
+			return s.Name == sku.Name && s.UsageName == sku.UsageName
+		}) {
+			skus = append(skus, sku)
+		}
+	}
+
+	return skus
+}
+
+// mergeModelCapabilities adds more to capabilities, keeping the result sorted and free of duplicates.
+func mergeModelCapabilities(capabilities []string, more []string) []string {
+	for _, capability := range more {
+		if !slices.Contains(capabilities, capability) {
+			capabilities = append(capabilities, capability)
+		}
+	}
+	slices.Sort(capabilities)
+
+	return capabilities
+}
+
+// mergeCapabilities adds the keys of raw to capabilities, keeping the result sorted and free of duplicates.
+func mergeCapabilities(capabilities []string, raw map[string]*string) []string {
+	return mergeModelCapabilities(capabilities, slices.Collect(maps.Keys(raw)))
+}
+
+// hasAnyCapability reports whether capabilities include at least one of wanted.
+func hasAnyCapability(capabilities []string, wanted []string) bool {
+	return slices.ContainsFunc(wanted, func(capability string) bool {
+		return slices.Contains(capabilities, capability)
+	})
+}
+
 // modelVersionExcluded reports whether a model version should be excluded from the
 // default new-deployment view. A version is excluded when its ARM lifecycleStatus is
 // "Deprecating" (customer-facing Deprecated) or "Deprecated" (Retired), or when its
@@ -767,6 +2004,18 @@ func modelLifecycleStatusValue(status *armcognitiveservices.ModelLifecycleStatus
 	return string(*status)
 }
 
+// modelStatusExcluded reports whether the lifecycle status filter drops a model version: with explicit statuses,
+// only versions whose lifecycle status was requested are kept. This includes ARM "Deprecating"/"Deprecated" so
+// callers can support existing-customer management scenarios. Otherwise the default new-deployment view applies.
+func modelStatusExcluded(model *armcognitiveservices.AccountModel, now time.Time, statuses []string,
+	includeDeprecated bool) bool {
+	if len(statuses) > 0 {
+		return !slices.Contains(statuses, modelLifecycleStatusValue(model.LifecycleStatus))
+	}
+
+	return !includeDeprecated && modelVersionExcluded(model, now)
+}
+
 // modelInferenceRetired reports whether a model version's inference endpoint has retired
 // (ARM deprecation.inference <= now). Such versions return 410 Gone and are always excluded.
 func modelInferenceRetired(info *armcognitiveservices.ModelDeprecationInfo, now time.Time) bool {
@@ -802,37 +2051,70 @@ func deprecationReached(value string, now time.Time) bool {
 // versions are pruned, but Locations cannot be recomputed (version-to-location provenance
 // is lost). Use ListFilteredModels for full fidelity.
 func FilterModels(models []AiModel, options *FilterOptions) []AiModel {
+	return filterModels(models, options, nil)
+}
+
+// filterModels implements FilterModels, recording in removals how many versions each filter removed.
+func filterModels(models []AiModel, options *FilterOptions, removals filterRemovals) []AiModel {
 	if options == nil {
 		return models
 	}
 
+	// pruneVersions keeps the versions of model that match keep, recording the rest as removed by filter.
+	pruneVersions := func(model *AiModel, filter string, keep func(AiModelVersion) bool) {
+		before := len(model.Versions)
+		model.Versions = slices.DeleteFunc(slices.Clone(model.Versions), func(version AiModelVersion) bool {
+			return !keep(version)
+		})
+		removals.add(filter, before-len(model.Versions))
+	}
+
+	capabilityNames, capabilityPredicates := splitCapabilityFilter(options.Capabilities)
+
 	var filtered []AiModel
 	for _, model := range models {
 		if len(options.Statuses) > 0 {
-			model.Versions = slices.DeleteFunc(slices.Clone(model.Versions), func(version AiModelVersion) bool {
-				return !slices.Contains(options.Statuses, version.LifecycleStatus)
+			pruneVersions(&model, filterStatuses, func(version AiModelVersion) bool {
+				return slices.Contains(options.Statuses, version.LifecycleStatus)
 			})
 			if len(model.Versions) == 0 {
 				continue
 			}
 		}
 		if len(options.ExcludeModelNames) > 0 && slices.Contains(options.ExcludeModelNames, model.Name) {
+			removals.add(filterExcludeModelNames, len(model.Versions))
 			continue
 		}
-		if len(options.Formats) > 0 && !slices.Contains(options.Formats, model.Format) {
-			continue
-		}
-		if len(options.Capabilities) > 0 {
-			hasCapability := false
-			for _, cap := range options.Capabilities {
-				if slices.Contains(model.Capabilities, cap) {
-					hasCapability = true
-					break
-				}
+		if len(options.Formats) > 0 {
+			pruneVersions(&model, filterFormats, func(version AiModelVersion) bool {
+				return slices.Contains(options.Formats, cmp.Or(version.Format, model.Format))
+			})
+			if len(model.Versions) == 0 {
+				continue
 			}
-			if !hasCapability {
+			model.Format = ModelFormats(model)[0]
+		}
+		if len(capabilityPredicates) > 0 {
+			pruneVersions(&model, filterCapabilityValues, func(version AiModelVersion) bool {
+				return matchesCapabilityPredicates(version.CapabilityValues, capabilityPredicates)
+			})
+			if len(model.Versions) == 0 {
 				continue
 			}
+			model.Format = ModelFormats(model)[0]
+		}
+		if options.DefaultVersionsOnly {
+			before := len(model.Versions)
+			model.Versions = DefaultModelVersions(model.Versions, model.Format)
+			removals.add(filterDefaultVersionsOnly, before-len(model.Versions))
+		}
+		if len(capabilityNames) > 0 && !hasAnyCapability(model.Capabilities, capabilityNames) {
+			removals.add(filterCapabilities, len(model.Versions))
+			continue
+		}
+		if !options.Intent.Matches(model.Capabilities) {
+			removals.add(filterIntent, len(model.Versions))
+			continue
 		}
 		if len(options.Locations) > 0 {
 			hasLocation := false
@@ -843,6 +2125,7 @@ func FilterModels(models []AiModel, options *FilterOptions) []AiModel {
 				}
 			}
 			if !hasLocation {
+				removals.add(filterLocations, len(model.Versions))
 				continue
 			}
 		}
@@ -854,8 +2137,9 @@ func FilterModels(models []AiModel, options *FilterOptions) []AiModel {
 
 func convertSku(sku *armcognitiveservices.ModelSKU) AiModelSku {
 	result := AiModelSku{
-		Name:      safeString(sku.Name),
-		UsageName: safeString(sku.UsageName),
+		Name:           safeString(sku.Name),
+		UsageName:      safeString(sku.UsageName),
+		DeploymentKind: ClassifySkuDeploymentKind(safeString(sku.Name)),
 	}
 	if sku.Capacity != nil {
 		if sku.Capacity.Default != nil {
@@ -871,9 +2155,67 @@ func convertSku(sku *armcognitiveservices.ModelSKU) AiModelSku {
 			result.CapacityStep = *sku.Capacity.Step
 		}
 	}
+	result.DefaultCapacity = normalizeDefaultCapacity(result)
 	return result
 }
 
+// ClassifySkuDeploymentKind derives the deployment kind from a SKU name.
+// Provisioned SKUs are classified as provisioned regardless of their routing prefix.
+func ClassifySkuDeploymentKind(skuName string) SkuDeploymentKind {
+	switch {
+	case strings.Contains(skuName, "Provisioned"):
+		return SkuDeploymentKindProvisioned
+	case strings.HasPrefix(skuName, "Global"):
+		return SkuDeploymentKindGlobal
+	case strings.HasPrefix(skuName, "DataZone"):
+		return SkuDeploymentKindDataZone
+	case skuName == "Standard":
+		return SkuDeploymentKindRegional
+	default:
+		return SkuDeploymentKindUnknown
+	}
+}
+
+// SkuDeploymentKindDescription returns a short explanation of a deployment kind suitable for pickers,
+// or an empty string when the kind is unknown.
+func SkuDeploymentKindDescription(kind SkuDeploymentKind) string {
+	switch kind {
+	case SkuDeploymentKindGlobal:
+		return "routed globally for best availability"
+	case SkuDeploymentKindDataZone:
+		return "processed within a data zone (e.g. US or EU)"
+	case SkuDeploymentKindRegional:
+		return "processed in the deployment region"
+	case SkuDeploymentKindProvisioned:
+		return "reserved throughput billed hourly"
+	default:
+		return ""
+	}
+}
+
+// normalizeDefaultCapacity returns a default capacity that respects the SKU's minimum and step.
+// Some ARM responses report a default of 0 or a value below the minimum; such defaults are normalized to
+// max(MinCapacity, roundUpToStep(DefaultCapacity)), capped at MaxCapacity when set.
+// A default of 0 is kept as-is when the SKU has no min or step constraints.
+func normalizeDefaultCapacity(sku AiModelSku) int32 {
+	capacity := sku.DefaultCapacity
+	if sku.CapacityStep > 0 && capacity%sku.CapacityStep != 0 {
+		capacity += sku.CapacityStep - capacity%sku.CapacityStep
+	}
+
+	if capacity <= 0 {
+		capacity = sku.CapacityStep
+	}
+
+	capacity = max(capacity, sku.MinCapacity)
+
+	if sku.MaxCapacity > 0 && capacity > sku.MaxCapacity {
+		capacity = sku.MaxCapacity
+	}
+
+	return capacity
+}
+
 // ResolveCapacity resolves the deployment capacity for a SKU.
 // If preferred is set and valid within the SKU's min/max/step constraints, it's used.
 // Otherwise falls back to the SKU's default capacity.
@@ -904,6 +2246,80 @@ func ResolveCapacityWithQuota(sku AiModelSku, preferred *int32, remaining float6
 	return fallbackCapacityWithinQuota(sku, remaining)
 }
 
+// RecommendCapacity recommends a deployment capacity for sku given its usage at a location: the SKU default when it
+// fits in the remaining quota, otherwise the largest step-aligned capacity that fits. When usage is nil (e.g.
+// free-tier subscriptions where the usages API returns no entries), the SKU default is recommended unconstrained.
+func RecommendCapacity(sku AiModelSku, usage *AiModelUsage) CapacityRecommendation {
+	if usage == nil {
+		return CapacityRecommendation{Capacity: sku.DefaultCapacity}
+	}
+
+	remaining := usage.Limit - usage.CurrentValue
+	capacity, fits := ResolveCapacityWithQuota(sku, nil, remaining)
+	if !fits {
+		capacity = 0
+	}
+
+	return CapacityRecommendation{
+		Capacity: capacity,
+		QuotaConstrained: !fits ||
+			(capacityValidForSku(sku, sku.DefaultCapacity) && !capacityFitsWithinQuota(sku, sku.DefaultCapacity, remaining)),
+		RemainingQuota: &remaining,
+	}
+}
+
+// ValidateCapacity checks capacity against the min, max and step constraints of sku. Steps count from the SKU minimum,
+// or from the step itself when the SKU has no minimum.
+func ValidateCapacity(sku AiModelSku, capacity int32) error {
+	if capacity <= 0 {
+		return errors.New("capacity must be greater than 0")
+	}
+
+	if !capacityValidForSku(sku, capacity) {
+		return fmt.Errorf("capacity must be %s", CapacityConstraintDescription(sku))
+	}
+
+	return nil
+}
+
+// ParseCapacity parses value as a deployment capacity for sku and validates it with ValidateCapacity.
+func ParseCapacity(sku AiModelSku, value string) (int32, error) {
+	parsed, err := strconv.ParseInt(strings.TrimSpace(value), 10, 32)
+	if err != nil {
+		return 0, errors.New("capacity must be a whole number")
+	}
+
+	capacity := int32(parsed)
+	if err := ValidateCapacity(sku, capacity); err != nil {
+		return 0, err
+	}
+
+	return capacity, nil
+}
+
+// CapacityConstraintDescription describes the valid capacities for sku, e.g. "between 10 and 100 in steps of 10".
+// Returns an empty string when the SKU has no min, max or step constraints.
+func CapacityConstraintDescription(sku AiModelSku) string {
+	var description string
+	switch {
+	case sku.MinCapacity > 0 && sku.MaxCapacity > 0:
+		description = fmt.Sprintf("between %d and %d", sku.MinCapacity, sku.MaxCapacity)
+	case sku.MinCapacity > 0:
+		description = fmt.Sprintf("at least %d", sku.MinCapacity)
+	case sku.MaxCapacity > 0:
+		description = fmt.Sprintf("at most %d", sku.MaxCapacity)
+	}
+
+	if sku.CapacityStep > 0 {
+		if description == "" {
+			return fmt.Sprintf("a multiple of %d", sku.CapacityStep)
+		}
+		description += fmt.Sprintf(" in steps of %d", sku.CapacityStep)
+	}
+
+	return description
+}
+
 func capacityValidForSku(sku AiModelSku, capacity int32) bool {
 	if capacity <= 0 {
 		return false
@@ -983,6 +2399,47 @@ func fallbackCapacityWithinQuota(sku AiModelSku, remaining float64) (int32, bool
 	return candidate, true
 }
 
+// ModelFamily derives a model family from a model name so related models can be grouped.
+// The family is the name up to and including the leading digits of the first hyphen-separated
+// segment that contains a digit, e.g. "gpt-4o-mini" -> "gpt-4", "Phi-3-mini-4k-instruct" -> "Phi-3".
+// Names without digits are their own family.
+func ModelFamily(name string) string {
+	segments := strings.Split(name, "-")
+	for i, segment := range segments {
+		start := strings.IndexFunc(segment, unicode.IsDigit)
+		if start < 0 {
+			continue
+		}
+
+		end := start
+		for end < len(segment) && unicode.IsDigit(rune(segment[end])) {
+			end++
+		}
+
+		return strings.Join(append(slices.Clone(segments[:i]), segment[:end]), "-")
+	}
+
+	return name
+}
+
+// ModelFormats returns the distinct formats the model is offered in, in sorted order. Versions without a format
+// use model.Format.
+func ModelFormats(model AiModel) []string {
+	formats := []string{}
+	for _, version := range model.Versions {
+		format := cmp.Or(version.Format, model.Format)
+		if !slices.Contains(formats, format) {
+			formats = append(formats, format)
+		}
+	}
+	if len(formats) == 0 {
+		formats = append(formats, model.Format)
+	}
+
+	slices.Sort(formats)
+	return formats
+}
+
 // ModelHasDefaultVersion returns true if any version of the model is marked as default.
 func ModelHasDefaultVersion(model AiModel) bool {
 	for _, v := range model.Versions {
@@ -1022,6 +2479,22 @@ func modelHasQuota(model AiModel, usageMap map[string]AiModelUsage, minRemaining
 	return false
 }
 
+// HasAccountQuota reports whether usageMap leaves at least minAccountQuota remaining
+// account-count quota on accountUsageName, e.g. AccountCountUsageNameForKind(kind). Empty usage
+// data (e.g. free-tier subscriptions) is treated as available, consistent with model quota checks.
+func HasAccountQuota(usageMap map[string]AiModelUsage, accountUsageName string, minAccountQuota float64) bool {
+	if len(usageMap) == 0 {
+		return true
+	}
+
+	usage, ok := usageMap[accountUsageName]
+	if !ok {
+		return false
+	}
+
+	return usage.Limit-usage.CurrentValue >= minAccountQuota
+}
+
 func maxModelRemainingQuota(model AiModel, usageMap map[string]AiModelUsage) (float64, bool) {
 	// When usage data is empty (e.g. free-tier subscriptions), treat the
 	// model as available if it has at least one SKU.  Return
@@ -1112,13 +2585,14 @@ func filterModelsByAnyLocationQuota(
 	return filtered
 }
 
+// maxConcurrentUsageCalls caps the usage queries issued at once when fetching the usages of several locations.
+const maxConcurrentUsageCalls = 8
+
 func (s *AiModelService) listUsagesByLocation(
 	ctx context.Context,
 	subscriptionId string,
 	locations []string,
 ) (map[string][]AiModelUsage, error) {
-	const maxConcurrentUsageCalls = 8
-
 	var wg sync.WaitGroup
 	var mu sync.Mutex
 	sem := make(chan struct{}, maxConcurrentUsageCalls)
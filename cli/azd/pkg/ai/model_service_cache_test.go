@@ -85,6 +85,87 @@ func TestAiModelService_ListModels_FromCache(t *testing.T) {
 	require.Equal(t, "gpt-4o-mini", models[1].Name)
 }
 
+func TestAiModelService_ListLocations_FromCache(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	svc := seedCache(t, "sub-1", map[string][]*armcognitiveservices.Model{
+		"eastus": {
+			sampleModel("gpt-4o", "2024-05-13", "Standard", "OpenAI.Standard.gpt-4o", true),
+		},
+		"westus": {
+			sampleModel("gpt-4o-mini", "2024-07-18", "Standard", "OpenAI.Standard.gpt-4o-mini", true),
+		},
+	})
+	svc.locationsCache["sub-1"] = []string{"eastus", "westus"}
+
+	locations, err := svc.ListLocations(ctx, "sub-1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"eastus", "westus"}, locations)
+
+	// Callers may reorder the result without affecting the cached list.
+	locations[0] = "swedencentral"
+	locations, err = svc.ListLocations(ctx, "sub-1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"eastus", "westus"}, locations)
+
+	// Listing models across all locations resolves them from the cache too.
+	models, err := svc.ListModels(ctx, "sub-1", nil)
+	require.NoError(t, err)
+	require.Len(t, models, 2)
+}
+
+func TestAiModelService_ListModelCatalog_AvailableCapabilities(t *testing.T) {
+	t.Parallel()
+
+	embedding := sampleModel("text-embedding-3-small", "1", "Standard", "OpenAI.Standard.text-embedding-3-small", true)
+	embedding.Model.Capabilities = map[string]*string{"embeddings": new("true"), "responses": new("true")}
+	svc := seedCache(t, "sub-1", map[string][]*armcognitiveservices.Model{
+		"eastus": {sampleModel("gpt-4o", "2024-05-13", "Standard", "OpenAI.Standard.gpt-4o", true)},
+		"westus": {embedding},
+	})
+	svc.locationsCache["sub-1"] = []string{"eastus", "westus"}
+
+	tests := []struct {
+		name                      string
+		options                   *FilterOptions
+		wantModels                int
+		wantAvailableCapabilities []string
+	}{
+		{
+			name:                      "CapabilityExcludesEverything",
+			options:                   &FilterOptions{Capabilities: []string{"chatCompletion"}},
+			wantAvailableCapabilities: []string{"chat", "embeddings", "responses"},
+		},
+		{
+			name:       "CapabilityMatches",
+			options:    &FilterOptions{Capabilities: []string{"chat"}},
+			wantModels: 1,
+		},
+		{
+			// Only a capability filter that excludes everything is explained.
+			name:    "OtherFilterExcludesEverything",
+			options: &FilterOptions{Formats: []string{"Microsoft"}},
+		},
+		{
+			// A capability that matches is not explained when another filter excludes everything.
+			name:    "CapabilityMatchesButOtherFilterExcludesEverything",
+			options: &FilterOptions{Capabilities: []string{"chat"}, Formats: []string{"Microsoft"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := svc.ListModelCatalog(t.Context(), "sub-1", tt.options)
+			require.NoError(t, err)
+			require.Len(t, result.Models, tt.wantModels)
+			require.Equal(t, tt.wantAvailableCapabilities, result.AvailableCapabilities)
+		})
+	}
+}
+
 func TestAiModelService_ListModelVersions(t *testing.T) {
 	t.Parallel()
 	ctx := t.Context()
@@ -223,6 +304,45 @@ func TestAiModelService_ResolveModelDeployments(t *testing.T) {
 	})
 }
 
+func TestAiModelService_ResolveModelDeployments_PreferredSkus(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	models := map[string][]*armcognitiveservices.Model{
+		"eastus": {
+			sampleModel("gpt-4o", "2024-05-13", "Standard", "OpenAI.Standard.gpt-4o", true),
+			sampleModel("gpt-4o", "2024-11-20", "GlobalStandard", "OpenAI.GlobalStandard.gpt-4o", false),
+		},
+	}
+
+	for _, preferred := range []string{"Standard", "GlobalStandard"} {
+		t.Run("orders by configured "+preferred, func(t *testing.T) {
+			svc := seedCache(t, "sub-1", models)
+			svc.SetPreferredSkus([]string{preferred})
+
+			result, err := svc.ResolveModelDeployments(ctx, "sub-1", "gpt-4o", &DeploymentOptions{
+				Locations: []string{"eastus"},
+			})
+			require.NoError(t, err)
+			require.Len(t, result, 2)
+			require.Equal(t, preferred, result[0].Sku.Name)
+		})
+	}
+
+	t.Run("caller skus win over configured order", func(t *testing.T) {
+		svc := seedCache(t, "sub-1", models)
+		svc.SetPreferredSkus([]string{"GlobalStandard"})
+
+		result, err := svc.ResolveModelDeployments(ctx, "sub-1", "gpt-4o", &DeploymentOptions{
+			Locations: []string{"eastus"},
+			Skus:      []string{"Standard"},
+		})
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.Equal(t, "Standard", result[0].Sku.Name)
+	})
+}
+
 func TestAiModelService_ResolveModelDeployments_ExcludesFinetuneByDefault(t *testing.T) {
 	t.Parallel()
 	ctx := t.Context()
@@ -251,6 +371,108 @@ func TestAiModelService_ResolveModelDeployments_ExcludesFinetuneByDefault(t *tes
 	})
 }
 
+func TestAiModelService_ResolveModelDeployments_FallbackToDefaultVersion(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	svc := seedCache(t, "sub-1", map[string][]*armcognitiveservices.Model{
+		"eastus": {
+			sampleModel("gpt-4o", "2024-05-13", "Standard", "OpenAI.Standard.gpt-4o", false),
+			sampleModel("gpt-4o", "2024-11-20", "Standard", "OpenAI.Standard.gpt-4o", true),
+		},
+	})
+
+	tests := []struct {
+		name        string
+		versions    []string
+		fallback    bool
+		wantVersion string
+		wantErr     error
+	}{
+		{
+			name:        "preferred version available",
+			versions:    []string{"2024-05-13"},
+			fallback:    true,
+			wantVersion: "2024-05-13",
+		},
+		{
+			name:     "retired version is strict by default",
+			versions: []string{"2024-02-15"},
+			wantErr:  ErrNoDeploymentMatch,
+		},
+		{
+			name:        "retired version falls back to default",
+			versions:    []string{"2024-02-15"},
+			fallback:    true,
+			wantVersion: "2024-11-20",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deployments, err := svc.ResolveModelDeployments(ctx, "sub-1", "gpt-4o", &DeploymentOptions{
+				Locations:                []string{"eastus"},
+				Versions:                 tt.versions,
+				FallbackToDefaultVersion: tt.fallback,
+			})
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, deployments, 1)
+			require.Equal(t, tt.wantVersion, deployments[0].Version)
+		})
+	}
+}
+
+func TestAiModelService_ResolveModelDeployments_PreferStable(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	preview := armcognitiveservices.ModelLifecycleStatusPreview
+	previewDefault := sampleModel("gpt-4o", "2025-01-01", "Standard", "OpenAI.Standard.gpt-4o", true)
+	previewDefault.Model.LifecycleStatus = &preview
+	gaNonDefault := sampleModel("gpt-4o", "2024-11-20", "Standard", "OpenAI.Standard.gpt-4o", false)
+
+	svc := seedCache(t, "sub-1", map[string][]*armcognitiveservices.Model{
+		"eastus": {previewDefault, gaNonDefault},
+	})
+
+	tests := []struct {
+		name         string
+		preferStable bool
+		wantVersions []string
+	}{
+		{
+			name:         "catalog order by default",
+			wantVersions: []string{"2025-01-01", "2024-11-20"},
+		},
+		{
+			name:         "generally available version before preview default",
+			preferStable: true,
+			wantVersions: []string{"2024-11-20", "2025-01-01"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deployments, err := svc.ResolveModelDeployments(ctx, "sub-1", "gpt-4o", &DeploymentOptions{
+				Locations:    []string{"eastus"},
+				PreferStable: tt.preferStable,
+			})
+			require.NoError(t, err)
+
+			versions := make([]string, len(deployments))
+			for i, deployment := range deployments {
+				versions[i] = deployment.Version
+			}
+			require.Equal(t, tt.wantVersions, versions)
+		})
+	}
+}
+
 func TestAiModelService_ResolveModelDeploymentsWithQuota_RequiresSingleLocation(t *testing.T) {
 	t.Parallel()
 	ctx := t.Context()
@@ -286,8 +508,9 @@ func TestAiModelService_FetchModelsForLocations_CachedOnly(t *testing.T) {
 		"westus": {sampleModel("m2", "v1", "Standard", "a.b.c", true)},
 	})
 
-	result, err := svc.fetchModelsForLocations(ctx, "sub-1", []string{"eastus", "westus"})
+	result, failedLocations, err := svc.fetchModelsForLocations(ctx, "sub-1", []string{"eastus", "westus"})
 	require.NoError(t, err)
+	require.Empty(t, failedLocations)
 	require.Len(t, result, 2)
 	require.Contains(t, result, "eastus")
 	require.Contains(t, result, "westus")
@@ -304,3 +527,55 @@ func TestAiModelService_ConvertToAiModels_UsesNow(t *testing.T) {
 	require.Len(t, models, 1)
 	require.Equal(t, "m1", models[0].Name)
 }
+
+func TestAiModelService_FindModel(t *testing.T) {
+	t.Parallel()
+
+	retired := sampleModel("gpt-35-turbo", "0301", "Standard", "OpenAI.Standard.gpt-35-turbo", true)
+	retired.Model.SKUs = nil
+
+	svc := NewAiModelService(nil, nil)
+	svc.locationsCache["sub-1"] = []string{"eastus"}
+	svc.catalogCache["sub-1:eastus"] = []*armcognitiveservices.Model{
+		sampleModel("gpt-4o", "2024-11-20", "GlobalStandard", "OpenAI.GlobalStandard.gpt-4o", true),
+		retired,
+	}
+
+	tests := []struct {
+		name      string
+		modelName string
+		wantModel string
+		wantErr   error
+	}{
+		{name: "deployable model", modelName: "gpt-4o", wantModel: "gpt-4o"},
+		{name: "case variant", modelName: "GPT-4o", wantModel: "gpt-4o"},
+		{name: "hyphenation variant", modelName: "gpt4o", wantModel: "gpt-4o"},
+		{name: "listed without deployable versions", modelName: "gpt-35-turbo", wantErr: ErrModelNotDeployable},
+		{name: "alias without deployable versions", modelName: "gpt-3.5-turbo", wantErr: ErrModelNotDeployable},
+		{name: "not listed", modelName: "gpt-unknown", wantErr: ErrModelNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, err := svc.FindModel(t.Context(), "sub-1", nil, tt.modelName)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, model)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantModel, model.Name)
+		})
+	}
+
+	_, err := svc.FindModel(t.Context(), "sub-1", nil, "gpt-4x")
+	notFound, ok := errors.AsType[*ModelNotFoundError](err)
+	require.True(t, ok)
+	require.Equal(t, "gpt-4o", notFound.Suggestion)
+	require.EqualError(t, err, `model not found: "gpt-4x"; did you mean "gpt-4o"?`)
+
+	_, err = svc.ResolveModelDeployments(t.Context(), "sub-1", "gpt-35-turbo", nil)
+	require.ErrorIs(t, err, ErrModelNotDeployable)
+	require.NotErrorIs(t, err, ErrModelNotFound)
+}
@@ -0,0 +1,680 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ai
+
+import (
+	"cmp"
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cognitiveservices/armcognitiveservices/v2"
+	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockaccount"
+	"github.com/stretchr/testify/require"
+)
+
+// newQuotaTestService returns a service whose usages requests are answered by the mock context, with the AI
+// Services locations and the model catalog served from the cache.
+func newQuotaTestService(
+	t *testing.T,
+	mockCtx *mocks.MockContext,
+	models map[string][]*armcognitiveservices.Model,
+) *AiModelService {
+	t.Helper()
+
+	azureClient := azapi.NewAzureClient(
+		mockaccount.SubscriptionCredentialProviderFunc(func(context.Context, string) (azcore.TokenCredential, error) {
+			return mockCtx.Credentials, nil
+		}),
+		mockCtx.ArmClientOptions,
+	)
+
+	svc := NewAiModelService(azureClient, nil)
+	for loc, list := range models {
+		svc.catalogCache["sub-1:"+loc] = list
+		svc.locationsCache["sub-1"] = append(svc.locationsCache["sub-1"], loc)
+	}
+
+	return svc
+}
+
+// registerUsages answers usages requests with a single usage, sleeping first for the locations in slow.
+func registerUsages(mockCtx *mocks.MockContext, usage *armcognitiveservices.Usage, slow map[string]time.Duration) {
+	mockCtx.HttpClient.When(func(req *http.Request) bool {
+		return req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, "/usages")
+	}).RespondFn(func(req *http.Request) (*http.Response, error) {
+		for loc, delay := range slow {
+			if strings.Contains(req.URL.Path, "/locations/"+loc+"/") {
+				time.Sleep(delay)
+			}
+		}
+
+		return mocks.CreateHttpResponseWithBody(req, http.StatusOK, armcognitiveservices.UsageListResult{
+			Value: []*armcognitiveservices.Usage{usage},
+		})
+	})
+}
+
+func TestAiModelService_EvaluateLocationsWithQuota_LocationTimeout(t *testing.T) {
+	mockCtx := mocks.NewMockContext(t.Context())
+	svc := newQuotaTestService(t, mockCtx, map[string][]*armcognitiveservices.Model{
+		"eastus": {},
+		"westus": {},
+	})
+	registerUsages(mockCtx, &armcognitiveservices.Usage{
+		Name:         &armcognitiveservices.MetricName{Value: new("OpenAI.Standard.gpt-4o")},
+		CurrentValue: new(float64(10)),
+		Limit:        new(float64(100)),
+	}, map[string]time.Duration{"westus": 2 * time.Second})
+
+	start := time.Now()
+	result, err := svc.EvaluateLocationsWithQuota(
+		*mockCtx.Context,
+		"sub-1",
+		nil,
+		[]QuotaRequirement{{UsageName: "OpenAI.Standard.gpt-4o", MinCapacity: 10}},
+		WithLocationTimeout(50*time.Millisecond),
+	)
+	require.NoError(t, err)
+	require.Less(t, time.Since(start), time.Second)
+
+	require.Equal(t, []string{"eastus"}, result.Locations)
+	require.Len(t, result.FailedLocations, 1)
+	require.Equal(t, "westus", result.FailedLocations[0].Location)
+	require.ErrorIs(t, result.FailedLocations[0].Err, ErrLocationTimeout)
+}
+
+func TestAiModelService_EvaluateLocationsWithQuota_MaxLocations(t *testing.T) {
+	mockCtx := mocks.NewMockContext(t.Context())
+	svc := newQuotaTestService(t, mockCtx, map[string][]*armcognitiveservices.Model{
+		"eastus":        {},
+		"westus":        {},
+		"northeurope":   {},
+		"swedencentral": {},
+		"japaneast":     {},
+	})
+
+	var mu sync.Mutex
+	var queried []string
+	mockCtx.HttpClient.When(func(req *http.Request) bool {
+		return req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, "/usages")
+	}).RespondFn(func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		location := strings.Split(strings.Split(req.URL.Path, "/locations/")[1], "/")[0]
+		queried = append(queried, location)
+		mu.Unlock()
+
+		return mocks.CreateHttpResponseWithBody(req, http.StatusOK, armcognitiveservices.UsageListResult{
+			Value: []*armcognitiveservices.Usage{{
+				Name:         &armcognitiveservices.MetricName{Value: new("OpenAI.Standard.gpt-4o")},
+				CurrentValue: new(float64(10)),
+				Limit:        new(float64(100)),
+			}},
+		})
+	})
+
+	result, err := svc.EvaluateLocationsWithQuota(
+		*mockCtx.Context,
+		"sub-1",
+		nil,
+		[]QuotaRequirement{{UsageName: "OpenAI.Standard.gpt-4o", MinCapacity: 10}},
+		WithMaxLocations(3, "westus", "swedencentral"),
+	)
+	require.NoError(t, err)
+
+	// The preferred locations are checked first, then the others by name.
+	require.ElementsMatch(t, []string{"westus", "swedencentral", "eastus"}, queried)
+	require.Equal(t, []string{"eastus", "swedencentral", "westus"}, result.Locations)
+	require.Equal(t, []string{"japaneast", "northeurope"}, result.SkippedLocations)
+}
+
+func TestLimitLocations(t *testing.T) {
+	locations := []string{"westus", "eastus", "northeurope"}
+
+	tests := []struct {
+		name        string
+		limit       int
+		preferred   []string
+		wantChecked []string
+		wantSkipped []string
+	}{
+		{"Unlimited", 0, nil, locations, nil},
+		{"LimitAboveCount", 5, nil, locations, nil},
+		{"ByName", 2, nil, []string{"eastus", "northeurope"}, []string{"westus"}},
+		{"Preferred", 2, []string{"westus"}, []string{"westus", "eastus"}, []string{"northeurope"}},
+		{
+			"UnknownPreferred",
+			1,
+			[]string{"japaneast", "northeurope"},
+			[]string{"northeurope"},
+			[]string{"eastus", "westus"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checked, skipped := limitLocations(locations, tt.limit, tt.preferred)
+			require.Equal(t, tt.wantChecked, checked)
+			require.Equal(t, tt.wantSkipped, skipped)
+		})
+	}
+}
+
+func TestAiModelService_EvaluateLocationsWithQuota_Throttled(t *testing.T) {
+	mockCtx := mocks.NewMockContext(t.Context())
+	// Answer the throttled request once instead of retrying it.
+	mockCtx.ArmClientOptions.Retry.MaxRetries = -1
+	svc := newQuotaTestService(t, mockCtx, map[string][]*armcognitiveservices.Model{
+		"eastus":      {},
+		"westus":      {},
+		"northeurope": {},
+	})
+	mockCtx.HttpClient.When(func(req *http.Request) bool {
+		return req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, "/usages")
+	}).RespondFn(func(req *http.Request) (*http.Response, error) {
+		switch {
+		case strings.Contains(req.URL.Path, "/locations/westus/"):
+			return mocks.CreateEmptyHttpResponse(req, http.StatusTooManyRequests)
+		case strings.Contains(req.URL.Path, "/locations/northeurope/"):
+			return mocks.CreateEmptyHttpResponse(req, http.StatusForbidden)
+		}
+
+		return mocks.CreateHttpResponseWithBody(req, http.StatusOK, armcognitiveservices.UsageListResult{
+			Value: []*armcognitiveservices.Usage{{
+				Name:         &armcognitiveservices.MetricName{Value: new("OpenAI.Standard.gpt-4o")},
+				CurrentValue: new(float64(10)),
+				Limit:        new(float64(100)),
+			}},
+		})
+	})
+
+	result, err := svc.EvaluateLocationsWithQuota(
+		*mockCtx.Context,
+		"sub-1",
+		nil,
+		[]QuotaRequirement{{UsageName: "OpenAI.Standard.gpt-4o", MinCapacity: 10}},
+	)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"eastus"}, result.Locations)
+	require.Len(t, result.FailedLocations, 2)
+	require.Equal(t, "northeurope", result.FailedLocations[0].Location)
+	require.False(t, result.FailedLocations[0].Throttled)
+	require.Equal(t, "westus", result.FailedLocations[1].Location)
+	require.True(t, result.FailedLocations[1].Throttled)
+}
+
+func TestAiModelService_EvaluateModelLocationsWithQuota_LocationTimeout(t *testing.T) {
+	mockCtx := mocks.NewMockContext(t.Context())
+	usageName := "OpenAI.Standard.gpt-4o"
+	svc := newQuotaTestService(t, mockCtx, map[string][]*armcognitiveservices.Model{
+		"eastus": {sampleModel("gpt-4o", "2024-05-13", "Standard", usageName, true)},
+		"westus": {sampleModel("gpt-4o", "2024-05-13", "Standard", usageName, true)},
+	})
+	registerUsages(mockCtx, &armcognitiveservices.Usage{
+		Name:         &armcognitiveservices.MetricName{Value: &usageName},
+		CurrentValue: new(float64(10)),
+		Limit:        new(float64(100)),
+	}, map[string]time.Duration{"westus": 2 * time.Second})
+
+	var progress []QuotaProgress
+	start := time.Now()
+	result, err := svc.EvaluateModelLocationsWithQuota(
+		*mockCtx.Context,
+		"sub-1",
+		"gpt-4o",
+		nil,
+		1,
+		0,
+		WithLocationTimeout(50*time.Millisecond),
+		WithQuotaProgress(func(p QuotaProgress) { progress = append(progress, p) }),
+	)
+	require.NoError(t, err)
+	require.Less(t, time.Since(start), time.Second)
+
+	require.Len(t, result.Locations, 1)
+	require.Equal(t, "eastus", result.Locations[0].Location)
+	require.Empty(t, result.InsufficientQuota)
+	require.Len(t, result.FailedLocations, 1)
+	require.Equal(t, "westus", result.FailedLocations[0].Location)
+	require.ErrorIs(t, result.FailedLocations[0].Err, ErrLocationTimeout)
+
+	// The timed-out location still counts as checked.
+	require.Len(t, progress, 2)
+	require.Equal(t, 2, progress[1].Checked)
+}
+
+func TestAiModelService_EvaluateModelLocationsWithQuota_RequiredCapabilities(t *testing.T) {
+	mockCtx := mocks.NewMockContext(t.Context())
+	usageName := "OpenAI.Standard.gpt-4o"
+	chat := sampleModel("gpt-4o", "2024-08-06", "Standard", usageName, true)
+	chat.Model.Capabilities = map[string]*string{"chatCompletion": new("true")}
+	// westus offers the model, but only a version without the chatCompletion capability.
+	legacy := sampleModel("gpt-4o", "2024-05-13", "Standard", usageName, true)
+	legacy.Model.Capabilities = map[string]*string{"completion": new("true")}
+	svc := newQuotaTestService(t, mockCtx, map[string][]*armcognitiveservices.Model{
+		"eastus": {chat},
+		"westus": {legacy},
+	})
+	registerUsages(mockCtx, &armcognitiveservices.Usage{
+		Name:         &armcognitiveservices.MetricName{Value: &usageName},
+		CurrentValue: new(float64(10)),
+		Limit:        new(float64(100)),
+	}, nil)
+
+	result, err := svc.EvaluateModelLocationsWithQuota(*mockCtx.Context, "sub-1", "gpt-4o", nil, 1, 0)
+	require.NoError(t, err)
+	require.Len(t, result.Locations, 2)
+
+	result, err = svc.EvaluateModelLocationsWithQuota(
+		*mockCtx.Context, "sub-1", "gpt-4o", nil, 1, 0, WithRequiredCapabilities("chatCompletion"))
+	require.NoError(t, err)
+	require.Len(t, result.Locations, 1)
+	require.Equal(t, "eastus", result.Locations[0].Location)
+	require.Equal(t, []string{"westus"}, result.ModelUnavailable)
+	require.Empty(t, result.InsufficientQuota)
+}
+
+func TestAiModelService_EvaluateModelLocationsWithQuota_AccountKind(t *testing.T) {
+	mockCtx := mocks.NewMockContext(t.Context())
+	usageName := "AIServices.GlobalStandard.Phi-4"
+	svc := newQuotaTestService(t, mockCtx, map[string][]*armcognitiveservices.Model{
+		"eastus": {sampleModel("Phi-4", "7", "GlobalStandard", usageName, true)},
+	})
+	// The subscription has used up its OpenAI accounts, but can still create AIServices accounts.
+	mockCtx.HttpClient.When(func(req *http.Request) bool {
+		return req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, "/usages")
+	}).RespondFn(func(req *http.Request) (*http.Response, error) {
+		usage := func(name string, current float64, limit float64) *armcognitiveservices.Usage {
+			return &armcognitiveservices.Usage{
+				Name:         &armcognitiveservices.MetricName{Value: &name},
+				CurrentValue: &current,
+				Limit:        &limit,
+			}
+		}
+
+		return mocks.CreateHttpResponseWithBody(req, http.StatusOK, armcognitiveservices.UsageListResult{
+			Value: []*armcognitiveservices.Usage{
+				usage(usageName, 0, 100),
+				usage(AccountCountUsageName, 30, 30),
+				usage("AIServices.S0.AccountCount", 2, 30),
+			},
+		})
+	})
+
+	result, err := svc.EvaluateModelLocationsWithQuota(*mockCtx.Context, "sub-1", "Phi-4", nil, 1, 1)
+	require.NoError(t, err)
+	require.Empty(t, result.Locations)
+	require.Equal(t, []string{"eastus"}, result.InsufficientQuota)
+
+	result, err = svc.EvaluateModelLocationsWithQuota(
+		*mockCtx.Context, "sub-1", "Phi-4", nil, 1, 1, WithAccountKind("AIServices"))
+	require.NoError(t, err)
+	require.Len(t, result.Locations, 1)
+	require.Equal(t, "eastus", result.Locations[0].Location)
+	require.Empty(t, result.InsufficientQuota)
+}
+
+func TestAiModelService_EvaluateModelLocationsWithQuota_Formats(t *testing.T) {
+	mockCtx := mocks.NewMockContext(t.Context())
+	openAiUsage := "OpenAI.GlobalStandard.mistral-large"
+	mistralUsage := "AIServices.GlobalStandard.mistral-large"
+	openAi := sampleModel("mistral-large", "2411", "GlobalStandard", openAiUsage, true)
+	mistral := sampleModel("mistral-large", "2411", "GlobalStandard", mistralUsage, true)
+	mistral.Model.Format = new("Mistral AI")
+	svc := newQuotaTestService(t, mockCtx, map[string][]*armcognitiveservices.Model{
+		"eastus": {openAi, mistral},
+		"westus": {mistral},
+	})
+	// The OpenAI format's quota is used up; the Mistral AI format's is not.
+	mockCtx.HttpClient.When(func(req *http.Request) bool {
+		return req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, "/usages")
+	}).RespondFn(func(req *http.Request) (*http.Response, error) {
+		usage := func(name string, current float64, limit float64) *armcognitiveservices.Usage {
+			return &armcognitiveservices.Usage{
+				Name:         &armcognitiveservices.MetricName{Value: &name},
+				CurrentValue: &current,
+				Limit:        &limit,
+			}
+		}
+
+		return mocks.CreateHttpResponseWithBody(req, http.StatusOK, armcognitiveservices.UsageListResult{
+			Value: []*armcognitiveservices.Usage{
+				usage(openAiUsage, 100, 100),
+				usage(mistralUsage, 0, 100),
+			},
+		})
+	})
+
+	result, err := svc.EvaluateModelLocationsWithQuota(
+		*mockCtx.Context, "sub-1", "mistral-large", nil, 1, 0, WithFormats("OpenAI"))
+	require.NoError(t, err)
+	require.Empty(t, result.Locations)
+	require.Equal(t, []string{"eastus"}, result.InsufficientQuota)
+	require.Equal(t, []string{"westus"}, result.ModelUnavailable)
+
+	result, err = svc.EvaluateModelLocationsWithQuota(
+		*mockCtx.Context, "sub-1", "mistral-large", nil, 1, 0, WithFormats("Mistral AI"))
+	require.NoError(t, err)
+	require.Len(t, result.Locations, 2)
+	require.Empty(t, result.InsufficientQuota)
+	require.Empty(t, result.ModelUnavailable)
+
+	// The deployments at a dual-format location each carry the format of the SKU they were built from.
+	deployments, err := svc.ResolveModelDeploymentsWithQuota(
+		*mockCtx.Context, "sub-1", "mistral-large", &DeploymentOptions{Locations: []string{"eastus"}},
+		&QuotaCheckOptions{MinRemainingCapacity: 1})
+	require.NoError(t, err)
+	require.Len(t, deployments, 1)
+	require.Equal(t, "Mistral AI", deployments[0].Format)
+	require.Equal(t, mistralUsage, deployments[0].Sku.UsageName)
+
+	deployments, err = svc.ResolveModelDeployments(
+		*mockCtx.Context, "sub-1", "mistral-large", &DeploymentOptions{Locations: []string{"eastus"}})
+	require.NoError(t, err)
+	formats := map[string]string{}
+	for _, deployment := range deployments {
+		formats[deployment.Sku.UsageName] = deployment.Format
+	}
+	require.Equal(t, map[string]string{openAiUsage: "OpenAI", mistralUsage: "Mistral AI"}, formats)
+}
+
+func TestAiModelService_ResolveModelDeploymentsInRegions(t *testing.T) {
+	usageName := "OpenAI.GlobalStandard.gpt-4o"
+	newService := func(t *testing.T) *AiModelService {
+		mockCtx := mocks.NewMockContext(t.Context())
+		svc := newQuotaTestService(t, mockCtx, map[string][]*armcognitiveservices.Model{
+			"eastus":        {sampleModel("gpt-4o", "2024-08-06", "GlobalStandard", usageName, true)},
+			"swedencentral": {sampleModel("gpt-4o", "2024-08-06", "GlobalStandard", usageName, true)},
+			"westus":        {sampleModel("gpt-4o", "2024-08-06", "GlobalStandard", usageName, true)},
+			"northeurope":   {},
+		})
+		// westus has used up its quota.
+		mockCtx.HttpClient.When(func(req *http.Request) bool {
+			return req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, "/usages")
+		}).RespondFn(func(req *http.Request) (*http.Response, error) {
+			current := float64(0)
+			if strings.Contains(req.URL.Path, "/locations/westus/") {
+				current = 100
+			}
+			return mocks.CreateHttpResponseWithBody(req, http.StatusOK, armcognitiveservices.UsageListResult{
+				Value: []*armcognitiveservices.Usage{{
+					Name:         &armcognitiveservices.MetricName{Value: &usageName},
+					CurrentValue: &current,
+					Limit:        new(float64(100)),
+				}},
+			})
+		})
+		return svc
+	}
+
+	tests := []struct {
+		name         string
+		preference   RegionPreference
+		quota        *QuotaCheckOptions
+		wantLocation string
+		wantRank     int
+		wantFallback bool
+		wantErr      error
+	}{
+		{
+			name:         "first preferred location wins",
+			preference:   RegionPreference{Locations: []string{"westus", "eastus"}},
+			wantLocation: "westus",
+			wantRank:     1,
+		},
+		{
+			name:         "locations without the model are skipped in order",
+			preference:   RegionPreference{Locations: []string{"northeurope", "swedencentral", "eastus"}},
+			wantLocation: "swedencentral",
+			wantRank:     2,
+		},
+		{
+			name:         "locations without quota are skipped in order",
+			preference:   RegionPreference{Locations: []string{"westus", "WestUS", "eastus"}},
+			quota:        &QuotaCheckOptions{MinRemainingCapacity: 1},
+			wantLocation: "eastus",
+			wantRank:     2,
+		},
+		{
+			name:       "no fallback outside the preferred locations",
+			preference: RegionPreference{Locations: []string{"northeurope", "westus"}},
+			quota:      &QuotaCheckOptions{MinRemainingCapacity: 1},
+			wantErr:    ErrNoDeploymentMatch,
+		},
+		{
+			name:         "fallback to the other locations by name",
+			preference:   RegionPreference{Locations: []string{"northeurope", "westus"}, AllowFallback: true},
+			quota:        &QuotaCheckOptions{MinRemainingCapacity: 1},
+			wantLocation: "eastus",
+			wantRank:     3,
+			wantFallback: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := newService(t).ResolveModelDeploymentsInRegions(
+				t.Context(), "sub-1", "gpt-4o", tt.preference, nil, tt.quota)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantLocation, result.Location)
+			require.Equal(t, tt.wantRank, result.Rank)
+			require.Equal(t, tt.wantFallback, result.Fallback)
+			require.NotEmpty(t, result.Deployments)
+			for _, deployment := range result.Deployments {
+				require.Equal(t, tt.wantLocation, deployment.Location)
+			}
+		})
+	}
+
+	t.Run("requires a location without fallback", func(t *testing.T) {
+		_, err := newService(t).ResolveModelDeploymentsInRegions(
+			t.Context(), "sub-1", "gpt-4o", RegionPreference{}, nil, nil)
+		require.Error(t, err)
+	})
+}
+
+func TestWithLocationTimeout(t *testing.T) {
+	t.Run("returns the result in time", func(t *testing.T) {
+		value, err := withLocationTimeout(t.Context(), time.Second, func(context.Context) (string, error) {
+			return "eastus", nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, "eastus", value)
+	})
+
+	t.Run("parent cancellation is not a timeout", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		_, err := withLocationTimeout(ctx, time.Second, func(ctx context.Context) (string, error) {
+			<-ctx.Done()
+			return "", ctx.Err()
+		})
+		require.ErrorIs(t, err, context.Canceled)
+		require.NotErrorIs(t, err, ErrLocationTimeout)
+	})
+
+	t.Run("default timeout", func(t *testing.T) {
+		require.Equal(t, DefaultLocationTimeout, newQuotaCheckConfig(nil).locationTimeout)
+		config := newQuotaCheckConfig([]QuotaCheckOption{WithLocationTimeout(time.Minute)})
+		require.Equal(t, time.Minute, config.locationTimeout)
+	})
+}
+
+func TestAiModelService_CheckDeploymentQuota(t *testing.T) {
+	const usageName = "OpenAI.GlobalStandard.gpt-4o"
+	const ptuUsageName = "OpenAI.ProvisionedManaged.gpt-4o"
+
+	// ptuModel offers gpt-4o as ProvisionedManaged with a minimum of 15 PTUs in steps of 10, so a 15 PTU
+	// deployment draws 20 PTUs of quota.
+	ptuModel := sampleModel("gpt-4o", "2024-08-06", "ProvisionedManaged", ptuUsageName, true)
+	ptuModel.Model.SKUs[0].Capacity.Minimum = new(int32(15))
+	ptuModel.Model.SKUs[0].Capacity.Step = new(int32(10))
+	ptuModel.Model.SKUs[0].Capacity.Default = new(int32(15))
+
+	tests := []struct {
+		name          string
+		model         *armcognitiveservices.Model
+		capacity      int32
+		current       float64
+		wantCapacity  int32
+		wantValid     bool
+		wantFits      bool
+		wantRemaining float64
+	}{
+		{name: "default capacity fits", capacity: 0, current: 50, wantCapacity: 10, wantValid: true, wantFits: true,
+			wantRemaining: 50},
+		{name: "capacity over remaining quota", capacity: 60, current: 50, wantCapacity: 60, wantValid: true,
+			wantRemaining: 50},
+		{name: "capacity over sku maximum", capacity: 200, current: 0, wantCapacity: 200, wantRemaining: 100},
+		{name: "provisioned capacity rounds up to the step", model: ptuModel, capacity: 15, current: 85,
+			wantCapacity: 15, wantValid: true, wantRemaining: 15},
+		{name: "provisioned capacity fits rounded up", model: ptuModel, capacity: 15, current: 80, wantCapacity: 15,
+			wantValid: true, wantFits: true, wantRemaining: 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := cmp.Or(tt.model, sampleModel("gpt-4o", "2024-08-06", "GlobalStandard", usageName, true))
+			mockCtx := mocks.NewMockContext(t.Context())
+			svc := newQuotaTestService(t, mockCtx, map[string][]*armcognitiveservices.Model{"eastus": {model}})
+			registerUsages(mockCtx, &armcognitiveservices.Usage{
+				Name:         &armcognitiveservices.MetricName{Value: model.Model.SKUs[0].UsageName},
+				CurrentValue: new(tt.current),
+				Limit:        new(float64(100)),
+			}, nil)
+			check, err := svc.CheckDeploymentQuota(*mockCtx.Context,
+				"sub-1", "gpt-4o", "2024-08-06", *model.Model.SKUs[0].Name, "eastus", tt.capacity)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantCapacity, check.Capacity)
+			require.Equal(t, tt.wantValid, check.CapacityValid)
+			require.Equal(t, tt.wantFits, check.Fits)
+			require.NotNil(t, check.RemainingQuota)
+			require.Equal(t, tt.wantRemaining, *check.RemainingQuota)
+			require.Nil(t, check.AccountQuotaRemaining)
+		})
+	}
+
+	t.Run("unknown sku", func(t *testing.T) {
+		mockCtx := mocks.NewMockContext(t.Context())
+		svc := newQuotaTestService(t, mockCtx, map[string][]*armcognitiveservices.Model{
+			"eastus": {sampleModel("gpt-4o", "2024-08-06", "GlobalStandard", usageName, true)},
+		})
+		_, err := svc.CheckDeploymentQuota(
+			*mockCtx.Context, "sub-1", "gpt-4o", "2024-08-06", "Standard", "eastus", 10)
+		require.ErrorIs(t, err, ErrNoDeploymentMatch)
+	})
+}
+
+func TestAiModelService_ListModelCatalog_MinAvailableCapacity(t *testing.T) {
+	usageName := "OpenAI.GlobalStandard.gpt-4o"
+	miniUsageName := "OpenAI.GlobalStandard.gpt-4o-mini"
+	newService := func(t *testing.T) *AiModelService {
+		mockCtx := mocks.NewMockContext(t.Context())
+		svc := newQuotaTestService(t, mockCtx, map[string][]*armcognitiveservices.Model{
+			"eastus": {sampleModel("gpt-4o", "2024-08-06", "GlobalStandard", usageName, true)},
+			"westus": {
+				sampleModel("gpt-4o", "2024-08-06", "GlobalStandard", usageName, true),
+				sampleModel("gpt-4o-mini", "2024-07-18", "GlobalStandard", miniUsageName, true),
+			},
+		})
+		// gpt-4o has 80 remaining in eastus but only 5 in westus, where gpt-4o-mini has 90.
+		mockCtx.HttpClient.When(func(req *http.Request) bool {
+			return req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, "/usages")
+		}).RespondFn(func(req *http.Request) (*http.Response, error) {
+			usages := []*armcognitiveservices.Usage{{
+				Name:         &armcognitiveservices.MetricName{Value: &usageName},
+				CurrentValue: new(float64(20)),
+				Limit:        new(float64(100)),
+			}}
+			if strings.Contains(req.URL.Path, "/locations/westus/") {
+				usages = []*armcognitiveservices.Usage{
+					{
+						Name:         &armcognitiveservices.MetricName{Value: &usageName},
+						CurrentValue: new(float64(95)),
+						Limit:        new(float64(100)),
+					},
+					{
+						Name:         &armcognitiveservices.MetricName{Value: &miniUsageName},
+						CurrentValue: new(float64(10)),
+						Limit:        new(float64(100)),
+					},
+				}
+			}
+			return mocks.CreateHttpResponseWithBody(req, http.StatusOK, armcognitiveservices.UsageListResult{
+				Value: usages,
+			})
+		})
+		return svc
+	}
+
+	tests := []struct {
+		name       string
+		options    *FilterOptions
+		wantModels map[string][]string
+	}{
+		{
+			name:    "Disabled",
+			options: &FilterOptions{},
+			wantModels: map[string][]string{
+				"gpt-4o":      {"eastus", "westus"},
+				"gpt-4o-mini": {"westus"},
+			},
+		},
+		{
+			name:    "DropsLocationsBelowThreshold",
+			options: &FilterOptions{MinAvailableCapacity: 50},
+			wantModels: map[string][]string{
+				"gpt-4o":      {"eastus"},
+				"gpt-4o-mini": {"westus"},
+			},
+		},
+		{
+			name:    "OnlyChecksRequestedLocations",
+			options: &FilterOptions{Locations: []string{"westus"}, MinAvailableCapacity: 50},
+			wantModels: map[string][]string{
+				"gpt-4o-mini": {"westus"},
+			},
+		},
+		{
+			name:       "DropsModelsWithoutCapacity",
+			options:    &FilterOptions{MinAvailableCapacity: 95},
+			wantModels: map[string][]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newService(t)
+
+			result, err := svc.ListModelCatalog(t.Context(), "sub-1", tt.options)
+			require.NoError(t, err)
+
+			got := map[string][]string{}
+			for _, model := range result.Models {
+				got[model.Name] = model.Locations
+			}
+			require.Equal(t, tt.wantModels, got)
+
+			// The cached catalog is left intact for later requests.
+			for _, models := range svc.catalogCache {
+				for _, model := range models {
+					require.Len(t, model.Model.SKUs, 1)
+				}
+			}
+		})
+	}
+}
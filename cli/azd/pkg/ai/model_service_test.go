@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cognitiveservices/armcognitiveservices/v2"
+	"github.com/azure/azure-dev/cli/azd/pkg/account"
 	"github.com/stretchr/testify/require"
 )
 
@@ -112,6 +113,72 @@ func TestFilterModels(t *testing.T) {
 	}
 }
 
+func TestFilterModels_Intent(t *testing.T) {
+	models := []AiModel{
+		{Name: "gpt-4o", Capabilities: []string{"assistants", "chatCompletion"}},
+		{Name: "text-embedding-3-small", Capabilities: []string{"embeddings", "embeddingsMaxInputs"}},
+		{Name: "dall-e-3", Capabilities: []string{"imageGenerations"}},
+		{Name: "whisper", Capabilities: []string{"audio"}},
+	}
+
+	tests := []struct {
+		name     string
+		options  *FilterOptions
+		expected []string
+	}{
+		{
+			name:     "any",
+			options:  &FilterOptions{Intent: ModelIntentAny},
+			expected: []string{"gpt-4o", "text-embedding-3-small", "dall-e-3", "whisper"},
+		},
+		{
+			name:     "chat",
+			options:  &FilterOptions{Intent: ModelIntentChat},
+			expected: []string{"gpt-4o"},
+		},
+		{
+			name:     "embeddings",
+			options:  &FilterOptions{Intent: ModelIntentEmbeddings},
+			expected: []string{"text-embedding-3-small"},
+		},
+		{
+			name:     "image generation",
+			options:  &FilterOptions{Intent: ModelIntentImageGeneration},
+			expected: []string{"dall-e-3"},
+		},
+		{
+			name:     "intent and capabilities must both match",
+			options:  &FilterOptions{Intent: ModelIntentChat, Capabilities: []string{"embeddings"}},
+			expected: []string{},
+		},
+		{
+			name:     "unknown intent matches nothing",
+			options:  &FilterOptions{Intent: "speech"},
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names := []string{}
+			for _, m := range FilterModels(models, tt.options) {
+				names = append(names, m.Name)
+			}
+			require.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestModelIntent_Validate(t *testing.T) {
+	for _, intent := range []ModelIntent{
+		ModelIntentAny, ModelIntentChat, ModelIntentEmbeddings, ModelIntentImageGeneration,
+	} {
+		require.NoError(t, intent.Validate(), "intent %q", intent)
+	}
+
+	require.ErrorContains(t, ModelIntent("speech").Validate(), `unknown model intent "speech"`)
+}
+
 func TestFilterModels_FiltersVersionsByStatus(t *testing.T) {
 	t.Parallel()
 
@@ -134,6 +201,48 @@ func TestFilterModels_FiltersVersionsByStatus(t *testing.T) {
 	require.Equal(t, "Deprecating", filtered[0].Versions[0].LifecycleStatus)
 }
 
+func TestFilterModels_DefaultVersionsOnly(t *testing.T) {
+	t.Parallel()
+
+	models := []AiModel{
+		{
+			Name:   "gpt-4o",
+			Format: "OpenAI",
+			Versions: []AiModelVersion{
+				{Version: "2024-05-13"},
+				{Version: "2024-08-06"},
+				{Version: "2024-11-20", IsDefault: true},
+			},
+		},
+		{
+			Name:     "o3-mini",
+			Format:   "OpenAI",
+			Versions: []AiModelVersion{{Version: "2025-01-31"}, {Version: "2024-12-17"}},
+		},
+	}
+
+	versionsOf := func(models []AiModel) map[string][]string {
+		versions := map[string][]string{}
+		for _, m := range models {
+			for _, v := range m.Versions {
+				versions[m.Name] = append(versions[m.Name], v.Version)
+			}
+		}
+		return versions
+	}
+
+	require.Equal(t, map[string][]string{
+		"gpt-4o":  {"2024-11-20"},
+		"o3-mini": {"2025-01-31"},
+	}, versionsOf(FilterModels(models, &FilterOptions{DefaultVersionsOnly: true})))
+
+	require.Equal(t, map[string][]string{
+		"gpt-4o":  {"2024-05-13", "2024-08-06", "2024-11-20"},
+		"o3-mini": {"2025-01-31", "2024-12-17"},
+	}, versionsOf(FilterModels(models, &FilterOptions{})))
+	require.Len(t, models[0].Versions, 3, "the input models are not modified")
+}
+
 func TestConvertToAiModels_FiltersDeprecatedVersionsAndSkus(t *testing.T) {
 	t.Parallel()
 
@@ -212,7 +321,7 @@ func TestConvertToAiModels_FiltersDeprecatedVersionsAndSkus(t *testing.T) {
 		},
 	}
 
-	models := svc.convertToAiModelsAt(rawModels, now, nil)
+	models := svc.convertToAiModelsAt(rawModels, now, nil, false)
 	require.Len(t, models, 1)
 
 	// gpt-35-turbo (ARM "Deprecated"/Retired with past inference), gpt-4o
@@ -266,7 +375,7 @@ func TestConvertToAiModels_PreservesVersionLifecycleStatus(t *testing.T) {
 		},
 	}
 
-	models := svc.convertToAiModelsAt(rawModels, now, nil)
+	models := svc.convertToAiModelsAt(rawModels, now, nil, false)
 	require.Len(t, models, 1)
 	require.Equal(t, "gpt-4o", models[0].Name)
 	require.Empty(t, models[0].LifecycleStatus)
@@ -283,6 +392,62 @@ func TestConvertToAiModels_PreservesVersionLifecycleStatus(t *testing.T) {
 	}, versionStatuses)
 }
 
+func TestConvertToAiModels_SplitsVersionsByFormatWithinLocation(t *testing.T) {
+	t.Parallel()
+
+	svc := NewAiModelService(nil, nil)
+	now := time.Date(2026, 4, 6, 0, 0, 0, 0, time.UTC)
+
+	rawModels := map[string][]*armcognitiveservices.Model{
+		"eastus": {
+			{
+				Model: &armcognitiveservices.AccountModel{
+					Name:    new("gpt-oss-120b"),
+					Format:  new("OpenAI-OSS"),
+					Version: new("1"),
+					SKUs: []*armcognitiveservices.ModelSKU{
+						{Name: new("GlobalStandard"), UsageName: new("AIServices.GlobalStandard.gpt-oss-120b")},
+					},
+				},
+			},
+			{
+				Model: &armcognitiveservices.AccountModel{
+					Name:    new("gpt-oss-120b"),
+					Format:  new("OpenAI"),
+					Version: new("1"),
+					SKUs: []*armcognitiveservices.ModelSKU{
+						{Name: new("GlobalStandard"), UsageName: new("OpenAI.GlobalStandard.gpt-oss-120b")},
+					},
+				},
+			},
+		},
+	}
+
+	models := svc.convertToAiModelsAt(rawModels, now, nil, false)
+	require.Len(t, models, 1)
+	require.Equal(t, "OpenAI", models[0].Format)
+	require.Equal(t, []string{"OpenAI", "OpenAI-OSS"}, ModelFormats(models[0]))
+
+	skusByFormat := map[string][]string{}
+	for _, version := range models[0].Versions {
+		require.Equal(t, "1", version.Version)
+		for _, sku := range version.Skus {
+			skusByFormat[version.Format] = append(skusByFormat[version.Format], sku.UsageName)
+		}
+	}
+	require.Equal(t, map[string][]string{
+		"OpenAI":     {"OpenAI.GlobalStandard.gpt-oss-120b"},
+		"OpenAI-OSS": {"AIServices.GlobalStandard.gpt-oss-120b"},
+	}, skusByFormat)
+
+	// Filtering by the second format keeps only its versions.
+	filtered := FilterModels(models, &FilterOptions{Formats: []string{"OpenAI-OSS"}})
+	require.Len(t, filtered, 1)
+	require.Equal(t, "OpenAI-OSS", filtered[0].Format)
+	require.Len(t, filtered[0].Versions, 1)
+	require.Equal(t, "OpenAI-OSS", filtered[0].Versions[0].Format)
+}
+
 func TestConvertToAiModels_FiltersStatusesBeforeAggregation(t *testing.T) {
 	t.Parallel()
 
@@ -325,7 +490,7 @@ func TestConvertToAiModels_FiltersStatusesBeforeAggregation(t *testing.T) {
 		},
 	}
 
-	models := svc.convertToAiModelsAt(rawModels, now, []string{"GenerallyAvailable"})
+	models := svc.convertToAiModelsAt(rawModels, now, []string{"GenerallyAvailable"}, false)
 	require.Len(t, models, 1)
 	require.Equal(t, "gpt-4o", models[0].Name)
 	require.Empty(t, models[0].LifecycleStatus)
@@ -385,18 +550,22 @@ func TestConvertToAiModels_ExcludesDeprecatingByDefaultButAllowsOptIn(t *testing
 
 	// Default view: gpt-4.1-mini ("Deprecating") is excluded even though its inference
 	// endpoint is still active.
-	defaultModels := svc.convertToAiModelsAt(rawModels, now, nil)
+	defaultModels := svc.convertToAiModelsAt(rawModels, now, nil, false)
 	require.Len(t, defaultModels, 1)
 	require.Equal(t, "gpt-4o", defaultModels[0].Name)
 
 	// Opt-in: callers can request "Deprecating" models explicitly for existing-customer
 	// management scenarios.
-	optIn := svc.convertToAiModelsAt(rawModels, now, []string{"Deprecating"})
+	optIn := svc.convertToAiModelsAt(rawModels, now, []string{"Deprecating"}, false)
 	require.Len(t, optIn, 1)
 	require.Equal(t, "gpt-4.1-mini", optIn[0].Name)
 	require.Len(t, optIn[0].Versions, 1)
 	require.Equal(t, "2025-04-14", optIn[0].Versions[0].Version)
 	require.Equal(t, "Deprecating", optIn[0].Versions[0].LifecycleStatus)
+
+	// IncludeDeprecated keeps deprecating versions alongside the default view.
+	withDeprecated := svc.convertToAiModelsAt(rawModels, now, nil, true)
+	require.Len(t, withDeprecated, 2)
 }
 
 func TestConvertToAiModels_ExcludesLocationsWithOnlyDeprecatedEntries(t *testing.T) {
@@ -440,7 +609,7 @@ func TestConvertToAiModels_ExcludesLocationsWithOnlyDeprecatedEntries(t *testing
 		},
 	}
 
-	models := svc.convertToAiModelsAt(rawModels, now, nil)
+	models := svc.convertToAiModelsAt(rawModels, now, nil, false)
 	require.Len(t, models, 1)
 	require.Equal(t, "gpt-4o", models[0].Name)
 	require.Empty(t, models[0].LifecycleStatus)
@@ -450,6 +619,133 @@ func TestConvertToAiModels_ExcludesLocationsWithOnlyDeprecatedEntries(t *testing
 	require.Len(t, models[0].Versions[0].Skus, 1)
 }
 
+func TestConvertToAiModels_DropsVersionsWithoutSkus(t *testing.T) {
+	t.Parallel()
+
+	svc := NewAiModelService(nil, nil)
+	now := time.Date(2026, 4, 6, 0, 0, 0, 0, time.UTC)
+	standard := &armcognitiveservices.ModelSKU{
+		Name:      new("Standard"),
+		UsageName: new("OpenAI.Standard.gpt-4o"),
+	}
+
+	rawModels := map[string][]*armcognitiveservices.Model{
+		"eastus": {
+			// Version with SKUs in eastus only.
+			{Model: &armcognitiveservices.AccountModel{
+				Name: new("gpt-4o"), Format: new("OpenAI"), Version: new("2024-08-06"),
+				SKUs: []*armcognitiveservices.ModelSKU{standard},
+			}},
+			// Version without SKUs anywhere.
+			{Model: &armcognitiveservices.AccountModel{
+				Name: new("gpt-4o"), Format: new("OpenAI"), Version: new("2024-05-13"),
+			}},
+			// Model whose only version has a nil SKU entry.
+			{Model: &armcognitiveservices.AccountModel{
+				Name: new("text-embedding-3-small"), Format: new("OpenAI"), Version: new("1"),
+				SKUs: []*armcognitiveservices.ModelSKU{nil},
+			}},
+		},
+		"westus": {
+			// Same version without SKUs: must not add westus to the model locations.
+			{Model: &armcognitiveservices.AccountModel{
+				Name: new("gpt-4o"), Format: new("OpenAI"), Version: new("2024-08-06"),
+				SKUs: []*armcognitiveservices.ModelSKU{},
+			}},
+		},
+	}
+
+	models := svc.convertToAiModelsAt(rawModels, now, nil, false)
+	require.Len(t, models, 1)
+	require.Equal(t, "gpt-4o", models[0].Name)
+	require.Equal(t, []string{"eastus"}, models[0].Locations)
+	require.Len(t, models[0].Versions, 1)
+	require.Equal(t, "2024-08-06", models[0].Versions[0].Version)
+	require.Len(t, models[0].Versions[0].Skus, 1)
+}
+
+func TestMergeAiModels(t *testing.T) {
+	t.Parallel()
+
+	standard := AiModelSku{Name: "Standard", UsageName: "OpenAI.Standard.gpt-4o"}
+	globalStandard := AiModelSku{Name: "GlobalStandard", UsageName: "OpenAI.GlobalStandard.gpt-4o"}
+
+	// The subscriptions share eastus, and each has a location of its own.
+	first := []AiModel{{
+		Name:         "gpt-4o",
+		Format:       "OpenAI",
+		Capabilities: []string{"chatCompletion"},
+		Locations:    []string{"eastus", "westus"},
+		Versions: []AiModelVersion{{
+			Version:      "2024-08-06",
+			Format:       "OpenAI",
+			Capabilities: []string{"chatCompletion"},
+			Skus:         []AiModelSku{standard},
+		}},
+	}}
+	second := []AiModel{
+		{
+			Name:         "gpt-4o",
+			Format:       "OpenAI",
+			Capabilities: []string{"chatCompletion", "responses"},
+			Locations:    []string{"eastus", "swedencentral"},
+			Versions: []AiModelVersion{
+				{
+					Version:      "2024-08-06",
+					IsDefault:    true,
+					Format:       "OpenAI",
+					Capabilities: []string{"responses"},
+					Skus:         []AiModelSku{standard, globalStandard},
+				},
+				{
+					Version: "2024-11-20",
+					Format:  "OpenAI",
+					Skus:    []AiModelSku{globalStandard},
+				},
+			},
+		},
+		{
+			Name:      "text-embedding-3-small",
+			Format:    "OpenAI",
+			Locations: []string{"swedencentral"},
+			Versions:  []AiModelVersion{{Version: "1", Format: "OpenAI", Skus: []AiModelSku{standard}}},
+		},
+	}
+
+	merged := MergeAiModels(first, second)
+
+	require.Equal(t, []AiModel{
+		{
+			Name:         "gpt-4o",
+			Format:       "OpenAI",
+			Capabilities: []string{"chatCompletion", "responses"},
+			Locations:    []string{"eastus", "swedencentral", "westus"},
+			Versions: []AiModelVersion{
+				{
+					Version:      "2024-08-06",
+					IsDefault:    true,
+					Format:       "OpenAI",
+					Capabilities: []string{"chatCompletion", "responses"},
+					Skus:         []AiModelSku{standard, globalStandard},
+				},
+				{
+					Version: "2024-11-20",
+					Format:  "OpenAI",
+					Skus:    []AiModelSku{globalStandard},
+				},
+			},
+		},
+		second[1],
+	}, merged)
+
+	// The input catalogs are left untouched.
+	require.Equal(t, []string{"eastus", "westus"}, first[0].Locations)
+	require.Equal(t, []AiModelSku{standard}, first[0].Versions[0].Skus)
+	require.Equal(t, []string{"chatCompletion"}, first[0].Versions[0].Capabilities)
+
+	require.Empty(t, MergeAiModels())
+}
+
 func TestFilterModelsByQuota(t *testing.T) {
 	models := []AiModel{
 		{
@@ -565,6 +861,75 @@ func TestFilterModelsByQuota(t *testing.T) {
 	}
 }
 
+func TestClassifySkuDeploymentKind(t *testing.T) {
+	tests := []struct {
+		skuName  string
+		expected SkuDeploymentKind
+	}{
+		{"GlobalStandard", SkuDeploymentKindGlobal},
+		{"GlobalBatch", SkuDeploymentKindGlobal},
+		{"DataZoneStandard", SkuDeploymentKindDataZone},
+		{"DataZoneBatch", SkuDeploymentKindDataZone},
+		{"Standard", SkuDeploymentKindRegional},
+		{"ProvisionedManaged", SkuDeploymentKindProvisioned},
+		{"GlobalProvisionedManaged", SkuDeploymentKindProvisioned},
+		{"DataZoneProvisionedManaged", SkuDeploymentKindProvisioned},
+		{"DeveloperTier", SkuDeploymentKindUnknown},
+		{"", SkuDeploymentKindUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.skuName, func(t *testing.T) {
+			require.Equal(t, tt.expected, ClassifySkuDeploymentKind(tt.skuName))
+		})
+	}
+}
+
+func TestNormalizeDefaultCapacity(t *testing.T) {
+	tests := []struct {
+		name     string
+		sku      AiModelSku
+		expected int32
+	}{
+		{
+			name:     "zero default raised to min on step",
+			sku:      AiModelSku{DefaultCapacity: 0, MinCapacity: 10, CapacityStep: 10},
+			expected: 10,
+		},
+		{
+			name:     "off-step default rounded up",
+			sku:      AiModelSku{DefaultCapacity: 15, MinCapacity: 10, CapacityStep: 10},
+			expected: 20,
+		},
+		{
+			name:     "zero default without min raised to step",
+			sku:      AiModelSku{DefaultCapacity: 0, CapacityStep: 5},
+			expected: 5,
+		},
+		{
+			name:     "rounded default capped to max",
+			sku:      AiModelSku{DefaultCapacity: 95, MinCapacity: 10, MaxCapacity: 90, CapacityStep: 10},
+			expected: 90,
+		},
+		{
+			name:     "valid default unchanged",
+			sku:      AiModelSku{DefaultCapacity: 30, MinCapacity: 10, MaxCapacity: 100, CapacityStep: 10},
+			expected: 30,
+		},
+		{
+			name:     "zero default without constraints unchanged",
+			sku:      AiModelSku{},
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, normalizeDefaultCapacity(tt.sku))
+		})
+	}
+}
+
 func TestResolveCapacity(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -733,6 +1098,70 @@ func TestResolveCapacityWithQuota(t *testing.T) {
 	})
 }
 
+func TestRecommendCapacity(t *testing.T) {
+	sku := AiModelSku{
+		UsageName:       "OpenAI.GlobalStandard.gpt-4o",
+		DefaultCapacity: 50,
+		MinCapacity:     10,
+		MaxCapacity:     1000,
+		CapacityStep:    10,
+	}
+
+	tests := []struct {
+		name            string
+		sku             AiModelSku
+		usage           *AiModelUsage
+		wantCapacity    int32
+		wantConstrained bool
+		wantRemaining   *float64
+	}{
+		{
+			name:         "no usage data uses default",
+			sku:          sku,
+			wantCapacity: 50,
+		},
+		{
+			name:          "default fits in remaining quota",
+			sku:           sku,
+			usage:         &AiModelUsage{Limit: 300, CurrentValue: 100},
+			wantCapacity:  50,
+			wantRemaining: new(float64(200)),
+		},
+		{
+			name:            "largest step-aligned value below remaining quota",
+			sku:             sku,
+			usage:           &AiModelUsage{Limit: 300, CurrentValue: 265},
+			wantCapacity:    30,
+			wantConstrained: true,
+			wantRemaining:   new(float64(35)),
+		},
+		{
+			name:            "nothing fits",
+			sku:             sku,
+			usage:           &AiModelUsage{Limit: 300, CurrentValue: 295},
+			wantCapacity:    0,
+			wantConstrained: true,
+			wantRemaining:   new(float64(5)),
+		},
+		{
+			name:          "no default capacity picks largest fitting value",
+			sku:           AiModelSku{MinCapacity: 10, MaxCapacity: 100, CapacityStep: 10},
+			usage:         &AiModelUsage{Limit: 300, CurrentValue: 0},
+			wantCapacity:  100,
+			wantRemaining: new(float64(300)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RecommendCapacity(tt.sku, tt.usage)
+			require.Equal(t, tt.wantCapacity, got.Capacity)
+			require.Equal(t, tt.wantConstrained, got.QuotaConstrained)
+			require.Equal(t, tt.wantRemaining, got.RemainingQuota)
+		})
+	}
+}
+
 func TestMaxModelRemainingQuota(t *testing.T) {
 	model := AiModel{
 		Name: "gpt-4o",
@@ -894,39 +1323,257 @@ func TestFilterModelsByAnyLocationQuota_EmptyUsagesDoNotLeakAcrossLocations(t *t
 	require.Equal(t, []string{"model-b"}, names)
 }
 
-func TestIsFinetuneUsageName(t *testing.T) {
-	tests := []struct {
-		usageName string
-		expected  bool
-	}{
-		{"OpenAI.Standard.gpt-4o", false},
-		{"OpenAI.GlobalStandard.gpt-4o", false},
-		{"OpenAI.Standard.gpt-4o-finetune", true},
-		{"OpenAI.GlobalStandard.gpt-4o-FINEtune", true},
-		{"OpenAI.ProvisionedManaged", false},
-		{"OpenAI.ProvisionedManaged-finetune", true},
-		{"", false},
+func TestSummarizeDeployableModels(t *testing.T) {
+	models := []AiModel{
+		{
+			Name:      "gpt-4o",
+			Format:    "OpenAI",
+			Locations: []string{"eastus", "swedencentral", "westus"},
+			Versions: []AiModelVersion{
+				{
+					Version: "2024-08-06",
+					Skus: []AiModelSku{
+						{
+							Name:            "GlobalStandard",
+							UsageName:       "OpenAI.GlobalStandard.gpt-4o",
+							DefaultCapacity: 10,
+							DeploymentKind:  SkuDeploymentKindGlobal,
+						},
+						{
+							Name:            "Standard",
+							UsageName:       "OpenAI.Standard.gpt-4o",
+							DefaultCapacity: 10,
+							DeploymentKind:  SkuDeploymentKindRegional,
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:      "text-embedding-3-small",
+			Format:    "OpenAI",
+			Locations: []string{"eastus"},
+			Versions: []AiModelVersion{
+				{
+					Version: "1",
+					Skus: []AiModelSku{
+						{
+							Name:            "Standard",
+							UsageName:       "OpenAI.Standard.text-embedding-3-small",
+							DefaultCapacity: 120,
+							DeploymentKind:  SkuDeploymentKindRegional,
+						},
+					},
+				},
+			},
+		},
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.usageName, func(t *testing.T) {
-			require.Equal(t, tt.expected, IsFinetuneUsageName(tt.usageName))
-		})
+	usagesByLocation := map[string][]AiModelUsage{
+		"eastus": {
+			{Name: "OpenAI.GlobalStandard.gpt-4o", CurrentValue: 0, Limit: 50},
+			{Name: "OpenAI.Standard.gpt-4o", CurrentValue: 0, Limit: 80},
+			// Remaining quota is below the default capacity.
+			{Name: "OpenAI.Standard.text-embedding-3-small", CurrentValue: 100, Limit: 200},
+		},
+		"swedencentral": {
+			{Name: "OpenAI.GlobalStandard.gpt-4o", CurrentValue: 0, Limit: 150},
+			{Name: "OpenAI.Standard.gpt-4o", CurrentValue: 75, Limit: 80},
+		},
+		// westus has no usage data (e.g. free-tier subscriptions).
+		"westus": {},
 	}
-}
 
-func TestModelHasQuota_EmptyUsages(t *testing.T) {
-	modelWithSkus := AiModel{
-		Name: "gpt-4o",
-		Versions: []AiModelVersion{
+	t.Run("all kinds", func(t *testing.T) {
+		summaries := summarizeDeployableModels(models, usagesByLocation, nil)
+
+		require.Equal(t, []DeployableModelSummary{
 			{
-				Version: "2024-05-13",
-				Skus:    []AiModelSku{{Name: "Standard", UsageName: "OpenAI.Standard.gpt-4o"}},
+				ModelName: "gpt-4o",
+				Format:    "OpenAI",
+				Locations: []ModelLocationQuota{
+					{Location: "eastus", MaxRemainingQuota: 80},
+					{Location: "swedencentral", MaxRemainingQuota: 150},
+					{Location: "westus", MaxRemainingQuota: QuotaRemainingUnknown},
+				},
+				MostAvailableLocation: "swedencentral",
 			},
-		},
-	}
-	modelNoSkus := AiModel{
-		Name:     "empty-model",
+		}, summaries)
+	})
+
+	t.Run("regional only", func(t *testing.T) {
+		summaries := summarizeDeployableModels(
+			models, usagesByLocation, []SkuDeploymentKind{SkuDeploymentKindRegional})
+
+		require.Len(t, summaries, 1)
+		require.Equal(t, []ModelLocationQuota{
+			{Location: "eastus", MaxRemainingQuota: 80},
+			{Location: "westus", MaxRemainingQuota: QuotaRemainingUnknown},
+		}, summaries[0].Locations)
+		require.Equal(t, "eastus", summaries[0].MostAvailableLocation)
+	})
+
+	t.Run("locations without usages are skipped", func(t *testing.T) {
+		summaries := summarizeDeployableModels(models, map[string][]AiModelUsage{
+			"westus": {},
+		}, nil)
+
+		require.Len(t, summaries, 1)
+		require.Equal(t, "westus", summaries[0].MostAvailableLocation)
+	})
+
+	t.Run("no deployable models", func(t *testing.T) {
+		summaries := summarizeDeployableModels(
+			models, usagesByLocation, []SkuDeploymentKind{SkuDeploymentKindProvisioned})
+
+		require.Empty(t, summaries)
+	})
+}
+
+func TestFilterUsagesByNamePrefixes(t *testing.T) {
+	usages := []AiModelUsage{
+		{Name: "OpenAI.Standard.gpt-4o"},
+		{Name: "AIServices.S0.AccountCount"},
+		{Name: "ContentSafety.S0.Calls"},
+	}
+
+	tests := []struct {
+		name     string
+		prefixes []string
+		expected []string
+	}{
+		{
+			"NoPrefixes",
+			nil,
+			[]string{"OpenAI.Standard.gpt-4o", "AIServices.S0.AccountCount", "ContentSafety.S0.Calls"},
+		},
+		{"SinglePrefix", []string{"OpenAI."}, []string{"OpenAI.Standard.gpt-4o"}},
+		{
+			"MultiplePrefixes",
+			[]string{"OpenAI.", "AIServices."},
+			[]string{"OpenAI.Standard.gpt-4o", "AIServices.S0.AccountCount"},
+		},
+		{"NoMatch", []string{"Speech."}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names := []string{}
+			for _, usage := range FilterUsagesByNamePrefixes(usages, tt.prefixes) {
+				names = append(names, usage.Name)
+			}
+			require.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestHasAccountQuota(t *testing.T) {
+	aiServicesUsageName := AccountCountUsageNameForKind("AIServices")
+	tests := []struct {
+		name     string
+		usageMap map[string]AiModelUsage
+		minimum  float64
+		expected bool
+	}{
+		{"EmptyUsages", map[string]AiModelUsage{}, 1, true},
+		{
+			"MissingAccountUsage",
+			map[string]AiModelUsage{"OpenAI.Standard.gpt-4o": {Name: "OpenAI.Standard.gpt-4o", Limit: 10}},
+			1,
+			false,
+		},
+		{
+			"SufficientHeadroom",
+			map[string]AiModelUsage{AccountCountUsageName: {Name: AccountCountUsageName, CurrentValue: 28, Limit: 30}},
+			2,
+			true,
+		},
+		{
+			"InsufficientHeadroom",
+			map[string]AiModelUsage{AccountCountUsageName: {Name: AccountCountUsageName, CurrentValue: 29, Limit: 30}},
+			2,
+			false,
+		},
+		{
+			"OtherKindHeadroomIgnored",
+			map[string]AiModelUsage{aiServicesUsageName: {Name: aiServicesUsageName, CurrentValue: 0, Limit: 30}},
+			1,
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, HasAccountQuota(tt.usageMap, AccountCountUsageName, tt.minimum))
+		})
+	}
+}
+
+func TestAccountCountUsageNameForKind(t *testing.T) {
+	require.Equal(t, "OpenAI.S0.AccountCount", AccountCountUsageNameForKind("OpenAI"))
+	require.Equal(t, "AIServices.S0.AccountCount", AccountCountUsageNameForKind("AIServices"))
+	require.Equal(t, "AIServices.S0.AccountCount", AccountCountUsageNameForKind("aiservices"))
+	require.Equal(t, AccountCountUsageName, AccountCountUsageNameForKind(""))
+	require.Equal(t, AccountCountUsageName, AccountCountUsageNameForKind("SpeechServices"))
+}
+
+func TestModelFamily(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"gpt-4o", "gpt-4"},
+		{"gpt-4o-mini", "gpt-4"},
+		{"gpt-4", "gpt-4"},
+		{"gpt-4.1-nano", "gpt-4"},
+		{"gpt-35-turbo", "gpt-35"},
+		{"o3-mini", "o3"},
+		{"text-embedding-3-small", "text-embedding-3"},
+		{"Phi-3-mini-4k-instruct", "Phi-3"},
+		{"whisper", "whisper"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, ModelFamily(tt.name))
+		})
+	}
+}
+
+func TestIsFinetuneUsageName(t *testing.T) {
+	tests := []struct {
+		usageName string
+		expected  bool
+	}{
+		{"OpenAI.Standard.gpt-4o", false},
+		{"OpenAI.GlobalStandard.gpt-4o", false},
+		{"OpenAI.Standard.gpt-4o-finetune", true},
+		{"OpenAI.GlobalStandard.gpt-4o-FINEtune", true},
+		{"OpenAI.ProvisionedManaged", false},
+		{"OpenAI.ProvisionedManaged-finetune", true},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.usageName, func(t *testing.T) {
+			require.Equal(t, tt.expected, IsFinetuneUsageName(tt.usageName))
+		})
+	}
+}
+
+func TestModelHasQuota_EmptyUsages(t *testing.T) {
+	modelWithSkus := AiModel{
+		Name: "gpt-4o",
+		Versions: []AiModelVersion{
+			{
+				Version: "2024-05-13",
+				Skus:    []AiModelSku{{Name: "Standard", UsageName: "OpenAI.Standard.gpt-4o"}},
+			},
+		},
+	}
+	modelNoSkus := AiModel{
+		Name:     "empty-model",
 		Versions: []AiModelVersion{{Version: "1.0"}},
 	}
 
@@ -965,3 +1612,604 @@ func TestMaxModelRemainingQuota_EmptyUsages(t *testing.T) {
 	_, found = maxModelRemainingQuota(modelNoSkus, emptyUsages)
 	require.False(t, found)
 }
+
+func TestJoinLocationMetadata(t *testing.T) {
+	metadata := []account.Location{
+		{Name: "eastus", DisplayName: "East US", RegionalDisplayName: "(US) East US"},
+		{Name: "swedencentral", DisplayName: "Sweden Central"},
+	}
+
+	got := joinLocationMetadata([]string{"EastUS", "swedencentral", "newregion"}, metadata)
+
+	require.Equal(t, []account.Location{
+		{Name: "EastUS", DisplayName: "East US", RegionalDisplayName: "(US) East US"},
+		{Name: "swedencentral", DisplayName: "Sweden Central", RegionalDisplayName: "Sweden Central"},
+		{Name: "newregion", DisplayName: "newregion", RegionalDisplayName: "newregion"},
+	}, got)
+
+	require.Empty(t, joinLocationMetadata(nil, metadata))
+
+	paired := joinLocationMetadata(
+		[]string{"eastus"}, []account.Location{{Name: "eastus", PairedRegions: []string{"westus"}}})
+	require.Equal(t, []string{"westus"}, paired[0].PairedRegions)
+}
+
+func TestAggregateRegionPairs(t *testing.T) {
+	metadata := []account.Location{
+		{Name: "eastus", PairedRegions: []string{"westus"}},
+		{Name: "westus", PairedRegions: []string{"eastus"}},
+		{Name: "swedencentral", PairedRegions: []string{"swedensouth"}},
+		{Name: "northeurope", PairedRegions: []string{"westeurope"}},
+	}
+
+	tests := []struct {
+		name      string
+		locations []ModelLocationQuota
+		metadata  []account.Location
+		want      []RegionPairQuota
+	}{
+		{
+			name: "sums paired regions",
+			locations: []ModelLocationQuota{
+				{Location: "westus", MaxRemainingQuota: 15},
+				{Location: "eastus", MaxRemainingQuota: 25},
+			},
+			metadata: metadata,
+			want:     []RegionPairQuota{{Locations: []string{"eastus", "westus"}, CombinedRemainingQuota: 40}},
+		},
+		{
+			name: "unmatched partner is reported alone",
+			locations: []ModelLocationQuota{
+				{Location: "eastus", MaxRemainingQuota: 25},
+				{Location: "swedencentral", MaxRemainingQuota: 10},
+			},
+			metadata: metadata,
+			want: []RegionPairQuota{
+				{Locations: []string{"eastus"}, CombinedRemainingQuota: 25},
+				{Locations: []string{"swedencentral"}, CombinedRemainingQuota: 10},
+			},
+		},
+		{
+			name: "pairing is symmetric",
+			locations: []ModelLocationQuota{
+				{Location: "westeurope", MaxRemainingQuota: 5},
+				{Location: "northeurope", MaxRemainingQuota: 7},
+			},
+			metadata: metadata,
+			want:     []RegionPairQuota{{Locations: []string{"northeurope", "westeurope"}, CombinedRemainingQuota: 12}},
+		},
+		{
+			name: "unknown quota makes the pair unknown",
+			locations: []ModelLocationQuota{
+				{Location: "eastus", MaxRemainingQuota: 25},
+				{Location: "westus", MaxRemainingQuota: QuotaRemainingUnknown},
+			},
+			metadata: metadata,
+			want: []RegionPairQuota{
+				{Locations: []string{"eastus", "westus"}, CombinedRemainingQuota: QuotaRemainingUnknown},
+			},
+		},
+		{
+			name: "without metadata",
+			locations: []ModelLocationQuota{
+				{Location: "eastus", MaxRemainingQuota: 25},
+				{Location: "westus", MaxRemainingQuota: 15},
+			},
+			want: []RegionPairQuota{
+				{Locations: []string{"eastus"}, CombinedRemainingQuota: 25},
+				{Locations: []string{"westus"}, CombinedRemainingQuota: 15},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, aggregateRegionPairs(tt.locations, tt.metadata))
+		})
+	}
+}
+
+func TestLocationGeoCluster(t *testing.T) {
+	tests := []struct {
+		regionalDisplayName string
+		expected            string
+	}{
+		{"(US) East US 2", "US"},
+		{"(Asia Pacific) Japan East", "Asia Pacific"},
+		{"(Europe) Sweden Central", "Europe"},
+		{"Sweden Central", ""},
+		{"(Unterminated", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.regionalDisplayName, func(t *testing.T) {
+			require.Equal(t, tt.expected, LocationGeoCluster(account.Location{RegionalDisplayName: tt.regionalDisplayName}))
+		})
+	}
+}
+
+func TestSortLocationsByProximity(t *testing.T) {
+	locations := func() []account.Location {
+		return []account.Location{
+			{Name: "australiaeast", RegionalDisplayName: "(Asia Pacific) Australia East"},
+			{Name: "eastus", RegionalDisplayName: "(US) East US"},
+			{Name: "newregion", RegionalDisplayName: "newregion"},
+			{Name: "swedencentral", RegionalDisplayName: "(Europe) Sweden Central"},
+			{Name: "westeurope", RegionalDisplayName: "(Europe) West Europe"},
+			{Name: "westus", RegionalDisplayName: "(US) West US"},
+		}
+	}
+	names := func(locations []account.Location) []string {
+		result := make([]string, len(locations))
+		for i, location := range locations {
+			result[i] = location.Name
+		}
+		return result
+	}
+
+	tests := []struct {
+		name         string
+		homeLocation string
+		expected     []string
+	}{
+		{
+			name:         "NoHome",
+			homeLocation: "",
+			expected:     []string{"australiaeast", "eastus", "newregion", "swedencentral", "westeurope", "westus"},
+		},
+		{
+			name:         "HomeInEurope",
+			homeLocation: "westeurope",
+			expected:     []string{"westeurope", "swedencentral", "australiaeast", "eastus", "newregion", "westus"},
+		},
+		{
+			name:         "HomeCaseInsensitive",
+			homeLocation: "WestUS",
+			expected:     []string{"westus", "eastus", "australiaeast", "newregion", "swedencentral", "westeurope"},
+		},
+		{
+			name:         "HomeWithoutGeography",
+			homeLocation: "newregion",
+			expected:     []string{"newregion", "australiaeast", "eastus", "swedencentral", "westeurope", "westus"},
+		},
+		{
+			name:         "HomeNotAChoice",
+			homeLocation: "northeurope",
+			expected:     []string{"australiaeast", "eastus", "newregion", "swedencentral", "westeurope", "westus"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := locations()
+			SortLocationsByProximity(got, tt.homeLocation)
+			require.Equal(t, tt.expected, names(got))
+		})
+	}
+}
+
+func TestSplitModelLocations(t *testing.T) {
+	tests := []struct {
+		name            string
+		modelLocations  []string
+		allowed         []string
+		wantOffered     []string
+		wantUnavailable []string
+	}{
+		{
+			name:            "no allow-list evaluates all model locations",
+			modelLocations:  []string{"eastus", "westus"},
+			wantOffered:     []string{"eastus", "westus"},
+			wantUnavailable: []string{},
+		},
+		{
+			name:            "allow-list separates locations without the model",
+			modelLocations:  []string{"eastus", "westus", "swedencentral"},
+			allowed:         []string{"westus3", "eastus", "brazilsouth", "westus3"},
+			wantOffered:     []string{"eastus"},
+			wantUnavailable: []string{"brazilsouth", "westus3"},
+		},
+		{
+			name:            "allow-list entries that are not AI Services locations are reported",
+			modelLocations:  []string{"eastus2", "swedencentral"},
+			allowed:         []string{"eastus2euap", "swedencentral"},
+			wantOffered:     []string{"swedencentral"},
+			wantUnavailable: []string{"eastus2euap"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offered, unavailable := splitModelLocations(tt.modelLocations, tt.allowed)
+			require.Equal(t, tt.wantOffered, offered)
+			require.Equal(t, tt.wantUnavailable, unavailable)
+		})
+	}
+}
+
+func TestMeetsQuotaRequirements(t *testing.T) {
+	usage := func(name string, current, limit float64) *armcognitiveservices.Usage {
+		return &armcognitiveservices.Usage{
+			Name:         &armcognitiveservices.MetricName{Value: &name},
+			CurrentValue: &current,
+			Limit:        &limit,
+		}
+	}
+
+	usages := []*armcognitiveservices.Usage{
+		usage("OpenAI.Standard.gpt-4o", 90, 100),
+		usage(AccountCountUsageName, 29, 30),
+	}
+
+	tests := []struct {
+		name         string
+		usages       []*armcognitiveservices.Usage
+		requirements []QuotaRequirement
+		expected     bool
+	}{
+		{
+			name:   "all requirements met",
+			usages: usages,
+			requirements: []QuotaRequirement{
+				{UsageName: "OpenAI.Standard.gpt-4o", MinCapacity: 10},
+				{UsageName: AccountCountUsageName},
+			},
+			expected: true,
+		},
+		{
+			name:         "insufficient capacity",
+			usages:       usages,
+			requirements: []QuotaRequirement{{UsageName: "OpenAI.Standard.gpt-4o", MinCapacity: 11}},
+			expected:     false,
+		},
+		{
+			name:         "missing usage",
+			usages:       usages,
+			requirements: []QuotaRequirement{{UsageName: "OpenAI.Standard.gpt-4o-mini"}},
+			expected:     false,
+		},
+		{
+			name:         "empty usages treated as available",
+			usages:       nil,
+			requirements: []QuotaRequirement{{UsageName: "OpenAI.Standard.gpt-4o", MinCapacity: 10}},
+			expected:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, meetsQuotaRequirements(tt.usages, tt.requirements))
+		})
+	}
+}
+
+func TestModelLocationHasQuota(t *testing.T) {
+	model := AiModel{
+		Name: "gpt-4o",
+		Versions: []AiModelVersion{
+			{
+				Version: "2024-08-06",
+				Skus:    []AiModelSku{{Name: "Standard", UsageName: "OpenAI.Standard.gpt-4o"}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		usages          []AiModelUsage
+		minAccountQuota float64
+		expectedOk      bool
+		expectedMax     float64
+	}{
+		{
+			name:        "sufficient quota",
+			usages:      []AiModelUsage{{Name: "OpenAI.Standard.gpt-4o", CurrentValue: 10, Limit: 100}},
+			expectedOk:  true,
+			expectedMax: 90,
+		},
+		{
+			name:       "insufficient quota",
+			usages:     []AiModelUsage{{Name: "OpenAI.Standard.gpt-4o", CurrentValue: 100, Limit: 100}},
+			expectedOk: false,
+		},
+		{
+			name: "account quota exhausted",
+			usages: []AiModelUsage{
+				{Name: "OpenAI.Standard.gpt-4o", CurrentValue: 10, Limit: 100},
+				{Name: AccountCountUsageName, CurrentValue: 30, Limit: 30},
+			},
+			minAccountQuota: 1,
+			expectedOk:      false,
+		},
+		{
+			name:        "no usage data",
+			usages:      nil,
+			expectedOk:  true,
+			expectedMax: QuotaRemainingUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maxRemaining, required, ok := modelLocationHasQuota(
+				model, tt.usages, 1, AccountCountUsageName, tt.minAccountQuota)
+			require.Equal(t, tt.expectedOk, ok)
+			if ok {
+				require.Equal(t, tt.expectedMax, maxRemaining)
+				require.Equal(t, float64(1), required)
+			}
+		})
+	}
+}
+
+func TestModelLocationHasQuota_ProvisionedStep(t *testing.T) {
+	model := AiModel{
+		Name: "gpt-4o",
+		Versions: []AiModelVersion{
+			{
+				Version: "2024-08-06",
+				Skus: []AiModelSku{
+					{
+						Name:           "ProvisionedManaged",
+						UsageName:      "OpenAI.ProvisionedManaged.gpt-4o",
+						MinCapacity:    50,
+						CapacityStep:   50,
+						DeploymentKind: SkuDeploymentKindProvisioned,
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		remaining        float64
+		minRemaining     float64
+		expectedOk       bool
+		expectedRequired float64
+	}{
+		{
+			name:         "non-aligned request rounds up past remaining quota",
+			remaining:    120,
+			minRemaining: 110,
+			expectedOk:   false,
+		},
+		{
+			name:             "non-aligned request rounds up within remaining quota",
+			remaining:        150,
+			minRemaining:     110,
+			expectedOk:       true,
+			expectedRequired: 150,
+		},
+		{
+			name:             "aligned request",
+			remaining:        100,
+			minRemaining:     100,
+			expectedOk:       true,
+			expectedRequired: 100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usages := []AiModelUsage{{Name: "OpenAI.ProvisionedManaged.gpt-4o", Limit: tt.remaining}}
+			_, required, ok := modelLocationHasQuota(model, usages, tt.minRemaining, AccountCountUsageName, 0)
+			require.Equal(t, tt.expectedOk, ok)
+			if ok {
+				require.Equal(t, tt.expectedRequired, required)
+			}
+		})
+	}
+}
+
+func TestRequiredQuotaForSku(t *testing.T) {
+	provisioned := AiModelSku{
+		Name:           "GlobalProvisionedManaged",
+		CapacityStep:   50,
+		DeploymentKind: SkuDeploymentKindProvisioned,
+	}
+	standard := AiModelSku{Name: "Standard", CapacityStep: 10, DeploymentKind: SkuDeploymentKindRegional}
+
+	require.Equal(t, float64(100), requiredQuotaForSku(provisioned, 100))
+	require.Equal(t, float64(150), requiredQuotaForSku(provisioned, 101))
+	require.Equal(t, float64(50), requiredQuotaForSku(provisioned, 1))
+	require.Equal(t, float64(15), requiredQuotaForSku(standard, 15))
+	require.Equal(t, float64(15), requiredQuotaForSku(AiModelSku{DeploymentKind: SkuDeploymentKindProvisioned}, 15))
+}
+
+func TestWithQuotaProgress(t *testing.T) {
+	var reported []QuotaProgress
+	config := newQuotaCheckConfig([]QuotaCheckOption{
+		WithQuotaProgress(func(progress QuotaProgress) {
+			reported = append(reported, progress)
+		}),
+	})
+
+	config.report(QuotaProgress{Checked: 1, Matched: 1, Total: 2})
+	config.report(QuotaProgress{Checked: 2, Matched: 1, Total: 2})
+	require.Equal(t, []QuotaProgress{
+		{Checked: 1, Matched: 1, Total: 2},
+		{Checked: 2, Matched: 1, Total: 2},
+	}, reported)
+
+	// Without a callback, reporting is a no-op.
+	newQuotaCheckConfig(nil).report(QuotaProgress{Checked: 1, Total: 1})
+}
+
+func TestPreferDeploymentsWithQuota(t *testing.T) {
+	deployment := func(sku string, capacity int32) AiModelDeployment {
+		return AiModelDeployment{
+			ModelName: "gpt-4o",
+			Sku:       AiModelSku{Name: sku, UsageName: "OpenAI." + sku + ".gpt-4o"},
+			Capacity:  capacity,
+		}
+	}
+	skuNames := func(deployments []AiModelDeployment) []string {
+		names := make([]string, len(deployments))
+		for i, d := range deployments {
+			names[i] = d.Sku.Name
+		}
+		return names
+	}
+
+	tests := []struct {
+		name          string
+		deployments   []AiModelDeployment
+		preferredSkus []string
+		usageMap      map[string]AiModelUsage
+		expected      []string
+	}{
+		{
+			name:          "preference order without usage data",
+			deployments:   []AiModelDeployment{deployment("Standard", 10), deployment("GlobalStandard", 10)},
+			preferredSkus: []string{"GlobalStandard", "Standard"},
+			expected:      []string{"GlobalStandard", "Standard"},
+		},
+		{
+			name:          "exhausted preferred SKU moves last",
+			deployments:   []AiModelDeployment{deployment("GlobalStandard", 10), deployment("Standard", 10)},
+			preferredSkus: []string{"GlobalStandard", "Standard"},
+			usageMap: map[string]AiModelUsage{
+				"OpenAI.GlobalStandard.gpt-4o": {Name: "OpenAI.GlobalStandard.gpt-4o", CurrentValue: 45, Limit: 50},
+				"OpenAI.Standard.gpt-4o":       {Name: "OpenAI.Standard.gpt-4o", CurrentValue: 0, Limit: 50},
+			},
+			expected: []string{"Standard", "GlobalStandard"},
+		},
+		{
+			name:          "unlisted SKUs follow listed ones",
+			deployments:   []AiModelDeployment{deployment("DataZoneStandard", 10), deployment("Standard", 10)},
+			preferredSkus: []string{"Standard"},
+			expected:      []string{"Standard", "DataZoneStandard"},
+		},
+		{
+			name:        "SKU without usage data is treated as having quota",
+			deployments: []AiModelDeployment{deployment("GlobalStandard", 10), deployment("Standard", 10)},
+			usageMap: map[string]AiModelUsage{
+				"OpenAI.GlobalStandard.gpt-4o": {Name: "OpenAI.GlobalStandard.gpt-4o", CurrentValue: 50, Limit: 50},
+			},
+			expected: []string{"Standard", "GlobalStandard"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preferDeploymentsWithQuota(tt.deployments, tt.preferredSkus, tt.usageMap)
+			require.Equal(t, tt.expected, skuNames(tt.deployments))
+
+			for _, d := range tt.deployments {
+				_, hasUsage := tt.usageMap[d.Sku.UsageName]
+				require.Equal(t, hasUsage, d.RemainingQuota != nil)
+			}
+		})
+	}
+}
+
+func TestSuggestAlternativeLocations(t *testing.T) {
+	t.Run("most remaining quota", func(t *testing.T) {
+		result := &ModelLocationQuotaResult{
+			Locations: []ModelLocationQuota{
+				{Location: "eastus2", MaxRemainingQuota: 40},
+				{Location: "swedencentral", MaxRemainingQuota: 120},
+				{Location: "westus", MaxRemainingQuota: QuotaRemainingUnknown},
+			},
+			ModelUnavailable:  []string{"brazilsouth"},
+			InsufficientQuota: []string{"eastus"},
+		}
+
+		require.Equal(t, map[string]string{
+			"brazilsouth": "swedencentral",
+			"eastus":      "swedencentral",
+		}, suggestAlternativeLocations(result))
+	})
+
+	t.Run("ties prefer first by name", func(t *testing.T) {
+		result := &ModelLocationQuotaResult{
+			Locations: []ModelLocationQuota{
+				{Location: "eastus2", MaxRemainingQuota: 40},
+				{Location: "westus", MaxRemainingQuota: 40},
+			},
+			InsufficientQuota: []string{"eastus"},
+		}
+
+		require.Equal(t, map[string]string{"eastus": "eastus2"}, suggestAlternativeLocations(result))
+	})
+
+	t.Run("no matched locations", func(t *testing.T) {
+		result := &ModelLocationQuotaResult{InsufficientQuota: []string{"eastus"}}
+		require.Nil(t, suggestAlternativeLocations(result))
+	})
+
+	t.Run("no unmatched locations", func(t *testing.T) {
+		result := &ModelLocationQuotaResult{Locations: []ModelLocationQuota{{Location: "eastus", MaxRemainingQuota: 10}}}
+		require.Nil(t, suggestAlternativeLocations(result))
+	})
+}
+
+func TestModelLocationQuotaHeadroom(t *testing.T) {
+	tests := []struct {
+		name            string
+		quota           ModelLocationQuota
+		wantHeadroom    float64
+		wantUtilization float64
+		wantOk          bool
+	}{
+		{
+			name:            "evaluated",
+			quota:           ModelLocationQuota{Location: "eastus", MaxRemainingQuota: 200, RequiredQuota: 50},
+			wantHeadroom:    150,
+			wantUtilization: 25,
+			wantOk:          true,
+		},
+		{
+			name:            "exactly sufficient",
+			quota:           ModelLocationQuota{Location: "eastus", MaxRemainingQuota: 50, RequiredQuota: 50},
+			wantHeadroom:    0,
+			wantUtilization: 100,
+			wantOk:          true,
+		},
+		{
+			name:  "usage unknown",
+			quota: ModelLocationQuota{Location: "eastus", MaxRemainingQuota: QuotaRemainingUnknown, RequiredQuota: 50},
+		},
+		{
+			name:  "not evaluated against a minimum",
+			quota: ModelLocationQuota{Location: "eastus", MaxRemainingQuota: 200},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headroom, utilization, ok := tt.quota.Headroom()
+			require.Equal(t, tt.wantOk, ok)
+			require.Equal(t, tt.wantHeadroom, headroom)
+			require.Equal(t, tt.wantUtilization, utilization)
+		})
+	}
+}
+
+func TestFilterUsagesWithLimit(t *testing.T) {
+	usages := []AiModelUsage{
+		{Name: "OpenAI.Standard.gpt-4o", CurrentValue: 10, Limit: 100},
+		{Name: "OpenAI.ProvisionedManaged.gpt-4o", Limit: 0},
+		{Name: "OpenAI.GlobalStandard.gpt-4o", CurrentValue: 0, Limit: 50},
+		// Exhausted meters still have a limit and are kept.
+		{Name: AccountCountUsageName, CurrentValue: 30, Limit: 30},
+		{Name: "OpenAI.DataZoneStandard.gpt-4o", CurrentValue: 0, Limit: 0},
+	}
+
+	var names []string
+	for _, usage := range FilterUsagesWithLimit(usages) {
+		names = append(names, usage.Name)
+	}
+	require.Equal(t, []string{
+		"OpenAI.Standard.gpt-4o",
+		"OpenAI.GlobalStandard.gpt-4o",
+		AccountCountUsageName,
+	}, names)
+
+	// The input is not modified.
+	require.Len(t, usages, 5)
+	require.Equal(t, "OpenAI.ProvisionedManaged.gpt-4o", usages[1].Name)
+	require.Empty(t, FilterUsagesWithLimit(nil))
+}
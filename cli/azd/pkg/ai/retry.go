@@ -0,0 +1,61 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ai
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+)
+
+// CatalogRetriesConfigPath is the user config path of how many times fetching a location's model catalog is retried,
+// set with `azd config set ai.catalogRetries 5`.
+const CatalogRetriesConfigPath = "ai.catalogRetries"
+
+// DefaultCatalogRetries is how many times fetching a location's model catalog is retried after a transient failure.
+// It matches the default of the ARM pipeline retry policy.
+const DefaultCatalogRetries = 3
+
+// ConfiguredCatalogRetries returns the catalog retry count set by the user config at CatalogRetriesConfigPath, or
+// DefaultCatalogRetries when it is unset or not a non-negative integer. userConfig may be nil.
+func ConfiguredCatalogRetries(userConfig config.Config) int {
+	if userConfig == nil {
+		return DefaultCatalogRetries
+	}
+
+	value, ok := userConfig.Get(CatalogRetriesConfigPath)
+	if !ok {
+		return DefaultCatalogRetries
+	}
+
+	// `azd config set` stores a string; a number edited into config.json is accepted too.
+	retries, err := strconv.Atoi(fmt.Sprint(value))
+	if err != nil || retries < 0 {
+		log.Printf("ignoring invalid %s value %v", CatalogRetriesConfigPath, value)
+		return DefaultCatalogRetries
+	}
+
+	return retries
+}
+
+// withCatalogRetry returns a context that makes the ARM pipeline retry a catalog request catalogRetries times. The
+// pipeline already retries throttling, timeouts, server errors and connection failures with exponential backoff, so
+// only its retry count is tuned here.
+func withCatalogRetry(ctx context.Context, catalogRetries int, retryDelay time.Duration) context.Context {
+	// A zero MaxRetries means the pipeline default; a negative one disables retries.
+	maxRetries := int32(catalogRetries)
+	if maxRetries == 0 {
+		maxRetries = -1
+	}
+
+	return policy.WithRetryOptions(ctx, policy.RetryOptions{
+		MaxRetries: maxRetries,
+		RetryDelay: retryDelay,
+	})
+}
@@ -0,0 +1,110 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ai
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cognitiveservices/armcognitiveservices/v2"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfiguredCatalogRetries(t *testing.T) {
+	tests := []struct {
+		name       string
+		userConfig config.Config
+		want       int
+	}{
+		{"NoConfig", nil, DefaultCatalogRetries},
+		{"Unset", config.NewEmptyConfig(), DefaultCatalogRetries},
+		{"String", config.NewConfig(map[string]any{"ai": map[string]any{"catalogRetries": "5"}}), 5},
+		{"Number", config.NewConfig(map[string]any{"ai": map[string]any{"catalogRetries": float64(1)}}), 1},
+		{"Zero", config.NewConfig(map[string]any{"ai": map[string]any{"catalogRetries": "0"}}), 0},
+		{
+			"Negative",
+			config.NewConfig(map[string]any{"ai": map[string]any{"catalogRetries": "-1"}}),
+			DefaultCatalogRetries,
+		},
+		{
+			"NotANumber",
+			config.NewConfig(map[string]any{"ai": map[string]any{"catalogRetries": "many"}}),
+			DefaultCatalogRetries,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, ConfiguredCatalogRetries(tt.userConfig))
+		})
+	}
+}
+
+func TestAiModelService_FetchModelsForLocations_RetriesTransientFailure(t *testing.T) {
+	tests := []struct {
+		name            string
+		retries         int
+		wantEastus      bool
+		wantEastusCalls int
+	}{
+		{"Retried", DefaultCatalogRetries, true, 2},
+		{"RetriesDisabled", 0, false, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtx := mocks.NewMockContext(t.Context())
+			svc := newQuotaTestService(t, mockCtx, nil)
+			svc.SetCatalogRetries(tt.retries)
+			svc.retryDelay = time.Millisecond
+
+			var mu sync.Mutex
+			requests := map[string]int{}
+			mockCtx.HttpClient.When(func(req *http.Request) bool {
+				return req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, "/models")
+			}).RespondFn(func(req *http.Request) (*http.Response, error) {
+				location := strings.Split(strings.Split(req.URL.Path, "/locations/")[1], "/")[0]
+				mu.Lock()
+				requests[location]++
+				attempt := requests[location]
+				mu.Unlock()
+
+				switch {
+				case location == "eastus" && attempt == 1:
+					return mocks.CreateEmptyHttpResponse(req, http.StatusServiceUnavailable)
+				case location == "westus":
+					return mocks.CreateEmptyHttpResponse(req, http.StatusForbidden)
+				}
+
+				return mocks.CreateHttpResponseWithBody(req, http.StatusOK, armcognitiveservices.ModelListResult{
+					Value: []*armcognitiveservices.Model{
+						sampleModel("gpt-4o", "2024-08-06", "Standard", "OpenAI.Standard.gpt-4o", true),
+					},
+				})
+			})
+
+			result, failedLocations, err := svc.fetchModelsForLocations(
+				*mockCtx.Context, "sub-1", []string{"eastus", "westus"})
+
+			// westus failed with a permanent error and is never retried.
+			require.Equal(t, 1, requests["westus"])
+			require.Equal(t, tt.wantEastusCalls, requests["eastus"])
+			if !tt.wantEastus {
+				// Both locations failed, so there is no catalog to report on.
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, result["eastus"], 1)
+			require.Len(t, failedLocations, 1)
+			require.Equal(t, "westus", failedLocations[0].Location)
+		})
+	}
+}
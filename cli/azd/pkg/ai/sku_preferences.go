@@ -0,0 +1,88 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ai
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+)
+
+// PreferredSkusConfigPath is the user config path of the preferred deployment SKU order, set with
+// `azd config set ai.preferredSkus DataZoneStandard,GlobalStandard`.
+const PreferredSkusConfigPath = "ai.preferredSkus"
+
+// defaultPreferredSkus is the SKU order used when neither the project nor the user config sets one.
+var defaultPreferredSkus = []string{"GlobalStandard", "Standard"}
+
+// ConfiguredPreferredSkus returns the preferred deployment SKU order set by the project (preferredAiSkus in
+// azure.yaml) or, failing that, by the user config at PreferredSkusConfigPath. It returns nil when neither sets one.
+// userConfig may be nil.
+func ConfiguredPreferredSkus(projectSkus []string, userConfig config.Config) []string {
+	if skus := normalizeSkuNames(projectSkus); len(skus) > 0 {
+		return skus
+	}
+
+	if userConfig == nil {
+		return nil
+	}
+
+	// `azd config set` stores a comma-separated string; a list edited into config.json is accepted too.
+	var skus []string
+	if value, ok := userConfig.GetString(PreferredSkusConfigPath); ok {
+		skus = strings.Split(value, ",")
+	} else if values, ok := userConfig.GetSlice(PreferredSkusConfigPath); ok {
+		for _, value := range values {
+			if name, ok := value.(string); ok {
+				skus = append(skus, name)
+			}
+		}
+	}
+
+	return normalizeSkuNames(skus)
+}
+
+// PreferredSkus returns the configured preferred deployment SKU order, falling back to GlobalStandard then Standard.
+func PreferredSkus(projectSkus []string, userConfig config.Config) []string {
+	if skus := ConfiguredPreferredSkus(projectSkus, userConfig); len(skus) > 0 {
+		return skus
+	}
+
+	return slices.Clone(defaultPreferredSkus)
+}
+
+// skuPreference returns the rank of skuName in preferredSkus; SKUs not listed rank after all listed ones.
+func skuPreference(preferredSkus []string, skuName string) int {
+	if idx := slices.IndexFunc(preferredSkus, func(preferred string) bool {
+		return strings.EqualFold(preferred, skuName)
+	}); idx >= 0 {
+		return idx
+	}
+
+	return len(preferredSkus)
+}
+
+// SortSkusByPreference stably orders items by the rank of their SKU name in preferredSkus, keeping SKUs that are not
+// listed after the listed ones in their original order.
+func SortSkusByPreference[T any](items []T, preferredSkus []string, skuName func(T) string) {
+	slices.SortStableFunc(items, func(a, b T) int {
+		return skuPreference(preferredSkus, skuName(a)) - skuPreference(preferredSkus, skuName(b))
+	})
+}
+
+// normalizeSkuNames trims the names, dropping empty and repeated ones.
+func normalizeSkuNames(names []string) []string {
+	var normalized []string
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name != "" && !slices.ContainsFunc(normalized, func(existing string) bool {
+			return strings.EqualFold(existing, name)
+		}) {
+			normalized = append(normalized, name)
+		}
+	}
+
+	return normalized
+}
@@ -0,0 +1,73 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ai
+
+import (
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfiguredPreferredSkus(t *testing.T) {
+	tests := []struct {
+		name        string
+		projectSkus []string
+		userConfig  config.Config
+		want        []string
+	}{
+		{
+			name: "nothing configured",
+			want: nil,
+		},
+		{
+			name:       "empty user config",
+			userConfig: config.NewEmptyConfig(),
+			want:       nil,
+		},
+		{
+			name:        "project wins over user config",
+			projectSkus: []string{"DataZoneStandard"},
+			userConfig:  config.NewConfig(map[string]any{"ai": map[string]any{"preferredSkus": "Standard"}}),
+			want:        []string{"DataZoneStandard"},
+		},
+		{
+			name: "comma-separated user config",
+			userConfig: config.NewConfig(map[string]any{
+				"ai": map[string]any{"preferredSkus": " DataZoneStandard, ,Standard"},
+			}),
+			want: []string{"DataZoneStandard", "Standard"},
+		},
+		{
+			name: "user config list",
+			userConfig: config.NewConfig(map[string]any{
+				"ai": map[string]any{"preferredSkus": []any{"GlobalStandard", "globalstandard", "Standard"}},
+			}),
+			want: []string{"GlobalStandard", "Standard"},
+		},
+		{
+			name:        "blank project entries fall through",
+			projectSkus: []string{" "},
+			userConfig:  config.NewConfig(map[string]any{"ai": map[string]any{"preferredSkus": "Standard"}}),
+			want:        []string{"Standard"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, ConfiguredPreferredSkus(tt.projectSkus, tt.userConfig))
+		})
+	}
+}
+
+func TestPreferredSkus(t *testing.T) {
+	require.Equal(t, []string{"GlobalStandard", "Standard"}, PreferredSkus(nil, nil))
+	require.Equal(t, []string{"DataZoneStandard"}, PreferredSkus([]string{"DataZoneStandard"}, nil))
+}
+
+func TestSortSkusByPreference(t *testing.T) {
+	skus := []string{"Standard", "ProvisionedManaged", "GlobalStandard", "DataZoneStandard"}
+	SortSkusByPreference(skus, []string{"datazonestandard", "GlobalStandard"}, func(sku string) string { return sku })
+	require.Equal(t, []string{"DataZoneStandard", "GlobalStandard", "Standard", "ProvisionedManaged"}, skus)
+}
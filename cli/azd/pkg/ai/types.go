@@ -3,7 +3,12 @@
 
 package ai
 
-import "strings"
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
 
 // IsFinetuneUsageName reports whether the given usage name represents a fine-tune SKU.
 // Fine-tune usage names end with "-finetune" (case-insensitive).
@@ -20,16 +25,20 @@ func IsFinetuneUsageName(usageName string) bool {
 type AiModel struct {
 	// Name is the model name, e.g. "gpt-4o".
 	Name string
-	// Format is the model format, e.g. "OpenAI".
+	// Format is the model format, e.g. "OpenAI". When the model is offered in several formats, this is the first of
+	// them in sorted order; see ModelFormats.
 	Format string
 	// Deprecated: Use AiModelVersion.LifecycleStatus instead. Always empty ("").
 	LifecycleStatus string
-	// Capabilities lists the model's capabilities, e.g. ["chat", "embeddings"].
+	// Capabilities lists the model's capabilities, e.g. ["chat", "embeddings"]: those of any of its versions.
 	Capabilities []string
 	// Versions lists the available versions of this model.
 	Versions []AiModelVersion
 	// Locations lists the Azure locations where this model is available.
 	Locations []string
+	// Family is the derived model family used to group related models, e.g. "gpt-4" for
+	// "gpt-4o", "gpt-4o-mini", and "gpt-4". See ModelFamily.
+	Family string
 }
 
 // AiModelVersion represents a specific version of an AI model.
@@ -40,7 +49,16 @@ type AiModelVersion struct {
 	IsDefault bool
 	// LifecycleStatus is the lifecycle status for this specific version.
 	LifecycleStatus string
-	// Skus lists the available SKUs for this version.
+	// Format is the model format of this version, e.g. "OpenAI". A version offered in several formats has one
+	// entry per format. Empty means AiModel.Format.
+	Format string
+	// Capabilities lists the capabilities of this version, e.g. ["chatCompletion"]. Versions of one model can differ,
+	// so a model-level capability does not guarantee every version has it.
+	Capabilities []string
+	// CapabilityValues holds the values of the capabilities of this version, e.g. {"maxContextToken": "128000"}.
+	CapabilityValues map[string]string
+	// Skus lists the available SKUs for this version. It is never empty in catalog results: versions without
+	// deployable SKUs are dropped during aggregation.
 	Skus []AiModelSku
 }
 
@@ -48,8 +66,9 @@ type AiModelVersion struct {
 type AiModelSku struct {
 	// Name is the SKU name, e.g. "GlobalStandard", "Standard".
 	Name string
-	// UsageName is the quota usage name used to join with usage/quota data,
-	// e.g. "OpenAI.Standard.gpt-4o".
+	// UsageName is the full usage meter name used to join with usage/quota data,
+	// e.g. "OpenAI.Standard.gpt-4o". It already includes the model name and matches
+	// AiModelUsage.Name without further qualification.
 	UsageName string
 	// DefaultCapacity is the suggested deployment capacity (0 if unavailable).
 	DefaultCapacity int32
@@ -59,6 +78,67 @@ type AiModelSku struct {
 	MaxCapacity int32
 	// CapacityStep is the capacity increment granularity.
 	CapacityStep int32
+	// DeploymentKind classifies how deployments of this SKU are routed and billed, derived from Name.
+	DeploymentKind SkuDeploymentKind
+}
+
+// SkuDeploymentKind classifies a deployment SKU by where requests are processed and how capacity is billed.
+type SkuDeploymentKind string
+
+const (
+	// SkuDeploymentKindUnknown is used for SKU names that do not match a known deployment kind.
+	SkuDeploymentKindUnknown SkuDeploymentKind = ""
+	// SkuDeploymentKindGlobal SKUs route requests to any Azure region, e.g. "GlobalStandard", "GlobalBatch".
+	SkuDeploymentKindGlobal SkuDeploymentKind = "Global"
+	// SkuDeploymentKindDataZone SKUs route requests within a data zone such as US or EU, e.g. "DataZoneStandard".
+	SkuDeploymentKindDataZone SkuDeploymentKind = "DataZone"
+	// SkuDeploymentKindRegional SKUs process requests in the deployment region, e.g. "Standard".
+	SkuDeploymentKindRegional SkuDeploymentKind = "Regional"
+	// SkuDeploymentKindProvisioned SKUs reserve dedicated throughput, e.g. "ProvisionedManaged",
+	// "GlobalProvisionedManaged".
+	SkuDeploymentKindProvisioned SkuDeploymentKind = "Provisioned"
+)
+
+// ModelIntent describes what a caller wants to use a model for, so callers can filter the catalog without
+// knowing the underlying capability names.
+type ModelIntent string
+
+const (
+	// ModelIntentAny applies no intent filtering.
+	ModelIntentAny ModelIntent = ""
+	// ModelIntentChat selects models that support chat completions, e.g. "gpt-4o".
+	ModelIntentChat ModelIntent = "chat"
+	// ModelIntentEmbeddings selects models that produce embeddings, e.g. "text-embedding-3-small".
+	ModelIntentEmbeddings ModelIntent = "embeddings"
+	// ModelIntentImageGeneration selects models that generate images, e.g. "dall-e-3".
+	ModelIntentImageGeneration ModelIntent = "imageGeneration"
+)
+
+// modelIntentCapabilities maps each intent to the model capability that satisfies it.
+var modelIntentCapabilities = map[ModelIntent]string{
+	ModelIntentChat:            "chatCompletion",
+	ModelIntentEmbeddings:      "embeddings",
+	ModelIntentImageGeneration: "imageGenerations",
+}
+
+// Validate returns an error when the intent is not one of the known ModelIntent values.
+func (i ModelIntent) Validate() error {
+	if _, has := modelIntentCapabilities[i]; i != ModelIntentAny && !has {
+		return fmt.Errorf("unknown model intent %q", i)
+	}
+
+	return nil
+}
+
+// Matches reports whether a model with the given capabilities satisfies the intent.
+// ModelIntentAny matches every model; unknown intents match none.
+func (i ModelIntent) Matches(capabilities []string) bool {
+	if i == ModelIntentAny {
+		return true
+	}
+
+	capability, has := modelIntentCapabilities[i]
+	return has && slices.Contains(capabilities, capability)
 }
 
 // AiModelDeployment is a fully resolved deployment configuration.
@@ -84,10 +164,56 @@ type AiModelDeployment struct {
 	// Resolved from: DeploymentOptions.Capacity → Sku.DefaultCapacity → 0 (caller must handle).
 	Capacity int32
 	// RemainingQuota is the subscription quota remaining at this location for this SKU.
-	// Only populated when a quota check is performed. nil means no quota check was done.
+	// Only populated when a quota check is performed or DeploymentOptions.PreferSkusWithQuota is set.
+	// nil means no quota check was done.
+	RemainingQuota *float64
+}
+
+// AiAccountDeployment is a model deployment that exists on a provisioned AI Services account.
+type AiAccountDeployment struct {
+	// Name is the deployment name, unique within the account.
+	Name string
+	// AccountName is the name of the AI Services account the deployment belongs to.
+	AccountName string
+	// Deployment is the deployed model, SKU and capacity. Location is the account location; the SKU only carries its
+	// name and deployment kind, and RemainingQuota is never populated.
+	Deployment AiModelDeployment
+	// ProvisioningState is the ARM provisioning state of the deployment, e.g. "Succeeded".
+	ProvisioningState string
+}
+
+// CapacityRecommendation is a suggested deployment capacity for a model SKU, informed by the remaining quota at a
+// location.
+type CapacityRecommendation struct {
+	// Capacity is the recommended deployment capacity in units. 0 when no valid capacity fits in the remaining quota,
+	// or when usage data is unavailable and the SKU has no default capacity.
+	Capacity int32
+	// QuotaConstrained is true when the SKU default capacity does not fit in the remaining quota, so Capacity was
+	// lowered to the largest step-aligned value that fits (or 0 when none fits).
+	QuotaConstrained bool
+	// RemainingQuota is the subscription quota remaining at the location for the SKU's usage name.
+	// nil means usage data was unavailable and Capacity is the SKU default.
 	RemainingQuota *float64
 }
 
+// DeploymentQuotaCheck reports whether a deployment of an exact model version, SKU and capacity fits in the quota
+// remaining at a location.
+type DeploymentQuotaCheck struct {
+	// Capacity is the capacity that was checked: the requested capacity, or the SKU default when none was requested.
+	Capacity int32
+	// CapacityValid is false when Capacity is outside the SKU's minimum and maximum or not aligned to its step.
+	CapacityValid bool
+	// Fits is true when Capacity is valid and fits in RemainingQuota. It is also true for a valid capacity when usage
+	// data is unavailable, consistent with the other quota checks.
+	Fits bool
+	// RemainingQuota is the subscription quota remaining at the location for the SKU's usage name.
+	// nil means usage data was unavailable.
+	RemainingQuota *float64
+	// AccountQuotaRemaining is the number of AI Services accounts that can still be created at the location.
+	// nil means account usage data was unavailable.
+	AccountQuotaRemaining *float64
+}
+
 // AiModelUsage represents a subscription-level quota/usage entry for a specific
 // model SKU at a location.
 type AiModelUsage struct {
@@ -107,6 +233,292 @@ type ModelLocationQuota struct {
 	// A value of QuotaRemainingUnknown (-1) indicates that usage data was unavailable
 	// (e.g. free-tier subscriptions) and the actual remaining quota is unknown.
 	MaxRemainingQuota float64
+	// RequiredQuota is the remaining quota the location had to provide when evaluated with a minimum: the minimum
+	// itself, or the minimum rounded up to the capacity step when only a provisioned SKU satisfied it.
+	// It is zero when the location was not evaluated against a minimum.
+	RequiredQuota float64
+}
+
+// Headroom returns the quota left at the location after a deployment that consumes RequiredQuota, and the percentage
+// of the remaining quota that deployment uses. ok is false when usage data was unavailable or the location was not
+// evaluated against a minimum.
+func (q ModelLocationQuota) Headroom() (headroom float64, utilizationPercent float64, ok bool) {
+	if q.RequiredQuota <= 0 || q.MaxRemainingQuota <= 0 {
+		return 0, 0, false
+	}
+
+	return q.MaxRemainingQuota - q.RequiredQuota, q.RequiredQuota / q.MaxRemainingQuota * 100, true
+}
+
+// LocationQuotaResult is the outcome of evaluating quota requirements across AI Services locations.
+type LocationQuotaResult struct {
+	// Locations are the AI Services locations that satisfy all quota requirements.
+	Locations []string
+	// UnsupportedLocations lists requested locations that are not AI Services locations.
+	// These locations are ignored and not evaluated for quota.
+	UnsupportedLocations []string
+	// FailedLocations lists the locations whose usages could not be fetched, sorted by location. A location that
+	// did not answer within the location timeout fails with ErrLocationTimeout.
+	FailedLocations []LocationError
+	// SkippedLocations lists the locations left unchecked by WithMaxLocations, sorted. When it is not empty the scan
+	// was partial, and locations with quota may be missing from Locations.
+	SkippedLocations []string
+}
+
+// ModelCatalogResult is the outcome of listing the model catalog across locations.
+type ModelCatalogResult struct {
+	// Models are the catalog models after applying filters.
+	Models []AiModel
+	// FailedLocations lists the locations whose catalog could not be fetched, sorted by location. Models offered
+	// only in those locations are missing from Models.
+	FailedLocations []LocationError
+	// AvailableCapabilities lists the capabilities offered by the models in the catalog, sorted, when the Capabilities
+	// filter alone excludes every model. It helps to correct the filter, e.g. "chatCompletion" rather than "chat".
+	AvailableCapabilities []string
+}
+
+// LocationError is an error that occurred while querying a single location.
+type LocationError struct {
+	Location string
+	Err      error
+	// Throttled is true when the location rejected the query because of rate limiting (HTTP 429), rather than
+	// failing outright. Retrying later may succeed, so the location should not be read as lacking quota.
+	Throttled bool
+}
+
+// ThrottledLocations returns the locations of failures that were throttled, in order.
+func ThrottledLocations(failures []LocationError) []string {
+	var locations []string
+	for _, failure := range failures {
+		if failure.Throttled {
+			locations = append(locations, failure.Location)
+		}
+	}
+
+	return locations
+}
+
+// ModelLocationQuotaResult is the outcome of evaluating a model's remaining quota across locations.
+type ModelLocationQuotaResult struct {
+	// Locations are the locations where the model is offered and has sufficient remaining quota.
+	Locations []ModelLocationQuota
+	// ModelUnavailable lists requested locations where the model is not offered.
+	// These locations are not evaluated for quota.
+	ModelUnavailable []string
+	// InsufficientQuota lists locations where the model is offered but remaining model or account quota is short.
+	InsufficientQuota []string
+	// FailedLocations lists the locations where the model is offered but usages could not be fetched, sorted by
+	// location. A location that did not answer within the location timeout fails with ErrLocationTimeout.
+	FailedLocations []LocationError
+	// SuggestedAlternatives maps each location in ModelUnavailable and InsufficientQuota to the matched location
+	// with the most remaining quota. Only populated with WithSuggestedAlternatives and when a location matched.
+	SuggestedAlternatives map[string]string
+	// RegionPairs groups Locations by Azure region pair with their combined remaining quota, e.g. eastus and westus.
+	// Only populated with WithRegionPairs.
+	RegionPairs []RegionPairQuota
+}
+
+// RegionPairQuota is the combined remaining quota of matched locations that form an Azure region pair.
+type RegionPairQuota struct {
+	// Locations are the matched locations of the pair, sorted. A matched location whose paired region did not match,
+	// or that has no paired region, is reported alone.
+	Locations []string
+	// CombinedRemainingQuota is the sum of MaxRemainingQuota across Locations, or QuotaRemainingUnknown when usage
+	// data was unavailable for any of them.
+	CombinedRemainingQuota float64
+}
+
+// QuotaProgress reports the progress of a quota evaluation across locations.
+type QuotaProgress struct {
+	// Checked is the number of locations evaluated so far.
+	Checked int
+	// Matched is the number of evaluated locations that satisfy the quota requirements.
+	Matched int
+	// Total is the number of locations being evaluated.
+	Total int
+}
+
+// QuotaCheckOption configures a quota evaluation across locations.
+type QuotaCheckOption func(*quotaCheckConfig)
+
+// DefaultLocationTimeout is how long a quota evaluation waits for a single location's usages.
+const DefaultLocationTimeout = 15 * time.Second
+
+type quotaCheckConfig struct {
+	onProgress          func(QuotaProgress)
+	suggestAlternatives bool
+	regionPairs         bool
+	locationTimeout     time.Duration
+	capabilities        []string
+	formats             []string
+	accountUsageName    string
+	maxLocations        int
+	preferredLocations  []string
+}
+
+// WithQuotaProgress registers fn to be called each time a location's evaluation completes. Calls are serialized.
+func WithQuotaProgress(fn func(QuotaProgress)) QuotaCheckOption {
+	return func(c *quotaCheckConfig) {
+		c.onProgress = fn
+	}
+}
+
+// WithSuggestedAlternatives makes EvaluateModelLocationsWithQuota suggest a matched location for each location that
+// was not matched.
+func WithSuggestedAlternatives() QuotaCheckOption {
+	return func(c *quotaCheckConfig) {
+		c.suggestAlternatives = true
+	}
+}
+
+// WithRegionPairs makes EvaluateModelLocationsWithQuota group the matched locations by Azure region pair and report
+// their combined remaining quota. Pairs come from the subscription's location metadata; without it, every location is
+// reported alone.
+func WithRegionPairs() QuotaCheckOption {
+	return func(c *quotaCheckConfig) {
+		c.regionPairs = true
+	}
+}
+
+// WithLocationTimeout bounds how long the evaluation waits for each location's usages, so one slow location does not
+// stall the whole evaluation. A location that does not answer in time is reported as failed. Defaults to
+// DefaultLocationTimeout; a timeout of 0 or less restores the default.
+func WithLocationTimeout(timeout time.Duration) QuotaCheckOption {
+	return func(c *quotaCheckConfig) {
+		c.locationTimeout = timeout
+	}
+}
+
+// WithRequiredCapabilities makes EvaluateModelLocationsWithQuota only match a location when a version of the model
+// offered there matches capabilities, including numeric predicates, as in FilterOptions.Capabilities. Only the SKUs
+// of such versions count toward the location's quota. Locations that only offer other versions are reported as
+// ModelUnavailable.
+func WithRequiredCapabilities(capabilities ...string) QuotaCheckOption {
+	return func(c *quotaCheckConfig) {
+		c.capabilities = capabilities
+	}
+}
+
+// WithFormats makes EvaluateModelLocationsWithQuota only match a location when a version of the model offered there
+// is in one of formats, e.g. "OpenAI", consistent with FilterOptions.Formats. Only the SKUs of such versions count
+// toward the location's quota, so a model offered in several formats is not matched on another format's quota.
+// Locations that only offer other formats are reported as ModelUnavailable.
+func WithFormats(formats ...string) QuotaCheckOption {
+	return func(c *quotaCheckConfig) {
+		c.formats = formats
+	}
+}
+
+// WithAccountKind sets the kind of the account to be created, e.g. "AIServices", so the account-count quota is
+// checked on the usage name that tracks that kind. See AccountCountUsageNameForKind. Defaults to
+// AccountCountUsageName.
+func WithAccountKind(kind string) QuotaCheckOption {
+	return func(c *quotaCheckConfig) {
+		c.accountUsageName = AccountCountUsageNameForKind(kind)
+	}
+}
+
+// WithMaxLocations makes EvaluateLocationsWithQuota check at most n locations, trading completeness for speed on
+// subscriptions with many AI Services locations. The preferred locations are checked first, in order, then the others
+// by name. Locations left out are reported as SkippedLocations. n of 0 or less checks every location.
+func WithMaxLocations(n int, preferred ...string) QuotaCheckOption {
+	return func(c *quotaCheckConfig) {
+		c.maxLocations = n
+		c.preferredLocations = preferred
+	}
+}
+
+func newQuotaCheckConfig(opts []QuotaCheckOption) *quotaCheckConfig {
+	config := &quotaCheckConfig{accountUsageName: AccountCountUsageName}
+	for _, opt := range opts {
+		opt(config)
+	}
+	if config.locationTimeout <= 0 {
+		config.locationTimeout = DefaultLocationTimeout
+	}
+	return config
+}
+
+// report invokes the progress callback, if any.
+func (c *quotaCheckConfig) report(progress QuotaProgress) {
+	if c.onProgress != nil {
+		c.onProgress(progress)
+	}
+}
+
+// DeployableModelSummary lists the locations where a model can be deployed right now.
+type DeployableModelSummary struct {
+	// ModelName is the model name, e.g. "gpt-4o".
+	ModelName string
+	// Format is the model format, e.g. "OpenAI".
+	Format string
+	// Locations are the locations where at least one SKU of the model has remaining quota for its default
+	// capacity, sorted by location name.
+	Locations []ModelLocationQuota
+	// MostAvailableLocation is the location with the highest remaining quota. Locations without usage data rank
+	// below locations with known quota; ties are broken by location name.
+	MostAvailableLocation string
+}
+
+// ModelReport describes, for one model across locations, where it is offered, which versions and SKUs are offered
+// at each location, and how much quota each SKU has left. The model catalog carries no pricing metadata, so the
+// report does not compare cost.
+type ModelReport struct {
+	// ModelName is the catalog name of the model, e.g. "gpt-4o".
+	ModelName string
+	// Locations are the locations that offer the model, sorted by location name.
+	Locations []ModelReportLocation
+	// UnavailableLocations are the checked locations that do not offer the model, sorted.
+	UnavailableLocations []string
+	// FailedLocations are the locations whose model catalog or usages could not be fetched, sorted by location, so
+	// the report may be incomplete. A location whose usages could not be fetched is still listed in Locations, with
+	// QuotaUnknown set, when its catalog offers the model.
+	FailedLocations []LocationError
+	// MostAvailableLocation is the location with the most remaining quota on a deployable SKU. Locations without
+	// usage data rank below locations with known quota; ties are broken by location name. It is empty when no SKU
+	// is deployable.
+	MostAvailableLocation string
+}
+
+// ModelReportLocation lists the versions of a model offered at a location, with the quota of their SKUs.
+type ModelReportLocation struct {
+	// Location is the Azure location name.
+	Location string
+	// Versions are the deployable versions offered at the location.
+	Versions []ModelReportVersion
+	// QuotaUnknown is true when the location returned no usage data, e.g. because the usage query failed or the
+	// subscription has no Cognitive Services quota there yet. RemainingQuota is then QuotaRemainingUnknown.
+	QuotaUnknown bool
+}
+
+// ModelReportVersion is a model version offered at a location.
+type ModelReportVersion struct {
+	// Version is the model version, e.g. "2024-08-06".
+	Version string
+	// IsDefault is true for the version ARM deploys when none is requested.
+	IsDefault bool
+	// LifecycleStatus is the version lifecycle status, e.g. "GenerallyAvailable".
+	LifecycleStatus string
+	// Skus are the SKUs the version can be deployed with at the location.
+	Skus []ModelReportSku
+}
+
+// ModelReportSku is a deployment SKU of a model version with the quota of its usage meter at a location.
+type ModelReportSku struct {
+	AiModelSku
+	// CurrentUsage is the quota the subscription already consumes on the SKU's usage meter.
+	CurrentUsage float64
+	// Limit is the quota limit of the SKU's usage meter.
+	Limit float64
+	// RemainingQuota is Limit minus CurrentUsage, at least 0, or QuotaRemainingUnknown when the location has no usage
+	// data. A SKU whose meter is missing from the location's usage data has no remaining quota.
+	RemainingQuota float64
+}
+
+// Deployable reports whether the remaining quota covers a deployment with the SKU's default capacity. A SKU at a
+// location without usage data is assumed to be deployable, like elsewhere in quota checks.
+func (s ModelReportSku) Deployable() bool {
+	return s.RemainingQuota == QuotaRemainingUnknown || s.RemainingQuota >= float64(max(s.DefaultCapacity, 1))
 }
 
 // QuotaRemainingUnknown is a sentinel value for MaxRemainingQuota indicating that
@@ -114,6 +526,31 @@ type ModelLocationQuota struct {
 // provisioned Cognitive Services resources) and the actual remaining quota is unknown.
 const QuotaRemainingUnknown float64 = -1
 
+// AccountCountUsageName is the usage name that tracks AI Services accounts at a location.
+// Each CognitiveServices/accounts resource consumes exactly one unit of this quota.
+// It is the account-count usage name of OpenAI accounts, and the default for other kinds.
+const AccountCountUsageName = "OpenAI.S0.AccountCount"
+
+// accountCountUsageNames maps lower-cased account kinds to the usage name that tracks their account count.
+var accountCountUsageNames = map[string]string{
+	"openai":     AccountCountUsageName,
+	"aiservices": "AIServices.S0.AccountCount",
+}
+
+// AccountCountUsageNameForKind returns the usage name that tracks accounts of the given kind, e.g. "AIServices".
+// Unknown and empty kinds return AccountCountUsageName.
+func AccountCountUsageNameForKind(kind string) string {
+	if usageName, ok := accountCountUsageNames[strings.ToLower(kind)]; ok {
+		return usageName
+	}
+
+	return AccountCountUsageName
+}
+
+// DefaultMinAccountQuota is the default remaining account-count quota required when an
+// account quota baseline is requested.
+const DefaultMinAccountQuota float64 = 1
+
 // QuotaRequirement specifies a single quota check: the usage name to check
 // and the minimum remaining capacity needed.
 type QuotaRequirement struct {
@@ -137,7 +574,10 @@ type QuotaCheckOptions struct {
 type FilterOptions struct {
 	// Locations filters to models available at these locations.
 	Locations []string
-	// Capabilities filters by model capabilities, e.g. ["chat", "embeddings"].
+	// Capabilities filters by model capabilities, e.g. ["chat", "embeddings"]. Models match when they have at least
+	// one of the capability names. Entries comparing a capability value with a number, e.g. "maxContextToken>=128000",
+	// must all hold instead, and keep only the versions that satisfy them. The operators are >=, <=, >, <, = (or ==)
+	// and !=.
 	Capabilities []string
 	// Formats filters by model format, e.g. ["OpenAI"].
 	Formats []string
@@ -149,8 +589,44 @@ type FilterOptions struct {
 	// inference endpoint has retired (deprecation.inference <= now) are always
 	// excluded, even when explicitly requested.
 	Statuses []string
+	// IncludeDeprecated keeps "Deprecating" and "Deprecated" versions in the default view when
+	// Statuses is unset. It has no effect when Statuses is set.
+	IncludeDeprecated bool
 	// ExcludeModelNames excludes models by name (for multi-model selection flows).
 	ExcludeModelNames []string
+	// Intent filters to models suitable for the given use, in addition to any Capabilities filter.
+	Intent ModelIntent
+	// MinAvailableCapacity keeps only SKUs with at least this much remaining quota at a location, dropping versions and
+	// locations left without SKUs. Setting it costs a usage lookup per location, and when Locations is set, only
+	// those locations are checked and kept. 0 disables the check.
+	MinAvailableCapacity float64
+	// DefaultVersionsOnly collapses each model to its default versions, or to its newest version when none is marked
+	// as default (see DefaultModelVersions). It is applied after the other version filters. Locations are not
+	// recomputed for the remaining versions.
+	DefaultVersionsOnly bool
+}
+
+// RegionPreference is an ordered list of acceptable locations for ResolveModelDeploymentsInRegions, e.g. a primary,
+// secondary and tertiary region.
+type RegionPreference struct {
+	// Locations are tried strictly in order. Duplicates, compared case-insensitively, are ignored.
+	Locations []string
+	// AllowFallback tries the other locations offering the model, in name order, when none of Locations yields a
+	// deployment. Defaults to false, so only Locations are considered.
+	AllowFallback bool
+}
+
+// RegionalDeployment is the outcome of ResolveModelDeploymentsInRegions.
+type RegionalDeployment struct {
+	// Location is the first location, in preference order, that yielded a deployment.
+	Location string
+	// Rank is the 1-based position of Location in the order locations were tried. Fallback locations rank after the
+	// preferred ones.
+	Rank int
+	// Fallback reports whether Location is a fallback location rather than one of the preferred locations.
+	Fallback bool
+	// Deployments are the deployment candidates at Location, as ResolveModelDeployments returns them.
+	Deployments []AiModelDeployment
 }
 
 // DeploymentOptions specifies preferences for resolving a model deployment.
@@ -160,6 +636,10 @@ type DeploymentOptions struct {
 	Locations []string
 	// Versions lists preferred versions. If empty, all versions are included.
 	Versions []string
+	// FallbackToDefaultVersion resolves the model's default version when none of the preferred Versions yields a
+	// deployment, e.g. because a pinned version was retired. The chosen version is reported on each result.
+	// Defaults to false (strict).
+	FallbackToDefaultVersion bool
 	// Skus lists preferred SKU names, e.g. ["GlobalStandard", "Standard"]. If empty, all SKUs are included.
 	Skus []string
 	// Capacity is the preferred deployment capacity. If set and valid
@@ -168,4 +648,14 @@ type DeploymentOptions struct {
 	// IncludeFinetuneSkus controls whether fine-tune SKUs (usage names ending with
 	// "-finetune") are included. Defaults to false (excluded).
 	IncludeFinetuneSkus bool
+	// PreferSkusWithQuota orders results by the preference order of Skus, then moves results whose SKU lacks
+	// remaining quota for the resolved capacity after the others, so the first result is deployable when the most
+	// preferred SKU is exhausted. It costs a usage lookup and requires exactly one location. It has no effect when
+	// quota checking is requested, which already excludes such results. Defaults to false.
+	PreferSkusWithQuota bool
+	// PreferStable moves results whose version is in "Preview" lifecycle status after the others, so automation
+	// picking the first result does not deploy a preview version when a generally available one is deployable,
+	// even if the preview version is the model's default. SKU and quota preferences still take precedence.
+	// Defaults to false.
+	PreferStable bool
 }
@@ -0,0 +1,140 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ai
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// CompareModelVersions compares two model versions, returning a negative number when a is older than b, a positive
+// number when it is newer and zero when they are equal.
+//
+// Versions are compared segment by segment, splitting on '.', '-' and '_', so both date versions ("2024-05-13") and
+// dotted-numeric versions ("1.10") order by value rather than lexically. Numeric segments compare as numbers and
+// other segments compare case-insensitively as text. A numeric segment is newer than a text one, so a release
+// ("1.0") is newer than its pre-release ("1.0-preview"), while an extra numeric segment makes a version newer
+// ("1.0.1" after "1.0").
+func CompareModelVersions(a string, b string) int {
+	aSegments := versionSegments(a)
+	bSegments := versionSegments(b)
+
+	for i := range min(len(aSegments), len(bSegments)) {
+		if n := compareVersionSegments(aSegments[i], bSegments[i]); n != 0 {
+			return n
+		}
+	}
+
+	switch {
+	case len(aSegments) > len(bSegments):
+		return extraSegmentsOrder(aSegments[len(bSegments)])
+	case len(aSegments) < len(bSegments):
+		return -extraSegmentsOrder(bSegments[len(aSegments)])
+	default:
+		return 0
+	}
+}
+
+// CompareModelVersionsNewestFirst orders model versions for selection: the default version comes first, then the
+// other versions from newest to oldest according to CompareModelVersions.
+func CompareModelVersionsNewestFirst(a string, aIsDefault bool, b string, bIsDefault bool) int {
+	if aIsDefault != bIsDefault {
+		if aIsDefault {
+			return -1
+		}
+		return 1
+	}
+
+	return CompareModelVersions(b, a)
+}
+
+// DefaultModelVersions returns the versions that a collapsed view of a model shows: per format, the ones marked as
+// default, or the newest version when none in that format is marked. A version without a format has modelFormat. The
+// order of versions is kept.
+func DefaultModelVersions(versions []AiModelVersion, modelFormat string) []AiModelVersion {
+	byFormat := map[string][]AiModelVersion{}
+	for _, version := range versions {
+		format := cmp.Or(version.Format, modelFormat)
+		byFormat[format] = append(byFormat[format], version)
+	}
+
+	shown := map[string]AiModelVersion{}
+	for format, formatVersions := range byFormat {
+		if !slices.ContainsFunc(formatVersions, func(version AiModelVersion) bool { return version.IsDefault }) {
+			shown[format] = slices.MaxFunc(formatVersions, func(a, b AiModelVersion) int {
+				return CompareModelVersions(a.Version, b.Version)
+			})
+		}
+	}
+
+	return slices.DeleteFunc(slices.Clone(versions), func(version AiModelVersion) bool {
+		if version.IsDefault {
+			return false
+		}
+		newest, ok := shown[cmp.Or(version.Format, modelFormat)]
+		return !ok || newest.Version != version.Version
+	})
+}
+
+// NonDefaultVersionWarning returns the warning shown when version of model is selected rather than its default
+// version, defaultVersion, or "" when version is the default or the model has no default version.
+func NonDefaultVersionWarning(model string, version string, defaultVersion string) string {
+	if defaultVersion == "" || version == defaultVersion {
+		return ""
+	}
+
+	return fmt.Sprintf("You selected %s version %s, but the default version is %s.", model, version, defaultVersion)
+}
+
+// versionSegments splits a version into its '.', '-' and '_' separated segments.
+func versionSegments(version string) []string {
+	return strings.FieldsFunc(version, func(r rune) bool {
+		return r == '.' || r == '-' || r == '_'
+	})
+}
+
+// compareVersionSegments compares two version segments: numbers by value, numbers above text, and text
+// case-insensitively.
+func compareVersionSegments(a string, b string) int {
+	aNumeric, bNumeric := isNumeric(a), isNumeric(b)
+	switch {
+	case aNumeric && bNumeric:
+		// Compare by length once leading zeros are dropped, so values of any size compare without overflow.
+		a, b = trimLeadingZeros(a), trimLeadingZeros(b)
+		if n := cmp.Compare(len(a), len(b)); n != 0 {
+			return n
+		}
+		return strings.Compare(a, b)
+	case aNumeric:
+		return 1
+	case bNumeric:
+		return -1
+	default:
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	}
+}
+
+// extraSegmentsOrder orders a version with more segments than an otherwise equal one: newer when the first extra
+// segment is numeric ("1.0.1"), older when it is text such as a pre-release tag ("1.0-preview").
+func extraSegmentsOrder(firstExtra string) int {
+	if isNumeric(firstExtra) {
+		return 1
+	}
+
+	return -1
+}
+
+func isNumeric(segment string) bool {
+	return segment != "" && strings.Trim(segment, "0123456789") == ""
+}
+
+func trimLeadingZeros(segment string) string {
+	if trimmed := strings.TrimLeft(segment, "0"); trimmed != "" {
+		return trimmed
+	}
+
+	return "0"
+}
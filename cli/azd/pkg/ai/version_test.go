@@ -0,0 +1,124 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ai
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareModelVersions(t *testing.T) {
+	tests := []struct {
+		name  string
+		older string
+		newer string
+	}{
+		{name: "dates", older: "2024-05-13", newer: "2024-08-06"},
+		{name: "dates across years", older: "2023-12-01", newer: "2024-01-15"},
+		{name: "dotted numeric", older: "1.9", newer: "1.10"},
+		{name: "single numbers", older: "2", newer: "10"},
+		{name: "leading zeros", older: "0301", newer: "0613"},
+		{name: "extra numeric segment", older: "1.0", newer: "1.0.1"},
+		{name: "release after pre-release", older: "1.0-preview", newer: "1.0"},
+		{name: "dated pre-release", older: "2024-05-01-preview", newer: "2024-05-01"},
+		{name: "text segments", older: "1-alpha", newer: "1-Beta"},
+		{name: "date after plain number", older: "1", newer: "2024-05-13"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Negative(t, CompareModelVersions(tt.older, tt.newer))
+			require.Positive(t, CompareModelVersions(tt.newer, tt.older))
+		})
+	}
+
+	require.Zero(t, CompareModelVersions("2024-05-13", "2024-05-13"))
+	require.Zero(t, CompareModelVersions("1.01", "1.1"))
+}
+
+func TestCompareModelVersionsNewestFirst(t *testing.T) {
+	versions := []AiModelVersion{
+		{Version: "1.9"},
+		{Version: "2024-05-13"},
+		{Version: "1.10", IsDefault: true},
+		{Version: "1.2"},
+		{Version: "2024-11-20"},
+		{Version: "1.10-preview"},
+	}
+
+	slices.SortStableFunc(versions, func(a, b AiModelVersion) int {
+		return CompareModelVersionsNewestFirst(a.Version, a.IsDefault, b.Version, b.IsDefault)
+	})
+
+	got := make([]string, len(versions))
+	for i, v := range versions {
+		got[i] = v.Version
+	}
+	require.Equal(t, []string{"1.10", "2024-11-20", "2024-05-13", "1.10-preview", "1.9", "1.2"}, got)
+}
+
+func TestDefaultModelVersions(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions []AiModelVersion
+		want     []string
+	}{
+		{
+			name: "DefaultOnly",
+			versions: []AiModelVersion{
+				{Version: "2024-05-13"}, {Version: "2024-08-06", IsDefault: true}, {Version: "2024-11-20"},
+			},
+			want: []string{"2024-08-06"},
+		},
+		{
+			name: "DefaultPerFormat",
+			versions: []AiModelVersion{
+				{Version: "2024-08-06", Format: "OpenAI", IsDefault: true},
+				{Version: "2024-11-20", Format: "OpenAI"},
+				{Version: "1", Format: "Microsoft", IsDefault: true},
+			},
+			want: []string{"2024-08-06", "1"},
+		},
+		{
+			name: "NewestInFormatWithoutDefault",
+			versions: []AiModelVersion{
+				{Version: "2024-05-13"},
+				{Version: "2024-08-06", IsDefault: true},
+				{Version: "2", Format: "Microsoft"},
+				{Version: "3", Format: "Microsoft"},
+				{Version: "2024-11-20", Format: "OpenAI"},
+			},
+			want: []string{"2024-08-06", "3"},
+		},
+		{
+			name:     "NewestWithoutDefault",
+			versions: []AiModelVersion{{Version: "1.9"}, {Version: "1.10"}, {Version: "1.2"}},
+			want:     []string{"1.10"},
+		},
+		{
+			name: "NoVersions",
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, v := range DefaultModelVersions(tt.versions, "OpenAI") {
+				got = append(got, v.Version)
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNonDefaultVersionWarning(t *testing.T) {
+	require.Equal(t,
+		"You selected gpt-4o version 2024-05-13, but the default version is 2024-08-06.",
+		NonDefaultVersionWarning("gpt-4o", "2024-05-13", "2024-08-06"))
+	require.Empty(t, NonDefaultVersionWarning("gpt-4o", "2024-08-06", "2024-08-06"))
+	require.Empty(t, NonDefaultVersionWarning("gpt-4o", "2024-05-13", ""))
+}
@@ -0,0 +1,115 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package apphost
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+)
+
+// maxParamResolutionDepth bounds how many references can be followed while resolving a single expression, so that
+// cyclic references (e.g. two parameters whose values reference each other) fail instead of recursing forever.
+const maxParamResolutionDepth = 32
+
+// ResolveDeploymentParams expands "{resource.property}" expressions found in the string values of params (including
+// strings nested in objects and arrays) against the resources of the manifest. It returns a new map and does not modify
+// params.
+//
+// Expressions whose values are known from the manifest are expanded:
+//   - {name.value} for parameter.v0 resources
+//   - {name.connectionString} for resources that declare a connection string
+//
+// Expressions whose values are only known at provisioning time are kept as-is, after checking that they reference
+// something the manifest declares:
+//   - {name.inputs.<input>} for declared inputs
+//   - {name.outputs.<output>} and {name.secretOutputs.<output>} for bicep resources
+//   - {name.bindings.<binding>.<property>} for declared bindings
+//
+// Any other expression, including references to resources not present in the manifest, returns an error.
+func ResolveDeploymentParams(m *Manifest, params map[string]any) (map[string]any, error) {
+	if params == nil {
+		return nil, nil
+	}
+
+	resolved := make(map[string]any, len(params))
+	for key, value := range params {
+		v, err := resolveParamValue(m, value)
+		if err != nil {
+			return nil, fmt.Errorf("resolving deployment parameter %q: %w", key, err)
+		}
+		resolved[key] = v
+	}
+
+	return resolved, nil
+}
+
+func resolveParamValue(m *Manifest, value any) (any, error) {
+	switch v := value.(type) {
+	case string:
+		return resolveParamString(m, v, 0)
+	case map[string]any:
+		resolved := maps.Clone(v)
+		for key, item := range v {
+			r, err := resolveParamValue(m, item)
+			if err != nil {
+				return nil, err
+			}
+			resolved[key] = r
+		}
+		return resolved, nil
+	case []any:
+		resolved := make([]any, len(v))
+		for i, item := range v {
+			r, err := resolveParamValue(m, item)
+			if err != nil {
+				return nil, err
+			}
+			resolved[i] = r
+		}
+		return resolved, nil
+	default:
+		return value, nil
+	}
+}
+
+func resolveParamString(m *Manifest, value string, depth int) (string, error) {
+	if depth > maxParamResolutionDepth {
+		return "", fmt.Errorf("exceeded maximum reference depth resolving %q, check for cyclic references", value)
+	}
+
+	return EvalString(value, func(expr string) (string, error) {
+		return resolveParamExpression(m, expr, depth)
+	})
+}
+
+func resolveParamExpression(m *Manifest, expr string, depth int) (string, error) {
+	resourceName, prop, _ := strings.Cut(expr, ".")
+	resource, has := m.Resources[resourceName]
+	if !has || resource == nil {
+		return "", fmt.Errorf("expression {%s} references unknown resource %q", expr, resourceName)
+	}
+
+	parts := strings.Split(prop, ".")
+	switch {
+	case prop == "value" && resource.Type == "parameter.v0":
+		return resolveParamString(m, resource.Value, depth+1)
+	case prop == "connectionString" && resource.ConnectionString != nil:
+		return resolveParamString(m, *resource.ConnectionString, depth+1)
+	case len(parts) == 2 && parts[0] == "inputs":
+		if _, has := resource.Inputs[parts[1]]; has {
+			return "", UnrecognizedExpressionError{}
+		}
+	case len(parts) == 2 && (parts[0] == "outputs" || parts[0] == "secretOutputs"):
+		if resource.Type == "azure.bicep.v0" || resource.Type == "azure.bicep.v1" {
+			return "", UnrecognizedExpressionError{}
+		}
+	case len(parts) == 3 && parts[0] == "bindings":
+		if _, has := resource.Bindings.Get(parts[1]); has {
+			return "", UnrecognizedExpressionError{}
+		}
+	}
+
+	return "", fmt.Errorf("expression {%s} cannot be resolved for resource %q of type %q", expr, resourceName, resource.Type)
+}
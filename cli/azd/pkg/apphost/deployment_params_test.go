@@ -0,0 +1,133 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package apphost
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const deploymentParamsManifest = `{
+  "resources": {
+    "region": {
+      "type": "parameter.v0",
+      "value": "{region.inputs.value}",
+      "inputs": { "value": { "type": "string" } }
+    },
+    "sku": {
+      "type": "parameter.v0",
+      "value": "Standard"
+    },
+    "tier": {
+      "type": "parameter.v0",
+      "value": "{sku.value}-tier"
+    },
+    "db": {
+      "type": "azure.bicep.v0",
+      "connectionString": "{db.outputs.connectionString}",
+      "path": "db.bicep"
+    },
+    "api": {
+      "type": "project.v1",
+      "path": "api.csproj",
+      "bindings": {
+        "http": { "scheme": "http", "protocol": "tcp", "transport": "http" }
+      }
+    },
+    "loopA": {
+      "type": "parameter.v0",
+      "value": "{loopB.value}"
+    },
+    "loopB": {
+      "type": "parameter.v0",
+      "value": "{loopA.value}"
+    }
+  }
+}`
+
+func TestResolveDeploymentParams(t *testing.T) {
+	var m Manifest
+	require.NoError(t, json.Unmarshal([]byte(deploymentParamsManifest), &m))
+
+	tests := []struct {
+		name    string
+		params  map[string]any
+		want    map[string]any
+		wantErr string
+	}{
+		{
+			name:   "parameter value",
+			params: map[string]any{"sku": "{sku.value}"},
+			want:   map[string]any{"sku": "Standard"},
+		},
+		{
+			name:   "nested parameter value",
+			params: map[string]any{"tier": "prefix-{tier.value}"},
+			want:   map[string]any{"tier": "prefix-Standard-tier"},
+		},
+		{
+			name:   "parameter backed by input is kept",
+			params: map[string]any{"location": "{region.value}"},
+			want:   map[string]any{"location": "{region.inputs.value}"},
+		},
+		{
+			name:   "connection string backed by outputs is kept",
+			params: map[string]any{"cs": "{db.connectionString}"},
+			want:   map[string]any{"cs": "{db.outputs.connectionString}"},
+		},
+		{
+			name:   "binding reference is kept",
+			params: map[string]any{"url": "{api.bindings.http.url}"},
+			want:   map[string]any{"url": "{api.bindings.http.url}"},
+		},
+		{
+			name: "objects, arrays and non-string values",
+			params: map[string]any{
+				"settings": map[string]any{"sku": "{sku.value}", "count": 3},
+				"tags":     []any{"{sku.value}", true},
+				"enabled":  false,
+			},
+			want: map[string]any{
+				"settings": map[string]any{"sku": "Standard", "count": 3},
+				"tags":     []any{"Standard", true},
+				"enabled":  false,
+			},
+		},
+		{
+			name:    "unknown resource",
+			params:  map[string]any{"x": "{missing.value}"},
+			wantErr: `unknown resource "missing"`,
+		},
+		{
+			name:    "unsupported property",
+			params:  map[string]any{"x": "{api.connectionString}"},
+			wantErr: `cannot be resolved for resource "api"`,
+		},
+		{
+			name:    "undeclared binding",
+			params:  map[string]any{"x": "{api.bindings.https.url}"},
+			wantErr: `cannot be resolved for resource "api"`,
+		},
+		{
+			name:    "cyclic reference",
+			params:  map[string]any{"x": "{loopA.value}"},
+			wantErr: "cyclic references",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveDeploymentParams(&m, tt.params)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -141,12 +141,13 @@ func Dockerfiles(manifest *Manifest) map[string]genDockerfile {
 		switch comp.Type {
 		case "dockerfile.v0":
 			res[name] = genDockerfile{
-				Path:      *comp.Path,
-				Context:   *comp.Context,
-				Env:       comp.Env,
-				Bindings:  comp.Bindings,
-				BuildArgs: comp.BuildArgs,
-				Args:      comp.Args,
+				Path:         *comp.Path,
+				Context:      *comp.Context,
+				Env:          comp.Env,
+				Bindings:     comp.Bindings,
+				BuildArgs:    comp.BuildArgs,
+				BuildSecrets: comp.BuildSecrets,
+				Args:         comp.Args,
 			}
 		}
 	}
@@ -1119,6 +1120,7 @@ func buildContainerFromResource(r *Resource) (*genBuildContainer, error) {
 		build = &genBuildContainerDetails{
 			Context: *r.Context,
 			Args:    nil, // dockerfile.v0 does not support build args, it only has top level args []string
+			Secrets: r.BuildSecrets,
 		}
 		if r.Path != nil {
 			build.Dockerfile = *r.Path
@@ -1133,6 +1135,7 @@ func buildContainerFromResource(r *Resource) (*genBuildContainer, error) {
 			Args:       r.Build.Args,
 			Secrets:    r.Build.Secrets,
 			BuildOnly:  r.Build.BuildOnly,
+			CacheFrom:  r.Build.CacheFrom,
 		}
 	}
 
@@ -5,6 +5,8 @@ package apphost
 
 import (
 	_ "embed"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io/fs"
 	"os"
@@ -12,6 +14,7 @@ import (
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -28,6 +31,9 @@ import (
 //go:embed testdata/aspire-docker.json
 var aspireDockerManifest []byte
 
+//go:embed testdata/aspire-docker-secrets.json
+var aspireDockerSecretsManifest []byte
+
 //go:embed testdata/aspire-args.json
 var aspireArgsManifest []byte
 
@@ -40,6 +46,12 @@ var aspireContainerManifest []byte
 //go:embed testdata/aspire-container-args.json
 var aspireContainerArgsManifest []byte
 
+//go:embed testdata/aspire-container-v1-mixed.json
+var aspireContainerV1MixedManifest []byte
+
+//go:embed testdata/aspire-container-v1-cache.json
+var aspireContainerV1CacheManifest []byte
+
 //go:embed testdata/aspire-projectv1.json
 var aspireProjectV1Manifet []byte
 
@@ -176,6 +188,32 @@ func TestAspireBicepGeneration(t *testing.T) {
 	}
 }
 
+func TestAspireDockerBuildSecrets(t *testing.T) {
+	ctx := t.Context()
+	mockCtx := mocks.NewMockContext(ctx)
+	mockPublishManifest(mockCtx, aspireDockerSecretsManifest, nil)
+	mockCli := dotnet.NewCli(mockCtx.CommandRunner)
+
+	m, err := ManifestFromAppHost(ctx, filepath.Join("testdata", "AspireDocker.AppHost.csproj"), mockCli, "")
+	require.NoError(t, err)
+
+	secrets := Dockerfiles(m)["nodeapp"].BuildSecrets
+	require.Len(t, secrets, 2)
+
+	require.Equal(t, "env", secrets["NPM_TOKEN"].Type)
+	require.NotNil(t, secrets["NPM_TOKEN"].Value)
+	require.Equal(t, "{npm-token.value}", *secrets["NPM_TOKEN"].Value)
+	require.Nil(t, secrets["NPM_TOKEN"].Source)
+
+	require.Equal(t, "file", secrets["NPMRC"].Type)
+	require.NotNil(t, secrets["NPMRC"].Source)
+	// relative sources are resolved against the directory the manifest was written to
+	source := *secrets["NPMRC"].Source
+	require.True(t, filepath.IsAbs(source))
+	require.Equal(t, ".npmrc", filepath.Base(source))
+	require.Equal(t, "NodeApp", filepath.Base(filepath.Dir(source)))
+}
+
 func TestAspireDockerGeneration(t *testing.T) {
 	if runtime.GOOS == "windows" {
 		t.Skip("Skipping due to EOL issues on Windows with the baselines")
@@ -351,6 +389,60 @@ func TestAspireContainerArgs(t *testing.T) {
 	}
 }
 
+func TestManifestFromAppHost_ContainerV1ImageAndBuild(t *testing.T) {
+	ctx := t.Context()
+	mockCtx := mocks.NewMockContext(ctx)
+	mockPublishManifest(mockCtx, aspireContainerV1MixedManifest, map[string]string{
+		"api.Dockerfile": "FROM mcr.microsoft.com/dotnet/aspnet:9.0\n",
+	})
+	mockCli := dotnet.NewCli(mockCtx.CommandRunner)
+
+	m, err := ManifestFromAppHost(ctx, filepath.Join("testdata", "AspireDocker.AppHost.csproj"), mockCli, "")
+	require.NoError(t, err)
+
+	// The image-only resource keeps its image reference and gets no build details.
+	cache := m.Resources["cache"]
+	require.Nil(t, cache.Build)
+	require.Equal(t, "docker.io/library/redis:7.4", *cache.Image)
+
+	// The build-based resource has its dockerfile copied into the manifest files.
+	api := m.Resources["api"]
+	require.NotNil(t, api.Build)
+	require.Nil(t, api.Image)
+	require.Equal(t, filepath.Join("api", "api.Dockerfile"), api.Build.Dockerfile)
+	require.True(t, filepath.IsAbs(api.Build.Context))
+
+	buildContainers, err := BuildContainers(m)
+	require.NoError(t, err)
+	require.Equal(t, "docker.io/library/redis:7.4", buildContainers["cache"].Image)
+	require.Nil(t, buildContainers["cache"].Build)
+	require.NotNil(t, buildContainers["api"].Build)
+	require.Empty(t, buildContainers["api"].Image)
+}
+
+func TestManifestFromAppHost_ContainerV1BuildCacheFrom(t *testing.T) {
+	ctx := t.Context()
+	mockCtx := mocks.NewMockContext(ctx)
+	mockPublishManifest(mockCtx, aspireContainerV1CacheManifest, map[string]string{
+		"api.Dockerfile":    "FROM mcr.microsoft.com/dotnet/aspnet:9.0\n",
+		"worker.Dockerfile": "FROM mcr.microsoft.com/dotnet/runtime:9.0\n",
+	})
+	mockCli := dotnet.NewCli(mockCtx.CommandRunner)
+
+	m, err := ManifestFromAppHost(ctx, filepath.Join("testdata", "AspireDocker.AppHost.csproj"), mockCli, "")
+	require.NoError(t, err)
+
+	// Cache hints are optional, and unknown build properties from newer manifests are ignored.
+	require.Equal(t, []string{"type=registry,ref=contoso.azurecr.io/api:buildcache"}, m.Resources["api"].Build.CacheFrom)
+	require.Nil(t, m.Resources["worker"].Build.CacheFrom)
+
+	buildContainers, err := BuildContainers(m)
+	require.NoError(t, err)
+	require.Equal(t,
+		[]string{"type=registry,ref=contoso.azurecr.io/api:buildcache"}, buildContainers["api"].Build.CacheFrom)
+	require.Empty(t, buildContainers["worker"].Build.CacheFrom)
+}
+
 func TestEvaluateForOutputs(t *testing.T) {
 	value := "{resource.outputs.output1} and {resource.secretOutputs.output2}"
 
@@ -938,3 +1030,88 @@ func TestInfraGenerator_IgnoreUnsupportedEnvVar(t *testing.T) {
 	}}
 	require.NoError(t, g.LoadManifest(m))
 }
+
+func TestManifestFromAppHost_GeneratedFor(t *testing.T) {
+	ctx := t.Context()
+	mockCtx := mocks.NewMockContext(ctx)
+	mockPublishManifest(mockCtx, aspireArgsManifest, nil)
+	mockCli := dotnet.NewCli(mockCtx.CommandRunner)
+
+	m, err := ManifestFromAppHost(ctx, filepath.Join("testdata", "AspireArgs.AppHost.csproj"), mockCli, "Staging")
+	require.NoError(t, err)
+	require.Equal(t, ManifestGeneratedFor{DotnetEnv: "Staging"}, m.GeneratedFor)
+}
+
+func TestManifestFromAppHost_RetriesTransientFailures(t *testing.T) {
+	manifestPublishRetryDelay = time.Millisecond
+	t.Cleanup(func() { manifestPublishRetryDelay = 2 * time.Second })
+
+	tests := []struct {
+		name      string
+		failure   string
+		failures  int
+		wantErr   bool
+		wantCalls int
+	}{
+		{"TransientThenSuccess", "error NU1301: Unable to load the service index for source", 1, false, 2},
+		{"FileLockedThenSuccess", "cannot access the file because it is being used by another process", 1, false, 2},
+		{"TransientExhaustsRetries", "error NU1301: Unable to load the service index for source", 5, true, 3},
+		{"NonTransientFailsFast", "error CS1002: ; expected", 1, true, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			mockCtx := mocks.NewMockContext(ctx)
+			calls := 0
+			mockCtx.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+				return args.Cmd == "dotnet" && args.Args[0] == "run" && args.Args[3] == "--publisher"
+			}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+				calls++
+				if calls <= tt.failures {
+					return exec.RunResult{ExitCode: 1, Stderr: tt.failure}, errors.New(tt.failure)
+				}
+				return exec.RunResult{}, os.WriteFile(args.Args[6], aspireArgsManifest, osutil.PermissionFile)
+			})
+
+			_, err := ManifestFromAppHost(
+				ctx, filepath.Join("testdata", "AspireArgs.AppHost.csproj"), dotnet.NewCli(mockCtx.CommandRunner), "")
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.wantCalls, calls)
+		})
+	}
+}
+
+func TestManifestPublishRetries(t *testing.T) {
+	require.Equal(t, uint64(defaultManifestPublishRetries), manifestPublishRetries())
+
+	t.Setenv("AZD_DOTNET_APPHOST_MANIFEST_RETRIES", "0")
+	require.Equal(t, uint64(0), manifestPublishRetries())
+
+	t.Setenv("AZD_DOTNET_APPHOST_MANIFEST_RETRIES", "not-a-number")
+	require.Equal(t, uint64(defaultManifestPublishRetries), manifestPublishRetries())
+}
+
+func TestManifestFromAppHost_RejectsOutOfTreeBicep(t *testing.T) {
+	outOfTree := filepath.Join(t.TempDir(), "secrets.bicep")
+	require.NoError(t, os.WriteFile(outOfTree, []byte("param secret string"), osutil.PermissionFile))
+
+	manifest, err := json.Marshal(map[string]any{
+		"resources": map[string]any{
+			"storage": map[string]any{"type": "azure.bicep.v0", "path": outOfTree},
+		},
+	})
+	require.NoError(t, err)
+
+	ctx := t.Context()
+	mockCtx := mocks.NewMockContext(ctx)
+	mockPublishManifest(mockCtx, manifest, nil)
+
+	_, err = ManifestFromAppHost(
+		ctx, filepath.Join("testdata", "AspireDocker.AppHost.csproj"), dotnet.NewCli(mockCtx.CommandRunner), "")
+	require.ErrorIs(t, err, errBicepOutsideRoots)
+}
@@ -58,6 +58,7 @@ type genDockerfile struct {
 	Env              map[string]string
 	Bindings         custommaps.WithOrder[Binding]
 	BuildArgs        map[string]string
+	BuildSecrets     map[string]ContainerV1BuildSecrets
 	Args             []string
 	DeploymentParams map[string]any
 	DeploymentSource string
@@ -83,6 +84,7 @@ type genBuildContainerDetails struct {
 	Args       map[string]string
 	Secrets    map[string]ContainerV1BuildSecrets
 	BuildOnly  bool
+	CacheFrom  []string
 }
 
 type genProject struct {
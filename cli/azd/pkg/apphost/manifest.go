@@ -6,17 +6,21 @@ package apphost
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/custommaps"
 	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/dotnet"
 	"github.com/psanford/memfs"
+	"github.com/sethvargo/go-retry"
 )
 
 // apphostPublishMode describes the way a manifest is intended to be published, either by delegating infrastructure
@@ -40,6 +44,14 @@ type Manifest struct {
 	Files *memfs.FS `json:"-"`
 	// publish mode intention from the manifest
 	publishMode apphostPublishMode `json:"-"`
+	// GeneratedFor records the inputs the manifest was generated with.
+	GeneratedFor ManifestGeneratedFor `json:"-"`
+}
+
+// ManifestGeneratedFor describes the environment an app host manifest was generated for.
+type ManifestGeneratedFor struct {
+	// DotnetEnv is the value of DOTNET_ENVIRONMENT used when running the app host, empty when unset.
+	DotnetEnv string
 }
 
 func (m *Manifest) Warnings() string {
@@ -89,6 +101,9 @@ type Resource struct {
 	// BuildArgs is present on a dockerfile.v0 resource and is the --build-arg for building the docker image.
 	BuildArgs map[string]string `json:"buildArgs,omitempty"`
 
+	// BuildSecrets is present on a dockerfile.v0 resource and are the secrets to use when building the docker image.
+	BuildSecrets map[string]ContainerV1BuildSecrets `json:"buildSecrets,omitempty"`
+
 	// Args is optionally present on project.v0 and dockerfile.v0 resources and are the arguments to pass to the container.
 	Args []string `json:"args,omitempty"`
 
@@ -198,6 +213,11 @@ type ContainerV1Build struct {
 
 	// If true, only build the image and tag it, but this should not be deployed as a running container.
 	BuildOnly bool `json:"buildOnly,omitempty"`
+
+	// CacheFrom is optionally present and lists external cache sources for the build, passed to docker build as
+	// --cache-from, e.g. "type=registry,ref=myregistry.azurecr.io/api:buildcache". Absent when the AppHost does not
+	// emit cache hints.
+	CacheFrom []string `json:"cacheFrom,omitempty"`
 }
 
 type ContainerV1BuildSecrets struct {
@@ -276,6 +296,148 @@ type InputDefault struct {
 	Value    *string               `json:"value,omitempty"`
 }
 
+// transientPublishErrorSignatures are fragments of `dotnet run --publisher manifest` failures that are known to be
+// transient, such as a NuGet feed being temporarily unreachable or build outputs being locked by another process.
+var transientPublishErrorSignatures = []string{
+	"NU1301",                        // Unable to load the service index for source
+	"MSB3026",                       // Could not copy file, beginning retry
+	"MSB3027",                       // Could not copy file, exceeded retry count
+	"being used by another process", // File locked, typically by an antivirus scan or a parallel build on Windows
+}
+
+// defaultManifestPublishRetries is the number of times a transient manifest publish failure is retried when
+// AZD_DOTNET_APPHOST_MANIFEST_RETRIES is not set.
+const defaultManifestPublishRetries = 2
+
+// manifestPublishRetryDelay is the delay between manifest publish attempts.
+var manifestPublishRetryDelay = 2 * time.Second
+
+// manifestPublishRetries returns the number of retries for transient manifest publish failures, which can be overridden
+// with AZD_DOTNET_APPHOST_MANIFEST_RETRIES.
+func manifestPublishRetries() uint64 {
+	if value, has := os.LookupEnv("AZD_DOTNET_APPHOST_MANIFEST_RETRIES"); has {
+		retries, err := strconv.ParseUint(value, 10, 64)
+		if err == nil {
+			return retries
+		}
+
+		log.Printf("ignoring invalid AZD_DOTNET_APPHOST_MANIFEST_RETRIES value %q: %v", value, err)
+	}
+
+	return defaultManifestPublishRetries
+}
+
+// isTransientPublishError reports whether err matches one of the known transient manifest publish failures.
+func isTransientPublishError(err error) bool {
+	msg := err.Error()
+	for _, signature := range transientPublishErrorSignatures {
+		if strings.Contains(msg, signature) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// publishAppHostManifest runs PublishAppHostManifest, retrying failures that match a known transient signature.
+// Any other failure (compile errors, a missing project, etc.) is returned without retrying.
+func publishAppHostManifest(
+	ctx context.Context, appHostProject string, manifestPath string, dotnetCli *dotnet.Cli, dotnetEnv string,
+) error {
+	maxRetries := manifestPublishRetries()
+	attempt := 0
+
+	return retry.Do(
+		ctx,
+		retry.WithMaxRetries(maxRetries, retry.NewConstant(manifestPublishRetryDelay)),
+		func(ctx context.Context) error {
+			attempt++
+			err := dotnetCli.PublishAppHostManifest(ctx, appHostProject, manifestPath, dotnetEnv)
+			if err == nil {
+				return nil
+			}
+
+			if !isTransientPublishError(err) {
+				return err
+			}
+
+			if uint64(attempt) <= maxRetries {
+				log.Printf(
+					"transient failure generating app host manifest (attempt %d of %d), retrying: %v",
+					attempt, maxRetries+1, err)
+			}
+
+			return retry.RetryableError(err)
+		},
+	)
+}
+
+// manifestCleanupTimeout bounds how long removing the temp manifest directory keeps retrying transient file locks.
+var manifestCleanupTimeout = 5 * time.Second
+
+// removeManifestDir removes the temp directory the manifest was published to. It runs even when ctx is cancelled,
+// and on Windows retries briefly while a file in the directory is still held open, e.g. by dotnet. A directory that
+// still cannot be removed is logged and left behind.
+func removeManifestDir(ctx context.Context, dir string) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), manifestCleanupTimeout)
+	defer cancel()
+
+	if err := osutil.RemoveAll(ctx, dir); err != nil {
+		log.Printf("failed to remove temp manifest directory %s: %v", dir, err)
+	}
+}
+
+// externalBicepRootsEnvVar lists additional directories, separated by the OS path list separator, that a manifest may
+// reference external bicep files from.
+const externalBicepRootsEnvVar = "AZD_DOTNET_APPHOST_BICEP_ROOTS"
+
+var errBicepOutsideRoots = errors.New("bicep file is outside the allowed directories")
+
+// readExternalBicep reads a bicep file that the manifest references by a path outside the generated manifest directory.
+// The file must be within the app host project directory or a directory listed in AZD_DOTNET_APPHOST_BICEP_ROOTS, so
+// that a manifest can't make azd read arbitrary files. Symlinks are resolved before the check.
+func readExternalBicep(appHostProject string, bicepPath string) ([]byte, error) {
+	resolvedPath, err := resolvePath(bicepPath)
+	if err != nil {
+		return nil, err
+	}
+
+	roots := append([]string{filepath.Dir(appHostProject)}, filepath.SplitList(os.Getenv(externalBicepRootsEnvVar))...)
+	for _, root := range roots {
+		if root == "" {
+			continue
+		}
+
+		resolvedRoot, err := resolvePath(root)
+		if err != nil {
+			return nil, err
+		}
+
+		if osutil.IsPathContained(resolvedRoot, resolvedPath) {
+			return os.ReadFile(resolvedPath)
+		}
+	}
+
+	return nil, fmt.Errorf(
+		"%w: %s is not within the app host project directory %s. Set %s to allow other directories",
+		errBicepOutsideRoots, bicepPath, filepath.Dir(appHostProject), externalBicepRootsEnvVar)
+}
+
+// resolvePath returns the absolute form of path with symlinks resolved. A path that doesn't exist is returned as an
+// absolute path.
+func resolvePath(path string) (string, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	if resolved, err := filepath.EvalSymlinks(absPath); err == nil {
+		return resolved, nil
+	}
+
+	return absPath, nil
+}
+
 // ManifestFromAppHost returns the Manifest from the given app host.
 func ManifestFromAppHost(
 	ctx context.Context, appHostProject string, dotnetCli *dotnet.Cli, dotnetEnv string,
@@ -284,11 +446,11 @@ func ManifestFromAppHost(
 	if err != nil {
 		return nil, fmt.Errorf("creating temp directory for apphost-manifest.json: %w", err)
 	}
-	defer os.RemoveAll(tempDir)
+	defer removeManifestDir(ctx, tempDir)
 
 	manifestPath := filepath.Join(tempDir, "apphost-manifest.json")
 
-	if err := dotnetCli.PublishAppHostManifest(ctx, appHostProject, manifestPath, dotnetEnv); err != nil {
+	if err := publishAppHostManifest(ctx, appHostProject, manifestPath, dotnetCli, dotnetEnv); err != nil {
 		return nil, fmt.Errorf("generating app host manifest: %w", err)
 	}
 
@@ -302,6 +464,10 @@ func ManifestFromAppHost(
 		return nil, fmt.Errorf("unmarshalling manifest: %w", err)
 	}
 
+	manifest.GeneratedFor = ManifestGeneratedFor{
+		DotnetEnv: dotnetEnv,
+	}
+
 	// Make all paths absolute, to simplify logic for consumers.
 	// Note that since we created a temp dir, and `dotnet run --publisher` returns relative paths to the temp dir,
 	// the resulting path may be a symlinked path that isn't safe for Rel comparisons with the azd root directory.
@@ -326,7 +492,10 @@ func ManifestFromAppHost(
 				content, e := os.ReadFile(filepath.Join(manifestDir, *res.Path))
 				if e != nil {
 					// second try reading as relative (external bicep reference)
-					content, e = os.ReadFile(*res.Path)
+					content, e = readExternalBicep(appHostProject, *res.Path)
+					if errors.Is(e, errBicepOutsideRoots) {
+						return nil, e
+					}
 					if e != nil {
 						return nil, fmt.Errorf("did not find bicep at generated path or at: %s. Error: %w", *res.Path, e)
 					}
@@ -370,6 +539,11 @@ func ManifestFromAppHost(
 			if !filepath.IsAbs(*res.Context) {
 				*res.Context = filepath.Join(manifestDir, *res.Context)
 			}
+			for _, secret := range res.BuildSecrets {
+				if secret.Source != nil && !filepath.IsAbs(*secret.Source) {
+					*secret.Source = filepath.Join(manifestDir, *secret.Source)
+				}
+			}
 		}
 		if res.BindMounts != nil {
 			for _, bindMount := range res.BindMounts {
@@ -379,6 +553,8 @@ func ManifestFromAppHost(
 			}
 		}
 		if res.Type == "container.v1" {
+			// A container.v1 resource either builds an image (build) or references an existing one (image). Only
+			// the build block has paths to resolve; an image reference is kept as-is.
 			if res.Build != nil {
 				if !filepath.IsAbs(res.Build.Dockerfile) {
 					res.Build.Dockerfile = filepath.Join(manifestDir, res.Build.Dockerfile)
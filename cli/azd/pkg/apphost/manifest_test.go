@@ -4,6 +4,9 @@
 package apphost
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -377,3 +380,67 @@ func TestInfraGenerator_BicepV0_ScopeWithoutResourceGroup(t *testing.T) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "scope without a resource group")
 }
+
+func TestRemoveManifestDir_CancelledContext(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "azd-provision")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "nested"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "apphost-manifest.json"), []byte("{}"), 0600))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	removeManifestDir(ctx, dir)
+
+	_, err := os.Stat(dir)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestReadExternalBicep(t *testing.T) {
+	appHostDir := t.TempDir()
+	appHostProject := filepath.Join(appHostDir, "App.AppHost.csproj")
+	inTree := filepath.Join(appHostDir, "infra", "storage.bicep")
+	require.NoError(t, os.MkdirAll(filepath.Dir(inTree), 0700))
+	require.NoError(t, os.WriteFile(inTree, []byte("in tree"), 0600))
+
+	outsideDir := t.TempDir()
+	outOfTree := filepath.Join(outsideDir, "secrets.bicep")
+	require.NoError(t, os.WriteFile(outOfTree, []byte("out of tree"), 0600))
+
+	t.Run("within the app host project", func(t *testing.T) {
+		content, err := readExternalBicep(appHostProject, inTree)
+		require.NoError(t, err)
+		require.Equal(t, "in tree", string(content))
+	})
+
+	t.Run("outside the app host project", func(t *testing.T) {
+		_, err := readExternalBicep(appHostProject, outOfTree)
+		require.ErrorIs(t, err, errBicepOutsideRoots)
+		require.ErrorContains(t, err, externalBicepRootsEnvVar)
+	})
+
+	t.Run("traversal out of the app host project", func(t *testing.T) {
+		rel, err := filepath.Rel(appHostDir, outOfTree)
+		require.NoError(t, err)
+
+		_, err = readExternalBicep(appHostProject, appHostDir+string(filepath.Separator)+rel)
+		require.ErrorIs(t, err, errBicepOutsideRoots)
+	})
+
+	t.Run("symlink out of the app host project", func(t *testing.T) {
+		link := filepath.Join(appHostDir, "linked.bicep")
+		if err := os.Symlink(outOfTree, link); err != nil {
+			t.Skipf("creating symlink: %v", err)
+		}
+
+		_, err := readExternalBicep(appHostProject, link)
+		require.ErrorIs(t, err, errBicepOutsideRoots)
+	})
+
+	t.Run("outside the app host project but in an allowed root", func(t *testing.T) {
+		t.Setenv(externalBicepRootsEnvVar, outsideDir)
+
+		content, err := readExternalBicep(appHostProject, outOfTree)
+		require.NoError(t, err)
+		require.Equal(t, "out of tree", string(content))
+	})
+}
@@ -5,10 +5,13 @@ package azapi
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"slices"
 	"strings"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cognitiveservices/armcognitiveservices/v2"
 )
 
@@ -52,6 +55,68 @@ func (cli *AzureClient) PurgeCognitiveAccount(
 	return nil
 }
 
+// ListCognitiveAccounts lists the cognitive accounts in a resource group.
+func (cli *AzureClient) ListCognitiveAccounts(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string) ([]*armcognitiveservices.Account, error) {
+	client, err := cli.createCognitiveAccountClient(ctx, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	pager := client.NewListByResourceGroupPager(resourceGroupName, nil)
+	var accounts []*armcognitiveservices.Account
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, page.Value...)
+	}
+
+	return accounts, nil
+}
+
+// GetCognitiveAccountDeployments lists the model deployments of a cognitive account.
+func (cli *AzureClient) GetCognitiveAccountDeployments(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	accountName string) ([]*armcognitiveservices.Deployment, error) {
+	client, err := cli.createDeploymentsClient(ctx, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	pager := client.NewListPager(resourceGroupName, accountName, nil)
+	var deployments []*armcognitiveservices.Deployment
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		deployments = append(deployments, page.Value...)
+	}
+
+	return deployments, nil
+}
+
+func (cli *AzureClient) createDeploymentsClient(
+	ctx context.Context, subscriptionId string) (*armcognitiveservices.DeploymentsClient, error) {
+	credential, err := cli.credentialProvider.CredentialForSubscription(ctx, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := armcognitiveservices.NewDeploymentsClient(subscriptionId, credential, cli.armClientOptions)
+	if err != nil {
+		return nil, fmt.Errorf("creating Resource client: %w", err)
+	}
+
+	return client, nil
+}
+
 func (cli *AzureClient) createCognitiveAccountClient(
 	ctx context.Context, subscriptionId string) (*armcognitiveservices.AccountsClient, error) {
 	credential, err := cli.credentialProvider.CredentialForSubscription(ctx, subscriptionId)
@@ -82,6 +147,8 @@ func (cli *AzureClient) createDeletedCognitiveAccountClient(
 	return client, nil
 }
 
+// GetAiModels lists the AI models available in a location. Like the other Cognitive Services calls, the request targets
+// the Resource Manager endpoint of the cloud configured in the client's ARM options, so sovereign clouds are supported.
 func (cli *AzureClient) GetAiModels(
 	ctx context.Context,
 	subscriptionId string,
@@ -119,6 +186,7 @@ func (cli *AzureClient) createModelsClient(
 	return client, nil
 }
 
+// GetAiUsages lists the Cognitive Services quota usages in a location of the configured cloud.
 func (cli *AzureClient) GetAiUsages(
 	ctx context.Context,
 	subscriptionId string,
@@ -141,6 +209,13 @@ func (cli *AzureClient) GetAiUsages(
 	return models, nil
 }
 
+// IsThrottledError reports whether err is an Azure response rejecting the request with HTTP 429 because of rate
+// limiting, such as from GetAiUsages. Unlike other failures, a throttled request may succeed when retried later.
+func IsThrottledError(err error) bool {
+	respErr, ok := errors.AsType[*azcore.ResponseError](err)
+	return ok && respErr.StatusCode == http.StatusTooManyRequests
+}
+
 func (cli *AzureClient) createUsagesClient(
 	ctx context.Context, subscriptionId string) (*armcognitiveservices.UsagesClient, error) {
 	credential, err := cli.credentialProvider.CredentialForSubscription(ctx, subscriptionId)
@@ -158,6 +233,7 @@ func (cli *AzureClient) createUsagesClient(
 
 // GetResourceSkuLocations retrieves a list of unique locations where a specific resource SKU is available.
 // It filters the resource SKUs based on the provided kind, SKU name, tier, and resource type.
+// The SKUs are listed from the configured cloud, so the result reflects that cloud's region set.
 //
 // Parameters:
 //   - ctx: The context for the operation.
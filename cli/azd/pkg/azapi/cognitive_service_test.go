@@ -4,10 +4,14 @@
 package azapi
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 	"testing"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	azcloud "github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cognitiveservices/armcognitiveservices/v2"
 	"github.com/stretchr/testify/assert"
@@ -138,6 +142,42 @@ func Test_AzureClient_GetAiUsages(t *testing.T) {
 	assert.Equal(t, float64(1000), *usages[0].CurrentValue)
 }
 
+func Test_IsThrottledError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "throttled",
+			err:  &azcore.ResponseError{StatusCode: http.StatusTooManyRequests},
+			want: true,
+		},
+		{
+			name: "wrapped throttled",
+			err:  fmt.Errorf("listing usages: %w", &azcore.ResponseError{StatusCode: http.StatusTooManyRequests}),
+			want: true,
+		},
+		{
+			name: "other status",
+			err:  &azcore.ResponseError{StatusCode: http.StatusForbidden},
+		},
+		{
+			name: "not a response error",
+			err:  errors.New("connection reset"),
+		},
+		{
+			name: "nil",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsThrottledError(tt.err))
+		})
+	}
+}
+
 func Test_AzureClient_GetResourceSkuLocations(t *testing.T) {
 	t.Run("Found", func(t *testing.T) {
 		mockCtx := mocks.NewMockContext(t.Context())
@@ -203,3 +243,70 @@ func Test_AzureClient_GetResourceSkuLocations(t *testing.T) {
 		assert.Contains(t, err.Error(), "no locations found")
 	})
 }
+
+func Test_AzureClient_AiMethodsUseConfiguredCloud(t *testing.T) {
+	tests := []struct {
+		name string
+		call func(client *AzureClient, mockCtx *mocks.MockContext) error
+	}{
+		{
+			name: "GetAiModels",
+			call: func(client *AzureClient, mockCtx *mocks.MockContext) error {
+				_, err := client.GetAiModels(*mockCtx.Context, "SUB", "usgovvirginia")
+				return err
+			},
+		},
+		{
+			name: "GetAiUsages",
+			call: func(client *AzureClient, mockCtx *mocks.MockContext) error {
+				_, err := client.GetAiUsages(*mockCtx.Context, "SUB", "usgovvirginia")
+				return err
+			},
+		},
+		{
+			name: "GetResourceSkuLocations",
+			call: func(client *AzureClient, mockCtx *mocks.MockContext) error {
+				_, err := client.GetResourceSkuLocations(
+					*mockCtx.Context, "SUB", "AIServices", "S0", "Standard", "accounts")
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtx := mocks.NewMockContext(t.Context())
+			mockCtx.ArmClientOptions.Cloud = azcloud.AzureGovernment
+			client := newAzureClientFromMockContext(mockCtx)
+
+			var hosts []string
+			mockCtx.HttpClient.When(func(req *http.Request) bool {
+				return req.Method == http.MethodGet
+			}).RespondFn(func(req *http.Request) (*http.Response, error) {
+				hosts = append(hosts, req.URL.Host)
+				if !strings.HasSuffix(req.URL.Path, "/skus") {
+					return mocks.CreateHttpResponseWithBody(req, http.StatusOK, map[string]any{"value": []any{}})
+				}
+
+				return mocks.CreateHttpResponseWithBody(req, http.StatusOK,
+					armcognitiveservices.ResourceSKUListResult{
+						Value: []*armcognitiveservices.ResourceSKU{
+							{
+								Kind:         new("AIServices"),
+								Name:         new("S0"),
+								Tier:         new("Standard"),
+								ResourceType: new("accounts"),
+								Locations:    []*string{new("USGovVirginia")},
+							},
+						},
+					})
+			})
+
+			require.NoError(t, tt.call(client, mockCtx))
+			require.NotEmpty(t, hosts)
+			for _, host := range hosts {
+				assert.Equal(t, "management.usgovcloudapi.net", host)
+			}
+		})
+	}
+}
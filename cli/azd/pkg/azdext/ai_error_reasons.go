@@ -3,6 +3,11 @@
 
 package azdext
 
+import (
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+)
+
 // AI error metadata constants used in gRPC ErrorInfo for AI model/prompt APIs.
 const (
 	AiErrorDomain = "azd.ai"
@@ -14,10 +19,40 @@ const (
 	AiErrorReasonLocationRequired     = "AI_LOCATION_REQUIRED"
 	AiErrorReasonQuotaLocation        = "AI_QUOTA_LOCATION_REQUIRED"
 	AiErrorReasonModelNotFound        = "AI_MODEL_NOT_FOUND"
+	AiErrorReasonModelNotDeployable   = "AI_MODEL_NOT_DEPLOYABLE"
 	AiErrorReasonNoModelsMatch        = "AI_NO_MODELS_MATCH"
 	AiErrorReasonNoDeploymentMatch    = "AI_NO_DEPLOYMENT_MATCH"
 	AiErrorReasonNoValidSkus          = "AI_NO_VALID_SKUS"
 	AiErrorReasonNoLocationsWithQuota = "AI_NO_LOCATIONS_WITH_QUOTA"
 	AiErrorReasonInvalidCapacity      = "AI_INVALID_CAPACITY"
 	AiErrorReasonInteractiveRequired  = "AI_INTERACTIVE_REQUIRED"
+	AiErrorReasonNoAccountQuota       = "AI_NO_ACCOUNT_QUOTA"
+	AiErrorReasonInvalidIntent        = "AI_INVALID_INTENT"
 )
+
+// AiErrorReason extracts the ErrorInfo.Reason from a gRPC status when the domain
+// matches [AiErrorDomain].
+func AiErrorReason(st *status.Status) string {
+	for _, detail := range st.Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if ok && info.Domain == AiErrorDomain {
+			return info.Reason
+		}
+	}
+
+	return ""
+}
+
+// AiErrorMetadata extracts the ErrorInfo.Metadata from a gRPC status when the domain
+// matches [AiErrorDomain], e.g. model_name and suggested_model_name for
+// [AiErrorReasonModelNotFound]. It returns nil when the status has no such detail.
+func AiErrorMetadata(st *status.Status) map[string]string {
+	for _, detail := range st.Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if ok && info.Domain == AiErrorDomain {
+			return info.Metadata
+		}
+	}
+
+	return nil
+}
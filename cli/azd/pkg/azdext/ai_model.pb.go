@@ -27,12 +27,13 @@ const (
 type AiModel struct {
 	state  protoimpl.MessageState `protogen:"open.v1"`
 	Name   string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`     // e.g. "gpt-4o"
-	Format string                 `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"` // e.g. "OpenAI"
+	Format string                 `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"` // e.g. "OpenAI"; first in sorted order when offered in several
 	// Deprecated: Marked as deprecated in ai_model.proto.
 	LifecycleStatus string            `protobuf:"bytes,3,opt,name=lifecycle_status,json=lifecycleStatus,proto3" json:"lifecycle_status,omitempty"` // deprecated; always empty; use AiModelVersion.lifecycle_status
 	Capabilities    []string          `protobuf:"bytes,4,rep,name=capabilities,proto3" json:"capabilities,omitempty"`                              // e.g. ["chat", "embeddings"]
 	Versions        []*AiModelVersion `protobuf:"bytes,5,rep,name=versions,proto3" json:"versions,omitempty"`
 	Locations       []string          `protobuf:"bytes,6,rep,name=locations,proto3" json:"locations,omitempty"` // canonical locations where available
+	Family          string            `protobuf:"bytes,7,opt,name=family,proto3" json:"family,omitempty"`       // derived model family, e.g. "gpt-4" for gpt-4o/gpt-4o-mini
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -110,14 +111,25 @@ func (x *AiModel) GetLocations() []string {
 	return nil
 }
 
+func (x *AiModel) GetFamily() string {
+	if x != nil {
+		return x.Family
+	}
+	return ""
+}
+
 type AiModelVersion struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	Version         string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
 	IsDefault       bool                   `protobuf:"varint,2,opt,name=is_default,json=isDefault,proto3" json:"is_default,omitempty"`
-	Skus            []*AiModelSku          `protobuf:"bytes,3,rep,name=skus,proto3" json:"skus,omitempty"`
+	Skus            []*AiModelSku          `protobuf:"bytes,3,rep,name=skus,proto3" json:"skus,omitempty"`                                              // never empty; versions without SKUs are omitted
 	LifecycleStatus string                 `protobuf:"bytes,4,opt,name=lifecycle_status,json=lifecycleStatus,proto3" json:"lifecycle_status,omitempty"` // e.g. "GenerallyAvailable", "Preview"
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	Format          string                 `protobuf:"bytes,5,opt,name=format,proto3" json:"format,omitempty"`                                          // e.g. "OpenAI"; one entry per format when a version has several
+	Capabilities    []string               `protobuf:"bytes,6,rep,name=capabilities,proto3" json:"capabilities,omitempty"`                              // sorted capability names of this version, e.g. ["chatCompletion"]
+	// Capability values of this version, keyed by capability name, e.g. {"maxContextToken": "128000"}.
+	CapabilityValues map[string]string `protobuf:"bytes,7,rep,name=capability_values,json=capabilityValues,proto3" json:"capability_values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *AiModelVersion) Reset() {
@@ -178,15 +190,39 @@ func (x *AiModelVersion) GetLifecycleStatus() string {
 	return ""
 }
 
+func (x *AiModelVersion) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *AiModelVersion) GetCapabilities() []string {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+func (x *AiModelVersion) GetCapabilityValues() map[string]string {
+	if x != nil {
+		return x.CapabilityValues
+	}
+	return nil
+}
+
 // AiModelSku represents a deployment SKU with capacity constraints.
 type AiModelSku struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                            // e.g. "GlobalStandard"
-	UsageName       string                 `protobuf:"bytes,2,opt,name=usage_name,json=usageName,proto3" json:"usage_name,omitempty"` // join key to usage API, e.g. "OpenAI.Standard.gpt-4o"
-	DefaultCapacity int32                  `protobuf:"varint,3,opt,name=default_capacity,json=defaultCapacity,proto3" json:"default_capacity,omitempty"`
-	MinCapacity     int32                  `protobuf:"varint,4,opt,name=min_capacity,json=minCapacity,proto3" json:"min_capacity,omitempty"`
-	MaxCapacity     int32                  `protobuf:"varint,5,opt,name=max_capacity,json=maxCapacity,proto3" json:"max_capacity,omitempty"`
-	CapacityStep    int32                  `protobuf:"varint,6,opt,name=capacity_step,json=capacityStep,proto3" json:"capacity_step,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // e.g. "GlobalStandard"
+	// Full Azure usage meter name, already qualified with the model, e.g. "OpenAI.Standard.gpt-4o".
+	// Matches AiModelUsage.name and can be passed as QuotaRequirement.usage_name as-is.
+	UsageName       string `protobuf:"bytes,2,opt,name=usage_name,json=usageName,proto3" json:"usage_name,omitempty"`
+	DefaultCapacity int32  `protobuf:"varint,3,opt,name=default_capacity,json=defaultCapacity,proto3" json:"default_capacity,omitempty"`
+	MinCapacity     int32  `protobuf:"varint,4,opt,name=min_capacity,json=minCapacity,proto3" json:"min_capacity,omitempty"`
+	MaxCapacity     int32  `protobuf:"varint,5,opt,name=max_capacity,json=maxCapacity,proto3" json:"max_capacity,omitempty"`
+	CapacityStep    int32  `protobuf:"varint,6,opt,name=capacity_step,json=capacityStep,proto3" json:"capacity_step,omitempty"`
+	DeploymentKind  string `protobuf:"bytes,7,opt,name=deployment_kind,json=deploymentKind,proto3" json:"deployment_kind,omitempty"` // "Global", "DataZone", "Regional", "Provisioned", or empty if unknown
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -263,6 +299,13 @@ func (x *AiModelSku) GetCapacityStep() int32 {
 	return 0
 }
 
+func (x *AiModelSku) GetDeploymentKind() string {
+	if x != nil {
+		return x.DeploymentKind
+	}
+	return ""
+}
+
 // AiModelDeployment is a fully resolved deployment configuration.
 // capacity = deployment-level units; remaining_quota = subscription-level remaining.
 type AiModelDeployment struct {
@@ -541,8 +584,21 @@ type AiModelFilterOptions struct {
 	Statuses []string `protobuf:"bytes,4,rep,name=statuses,proto3" json:"statuses,omitempty"`
 	// Exclude models by exact model name (for example: "gpt-4o-mini").
 	ExcludeModelNames []string `protobuf:"bytes,5,rep,name=exclude_model_names,json=excludeModelNames,proto3" json:"exclude_model_names,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+	// Include only models suited to this use: "chat", "embeddings", or "imageGeneration".
+	// Empty means any model. Combined with capabilities when both are set.
+	// Unknown values are rejected.
+	Intent string `protobuf:"bytes,6,opt,name=intent,proto3" json:"intent,omitempty"`
+	// Keep only SKUs with at least this much remaining quota at a location, dropping
+	// versions and locations left without SKUs. Costs a usage lookup per location;
+	// when locations is set, only those locations are checked and kept.
+	// 0 disables the check.
+	MinAvailableCapacity float64 `protobuf:"fixed64,7,opt,name=min_available_capacity,json=minAvailableCapacity,proto3" json:"min_available_capacity,omitempty"`
+	// Collapse each model to its default version, one per format, or to its newest
+	// version when none is marked as default. Pickers can list models this way and
+	// offer the other versions on request. Defaults to false.
+	DefaultVersionsOnly bool `protobuf:"varint,8,opt,name=default_versions_only,json=defaultVersionsOnly,proto3" json:"default_versions_only,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *AiModelFilterOptions) Reset() {
@@ -610,6 +666,27 @@ func (x *AiModelFilterOptions) GetExcludeModelNames() []string {
 	return nil
 }
 
+func (x *AiModelFilterOptions) GetIntent() string {
+	if x != nil {
+		return x.Intent
+	}
+	return ""
+}
+
+func (x *AiModelFilterOptions) GetMinAvailableCapacity() float64 {
+	if x != nil {
+		return x.MinAvailableCapacity
+	}
+	return 0
+}
+
+func (x *AiModelFilterOptions) GetDefaultVersionsOnly() bool {
+	if x != nil {
+		return x.DefaultVersionsOnly
+	}
+	return false
+}
+
 // AiModelDeploymentOptions: all fields optional — empty means no filtering.
 type AiModelDeploymentOptions struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -620,7 +697,18 @@ type AiModelDeploymentOptions struct {
 	// Preferred SKU names. Empty means all available SKUs.
 	Skus []string `protobuf:"bytes,3,rep,name=skus,proto3" json:"skus,omitempty"`
 	// Preferred deployment capacity. If unset, SKU default is used.
-	Capacity      *int32 `protobuf:"varint,4,opt,name=capacity,proto3,oneof" json:"capacity,omitempty"`
+	Capacity *int32 `protobuf:"varint,4,opt,name=capacity,proto3,oneof" json:"capacity,omitempty"`
+	// Use the model's default version when none of the preferred versions yields a deployment.
+	// Defaults to false. The chosen version is reported on each resolved deployment.
+	FallbackToDefaultVersion bool `protobuf:"varint,5,opt,name=fallback_to_default_version,json=fallbackToDefaultVersion,proto3" json:"fallback_to_default_version,omitempty"`
+	// Order resolved deployments by the preference order of skus, then move deployments whose SKU lacks
+	// remaining quota for the resolved capacity after the others, so the first deployment is deployable
+	// when the most preferred SKU is exhausted. Costs a usage lookup and requires exactly one location.
+	// Has no effect when quota is set, which already excludes such deployments. Defaults to false.
+	PreferSkusWithQuota bool `protobuf:"varint,6,opt,name=prefer_skus_with_quota,json=preferSkusWithQuota,proto3" json:"prefer_skus_with_quota,omitempty"`
+	// Order deployments of "Preview" model versions after generally available ones, even when the preview
+	// version is the model's default. SKU and quota preferences still take precedence. Defaults to false.
+	PreferStable  bool `protobuf:"varint,7,opt,name=prefer_stable,json=preferStable,proto3" json:"prefer_stable,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -683,6 +771,27 @@ func (x *AiModelDeploymentOptions) GetCapacity() int32 {
 	return 0
 }
 
+func (x *AiModelDeploymentOptions) GetFallbackToDefaultVersion() bool {
+	if x != nil {
+		return x.FallbackToDefaultVersion
+	}
+	return false
+}
+
+func (x *AiModelDeploymentOptions) GetPreferSkusWithQuota() bool {
+	if x != nil {
+		return x.PreferSkusWithQuota
+	}
+	return false
+}
+
+func (x *AiModelDeploymentOptions) GetPreferStable() bool {
+	if x != nil {
+		return x.PreferStable
+	}
+	return false
+}
+
 type ListModelsRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Azure context with scope.subscription_id required.
@@ -740,9 +849,15 @@ func (x *ListModelsRequest) GetFilter() *AiModelFilterOptions {
 type ListModelsResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Catalog models after applying optional filters.
-	Models        []*AiModel `protobuf:"bytes,1,rep,name=models,proto3" json:"models,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Models []*AiModel `protobuf:"bytes,1,rep,name=models,proto3" json:"models,omitempty"`
+	// Locations whose catalog could not be fetched, sorted by location.
+	// When non-empty, models offered only in those locations are missing from models.
+	FailedLocations []*AiLocationError `protobuf:"bytes,2,rep,name=failed_locations,json=failedLocations,proto3" json:"failed_locations,omitempty"`
+	// Capabilities offered by the models in the catalog, sorted. Only set when filter.capabilities alone
+	// excludes every model, to help correct the filter (for example "chatCompletion" rather than "chat").
+	AvailableCapabilities []string `protobuf:"bytes,3,rep,name=available_capabilities,json=availableCapabilities,proto3" json:"available_capabilities,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
 func (x *ListModelsResponse) Reset() {
@@ -782,6 +897,83 @@ func (x *ListModelsResponse) GetModels() []*AiModel {
 	return nil
 }
 
+func (x *ListModelsResponse) GetFailedLocations() []*AiLocationError {
+	if x != nil {
+		return x.FailedLocations
+	}
+	return nil
+}
+
+func (x *ListModelsResponse) GetAvailableCapabilities() []string {
+	if x != nil {
+		return x.AvailableCapabilities
+	}
+	return nil
+}
+
+// AiLocationError describes a failure to query a single location.
+type AiLocationError struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Location string                 `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	Message  string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// True when the location rejected the query because of rate limiting (HTTP 429). Retrying later may succeed, so
+	// the location should not be read as lacking quota.
+	Throttled     bool `protobuf:"varint,3,opt,name=throttled,proto3" json:"throttled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AiLocationError) Reset() {
+	*x = AiLocationError{}
+	mi := &file_ai_model_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AiLocationError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AiLocationError) ProtoMessage() {}
+
+func (x *AiLocationError) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_model_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AiLocationError.ProtoReflect.Descriptor instead.
+func (*AiLocationError) Descriptor() ([]byte, []int) {
+	return file_ai_model_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AiLocationError) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *AiLocationError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AiLocationError) GetThrottled() bool {
+	if x != nil {
+		return x.Throttled
+	}
+	return false
+}
+
 type ResolveModelDeploymentsRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Azure context with scope.subscription_id required.
@@ -801,7 +993,7 @@ type ResolveModelDeploymentsRequest struct {
 
 func (x *ResolveModelDeploymentsRequest) Reset() {
 	*x = ResolveModelDeploymentsRequest{}
-	mi := &file_ai_model_proto_msgTypes[11]
+	mi := &file_ai_model_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -813,7 +1005,7 @@ func (x *ResolveModelDeploymentsRequest) String() string {
 func (*ResolveModelDeploymentsRequest) ProtoMessage() {}
 
 func (x *ResolveModelDeploymentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ai_model_proto_msgTypes[11]
+	mi := &file_ai_model_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -826,7 +1018,7 @@ func (x *ResolveModelDeploymentsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResolveModelDeploymentsRequest.ProtoReflect.Descriptor instead.
 func (*ResolveModelDeploymentsRequest) Descriptor() ([]byte, []int) {
-	return file_ai_model_proto_rawDescGZIP(), []int{11}
+	return file_ai_model_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ResolveModelDeploymentsRequest) GetAzureContext() *AzureContext {
@@ -874,7 +1066,7 @@ type ResolveModelDeploymentsResponse struct {
 
 func (x *ResolveModelDeploymentsResponse) Reset() {
 	*x = ResolveModelDeploymentsResponse{}
-	mi := &file_ai_model_proto_msgTypes[12]
+	mi := &file_ai_model_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -886,7 +1078,7 @@ func (x *ResolveModelDeploymentsResponse) String() string {
 func (*ResolveModelDeploymentsResponse) ProtoMessage() {}
 
 func (x *ResolveModelDeploymentsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ai_model_proto_msgTypes[12]
+	mi := &file_ai_model_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -899,7 +1091,7 @@ func (x *ResolveModelDeploymentsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResolveModelDeploymentsResponse.ProtoReflect.Descriptor instead.
 func (*ResolveModelDeploymentsResponse) Descriptor() ([]byte, []int) {
-	return file_ai_model_proto_rawDescGZIP(), []int{12}
+	return file_ai_model_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ResolveModelDeploymentsResponse) GetDeployments() []*AiModelDeployment {
@@ -914,14 +1106,20 @@ type ListUsagesRequest struct {
 	// Azure context with scope.subscription_id required.
 	AzureContext *AzureContext `protobuf:"bytes,1,opt,name=azure_context,json=azureContext,proto3" json:"azure_context,omitempty"`
 	// Required location for usage query (no fallback from azure_context.scope.location).
-	Location      string `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	Location string `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	// Optional usage-name prefixes (for example: "OpenAI.", "AIServices.").
+	// A usage is returned when its name starts with any prefix. Empty means no filtering.
+	NamePrefixes []string `protobuf:"bytes,3,rep,name=name_prefixes,json=namePrefixes,proto3" json:"name_prefixes,omitempty"`
+	// Omit usages whose limit is 0, i.e. meters that do not apply at the location. Defaults to false, which returns
+	// every usage.
+	OmitZeroLimit bool `protobuf:"varint,4,opt,name=omit_zero_limit,json=omitZeroLimit,proto3" json:"omit_zero_limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListUsagesRequest) Reset() {
 	*x = ListUsagesRequest{}
-	mi := &file_ai_model_proto_msgTypes[13]
+	mi := &file_ai_model_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -933,7 +1131,7 @@ func (x *ListUsagesRequest) String() string {
 func (*ListUsagesRequest) ProtoMessage() {}
 
 func (x *ListUsagesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ai_model_proto_msgTypes[13]
+	mi := &file_ai_model_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -946,7 +1144,7 @@ func (x *ListUsagesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListUsagesRequest.ProtoReflect.Descriptor instead.
 func (*ListUsagesRequest) Descriptor() ([]byte, []int) {
-	return file_ai_model_proto_rawDescGZIP(), []int{13}
+	return file_ai_model_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ListUsagesRequest) GetAzureContext() *AzureContext {
@@ -963,6 +1161,20 @@ func (x *ListUsagesRequest) GetLocation() string {
 	return ""
 }
 
+func (x *ListUsagesRequest) GetNamePrefixes() []string {
+	if x != nil {
+		return x.NamePrefixes
+	}
+	return nil
+}
+
+func (x *ListUsagesRequest) GetOmitZeroLimit() bool {
+	if x != nil {
+		return x.OmitZeroLimit
+	}
+	return false
+}
+
 type ListUsagesResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Quota usage entries for the requested location.
@@ -973,7 +1185,7 @@ type ListUsagesResponse struct {
 
 func (x *ListUsagesResponse) Reset() {
 	*x = ListUsagesResponse{}
-	mi := &file_ai_model_proto_msgTypes[14]
+	mi := &file_ai_model_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -985,7 +1197,7 @@ func (x *ListUsagesResponse) String() string {
 func (*ListUsagesResponse) ProtoMessage() {}
 
 func (x *ListUsagesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ai_model_proto_msgTypes[14]
+	mi := &file_ai_model_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -998,7 +1210,7 @@ func (x *ListUsagesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListUsagesResponse.ProtoReflect.Descriptor instead.
 func (*ListUsagesResponse) Descriptor() ([]byte, []int) {
-	return file_ai_model_proto_rawDescGZIP(), []int{14}
+	return file_ai_model_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *ListUsagesResponse) GetUsages() []*AiModelUsage {
@@ -1008,33 +1220,35 @@ func (x *ListUsagesResponse) GetUsages() []*AiModelUsage {
 	return nil
 }
 
-type ListLocationsWithQuotaRequest struct {
+type ListUsagesBatchRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Azure context with scope.subscription_id required.
 	AzureContext *AzureContext `protobuf:"bytes,1,opt,name=azure_context,json=azureContext,proto3" json:"azure_context,omitempty"`
-	// Required quota requirements that each returned location must satisfy.
-	Requirements []*QuotaRequirement `protobuf:"bytes,2,rep,name=requirements,proto3" json:"requirements,omitempty"`
-	// Optional allow-list. Empty means all AI Services-supported locations.
-	AllowedLocations []string `protobuf:"bytes,3,rep,name=allowed_locations,json=allowedLocations,proto3" json:"allowed_locations,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	// Optional locations to query. Empty means all AI Services-supported locations.
+	Locations []string `protobuf:"bytes,2,rep,name=locations,proto3" json:"locations,omitempty"`
+	// Optional usage-name prefixes applied to every location (same semantics as ListUsagesRequest).
+	NamePrefixes []string `protobuf:"bytes,3,rep,name=name_prefixes,json=namePrefixes,proto3" json:"name_prefixes,omitempty"`
+	// Omit usages whose limit is 0 (same semantics as ListUsagesRequest).
+	OmitZeroLimit bool `protobuf:"varint,4,opt,name=omit_zero_limit,json=omitZeroLimit,proto3" json:"omit_zero_limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListLocationsWithQuotaRequest) Reset() {
-	*x = ListLocationsWithQuotaRequest{}
-	mi := &file_ai_model_proto_msgTypes[15]
+func (x *ListUsagesBatchRequest) Reset() {
+	*x = ListUsagesBatchRequest{}
+	mi := &file_ai_model_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListLocationsWithQuotaRequest) String() string {
+func (x *ListUsagesBatchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListLocationsWithQuotaRequest) ProtoMessage() {}
+func (*ListUsagesBatchRequest) ProtoMessage() {}
 
-func (x *ListLocationsWithQuotaRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ai_model_proto_msgTypes[15]
+func (x *ListUsagesBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_model_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1045,55 +1259,65 @@ func (x *ListLocationsWithQuotaRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListLocationsWithQuotaRequest.ProtoReflect.Descriptor instead.
-func (*ListLocationsWithQuotaRequest) Descriptor() ([]byte, []int) {
-	return file_ai_model_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use ListUsagesBatchRequest.ProtoReflect.Descriptor instead.
+func (*ListUsagesBatchRequest) Descriptor() ([]byte, []int) {
+	return file_ai_model_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *ListLocationsWithQuotaRequest) GetAzureContext() *AzureContext {
+func (x *ListUsagesBatchRequest) GetAzureContext() *AzureContext {
 	if x != nil {
 		return x.AzureContext
 	}
 	return nil
 }
 
-func (x *ListLocationsWithQuotaRequest) GetRequirements() []*QuotaRequirement {
+func (x *ListUsagesBatchRequest) GetLocations() []string {
 	if x != nil {
-		return x.Requirements
+		return x.Locations
 	}
 	return nil
 }
 
-func (x *ListLocationsWithQuotaRequest) GetAllowedLocations() []string {
+func (x *ListUsagesBatchRequest) GetNamePrefixes() []string {
 	if x != nil {
-		return x.AllowedLocations
+		return x.NamePrefixes
 	}
 	return nil
 }
 
-type ListLocationsWithQuotaResponse struct {
+func (x *ListUsagesBatchRequest) GetOmitZeroLimit() bool {
+	if x != nil {
+		return x.OmitZeroLimit
+	}
+	return false
+}
+
+// LocationUsages groups quota usage entries for a single location.
+type LocationUsages struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Locations that satisfy all quota requirements.
-	Locations     []*Location `protobuf:"bytes,1,rep,name=locations,proto3" json:"locations,omitempty"`
+	// Location the usages were queried for.
+	Location string `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	// Quota usage entries for the location.
+	Usages        []*AiModelUsage `protobuf:"bytes,2,rep,name=usages,proto3" json:"usages,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListLocationsWithQuotaResponse) Reset() {
-	*x = ListLocationsWithQuotaResponse{}
-	mi := &file_ai_model_proto_msgTypes[16]
+func (x *LocationUsages) Reset() {
+	*x = LocationUsages{}
+	mi := &file_ai_model_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListLocationsWithQuotaResponse) String() string {
+func (x *LocationUsages) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListLocationsWithQuotaResponse) ProtoMessage() {}
+func (*LocationUsages) ProtoMessage() {}
 
-func (x *ListLocationsWithQuotaResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ai_model_proto_msgTypes[16]
+func (x *LocationUsages) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_model_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1104,45 +1328,49 @@ func (x *ListLocationsWithQuotaResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListLocationsWithQuotaResponse.ProtoReflect.Descriptor instead.
-func (*ListLocationsWithQuotaResponse) Descriptor() ([]byte, []int) {
-	return file_ai_model_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use LocationUsages.ProtoReflect.Descriptor instead.
+func (*LocationUsages) Descriptor() ([]byte, []int) {
+	return file_ai_model_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *ListLocationsWithQuotaResponse) GetLocations() []*Location {
+func (x *LocationUsages) GetLocation() string {
 	if x != nil {
-		return x.Locations
+		return x.Location
+	}
+	return ""
+}
+
+func (x *LocationUsages) GetUsages() []*AiModelUsage {
+	if x != nil {
+		return x.Usages
 	}
 	return nil
 }
 
-type ModelLocationQuota struct {
+type ListUsagesBatchResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Location where model quota was evaluated.
-	Location *Location `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
-	// Maximum remaining quota across model SKUs.
-	// A value of -1 indicates that usage data was unavailable (e.g. free-tier
-	// subscriptions) and the actual remaining quota is unknown.
-	MaxRemainingQuota float64 `protobuf:"fixed64,2,opt,name=max_remaining_quota,json=maxRemainingQuota,proto3" json:"max_remaining_quota,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+	// Usage entries grouped by location, sorted by location name.
+	// Locations whose usage query failed are omitted.
+	Locations     []*LocationUsages `protobuf:"bytes,1,rep,name=locations,proto3" json:"locations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ModelLocationQuota) Reset() {
-	*x = ModelLocationQuota{}
-	mi := &file_ai_model_proto_msgTypes[17]
+func (x *ListUsagesBatchResponse) Reset() {
+	*x = ListUsagesBatchResponse{}
+	mi := &file_ai_model_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ModelLocationQuota) String() string {
+func (x *ListUsagesBatchResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ModelLocationQuota) ProtoMessage() {}
+func (*ListUsagesBatchResponse) ProtoMessage() {}
 
-func (x *ModelLocationQuota) ProtoReflect() protoreflect.Message {
-	mi := &file_ai_model_proto_msgTypes[17]
+func (x *ListUsagesBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_model_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1153,54 +1381,1251 @@ func (x *ModelLocationQuota) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ModelLocationQuota.ProtoReflect.Descriptor instead.
-func (*ModelLocationQuota) Descriptor() ([]byte, []int) {
-	return file_ai_model_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use ListUsagesBatchResponse.ProtoReflect.Descriptor instead.
+func (*ListUsagesBatchResponse) Descriptor() ([]byte, []int) {
+	return file_ai_model_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *ModelLocationQuota) GetLocation() *Location {
+func (x *ListUsagesBatchResponse) GetLocations() []*LocationUsages {
 	if x != nil {
-		return x.Location
+		return x.Locations
 	}
 	return nil
 }
 
-func (x *ModelLocationQuota) GetMaxRemainingQuota() float64 {
-	if x != nil {
-		return x.MaxRemainingQuota
-	}
-	return 0
-}
-
-type ListModelLocationsWithQuotaRequest struct {
+type ListLocationsWithQuotaRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Azure context with scope.subscription_id required.
+	// Quota is evaluated at subscription scope; scope.resource_group is ignored.
 	AzureContext *AzureContext `protobuf:"bytes,1,opt,name=azure_context,json=azureContext,proto3" json:"azure_context,omitempty"`
-	// Required model name to evaluate across locations.
-	ModelName string `protobuf:"bytes,2,opt,name=model_name,json=modelName,proto3" json:"model_name,omitempty"`
-	// Optional allow-list. Empty means all locations where the model is available.
+	// Required quota requirements that each returned location must satisfy.
+	Requirements []*QuotaRequirement `protobuf:"bytes,2,rep,name=requirements,proto3" json:"requirements,omitempty"`
+	// Optional allow-list. Empty means all AI Services-supported locations.
+	AllowedLocations []string `protobuf:"bytes,3,rep,name=allowed_locations,json=allowedLocations,proto3" json:"allowed_locations,omitempty"`
+	// Optional cap on the number of locations checked, for a quick partial scan. 0 checks every location.
+	MaxLocations int32 `protobuf:"varint,4,opt,name=max_locations,json=maxLocations,proto3" json:"max_locations,omitempty"`
+	// Locations checked first, in order, when max_locations is set. The others follow by name.
+	PreferredLocations []string `protobuf:"bytes,5,rep,name=preferred_locations,json=preferredLocations,proto3" json:"preferred_locations,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ListLocationsWithQuotaRequest) Reset() {
+	*x = ListLocationsWithQuotaRequest{}
+	mi := &file_ai_model_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLocationsWithQuotaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLocationsWithQuotaRequest) ProtoMessage() {}
+
+func (x *ListLocationsWithQuotaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_model_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLocationsWithQuotaRequest.ProtoReflect.Descriptor instead.
+func (*ListLocationsWithQuotaRequest) Descriptor() ([]byte, []int) {
+	return file_ai_model_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListLocationsWithQuotaRequest) GetAzureContext() *AzureContext {
+	if x != nil {
+		return x.AzureContext
+	}
+	return nil
+}
+
+func (x *ListLocationsWithQuotaRequest) GetRequirements() []*QuotaRequirement {
+	if x != nil {
+		return x.Requirements
+	}
+	return nil
+}
+
+func (x *ListLocationsWithQuotaRequest) GetAllowedLocations() []string {
+	if x != nil {
+		return x.AllowedLocations
+	}
+	return nil
+}
+
+func (x *ListLocationsWithQuotaRequest) GetMaxLocations() int32 {
+	if x != nil {
+		return x.MaxLocations
+	}
+	return 0
+}
+
+func (x *ListLocationsWithQuotaRequest) GetPreferredLocations() []string {
+	if x != nil {
+		return x.PreferredLocations
+	}
+	return nil
+}
+
+type ListLocationsWithQuotaResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Locations that satisfy all quota requirements.
+	Locations []*Location `protobuf:"bytes,1,rep,name=locations,proto3" json:"locations,omitempty"`
+	// Allowed locations that are not AI Services locations. These are ignored and not evaluated for quota.
+	UnsupportedLocations []string `protobuf:"bytes,2,rep,name=unsupported_locations,json=unsupportedLocations,proto3" json:"unsupported_locations,omitempty"`
+	// Locations whose usages could not be fetched, including locations that did not answer within the per-location
+	// timeout.
+	FailedLocations []*AiLocationError `protobuf:"bytes,3,rep,name=failed_locations,json=failedLocations,proto3" json:"failed_locations,omitempty"`
+	// Locations left unchecked because of max_locations, sorted. Non-empty means the scan was partial.
+	SkippedLocations []string `protobuf:"bytes,4,rep,name=skipped_locations,json=skippedLocations,proto3" json:"skipped_locations,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListLocationsWithQuotaResponse) Reset() {
+	*x = ListLocationsWithQuotaResponse{}
+	mi := &file_ai_model_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLocationsWithQuotaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLocationsWithQuotaResponse) ProtoMessage() {}
+
+func (x *ListLocationsWithQuotaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_model_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLocationsWithQuotaResponse.ProtoReflect.Descriptor instead.
+func (*ListLocationsWithQuotaResponse) Descriptor() ([]byte, []int) {
+	return file_ai_model_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListLocationsWithQuotaResponse) GetLocations() []*Location {
+	if x != nil {
+		return x.Locations
+	}
+	return nil
+}
+
+func (x *ListLocationsWithQuotaResponse) GetUnsupportedLocations() []string {
+	if x != nil {
+		return x.UnsupportedLocations
+	}
+	return nil
+}
+
+func (x *ListLocationsWithQuotaResponse) GetFailedLocations() []*AiLocationError {
+	if x != nil {
+		return x.FailedLocations
+	}
+	return nil
+}
+
+func (x *ListLocationsWithQuotaResponse) GetSkippedLocations() []string {
+	if x != nil {
+		return x.SkippedLocations
+	}
+	return nil
+}
+
+type ModelLocationQuota struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Location where model quota was evaluated.
+	Location *Location `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	// Maximum remaining quota across model SKUs.
+	// A value of -1 indicates that usage data was unavailable (e.g. free-tier
+	// subscriptions) and the actual remaining quota is unknown.
+	MaxRemainingQuota float64 `protobuf:"fixed64,2,opt,name=max_remaining_quota,json=maxRemainingQuota,proto3" json:"max_remaining_quota,omitempty"`
+	// Remaining quota the location had to provide: the requested minimum, or the
+	// minimum rounded up to the capacity step when only a provisioned SKU met it.
+	// Only set by ListModelLocationsWithQuota.
+	RequiredQuota float64 `protobuf:"fixed64,3,opt,name=required_quota,json=requiredQuota,proto3" json:"required_quota,omitempty"`
+	// Quota left after a deployment that consumes required_quota (max_remaining_quota - required_quota).
+	// Only set by ListModelLocationsWithQuota, and unset when usage data was unavailable.
+	HeadroomQuota *float64 `protobuf:"fixed64,4,opt,name=headroom_quota,json=headroomQuota,proto3,oneof" json:"headroom_quota,omitempty"`
+	// Percentage of max_remaining_quota that a deployment of required_quota uses.
+	// Set together with headroom_quota.
+	UtilizationPercent *float64 `protobuf:"fixed64,5,opt,name=utilization_percent,json=utilizationPercent,proto3,oneof" json:"utilization_percent,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ModelLocationQuota) Reset() {
+	*x = ModelLocationQuota{}
+	mi := &file_ai_model_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelLocationQuota) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelLocationQuota) ProtoMessage() {}
+
+func (x *ModelLocationQuota) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_model_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelLocationQuota.ProtoReflect.Descriptor instead.
+func (*ModelLocationQuota) Descriptor() ([]byte, []int) {
+	return file_ai_model_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ModelLocationQuota) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *ModelLocationQuota) GetMaxRemainingQuota() float64 {
+	if x != nil {
+		return x.MaxRemainingQuota
+	}
+	return 0
+}
+
+func (x *ModelLocationQuota) GetRequiredQuota() float64 {
+	if x != nil {
+		return x.RequiredQuota
+	}
+	return 0
+}
+
+func (x *ModelLocationQuota) GetHeadroomQuota() float64 {
+	if x != nil && x.HeadroomQuota != nil {
+		return *x.HeadroomQuota
+	}
+	return 0
+}
+
+func (x *ModelLocationQuota) GetUtilizationPercent() float64 {
+	if x != nil && x.UtilizationPercent != nil {
+		return *x.UtilizationPercent
+	}
+	return 0
+}
+
+type ListModelLocationsWithQuotaRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Azure context with scope.subscription_id required.
+	// Quota is evaluated at subscription scope; scope.resource_group is ignored.
+	AzureContext *AzureContext `protobuf:"bytes,1,opt,name=azure_context,json=azureContext,proto3" json:"azure_context,omitempty"`
+	// Required model name to evaluate across locations.
+	ModelName string `protobuf:"bytes,2,opt,name=model_name,json=modelName,proto3" json:"model_name,omitempty"`
+	// Optional allow-list. Empty means all locations where the model is available.
 	AllowedLocations []string `protobuf:"bytes,3,rep,name=allowed_locations,json=allowedLocations,proto3" json:"allowed_locations,omitempty"`
 	// Optional min remaining quota threshold.
-	Quota         *QuotaCheckOptions `protobuf:"bytes,4,opt,name=quota,proto3" json:"quota,omitempty"`
+	Quota *QuotaCheckOptions `protobuf:"bytes,4,opt,name=quota,proto3" json:"quota,omitempty"`
+	// Require remaining AI Services account-count quota at each location, on the usage that tracks accounts of
+	// account_kind. Defaults to false. Leave unset when deploying into an existing account.
+	RequireAccountQuota *bool `protobuf:"varint,5,opt,name=require_account_quota,json=requireAccountQuota,proto3,oneof" json:"require_account_quota,omitempty"`
+	// Minimum remaining account-count quota when require_account_quota is true. Defaults to 1.
+	MinimumAccountQuota *float64 `protobuf:"fixed64,6,opt,name=minimum_account_quota,json=minimumAccountQuota,proto3,oneof" json:"minimum_account_quota,omitempty"`
+	// Suggest, for each unmatched location, the matched location with the most remaining quota.
+	// Defaults to false.
+	IncludeSuggestedAlternatives bool `protobuf:"varint,7,opt,name=include_suggested_alternatives,json=includeSuggestedAlternatives,proto3" json:"include_suggested_alternatives,omitempty"`
+	// Optional capabilities, e.g. ["chatCompletion"]. A location only matches when a version of the model offered
+	// there has at least one of them, and only that version's SKUs count toward its quota. Locations offering only
+	// other versions are reported in model_unavailable_locations.
+	Capabilities []string `protobuf:"bytes,8,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	// Kind of the account to be created, e.g. "AIServices", which selects the account-count usage checked by
+	// require_account_quota (AIServices.S0.AccountCount for "AIServices"). Empty and unknown kinds check
+	// OpenAI.S0.AccountCount.
+	AccountKind string `protobuf:"bytes,9,opt,name=account_kind,json=accountKind,proto3" json:"account_kind,omitempty"`
+	// Optional formats, e.g. ["OpenAI"]. A location only matches when a version of the model offered there is in one
+	// of them, and only that version's SKUs count toward its quota. Locations offering only other formats are
+	// reported in model_unavailable_locations.
+	Formats []string `protobuf:"bytes,10,rep,name=formats,proto3" json:"formats,omitempty"`
+	// Group the matched locations by Azure region pair, e.g. eastus and westus, with their combined remaining quota.
+	// Defaults to false.
+	IncludeRegionPairs bool `protobuf:"varint,11,opt,name=include_region_pairs,json=includeRegionPairs,proto3" json:"include_region_pairs,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ListModelLocationsWithQuotaRequest) Reset() {
+	*x = ListModelLocationsWithQuotaRequest{}
+	mi := &file_ai_model_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListModelLocationsWithQuotaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListModelLocationsWithQuotaRequest) ProtoMessage() {}
+
+func (x *ListModelLocationsWithQuotaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_model_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListModelLocationsWithQuotaRequest.ProtoReflect.Descriptor instead.
+func (*ListModelLocationsWithQuotaRequest) Descriptor() ([]byte, []int) {
+	return file_ai_model_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListModelLocationsWithQuotaRequest) GetAzureContext() *AzureContext {
+	if x != nil {
+		return x.AzureContext
+	}
+	return nil
+}
+
+func (x *ListModelLocationsWithQuotaRequest) GetModelName() string {
+	if x != nil {
+		return x.ModelName
+	}
+	return ""
+}
+
+func (x *ListModelLocationsWithQuotaRequest) GetAllowedLocations() []string {
+	if x != nil {
+		return x.AllowedLocations
+	}
+	return nil
+}
+
+func (x *ListModelLocationsWithQuotaRequest) GetQuota() *QuotaCheckOptions {
+	if x != nil {
+		return x.Quota
+	}
+	return nil
+}
+
+func (x *ListModelLocationsWithQuotaRequest) GetRequireAccountQuota() bool {
+	if x != nil && x.RequireAccountQuota != nil {
+		return *x.RequireAccountQuota
+	}
+	return false
+}
+
+func (x *ListModelLocationsWithQuotaRequest) GetMinimumAccountQuota() float64 {
+	if x != nil && x.MinimumAccountQuota != nil {
+		return *x.MinimumAccountQuota
+	}
+	return 0
+}
+
+func (x *ListModelLocationsWithQuotaRequest) GetIncludeSuggestedAlternatives() bool {
+	if x != nil {
+		return x.IncludeSuggestedAlternatives
+	}
+	return false
+}
+
+func (x *ListModelLocationsWithQuotaRequest) GetCapabilities() []string {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+func (x *ListModelLocationsWithQuotaRequest) GetAccountKind() string {
+	if x != nil {
+		return x.AccountKind
+	}
+	return ""
+}
+
+func (x *ListModelLocationsWithQuotaRequest) GetFormats() []string {
+	if x != nil {
+		return x.Formats
+	}
+	return nil
+}
+
+func (x *ListModelLocationsWithQuotaRequest) GetIncludeRegionPairs() bool {
+	if x != nil {
+		return x.IncludeRegionPairs
+	}
+	return false
+}
+
+type ListModelLocationsWithQuotaResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Locations where the model has sufficient remaining quota.
+	Locations []*ModelLocationQuota `protobuf:"bytes,1,rep,name=locations,proto3" json:"locations,omitempty"`
+	// Allowed locations where the model is not offered. These are not evaluated for quota.
+	ModelUnavailableLocations []string `protobuf:"bytes,2,rep,name=model_unavailable_locations,json=modelUnavailableLocations,proto3" json:"model_unavailable_locations,omitempty"`
+	// Locations where the model is offered but remaining model or account quota is insufficient.
+	InsufficientQuotaLocations []string `protobuf:"bytes,3,rep,name=insufficient_quota_locations,json=insufficientQuotaLocations,proto3" json:"insufficient_quota_locations,omitempty"`
+	// Maps each model_unavailable_locations and insufficient_quota_locations entry to the matched location with
+	// the most remaining quota. Only set when include_suggested_alternatives is true and a location matched.
+	SuggestedAlternatives map[string]string `protobuf:"bytes,4,rep,name=suggested_alternatives,json=suggestedAlternatives,proto3" json:"suggested_alternatives,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Locations where the model is offered but usages could not be fetched, including locations that did not answer
+	// within the per-location timeout.
+	FailedLocations []*AiLocationError `protobuf:"bytes,5,rep,name=failed_locations,json=failedLocations,proto3" json:"failed_locations,omitempty"`
+	// The matched locations grouped by region pair. Only set when include_region_pairs is true.
+	RegionPairs   []*AiRegionPairQuota `protobuf:"bytes,6,rep,name=region_pairs,json=regionPairs,proto3" json:"region_pairs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListModelLocationsWithQuotaResponse) Reset() {
+	*x = ListModelLocationsWithQuotaResponse{}
+	mi := &file_ai_model_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListModelLocationsWithQuotaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListModelLocationsWithQuotaResponse) ProtoMessage() {}
+
+func (x *ListModelLocationsWithQuotaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_model_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListModelLocationsWithQuotaResponse.ProtoReflect.Descriptor instead.
+func (*ListModelLocationsWithQuotaResponse) Descriptor() ([]byte, []int) {
+	return file_ai_model_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListModelLocationsWithQuotaResponse) GetLocations() []*ModelLocationQuota {
+	if x != nil {
+		return x.Locations
+	}
+	return nil
+}
+
+func (x *ListModelLocationsWithQuotaResponse) GetModelUnavailableLocations() []string {
+	if x != nil {
+		return x.ModelUnavailableLocations
+	}
+	return nil
+}
+
+func (x *ListModelLocationsWithQuotaResponse) GetInsufficientQuotaLocations() []string {
+	if x != nil {
+		return x.InsufficientQuotaLocations
+	}
+	return nil
+}
+
+func (x *ListModelLocationsWithQuotaResponse) GetSuggestedAlternatives() map[string]string {
+	if x != nil {
+		return x.SuggestedAlternatives
+	}
+	return nil
+}
+
+func (x *ListModelLocationsWithQuotaResponse) GetFailedLocations() []*AiLocationError {
+	if x != nil {
+		return x.FailedLocations
+	}
+	return nil
+}
+
+func (x *ListModelLocationsWithQuotaResponse) GetRegionPairs() []*AiRegionPairQuota {
+	if x != nil {
+		return x.RegionPairs
+	}
+	return nil
+}
+
+// AiRegionPairQuota is the combined remaining quota of matched locations that form an Azure region pair.
+type AiRegionPairQuota struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The matched locations of the pair, sorted. A matched location whose paired region did not match, or that has no
+	// paired region, is reported alone.
+	Locations []string `protobuf:"bytes,1,rep,name=locations,proto3" json:"locations,omitempty"`
+	// Sum of max_remaining_quota across locations, or -1 when usage data was unavailable for any of them.
+	CombinedRemainingQuota float64 `protobuf:"fixed64,2,opt,name=combined_remaining_quota,json=combinedRemainingQuota,proto3" json:"combined_remaining_quota,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *AiRegionPairQuota) Reset() {
+	*x = AiRegionPairQuota{}
+	mi := &file_ai_model_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AiRegionPairQuota) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AiRegionPairQuota) ProtoMessage() {}
+
+func (x *AiRegionPairQuota) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_model_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AiRegionPairQuota.ProtoReflect.Descriptor instead.
+func (*AiRegionPairQuota) Descriptor() ([]byte, []int) {
+	return file_ai_model_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AiRegionPairQuota) GetLocations() []string {
+	if x != nil {
+		return x.Locations
+	}
+	return nil
+}
+
+func (x *AiRegionPairQuota) GetCombinedRemainingQuota() float64 {
+	if x != nil {
+		return x.CombinedRemainingQuota
+	}
+	return 0
+}
+
+type RecommendCapacityRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Azure context with scope.subscription_id required.
+	AzureContext *AzureContext `protobuf:"bytes,1,opt,name=azure_context,json=azureContext,proto3" json:"azure_context,omitempty"`
+	// Required model name, e.g. "gpt-4o".
+	ModelName string `protobuf:"bytes,2,opt,name=model_name,json=modelName,proto3" json:"model_name,omitempty"`
+	// Required model version, e.g. "2024-08-06".
+	Version string `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	// Required SKU name, e.g. "GlobalStandard".
+	SkuName string `protobuf:"bytes,4,opt,name=sku_name,json=skuName,proto3" json:"sku_name,omitempty"`
+	// Required location where the deployment will be created.
+	Location      string `protobuf:"bytes,5,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecommendCapacityRequest) Reset() {
+	*x = RecommendCapacityRequest{}
+	mi := &file_ai_model_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecommendCapacityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecommendCapacityRequest) ProtoMessage() {}
+
+func (x *RecommendCapacityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_model_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecommendCapacityRequest.ProtoReflect.Descriptor instead.
+func (*RecommendCapacityRequest) Descriptor() ([]byte, []int) {
+	return file_ai_model_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RecommendCapacityRequest) GetAzureContext() *AzureContext {
+	if x != nil {
+		return x.AzureContext
+	}
+	return nil
+}
+
+func (x *RecommendCapacityRequest) GetModelName() string {
+	if x != nil {
+		return x.ModelName
+	}
+	return ""
+}
+
+func (x *RecommendCapacityRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *RecommendCapacityRequest) GetSkuName() string {
+	if x != nil {
+		return x.SkuName
+	}
+	return ""
+}
+
+func (x *RecommendCapacityRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+type RecommendCapacityResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Recommended deployment capacity in units. 0 when no valid capacity fits in the remaining quota.
+	Capacity int32 `protobuf:"varint,1,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	// True when the SKU default capacity does not fit in the remaining quota and capacity was lowered to fit.
+	QuotaConstrained bool `protobuf:"varint,2,opt,name=quota_constrained,json=quotaConstrained,proto3" json:"quota_constrained,omitempty"`
+	// Remaining subscription quota for the SKU at the location; unset when usage data is unavailable.
+	RemainingQuota *float64 `protobuf:"fixed64,3,opt,name=remaining_quota,json=remainingQuota,proto3,oneof" json:"remaining_quota,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RecommendCapacityResponse) Reset() {
+	*x = RecommendCapacityResponse{}
+	mi := &file_ai_model_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecommendCapacityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecommendCapacityResponse) ProtoMessage() {}
+
+func (x *RecommendCapacityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_model_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecommendCapacityResponse.ProtoReflect.Descriptor instead.
+func (*RecommendCapacityResponse) Descriptor() ([]byte, []int) {
+	return file_ai_model_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RecommendCapacityResponse) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *RecommendCapacityResponse) GetQuotaConstrained() bool {
+	if x != nil {
+		return x.QuotaConstrained
+	}
+	return false
+}
+
+func (x *RecommendCapacityResponse) GetRemainingQuota() float64 {
+	if x != nil && x.RemainingQuota != nil {
+		return *x.RemainingQuota
+	}
+	return 0
+}
+
+type CheckDeploymentQuotaRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Azure context with scope.subscription_id required.
+	AzureContext *AzureContext `protobuf:"bytes,1,opt,name=azure_context,json=azureContext,proto3" json:"azure_context,omitempty"`
+	// Required model name, e.g. "gpt-4o".
+	ModelName string `protobuf:"bytes,2,opt,name=model_name,json=modelName,proto3" json:"model_name,omitempty"`
+	// Required model version, e.g. "2024-08-06".
+	Version string `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	// Required SKU name, e.g. "GlobalStandard".
+	SkuName string `protobuf:"bytes,4,opt,name=sku_name,json=skuName,proto3" json:"sku_name,omitempty"`
+	// Required location where the deployment will be created.
+	Location string `protobuf:"bytes,5,opt,name=location,proto3" json:"location,omitempty"`
+	// Desired deployment capacity in units. 0 checks the SKU default capacity.
+	Capacity      int32 `protobuf:"varint,6,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckDeploymentQuotaRequest) Reset() {
+	*x = CheckDeploymentQuotaRequest{}
+	mi := &file_ai_model_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckDeploymentQuotaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckDeploymentQuotaRequest) ProtoMessage() {}
+
+func (x *CheckDeploymentQuotaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_model_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckDeploymentQuotaRequest.ProtoReflect.Descriptor instead.
+func (*CheckDeploymentQuotaRequest) Descriptor() ([]byte, []int) {
+	return file_ai_model_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CheckDeploymentQuotaRequest) GetAzureContext() *AzureContext {
+	if x != nil {
+		return x.AzureContext
+	}
+	return nil
+}
+
+func (x *CheckDeploymentQuotaRequest) GetModelName() string {
+	if x != nil {
+		return x.ModelName
+	}
+	return ""
+}
+
+func (x *CheckDeploymentQuotaRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *CheckDeploymentQuotaRequest) GetSkuName() string {
+	if x != nil {
+		return x.SkuName
+	}
+	return ""
+}
+
+func (x *CheckDeploymentQuotaRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *CheckDeploymentQuotaRequest) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+type CheckDeploymentQuotaResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// True when the capacity is valid for the SKU and fits in the remaining quota. Also true for a valid capacity
+	// when usage data is unavailable.
+	Fits bool `protobuf:"varint,1,opt,name=fits,proto3" json:"fits,omitempty"`
+	// The capacity that was checked: the requested capacity, or the SKU default when none was requested.
+	Capacity int32 `protobuf:"varint,2,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	// False when the capacity is outside the SKU minimum and maximum or not aligned to its step.
+	CapacityValid bool `protobuf:"varint,3,opt,name=capacity_valid,json=capacityValid,proto3" json:"capacity_valid,omitempty"`
+	// Remaining subscription quota for the SKU at the location; unset when usage data is unavailable.
+	RemainingQuota *float64 `protobuf:"fixed64,4,opt,name=remaining_quota,json=remainingQuota,proto3,oneof" json:"remaining_quota,omitempty"`
+	// Number of AI Services accounts that can still be created at the location; unset when unavailable.
+	AccountQuotaRemaining *float64 `protobuf:"fixed64,5,opt,name=account_quota_remaining,json=accountQuotaRemaining,proto3,oneof" json:"account_quota_remaining,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *CheckDeploymentQuotaResponse) Reset() {
+	*x = CheckDeploymentQuotaResponse{}
+	mi := &file_ai_model_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckDeploymentQuotaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckDeploymentQuotaResponse) ProtoMessage() {}
+
+func (x *CheckDeploymentQuotaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_model_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckDeploymentQuotaResponse.ProtoReflect.Descriptor instead.
+func (*CheckDeploymentQuotaResponse) Descriptor() ([]byte, []int) {
+	return file_ai_model_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CheckDeploymentQuotaResponse) GetFits() bool {
+	if x != nil {
+		return x.Fits
+	}
+	return false
+}
+
+func (x *CheckDeploymentQuotaResponse) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *CheckDeploymentQuotaResponse) GetCapacityValid() bool {
+	if x != nil {
+		return x.CapacityValid
+	}
+	return false
+}
+
+func (x *CheckDeploymentQuotaResponse) GetRemainingQuota() float64 {
+	if x != nil && x.RemainingQuota != nil {
+		return *x.RemainingQuota
+	}
+	return 0
+}
+
+func (x *CheckDeploymentQuotaResponse) GetAccountQuotaRemaining() float64 {
+	if x != nil && x.AccountQuotaRemaining != nil {
+		return *x.AccountQuotaRemaining
+	}
+	return 0
+}
+
+type ListAccountDeploymentsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Azure context with scope.subscription_id and scope.resource_group required.
+	AzureContext *AzureContext `protobuf:"bytes,1,opt,name=azure_context,json=azureContext,proto3" json:"azure_context,omitempty"`
+	// Optional AI Services account name. When empty, every AI Services and Azure OpenAI account in the resource group
+	// is listed.
+	AccountName   string `protobuf:"bytes,2,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccountDeploymentsRequest) Reset() {
+	*x = ListAccountDeploymentsRequest{}
+	mi := &file_ai_model_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccountDeploymentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccountDeploymentsRequest) ProtoMessage() {}
+
+func (x *ListAccountDeploymentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_model_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccountDeploymentsRequest.ProtoReflect.Descriptor instead.
+func (*ListAccountDeploymentsRequest) Descriptor() ([]byte, []int) {
+	return file_ai_model_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListAccountDeploymentsRequest) GetAzureContext() *AzureContext {
+	if x != nil {
+		return x.AzureContext
+	}
+	return nil
+}
+
+func (x *ListAccountDeploymentsRequest) GetAccountName() string {
+	if x != nil {
+		return x.AccountName
+	}
+	return ""
+}
+
+// AiAccountDeployment is a model deployment that exists on a provisioned AI Services account.
+type AiAccountDeployment struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Name              string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // deployment name, unique within the account
+	AccountName       string                 `protobuf:"bytes,2,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
+	Deployment        *AiModelDeployment     `protobuf:"bytes,3,opt,name=deployment,proto3" json:"deployment,omitempty"`                                        // location is the account location; sku only has name and kind
+	ProvisioningState string                 `protobuf:"bytes,4,opt,name=provisioning_state,json=provisioningState,proto3" json:"provisioning_state,omitempty"` // e.g. "Succeeded"
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *AiAccountDeployment) Reset() {
+	*x = AiAccountDeployment{}
+	mi := &file_ai_model_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AiAccountDeployment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AiAccountDeployment) ProtoMessage() {}
+
+func (x *AiAccountDeployment) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_model_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AiAccountDeployment.ProtoReflect.Descriptor instead.
+func (*AiAccountDeployment) Descriptor() ([]byte, []int) {
+	return file_ai_model_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *AiAccountDeployment) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AiAccountDeployment) GetAccountName() string {
+	if x != nil {
+		return x.AccountName
+	}
+	return ""
+}
+
+func (x *AiAccountDeployment) GetDeployment() *AiModelDeployment {
+	if x != nil {
+		return x.Deployment
+	}
+	return nil
+}
+
+func (x *AiAccountDeployment) GetProvisioningState() string {
+	if x != nil {
+		return x.ProvisioningState
+	}
+	return ""
+}
+
+type ListAccountDeploymentsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deployments sorted by account name, then deployment name.
+	Deployments   []*AiAccountDeployment `protobuf:"bytes,1,rep,name=deployments,proto3" json:"deployments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccountDeploymentsResponse) Reset() {
+	*x = ListAccountDeploymentsResponse{}
+	mi := &file_ai_model_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccountDeploymentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccountDeploymentsResponse) ProtoMessage() {}
+
+func (x *ListAccountDeploymentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_model_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccountDeploymentsResponse.ProtoReflect.Descriptor instead.
+func (*ListAccountDeploymentsResponse) Descriptor() ([]byte, []int) {
+	return file_ai_model_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListAccountDeploymentsResponse) GetDeployments() []*AiAccountDeployment {
+	if x != nil {
+		return x.Deployments
+	}
+	return nil
+}
+
+type SummarizeDeployableModelsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Azure context with scope.subscription_id required.
+	AzureContext *AzureContext `protobuf:"bytes,1,opt,name=azure_context,json=azureContext,proto3" json:"azure_context,omitempty"`
+	// Optional locations to evaluate. Empty means all AI Services-supported locations.
+	Locations []string `protobuf:"bytes,2,rep,name=locations,proto3" json:"locations,omitempty"`
+	// Optional SKU deployment kinds to consider ("Global", "DataZone", "Regional", "Provisioned").
+	// Empty means all SKUs.
+	DeploymentKinds []string `protobuf:"bytes,3,rep,name=deployment_kinds,json=deploymentKinds,proto3" json:"deployment_kinds,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SummarizeDeployableModelsRequest) Reset() {
+	*x = SummarizeDeployableModelsRequest{}
+	mi := &file_ai_model_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SummarizeDeployableModelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SummarizeDeployableModelsRequest) ProtoMessage() {}
+
+func (x *SummarizeDeployableModelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_model_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SummarizeDeployableModelsRequest.ProtoReflect.Descriptor instead.
+func (*SummarizeDeployableModelsRequest) Descriptor() ([]byte, []int) {
+	return file_ai_model_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SummarizeDeployableModelsRequest) GetAzureContext() *AzureContext {
+	if x != nil {
+		return x.AzureContext
+	}
+	return nil
+}
+
+func (x *SummarizeDeployableModelsRequest) GetLocations() []string {
+	if x != nil {
+		return x.Locations
+	}
+	return nil
+}
+
+func (x *SummarizeDeployableModelsRequest) GetDeploymentKinds() []string {
+	if x != nil {
+		return x.DeploymentKinds
+	}
+	return nil
+}
+
+// DeployableModelSummary lists the locations where a model can be deployed right now.
+type DeployableModelSummary struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ModelName string                 `protobuf:"bytes,1,opt,name=model_name,json=modelName,proto3" json:"model_name,omitempty"`
+	Format    string                 `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	// Deployable locations sorted by name, with the max remaining quota across the considered SKUs.
+	// A max_remaining_quota of -1 indicates that usage data was unavailable at the location.
+	Locations []*ModelLocationQuota `protobuf:"bytes,3,rep,name=locations,proto3" json:"locations,omitempty"`
+	// Location with the highest remaining quota. Locations without usage data rank below locations with known quota.
+	MostAvailableLocation string `protobuf:"bytes,4,opt,name=most_available_location,json=mostAvailableLocation,proto3" json:"most_available_location,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *DeployableModelSummary) Reset() {
+	*x = DeployableModelSummary{}
+	mi := &file_ai_model_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeployableModelSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeployableModelSummary) ProtoMessage() {}
+
+func (x *DeployableModelSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_model_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeployableModelSummary.ProtoReflect.Descriptor instead.
+func (*DeployableModelSummary) Descriptor() ([]byte, []int) {
+	return file_ai_model_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DeployableModelSummary) GetModelName() string {
+	if x != nil {
+		return x.ModelName
+	}
+	return ""
+}
+
+func (x *DeployableModelSummary) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *DeployableModelSummary) GetLocations() []*ModelLocationQuota {
+	if x != nil {
+		return x.Locations
+	}
+	return nil
+}
+
+func (x *DeployableModelSummary) GetMostAvailableLocation() string {
+	if x != nil {
+		return x.MostAvailableLocation
+	}
+	return ""
+}
+
+type ListRawModelsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Azure context with scope.subscription_id required.
+	AzureContext *AzureContext `protobuf:"bytes,1,opt,name=azure_context,json=azureContext,proto3" json:"azure_context,omitempty"`
+	// Required location to list models for.
+	Location      string `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRawModelsRequest) Reset() {
+	*x = ListRawModelsRequest{}
+	mi := &file_ai_model_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRawModelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRawModelsRequest) ProtoMessage() {}
+
+func (x *ListRawModelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_model_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRawModelsRequest.ProtoReflect.Descriptor instead.
+func (*ListRawModelsRequest) Descriptor() ([]byte, []int) {
+	return file_ai_model_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListRawModelsRequest) GetAzureContext() *AzureContext {
+	if x != nil {
+		return x.AzureContext
+	}
+	return nil
+}
+
+func (x *ListRawModelsRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+type ListRawModelsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// JSON array of the models returned by the Cognitive Services models API, before any conversion or filtering.
+	ModelsJson    string `protobuf:"bytes,1,opt,name=models_json,json=modelsJson,proto3" json:"models_json,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListModelLocationsWithQuotaRequest) Reset() {
-	*x = ListModelLocationsWithQuotaRequest{}
-	mi := &file_ai_model_proto_msgTypes[18]
+func (x *ListRawModelsResponse) Reset() {
+	*x = ListRawModelsResponse{}
+	mi := &file_ai_model_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListModelLocationsWithQuotaRequest) String() string {
+func (x *ListRawModelsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListModelLocationsWithQuotaRequest) ProtoMessage() {}
+func (*ListRawModelsResponse) ProtoMessage() {}
 
-func (x *ListModelLocationsWithQuotaRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ai_model_proto_msgTypes[18]
+func (x *ListRawModelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_model_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1211,62 +2636,189 @@ func (x *ListModelLocationsWithQuotaRequest) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListModelLocationsWithQuotaRequest.ProtoReflect.Descriptor instead.
-func (*ListModelLocationsWithQuotaRequest) Descriptor() ([]byte, []int) {
-	return file_ai_model_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use ListRawModelsResponse.ProtoReflect.Descriptor instead.
+func (*ListRawModelsResponse) Descriptor() ([]byte, []int) {
+	return file_ai_model_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *ListModelLocationsWithQuotaRequest) GetAzureContext() *AzureContext {
+func (x *ListRawModelsResponse) GetModelsJson() string {
+	if x != nil {
+		return x.ModelsJson
+	}
+	return ""
+}
+
+type BuildModelReportRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Azure context with scope.subscription_id required.
+	AzureContext *AzureContext `protobuf:"bytes,1,opt,name=azure_context,json=azureContext,proto3" json:"azure_context,omitempty"`
+	// Required model name, e.g. "gpt-4o". Common variants such as "gpt4o" are resolved.
+	ModelName string `protobuf:"bytes,2,opt,name=model_name,json=modelName,proto3" json:"model_name,omitempty"`
+	// Optional locations to report on. Empty means all AI Services-supported locations.
+	Locations     []string `protobuf:"bytes,3,rep,name=locations,proto3" json:"locations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BuildModelReportRequest) Reset() {
+	*x = BuildModelReportRequest{}
+	mi := &file_ai_model_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuildModelReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildModelReportRequest) ProtoMessage() {}
+
+func (x *BuildModelReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_model_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildModelReportRequest.ProtoReflect.Descriptor instead.
+func (*BuildModelReportRequest) Descriptor() ([]byte, []int) {
+	return file_ai_model_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *BuildModelReportRequest) GetAzureContext() *AzureContext {
 	if x != nil {
 		return x.AzureContext
 	}
 	return nil
 }
 
-func (x *ListModelLocationsWithQuotaRequest) GetModelName() string {
+func (x *BuildModelReportRequest) GetModelName() string {
 	if x != nil {
 		return x.ModelName
 	}
 	return ""
 }
 
-func (x *ListModelLocationsWithQuotaRequest) GetAllowedLocations() []string {
+func (x *BuildModelReportRequest) GetLocations() []string {
 	if x != nil {
-		return x.AllowedLocations
+		return x.Locations
 	}
 	return nil
 }
 
-func (x *ListModelLocationsWithQuotaRequest) GetQuota() *QuotaCheckOptions {
+type BuildModelReportResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Catalog name of the model.
+	ModelName string `protobuf:"bytes,1,opt,name=model_name,json=modelName,proto3" json:"model_name,omitempty"`
+	// Locations offering the model, sorted by location name.
+	Locations []*AiModelReportLocation `protobuf:"bytes,2,rep,name=locations,proto3" json:"locations,omitempty"`
+	// Checked locations that do not offer the model, sorted.
+	UnavailableLocations []string `protobuf:"bytes,3,rep,name=unavailable_locations,json=unavailableLocations,proto3" json:"unavailable_locations,omitempty"`
+	// Locations whose model catalog or usages could not be fetched, sorted. Non-empty means the report may be
+	// incomplete; a location whose usages failed is still listed in locations, with quota_unknown set.
+	FailedLocations []*AiLocationError `protobuf:"bytes,4,rep,name=failed_locations,json=failedLocations,proto3" json:"failed_locations,omitempty"`
+	// Location with the most remaining quota on a deployable SKU, or empty when no SKU is deployable.
+	MostAvailableLocation string `protobuf:"bytes,5,opt,name=most_available_location,json=mostAvailableLocation,proto3" json:"most_available_location,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *BuildModelReportResponse) Reset() {
+	*x = BuildModelReportResponse{}
+	mi := &file_ai_model_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuildModelReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildModelReportResponse) ProtoMessage() {}
+
+func (x *BuildModelReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_model_proto_msgTypes[37]
 	if x != nil {
-		return x.Quota
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildModelReportResponse.ProtoReflect.Descriptor instead.
+func (*BuildModelReportResponse) Descriptor() ([]byte, []int) {
+	return file_ai_model_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *BuildModelReportResponse) GetModelName() string {
+	if x != nil {
+		return x.ModelName
+	}
+	return ""
+}
+
+func (x *BuildModelReportResponse) GetLocations() []*AiModelReportLocation {
+	if x != nil {
+		return x.Locations
 	}
 	return nil
 }
 
-type ListModelLocationsWithQuotaResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Locations where the model has sufficient remaining quota.
-	Locations     []*ModelLocationQuota `protobuf:"bytes,1,rep,name=locations,proto3" json:"locations,omitempty"`
+func (x *BuildModelReportResponse) GetUnavailableLocations() []string {
+	if x != nil {
+		return x.UnavailableLocations
+	}
+	return nil
+}
+
+func (x *BuildModelReportResponse) GetFailedLocations() []*AiLocationError {
+	if x != nil {
+		return x.FailedLocations
+	}
+	return nil
+}
+
+func (x *BuildModelReportResponse) GetMostAvailableLocation() string {
+	if x != nil {
+		return x.MostAvailableLocation
+	}
+	return ""
+}
+
+// AiModelReportLocation lists the versions of a model offered at a location.
+type AiModelReportLocation struct {
+	state    protoimpl.MessageState  `protogen:"open.v1"`
+	Location string                  `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	Versions []*AiModelReportVersion `protobuf:"bytes,2,rep,name=versions,proto3" json:"versions,omitempty"`
+	// True when the location returned no usage data; remaining_quota is then -1 for every SKU.
+	QuotaUnknown  bool `protobuf:"varint,3,opt,name=quota_unknown,json=quotaUnknown,proto3" json:"quota_unknown,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListModelLocationsWithQuotaResponse) Reset() {
-	*x = ListModelLocationsWithQuotaResponse{}
-	mi := &file_ai_model_proto_msgTypes[19]
+func (x *AiModelReportLocation) Reset() {
+	*x = AiModelReportLocation{}
+	mi := &file_ai_model_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListModelLocationsWithQuotaResponse) String() string {
+func (x *AiModelReportLocation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListModelLocationsWithQuotaResponse) ProtoMessage() {}
+func (*AiModelReportLocation) ProtoMessage() {}
 
-func (x *ListModelLocationsWithQuotaResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ai_model_proto_msgTypes[19]
+func (x *AiModelReportLocation) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_model_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1277,36 +2829,205 @@ func (x *ListModelLocationsWithQuotaResponse) ProtoReflect() protoreflect.Messag
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListModelLocationsWithQuotaResponse.ProtoReflect.Descriptor instead.
-func (*ListModelLocationsWithQuotaResponse) Descriptor() ([]byte, []int) {
-	return file_ai_model_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use AiModelReportLocation.ProtoReflect.Descriptor instead.
+func (*AiModelReportLocation) Descriptor() ([]byte, []int) {
+	return file_ai_model_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *ListModelLocationsWithQuotaResponse) GetLocations() []*ModelLocationQuota {
+func (x *AiModelReportLocation) GetLocation() string {
 	if x != nil {
-		return x.Locations
+		return x.Location
+	}
+	return ""
+}
+
+func (x *AiModelReportLocation) GetVersions() []*AiModelReportVersion {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+func (x *AiModelReportLocation) GetQuotaUnknown() bool {
+	if x != nil {
+		return x.QuotaUnknown
+	}
+	return false
+}
+
+type AiModelReportVersion struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Version         string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	IsDefault       bool                   `protobuf:"varint,2,opt,name=is_default,json=isDefault,proto3" json:"is_default,omitempty"`
+	LifecycleStatus string                 `protobuf:"bytes,3,opt,name=lifecycle_status,json=lifecycleStatus,proto3" json:"lifecycle_status,omitempty"`
+	Skus            []*AiModelReportSku    `protobuf:"bytes,4,rep,name=skus,proto3" json:"skus,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AiModelReportVersion) Reset() {
+	*x = AiModelReportVersion{}
+	mi := &file_ai_model_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AiModelReportVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AiModelReportVersion) ProtoMessage() {}
+
+func (x *AiModelReportVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_model_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AiModelReportVersion.ProtoReflect.Descriptor instead.
+func (*AiModelReportVersion) Descriptor() ([]byte, []int) {
+	return file_ai_model_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *AiModelReportVersion) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *AiModelReportVersion) GetIsDefault() bool {
+	if x != nil {
+		return x.IsDefault
+	}
+	return false
+}
+
+func (x *AiModelReportVersion) GetLifecycleStatus() string {
+	if x != nil {
+		return x.LifecycleStatus
+	}
+	return ""
+}
+
+func (x *AiModelReportVersion) GetSkus() []*AiModelReportSku {
+	if x != nil {
+		return x.Skus
+	}
+	return nil
+}
+
+// AiModelReportSku is a deployment SKU with the quota of its usage meter at the location.
+type AiModelReportSku struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Sku          *AiModelSku            `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	CurrentUsage float64                `protobuf:"fixed64,2,opt,name=current_usage,json=currentUsage,proto3" json:"current_usage,omitempty"`
+	Limit        float64                `protobuf:"fixed64,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// limit - current_usage, at least 0, or -1 when the location returned no usage data. A SKU whose usage meter
+	// is missing from the location usage data has 0 remaining.
+	RemainingQuota float64 `protobuf:"fixed64,4,opt,name=remaining_quota,json=remainingQuota,proto3" json:"remaining_quota,omitempty"`
+	// True when remaining_quota covers the SKU's default capacity, or is unknown.
+	Deployable    bool `protobuf:"varint,5,opt,name=deployable,proto3" json:"deployable,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AiModelReportSku) Reset() {
+	*x = AiModelReportSku{}
+	mi := &file_ai_model_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AiModelReportSku) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AiModelReportSku) ProtoMessage() {}
+
+func (x *AiModelReportSku) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_model_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AiModelReportSku.ProtoReflect.Descriptor instead.
+func (*AiModelReportSku) Descriptor() ([]byte, []int) {
+	return file_ai_model_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *AiModelReportSku) GetSku() *AiModelSku {
+	if x != nil {
+		return x.Sku
 	}
 	return nil
 }
 
+func (x *AiModelReportSku) GetCurrentUsage() float64 {
+	if x != nil {
+		return x.CurrentUsage
+	}
+	return 0
+}
+
+func (x *AiModelReportSku) GetLimit() float64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *AiModelReportSku) GetRemainingQuota() float64 {
+	if x != nil {
+		return x.RemainingQuota
+	}
+	return 0
+}
+
+func (x *AiModelReportSku) GetDeployable() bool {
+	if x != nil {
+		return x.Deployable
+	}
+	return false
+}
+
 var File_ai_model_proto protoreflect.FileDescriptor
 
 const file_ai_model_proto_rawDesc = "" +
 	"\n" +
-	"\x0eai_model.proto\x12\x06azdext\x1a\fmodels.proto\"\xda\x01\n" +
+	"\x0eai_model.proto\x12\x06azdext\x1a\fmodels.proto\"\xf2\x01\n" +
 	"\aAiModel\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
 	"\x06format\x18\x02 \x01(\tR\x06format\x12-\n" +
 	"\x10lifecycle_status\x18\x03 \x01(\tB\x02\x18\x01R\x0flifecycleStatus\x12\"\n" +
 	"\fcapabilities\x18\x04 \x03(\tR\fcapabilities\x122\n" +
 	"\bversions\x18\x05 \x03(\v2\x16.azdext.AiModelVersionR\bversions\x12\x1c\n" +
-	"\tlocations\x18\x06 \x03(\tR\tlocations\"\x9c\x01\n" +
+	"\tlocations\x18\x06 \x03(\tR\tlocations\x12\x16\n" +
+	"\x06family\x18\a \x01(\tR\x06family\"\xf8\x02\n" +
 	"\x0eAiModelVersion\x12\x18\n" +
 	"\aversion\x18\x01 \x01(\tR\aversion\x12\x1d\n" +
 	"\n" +
 	"is_default\x18\x02 \x01(\bR\tisDefault\x12&\n" +
 	"\x04skus\x18\x03 \x03(\v2\x12.azdext.AiModelSkuR\x04skus\x12)\n" +
-	"\x10lifecycle_status\x18\x04 \x01(\tR\x0flifecycleStatus\"\xd5\x01\n" +
+	"\x10lifecycle_status\x18\x04 \x01(\tR\x0flifecycleStatus\x12\x16\n" +
+	"\x06format\x18\x05 \x01(\tR\x06format\x12\"\n" +
+	"\fcapabilities\x18\x06 \x03(\tR\fcapabilities\x12Y\n" +
+	"\x11capability_values\x18\a \x03(\v2,.azdext.AiModelVersion.CapabilityValuesEntryR\x10capabilityValues\x1aC\n" +
+	"\x15CapabilityValuesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xfe\x01\n" +
 	"\n" +
 	"AiModelSku\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
@@ -1315,7 +3036,8 @@ const file_ai_model_proto_rawDesc = "" +
 	"\x10default_capacity\x18\x03 \x01(\x05R\x0fdefaultCapacity\x12!\n" +
 	"\fmin_capacity\x18\x04 \x01(\x05R\vminCapacity\x12!\n" +
 	"\fmax_capacity\x18\x05 \x01(\x05R\vmaxCapacity\x12#\n" +
-	"\rcapacity_step\x18\x06 \x01(\x05R\fcapacityStep\"\x84\x02\n" +
+	"\rcapacity_step\x18\x06 \x01(\x05R\fcapacityStep\x12'\n" +
+	"\x0fdeployment_kind\x18\a \x01(\tR\x0edeploymentKind\"\x84\x02\n" +
 	"\x11AiModelDeployment\x12\x1d\n" +
 	"\n" +
 	"model_name\x18\x01 \x01(\tR\tmodelName\x12\x16\n" +
@@ -1335,24 +3057,36 @@ const file_ai_model_proto_rawDesc = "" +
 	"\rcurrent_value\x18\x02 \x01(\x01R\fcurrentValue\x12\x14\n" +
 	"\x05limit\x18\x03 \x01(\x01R\x05limit\"I\n" +
 	"\x11QuotaCheckOptions\x124\n" +
-	"\x16min_remaining_capacity\x18\x01 \x01(\x01R\x14minRemainingCapacity\"\xbe\x01\n" +
+	"\x16min_remaining_capacity\x18\x01 \x01(\x01R\x14minRemainingCapacity\"\xc0\x02\n" +
 	"\x14AiModelFilterOptions\x12\x1c\n" +
 	"\tlocations\x18\x01 \x03(\tR\tlocations\x12\"\n" +
 	"\fcapabilities\x18\x02 \x03(\tR\fcapabilities\x12\x18\n" +
 	"\aformats\x18\x03 \x03(\tR\aformats\x12\x1a\n" +
 	"\bstatuses\x18\x04 \x03(\tR\bstatuses\x12.\n" +
-	"\x13exclude_model_names\x18\x05 \x03(\tR\x11excludeModelNames\"\x96\x01\n" +
+	"\x13exclude_model_names\x18\x05 \x03(\tR\x11excludeModelNames\x12\x16\n" +
+	"\x06intent\x18\x06 \x01(\tR\x06intent\x124\n" +
+	"\x16min_available_capacity\x18\a \x01(\x01R\x14minAvailableCapacity\x122\n" +
+	"\x15default_versions_only\x18\b \x01(\bR\x13defaultVersionsOnly\"\xaf\x02\n" +
 	"\x18AiModelDeploymentOptions\x12\x1c\n" +
 	"\tlocations\x18\x01 \x03(\tR\tlocations\x12\x1a\n" +
 	"\bversions\x18\x02 \x03(\tR\bversions\x12\x12\n" +
 	"\x04skus\x18\x03 \x03(\tR\x04skus\x12\x1f\n" +
-	"\bcapacity\x18\x04 \x01(\x05H\x00R\bcapacity\x88\x01\x01B\v\n" +
+	"\bcapacity\x18\x04 \x01(\x05H\x00R\bcapacity\x88\x01\x01\x12=\n" +
+	"\x1bfallback_to_default_version\x18\x05 \x01(\bR\x18fallbackToDefaultVersion\x123\n" +
+	"\x16prefer_skus_with_quota\x18\x06 \x01(\bR\x13preferSkusWithQuota\x12#\n" +
+	"\rprefer_stable\x18\a \x01(\bR\fpreferStableB\v\n" +
 	"\t_capacity\"\x84\x01\n" +
 	"\x11ListModelsRequest\x129\n" +
 	"\razure_context\x18\x01 \x01(\v2\x14.azdext.AzureContextR\fazureContext\x124\n" +
-	"\x06filter\x18\x02 \x01(\v2\x1c.azdext.AiModelFilterOptionsR\x06filter\"=\n" +
+	"\x06filter\x18\x02 \x01(\v2\x1c.azdext.AiModelFilterOptionsR\x06filter\"\xb8\x01\n" +
 	"\x12ListModelsResponse\x12'\n" +
-	"\x06models\x18\x01 \x03(\v2\x0f.azdext.AiModelR\x06models\"\x9b\x02\n" +
+	"\x06models\x18\x01 \x03(\v2\x0f.azdext.AiModelR\x06models\x12B\n" +
+	"\x10failed_locations\x18\x02 \x03(\v2\x17.azdext.AiLocationErrorR\x0ffailedLocations\x125\n" +
+	"\x16available_capabilities\x18\x03 \x03(\tR\x15availableCapabilities\"e\n" +
+	"\x0fAiLocationError\x12\x1a\n" +
+	"\blocation\x18\x01 \x01(\tR\blocation\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1c\n" +
+	"\tthrottled\x18\x03 \x01(\bR\tthrottled\"\x9b\x02\n" +
 	"\x1eResolveModelDeploymentsRequest\x129\n" +
 	"\razure_context\x18\x01 \x01(\v2\x14.azdext.AzureContextR\fazureContext\x12\x1d\n" +
 	"\n" +
@@ -1361,37 +3095,173 @@ const file_ai_model_proto_rawDesc = "" +
 	"\x05quota\x18\x04 \x01(\v2\x19.azdext.QuotaCheckOptionsR\x05quota\x122\n" +
 	"\x15include_finetune_skus\x18\x05 \x01(\bR\x13includeFinetuneSkus\"^\n" +
 	"\x1fResolveModelDeploymentsResponse\x12;\n" +
-	"\vdeployments\x18\x01 \x03(\v2\x19.azdext.AiModelDeploymentR\vdeployments\"j\n" +
+	"\vdeployments\x18\x01 \x03(\v2\x19.azdext.AiModelDeploymentR\vdeployments\"\xb7\x01\n" +
 	"\x11ListUsagesRequest\x129\n" +
 	"\razure_context\x18\x01 \x01(\v2\x14.azdext.AzureContextR\fazureContext\x12\x1a\n" +
-	"\blocation\x18\x02 \x01(\tR\blocation\"B\n" +
+	"\blocation\x18\x02 \x01(\tR\blocation\x12#\n" +
+	"\rname_prefixes\x18\x03 \x03(\tR\fnamePrefixes\x12&\n" +
+	"\x0fomit_zero_limit\x18\x04 \x01(\bR\romitZeroLimit\"B\n" +
 	"\x12ListUsagesResponse\x12,\n" +
-	"\x06usages\x18\x01 \x03(\v2\x14.azdext.AiModelUsageR\x06usages\"\xc5\x01\n" +
+	"\x06usages\x18\x01 \x03(\v2\x14.azdext.AiModelUsageR\x06usages\"\xbe\x01\n" +
+	"\x16ListUsagesBatchRequest\x129\n" +
+	"\razure_context\x18\x01 \x01(\v2\x14.azdext.AzureContextR\fazureContext\x12\x1c\n" +
+	"\tlocations\x18\x02 \x03(\tR\tlocations\x12#\n" +
+	"\rname_prefixes\x18\x03 \x03(\tR\fnamePrefixes\x12&\n" +
+	"\x0fomit_zero_limit\x18\x04 \x01(\bR\romitZeroLimit\"Z\n" +
+	"\x0eLocationUsages\x12\x1a\n" +
+	"\blocation\x18\x01 \x01(\tR\blocation\x12,\n" +
+	"\x06usages\x18\x02 \x03(\v2\x14.azdext.AiModelUsageR\x06usages\"O\n" +
+	"\x17ListUsagesBatchResponse\x124\n" +
+	"\tlocations\x18\x01 \x03(\v2\x16.azdext.LocationUsagesR\tlocations\"\x9b\x02\n" +
 	"\x1dListLocationsWithQuotaRequest\x129\n" +
 	"\razure_context\x18\x01 \x01(\v2\x14.azdext.AzureContextR\fazureContext\x12<\n" +
 	"\frequirements\x18\x02 \x03(\v2\x18.azdext.QuotaRequirementR\frequirements\x12+\n" +
-	"\x11allowed_locations\x18\x03 \x03(\tR\x10allowedLocations\"P\n" +
+	"\x11allowed_locations\x18\x03 \x03(\tR\x10allowedLocations\x12#\n" +
+	"\rmax_locations\x18\x04 \x01(\x05R\fmaxLocations\x12/\n" +
+	"\x13preferred_locations\x18\x05 \x03(\tR\x12preferredLocations\"\xf6\x01\n" +
 	"\x1eListLocationsWithQuotaResponse\x12.\n" +
-	"\tlocations\x18\x01 \x03(\v2\x10.azdext.LocationR\tlocations\"r\n" +
+	"\tlocations\x18\x01 \x03(\v2\x10.azdext.LocationR\tlocations\x123\n" +
+	"\x15unsupported_locations\x18\x02 \x03(\tR\x14unsupportedLocations\x12B\n" +
+	"\x10failed_locations\x18\x03 \x03(\v2\x17.azdext.AiLocationErrorR\x0ffailedLocations\x12+\n" +
+	"\x11skipped_locations\x18\x04 \x03(\tR\x10skippedLocations\"\xa6\x02\n" +
 	"\x12ModelLocationQuota\x12,\n" +
 	"\blocation\x18\x01 \x01(\v2\x10.azdext.LocationR\blocation\x12.\n" +
-	"\x13max_remaining_quota\x18\x02 \x01(\x01R\x11maxRemainingQuota\"\xdc\x01\n" +
+	"\x13max_remaining_quota\x18\x02 \x01(\x01R\x11maxRemainingQuota\x12%\n" +
+	"\x0erequired_quota\x18\x03 \x01(\x01R\rrequiredQuota\x12*\n" +
+	"\x0eheadroom_quota\x18\x04 \x01(\x01H\x00R\rheadroomQuota\x88\x01\x01\x124\n" +
+	"\x13utilization_percent\x18\x05 \x01(\x01H\x01R\x12utilizationPercent\x88\x01\x01B\x11\n" +
+	"\x0f_headroom_quotaB\x16\n" +
+	"\x14_utilization_percent\"\xdb\x04\n" +
 	"\"ListModelLocationsWithQuotaRequest\x129\n" +
 	"\razure_context\x18\x01 \x01(\v2\x14.azdext.AzureContextR\fazureContext\x12\x1d\n" +
 	"\n" +
 	"model_name\x18\x02 \x01(\tR\tmodelName\x12+\n" +
 	"\x11allowed_locations\x18\x03 \x03(\tR\x10allowedLocations\x12/\n" +
-	"\x05quota\x18\x04 \x01(\v2\x19.azdext.QuotaCheckOptionsR\x05quota\"_\n" +
+	"\x05quota\x18\x04 \x01(\v2\x19.azdext.QuotaCheckOptionsR\x05quota\x127\n" +
+	"\x15require_account_quota\x18\x05 \x01(\bH\x00R\x13requireAccountQuota\x88\x01\x01\x127\n" +
+	"\x15minimum_account_quota\x18\x06 \x01(\x01H\x01R\x13minimumAccountQuota\x88\x01\x01\x12D\n" +
+	"\x1einclude_suggested_alternatives\x18\a \x01(\bR\x1cincludeSuggestedAlternatives\x12\"\n" +
+	"\fcapabilities\x18\b \x03(\tR\fcapabilities\x12!\n" +
+	"\faccount_kind\x18\t \x01(\tR\vaccountKind\x12\x18\n" +
+	"\aformats\x18\n" +
+	" \x03(\tR\aformats\x120\n" +
+	"\x14include_region_pairs\x18\v \x01(\bR\x12includeRegionPairsB\x18\n" +
+	"\x16_require_account_quotaB\x18\n" +
+	"\x16_minimum_account_quota\"\xac\x04\n" +
 	"#ListModelLocationsWithQuotaResponse\x128\n" +
-	"\tlocations\x18\x01 \x03(\v2\x1a.azdext.ModelLocationQuotaR\tlocations2\xe7\x03\n" +
+	"\tlocations\x18\x01 \x03(\v2\x1a.azdext.ModelLocationQuotaR\tlocations\x12>\n" +
+	"\x1bmodel_unavailable_locations\x18\x02 \x03(\tR\x19modelUnavailableLocations\x12@\n" +
+	"\x1cinsufficient_quota_locations\x18\x03 \x03(\tR\x1ainsufficientQuotaLocations\x12}\n" +
+	"\x16suggested_alternatives\x18\x04 \x03(\v2F.azdext.ListModelLocationsWithQuotaResponse.SuggestedAlternativesEntryR\x15suggestedAlternatives\x12B\n" +
+	"\x10failed_locations\x18\x05 \x03(\v2\x17.azdext.AiLocationErrorR\x0ffailedLocations\x12<\n" +
+	"\fregion_pairs\x18\x06 \x03(\v2\x19.azdext.AiRegionPairQuotaR\vregionPairs\x1aH\n" +
+	"\x1aSuggestedAlternativesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"k\n" +
+	"\x11AiRegionPairQuota\x12\x1c\n" +
+	"\tlocations\x18\x01 \x03(\tR\tlocations\x128\n" +
+	"\x18combined_remaining_quota\x18\x02 \x01(\x01R\x16combinedRemainingQuota\"\xc5\x01\n" +
+	"\x18RecommendCapacityRequest\x129\n" +
+	"\razure_context\x18\x01 \x01(\v2\x14.azdext.AzureContextR\fazureContext\x12\x1d\n" +
+	"\n" +
+	"model_name\x18\x02 \x01(\tR\tmodelName\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12\x19\n" +
+	"\bsku_name\x18\x04 \x01(\tR\askuName\x12\x1a\n" +
+	"\blocation\x18\x05 \x01(\tR\blocation\"\xa6\x01\n" +
+	"\x19RecommendCapacityResponse\x12\x1a\n" +
+	"\bcapacity\x18\x01 \x01(\x05R\bcapacity\x12+\n" +
+	"\x11quota_constrained\x18\x02 \x01(\bR\x10quotaConstrained\x12,\n" +
+	"\x0fremaining_quota\x18\x03 \x01(\x01H\x00R\x0eremainingQuota\x88\x01\x01B\x12\n" +
+	"\x10_remaining_quota\"\xe4\x01\n" +
+	"\x1bCheckDeploymentQuotaRequest\x129\n" +
+	"\razure_context\x18\x01 \x01(\v2\x14.azdext.AzureContextR\fazureContext\x12\x1d\n" +
+	"\n" +
+	"model_name\x18\x02 \x01(\tR\tmodelName\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12\x19\n" +
+	"\bsku_name\x18\x04 \x01(\tR\askuName\x12\x1a\n" +
+	"\blocation\x18\x05 \x01(\tR\blocation\x12\x1a\n" +
+	"\bcapacity\x18\x06 \x01(\x05R\bcapacity\"\x90\x02\n" +
+	"\x1cCheckDeploymentQuotaResponse\x12\x12\n" +
+	"\x04fits\x18\x01 \x01(\bR\x04fits\x12\x1a\n" +
+	"\bcapacity\x18\x02 \x01(\x05R\bcapacity\x12%\n" +
+	"\x0ecapacity_valid\x18\x03 \x01(\bR\rcapacityValid\x12,\n" +
+	"\x0fremaining_quota\x18\x04 \x01(\x01H\x00R\x0eremainingQuota\x88\x01\x01\x12;\n" +
+	"\x17account_quota_remaining\x18\x05 \x01(\x01H\x01R\x15accountQuotaRemaining\x88\x01\x01B\x12\n" +
+	"\x10_remaining_quotaB\x1a\n" +
+	"\x18_account_quota_remaining\"}\n" +
+	"\x1dListAccountDeploymentsRequest\x129\n" +
+	"\razure_context\x18\x01 \x01(\v2\x14.azdext.AzureContextR\fazureContext\x12!\n" +
+	"\faccount_name\x18\x02 \x01(\tR\vaccountName\"\xb6\x01\n" +
+	"\x13AiAccountDeployment\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\faccount_name\x18\x02 \x01(\tR\vaccountName\x129\n" +
+	"\n" +
+	"deployment\x18\x03 \x01(\v2\x19.azdext.AiModelDeploymentR\n" +
+	"deployment\x12-\n" +
+	"\x12provisioning_state\x18\x04 \x01(\tR\x11provisioningState\"_\n" +
+	"\x1eListAccountDeploymentsResponse\x12=\n" +
+	"\vdeployments\x18\x01 \x03(\v2\x1b.azdext.AiAccountDeploymentR\vdeployments\"\xa6\x01\n" +
+	" SummarizeDeployableModelsRequest\x129\n" +
+	"\razure_context\x18\x01 \x01(\v2\x14.azdext.AzureContextR\fazureContext\x12\x1c\n" +
+	"\tlocations\x18\x02 \x03(\tR\tlocations\x12)\n" +
+	"\x10deployment_kinds\x18\x03 \x03(\tR\x0fdeploymentKinds\"\xc1\x01\n" +
+	"\x16DeployableModelSummary\x12\x1d\n" +
+	"\n" +
+	"model_name\x18\x01 \x01(\tR\tmodelName\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\x128\n" +
+	"\tlocations\x18\x03 \x03(\v2\x1a.azdext.ModelLocationQuotaR\tlocations\x126\n" +
+	"\x17most_available_location\x18\x04 \x01(\tR\x15mostAvailableLocation\"m\n" +
+	"\x14ListRawModelsRequest\x129\n" +
+	"\razure_context\x18\x01 \x01(\v2\x14.azdext.AzureContextR\fazureContext\x12\x1a\n" +
+	"\blocation\x18\x02 \x01(\tR\blocation\"8\n" +
+	"\x15ListRawModelsResponse\x12\x1f\n" +
+	"\vmodels_json\x18\x01 \x01(\tR\n" +
+	"modelsJson\"\x91\x01\n" +
+	"\x17BuildModelReportRequest\x129\n" +
+	"\razure_context\x18\x01 \x01(\v2\x14.azdext.AzureContextR\fazureContext\x12\x1d\n" +
+	"\n" +
+	"model_name\x18\x02 \x01(\tR\tmodelName\x12\x1c\n" +
+	"\tlocations\x18\x03 \x03(\tR\tlocations\"\xa7\x02\n" +
+	"\x18BuildModelReportResponse\x12\x1d\n" +
+	"\n" +
+	"model_name\x18\x01 \x01(\tR\tmodelName\x12;\n" +
+	"\tlocations\x18\x02 \x03(\v2\x1d.azdext.AiModelReportLocationR\tlocations\x123\n" +
+	"\x15unavailable_locations\x18\x03 \x03(\tR\x14unavailableLocations\x12B\n" +
+	"\x10failed_locations\x18\x04 \x03(\v2\x17.azdext.AiLocationErrorR\x0ffailedLocations\x126\n" +
+	"\x17most_available_location\x18\x05 \x01(\tR\x15mostAvailableLocation\"\x92\x01\n" +
+	"\x15AiModelReportLocation\x12\x1a\n" +
+	"\blocation\x18\x01 \x01(\tR\blocation\x128\n" +
+	"\bversions\x18\x02 \x03(\v2\x1c.azdext.AiModelReportVersionR\bversions\x12#\n" +
+	"\rquota_unknown\x18\x03 \x01(\bR\fquotaUnknown\"\xa8\x01\n" +
+	"\x14AiModelReportVersion\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12\x1d\n" +
+	"\n" +
+	"is_default\x18\x02 \x01(\bR\tisDefault\x12)\n" +
+	"\x10lifecycle_status\x18\x03 \x01(\tR\x0flifecycleStatus\x12,\n" +
+	"\x04skus\x18\x04 \x03(\v2\x18.azdext.AiModelReportSkuR\x04skus\"\xbc\x01\n" +
+	"\x10AiModelReportSku\x12$\n" +
+	"\x03sku\x18\x01 \x01(\v2\x12.azdext.AiModelSkuR\x03sku\x12#\n" +
+	"\rcurrent_usage\x18\x02 \x01(\x01R\fcurrentUsage\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x01R\x05limit\x12'\n" +
+	"\x0fremaining_quota\x18\x04 \x01(\x01R\x0eremainingQuota\x12\x1e\n" +
+	"\n" +
+	"deployable\x18\x05 \x01(\bR\n" +
+	"deployable2\xef\b\n" +
 	"\x0eAiModelService\x12C\n" +
 	"\n" +
 	"ListModels\x12\x19.azdext.ListModelsRequest\x1a\x1a.azdext.ListModelsResponse\x12j\n" +
 	"\x17ResolveModelDeployments\x12&.azdext.ResolveModelDeploymentsRequest\x1a'.azdext.ResolveModelDeploymentsResponse\x12C\n" +
 	"\n" +
-	"ListUsages\x12\x19.azdext.ListUsagesRequest\x1a\x1a.azdext.ListUsagesResponse\x12g\n" +
+	"ListUsages\x12\x19.azdext.ListUsagesRequest\x1a\x1a.azdext.ListUsagesResponse\x12R\n" +
+	"\x0fListUsagesBatch\x12\x1e.azdext.ListUsagesBatchRequest\x1a\x1f.azdext.ListUsagesBatchResponse\x12g\n" +
 	"\x16ListLocationsWithQuota\x12%.azdext.ListLocationsWithQuotaRequest\x1a&.azdext.ListLocationsWithQuotaResponse\x12v\n" +
-	"\x1bListModelLocationsWithQuota\x12*.azdext.ListModelLocationsWithQuotaRequest\x1a+.azdext.ListModelLocationsWithQuotaResponseB/Z-github.com/azure/azure-dev/cli/azd/pkg/azdextb\x06proto3"
+	"\x1bListModelLocationsWithQuota\x12*.azdext.ListModelLocationsWithQuotaRequest\x1a+.azdext.ListModelLocationsWithQuotaResponse\x12X\n" +
+	"\x11RecommendCapacity\x12 .azdext.RecommendCapacityRequest\x1a!.azdext.RecommendCapacityResponse\x12a\n" +
+	"\x14CheckDeploymentQuota\x12#.azdext.CheckDeploymentQuotaRequest\x1a$.azdext.CheckDeploymentQuotaResponse\x12g\n" +
+	"\x16ListAccountDeployments\x12%.azdext.ListAccountDeploymentsRequest\x1a&.azdext.ListAccountDeploymentsResponse\x12g\n" +
+	"\x19SummarizeDeployableModels\x12(.azdext.SummarizeDeployableModelsRequest\x1a\x1e.azdext.DeployableModelSummary0\x01\x12L\n" +
+	"\rListRawModels\x12\x1c.azdext.ListRawModelsRequest\x1a\x1d.azdext.ListRawModelsResponse\x12U\n" +
+	"\x10BuildModelReport\x12\x1f.azdext.BuildModelReportRequest\x1a .azdext.BuildModelReportResponseB/Z-github.com/azure/azure-dev/cli/azd/pkg/azdextb\x06proto3"
 
 var (
 	file_ai_model_proto_rawDescOnce sync.Once
@@ -1405,7 +3275,7 @@ func file_ai_model_proto_rawDescGZIP() []byte {
 	return file_ai_model_proto_rawDescData
 }
 
-var file_ai_model_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_ai_model_proto_msgTypes = make([]protoimpl.MessageInfo, 43)
 var file_ai_model_proto_goTypes = []any{
 	(*AiModel)(nil),                             // 0: azdext.AiModel
 	(*AiModelVersion)(nil),                      // 1: azdext.AiModelVersion
@@ -1418,53 +3288,113 @@ var file_ai_model_proto_goTypes = []any{
 	(*AiModelDeploymentOptions)(nil),            // 8: azdext.AiModelDeploymentOptions
 	(*ListModelsRequest)(nil),                   // 9: azdext.ListModelsRequest
 	(*ListModelsResponse)(nil),                  // 10: azdext.ListModelsResponse
-	(*ResolveModelDeploymentsRequest)(nil),      // 11: azdext.ResolveModelDeploymentsRequest
-	(*ResolveModelDeploymentsResponse)(nil),     // 12: azdext.ResolveModelDeploymentsResponse
-	(*ListUsagesRequest)(nil),                   // 13: azdext.ListUsagesRequest
-	(*ListUsagesResponse)(nil),                  // 14: azdext.ListUsagesResponse
-	(*ListLocationsWithQuotaRequest)(nil),       // 15: azdext.ListLocationsWithQuotaRequest
-	(*ListLocationsWithQuotaResponse)(nil),      // 16: azdext.ListLocationsWithQuotaResponse
-	(*ModelLocationQuota)(nil),                  // 17: azdext.ModelLocationQuota
-	(*ListModelLocationsWithQuotaRequest)(nil),  // 18: azdext.ListModelLocationsWithQuotaRequest
-	(*ListModelLocationsWithQuotaResponse)(nil), // 19: azdext.ListModelLocationsWithQuotaResponse
-	(*AzureContext)(nil),                        // 20: azdext.AzureContext
-	(*Location)(nil),                            // 21: azdext.Location
+	(*AiLocationError)(nil),                     // 11: azdext.AiLocationError
+	(*ResolveModelDeploymentsRequest)(nil),      // 12: azdext.ResolveModelDeploymentsRequest
+	(*ResolveModelDeploymentsResponse)(nil),     // 13: azdext.ResolveModelDeploymentsResponse
+	(*ListUsagesRequest)(nil),                   // 14: azdext.ListUsagesRequest
+	(*ListUsagesResponse)(nil),                  // 15: azdext.ListUsagesResponse
+	(*ListUsagesBatchRequest)(nil),              // 16: azdext.ListUsagesBatchRequest
+	(*LocationUsages)(nil),                      // 17: azdext.LocationUsages
+	(*ListUsagesBatchResponse)(nil),             // 18: azdext.ListUsagesBatchResponse
+	(*ListLocationsWithQuotaRequest)(nil),       // 19: azdext.ListLocationsWithQuotaRequest
+	(*ListLocationsWithQuotaResponse)(nil),      // 20: azdext.ListLocationsWithQuotaResponse
+	(*ModelLocationQuota)(nil),                  // 21: azdext.ModelLocationQuota
+	(*ListModelLocationsWithQuotaRequest)(nil),  // 22: azdext.ListModelLocationsWithQuotaRequest
+	(*ListModelLocationsWithQuotaResponse)(nil), // 23: azdext.ListModelLocationsWithQuotaResponse
+	(*AiRegionPairQuota)(nil),                   // 24: azdext.AiRegionPairQuota
+	(*RecommendCapacityRequest)(nil),            // 25: azdext.RecommendCapacityRequest
+	(*RecommendCapacityResponse)(nil),           // 26: azdext.RecommendCapacityResponse
+	(*CheckDeploymentQuotaRequest)(nil),         // 27: azdext.CheckDeploymentQuotaRequest
+	(*CheckDeploymentQuotaResponse)(nil),        // 28: azdext.CheckDeploymentQuotaResponse
+	(*ListAccountDeploymentsRequest)(nil),       // 29: azdext.ListAccountDeploymentsRequest
+	(*AiAccountDeployment)(nil),                 // 30: azdext.AiAccountDeployment
+	(*ListAccountDeploymentsResponse)(nil),      // 31: azdext.ListAccountDeploymentsResponse
+	(*SummarizeDeployableModelsRequest)(nil),    // 32: azdext.SummarizeDeployableModelsRequest
+	(*DeployableModelSummary)(nil),              // 33: azdext.DeployableModelSummary
+	(*ListRawModelsRequest)(nil),                // 34: azdext.ListRawModelsRequest
+	(*ListRawModelsResponse)(nil),               // 35: azdext.ListRawModelsResponse
+	(*BuildModelReportRequest)(nil),             // 36: azdext.BuildModelReportRequest
+	(*BuildModelReportResponse)(nil),            // 37: azdext.BuildModelReportResponse
+	(*AiModelReportLocation)(nil),               // 38: azdext.AiModelReportLocation
+	(*AiModelReportVersion)(nil),                // 39: azdext.AiModelReportVersion
+	(*AiModelReportSku)(nil),                    // 40: azdext.AiModelReportSku
+	nil,                                         // 41: azdext.AiModelVersion.CapabilityValuesEntry
+	nil,                                         // 42: azdext.ListModelLocationsWithQuotaResponse.SuggestedAlternativesEntry
+	(*AzureContext)(nil),                        // 43: azdext.AzureContext
+	(*Location)(nil),                            // 44: azdext.Location
 }
 var file_ai_model_proto_depIdxs = []int32{
 	1,  // 0: azdext.AiModel.versions:type_name -> azdext.AiModelVersion
 	2,  // 1: azdext.AiModelVersion.skus:type_name -> azdext.AiModelSku
-	2,  // 2: azdext.AiModelDeployment.sku:type_name -> azdext.AiModelSku
-	20, // 3: azdext.ListModelsRequest.azure_context:type_name -> azdext.AzureContext
-	7,  // 4: azdext.ListModelsRequest.filter:type_name -> azdext.AiModelFilterOptions
-	0,  // 5: azdext.ListModelsResponse.models:type_name -> azdext.AiModel
-	20, // 6: azdext.ResolveModelDeploymentsRequest.azure_context:type_name -> azdext.AzureContext
-	8,  // 7: azdext.ResolveModelDeploymentsRequest.options:type_name -> azdext.AiModelDeploymentOptions
-	6,  // 8: azdext.ResolveModelDeploymentsRequest.quota:type_name -> azdext.QuotaCheckOptions
-	3,  // 9: azdext.ResolveModelDeploymentsResponse.deployments:type_name -> azdext.AiModelDeployment
-	20, // 10: azdext.ListUsagesRequest.azure_context:type_name -> azdext.AzureContext
-	5,  // 11: azdext.ListUsagesResponse.usages:type_name -> azdext.AiModelUsage
-	20, // 12: azdext.ListLocationsWithQuotaRequest.azure_context:type_name -> azdext.AzureContext
-	4,  // 13: azdext.ListLocationsWithQuotaRequest.requirements:type_name -> azdext.QuotaRequirement
-	21, // 14: azdext.ListLocationsWithQuotaResponse.locations:type_name -> azdext.Location
-	21, // 15: azdext.ModelLocationQuota.location:type_name -> azdext.Location
-	20, // 16: azdext.ListModelLocationsWithQuotaRequest.azure_context:type_name -> azdext.AzureContext
-	6,  // 17: azdext.ListModelLocationsWithQuotaRequest.quota:type_name -> azdext.QuotaCheckOptions
-	17, // 18: azdext.ListModelLocationsWithQuotaResponse.locations:type_name -> azdext.ModelLocationQuota
-	9,  // 19: azdext.AiModelService.ListModels:input_type -> azdext.ListModelsRequest
-	11, // 20: azdext.AiModelService.ResolveModelDeployments:input_type -> azdext.ResolveModelDeploymentsRequest
-	13, // 21: azdext.AiModelService.ListUsages:input_type -> azdext.ListUsagesRequest
-	15, // 22: azdext.AiModelService.ListLocationsWithQuota:input_type -> azdext.ListLocationsWithQuotaRequest
-	18, // 23: azdext.AiModelService.ListModelLocationsWithQuota:input_type -> azdext.ListModelLocationsWithQuotaRequest
-	10, // 24: azdext.AiModelService.ListModels:output_type -> azdext.ListModelsResponse
-	12, // 25: azdext.AiModelService.ResolveModelDeployments:output_type -> azdext.ResolveModelDeploymentsResponse
-	14, // 26: azdext.AiModelService.ListUsages:output_type -> azdext.ListUsagesResponse
-	16, // 27: azdext.AiModelService.ListLocationsWithQuota:output_type -> azdext.ListLocationsWithQuotaResponse
-	19, // 28: azdext.AiModelService.ListModelLocationsWithQuota:output_type -> azdext.ListModelLocationsWithQuotaResponse
-	24, // [24:29] is the sub-list for method output_type
-	19, // [19:24] is the sub-list for method input_type
-	19, // [19:19] is the sub-list for extension type_name
-	19, // [19:19] is the sub-list for extension extendee
-	0,  // [0:19] is the sub-list for field type_name
+	41, // 2: azdext.AiModelVersion.capability_values:type_name -> azdext.AiModelVersion.CapabilityValuesEntry
+	2,  // 3: azdext.AiModelDeployment.sku:type_name -> azdext.AiModelSku
+	43, // 4: azdext.ListModelsRequest.azure_context:type_name -> azdext.AzureContext
+	7,  // 5: azdext.ListModelsRequest.filter:type_name -> azdext.AiModelFilterOptions
+	0,  // 6: azdext.ListModelsResponse.models:type_name -> azdext.AiModel
+	11, // 7: azdext.ListModelsResponse.failed_locations:type_name -> azdext.AiLocationError
+	43, // 8: azdext.ResolveModelDeploymentsRequest.azure_context:type_name -> azdext.AzureContext
+	8,  // 9: azdext.ResolveModelDeploymentsRequest.options:type_name -> azdext.AiModelDeploymentOptions
+	6,  // 10: azdext.ResolveModelDeploymentsRequest.quota:type_name -> azdext.QuotaCheckOptions
+	3,  // 11: azdext.ResolveModelDeploymentsResponse.deployments:type_name -> azdext.AiModelDeployment
+	43, // 12: azdext.ListUsagesRequest.azure_context:type_name -> azdext.AzureContext
+	5,  // 13: azdext.ListUsagesResponse.usages:type_name -> azdext.AiModelUsage
+	43, // 14: azdext.ListUsagesBatchRequest.azure_context:type_name -> azdext.AzureContext
+	5,  // 15: azdext.LocationUsages.usages:type_name -> azdext.AiModelUsage
+	17, // 16: azdext.ListUsagesBatchResponse.locations:type_name -> azdext.LocationUsages
+	43, // 17: azdext.ListLocationsWithQuotaRequest.azure_context:type_name -> azdext.AzureContext
+	4,  // 18: azdext.ListLocationsWithQuotaRequest.requirements:type_name -> azdext.QuotaRequirement
+	44, // 19: azdext.ListLocationsWithQuotaResponse.locations:type_name -> azdext.Location
+	11, // 20: azdext.ListLocationsWithQuotaResponse.failed_locations:type_name -> azdext.AiLocationError
+	44, // 21: azdext.ModelLocationQuota.location:type_name -> azdext.Location
+	43, // 22: azdext.ListModelLocationsWithQuotaRequest.azure_context:type_name -> azdext.AzureContext
+	6,  // 23: azdext.ListModelLocationsWithQuotaRequest.quota:type_name -> azdext.QuotaCheckOptions
+	21, // 24: azdext.ListModelLocationsWithQuotaResponse.locations:type_name -> azdext.ModelLocationQuota
+	42, // 25: azdext.ListModelLocationsWithQuotaResponse.suggested_alternatives:type_name -> azdext.ListModelLocationsWithQuotaResponse.SuggestedAlternativesEntry
+	11, // 26: azdext.ListModelLocationsWithQuotaResponse.failed_locations:type_name -> azdext.AiLocationError
+	24, // 27: azdext.ListModelLocationsWithQuotaResponse.region_pairs:type_name -> azdext.AiRegionPairQuota
+	43, // 28: azdext.RecommendCapacityRequest.azure_context:type_name -> azdext.AzureContext
+	43, // 29: azdext.CheckDeploymentQuotaRequest.azure_context:type_name -> azdext.AzureContext
+	43, // 30: azdext.ListAccountDeploymentsRequest.azure_context:type_name -> azdext.AzureContext
+	3,  // 31: azdext.AiAccountDeployment.deployment:type_name -> azdext.AiModelDeployment
+	30, // 32: azdext.ListAccountDeploymentsResponse.deployments:type_name -> azdext.AiAccountDeployment
+	43, // 33: azdext.SummarizeDeployableModelsRequest.azure_context:type_name -> azdext.AzureContext
+	21, // 34: azdext.DeployableModelSummary.locations:type_name -> azdext.ModelLocationQuota
+	43, // 35: azdext.ListRawModelsRequest.azure_context:type_name -> azdext.AzureContext
+	43, // 36: azdext.BuildModelReportRequest.azure_context:type_name -> azdext.AzureContext
+	38, // 37: azdext.BuildModelReportResponse.locations:type_name -> azdext.AiModelReportLocation
+	11, // 38: azdext.BuildModelReportResponse.failed_locations:type_name -> azdext.AiLocationError
+	39, // 39: azdext.AiModelReportLocation.versions:type_name -> azdext.AiModelReportVersion
+	40, // 40: azdext.AiModelReportVersion.skus:type_name -> azdext.AiModelReportSku
+	2,  // 41: azdext.AiModelReportSku.sku:type_name -> azdext.AiModelSku
+	9,  // 42: azdext.AiModelService.ListModels:input_type -> azdext.ListModelsRequest
+	12, // 43: azdext.AiModelService.ResolveModelDeployments:input_type -> azdext.ResolveModelDeploymentsRequest
+	14, // 44: azdext.AiModelService.ListUsages:input_type -> azdext.ListUsagesRequest
+	16, // 45: azdext.AiModelService.ListUsagesBatch:input_type -> azdext.ListUsagesBatchRequest
+	19, // 46: azdext.AiModelService.ListLocationsWithQuota:input_type -> azdext.ListLocationsWithQuotaRequest
+	22, // 47: azdext.AiModelService.ListModelLocationsWithQuota:input_type -> azdext.ListModelLocationsWithQuotaRequest
+	25, // 48: azdext.AiModelService.RecommendCapacity:input_type -> azdext.RecommendCapacityRequest
+	27, // 49: azdext.AiModelService.CheckDeploymentQuota:input_type -> azdext.CheckDeploymentQuotaRequest
+	29, // 50: azdext.AiModelService.ListAccountDeployments:input_type -> azdext.ListAccountDeploymentsRequest
+	32, // 51: azdext.AiModelService.SummarizeDeployableModels:input_type -> azdext.SummarizeDeployableModelsRequest
+	34, // 52: azdext.AiModelService.ListRawModels:input_type -> azdext.ListRawModelsRequest
+	36, // 53: azdext.AiModelService.BuildModelReport:input_type -> azdext.BuildModelReportRequest
+	10, // 54: azdext.AiModelService.ListModels:output_type -> azdext.ListModelsResponse
+	13, // 55: azdext.AiModelService.ResolveModelDeployments:output_type -> azdext.ResolveModelDeploymentsResponse
+	15, // 56: azdext.AiModelService.ListUsages:output_type -> azdext.ListUsagesResponse
+	18, // 57: azdext.AiModelService.ListUsagesBatch:output_type -> azdext.ListUsagesBatchResponse
+	20, // 58: azdext.AiModelService.ListLocationsWithQuota:output_type -> azdext.ListLocationsWithQuotaResponse
+	23, // 59: azdext.AiModelService.ListModelLocationsWithQuota:output_type -> azdext.ListModelLocationsWithQuotaResponse
+	26, // 60: azdext.AiModelService.RecommendCapacity:output_type -> azdext.RecommendCapacityResponse
+	28, // 61: azdext.AiModelService.CheckDeploymentQuota:output_type -> azdext.CheckDeploymentQuotaResponse
+	31, // 62: azdext.AiModelService.ListAccountDeployments:output_type -> azdext.ListAccountDeploymentsResponse
+	33, // 63: azdext.AiModelService.SummarizeDeployableModels:output_type -> azdext.DeployableModelSummary
+	35, // 64: azdext.AiModelService.ListRawModels:output_type -> azdext.ListRawModelsResponse
+	37, // 65: azdext.AiModelService.BuildModelReport:output_type -> azdext.BuildModelReportResponse
+	54, // [54:66] is the sub-list for method output_type
+	42, // [42:54] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
 }
 
 func init() { file_ai_model_proto_init() }
@@ -1475,13 +3405,17 @@ func file_ai_model_proto_init() {
 	file_models_proto_init()
 	file_ai_model_proto_msgTypes[3].OneofWrappers = []any{}
 	file_ai_model_proto_msgTypes[8].OneofWrappers = []any{}
+	file_ai_model_proto_msgTypes[21].OneofWrappers = []any{}
+	file_ai_model_proto_msgTypes[22].OneofWrappers = []any{}
+	file_ai_model_proto_msgTypes[26].OneofWrappers = []any{}
+	file_ai_model_proto_msgTypes[28].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ai_model_proto_rawDesc), len(file_ai_model_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   20,
+			NumMessages:   43,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
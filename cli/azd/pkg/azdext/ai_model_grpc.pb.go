@@ -25,8 +25,15 @@ const (
 	AiModelService_ListModels_FullMethodName                  = "/azdext.AiModelService/ListModels"
 	AiModelService_ResolveModelDeployments_FullMethodName     = "/azdext.AiModelService/ResolveModelDeployments"
 	AiModelService_ListUsages_FullMethodName                  = "/azdext.AiModelService/ListUsages"
+	AiModelService_ListUsagesBatch_FullMethodName             = "/azdext.AiModelService/ListUsagesBatch"
 	AiModelService_ListLocationsWithQuota_FullMethodName      = "/azdext.AiModelService/ListLocationsWithQuota"
 	AiModelService_ListModelLocationsWithQuota_FullMethodName = "/azdext.AiModelService/ListModelLocationsWithQuota"
+	AiModelService_RecommendCapacity_FullMethodName           = "/azdext.AiModelService/RecommendCapacity"
+	AiModelService_CheckDeploymentQuota_FullMethodName        = "/azdext.AiModelService/CheckDeploymentQuota"
+	AiModelService_ListAccountDeployments_FullMethodName      = "/azdext.AiModelService/ListAccountDeployments"
+	AiModelService_SummarizeDeployableModels_FullMethodName   = "/azdext.AiModelService/SummarizeDeployableModels"
+	AiModelService_ListRawModels_FullMethodName               = "/azdext.AiModelService/ListRawModels"
+	AiModelService_BuildModelReport_FullMethodName            = "/azdext.AiModelService/BuildModelReport"
 )
 
 // AiModelServiceClient is the client API for AiModelService service.
@@ -47,13 +54,40 @@ type AiModelServiceClient interface {
 	// If quota is set, options.locations must contain exactly one location.
 	ResolveModelDeployments(ctx context.Context, in *ResolveModelDeploymentsRequest, opts ...grpc.CallOption) (*ResolveModelDeploymentsResponse, error)
 	// ListUsages returns quota/usage data for request.location.
-	// request.location is required.
+	// request.location is required. request.name_prefixes optionally restricts usage names.
 	ListUsages(ctx context.Context, in *ListUsagesRequest, opts ...grpc.CallOption) (*ListUsagesResponse, error)
+	// ListUsagesBatch returns quota/usage data for multiple locations in one call.
+	// Usages are fetched concurrently server-side and grouped by location.
+	// If request.locations is empty, all AI Services-supported locations are queried.
+	ListUsagesBatch(ctx context.Context, in *ListUsagesBatchRequest, opts ...grpc.CallOption) (*ListUsagesBatchResponse, error)
 	// ListLocationsWithQuota returns locations with sufficient quota.
 	ListLocationsWithQuota(ctx context.Context, in *ListLocationsWithQuotaRequest, opts ...grpc.CallOption) (*ListLocationsWithQuotaResponse, error)
 	// ListModelLocationsWithQuota returns locations where model has sufficient quota.
 	// Response includes max remaining quota per location for label rendering.
 	ListModelLocationsWithQuota(ctx context.Context, in *ListModelLocationsWithQuotaRequest, opts ...grpc.CallOption) (*ListModelLocationsWithQuotaResponse, error)
+	// RecommendCapacity recommends a deployment capacity for a model version and SKU at a location:
+	// the SKU default when it fits in the remaining quota, otherwise the largest step-aligned value that fits.
+	// Quota is evaluated at subscription scope; scope.resource_group is ignored.
+	RecommendCapacity(ctx context.Context, in *RecommendCapacityRequest, opts ...grpc.CallOption) (*RecommendCapacityResponse, error)
+	// CheckDeploymentQuota checks whether a deployment of an exact model version, SKU and capacity fits in the quota
+	// remaining at one location, and reports the AI Services account headroom there.
+	// Quota is evaluated at subscription scope; scope.resource_group is ignored.
+	CheckDeploymentQuota(ctx context.Context, in *CheckDeploymentQuotaRequest, opts ...grpc.CallOption) (*CheckDeploymentQuotaResponse, error)
+	// ListAccountDeployments lists the model deployments of the AI Services accounts provisioned in
+	// scope.resource_group, or of request.account_name only. scope.subscription_id and scope.resource_group are required.
+	ListAccountDeployments(ctx context.Context, in *ListAccountDeploymentsRequest, opts ...grpc.CallOption) (*ListAccountDeploymentsResponse, error)
+	// SummarizeDeployableModels streams, per catalog model, the locations where it can be deployed with at least
+	// the default capacity of one of its SKUs, and the location with the most remaining quota.
+	// One message is sent per model, sorted by model name; models without a deployable location are omitted.
+	// Quota is evaluated at subscription scope; scope.resource_group is ignored.
+	SummarizeDeployableModels(ctx context.Context, in *SummarizeDeployableModelsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DeployableModelSummary], error)
+	// ListRawModels returns the unprocessed ARM model list for request.location, for diagnosing
+	// catalog discrepancies. The catalog cache is bypassed. request.location is required.
+	ListRawModels(ctx context.Context, in *ListRawModelsRequest, opts ...grpc.CallOption) (*ListRawModelsResponse, error)
+	// BuildModelReport reports, for one model across locations, where it is offered, the versions and SKUs offered
+	// at each location, and the remaining quota of each SKU. The catalog has no pricing metadata, so cost is not
+	// reported. Quota is evaluated at subscription scope; scope.resource_group is ignored.
+	BuildModelReport(ctx context.Context, in *BuildModelReportRequest, opts ...grpc.CallOption) (*BuildModelReportResponse, error)
 }
 
 type aiModelServiceClient struct {
@@ -94,6 +128,16 @@ func (c *aiModelServiceClient) ListUsages(ctx context.Context, in *ListUsagesReq
 	return out, nil
 }
 
+func (c *aiModelServiceClient) ListUsagesBatch(ctx context.Context, in *ListUsagesBatchRequest, opts ...grpc.CallOption) (*ListUsagesBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUsagesBatchResponse)
+	err := c.cc.Invoke(ctx, AiModelService_ListUsagesBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aiModelServiceClient) ListLocationsWithQuota(ctx context.Context, in *ListLocationsWithQuotaRequest, opts ...grpc.CallOption) (*ListLocationsWithQuotaResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListLocationsWithQuotaResponse)
@@ -114,6 +158,75 @@ func (c *aiModelServiceClient) ListModelLocationsWithQuota(ctx context.Context,
 	return out, nil
 }
 
+func (c *aiModelServiceClient) RecommendCapacity(ctx context.Context, in *RecommendCapacityRequest, opts ...grpc.CallOption) (*RecommendCapacityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecommendCapacityResponse)
+	err := c.cc.Invoke(ctx, AiModelService_RecommendCapacity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aiModelServiceClient) CheckDeploymentQuota(ctx context.Context, in *CheckDeploymentQuotaRequest, opts ...grpc.CallOption) (*CheckDeploymentQuotaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckDeploymentQuotaResponse)
+	err := c.cc.Invoke(ctx, AiModelService_CheckDeploymentQuota_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aiModelServiceClient) ListAccountDeployments(ctx context.Context, in *ListAccountDeploymentsRequest, opts ...grpc.CallOption) (*ListAccountDeploymentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAccountDeploymentsResponse)
+	err := c.cc.Invoke(ctx, AiModelService_ListAccountDeployments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aiModelServiceClient) SummarizeDeployableModels(ctx context.Context, in *SummarizeDeployableModelsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DeployableModelSummary], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AiModelService_ServiceDesc.Streams[0], AiModelService_SummarizeDeployableModels_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SummarizeDeployableModelsRequest, DeployableModelSummary]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AiModelService_SummarizeDeployableModelsClient = grpc.ServerStreamingClient[DeployableModelSummary]
+
+func (c *aiModelServiceClient) ListRawModels(ctx context.Context, in *ListRawModelsRequest, opts ...grpc.CallOption) (*ListRawModelsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRawModelsResponse)
+	err := c.cc.Invoke(ctx, AiModelService_ListRawModels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aiModelServiceClient) BuildModelReport(ctx context.Context, in *BuildModelReportRequest, opts ...grpc.CallOption) (*BuildModelReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BuildModelReportResponse)
+	err := c.cc.Invoke(ctx, AiModelService_BuildModelReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AiModelServiceServer is the server API for AiModelService service.
 // All implementations must embed UnimplementedAiModelServiceServer
 // for forward compatibility.
@@ -132,13 +245,40 @@ type AiModelServiceServer interface {
 	// If quota is set, options.locations must contain exactly one location.
 	ResolveModelDeployments(context.Context, *ResolveModelDeploymentsRequest) (*ResolveModelDeploymentsResponse, error)
 	// ListUsages returns quota/usage data for request.location.
-	// request.location is required.
+	// request.location is required. request.name_prefixes optionally restricts usage names.
 	ListUsages(context.Context, *ListUsagesRequest) (*ListUsagesResponse, error)
+	// ListUsagesBatch returns quota/usage data for multiple locations in one call.
+	// Usages are fetched concurrently server-side and grouped by location.
+	// If request.locations is empty, all AI Services-supported locations are queried.
+	ListUsagesBatch(context.Context, *ListUsagesBatchRequest) (*ListUsagesBatchResponse, error)
 	// ListLocationsWithQuota returns locations with sufficient quota.
 	ListLocationsWithQuota(context.Context, *ListLocationsWithQuotaRequest) (*ListLocationsWithQuotaResponse, error)
 	// ListModelLocationsWithQuota returns locations where model has sufficient quota.
 	// Response includes max remaining quota per location for label rendering.
 	ListModelLocationsWithQuota(context.Context, *ListModelLocationsWithQuotaRequest) (*ListModelLocationsWithQuotaResponse, error)
+	// RecommendCapacity recommends a deployment capacity for a model version and SKU at a location:
+	// the SKU default when it fits in the remaining quota, otherwise the largest step-aligned value that fits.
+	// Quota is evaluated at subscription scope; scope.resource_group is ignored.
+	RecommendCapacity(context.Context, *RecommendCapacityRequest) (*RecommendCapacityResponse, error)
+	// CheckDeploymentQuota checks whether a deployment of an exact model version, SKU and capacity fits in the quota
+	// remaining at one location, and reports the AI Services account headroom there.
+	// Quota is evaluated at subscription scope; scope.resource_group is ignored.
+	CheckDeploymentQuota(context.Context, *CheckDeploymentQuotaRequest) (*CheckDeploymentQuotaResponse, error)
+	// ListAccountDeployments lists the model deployments of the AI Services accounts provisioned in
+	// scope.resource_group, or of request.account_name only. scope.subscription_id and scope.resource_group are required.
+	ListAccountDeployments(context.Context, *ListAccountDeploymentsRequest) (*ListAccountDeploymentsResponse, error)
+	// SummarizeDeployableModels streams, per catalog model, the locations where it can be deployed with at least
+	// the default capacity of one of its SKUs, and the location with the most remaining quota.
+	// One message is sent per model, sorted by model name; models without a deployable location are omitted.
+	// Quota is evaluated at subscription scope; scope.resource_group is ignored.
+	SummarizeDeployableModels(*SummarizeDeployableModelsRequest, grpc.ServerStreamingServer[DeployableModelSummary]) error
+	// ListRawModels returns the unprocessed ARM model list for request.location, for diagnosing
+	// catalog discrepancies. The catalog cache is bypassed. request.location is required.
+	ListRawModels(context.Context, *ListRawModelsRequest) (*ListRawModelsResponse, error)
+	// BuildModelReport reports, for one model across locations, where it is offered, the versions and SKUs offered
+	// at each location, and the remaining quota of each SKU. The catalog has no pricing metadata, so cost is not
+	// reported. Quota is evaluated at subscription scope; scope.resource_group is ignored.
+	BuildModelReport(context.Context, *BuildModelReportRequest) (*BuildModelReportResponse, error)
 	mustEmbedUnimplementedAiModelServiceServer()
 }
 
@@ -158,12 +298,33 @@ func (UnimplementedAiModelServiceServer) ResolveModelDeployments(context.Context
 func (UnimplementedAiModelServiceServer) ListUsages(context.Context, *ListUsagesRequest) (*ListUsagesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListUsages not implemented")
 }
+func (UnimplementedAiModelServiceServer) ListUsagesBatch(context.Context, *ListUsagesBatchRequest) (*ListUsagesBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUsagesBatch not implemented")
+}
 func (UnimplementedAiModelServiceServer) ListLocationsWithQuota(context.Context, *ListLocationsWithQuotaRequest) (*ListLocationsWithQuotaResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListLocationsWithQuota not implemented")
 }
 func (UnimplementedAiModelServiceServer) ListModelLocationsWithQuota(context.Context, *ListModelLocationsWithQuotaRequest) (*ListModelLocationsWithQuotaResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListModelLocationsWithQuota not implemented")
 }
+func (UnimplementedAiModelServiceServer) RecommendCapacity(context.Context, *RecommendCapacityRequest) (*RecommendCapacityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecommendCapacity not implemented")
+}
+func (UnimplementedAiModelServiceServer) CheckDeploymentQuota(context.Context, *CheckDeploymentQuotaRequest) (*CheckDeploymentQuotaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckDeploymentQuota not implemented")
+}
+func (UnimplementedAiModelServiceServer) ListAccountDeployments(context.Context, *ListAccountDeploymentsRequest) (*ListAccountDeploymentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccountDeployments not implemented")
+}
+func (UnimplementedAiModelServiceServer) SummarizeDeployableModels(*SummarizeDeployableModelsRequest, grpc.ServerStreamingServer[DeployableModelSummary]) error {
+	return status.Errorf(codes.Unimplemented, "method SummarizeDeployableModels not implemented")
+}
+func (UnimplementedAiModelServiceServer) ListRawModels(context.Context, *ListRawModelsRequest) (*ListRawModelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRawModels not implemented")
+}
+func (UnimplementedAiModelServiceServer) BuildModelReport(context.Context, *BuildModelReportRequest) (*BuildModelReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BuildModelReport not implemented")
+}
 func (UnimplementedAiModelServiceServer) mustEmbedUnimplementedAiModelServiceServer() {}
 func (UnimplementedAiModelServiceServer) testEmbeddedByValue()                        {}
 
@@ -239,6 +400,24 @@ func _AiModelService_ListUsages_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AiModelService_ListUsagesBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUsagesBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AiModelServiceServer).ListUsagesBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AiModelService_ListUsagesBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AiModelServiceServer).ListUsagesBatch(ctx, req.(*ListUsagesBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AiModelService_ListLocationsWithQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListLocationsWithQuotaRequest)
 	if err := dec(in); err != nil {
@@ -275,6 +454,107 @@ func _AiModelService_ListModelLocationsWithQuota_Handler(srv interface{}, ctx co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AiModelService_RecommendCapacity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecommendCapacityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AiModelServiceServer).RecommendCapacity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AiModelService_RecommendCapacity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AiModelServiceServer).RecommendCapacity(ctx, req.(*RecommendCapacityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AiModelService_CheckDeploymentQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckDeploymentQuotaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AiModelServiceServer).CheckDeploymentQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AiModelService_CheckDeploymentQuota_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AiModelServiceServer).CheckDeploymentQuota(ctx, req.(*CheckDeploymentQuotaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AiModelService_ListAccountDeployments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccountDeploymentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AiModelServiceServer).ListAccountDeployments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AiModelService_ListAccountDeployments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AiModelServiceServer).ListAccountDeployments(ctx, req.(*ListAccountDeploymentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AiModelService_SummarizeDeployableModels_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SummarizeDeployableModelsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AiModelServiceServer).SummarizeDeployableModels(m, &grpc.GenericServerStream[SummarizeDeployableModelsRequest, DeployableModelSummary]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AiModelService_SummarizeDeployableModelsServer = grpc.ServerStreamingServer[DeployableModelSummary]
+
+func _AiModelService_ListRawModels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRawModelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AiModelServiceServer).ListRawModels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AiModelService_ListRawModels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AiModelServiceServer).ListRawModels(ctx, req.(*ListRawModelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AiModelService_BuildModelReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BuildModelReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AiModelServiceServer).BuildModelReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AiModelService_BuildModelReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AiModelServiceServer).BuildModelReport(ctx, req.(*BuildModelReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AiModelService_ServiceDesc is the grpc.ServiceDesc for AiModelService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -294,6 +574,10 @@ var AiModelService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListUsages",
 			Handler:    _AiModelService_ListUsages_Handler,
 		},
+		{
+			MethodName: "ListUsagesBatch",
+			Handler:    _AiModelService_ListUsagesBatch_Handler,
+		},
 		{
 			MethodName: "ListLocationsWithQuota",
 			Handler:    _AiModelService_ListLocationsWithQuota_Handler,
@@ -302,7 +586,33 @@ var AiModelService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListModelLocationsWithQuota",
 			Handler:    _AiModelService_ListModelLocationsWithQuota_Handler,
 		},
+		{
+			MethodName: "RecommendCapacity",
+			Handler:    _AiModelService_RecommendCapacity_Handler,
+		},
+		{
+			MethodName: "CheckDeploymentQuota",
+			Handler:    _AiModelService_CheckDeploymentQuota_Handler,
+		},
+		{
+			MethodName: "ListAccountDeployments",
+			Handler:    _AiModelService_ListAccountDeployments_Handler,
+		},
+		{
+			MethodName: "ListRawModels",
+			Handler:    _AiModelService_ListRawModels_Handler,
+		},
+		{
+			MethodName: "BuildModelReport",
+			Handler:    _AiModelService_BuildModelReport_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SummarizeDeployableModels",
+			Handler:       _AiModelService_SummarizeDeployableModels_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "ai_model.proto",
 }
@@ -0,0 +1,21 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package azdext
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// CorrelationIdKey is the gRPC metadata key used to pass a correlation id from an extension to azd.
+// For AI and prompt RPCs, azd forwards the value as the `x-ms-correlation-request-id` header on the
+// ARM requests it makes while serving the call, so an extension operation can be traced end to end.
+const CorrelationIdKey = "x-ms-correlation-request-id"
+
+// WithCorrelationId appends a correlation id to the outgoing gRPC metadata of ctx. The id must be a GUID;
+// azd ignores values it cannot parse. Call it after [WithAccessToken], which replaces the outgoing metadata.
+func WithCorrelationId(ctx context.Context, correlationId string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, CorrelationIdKey, correlationId)
+}
@@ -0,0 +1,21 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package azdext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestWithCorrelationId(t *testing.T) {
+	ctx := WithAccessToken(t.Context(), "token")
+	ctx = WithCorrelationId(ctx, "0f8fad5b-d9cb-469f-a165-70867728950e")
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	require.True(t, ok)
+	require.Equal(t, []string{"token"}, md.Get("authorization"))
+	require.Equal(t, []string{"0f8fad5b-d9cb-469f-a165-70867728950e"}, md.Get(CorrelationIdKey))
+}
@@ -12,6 +12,10 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// ErrorCodeRetryable is a well-known error code an extension can set on a [LocalError] (Code) or [ServiceError]
+// (ErrorCode) to signal that the failure is transient and the operation can safely be retried.
+const ErrorCodeRetryable = "retryable"
+
 // ServiceError represents an HTTP/gRPC service error from an extension.
 // It preserves structured error information for telemetry and error handling.
 type ServiceError struct {
@@ -54,6 +58,19 @@ func (e *ServiceError) Error() string {
 	return e.Message
 }
 
+// IsRetryableError reports whether err is a [LocalError] or [ServiceError] carrying [ErrorCodeRetryable].
+func IsRetryableError(err error) bool {
+	if localErr, ok := errors.AsType[*LocalError](err); ok {
+		return localErr.Code == ErrorCodeRetryable
+	}
+
+	if svcErr, ok := errors.AsType[*ServiceError](err); ok {
+		return svcErr.ErrorCode == ErrorCodeRetryable
+	}
+
+	return false
+}
+
 // WrapError converts a Go error into an ExtensionError proto for transmission to the azd host.
 // It is called from extension processes (via [ReportError] and envelope SetError methods)
 // to serialize errors before sending them over gRPC.
@@ -358,6 +358,40 @@ func mustStatusErrorWithDetails(code codes.Code, message string, details ...prot
 	return withDetails.Err()
 }
 
+func TestIsRetryableError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil error", err: nil, want: false},
+		{name: "plain error", err: errors.New("boom"), want: false},
+		{name: "local error with retryable code", err: &LocalError{Code: ErrorCodeRetryable}, want: true},
+		{name: "local error with other code", err: &LocalError{Code: "invalid_config"}, want: false},
+		{name: "service error with retryable code", err: &ServiceError{ErrorCode: ErrorCodeRetryable}, want: true},
+		{name: "service error with other code", err: &ServiceError{ErrorCode: "Conflict"}, want: false},
+		{
+			name: "wrapped retryable error",
+			err:  fmt.Errorf("deploying: %w", &LocalError{Code: ErrorCodeRetryable}),
+			want: true,
+		},
+		{
+			name: "retryable code survives the wire",
+			err:  UnwrapError(WrapError(&LocalError{Code: ErrorCodeRetryable, Category: LocalErrorCategoryDependency})),
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, IsRetryableError(tt.err))
+		})
+	}
+}
+
 func TestGRPCStatusFromError(t *testing.T) {
 	t.Parallel()
 
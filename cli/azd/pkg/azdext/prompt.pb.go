@@ -312,27 +312,30 @@ func (x *PromptResourceGroupResponse) GetResourceGroup() *ResourceGroup {
 	return nil
 }
 
-type ConfirmRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Options       *ConfirmOptions        `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type PromptAzureScopeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional existing context used to pre-seed the scope.
+	AzureContext *AzureContext `protobuf:"bytes,1,opt,name=azure_context,json=azureContext,proto3" json:"azure_context,omitempty"`
+	// Prompt for a resource group after the location.
+	IncludeResourceGroup bool `protobuf:"varint,2,opt,name=include_resource_group,json=includeResourceGroup,proto3" json:"include_resource_group,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
-func (x *ConfirmRequest) Reset() {
-	*x = ConfirmRequest{}
+func (x *PromptAzureScopeRequest) Reset() {
+	*x = PromptAzureScopeRequest{}
 	mi := &file_prompt_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ConfirmRequest) String() string {
+func (x *PromptAzureScopeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConfirmRequest) ProtoMessage() {}
+func (*PromptAzureScopeRequest) ProtoMessage() {}
 
-func (x *ConfirmRequest) ProtoReflect() protoreflect.Message {
+func (x *PromptAzureScopeRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_prompt_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -344,39 +347,46 @@ func (x *ConfirmRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConfirmRequest.ProtoReflect.Descriptor instead.
-func (*ConfirmRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use PromptAzureScopeRequest.ProtoReflect.Descriptor instead.
+func (*PromptAzureScopeRequest) Descriptor() ([]byte, []int) {
 	return file_prompt_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *ConfirmRequest) GetOptions() *ConfirmOptions {
+func (x *PromptAzureScopeRequest) GetAzureContext() *AzureContext {
 	if x != nil {
-		return x.Options
+		return x.AzureContext
 	}
 	return nil
 }
 
-type ConfirmResponse struct {
+func (x *PromptAzureScopeRequest) GetIncludeResourceGroup() bool {
+	if x != nil {
+		return x.IncludeResourceGroup
+	}
+	return false
+}
+
+type PromptAzureScopeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Value         *bool                  `protobuf:"varint,1,opt,name=value,proto3,oneof" json:"value,omitempty"`
+	AzureContext  *AzureContext          `protobuf:"bytes,1,opt,name=azure_context,json=azureContext,proto3" json:"azure_context,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ConfirmResponse) Reset() {
-	*x = ConfirmResponse{}
+func (x *PromptAzureScopeResponse) Reset() {
+	*x = PromptAzureScopeResponse{}
 	mi := &file_prompt_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ConfirmResponse) String() string {
+func (x *PromptAzureScopeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConfirmResponse) ProtoMessage() {}
+func (*PromptAzureScopeResponse) ProtoMessage() {}
 
-func (x *ConfirmResponse) ProtoReflect() protoreflect.Message {
+func (x *PromptAzureScopeResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_prompt_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -388,39 +398,38 @@ func (x *ConfirmResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConfirmResponse.ProtoReflect.Descriptor instead.
-func (*ConfirmResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use PromptAzureScopeResponse.ProtoReflect.Descriptor instead.
+func (*PromptAzureScopeResponse) Descriptor() ([]byte, []int) {
 	return file_prompt_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ConfirmResponse) GetValue() bool {
-	if x != nil && x.Value != nil {
-		return *x.Value
+func (x *PromptAzureScopeResponse) GetAzureContext() *AzureContext {
+	if x != nil {
+		return x.AzureContext
 	}
-	return false
+	return nil
 }
 
-type PromptRequest struct {
+type GetAzureContextRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Options       *PromptOptions         `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PromptRequest) Reset() {
-	*x = PromptRequest{}
+func (x *GetAzureContextRequest) Reset() {
+	*x = GetAzureContextRequest{}
 	mi := &file_prompt_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PromptRequest) String() string {
+func (x *GetAzureContextRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PromptRequest) ProtoMessage() {}
+func (*GetAzureContextRequest) ProtoMessage() {}
 
-func (x *PromptRequest) ProtoReflect() protoreflect.Message {
+func (x *GetAzureContextRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_prompt_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -432,39 +441,32 @@ func (x *PromptRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PromptRequest.ProtoReflect.Descriptor instead.
-func (*PromptRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetAzureContextRequest.ProtoReflect.Descriptor instead.
+func (*GetAzureContextRequest) Descriptor() ([]byte, []int) {
 	return file_prompt_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *PromptRequest) GetOptions() *PromptOptions {
-	if x != nil {
-		return x.Options
-	}
-	return nil
-}
-
-type PromptResponse struct {
+type GetAzureContextResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	AzureContext  *AzureContext          `protobuf:"bytes,1,opt,name=azure_context,json=azureContext,proto3" json:"azure_context,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PromptResponse) Reset() {
-	*x = PromptResponse{}
+func (x *GetAzureContextResponse) Reset() {
+	*x = GetAzureContextResponse{}
 	mi := &file_prompt_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PromptResponse) String() string {
+func (x *GetAzureContextResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PromptResponse) ProtoMessage() {}
+func (*GetAzureContextResponse) ProtoMessage() {}
 
-func (x *PromptResponse) ProtoReflect() protoreflect.Message {
+func (x *GetAzureContextResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_prompt_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -476,39 +478,39 @@ func (x *PromptResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PromptResponse.ProtoReflect.Descriptor instead.
-func (*PromptResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetAzureContextResponse.ProtoReflect.Descriptor instead.
+func (*GetAzureContextResponse) Descriptor() ([]byte, []int) {
 	return file_prompt_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *PromptResponse) GetValue() string {
+func (x *GetAzureContextResponse) GetAzureContext() *AzureContext {
 	if x != nil {
-		return x.Value
+		return x.AzureContext
 	}
-	return ""
+	return nil
 }
 
-type SelectRequest struct {
+type ConfirmRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Options       *SelectOptions         `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
+	Options       *ConfirmOptions        `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SelectRequest) Reset() {
-	*x = SelectRequest{}
+func (x *ConfirmRequest) Reset() {
+	*x = ConfirmRequest{}
 	mi := &file_prompt_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SelectRequest) String() string {
+func (x *ConfirmRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SelectRequest) ProtoMessage() {}
+func (*ConfirmRequest) ProtoMessage() {}
 
-func (x *SelectRequest) ProtoReflect() protoreflect.Message {
+func (x *ConfirmRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_prompt_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -520,39 +522,39 @@ func (x *SelectRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SelectRequest.ProtoReflect.Descriptor instead.
-func (*SelectRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ConfirmRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmRequest) Descriptor() ([]byte, []int) {
 	return file_prompt_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *SelectRequest) GetOptions() *SelectOptions {
+func (x *ConfirmRequest) GetOptions() *ConfirmOptions {
 	if x != nil {
 		return x.Options
 	}
 	return nil
 }
 
-type SelectResponse struct {
+type ConfirmResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Value         *int32                 `protobuf:"varint,1,opt,name=value,proto3,oneof" json:"value,omitempty"`
+	Value         *bool                  `protobuf:"varint,1,opt,name=value,proto3,oneof" json:"value,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SelectResponse) Reset() {
-	*x = SelectResponse{}
+func (x *ConfirmResponse) Reset() {
+	*x = ConfirmResponse{}
 	mi := &file_prompt_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SelectResponse) String() string {
+func (x *ConfirmResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SelectResponse) ProtoMessage() {}
+func (*ConfirmResponse) ProtoMessage() {}
 
-func (x *SelectResponse) ProtoReflect() protoreflect.Message {
+func (x *ConfirmResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_prompt_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -564,39 +566,44 @@ func (x *SelectResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SelectResponse.ProtoReflect.Descriptor instead.
-func (*SelectResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ConfirmResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmResponse) Descriptor() ([]byte, []int) {
 	return file_prompt_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *SelectResponse) GetValue() int32 {
+func (x *ConfirmResponse) GetValue() bool {
 	if x != nil && x.Value != nil {
 		return *x.Value
 	}
-	return 0
+	return false
 }
 
-type MultiSelectRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Options       *MultiSelectOptions    `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
+type PromptSummaryConfirmRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional title displayed above the summary rows.
+	Title string `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	// Rows displayed as aligned key/value pairs, in order.
+	Rows []*SummaryRow `protobuf:"bytes,2,rep,name=rows,proto3" json:"rows,omitempty"`
+	// Confirmation prompt displayed after the summary. options.default_value is used in no-prompt mode.
+	Options       *ConfirmOptions `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MultiSelectRequest) Reset() {
-	*x = MultiSelectRequest{}
+func (x *PromptSummaryConfirmRequest) Reset() {
+	*x = PromptSummaryConfirmRequest{}
 	mi := &file_prompt_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MultiSelectRequest) String() string {
+func (x *PromptSummaryConfirmRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MultiSelectRequest) ProtoMessage() {}
+func (*PromptSummaryConfirmRequest) ProtoMessage() {}
 
-func (x *MultiSelectRequest) ProtoReflect() protoreflect.Message {
+func (x *PromptSummaryConfirmRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_prompt_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -608,39 +615,54 @@ func (x *MultiSelectRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MultiSelectRequest.ProtoReflect.Descriptor instead.
-func (*MultiSelectRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use PromptSummaryConfirmRequest.ProtoReflect.Descriptor instead.
+func (*PromptSummaryConfirmRequest) Descriptor() ([]byte, []int) {
 	return file_prompt_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *MultiSelectRequest) GetOptions() *MultiSelectOptions {
+func (x *PromptSummaryConfirmRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *PromptSummaryConfirmRequest) GetRows() []*SummaryRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+func (x *PromptSummaryConfirmRequest) GetOptions() *ConfirmOptions {
 	if x != nil {
 		return x.Options
 	}
 	return nil
 }
 
-type MultiSelectResponse struct {
+type SummaryRow struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Values        []*MultiSelectChoice   `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MultiSelectResponse) Reset() {
-	*x = MultiSelectResponse{}
+func (x *SummaryRow) Reset() {
+	*x = SummaryRow{}
 	mi := &file_prompt_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MultiSelectResponse) String() string {
+func (x *SummaryRow) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MultiSelectResponse) ProtoMessage() {}
+func (*SummaryRow) ProtoMessage() {}
 
-func (x *MultiSelectResponse) ProtoReflect() protoreflect.Message {
+func (x *SummaryRow) ProtoReflect() protoreflect.Message {
 	mi := &file_prompt_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -652,40 +674,46 @@ func (x *MultiSelectResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MultiSelectResponse.ProtoReflect.Descriptor instead.
-func (*MultiSelectResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SummaryRow.ProtoReflect.Descriptor instead.
+func (*SummaryRow) Descriptor() ([]byte, []int) {
 	return file_prompt_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *MultiSelectResponse) GetValues() []*MultiSelectChoice {
+func (x *SummaryRow) GetKey() string {
 	if x != nil {
-		return x.Values
+		return x.Key
 	}
-	return nil
+	return ""
 }
 
-type PromptSubscriptionResourceRequest struct {
+func (x *SummaryRow) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type PromptSummaryConfirmResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AzureContext  *AzureContext          `protobuf:"bytes,1,opt,name=azure_context,json=azureContext,proto3" json:"azure_context,omitempty"`
-	Options       *PromptResourceOptions `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	Value         *bool                  `protobuf:"varint,1,opt,name=value,proto3,oneof" json:"value,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PromptSubscriptionResourceRequest) Reset() {
-	*x = PromptSubscriptionResourceRequest{}
+func (x *PromptSummaryConfirmResponse) Reset() {
+	*x = PromptSummaryConfirmResponse{}
 	mi := &file_prompt_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PromptSubscriptionResourceRequest) String() string {
+func (x *PromptSummaryConfirmResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PromptSubscriptionResourceRequest) ProtoMessage() {}
+func (*PromptSummaryConfirmResponse) ProtoMessage() {}
 
-func (x *PromptSubscriptionResourceRequest) ProtoReflect() protoreflect.Message {
+func (x *PromptSummaryConfirmResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_prompt_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -697,46 +725,39 @@ func (x *PromptSubscriptionResourceRequest) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PromptSubscriptionResourceRequest.ProtoReflect.Descriptor instead.
-func (*PromptSubscriptionResourceRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use PromptSummaryConfirmResponse.ProtoReflect.Descriptor instead.
+func (*PromptSummaryConfirmResponse) Descriptor() ([]byte, []int) {
 	return file_prompt_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *PromptSubscriptionResourceRequest) GetAzureContext() *AzureContext {
-	if x != nil {
-		return x.AzureContext
-	}
-	return nil
-}
-
-func (x *PromptSubscriptionResourceRequest) GetOptions() *PromptResourceOptions {
-	if x != nil {
-		return x.Options
+func (x *PromptSummaryConfirmResponse) GetValue() bool {
+	if x != nil && x.Value != nil {
+		return *x.Value
 	}
-	return nil
+	return false
 }
 
-type PromptSubscriptionResourceResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Resource      *ResourceExtended      `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+type PromptDestructiveConfirmRequest struct {
+	state         protoimpl.MessageState           `protogen:"open.v1"`
+	Options       *PromptDestructiveConfirmOptions `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PromptSubscriptionResourceResponse) Reset() {
-	*x = PromptSubscriptionResourceResponse{}
+func (x *PromptDestructiveConfirmRequest) Reset() {
+	*x = PromptDestructiveConfirmRequest{}
 	mi := &file_prompt_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PromptSubscriptionResourceResponse) String() string {
+func (x *PromptDestructiveConfirmRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PromptSubscriptionResourceResponse) ProtoMessage() {}
+func (*PromptDestructiveConfirmRequest) ProtoMessage() {}
 
-func (x *PromptSubscriptionResourceResponse) ProtoReflect() protoreflect.Message {
+func (x *PromptDestructiveConfirmRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_prompt_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -748,40 +769,40 @@ func (x *PromptSubscriptionResourceResponse) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PromptSubscriptionResourceResponse.ProtoReflect.Descriptor instead.
-func (*PromptSubscriptionResourceResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use PromptDestructiveConfirmRequest.ProtoReflect.Descriptor instead.
+func (*PromptDestructiveConfirmRequest) Descriptor() ([]byte, []int) {
 	return file_prompt_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *PromptSubscriptionResourceResponse) GetResource() *ResourceExtended {
+func (x *PromptDestructiveConfirmRequest) GetOptions() *PromptDestructiveConfirmOptions {
 	if x != nil {
-		return x.Resource
+		return x.Options
 	}
 	return nil
 }
 
-type PromptResourceGroupResourceRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AzureContext  *AzureContext          `protobuf:"bytes,1,opt,name=azure_context,json=azureContext,proto3" json:"azure_context,omitempty"`
-	Options       *PromptResourceOptions `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+type PromptDestructiveConfirmResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// True only when the user typed the confirmation phrase exactly, or options.force was set.
+	Confirmed     bool `protobuf:"varint,1,opt,name=confirmed,proto3" json:"confirmed,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PromptResourceGroupResourceRequest) Reset() {
-	*x = PromptResourceGroupResourceRequest{}
+func (x *PromptDestructiveConfirmResponse) Reset() {
+	*x = PromptDestructiveConfirmResponse{}
 	mi := &file_prompt_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PromptResourceGroupResourceRequest) String() string {
+func (x *PromptDestructiveConfirmResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PromptResourceGroupResourceRequest) ProtoMessage() {}
+func (*PromptDestructiveConfirmResponse) ProtoMessage() {}
 
-func (x *PromptResourceGroupResourceRequest) ProtoReflect() protoreflect.Message {
+func (x *PromptDestructiveConfirmResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_prompt_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -793,46 +814,39 @@ func (x *PromptResourceGroupResourceRequest) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PromptResourceGroupResourceRequest.ProtoReflect.Descriptor instead.
-func (*PromptResourceGroupResourceRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use PromptDestructiveConfirmResponse.ProtoReflect.Descriptor instead.
+func (*PromptDestructiveConfirmResponse) Descriptor() ([]byte, []int) {
 	return file_prompt_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *PromptResourceGroupResourceRequest) GetAzureContext() *AzureContext {
-	if x != nil {
-		return x.AzureContext
-	}
-	return nil
-}
-
-func (x *PromptResourceGroupResourceRequest) GetOptions() *PromptResourceOptions {
+func (x *PromptDestructiveConfirmResponse) GetConfirmed() bool {
 	if x != nil {
-		return x.Options
+		return x.Confirmed
 	}
-	return nil
+	return false
 }
 
-type PromptResourceGroupResourceResponse struct {
+type PromptRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Resource      *ResourceExtended      `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	Options       *PromptOptions         `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PromptResourceGroupResourceResponse) Reset() {
-	*x = PromptResourceGroupResourceResponse{}
+func (x *PromptRequest) Reset() {
+	*x = PromptRequest{}
 	mi := &file_prompt_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PromptResourceGroupResourceResponse) String() string {
+func (x *PromptRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PromptResourceGroupResourceResponse) ProtoMessage() {}
+func (*PromptRequest) ProtoMessage() {}
 
-func (x *PromptResourceGroupResourceResponse) ProtoReflect() protoreflect.Message {
+func (x *PromptRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_prompt_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -844,43 +858,39 @@ func (x *PromptResourceGroupResourceResponse) ProtoReflect() protoreflect.Messag
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PromptResourceGroupResourceResponse.ProtoReflect.Descriptor instead.
-func (*PromptResourceGroupResourceResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use PromptRequest.ProtoReflect.Descriptor instead.
+func (*PromptRequest) Descriptor() ([]byte, []int) {
 	return file_prompt_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *PromptResourceGroupResourceResponse) GetResource() *ResourceExtended {
+func (x *PromptRequest) GetOptions() *PromptOptions {
 	if x != nil {
-		return x.Resource
+		return x.Options
 	}
 	return nil
 }
 
-type ConfirmOptions struct {
+type PromptResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DefaultValue  *bool                  `protobuf:"varint,1,opt,name=default_value,json=defaultValue,proto3,oneof" json:"default_value,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	HelpMessage   string                 `protobuf:"bytes,3,opt,name=help_message,json=helpMessage,proto3" json:"help_message,omitempty"`
-	Hint          string                 `protobuf:"bytes,4,opt,name=hint,proto3" json:"hint,omitempty"`
-	Placeholder   string                 `protobuf:"bytes,5,opt,name=placeholder,proto3" json:"placeholder,omitempty"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ConfirmOptions) Reset() {
-	*x = ConfirmOptions{}
+func (x *PromptResponse) Reset() {
+	*x = PromptResponse{}
 	mi := &file_prompt_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ConfirmOptions) String() string {
+func (x *PromptResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConfirmOptions) ProtoMessage() {}
+func (*PromptResponse) ProtoMessage() {}
 
-func (x *ConfirmOptions) ProtoReflect() protoreflect.Message {
+func (x *PromptResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_prompt_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -892,78 +902,2354 @@ func (x *ConfirmOptions) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConfirmOptions.ProtoReflect.Descriptor instead.
-func (*ConfirmOptions) Descriptor() ([]byte, []int) {
+// Deprecated: Use PromptResponse.ProtoReflect.Descriptor instead.
+func (*PromptResponse) Descriptor() ([]byte, []int) {
 	return file_prompt_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *ConfirmOptions) GetDefaultValue() bool {
-	if x != nil && x.DefaultValue != nil {
-		return *x.DefaultValue
+func (x *PromptResponse) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type SelectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Options       *SelectOptions         `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SelectRequest) Reset() {
+	*x = SelectRequest{}
+	mi := &file_prompt_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SelectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelectRequest) ProtoMessage() {}
+
+func (x *SelectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelectRequest.ProtoReflect.Descriptor instead.
+func (*SelectRequest) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SelectRequest) GetOptions() *SelectOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type SelectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         *int32                 `protobuf:"varint,1,opt,name=value,proto3,oneof" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SelectResponse) Reset() {
+	*x = SelectResponse{}
+	mi := &file_prompt_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SelectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelectResponse) ProtoMessage() {}
+
+func (x *SelectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelectResponse.ProtoReflect.Descriptor instead.
+func (*SelectResponse) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SelectResponse) GetValue() int32 {
+	if x != nil && x.Value != nil {
+		return *x.Value
+	}
+	return 0
+}
+
+type MultiSelectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Options       *MultiSelectOptions    `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MultiSelectRequest) Reset() {
+	*x = MultiSelectRequest{}
+	mi := &file_prompt_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MultiSelectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MultiSelectRequest) ProtoMessage() {}
+
+func (x *MultiSelectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MultiSelectRequest.ProtoReflect.Descriptor instead.
+func (*MultiSelectRequest) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *MultiSelectRequest) GetOptions() *MultiSelectOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type MultiSelectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []*MultiSelectChoice   `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MultiSelectResponse) Reset() {
+	*x = MultiSelectResponse{}
+	mi := &file_prompt_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MultiSelectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MultiSelectResponse) ProtoMessage() {}
+
+func (x *MultiSelectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MultiSelectResponse.ProtoReflect.Descriptor instead.
+func (*MultiSelectResponse) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *MultiSelectResponse) GetValues() []*MultiSelectChoice {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type PromptTreeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Options       *PromptTreeOptions     `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptTreeRequest) Reset() {
+	*x = PromptTreeRequest{}
+	mi := &file_prompt_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptTreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptTreeRequest) ProtoMessage() {}
+
+func (x *PromptTreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptTreeRequest.ProtoReflect.Descriptor instead.
+func (*PromptTreeRequest) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PromptTreeRequest) GetOptions() *PromptTreeOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type PromptTreeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Values of the selected choices from the root level down to the selected leaf.
+	Path          []string `protobuf:"bytes,1,rep,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptTreeResponse) Reset() {
+	*x = PromptTreeResponse{}
+	mi := &file_prompt_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptTreeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptTreeResponse) ProtoMessage() {}
+
+func (x *PromptTreeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptTreeResponse.ProtoReflect.Descriptor instead.
+func (*PromptTreeResponse) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PromptTreeResponse) GetPath() []string {
+	if x != nil {
+		return x.Path
+	}
+	return nil
+}
+
+type PromptPathRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Options       *PromptPathOptions     `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptPathRequest) Reset() {
+	*x = PromptPathRequest{}
+	mi := &file_prompt_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptPathRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptPathRequest) ProtoMessage() {}
+
+func (x *PromptPathRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptPathRequest.ProtoReflect.Descriptor instead.
+func (*PromptPathRequest) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *PromptPathRequest) GetOptions() *PromptPathOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type PromptPathResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The path entered by the user, as typed (relative paths are not resolved).
+	Path          string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptPathResponse) Reset() {
+	*x = PromptPathResponse{}
+	mi := &file_prompt_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptPathResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptPathResponse) ProtoMessage() {}
+
+func (x *PromptPathResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptPathResponse.ProtoReflect.Descriptor instead.
+func (*PromptPathResponse) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PromptPathResponse) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type PromptEditorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Options       *PromptEditorOptions   `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptEditorRequest) Reset() {
+	*x = PromptEditorRequest{}
+	mi := &file_prompt_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptEditorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptEditorRequest) ProtoMessage() {}
+
+func (x *PromptEditorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptEditorRequest.ProtoReflect.Descriptor instead.
+func (*PromptEditorRequest) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *PromptEditorRequest) GetOptions() *PromptEditorOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type PromptEditorResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The edited content.
+	Value         string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptEditorResponse) Reset() {
+	*x = PromptEditorResponse{}
+	mi := &file_prompt_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptEditorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptEditorResponse) ProtoMessage() {}
+
+func (x *PromptEditorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptEditorResponse.ProtoReflect.Descriptor instead.
+func (*PromptEditorResponse) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *PromptEditorResponse) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type PromptDurationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Options       *PromptDurationOptions `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptDurationRequest) Reset() {
+	*x = PromptDurationRequest{}
+	mi := &file_prompt_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptDurationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptDurationRequest) ProtoMessage() {}
+
+func (x *PromptDurationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptDurationRequest.ProtoReflect.Descriptor instead.
+func (*PromptDurationRequest) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *PromptDurationRequest) GetOptions() *PromptDurationOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type PromptDurationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The entered duration in nanoseconds.
+	Nanoseconds int64 `protobuf:"varint,1,opt,name=nanoseconds,proto3" json:"nanoseconds,omitempty"`
+	// The entered duration normalized to Go duration format, e.g. "1h30m0s".
+	Value         string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptDurationResponse) Reset() {
+	*x = PromptDurationResponse{}
+	mi := &file_prompt_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptDurationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptDurationResponse) ProtoMessage() {}
+
+func (x *PromptDurationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptDurationResponse.ProtoReflect.Descriptor instead.
+func (*PromptDurationResponse) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PromptDurationResponse) GetNanoseconds() int64 {
+	if x != nil {
+		return x.Nanoseconds
+	}
+	return 0
+}
+
+func (x *PromptDurationResponse) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type PromptKeyValuesRequest struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Options       *PromptKeyValuesOptions `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptKeyValuesRequest) Reset() {
+	*x = PromptKeyValuesRequest{}
+	mi := &file_prompt_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptKeyValuesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptKeyValuesRequest) ProtoMessage() {}
+
+func (x *PromptKeyValuesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptKeyValuesRequest.ProtoReflect.Descriptor instead.
+func (*PromptKeyValuesRequest) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *PromptKeyValuesRequest) GetOptions() *PromptKeyValuesOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type PromptKeyValuesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The entered key/value pairs.
+	Values        map[string]string `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptKeyValuesResponse) Reset() {
+	*x = PromptKeyValuesResponse{}
+	mi := &file_prompt_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptKeyValuesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptKeyValuesResponse) ProtoMessage() {}
+
+func (x *PromptKeyValuesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptKeyValuesResponse.ProtoReflect.Descriptor instead.
+func (*PromptKeyValuesResponse) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *PromptKeyValuesResponse) GetValues() map[string]string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type PromptOrderedSelectRequest struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Options       *PromptOrderedSelectOptions `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptOrderedSelectRequest) Reset() {
+	*x = PromptOrderedSelectRequest{}
+	mi := &file_prompt_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptOrderedSelectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptOrderedSelectRequest) ProtoMessage() {}
+
+func (x *PromptOrderedSelectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptOrderedSelectRequest.ProtoReflect.Descriptor instead.
+func (*PromptOrderedSelectRequest) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *PromptOrderedSelectRequest) GetOptions() *PromptOrderedSelectOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type PromptOrderedSelectResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The picked choices, in pick order.
+	Values        []*SelectChoice `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptOrderedSelectResponse) Reset() {
+	*x = PromptOrderedSelectResponse{}
+	mi := &file_prompt_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptOrderedSelectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptOrderedSelectResponse) ProtoMessage() {}
+
+func (x *PromptOrderedSelectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptOrderedSelectResponse.ProtoReflect.Descriptor instead.
+func (*PromptOrderedSelectResponse) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *PromptOrderedSelectResponse) GetValues() []*SelectChoice {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// PromptSearchableClientMessage is sent by the extension on a PromptSearchable stream. The first message must
+// carry options; every later message must carry the results for the latest query.
+type PromptSearchableClientMessage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to MessageType:
+	//
+	//	*PromptSearchableClientMessage_Options
+	//	*PromptSearchableClientMessage_Results
+	MessageType   isPromptSearchableClientMessage_MessageType `protobuf_oneof:"message_type"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptSearchableClientMessage) Reset() {
+	*x = PromptSearchableClientMessage{}
+	mi := &file_prompt_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptSearchableClientMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptSearchableClientMessage) ProtoMessage() {}
+
+func (x *PromptSearchableClientMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptSearchableClientMessage.ProtoReflect.Descriptor instead.
+func (*PromptSearchableClientMessage) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *PromptSearchableClientMessage) GetMessageType() isPromptSearchableClientMessage_MessageType {
+	if x != nil {
+		return x.MessageType
+	}
+	return nil
+}
+
+func (x *PromptSearchableClientMessage) GetOptions() *PromptSearchableOptions {
+	if x != nil {
+		if x, ok := x.MessageType.(*PromptSearchableClientMessage_Options); ok {
+			return x.Options
+		}
+	}
+	return nil
+}
+
+func (x *PromptSearchableClientMessage) GetResults() *PromptSearchableResults {
+	if x != nil {
+		if x, ok := x.MessageType.(*PromptSearchableClientMessage_Results); ok {
+			return x.Results
+		}
+	}
+	return nil
+}
+
+type isPromptSearchableClientMessage_MessageType interface {
+	isPromptSearchableClientMessage_MessageType()
+}
+
+type PromptSearchableClientMessage_Options struct {
+	Options *PromptSearchableOptions `protobuf:"bytes,1,opt,name=options,proto3,oneof"`
+}
+
+type PromptSearchableClientMessage_Results struct {
+	Results *PromptSearchableResults `protobuf:"bytes,2,opt,name=results,proto3,oneof"`
+}
+
+func (*PromptSearchableClientMessage_Options) isPromptSearchableClientMessage_MessageType() {}
+
+func (*PromptSearchableClientMessage_Results) isPromptSearchableClientMessage_MessageType() {}
+
+// PromptSearchableServerMessage is sent by azd on a PromptSearchable stream.
+type PromptSearchableServerMessage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to MessageType:
+	//
+	//	*PromptSearchableServerMessage_Query
+	//	*PromptSearchableServerMessage_Response
+	MessageType   isPromptSearchableServerMessage_MessageType `protobuf_oneof:"message_type"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptSearchableServerMessage) Reset() {
+	*x = PromptSearchableServerMessage{}
+	mi := &file_prompt_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptSearchableServerMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptSearchableServerMessage) ProtoMessage() {}
+
+func (x *PromptSearchableServerMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptSearchableServerMessage.ProtoReflect.Descriptor instead.
+func (*PromptSearchableServerMessage) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *PromptSearchableServerMessage) GetMessageType() isPromptSearchableServerMessage_MessageType {
+	if x != nil {
+		return x.MessageType
+	}
+	return nil
+}
+
+func (x *PromptSearchableServerMessage) GetQuery() *PromptSearchableQuery {
+	if x != nil {
+		if x, ok := x.MessageType.(*PromptSearchableServerMessage_Query); ok {
+			return x.Query
+		}
+	}
+	return nil
+}
+
+func (x *PromptSearchableServerMessage) GetResponse() *PromptSearchableResponse {
+	if x != nil {
+		if x, ok := x.MessageType.(*PromptSearchableServerMessage_Response); ok {
+			return x.Response
+		}
+	}
+	return nil
+}
+
+type isPromptSearchableServerMessage_MessageType interface {
+	isPromptSearchableServerMessage_MessageType()
+}
+
+type PromptSearchableServerMessage_Query struct {
+	Query *PromptSearchableQuery `protobuf:"bytes,1,opt,name=query,proto3,oneof"`
+}
+
+type PromptSearchableServerMessage_Response struct {
+	Response *PromptSearchableResponse `protobuf:"bytes,2,opt,name=response,proto3,oneof"`
+}
+
+func (*PromptSearchableServerMessage_Query) isPromptSearchableServerMessage_MessageType() {}
+
+func (*PromptSearchableServerMessage_Response) isPromptSearchableServerMessage_MessageType() {}
+
+// PromptSearchableQuery asks the extension for the choices matching the filter text typed by the user. The first
+// query, sent before the prompt is shown, has an empty filter.
+type PromptSearchableQuery struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Identifies the query; the results must echo it in query_id.
+	Id            int32  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Filter        string `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptSearchableQuery) Reset() {
+	*x = PromptSearchableQuery{}
+	mi := &file_prompt_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptSearchableQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptSearchableQuery) ProtoMessage() {}
+
+func (x *PromptSearchableQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptSearchableQuery.ProtoReflect.Descriptor instead.
+func (*PromptSearchableQuery) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *PromptSearchableQuery) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PromptSearchableQuery) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+// PromptSearchableResults answers a PromptSearchableQuery.
+type PromptSearchableResults struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QueryId       int32                  `protobuf:"varint,1,opt,name=query_id,json=queryId,proto3" json:"query_id,omitempty"`
+	Choices       []*SelectChoice        `protobuf:"bytes,2,rep,name=choices,proto3" json:"choices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptSearchableResults) Reset() {
+	*x = PromptSearchableResults{}
+	mi := &file_prompt_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptSearchableResults) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptSearchableResults) ProtoMessage() {}
+
+func (x *PromptSearchableResults) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptSearchableResults.ProtoReflect.Descriptor instead.
+func (*PromptSearchableResults) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *PromptSearchableResults) GetQueryId() int32 {
+	if x != nil {
+		return x.QueryId
+	}
+	return 0
+}
+
+func (x *PromptSearchableResults) GetChoices() []*SelectChoice {
+	if x != nil {
+		return x.Choices
+	}
+	return nil
+}
+
+// PromptSearchableResponse carries the choice selected by the user and is the last message azd sends.
+type PromptSearchableResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         *SelectChoice          `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptSearchableResponse) Reset() {
+	*x = PromptSearchableResponse{}
+	mi := &file_prompt_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptSearchableResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptSearchableResponse) ProtoMessage() {}
+
+func (x *PromptSearchableResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptSearchableResponse.ProtoReflect.Descriptor instead.
+func (*PromptSearchableResponse) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *PromptSearchableResponse) GetValue() *SelectChoice {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// PromptValidatedClientMessage is sent by the extension on a PromptValidated stream. The first message must carry
+// options; every later message must carry the result for the latest validation.
+type PromptValidatedClientMessage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to MessageType:
+	//
+	//	*PromptValidatedClientMessage_Options
+	//	*PromptValidatedClientMessage_Result
+	MessageType   isPromptValidatedClientMessage_MessageType `protobuf_oneof:"message_type"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptValidatedClientMessage) Reset() {
+	*x = PromptValidatedClientMessage{}
+	mi := &file_prompt_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptValidatedClientMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptValidatedClientMessage) ProtoMessage() {}
+
+func (x *PromptValidatedClientMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptValidatedClientMessage.ProtoReflect.Descriptor instead.
+func (*PromptValidatedClientMessage) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *PromptValidatedClientMessage) GetMessageType() isPromptValidatedClientMessage_MessageType {
+	if x != nil {
+		return x.MessageType
+	}
+	return nil
+}
+
+func (x *PromptValidatedClientMessage) GetOptions() *PromptOptions {
+	if x != nil {
+		if x, ok := x.MessageType.(*PromptValidatedClientMessage_Options); ok {
+			return x.Options
+		}
+	}
+	return nil
+}
+
+func (x *PromptValidatedClientMessage) GetResult() *PromptValidationResult {
+	if x != nil {
+		if x, ok := x.MessageType.(*PromptValidatedClientMessage_Result); ok {
+			return x.Result
+		}
+	}
+	return nil
+}
+
+type isPromptValidatedClientMessage_MessageType interface {
+	isPromptValidatedClientMessage_MessageType()
+}
+
+type PromptValidatedClientMessage_Options struct {
+	Options *PromptOptions `protobuf:"bytes,1,opt,name=options,proto3,oneof"`
+}
+
+type PromptValidatedClientMessage_Result struct {
+	Result *PromptValidationResult `protobuf:"bytes,2,opt,name=result,proto3,oneof"`
+}
+
+func (*PromptValidatedClientMessage_Options) isPromptValidatedClientMessage_MessageType() {}
+
+func (*PromptValidatedClientMessage_Result) isPromptValidatedClientMessage_MessageType() {}
+
+// PromptValidatedServerMessage is sent by azd on a PromptValidated stream.
+type PromptValidatedServerMessage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to MessageType:
+	//
+	//	*PromptValidatedServerMessage_Validation
+	//	*PromptValidatedServerMessage_Response
+	MessageType   isPromptValidatedServerMessage_MessageType `protobuf_oneof:"message_type"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptValidatedServerMessage) Reset() {
+	*x = PromptValidatedServerMessage{}
+	mi := &file_prompt_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptValidatedServerMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptValidatedServerMessage) ProtoMessage() {}
+
+func (x *PromptValidatedServerMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptValidatedServerMessage.ProtoReflect.Descriptor instead.
+func (*PromptValidatedServerMessage) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *PromptValidatedServerMessage) GetMessageType() isPromptValidatedServerMessage_MessageType {
+	if x != nil {
+		return x.MessageType
+	}
+	return nil
+}
+
+func (x *PromptValidatedServerMessage) GetValidation() *PromptValidation {
+	if x != nil {
+		if x, ok := x.MessageType.(*PromptValidatedServerMessage_Validation); ok {
+			return x.Validation
+		}
+	}
+	return nil
+}
+
+func (x *PromptValidatedServerMessage) GetResponse() *PromptResponse {
+	if x != nil {
+		if x, ok := x.MessageType.(*PromptValidatedServerMessage_Response); ok {
+			return x.Response
+		}
+	}
+	return nil
+}
+
+type isPromptValidatedServerMessage_MessageType interface {
+	isPromptValidatedServerMessage_MessageType()
+}
+
+type PromptValidatedServerMessage_Validation struct {
+	Validation *PromptValidation `protobuf:"bytes,1,opt,name=validation,proto3,oneof"`
+}
+
+type PromptValidatedServerMessage_Response struct {
+	Response *PromptResponse `protobuf:"bytes,2,opt,name=response,proto3,oneof"`
+}
+
+func (*PromptValidatedServerMessage_Validation) isPromptValidatedServerMessage_MessageType() {}
+
+func (*PromptValidatedServerMessage_Response) isPromptValidatedServerMessage_MessageType() {}
+
+// PromptValidation asks the extension to validate a value submitted by the user. The value has already passed the
+// required check of the prompt options.
+type PromptValidation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Identifies the validation; the result must echo it in validation_id.
+	Id            int32  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Value         string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptValidation) Reset() {
+	*x = PromptValidation{}
+	mi := &file_prompt_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptValidation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptValidation) ProtoMessage() {}
+
+func (x *PromptValidation) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptValidation.ProtoReflect.Descriptor instead.
+func (*PromptValidation) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *PromptValidation) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PromptValidation) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// PromptValidationResult answers a PromptValidation.
+type PromptValidationResult struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ValidationId int32                  `protobuf:"varint,1,opt,name=validation_id,json=validationId,proto3" json:"validation_id,omitempty"`
+	Valid        bool                   `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	// Shown when the value is rejected, e.g. "The name 'web' is already taken". Defaults to the validation_message of
+	// the prompt options.
+	Message       string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptValidationResult) Reset() {
+	*x = PromptValidationResult{}
+	mi := &file_prompt_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptValidationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptValidationResult) ProtoMessage() {}
+
+func (x *PromptValidationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptValidationResult.ProtoReflect.Descriptor instead.
+func (*PromptValidationResult) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *PromptValidationResult) GetValidationId() int32 {
+	if x != nil {
+		return x.ValidationId
+	}
+	return 0
+}
+
+func (x *PromptValidationResult) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *PromptValidationResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type PromptSubscriptionResourceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AzureContext  *AzureContext          `protobuf:"bytes,1,opt,name=azure_context,json=azureContext,proto3" json:"azure_context,omitempty"`
+	Options       *PromptResourceOptions `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptSubscriptionResourceRequest) Reset() {
+	*x = PromptSubscriptionResourceRequest{}
+	mi := &file_prompt_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptSubscriptionResourceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptSubscriptionResourceRequest) ProtoMessage() {}
+
+func (x *PromptSubscriptionResourceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptSubscriptionResourceRequest.ProtoReflect.Descriptor instead.
+func (*PromptSubscriptionResourceRequest) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *PromptSubscriptionResourceRequest) GetAzureContext() *AzureContext {
+	if x != nil {
+		return x.AzureContext
+	}
+	return nil
+}
+
+func (x *PromptSubscriptionResourceRequest) GetOptions() *PromptResourceOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type PromptSubscriptionResourceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Resource      *ResourceExtended      `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptSubscriptionResourceResponse) Reset() {
+	*x = PromptSubscriptionResourceResponse{}
+	mi := &file_prompt_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptSubscriptionResourceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptSubscriptionResourceResponse) ProtoMessage() {}
+
+func (x *PromptSubscriptionResourceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptSubscriptionResourceResponse.ProtoReflect.Descriptor instead.
+func (*PromptSubscriptionResourceResponse) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *PromptSubscriptionResourceResponse) GetResource() *ResourceExtended {
+	if x != nil {
+		return x.Resource
+	}
+	return nil
+}
+
+type PromptResourceGroupResourceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AzureContext  *AzureContext          `protobuf:"bytes,1,opt,name=azure_context,json=azureContext,proto3" json:"azure_context,omitempty"`
+	Options       *PromptResourceOptions `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptResourceGroupResourceRequest) Reset() {
+	*x = PromptResourceGroupResourceRequest{}
+	mi := &file_prompt_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptResourceGroupResourceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptResourceGroupResourceRequest) ProtoMessage() {}
+
+func (x *PromptResourceGroupResourceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptResourceGroupResourceRequest.ProtoReflect.Descriptor instead.
+func (*PromptResourceGroupResourceRequest) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *PromptResourceGroupResourceRequest) GetAzureContext() *AzureContext {
+	if x != nil {
+		return x.AzureContext
+	}
+	return nil
+}
+
+func (x *PromptResourceGroupResourceRequest) GetOptions() *PromptResourceOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type PromptResourceGroupResourceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Resource      *ResourceExtended      `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptResourceGroupResourceResponse) Reset() {
+	*x = PromptResourceGroupResourceResponse{}
+	mi := &file_prompt_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptResourceGroupResourceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptResourceGroupResourceResponse) ProtoMessage() {}
+
+func (x *PromptResourceGroupResourceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptResourceGroupResourceResponse.ProtoReflect.Descriptor instead.
+func (*PromptResourceGroupResourceResponse) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *PromptResourceGroupResourceResponse) GetResource() *ResourceExtended {
+	if x != nil {
+		return x.Resource
+	}
+	return nil
+}
+
+type ConfirmOptions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DefaultValue  *bool                  `protobuf:"varint,1,opt,name=default_value,json=defaultValue,proto3,oneof" json:"default_value,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	HelpMessage   string                 `protobuf:"bytes,3,opt,name=help_message,json=helpMessage,proto3" json:"help_message,omitempty"`
+	Hint          string                 `protobuf:"bytes,4,opt,name=hint,proto3" json:"hint,omitempty"`
+	Placeholder   string                 `protobuf:"bytes,5,opt,name=placeholder,proto3" json:"placeholder,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmOptions) Reset() {
+	*x = ConfirmOptions{}
+	mi := &file_prompt_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmOptions) ProtoMessage() {}
+
+func (x *ConfirmOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmOptions.ProtoReflect.Descriptor instead.
+func (*ConfirmOptions) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ConfirmOptions) GetDefaultValue() bool {
+	if x != nil && x.DefaultValue != nil {
+		return *x.DefaultValue
+	}
+	return false
+}
+
+func (x *ConfirmOptions) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ConfirmOptions) GetHelpMessage() string {
+	if x != nil {
+		return x.HelpMessage
+	}
+	return ""
+}
+
+func (x *ConfirmOptions) GetHint() string {
+	if x != nil {
+		return x.Hint
+	}
+	return ""
+}
+
+func (x *ConfirmOptions) GetPlaceholder() string {
+	if x != nil {
+		return x.Placeholder
+	}
+	return ""
+}
+
+type PromptDestructiveConfirmOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Describes the action and what it destroys, e.g. "This permanently deletes the account my-ai and its deployments."
+	Message     string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	HelpMessage string `protobuf:"bytes,2,opt,name=help_message,json=helpMessage,proto3" json:"help_message,omitempty"`
+	// Required phrase the user must type to confirm, such as the name of the resource. Matching is exact and
+	// case-sensitive.
+	ConfirmationPhrase string `protobuf:"bytes,3,opt,name=confirmation_phrase,json=confirmationPhrase,proto3" json:"confirmation_phrase,omitempty"`
+	// Number of attempts the user gets to type the phrase. Defaults to 3.
+	MaxAttempts int32 `protobuf:"varint,4,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
+	// Confirms without prompting, typically set from the extension's --force flag. Required in no-prompt mode.
+	Force         bool `protobuf:"varint,5,opt,name=force,proto3" json:"force,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptDestructiveConfirmOptions) Reset() {
+	*x = PromptDestructiveConfirmOptions{}
+	mi := &file_prompt_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptDestructiveConfirmOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptDestructiveConfirmOptions) ProtoMessage() {}
+
+func (x *PromptDestructiveConfirmOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptDestructiveConfirmOptions.ProtoReflect.Descriptor instead.
+func (*PromptDestructiveConfirmOptions) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *PromptDestructiveConfirmOptions) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PromptDestructiveConfirmOptions) GetHelpMessage() string {
+	if x != nil {
+		return x.HelpMessage
+	}
+	return ""
+}
+
+func (x *PromptDestructiveConfirmOptions) GetConfirmationPhrase() string {
+	if x != nil {
+		return x.ConfirmationPhrase
+	}
+	return ""
+}
+
+func (x *PromptDestructiveConfirmOptions) GetMaxAttempts() int32 {
+	if x != nil {
+		return x.MaxAttempts
+	}
+	return 0
+}
+
+func (x *PromptDestructiveConfirmOptions) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+type PromptOptions struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Message           string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	HelpMessage       string                 `protobuf:"bytes,2,opt,name=help_message,json=helpMessage,proto3" json:"help_message,omitempty"`
+	Hint              string                 `protobuf:"bytes,3,opt,name=hint,proto3" json:"hint,omitempty"`
+	Placeholder       string                 `protobuf:"bytes,4,opt,name=placeholder,proto3" json:"placeholder,omitempty"`
+	ValidationMessage string                 `protobuf:"bytes,5,opt,name=validation_message,json=validationMessage,proto3" json:"validation_message,omitempty"`
+	RequiredMessage   string                 `protobuf:"bytes,6,opt,name=required_message,json=requiredMessage,proto3" json:"required_message,omitempty"`
+	Required          bool                   `protobuf:"varint,7,opt,name=required,proto3" json:"required,omitempty"`
+	DefaultValue      string                 `protobuf:"bytes,8,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"`
+	ClearOnCompletion bool                   `protobuf:"varint,9,opt,name=clear_on_completion,json=clearOnCompletion,proto3" json:"clear_on_completion,omitempty"`
+	IgnoreHintKeys    bool                   `protobuf:"varint,10,opt,name=ignore_hint_keys,json=ignoreHintKeys,proto3" json:"ignore_hint_keys,omitempty"`
+	Secret            bool                   `protobuf:"varint,11,opt,name=secret,proto3" json:"secret,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *PromptOptions) Reset() {
+	*x = PromptOptions{}
+	mi := &file_prompt_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptOptions) ProtoMessage() {}
+
+func (x *PromptOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptOptions.ProtoReflect.Descriptor instead.
+func (*PromptOptions) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *PromptOptions) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PromptOptions) GetHelpMessage() string {
+	if x != nil {
+		return x.HelpMessage
+	}
+	return ""
+}
+
+func (x *PromptOptions) GetHint() string {
+	if x != nil {
+		return x.Hint
+	}
+	return ""
+}
+
+func (x *PromptOptions) GetPlaceholder() string {
+	if x != nil {
+		return x.Placeholder
+	}
+	return ""
+}
+
+func (x *PromptOptions) GetValidationMessage() string {
+	if x != nil {
+		return x.ValidationMessage
+	}
+	return ""
+}
+
+func (x *PromptOptions) GetRequiredMessage() string {
+	if x != nil {
+		return x.RequiredMessage
+	}
+	return ""
+}
+
+func (x *PromptOptions) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *PromptOptions) GetDefaultValue() string {
+	if x != nil {
+		return x.DefaultValue
+	}
+	return ""
+}
+
+func (x *PromptOptions) GetClearOnCompletion() bool {
+	if x != nil {
+		return x.ClearOnCompletion
+	}
+	return false
+}
+
+func (x *PromptOptions) GetIgnoreHintKeys() bool {
+	if x != nil {
+		return x.IgnoreHintKeys
+	}
+	return false
+}
+
+func (x *PromptOptions) GetSecret() bool {
+	if x != nil {
+		return x.Secret
+	}
+	return false
+}
+
+type SelectChoice struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Value string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Label string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	// Optional one-line description rendered dimmed beside the highlighted choice.
+	Description   string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SelectChoice) Reset() {
+	*x = SelectChoice{}
+	mi := &file_prompt_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SelectChoice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelectChoice) ProtoMessage() {}
+
+func (x *SelectChoice) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelectChoice.ProtoReflect.Descriptor instead.
+func (*SelectChoice) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *SelectChoice) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *SelectChoice) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *SelectChoice) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type MultiSelectChoice struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Label         string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	Selected      bool                   `protobuf:"varint,3,opt,name=selected,proto3" json:"selected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MultiSelectChoice) Reset() {
+	*x = MultiSelectChoice{}
+	mi := &file_prompt_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MultiSelectChoice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MultiSelectChoice) ProtoMessage() {}
+
+func (x *MultiSelectChoice) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MultiSelectChoice.ProtoReflect.Descriptor instead.
+func (*MultiSelectChoice) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *MultiSelectChoice) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *MultiSelectChoice) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *MultiSelectChoice) GetSelected() bool {
+	if x != nil {
+		return x.Selected
+	}
+	return false
+}
+
+// TreeChoice is a node in a PromptTree. Choices without children are leaves and complete the selection.
+type TreeChoice struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Value string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Label string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	// Optional one-line description rendered dimmed beside the highlighted choice.
+	Description   string        `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Children      []*TreeChoice `protobuf:"bytes,4,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TreeChoice) Reset() {
+	*x = TreeChoice{}
+	mi := &file_prompt_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TreeChoice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TreeChoice) ProtoMessage() {}
+
+func (x *TreeChoice) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TreeChoice.ProtoReflect.Descriptor instead.
+func (*TreeChoice) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *TreeChoice) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *TreeChoice) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *TreeChoice) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *TreeChoice) GetChildren() []*TreeChoice {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+type SelectOptions struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	SelectedIndex   *int32                 `protobuf:"varint,1,opt,name=selected_index,json=selectedIndex,proto3,oneof" json:"selected_index,omitempty"`
+	Message         string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Choices         []*SelectChoice        `protobuf:"bytes,3,rep,name=choices,proto3" json:"choices,omitempty"`
+	HelpMessage     string                 `protobuf:"bytes,4,opt,name=help_message,json=helpMessage,proto3" json:"help_message,omitempty"`
+	Hint            string                 `protobuf:"bytes,5,opt,name=hint,proto3" json:"hint,omitempty"`
+	DisplayCount    int32                  `protobuf:"varint,6,opt,name=display_count,json=displayCount,proto3" json:"display_count,omitempty"`
+	DisplayNumbers  *bool                  `protobuf:"varint,7,opt,name=display_numbers,json=displayNumbers,proto3,oneof" json:"display_numbers,omitempty"`
+	EnableFiltering *bool                  `protobuf:"varint,8,opt,name=enable_filtering,json=enableFiltering,proto3,oneof" json:"enable_filtering,omitempty"`
+	// When there is exactly one choice, select it without prompting and print a dimmed message naming it.
+	AnnounceAutoSelect bool `protobuf:"varint,9,opt,name=announce_auto_select,json=announceAutoSelect,proto3" json:"announce_auto_select,omitempty"`
+	// When set, the chosen value is remembered under this key in the user config, and the next select with the same
+	// key pre-selects it, taking precedence over selected_index while it is still one of the choices. In no-prompt
+	// mode the remembered value is used without prompting. Remembering is best effort and never fails the prompt.
+	PersistKey string `protobuf:"bytes,10,opt,name=persist_key,json=persistKey,proto3" json:"persist_key,omitempty"`
+	// Remembers the choice for persist_key separately for each azd project. Outside a project the choice is shared.
+	PersistPerProject bool `protobuf:"varint,11,opt,name=persist_per_project,json=persistPerProject,proto3" json:"persist_per_project,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SelectOptions) Reset() {
+	*x = SelectOptions{}
+	mi := &file_prompt_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SelectOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelectOptions) ProtoMessage() {}
+
+func (x *SelectOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelectOptions.ProtoReflect.Descriptor instead.
+func (*SelectOptions) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *SelectOptions) GetSelectedIndex() int32 {
+	if x != nil && x.SelectedIndex != nil {
+		return *x.SelectedIndex
+	}
+	return 0
+}
+
+func (x *SelectOptions) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SelectOptions) GetChoices() []*SelectChoice {
+	if x != nil {
+		return x.Choices
+	}
+	return nil
+}
+
+func (x *SelectOptions) GetHelpMessage() string {
+	if x != nil {
+		return x.HelpMessage
+	}
+	return ""
+}
+
+func (x *SelectOptions) GetHint() string {
+	if x != nil {
+		return x.Hint
+	}
+	return ""
+}
+
+func (x *SelectOptions) GetDisplayCount() int32 {
+	if x != nil {
+		return x.DisplayCount
+	}
+	return 0
+}
+
+func (x *SelectOptions) GetDisplayNumbers() bool {
+	if x != nil && x.DisplayNumbers != nil {
+		return *x.DisplayNumbers
 	}
 	return false
 }
 
-func (x *ConfirmOptions) GetMessage() string {
+func (x *SelectOptions) GetEnableFiltering() bool {
+	if x != nil && x.EnableFiltering != nil {
+		return *x.EnableFiltering
+	}
+	return false
+}
+
+func (x *SelectOptions) GetAnnounceAutoSelect() bool {
+	if x != nil {
+		return x.AnnounceAutoSelect
+	}
+	return false
+}
+
+func (x *SelectOptions) GetPersistKey() string {
+	if x != nil {
+		return x.PersistKey
+	}
+	return ""
+}
+
+func (x *SelectOptions) GetPersistPerProject() bool {
+	if x != nil {
+		return x.PersistPerProject
+	}
+	return false
+}
+
+type MultiSelectOptions struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Message         string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Choices         []*MultiSelectChoice   `protobuf:"bytes,2,rep,name=choices,proto3" json:"choices,omitempty"`
+	HelpMessage     string                 `protobuf:"bytes,3,opt,name=help_message,json=helpMessage,proto3" json:"help_message,omitempty"`
+	Hint            string                 `protobuf:"bytes,4,opt,name=hint,proto3" json:"hint,omitempty"`
+	DisplayCount    int32                  `protobuf:"varint,5,opt,name=display_count,json=displayCount,proto3" json:"display_count,omitempty"`
+	DisplayNumbers  *bool                  `protobuf:"varint,6,opt,name=display_numbers,json=displayNumbers,proto3,oneof" json:"display_numbers,omitempty"`
+	EnableFiltering *bool                  `protobuf:"varint,7,opt,name=enable_filtering,json=enableFiltering,proto3,oneof" json:"enable_filtering,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *MultiSelectOptions) Reset() {
+	*x = MultiSelectOptions{}
+	mi := &file_prompt_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MultiSelectOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MultiSelectOptions) ProtoMessage() {}
+
+func (x *MultiSelectOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MultiSelectOptions.ProtoReflect.Descriptor instead.
+func (*MultiSelectOptions) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *MultiSelectOptions) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *MultiSelectOptions) GetChoices() []*MultiSelectChoice {
+	if x != nil {
+		return x.Choices
+	}
+	return nil
+}
+
+func (x *MultiSelectOptions) GetHelpMessage() string {
+	if x != nil {
+		return x.HelpMessage
+	}
+	return ""
+}
+
+func (x *MultiSelectOptions) GetHint() string {
+	if x != nil {
+		return x.Hint
+	}
+	return ""
+}
+
+func (x *MultiSelectOptions) GetDisplayCount() int32 {
+	if x != nil {
+		return x.DisplayCount
+	}
+	return 0
+}
+
+func (x *MultiSelectOptions) GetDisplayNumbers() bool {
+	if x != nil && x.DisplayNumbers != nil {
+		return *x.DisplayNumbers
+	}
+	return false
+}
+
+func (x *MultiSelectOptions) GetEnableFiltering() bool {
+	if x != nil && x.EnableFiltering != nil {
+		return *x.EnableFiltering
+	}
+	return false
+}
+
+type PromptTreeOptions struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Message string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Choices []*TreeChoice          `protobuf:"bytes,2,rep,name=choices,proto3" json:"choices,omitempty"`
+	// Values of a pre-selected path from the root level. Used as the default choice at each level when
+	// prompting, and as the result in no-prompt mode, where it must end at a leaf.
+	SelectedPath    []string `protobuf:"bytes,3,rep,name=selected_path,json=selectedPath,proto3" json:"selected_path,omitempty"`
+	HelpMessage     string   `protobuf:"bytes,4,opt,name=help_message,json=helpMessage,proto3" json:"help_message,omitempty"`
+	DisplayCount    int32    `protobuf:"varint,5,opt,name=display_count,json=displayCount,proto3" json:"display_count,omitempty"`
+	DisplayNumbers  *bool    `protobuf:"varint,6,opt,name=display_numbers,json=displayNumbers,proto3,oneof" json:"display_numbers,omitempty"`
+	EnableFiltering *bool    `protobuf:"varint,7,opt,name=enable_filtering,json=enableFiltering,proto3,oneof" json:"enable_filtering,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PromptTreeOptions) Reset() {
+	*x = PromptTreeOptions{}
+	mi := &file_prompt_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptTreeOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptTreeOptions) ProtoMessage() {}
+
+func (x *PromptTreeOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptTreeOptions.ProtoReflect.Descriptor instead.
+func (*PromptTreeOptions) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *PromptTreeOptions) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
-	return ""
+	return ""
+}
+
+func (x *PromptTreeOptions) GetChoices() []*TreeChoice {
+	if x != nil {
+		return x.Choices
+	}
+	return nil
+}
+
+func (x *PromptTreeOptions) GetSelectedPath() []string {
+	if x != nil {
+		return x.SelectedPath
+	}
+	return nil
 }
 
-func (x *ConfirmOptions) GetHelpMessage() string {
+func (x *PromptTreeOptions) GetHelpMessage() string {
 	if x != nil {
 		return x.HelpMessage
 	}
 	return ""
 }
 
-func (x *ConfirmOptions) GetHint() string {
+func (x *PromptTreeOptions) GetDisplayCount() int32 {
 	if x != nil {
-		return x.Hint
+		return x.DisplayCount
 	}
-	return ""
+	return 0
 }
 
-func (x *ConfirmOptions) GetPlaceholder() string {
-	if x != nil {
-		return x.Placeholder
+func (x *PromptTreeOptions) GetDisplayNumbers() bool {
+	if x != nil && x.DisplayNumbers != nil {
+		return *x.DisplayNumbers
 	}
-	return ""
+	return false
 }
 
-type PromptOptions struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	Message           string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	HelpMessage       string                 `protobuf:"bytes,2,opt,name=help_message,json=helpMessage,proto3" json:"help_message,omitempty"`
-	Hint              string                 `protobuf:"bytes,3,opt,name=hint,proto3" json:"hint,omitempty"`
-	Placeholder       string                 `protobuf:"bytes,4,opt,name=placeholder,proto3" json:"placeholder,omitempty"`
-	ValidationMessage string                 `protobuf:"bytes,5,opt,name=validation_message,json=validationMessage,proto3" json:"validation_message,omitempty"`
-	RequiredMessage   string                 `protobuf:"bytes,6,opt,name=required_message,json=requiredMessage,proto3" json:"required_message,omitempty"`
-	Required          bool                   `protobuf:"varint,7,opt,name=required,proto3" json:"required,omitempty"`
-	DefaultValue      string                 `protobuf:"bytes,8,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"`
-	ClearOnCompletion bool                   `protobuf:"varint,9,opt,name=clear_on_completion,json=clearOnCompletion,proto3" json:"clear_on_completion,omitempty"`
-	IgnoreHintKeys    bool                   `protobuf:"varint,10,opt,name=ignore_hint_keys,json=ignoreHintKeys,proto3" json:"ignore_hint_keys,omitempty"`
-	Secret            bool                   `protobuf:"varint,11,opt,name=secret,proto3" json:"secret,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+func (x *PromptTreeOptions) GetEnableFiltering() bool {
+	if x != nil && x.EnableFiltering != nil {
+		return *x.EnableFiltering
+	}
+	return false
 }
 
-func (x *PromptOptions) Reset() {
-	*x = PromptOptions{}
-	mi := &file_prompt_proto_msgTypes[19]
+type PromptPathOptions struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Message      string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	HelpMessage  string                 `protobuf:"bytes,2,opt,name=help_message,json=helpMessage,proto3" json:"help_message,omitempty"`
+	Placeholder  string                 `protobuf:"bytes,3,opt,name=placeholder,proto3" json:"placeholder,omitempty"`
+	DefaultValue string                 `protobuf:"bytes,4,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"`
+	// Whether the path must already exist.
+	MustExist bool `protobuf:"varint,5,opt,name=must_exist,json=mustExist,proto3" json:"must_exist,omitempty"`
+	// Whether the path must be a directory when it exists. Mutually exclusive with must_be_file.
+	MustBeDir bool `protobuf:"varint,6,opt,name=must_be_dir,json=mustBeDir,proto3" json:"must_be_dir,omitempty"`
+	// Whether the path must be a file when it exists. Mutually exclusive with must_be_dir.
+	MustBeFile bool `protobuf:"varint,7,opt,name=must_be_file,json=mustBeFile,proto3" json:"must_be_file,omitempty"`
+	// Whether to create the directory (including parents) when it does not exist. Requires must_be_dir.
+	CreateIfMissing bool `protobuf:"varint,8,opt,name=create_if_missing,json=createIfMissing,proto3" json:"create_if_missing,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PromptPathOptions) Reset() {
+	*x = PromptPathOptions{}
+	mi := &file_prompt_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PromptOptions) String() string {
+func (x *PromptPathOptions) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PromptOptions) ProtoMessage() {}
+func (*PromptPathOptions) ProtoMessage() {}
 
-func (x *PromptOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_prompt_proto_msgTypes[19]
+func (x *PromptPathOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -974,111 +3260,166 @@ func (x *PromptOptions) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PromptOptions.ProtoReflect.Descriptor instead.
-func (*PromptOptions) Descriptor() ([]byte, []int) {
-	return file_prompt_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use PromptPathOptions.ProtoReflect.Descriptor instead.
+func (*PromptPathOptions) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *PromptOptions) GetMessage() string {
+func (x *PromptPathOptions) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *PromptOptions) GetHelpMessage() string {
+func (x *PromptPathOptions) GetHelpMessage() string {
 	if x != nil {
 		return x.HelpMessage
 	}
 	return ""
 }
 
-func (x *PromptOptions) GetHint() string {
+func (x *PromptPathOptions) GetPlaceholder() string {
 	if x != nil {
-		return x.Hint
+		return x.Placeholder
 	}
 	return ""
 }
 
-func (x *PromptOptions) GetPlaceholder() string {
+func (x *PromptPathOptions) GetDefaultValue() string {
 	if x != nil {
-		return x.Placeholder
+		return x.DefaultValue
 	}
 	return ""
 }
 
-func (x *PromptOptions) GetValidationMessage() string {
+func (x *PromptPathOptions) GetMustExist() bool {
 	if x != nil {
-		return x.ValidationMessage
+		return x.MustExist
 	}
-	return ""
+	return false
 }
 
-func (x *PromptOptions) GetRequiredMessage() string {
+func (x *PromptPathOptions) GetMustBeDir() bool {
 	if x != nil {
-		return x.RequiredMessage
+		return x.MustBeDir
 	}
-	return ""
+	return false
 }
 
-func (x *PromptOptions) GetRequired() bool {
+func (x *PromptPathOptions) GetMustBeFile() bool {
 	if x != nil {
-		return x.Required
+		return x.MustBeFile
 	}
 	return false
 }
 
-func (x *PromptOptions) GetDefaultValue() string {
+func (x *PromptPathOptions) GetCreateIfMissing() bool {
 	if x != nil {
-		return x.DefaultValue
+		return x.CreateIfMissing
+	}
+	return false
+}
+
+type PromptEditorOptions struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Message     string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	HelpMessage string                 `protobuf:"bytes,2,opt,name=help_message,json=helpMessage,proto3" json:"help_message,omitempty"`
+	// The content the editor is seeded with.
+	DefaultValue string `protobuf:"bytes,3,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"`
+	// Extension of the file opened in the editor, e.g. ".yaml", so the editor can apply syntax highlighting.
+	FileExtension string `protobuf:"bytes,4,opt,name=file_extension,json=fileExtension,proto3" json:"file_extension,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptEditorOptions) Reset() {
+	*x = PromptEditorOptions{}
+	mi := &file_prompt_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptEditorOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptEditorOptions) ProtoMessage() {}
+
+func (x *PromptEditorOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptEditorOptions.ProtoReflect.Descriptor instead.
+func (*PromptEditorOptions) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *PromptEditorOptions) GetMessage() string {
+	if x != nil {
+		return x.Message
 	}
 	return ""
 }
 
-func (x *PromptOptions) GetClearOnCompletion() bool {
+func (x *PromptEditorOptions) GetHelpMessage() string {
 	if x != nil {
-		return x.ClearOnCompletion
+		return x.HelpMessage
 	}
-	return false
+	return ""
 }
 
-func (x *PromptOptions) GetIgnoreHintKeys() bool {
+func (x *PromptEditorOptions) GetDefaultValue() string {
 	if x != nil {
-		return x.IgnoreHintKeys
+		return x.DefaultValue
 	}
-	return false
+	return ""
 }
 
-func (x *PromptOptions) GetSecret() bool {
+func (x *PromptEditorOptions) GetFileExtension() string {
 	if x != nil {
-		return x.Secret
+		return x.FileExtension
 	}
-	return false
+	return ""
 }
 
-type SelectChoice struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
-	Label         string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+type PromptDurationOptions struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Message     string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	HelpMessage string                 `protobuf:"bytes,2,opt,name=help_message,json=helpMessage,proto3" json:"help_message,omitempty"`
+	// Go duration used as the default, e.g. "30s".
+	DefaultValue string `protobuf:"bytes,3,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"`
+	// Optional smallest accepted Go duration, e.g. "1s".
+	MinValue string `protobuf:"bytes,4,opt,name=min_value,json=minValue,proto3" json:"min_value,omitempty"`
+	// Optional largest accepted Go duration, e.g. "1h".
+	MaxValue      string `protobuf:"bytes,5,opt,name=max_value,json=maxValue,proto3" json:"max_value,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SelectChoice) Reset() {
-	*x = SelectChoice{}
-	mi := &file_prompt_proto_msgTypes[20]
+func (x *PromptDurationOptions) Reset() {
+	*x = PromptDurationOptions{}
+	mi := &file_prompt_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SelectChoice) String() string {
+func (x *PromptDurationOptions) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SelectChoice) ProtoMessage() {}
+func (*PromptDurationOptions) ProtoMessage() {}
 
-func (x *SelectChoice) ProtoReflect() protoreflect.Message {
-	mi := &file_prompt_proto_msgTypes[20]
+func (x *PromptDurationOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1089,49 +3430,75 @@ func (x *SelectChoice) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SelectChoice.ProtoReflect.Descriptor instead.
-func (*SelectChoice) Descriptor() ([]byte, []int) {
-	return file_prompt_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use PromptDurationOptions.ProtoReflect.Descriptor instead.
+func (*PromptDurationOptions) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{59}
 }
 
-func (x *SelectChoice) GetValue() string {
+func (x *PromptDurationOptions) GetMessage() string {
 	if x != nil {
-		return x.Value
+		return x.Message
 	}
 	return ""
 }
 
-func (x *SelectChoice) GetLabel() string {
+func (x *PromptDurationOptions) GetHelpMessage() string {
 	if x != nil {
-		return x.Label
+		return x.HelpMessage
 	}
 	return ""
 }
 
-type MultiSelectChoice struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
-	Label         string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
-	Selected      bool                   `protobuf:"varint,3,opt,name=selected,proto3" json:"selected,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *PromptDurationOptions) GetDefaultValue() string {
+	if x != nil {
+		return x.DefaultValue
+	}
+	return ""
 }
 
-func (x *MultiSelectChoice) Reset() {
-	*x = MultiSelectChoice{}
-	mi := &file_prompt_proto_msgTypes[21]
+func (x *PromptDurationOptions) GetMinValue() string {
+	if x != nil {
+		return x.MinValue
+	}
+	return ""
+}
+
+func (x *PromptDurationOptions) GetMaxValue() string {
+	if x != nil {
+		return x.MaxValue
+	}
+	return ""
+}
+
+type PromptSearchableOptions struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Message        string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	HelpMessage    string                 `protobuf:"bytes,2,opt,name=help_message,json=helpMessage,proto3" json:"help_message,omitempty"`
+	Hint           string                 `protobuf:"bytes,3,opt,name=hint,proto3" json:"hint,omitempty"`
+	DisplayCount   int32                  `protobuf:"varint,4,opt,name=display_count,json=displayCount,proto3" json:"display_count,omitempty"`
+	DisplayNumbers *bool                  `protobuf:"varint,5,opt,name=display_numbers,json=displayNumbers,proto3,oneof" json:"display_numbers,omitempty"`
+	// When the first query returns exactly one choice, select it without prompting and print a dimmed message
+	// naming it.
+	AnnounceAutoSelect bool `protobuf:"varint,6,opt,name=announce_auto_select,json=announceAutoSelect,proto3" json:"announce_auto_select,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *PromptSearchableOptions) Reset() {
+	*x = PromptSearchableOptions{}
+	mi := &file_prompt_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MultiSelectChoice) String() string {
+func (x *PromptSearchableOptions) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MultiSelectChoice) ProtoMessage() {}
+func (*PromptSearchableOptions) ProtoMessage() {}
 
-func (x *MultiSelectChoice) ProtoReflect() protoreflect.Message {
-	mi := &file_prompt_proto_msgTypes[21]
+func (x *PromptSearchableOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1142,61 +3509,91 @@ func (x *MultiSelectChoice) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MultiSelectChoice.ProtoReflect.Descriptor instead.
-func (*MultiSelectChoice) Descriptor() ([]byte, []int) {
-	return file_prompt_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use PromptSearchableOptions.ProtoReflect.Descriptor instead.
+func (*PromptSearchableOptions) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{60}
 }
 
-func (x *MultiSelectChoice) GetValue() string {
+func (x *PromptSearchableOptions) GetMessage() string {
 	if x != nil {
-		return x.Value
+		return x.Message
 	}
 	return ""
 }
 
-func (x *MultiSelectChoice) GetLabel() string {
+func (x *PromptSearchableOptions) GetHelpMessage() string {
 	if x != nil {
-		return x.Label
+		return x.HelpMessage
 	}
 	return ""
 }
 
-func (x *MultiSelectChoice) GetSelected() bool {
+func (x *PromptSearchableOptions) GetHint() string {
 	if x != nil {
-		return x.Selected
+		return x.Hint
+	}
+	return ""
+}
+
+func (x *PromptSearchableOptions) GetDisplayCount() int32 {
+	if x != nil {
+		return x.DisplayCount
+	}
+	return 0
+}
+
+func (x *PromptSearchableOptions) GetDisplayNumbers() bool {
+	if x != nil && x.DisplayNumbers != nil {
+		return *x.DisplayNumbers
 	}
 	return false
 }
 
-type SelectOptions struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	SelectedIndex   *int32                 `protobuf:"varint,1,opt,name=selected_index,json=selectedIndex,proto3,oneof" json:"selected_index,omitempty"`
-	Message         string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Choices         []*SelectChoice        `protobuf:"bytes,3,rep,name=choices,proto3" json:"choices,omitempty"`
-	HelpMessage     string                 `protobuf:"bytes,4,opt,name=help_message,json=helpMessage,proto3" json:"help_message,omitempty"`
-	Hint            string                 `protobuf:"bytes,5,opt,name=hint,proto3" json:"hint,omitempty"`
-	DisplayCount    int32                  `protobuf:"varint,6,opt,name=display_count,json=displayCount,proto3" json:"display_count,omitempty"`
-	DisplayNumbers  *bool                  `protobuf:"varint,7,opt,name=display_numbers,json=displayNumbers,proto3,oneof" json:"display_numbers,omitempty"`
-	EnableFiltering *bool                  `protobuf:"varint,8,opt,name=enable_filtering,json=enableFiltering,proto3,oneof" json:"enable_filtering,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *PromptSearchableOptions) GetAnnounceAutoSelect() bool {
+	if x != nil {
+		return x.AnnounceAutoSelect
+	}
+	return false
 }
 
-func (x *SelectOptions) Reset() {
-	*x = SelectOptions{}
-	mi := &file_prompt_proto_msgTypes[22]
+type PromptKeyValuesOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Message describing the pairs being collected, e.g. "Add tags to the resource group".
+	Message     string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	HelpMessage string `protobuf:"bytes,2,opt,name=help_message,json=helpMessage,proto3" json:"help_message,omitempty"`
+	// Message of the key prompt (default: "Enter a key").
+	KeyMessage string `protobuf:"bytes,3,opt,name=key_message,json=keyMessage,proto3" json:"key_message,omitempty"`
+	// Message of the value prompt (default: "Enter a value for <key>").
+	ValueMessage string `protobuf:"bytes,4,opt,name=value_message,json=valueMessage,proto3" json:"value_message,omitempty"`
+	// Pairs the user starts from. Required in no-prompt mode when min_entries is set.
+	DefaultValues map[string]string `protobuf:"bytes,5,rep,name=default_values,json=defaultValues,proto3" json:"default_values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Optional smallest number of pairs; the user is not offered to stop before it is reached.
+	MinEntries int32 `protobuf:"varint,6,opt,name=min_entries,json=minEntries,proto3" json:"min_entries,omitempty"`
+	// Re-prompt when an entered key already exists instead of replacing its value.
+	DisallowDuplicateKeys bool `protobuf:"varint,7,opt,name=disallow_duplicate_keys,json=disallowDuplicateKeys,proto3" json:"disallow_duplicate_keys,omitempty"`
+	// Optional regular expression every key must fully match, e.g. "^[A-Z_][A-Z0-9_]*$".
+	KeyPattern string `protobuf:"bytes,8,opt,name=key_pattern,json=keyPattern,proto3" json:"key_pattern,omitempty"`
+	// Message shown when a key does not match key_pattern.
+	KeyValidationMessage string `protobuf:"bytes,9,opt,name=key_validation_message,json=keyValidationMessage,proto3" json:"key_validation_message,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *PromptKeyValuesOptions) Reset() {
+	*x = PromptKeyValuesOptions{}
+	mi := &file_prompt_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SelectOptions) String() string {
+func (x *PromptKeyValuesOptions) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SelectOptions) ProtoMessage() {}
+func (*PromptKeyValuesOptions) ProtoMessage() {}
 
-func (x *SelectOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_prompt_proto_msgTypes[22]
+func (x *PromptKeyValuesOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1207,95 +3604,107 @@ func (x *SelectOptions) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SelectOptions.ProtoReflect.Descriptor instead.
-func (*SelectOptions) Descriptor() ([]byte, []int) {
-	return file_prompt_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use PromptKeyValuesOptions.ProtoReflect.Descriptor instead.
+func (*PromptKeyValuesOptions) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{61}
 }
 
-func (x *SelectOptions) GetSelectedIndex() int32 {
-	if x != nil && x.SelectedIndex != nil {
-		return *x.SelectedIndex
+func (x *PromptKeyValuesOptions) GetMessage() string {
+	if x != nil {
+		return x.Message
 	}
-	return 0
+	return ""
 }
 
-func (x *SelectOptions) GetMessage() string {
+func (x *PromptKeyValuesOptions) GetHelpMessage() string {
 	if x != nil {
-		return x.Message
+		return x.HelpMessage
 	}
 	return ""
 }
 
-func (x *SelectOptions) GetChoices() []*SelectChoice {
+func (x *PromptKeyValuesOptions) GetKeyMessage() string {
 	if x != nil {
-		return x.Choices
+		return x.KeyMessage
 	}
-	return nil
+	return ""
 }
 
-func (x *SelectOptions) GetHelpMessage() string {
+func (x *PromptKeyValuesOptions) GetValueMessage() string {
 	if x != nil {
-		return x.HelpMessage
+		return x.ValueMessage
 	}
 	return ""
 }
 
-func (x *SelectOptions) GetHint() string {
+func (x *PromptKeyValuesOptions) GetDefaultValues() map[string]string {
 	if x != nil {
-		return x.Hint
+		return x.DefaultValues
 	}
-	return ""
+	return nil
 }
 
-func (x *SelectOptions) GetDisplayCount() int32 {
+func (x *PromptKeyValuesOptions) GetMinEntries() int32 {
 	if x != nil {
-		return x.DisplayCount
+		return x.MinEntries
 	}
 	return 0
 }
 
-func (x *SelectOptions) GetDisplayNumbers() bool {
-	if x != nil && x.DisplayNumbers != nil {
-		return *x.DisplayNumbers
+func (x *PromptKeyValuesOptions) GetDisallowDuplicateKeys() bool {
+	if x != nil {
+		return x.DisallowDuplicateKeys
 	}
 	return false
 }
 
-func (x *SelectOptions) GetEnableFiltering() bool {
-	if x != nil && x.EnableFiltering != nil {
-		return *x.EnableFiltering
+func (x *PromptKeyValuesOptions) GetKeyPattern() string {
+	if x != nil {
+		return x.KeyPattern
 	}
-	return false
+	return ""
 }
 
-type MultiSelectOptions struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	Message         string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	Choices         []*MultiSelectChoice   `protobuf:"bytes,2,rep,name=choices,proto3" json:"choices,omitempty"`
-	HelpMessage     string                 `protobuf:"bytes,3,opt,name=help_message,json=helpMessage,proto3" json:"help_message,omitempty"`
-	Hint            string                 `protobuf:"bytes,4,opt,name=hint,proto3" json:"hint,omitempty"`
-	DisplayCount    int32                  `protobuf:"varint,5,opt,name=display_count,json=displayCount,proto3" json:"display_count,omitempty"`
-	DisplayNumbers  *bool                  `protobuf:"varint,6,opt,name=display_numbers,json=displayNumbers,proto3,oneof" json:"display_numbers,omitempty"`
-	EnableFiltering *bool                  `protobuf:"varint,7,opt,name=enable_filtering,json=enableFiltering,proto3,oneof" json:"enable_filtering,omitempty"`
+func (x *PromptKeyValuesOptions) GetKeyValidationMessage() string {
+	if x != nil {
+		return x.KeyValidationMessage
+	}
+	return ""
+}
+
+type PromptOrderedSelectOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Message describing the list being collected, e.g. "Order the fallback regions".
+	Message     string          `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Choices     []*SelectChoice `protobuf:"bytes,2,rep,name=choices,proto3" json:"choices,omitempty"`
+	HelpMessage string          `protobuf:"bytes,3,opt,name=help_message,json=helpMessage,proto3" json:"help_message,omitempty"`
+	// Values of choices, in order, returned in no-prompt mode. Required in no-prompt mode when min_selections is set.
+	DefaultValues []string `protobuf:"bytes,4,rep,name=default_values,json=defaultValues,proto3" json:"default_values,omitempty"`
+	// Optional smallest number of picks; the user is not offered to stop before it is reached.
+	MinSelections int32 `protobuf:"varint,5,opt,name=min_selections,json=minSelections,proto3" json:"min_selections,omitempty"`
+	// Optional largest number of picks; the prompt ends once it is reached. Zero allows every choice to be picked.
+	MaxSelections   int32 `protobuf:"varint,6,opt,name=max_selections,json=maxSelections,proto3" json:"max_selections,omitempty"`
+	DisplayCount    int32 `protobuf:"varint,7,opt,name=display_count,json=displayCount,proto3" json:"display_count,omitempty"`
+	EnableFiltering *bool `protobuf:"varint,8,opt,name=enable_filtering,json=enableFiltering,proto3,oneof" json:"enable_filtering,omitempty"`
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
 
-func (x *MultiSelectOptions) Reset() {
-	*x = MultiSelectOptions{}
-	mi := &file_prompt_proto_msgTypes[23]
+func (x *PromptOrderedSelectOptions) Reset() {
+	*x = PromptOrderedSelectOptions{}
+	mi := &file_prompt_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MultiSelectOptions) String() string {
+func (x *PromptOrderedSelectOptions) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MultiSelectOptions) ProtoMessage() {}
+func (*PromptOrderedSelectOptions) ProtoMessage() {}
 
-func (x *MultiSelectOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_prompt_proto_msgTypes[23]
+func (x *PromptOrderedSelectOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1306,54 +3715,61 @@ func (x *MultiSelectOptions) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MultiSelectOptions.ProtoReflect.Descriptor instead.
-func (*MultiSelectOptions) Descriptor() ([]byte, []int) {
-	return file_prompt_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use PromptOrderedSelectOptions.ProtoReflect.Descriptor instead.
+func (*PromptOrderedSelectOptions) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{62}
 }
 
-func (x *MultiSelectOptions) GetMessage() string {
+func (x *PromptOrderedSelectOptions) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *MultiSelectOptions) GetChoices() []*MultiSelectChoice {
+func (x *PromptOrderedSelectOptions) GetChoices() []*SelectChoice {
 	if x != nil {
 		return x.Choices
 	}
 	return nil
 }
 
-func (x *MultiSelectOptions) GetHelpMessage() string {
+func (x *PromptOrderedSelectOptions) GetHelpMessage() string {
 	if x != nil {
 		return x.HelpMessage
 	}
 	return ""
 }
 
-func (x *MultiSelectOptions) GetHint() string {
+func (x *PromptOrderedSelectOptions) GetDefaultValues() []string {
 	if x != nil {
-		return x.Hint
+		return x.DefaultValues
 	}
-	return ""
+	return nil
 }
 
-func (x *MultiSelectOptions) GetDisplayCount() int32 {
+func (x *PromptOrderedSelectOptions) GetMinSelections() int32 {
 	if x != nil {
-		return x.DisplayCount
+		return x.MinSelections
 	}
 	return 0
 }
 
-func (x *MultiSelectOptions) GetDisplayNumbers() bool {
-	if x != nil && x.DisplayNumbers != nil {
-		return *x.DisplayNumbers
+func (x *PromptOrderedSelectOptions) GetMaxSelections() int32 {
+	if x != nil {
+		return x.MaxSelections
 	}
-	return false
+	return 0
 }
 
-func (x *MultiSelectOptions) GetEnableFiltering() bool {
+func (x *PromptOrderedSelectOptions) GetDisplayCount() int32 {
+	if x != nil {
+		return x.DisplayCount
+	}
+	return 0
+}
+
+func (x *PromptOrderedSelectOptions) GetEnableFiltering() bool {
 	if x != nil && x.EnableFiltering != nil {
 		return *x.EnableFiltering
 	}
@@ -1372,7 +3788,7 @@ type PromptResourceOptions struct {
 
 func (x *PromptResourceOptions) Reset() {
 	*x = PromptResourceOptions{}
-	mi := &file_prompt_proto_msgTypes[24]
+	mi := &file_prompt_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1384,7 +3800,7 @@ func (x *PromptResourceOptions) String() string {
 func (*PromptResourceOptions) ProtoMessage() {}
 
 func (x *PromptResourceOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_prompt_proto_msgTypes[24]
+	mi := &file_prompt_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1397,7 +3813,7 @@ func (x *PromptResourceOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PromptResourceOptions.ProtoReflect.Descriptor instead.
 func (*PromptResourceOptions) Descriptor() ([]byte, []int) {
-	return file_prompt_proto_rawDescGZIP(), []int{24}
+	return file_prompt_proto_rawDescGZIP(), []int{63}
 }
 
 func (x *PromptResourceOptions) GetResourceType() string {
@@ -1447,7 +3863,7 @@ type PromptResourceSelectOptions struct {
 
 func (x *PromptResourceSelectOptions) Reset() {
 	*x = PromptResourceSelectOptions{}
-	mi := &file_prompt_proto_msgTypes[25]
+	mi := &file_prompt_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1459,7 +3875,7 @@ func (x *PromptResourceSelectOptions) String() string {
 func (*PromptResourceSelectOptions) ProtoMessage() {}
 
 func (x *PromptResourceSelectOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_prompt_proto_msgTypes[25]
+	mi := &file_prompt_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1472,7 +3888,7 @@ func (x *PromptResourceSelectOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PromptResourceSelectOptions.ProtoReflect.Descriptor instead.
 func (*PromptResourceSelectOptions) Descriptor() ([]byte, []int) {
-	return file_prompt_proto_rawDescGZIP(), []int{25}
+	return file_prompt_proto_rawDescGZIP(), []int{64}
 }
 
 func (x *PromptResourceSelectOptions) GetForceNewResource() bool {
@@ -1561,7 +3977,7 @@ type PromptResourceGroupOptions struct {
 
 func (x *PromptResourceGroupOptions) Reset() {
 	*x = PromptResourceGroupOptions{}
-	mi := &file_prompt_proto_msgTypes[26]
+	mi := &file_prompt_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1573,7 +3989,7 @@ func (x *PromptResourceGroupOptions) String() string {
 func (*PromptResourceGroupOptions) ProtoMessage() {}
 
 func (x *PromptResourceGroupOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_prompt_proto_msgTypes[26]
+	mi := &file_prompt_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1586,7 +4002,7 @@ func (x *PromptResourceGroupOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PromptResourceGroupOptions.ProtoReflect.Descriptor instead.
 func (*PromptResourceGroupOptions) Descriptor() ([]byte, []int) {
-	return file_prompt_proto_rawDescGZIP(), []int{26}
+	return file_prompt_proto_rawDescGZIP(), []int{65}
 }
 
 func (x *PromptResourceGroupOptions) GetSelectOptions() *PromptResourceSelectOptions {
@@ -1609,14 +4025,24 @@ type PromptAiModelRequest struct {
 	// With multiple locations, a model is kept if any location has sufficient quota.
 	Quota *QuotaCheckOptions `protobuf:"bytes,4,opt,name=quota,proto3" json:"quota,omitempty"`
 	// Optional default model name to pre-select in the list.
-	DefaultValue  string `protobuf:"bytes,5,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	DefaultValue string `protobuf:"bytes,5,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"`
+	// Prompt for a model family first when multiple models share a family
+	// (for example gpt-4o, gpt-4o-mini, gpt-4). Ignored when all families are distinct.
+	GroupByFamily bool `protobuf:"varint,6,opt,name=group_by_family,json=groupByFamily,proto3" json:"group_by_family,omitempty"`
+	// When filter.statuses is empty, versions in the Deprecating/Deprecated lifecycle stages are
+	// excluded by default. Set to true to offer them as well. Ignored when filter.statuses is set.
+	IncludeDeprecated bool `protobuf:"varint,7,opt,name=include_deprecated,json=includeDeprecated,proto3" json:"include_deprecated,omitempty"`
+	// When no models match in filter.locations, offer to search all other locations with the same
+	// filters instead of failing. If accepted, a location offering the selected model is also
+	// prompted for. Ignored in no-prompt mode.
+	SearchOtherLocations bool `protobuf:"varint,8,opt,name=search_other_locations,json=searchOtherLocations,proto3" json:"search_other_locations,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
 func (x *PromptAiModelRequest) Reset() {
 	*x = PromptAiModelRequest{}
-	mi := &file_prompt_proto_msgTypes[27]
+	mi := &file_prompt_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1628,7 +4054,7 @@ func (x *PromptAiModelRequest) String() string {
 func (*PromptAiModelRequest) ProtoMessage() {}
 
 func (x *PromptAiModelRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_prompt_proto_msgTypes[27]
+	mi := &file_prompt_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1641,7 +4067,7 @@ func (x *PromptAiModelRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PromptAiModelRequest.ProtoReflect.Descriptor instead.
 func (*PromptAiModelRequest) Descriptor() ([]byte, []int) {
-	return file_prompt_proto_rawDescGZIP(), []int{27}
+	return file_prompt_proto_rawDescGZIP(), []int{66}
 }
 
 func (x *PromptAiModelRequest) GetAzureContext() *AzureContext {
@@ -1679,17 +4105,41 @@ func (x *PromptAiModelRequest) GetDefaultValue() string {
 	return ""
 }
 
+func (x *PromptAiModelRequest) GetGroupByFamily() bool {
+	if x != nil {
+		return x.GroupByFamily
+	}
+	return false
+}
+
+func (x *PromptAiModelRequest) GetIncludeDeprecated() bool {
+	if x != nil {
+		return x.IncludeDeprecated
+	}
+	return false
+}
+
+func (x *PromptAiModelRequest) GetSearchOtherLocations() bool {
+	if x != nil {
+		return x.SearchOtherLocations
+	}
+	return false
+}
+
 type PromptAiModelResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Selected model from the filtered catalog.
-	Model         *AiModel `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	Model *AiModel `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	// Location selected for the model when the search was widened beyond filter.locations
+	// (see PromptAiModelRequest.search_other_locations). Unset otherwise.
+	Location      *Location `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PromptAiModelResponse) Reset() {
 	*x = PromptAiModelResponse{}
-	mi := &file_prompt_proto_msgTypes[28]
+	mi := &file_prompt_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1701,7 +4151,7 @@ func (x *PromptAiModelResponse) String() string {
 func (*PromptAiModelResponse) ProtoMessage() {}
 
 func (x *PromptAiModelResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_prompt_proto_msgTypes[28]
+	mi := &file_prompt_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1714,7 +4164,7 @@ func (x *PromptAiModelResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PromptAiModelResponse.ProtoReflect.Descriptor instead.
 func (*PromptAiModelResponse) Descriptor() ([]byte, []int) {
-	return file_prompt_proto_rawDescGZIP(), []int{28}
+	return file_prompt_proto_rawDescGZIP(), []int{67}
 }
 
 func (x *PromptAiModelResponse) GetModel() *AiModel {
@@ -1724,6 +4174,13 @@ func (x *PromptAiModelResponse) GetModel() *AiModel {
 	return nil
 }
 
+func (x *PromptAiModelResponse) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
 type PromptAiDeploymentRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Azure context with scope.subscription_id required.
@@ -1732,7 +4189,8 @@ type PromptAiDeploymentRequest struct {
 	ModelName string `protobuf:"bytes,2,opt,name=model_name,json=modelName,proto3" json:"model_name,omitempty"`
 	// Optional deployment filters (locations/versions/SKUs/capacity).
 	Options *AiModelDeploymentOptions `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
-	// Optional quota filter. Requires options.locations with exactly one location.
+	// Optional quota filter. Requires options.locations with exactly one location, or a default location from
+	// azure_context or the azd environment.
 	Quota *QuotaCheckOptions `protobuf:"bytes,4,opt,name=quota,proto3" json:"quota,omitempty"`
 	// Skip version prompt and use the default version when available.
 	UseDefaultVersion bool `protobuf:"varint,5,opt,name=use_default_version,json=useDefaultVersion,proto3" json:"use_default_version,omitempty"`
@@ -1740,13 +4198,36 @@ type PromptAiDeploymentRequest struct {
 	UseDefaultCapacity bool `protobuf:"varint,6,opt,name=use_default_capacity,json=useDefaultCapacity,proto3" json:"use_default_capacity,omitempty"`
 	// Include fine-tune SKUs (usage names ending with "-finetune").
 	IncludeFinetuneSkus bool `protobuf:"varint,7,opt,name=include_finetune_skus,json=includeFinetuneSkus,proto3" json:"include_finetune_skus,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+	// Require remaining AI Services account-count quota at the location, on the usage that tracks accounts of
+	// account_kind. Only evaluated when quota is set. Defaults to false. Leave unset when deploying into an existing
+	// account.
+	RequireAccountQuota *bool `protobuf:"varint,8,opt,name=require_account_quota,json=requireAccountQuota,proto3,oneof" json:"require_account_quota,omitempty"`
+	// Minimum remaining account-count quota when require_account_quota is true. Defaults to 1.
+	MinimumAccountQuota *float64 `protobuf:"fixed64,9,opt,name=minimum_account_quota,json=minimumAccountQuota,proto3,oneof" json:"minimum_account_quota,omitempty"`
+	// Capacity to deploy with. When set, it overrides options.capacity, only SKUs whose min/max/step constraints accept
+	// it (and, when quota is set, whose remaining quota covers it) are offered, and the capacity prompt is skipped.
+	// 0 means unset.
+	DesiredCapacity int32 `protobuf:"varint,10,opt,name=desired_capacity,json=desiredCapacity,proto3" json:"desired_capacity,omitempty"`
+	// Kind of the account to be created, e.g. "AIServices", which selects the account-count usage checked by
+	// require_account_quota (AIServices.S0.AccountCount for "AIServices"). Empty and unknown kinds check
+	// OpenAI.S0.AccountCount.
+	AccountKind string `protobuf:"bytes,11,opt,name=account_kind,json=accountKind,proto3" json:"account_kind,omitempty"`
+	// Pick the whole deployment without the format, version, SKU and capacity prompts: the default version (or the
+	// newest) in the first format, the first of options.skus (or of the configured preferred SKUs) that is valid, and
+	// that SKU's default capacity. With quota and no single location, the location with the most remaining quota among
+	// options.locations is used. The choices are shown with a single confirm; declining falls back to the prompts.
+	// In no-prompt mode, the choices are returned without confirming. Defaults to false.
+	AcceptDefaults bool `protobuf:"varint,12,opt,name=accept_defaults,json=acceptDefaults,proto3" json:"accept_defaults,omitempty"`
+	// List every version in the version prompt. By default only the default version (or the newest, when none is
+	// marked as default) is listed, with a "Show all versions" choice that lists the others. Defaults to false.
+	ShowAllVersions bool `protobuf:"varint,13,opt,name=show_all_versions,json=showAllVersions,proto3" json:"show_all_versions,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *PromptAiDeploymentRequest) Reset() {
 	*x = PromptAiDeploymentRequest{}
-	mi := &file_prompt_proto_msgTypes[29]
+	mi := &file_prompt_proto_msgTypes[68]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1758,7 +4239,7 @@ func (x *PromptAiDeploymentRequest) String() string {
 func (*PromptAiDeploymentRequest) ProtoMessage() {}
 
 func (x *PromptAiDeploymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_prompt_proto_msgTypes[29]
+	mi := &file_prompt_proto_msgTypes[68]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1771,7 +4252,7 @@ func (x *PromptAiDeploymentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PromptAiDeploymentRequest.ProtoReflect.Descriptor instead.
 func (*PromptAiDeploymentRequest) Descriptor() ([]byte, []int) {
-	return file_prompt_proto_rawDescGZIP(), []int{29}
+	return file_prompt_proto_rawDescGZIP(), []int{68}
 }
 
 func (x *PromptAiDeploymentRequest) GetAzureContext() *AzureContext {
@@ -1823,6 +4304,48 @@ func (x *PromptAiDeploymentRequest) GetIncludeFinetuneSkus() bool {
 	return false
 }
 
+func (x *PromptAiDeploymentRequest) GetRequireAccountQuota() bool {
+	if x != nil && x.RequireAccountQuota != nil {
+		return *x.RequireAccountQuota
+	}
+	return false
+}
+
+func (x *PromptAiDeploymentRequest) GetMinimumAccountQuota() float64 {
+	if x != nil && x.MinimumAccountQuota != nil {
+		return *x.MinimumAccountQuota
+	}
+	return 0
+}
+
+func (x *PromptAiDeploymentRequest) GetDesiredCapacity() int32 {
+	if x != nil {
+		return x.DesiredCapacity
+	}
+	return 0
+}
+
+func (x *PromptAiDeploymentRequest) GetAccountKind() string {
+	if x != nil {
+		return x.AccountKind
+	}
+	return ""
+}
+
+func (x *PromptAiDeploymentRequest) GetAcceptDefaults() bool {
+	if x != nil {
+		return x.AcceptDefaults
+	}
+	return false
+}
+
+func (x *PromptAiDeploymentRequest) GetShowAllVersions() bool {
+	if x != nil {
+		return x.ShowAllVersions
+	}
+	return false
+}
+
 type PromptAiDeploymentResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Selected deployment configuration.
@@ -1833,7 +4356,7 @@ type PromptAiDeploymentResponse struct {
 
 func (x *PromptAiDeploymentResponse) Reset() {
 	*x = PromptAiDeploymentResponse{}
-	mi := &file_prompt_proto_msgTypes[30]
+	mi := &file_prompt_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1845,7 +4368,7 @@ func (x *PromptAiDeploymentResponse) String() string {
 func (*PromptAiDeploymentResponse) ProtoMessage() {}
 
 func (x *PromptAiDeploymentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_prompt_proto_msgTypes[30]
+	mi := &file_prompt_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1858,7 +4381,7 @@ func (x *PromptAiDeploymentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PromptAiDeploymentResponse.ProtoReflect.Descriptor instead.
 func (*PromptAiDeploymentResponse) Descriptor() ([]byte, []int) {
-	return file_prompt_proto_rawDescGZIP(), []int{30}
+	return file_prompt_proto_rawDescGZIP(), []int{69}
 }
 
 func (x *PromptAiDeploymentResponse) GetDeployment() *AiModelDeployment {
@@ -1868,6 +4391,168 @@ func (x *PromptAiDeploymentResponse) GetDeployment() *AiModelDeployment {
 	return nil
 }
 
+type PromptAiSkuRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Azure context with scope.subscription_id. Only required when quota is set.
+	AzureContext *AzureContext `protobuf:"bytes,1,opt,name=azure_context,json=azureContext,proto3" json:"azure_context,omitempty"`
+	// Required model name, used in prompt messages and errors.
+	ModelName string `protobuf:"bytes,2,opt,name=model_name,json=modelName,proto3" json:"model_name,omitempty"`
+	// Optional model version, used in prompt messages.
+	Version string `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	// Required SKUs to choose from, typically AiModelVersion.skus of the selected version.
+	Skus []*AiModelSku `protobuf:"bytes,4,rep,name=skus,proto3" json:"skus,omitempty"`
+	// Optional SKU name to pre-select, and to use in no-prompt mode.
+	PreferredSku string `protobuf:"bytes,5,opt,name=preferred_sku,json=preferredSku,proto3" json:"preferred_sku,omitempty"`
+	// Optional quota filter. SKUs without enough remaining quota at location are not offered.
+	Quota *QuotaCheckOptions `protobuf:"bytes,6,opt,name=quota,proto3" json:"quota,omitempty"`
+	// Location to evaluate quota at. Defaults to the location of azure_context, or else of the active azd environment.
+	Location      string `protobuf:"bytes,7,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptAiSkuRequest) Reset() {
+	*x = PromptAiSkuRequest{}
+	mi := &file_prompt_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptAiSkuRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptAiSkuRequest) ProtoMessage() {}
+
+func (x *PromptAiSkuRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptAiSkuRequest.ProtoReflect.Descriptor instead.
+func (*PromptAiSkuRequest) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *PromptAiSkuRequest) GetAzureContext() *AzureContext {
+	if x != nil {
+		return x.AzureContext
+	}
+	return nil
+}
+
+func (x *PromptAiSkuRequest) GetModelName() string {
+	if x != nil {
+		return x.ModelName
+	}
+	return ""
+}
+
+func (x *PromptAiSkuRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *PromptAiSkuRequest) GetSkus() []*AiModelSku {
+	if x != nil {
+		return x.Skus
+	}
+	return nil
+}
+
+func (x *PromptAiSkuRequest) GetPreferredSku() string {
+	if x != nil {
+		return x.PreferredSku
+	}
+	return ""
+}
+
+func (x *PromptAiSkuRequest) GetQuota() *QuotaCheckOptions {
+	if x != nil {
+		return x.Quota
+	}
+	return nil
+}
+
+func (x *PromptAiSkuRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+type PromptAiSkuResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Selected SKU.
+	Sku *AiModelSku `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	// Selected capacity, within the SKU's capacity range.
+	Capacity int32 `protobuf:"varint,2,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	// Remaining quota for the SKU at location. Populated when quota is set.
+	RemainingQuota *float64 `protobuf:"fixed64,3,opt,name=remaining_quota,json=remainingQuota,proto3,oneof" json:"remaining_quota,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PromptAiSkuResponse) Reset() {
+	*x = PromptAiSkuResponse{}
+	mi := &file_prompt_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptAiSkuResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptAiSkuResponse) ProtoMessage() {}
+
+func (x *PromptAiSkuResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prompt_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptAiSkuResponse.ProtoReflect.Descriptor instead.
+func (*PromptAiSkuResponse) Descriptor() ([]byte, []int) {
+	return file_prompt_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *PromptAiSkuResponse) GetSku() *AiModelSku {
+	if x != nil {
+		return x.Sku
+	}
+	return nil
+}
+
+func (x *PromptAiSkuResponse) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *PromptAiSkuResponse) GetRemainingQuota() float64 {
+	if x != nil && x.RemainingQuota != nil {
+		return *x.RemainingQuota
+	}
+	return 0
+}
+
 type PromptAiLocationWithQuotaRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Azure context with scope.subscription_id required.
@@ -1879,14 +4564,17 @@ type PromptAiLocationWithQuotaRequest struct {
 	// Optional select prompt customization (for example, message override).
 	SelectOptions *SelectOptions `protobuf:"bytes,4,opt,name=select_options,json=selectOptions,proto3" json:"select_options,omitempty"`
 	// Optional default location name to pre-select in the list.
-	DefaultValue  string `protobuf:"bytes,5,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"`
+	DefaultValue string `protobuf:"bytes,5,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"`
+	// Optional location closest to the users, e.g. "westeurope". When it is among the choices, it is listed first,
+	// followed by the other locations in its geography (e.g. Europe). Otherwise locations are listed alphabetically.
+	HomeLocation  string `protobuf:"bytes,6,opt,name=home_location,json=homeLocation,proto3" json:"home_location,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PromptAiLocationWithQuotaRequest) Reset() {
 	*x = PromptAiLocationWithQuotaRequest{}
-	mi := &file_prompt_proto_msgTypes[31]
+	mi := &file_prompt_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1898,7 +4586,7 @@ func (x *PromptAiLocationWithQuotaRequest) String() string {
 func (*PromptAiLocationWithQuotaRequest) ProtoMessage() {}
 
 func (x *PromptAiLocationWithQuotaRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_prompt_proto_msgTypes[31]
+	mi := &file_prompt_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1911,7 +4599,7 @@ func (x *PromptAiLocationWithQuotaRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PromptAiLocationWithQuotaRequest.ProtoReflect.Descriptor instead.
 func (*PromptAiLocationWithQuotaRequest) Descriptor() ([]byte, []int) {
-	return file_prompt_proto_rawDescGZIP(), []int{31}
+	return file_prompt_proto_rawDescGZIP(), []int{72}
 }
 
 func (x *PromptAiLocationWithQuotaRequest) GetAzureContext() *AzureContext {
@@ -1949,6 +4637,13 @@ func (x *PromptAiLocationWithQuotaRequest) GetDefaultValue() string {
 	return ""
 }
 
+func (x *PromptAiLocationWithQuotaRequest) GetHomeLocation() string {
+	if x != nil {
+		return x.HomeLocation
+	}
+	return ""
+}
+
 type PromptAiLocationWithQuotaResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Selected location.
@@ -1959,7 +4654,7 @@ type PromptAiLocationWithQuotaResponse struct {
 
 func (x *PromptAiLocationWithQuotaResponse) Reset() {
 	*x = PromptAiLocationWithQuotaResponse{}
-	mi := &file_prompt_proto_msgTypes[32]
+	mi := &file_prompt_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1971,7 +4666,7 @@ func (x *PromptAiLocationWithQuotaResponse) String() string {
 func (*PromptAiLocationWithQuotaResponse) ProtoMessage() {}
 
 func (x *PromptAiLocationWithQuotaResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_prompt_proto_msgTypes[32]
+	mi := &file_prompt_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1984,7 +4679,7 @@ func (x *PromptAiLocationWithQuotaResponse) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use PromptAiLocationWithQuotaResponse.ProtoReflect.Descriptor instead.
 func (*PromptAiLocationWithQuotaResponse) Descriptor() ([]byte, []int) {
-	return file_prompt_proto_rawDescGZIP(), []int{32}
+	return file_prompt_proto_rawDescGZIP(), []int{73}
 }
 
 func (x *PromptAiLocationWithQuotaResponse) GetLocation() *Location {
@@ -2007,14 +4702,17 @@ type PromptAiModelLocationWithQuotaRequest struct {
 	// Optional select prompt customization (for example, message override).
 	SelectOptions *SelectOptions `protobuf:"bytes,5,opt,name=select_options,json=selectOptions,proto3" json:"select_options,omitempty"`
 	// Optional default location name to pre-select in the list.
-	DefaultValue  string `protobuf:"bytes,6,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"`
+	DefaultValue string `protobuf:"bytes,6,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"`
+	// Optional capabilities, e.g. ["chatCompletion"]. Only locations offering a version of the model with at least
+	// one of them are listed, with quota counted for those versions.
+	Capabilities  []string `protobuf:"bytes,7,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PromptAiModelLocationWithQuotaRequest) Reset() {
 	*x = PromptAiModelLocationWithQuotaRequest{}
-	mi := &file_prompt_proto_msgTypes[33]
+	mi := &file_prompt_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2026,7 +4724,7 @@ func (x *PromptAiModelLocationWithQuotaRequest) String() string {
 func (*PromptAiModelLocationWithQuotaRequest) ProtoMessage() {}
 
 func (x *PromptAiModelLocationWithQuotaRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_prompt_proto_msgTypes[33]
+	mi := &file_prompt_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2039,7 +4737,7 @@ func (x *PromptAiModelLocationWithQuotaRequest) ProtoReflect() protoreflect.Mess
 
 // Deprecated: Use PromptAiModelLocationWithQuotaRequest.ProtoReflect.Descriptor instead.
 func (*PromptAiModelLocationWithQuotaRequest) Descriptor() ([]byte, []int) {
-	return file_prompt_proto_rawDescGZIP(), []int{33}
+	return file_prompt_proto_rawDescGZIP(), []int{74}
 }
 
 func (x *PromptAiModelLocationWithQuotaRequest) GetAzureContext() *AzureContext {
@@ -2084,6 +4782,13 @@ func (x *PromptAiModelLocationWithQuotaRequest) GetDefaultValue() string {
 	return ""
 }
 
+func (x *PromptAiModelLocationWithQuotaRequest) GetCapabilities() []string {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
 type PromptAiModelLocationWithQuotaResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Selected location.
@@ -2098,7 +4803,7 @@ type PromptAiModelLocationWithQuotaResponse struct {
 
 func (x *PromptAiModelLocationWithQuotaResponse) Reset() {
 	*x = PromptAiModelLocationWithQuotaResponse{}
-	mi := &file_prompt_proto_msgTypes[34]
+	mi := &file_prompt_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2110,7 +4815,7 @@ func (x *PromptAiModelLocationWithQuotaResponse) String() string {
 func (*PromptAiModelLocationWithQuotaResponse) ProtoMessage() {}
 
 func (x *PromptAiModelLocationWithQuotaResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_prompt_proto_msgTypes[34]
+	mi := &file_prompt_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2123,7 +4828,7 @@ func (x *PromptAiModelLocationWithQuotaResponse) ProtoReflect() protoreflect.Mes
 
 // Deprecated: Use PromptAiModelLocationWithQuotaResponse.ProtoReflect.Descriptor instead.
 func (*PromptAiModelLocationWithQuotaResponse) Descriptor() ([]byte, []int) {
-	return file_prompt_proto_rawDescGZIP(), []int{34}
+	return file_prompt_proto_rawDescGZIP(), []int{75}
 }
 
 func (x *PromptAiModelLocationWithQuotaResponse) GetLocation() *Location {
@@ -2159,12 +4864,35 @@ const file_prompt_proto_rawDesc = "" +
 	"\razure_context\x18\x01 \x01(\v2\x14.azdext.AzureContextR\fazureContext\x12<\n" +
 	"\aoptions\x18\x02 \x01(\v2\".azdext.PromptResourceGroupOptionsR\aoptions\"[\n" +
 	"\x1bPromptResourceGroupResponse\x12<\n" +
-	"\x0eresource_group\x18\x01 \x01(\v2\x15.azdext.ResourceGroupR\rresourceGroup\"B\n" +
+	"\x0eresource_group\x18\x01 \x01(\v2\x15.azdext.ResourceGroupR\rresourceGroup\"\x8a\x01\n" +
+	"\x17PromptAzureScopeRequest\x129\n" +
+	"\razure_context\x18\x01 \x01(\v2\x14.azdext.AzureContextR\fazureContext\x124\n" +
+	"\x16include_resource_group\x18\x02 \x01(\bR\x14includeResourceGroup\"U\n" +
+	"\x18PromptAzureScopeResponse\x129\n" +
+	"\razure_context\x18\x01 \x01(\v2\x14.azdext.AzureContextR\fazureContext\"\x18\n" +
+	"\x16GetAzureContextRequest\"T\n" +
+	"\x17GetAzureContextResponse\x129\n" +
+	"\razure_context\x18\x01 \x01(\v2\x14.azdext.AzureContextR\fazureContext\"B\n" +
 	"\x0eConfirmRequest\x120\n" +
 	"\aoptions\x18\x01 \x01(\v2\x16.azdext.ConfirmOptionsR\aoptions\"6\n" +
 	"\x0fConfirmResponse\x12\x19\n" +
 	"\x05value\x18\x01 \x01(\bH\x00R\x05value\x88\x01\x01B\b\n" +
-	"\x06_value\"@\n" +
+	"\x06_value\"\x8d\x01\n" +
+	"\x1bPromptSummaryConfirmRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12&\n" +
+	"\x04rows\x18\x02 \x03(\v2\x12.azdext.SummaryRowR\x04rows\x120\n" +
+	"\aoptions\x18\x03 \x01(\v2\x16.azdext.ConfirmOptionsR\aoptions\"4\n" +
+	"\n" +
+	"SummaryRow\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"C\n" +
+	"\x1cPromptSummaryConfirmResponse\x12\x19\n" +
+	"\x05value\x18\x01 \x01(\bH\x00R\x05value\x88\x01\x01B\b\n" +
+	"\x06_value\"d\n" +
+	"\x1fPromptDestructiveConfirmRequest\x12A\n" +
+	"\aoptions\x18\x01 \x01(\v2'.azdext.PromptDestructiveConfirmOptionsR\aoptions\"@\n" +
+	" PromptDestructiveConfirmResponse\x12\x1c\n" +
+	"\tconfirmed\x18\x01 \x01(\bR\tconfirmed\"@\n" +
 	"\rPromptRequest\x12/\n" +
 	"\aoptions\x18\x01 \x01(\v2\x15.azdext.PromptOptionsR\aoptions\"&\n" +
 	"\x0ePromptResponse\x12\x14\n" +
@@ -2177,7 +4905,68 @@ const file_prompt_proto_rawDesc = "" +
 	"\x12MultiSelectRequest\x124\n" +
 	"\aoptions\x18\x01 \x01(\v2\x1a.azdext.MultiSelectOptionsR\aoptions\"H\n" +
 	"\x13MultiSelectResponse\x121\n" +
-	"\x06values\x18\x01 \x03(\v2\x19.azdext.MultiSelectChoiceR\x06values\"\x97\x01\n" +
+	"\x06values\x18\x01 \x03(\v2\x19.azdext.MultiSelectChoiceR\x06values\"H\n" +
+	"\x11PromptTreeRequest\x123\n" +
+	"\aoptions\x18\x01 \x01(\v2\x19.azdext.PromptTreeOptionsR\aoptions\"(\n" +
+	"\x12PromptTreeResponse\x12\x12\n" +
+	"\x04path\x18\x01 \x03(\tR\x04path\"H\n" +
+	"\x11PromptPathRequest\x123\n" +
+	"\aoptions\x18\x01 \x01(\v2\x19.azdext.PromptPathOptionsR\aoptions\"(\n" +
+	"\x12PromptPathResponse\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\"L\n" +
+	"\x13PromptEditorRequest\x125\n" +
+	"\aoptions\x18\x01 \x01(\v2\x1b.azdext.PromptEditorOptionsR\aoptions\",\n" +
+	"\x14PromptEditorResponse\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\"P\n" +
+	"\x15PromptDurationRequest\x127\n" +
+	"\aoptions\x18\x01 \x01(\v2\x1d.azdext.PromptDurationOptionsR\aoptions\"P\n" +
+	"\x16PromptDurationResponse\x12 \n" +
+	"\vnanoseconds\x18\x01 \x01(\x03R\vnanoseconds\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"R\n" +
+	"\x16PromptKeyValuesRequest\x128\n" +
+	"\aoptions\x18\x01 \x01(\v2\x1e.azdext.PromptKeyValuesOptionsR\aoptions\"\x99\x01\n" +
+	"\x17PromptKeyValuesResponse\x12C\n" +
+	"\x06values\x18\x01 \x03(\v2+.azdext.PromptKeyValuesResponse.ValuesEntryR\x06values\x1a9\n" +
+	"\vValuesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"Z\n" +
+	"\x1aPromptOrderedSelectRequest\x12<\n" +
+	"\aoptions\x18\x01 \x01(\v2\".azdext.PromptOrderedSelectOptionsR\aoptions\"K\n" +
+	"\x1bPromptOrderedSelectResponse\x12,\n" +
+	"\x06values\x18\x01 \x03(\v2\x14.azdext.SelectChoiceR\x06values\"\xa9\x01\n" +
+	"\x1dPromptSearchableClientMessage\x12;\n" +
+	"\aoptions\x18\x01 \x01(\v2\x1f.azdext.PromptSearchableOptionsH\x00R\aoptions\x12;\n" +
+	"\aresults\x18\x02 \x01(\v2\x1f.azdext.PromptSearchableResultsH\x00R\aresultsB\x0e\n" +
+	"\fmessage_type\"\xa6\x01\n" +
+	"\x1dPromptSearchableServerMessage\x125\n" +
+	"\x05query\x18\x01 \x01(\v2\x1d.azdext.PromptSearchableQueryH\x00R\x05query\x12>\n" +
+	"\bresponse\x18\x02 \x01(\v2 .azdext.PromptSearchableResponseH\x00R\bresponseB\x0e\n" +
+	"\fmessage_type\"?\n" +
+	"\x15PromptSearchableQuery\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x16\n" +
+	"\x06filter\x18\x02 \x01(\tR\x06filter\"d\n" +
+	"\x17PromptSearchableResults\x12\x19\n" +
+	"\bquery_id\x18\x01 \x01(\x05R\aqueryId\x12.\n" +
+	"\achoices\x18\x02 \x03(\v2\x14.azdext.SelectChoiceR\achoices\"F\n" +
+	"\x18PromptSearchableResponse\x12*\n" +
+	"\x05value\x18\x01 \x01(\v2\x14.azdext.SelectChoiceR\x05value\"\x9b\x01\n" +
+	"\x1cPromptValidatedClientMessage\x121\n" +
+	"\aoptions\x18\x01 \x01(\v2\x15.azdext.PromptOptionsH\x00R\aoptions\x128\n" +
+	"\x06result\x18\x02 \x01(\v2\x1e.azdext.PromptValidationResultH\x00R\x06resultB\x0e\n" +
+	"\fmessage_type\"\xa0\x01\n" +
+	"\x1cPromptValidatedServerMessage\x12:\n" +
+	"\n" +
+	"validation\x18\x01 \x01(\v2\x18.azdext.PromptValidationH\x00R\n" +
+	"validation\x124\n" +
+	"\bresponse\x18\x02 \x01(\v2\x16.azdext.PromptResponseH\x00R\bresponseB\x0e\n" +
+	"\fmessage_type\"8\n" +
+	"\x10PromptValidation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"m\n" +
+	"\x16PromptValidationResult\x12#\n" +
+	"\rvalidation_id\x18\x01 \x01(\x05R\fvalidationId\x12\x14\n" +
+	"\x05valid\x18\x02 \x01(\bR\x05valid\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\x97\x01\n" +
 	"!PromptSubscriptionResourceRequest\x129\n" +
 	"\razure_context\x18\x01 \x01(\v2\x14.azdext.AzureContextR\fazureContext\x127\n" +
 	"\aoptions\x18\x02 \x01(\v2\x1d.azdext.PromptResourceOptionsR\aoptions\"Z\n" +
@@ -2194,7 +4983,13 @@ const file_prompt_proto_rawDesc = "" +
 	"\fhelp_message\x18\x03 \x01(\tR\vhelpMessage\x12\x12\n" +
 	"\x04hint\x18\x04 \x01(\tR\x04hint\x12 \n" +
 	"\vplaceholder\x18\x05 \x01(\tR\vplaceholderB\x10\n" +
-	"\x0e_default_value\"\x8f\x03\n" +
+	"\x0e_default_value\"\xc8\x01\n" +
+	"\x1fPromptDestructiveConfirmOptions\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12!\n" +
+	"\fhelp_message\x18\x02 \x01(\tR\vhelpMessage\x12/\n" +
+	"\x13confirmation_phrase\x18\x03 \x01(\tR\x12confirmationPhrase\x12!\n" +
+	"\fmax_attempts\x18\x04 \x01(\x05R\vmaxAttempts\x12\x14\n" +
+	"\x05force\x18\x05 \x01(\bR\x05force\"\x8f\x03\n" +
 	"\rPromptOptions\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12!\n" +
 	"\fhelp_message\x18\x02 \x01(\tR\vhelpMessage\x12\x12\n" +
@@ -2207,14 +5002,21 @@ const file_prompt_proto_rawDesc = "" +
 	"\x13clear_on_completion\x18\t \x01(\bR\x11clearOnCompletion\x12(\n" +
 	"\x10ignore_hint_keys\x18\n" +
 	" \x01(\bR\x0eignoreHintKeys\x12\x16\n" +
-	"\x06secret\x18\v \x01(\bR\x06secret\":\n" +
+	"\x06secret\x18\v \x01(\bR\x06secret\"\\\n" +
 	"\fSelectChoice\x12\x14\n" +
 	"\x05value\x18\x01 \x01(\tR\x05value\x12\x14\n" +
-	"\x05label\x18\x02 \x01(\tR\x05label\"[\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\"[\n" +
 	"\x11MultiSelectChoice\x12\x14\n" +
 	"\x05value\x18\x01 \x01(\tR\x05value\x12\x14\n" +
 	"\x05label\x18\x02 \x01(\tR\x05label\x12\x1a\n" +
-	"\bselected\x18\x03 \x01(\bR\bselected\"\xfb\x02\n" +
+	"\bselected\x18\x03 \x01(\bR\bselected\"\x8a\x01\n" +
+	"\n" +
+	"TreeChoice\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12.\n" +
+	"\bchildren\x18\x04 \x03(\v2\x12.azdext.TreeChoiceR\bchildren\"\xfe\x03\n" +
 	"\rSelectOptions\x12*\n" +
 	"\x0eselected_index\x18\x01 \x01(\x05H\x00R\rselectedIndex\x88\x01\x01\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12.\n" +
@@ -2223,7 +5025,12 @@ const file_prompt_proto_rawDesc = "" +
 	"\x04hint\x18\x05 \x01(\tR\x04hint\x12#\n" +
 	"\rdisplay_count\x18\x06 \x01(\x05R\fdisplayCount\x12,\n" +
 	"\x0fdisplay_numbers\x18\a \x01(\bH\x01R\x0edisplayNumbers\x88\x01\x01\x12.\n" +
-	"\x10enable_filtering\x18\b \x01(\bH\x02R\x0fenableFiltering\x88\x01\x01B\x11\n" +
+	"\x10enable_filtering\x18\b \x01(\bH\x02R\x0fenableFiltering\x88\x01\x01\x120\n" +
+	"\x14announce_auto_select\x18\t \x01(\bR\x12announceAutoSelect\x12\x1f\n" +
+	"\vpersist_key\x18\n" +
+	" \x01(\tR\n" +
+	"persistKey\x12.\n" +
+	"\x13persist_per_project\x18\v \x01(\bR\x11persistPerProjectB\x11\n" +
 	"\x0f_selected_indexB\x12\n" +
 	"\x10_display_numbersB\x13\n" +
 	"\x11_enable_filtering\"\xc6\x02\n" +
@@ -2236,6 +5043,72 @@ const file_prompt_proto_rawDesc = "" +
 	"\x0fdisplay_numbers\x18\x06 \x01(\bH\x00R\x0edisplayNumbers\x88\x01\x01\x12.\n" +
 	"\x10enable_filtering\x18\a \x01(\bH\x01R\x0fenableFiltering\x88\x01\x01B\x12\n" +
 	"\x10_display_numbersB\x13\n" +
+	"\x11_enable_filtering\"\xcf\x02\n" +
+	"\x11PromptTreeOptions\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12,\n" +
+	"\achoices\x18\x02 \x03(\v2\x12.azdext.TreeChoiceR\achoices\x12#\n" +
+	"\rselected_path\x18\x03 \x03(\tR\fselectedPath\x12!\n" +
+	"\fhelp_message\x18\x04 \x01(\tR\vhelpMessage\x12#\n" +
+	"\rdisplay_count\x18\x05 \x01(\x05R\fdisplayCount\x12,\n" +
+	"\x0fdisplay_numbers\x18\x06 \x01(\bH\x00R\x0edisplayNumbers\x88\x01\x01\x12.\n" +
+	"\x10enable_filtering\x18\a \x01(\bH\x01R\x0fenableFiltering\x88\x01\x01B\x12\n" +
+	"\x10_display_numbersB\x13\n" +
+	"\x11_enable_filtering\"\xa4\x02\n" +
+	"\x11PromptPathOptions\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12!\n" +
+	"\fhelp_message\x18\x02 \x01(\tR\vhelpMessage\x12 \n" +
+	"\vplaceholder\x18\x03 \x01(\tR\vplaceholder\x12#\n" +
+	"\rdefault_value\x18\x04 \x01(\tR\fdefaultValue\x12\x1d\n" +
+	"\n" +
+	"must_exist\x18\x05 \x01(\bR\tmustExist\x12\x1e\n" +
+	"\vmust_be_dir\x18\x06 \x01(\bR\tmustBeDir\x12 \n" +
+	"\fmust_be_file\x18\a \x01(\bR\n" +
+	"mustBeFile\x12*\n" +
+	"\x11create_if_missing\x18\b \x01(\bR\x0fcreateIfMissing\"\x9e\x01\n" +
+	"\x13PromptEditorOptions\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12!\n" +
+	"\fhelp_message\x18\x02 \x01(\tR\vhelpMessage\x12#\n" +
+	"\rdefault_value\x18\x03 \x01(\tR\fdefaultValue\x12%\n" +
+	"\x0efile_extension\x18\x04 \x01(\tR\rfileExtension\"\xb3\x01\n" +
+	"\x15PromptDurationOptions\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12!\n" +
+	"\fhelp_message\x18\x02 \x01(\tR\vhelpMessage\x12#\n" +
+	"\rdefault_value\x18\x03 \x01(\tR\fdefaultValue\x12\x1b\n" +
+	"\tmin_value\x18\x04 \x01(\tR\bminValue\x12\x1b\n" +
+	"\tmax_value\x18\x05 \x01(\tR\bmaxValue\"\x83\x02\n" +
+	"\x17PromptSearchableOptions\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12!\n" +
+	"\fhelp_message\x18\x02 \x01(\tR\vhelpMessage\x12\x12\n" +
+	"\x04hint\x18\x03 \x01(\tR\x04hint\x12#\n" +
+	"\rdisplay_count\x18\x04 \x01(\x05R\fdisplayCount\x12,\n" +
+	"\x0fdisplay_numbers\x18\x05 \x01(\bH\x00R\x0edisplayNumbers\x88\x01\x01\x120\n" +
+	"\x14announce_auto_select\x18\x06 \x01(\bR\x12announceAutoSelectB\x12\n" +
+	"\x10_display_numbers\"\xe7\x03\n" +
+	"\x16PromptKeyValuesOptions\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12!\n" +
+	"\fhelp_message\x18\x02 \x01(\tR\vhelpMessage\x12\x1f\n" +
+	"\vkey_message\x18\x03 \x01(\tR\n" +
+	"keyMessage\x12#\n" +
+	"\rvalue_message\x18\x04 \x01(\tR\fvalueMessage\x12X\n" +
+	"\x0edefault_values\x18\x05 \x03(\v21.azdext.PromptKeyValuesOptions.DefaultValuesEntryR\rdefaultValues\x12\x1f\n" +
+	"\vmin_entries\x18\x06 \x01(\x05R\n" +
+	"minEntries\x126\n" +
+	"\x17disallow_duplicate_keys\x18\a \x01(\bR\x15disallowDuplicateKeys\x12\x1f\n" +
+	"\vkey_pattern\x18\b \x01(\tR\n" +
+	"keyPattern\x124\n" +
+	"\x16key_validation_message\x18\t \x01(\tR\x14keyValidationMessage\x1a@\n" +
+	"\x12DefaultValuesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xe8\x02\n" +
+	"\x1aPromptOrderedSelectOptions\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12.\n" +
+	"\achoices\x18\x02 \x03(\v2\x14.azdext.SelectChoiceR\achoices\x12!\n" +
+	"\fhelp_message\x18\x03 \x01(\tR\vhelpMessage\x12%\n" +
+	"\x0edefault_values\x18\x04 \x03(\tR\rdefaultValues\x12%\n" +
+	"\x0emin_selections\x18\x05 \x01(\x05R\rminSelections\x12%\n" +
+	"\x0emax_selections\x18\x06 \x01(\x05R\rmaxSelections\x12#\n" +
+	"\rdisplay_count\x18\a \x01(\x05R\fdisplayCount\x12.\n" +
+	"\x10enable_filtering\x18\b \x01(\bH\x00R\x0fenableFiltering\x88\x01\x01B\x13\n" +
 	"\x11_enable_filtering\"\xdb\x01\n" +
 	"\x15PromptResourceOptions\x12#\n" +
 	"\rresource_type\x18\x01 \x01(\tR\fresourceType\x12\x14\n" +
@@ -2260,15 +5133,19 @@ const file_prompt_proto_rawDesc = "" +
 	"\x10_display_numbersB\x13\n" +
 	"\x11_enable_filtering\"h\n" +
 	"\x1aPromptResourceGroupOptions\x12J\n" +
-	"\x0eselect_options\x18\x01 \x01(\v2#.azdext.PromptResourceSelectOptionsR\rselectOptions\"\x9b\x02\n" +
+	"\x0eselect_options\x18\x01 \x01(\v2#.azdext.PromptResourceSelectOptionsR\rselectOptions\"\xa8\x03\n" +
 	"\x14PromptAiModelRequest\x129\n" +
 	"\razure_context\x18\x01 \x01(\v2\x14.azdext.AzureContextR\fazureContext\x124\n" +
 	"\x06filter\x18\x02 \x01(\v2\x1c.azdext.AiModelFilterOptionsR\x06filter\x12<\n" +
 	"\x0eselect_options\x18\x03 \x01(\v2\x15.azdext.SelectOptionsR\rselectOptions\x12/\n" +
 	"\x05quota\x18\x04 \x01(\v2\x19.azdext.QuotaCheckOptionsR\x05quota\x12#\n" +
-	"\rdefault_value\x18\x05 \x01(\tR\fdefaultValue\">\n" +
+	"\rdefault_value\x18\x05 \x01(\tR\fdefaultValue\x12&\n" +
+	"\x0fgroup_by_family\x18\x06 \x01(\bR\rgroupByFamily\x12-\n" +
+	"\x12include_deprecated\x18\a \x01(\bR\x11includeDeprecated\x124\n" +
+	"\x16search_other_locations\x18\b \x01(\bR\x14searchOtherLocations\"l\n" +
 	"\x15PromptAiModelResponse\x12%\n" +
-	"\x05model\x18\x01 \x01(\v2\x0f.azdext.AiModelR\x05model\"\xf8\x02\n" +
+	"\x05model\x18\x01 \x01(\v2\x0f.azdext.AiModelR\x05model\x12,\n" +
+	"\blocation\x18\x02 \x01(\v2\x10.azdext.LocationR\blocation\"\xc1\x05\n" +
 	"\x19PromptAiDeploymentRequest\x129\n" +
 	"\razure_context\x18\x01 \x01(\v2\x14.azdext.AzureContextR\fazureContext\x12\x1d\n" +
 	"\n" +
@@ -2277,19 +5154,43 @@ const file_prompt_proto_rawDesc = "" +
 	"\x05quota\x18\x04 \x01(\v2\x19.azdext.QuotaCheckOptionsR\x05quota\x12.\n" +
 	"\x13use_default_version\x18\x05 \x01(\bR\x11useDefaultVersion\x120\n" +
 	"\x14use_default_capacity\x18\x06 \x01(\bR\x12useDefaultCapacity\x122\n" +
-	"\x15include_finetune_skus\x18\a \x01(\bR\x13includeFinetuneSkus\"W\n" +
+	"\x15include_finetune_skus\x18\a \x01(\bR\x13includeFinetuneSkus\x127\n" +
+	"\x15require_account_quota\x18\b \x01(\bH\x00R\x13requireAccountQuota\x88\x01\x01\x127\n" +
+	"\x15minimum_account_quota\x18\t \x01(\x01H\x01R\x13minimumAccountQuota\x88\x01\x01\x12)\n" +
+	"\x10desired_capacity\x18\n" +
+	" \x01(\x05R\x0fdesiredCapacity\x12!\n" +
+	"\faccount_kind\x18\v \x01(\tR\vaccountKind\x12'\n" +
+	"\x0faccept_defaults\x18\f \x01(\bR\x0eacceptDefaults\x12*\n" +
+	"\x11show_all_versions\x18\r \x01(\bR\x0fshowAllVersionsB\x18\n" +
+	"\x16_require_account_quotaB\x18\n" +
+	"\x16_minimum_account_quota\"W\n" +
 	"\x1aPromptAiDeploymentResponse\x129\n" +
 	"\n" +
 	"deployment\x18\x01 \x01(\v2\x19.azdext.AiModelDeploymentR\n" +
-	"deployment\"\xab\x02\n" +
+	"deployment\"\xa2\x02\n" +
+	"\x12PromptAiSkuRequest\x129\n" +
+	"\razure_context\x18\x01 \x01(\v2\x14.azdext.AzureContextR\fazureContext\x12\x1d\n" +
+	"\n" +
+	"model_name\x18\x02 \x01(\tR\tmodelName\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12&\n" +
+	"\x04skus\x18\x04 \x03(\v2\x12.azdext.AiModelSkuR\x04skus\x12#\n" +
+	"\rpreferred_sku\x18\x05 \x01(\tR\fpreferredSku\x12/\n" +
+	"\x05quota\x18\x06 \x01(\v2\x19.azdext.QuotaCheckOptionsR\x05quota\x12\x1a\n" +
+	"\blocation\x18\a \x01(\tR\blocation\"\x99\x01\n" +
+	"\x13PromptAiSkuResponse\x12$\n" +
+	"\x03sku\x18\x01 \x01(\v2\x12.azdext.AiModelSkuR\x03sku\x12\x1a\n" +
+	"\bcapacity\x18\x02 \x01(\x05R\bcapacity\x12,\n" +
+	"\x0fremaining_quota\x18\x03 \x01(\x01H\x00R\x0eremainingQuota\x88\x01\x01B\x12\n" +
+	"\x10_remaining_quota\"\xd0\x02\n" +
 	" PromptAiLocationWithQuotaRequest\x129\n" +
 	"\razure_context\x18\x01 \x01(\v2\x14.azdext.AzureContextR\fazureContext\x12<\n" +
 	"\frequirements\x18\x02 \x03(\v2\x18.azdext.QuotaRequirementR\frequirements\x12+\n" +
 	"\x11allowed_locations\x18\x03 \x03(\tR\x10allowedLocations\x12<\n" +
 	"\x0eselect_options\x18\x04 \x01(\v2\x15.azdext.SelectOptionsR\rselectOptions\x12#\n" +
-	"\rdefault_value\x18\x05 \x01(\tR\fdefaultValue\"Q\n" +
+	"\rdefault_value\x18\x05 \x01(\tR\fdefaultValue\x12#\n" +
+	"\rhome_location\x18\x06 \x01(\tR\fhomeLocation\"Q\n" +
 	"!PromptAiLocationWithQuotaResponse\x12,\n" +
-	"\blocation\x18\x01 \x01(\v2\x10.azdext.LocationR\blocation\"\xc2\x02\n" +
+	"\blocation\x18\x01 \x01(\v2\x10.azdext.LocationR\blocation\"\xe6\x02\n" +
 	"%PromptAiModelLocationWithQuotaRequest\x129\n" +
 	"\razure_context\x18\x01 \x01(\v2\x14.azdext.AzureContextR\fazureContext\x12\x1d\n" +
 	"\n" +
@@ -2297,22 +5198,38 @@ const file_prompt_proto_rawDesc = "" +
 	"\x11allowed_locations\x18\x03 \x03(\tR\x10allowedLocations\x12/\n" +
 	"\x05quota\x18\x04 \x01(\v2\x19.azdext.QuotaCheckOptionsR\x05quota\x12<\n" +
 	"\x0eselect_options\x18\x05 \x01(\v2\x15.azdext.SelectOptionsR\rselectOptions\x12#\n" +
-	"\rdefault_value\x18\x06 \x01(\tR\fdefaultValue\"\x86\x01\n" +
+	"\rdefault_value\x18\x06 \x01(\tR\fdefaultValue\x12\"\n" +
+	"\fcapabilities\x18\a \x03(\tR\fcapabilities\"\x86\x01\n" +
 	"&PromptAiModelLocationWithQuotaResponse\x12,\n" +
 	"\blocation\x18\x01 \x01(\v2\x10.azdext.LocationR\blocation\x12.\n" +
-	"\x13max_remaining_quota\x18\x02 \x01(\x01R\x11maxRemainingQuota2\x9e\t\n" +
+	"\x13max_remaining_quota\x18\x02 \x01(\x01R\x11maxRemainingQuota2\x86\x12\n" +
 	"\rPromptService\x12[\n" +
 	"\x12PromptSubscription\x12!.azdext.PromptSubscriptionRequest\x1a\".azdext.PromptSubscriptionResponse\x12O\n" +
 	"\x0ePromptLocation\x12\x1d.azdext.PromptLocationRequest\x1a\x1e.azdext.PromptLocationResponse\x12^\n" +
-	"\x13PromptResourceGroup\x12\".azdext.PromptResourceGroupRequest\x1a#.azdext.PromptResourceGroupResponse\x12:\n" +
-	"\aConfirm\x12\x16.azdext.ConfirmRequest\x1a\x17.azdext.ConfirmResponse\x127\n" +
+	"\x13PromptResourceGroup\x12\".azdext.PromptResourceGroupRequest\x1a#.azdext.PromptResourceGroupResponse\x12U\n" +
+	"\x10PromptAzureScope\x12\x1f.azdext.PromptAzureScopeRequest\x1a .azdext.PromptAzureScopeResponse\x12R\n" +
+	"\x0fGetAzureContext\x12\x1e.azdext.GetAzureContextRequest\x1a\x1f.azdext.GetAzureContextResponse\x12:\n" +
+	"\aConfirm\x12\x16.azdext.ConfirmRequest\x1a\x17.azdext.ConfirmResponse\x12a\n" +
+	"\x14PromptSummaryConfirm\x12#.azdext.PromptSummaryConfirmRequest\x1a$.azdext.PromptSummaryConfirmResponse\x12m\n" +
+	"\x18PromptDestructiveConfirm\x12'.azdext.PromptDestructiveConfirmRequest\x1a(.azdext.PromptDestructiveConfirmResponse\x127\n" +
 	"\x06Prompt\x12\x15.azdext.PromptRequest\x1a\x16.azdext.PromptResponse\x127\n" +
 	"\x06Select\x12\x15.azdext.SelectRequest\x1a\x16.azdext.SelectResponse\x12F\n" +
-	"\vMultiSelect\x12\x1a.azdext.MultiSelectRequest\x1a\x1b.azdext.MultiSelectResponse\x12s\n" +
+	"\vMultiSelect\x12\x1a.azdext.MultiSelectRequest\x1a\x1b.azdext.MultiSelectResponse\x12C\n" +
+	"\n" +
+	"PromptTree\x12\x19.azdext.PromptTreeRequest\x1a\x1a.azdext.PromptTreeResponse\x12C\n" +
+	"\n" +
+	"PromptPath\x12\x19.azdext.PromptPathRequest\x1a\x1a.azdext.PromptPathResponse\x12I\n" +
+	"\fPromptEditor\x12\x1b.azdext.PromptEditorRequest\x1a\x1c.azdext.PromptEditorResponse\x12O\n" +
+	"\x0ePromptDuration\x12\x1d.azdext.PromptDurationRequest\x1a\x1e.azdext.PromptDurationResponse\x12R\n" +
+	"\x0fPromptKeyValues\x12\x1e.azdext.PromptKeyValuesRequest\x1a\x1f.azdext.PromptKeyValuesResponse\x12^\n" +
+	"\x13PromptOrderedSelect\x12\".azdext.PromptOrderedSelectRequest\x1a#.azdext.PromptOrderedSelectResponse\x12d\n" +
+	"\x10PromptSearchable\x12%.azdext.PromptSearchableClientMessage\x1a%.azdext.PromptSearchableServerMessage(\x010\x01\x12a\n" +
+	"\x0fPromptValidated\x12$.azdext.PromptValidatedClientMessage\x1a$.azdext.PromptValidatedServerMessage(\x010\x01\x12s\n" +
 	"\x1aPromptSubscriptionResource\x12).azdext.PromptSubscriptionResourceRequest\x1a*.azdext.PromptSubscriptionResourceResponse\x12v\n" +
 	"\x1bPromptResourceGroupResource\x12*.azdext.PromptResourceGroupResourceRequest\x1a+.azdext.PromptResourceGroupResourceResponse\x12L\n" +
 	"\rPromptAiModel\x12\x1c.azdext.PromptAiModelRequest\x1a\x1d.azdext.PromptAiModelResponse\x12[\n" +
-	"\x12PromptAiDeployment\x12!.azdext.PromptAiDeploymentRequest\x1a\".azdext.PromptAiDeploymentResponse\x12p\n" +
+	"\x12PromptAiDeployment\x12!.azdext.PromptAiDeploymentRequest\x1a\".azdext.PromptAiDeploymentResponse\x12F\n" +
+	"\vPromptAiSku\x12\x1a.azdext.PromptAiSkuRequest\x1a\x1b.azdext.PromptAiSkuResponse\x12p\n" +
 	"\x19PromptAiLocationWithQuota\x12(.azdext.PromptAiLocationWithQuotaRequest\x1a).azdext.PromptAiLocationWithQuotaResponse\x12\x7f\n" +
 	"\x1ePromptAiModelLocationWithQuota\x12-.azdext.PromptAiModelLocationWithQuotaRequest\x1a..azdext.PromptAiModelLocationWithQuotaResponseB/Z-github.com/azure/azure-dev/cli/azd/pkg/azdextb\x06proto3"
 
@@ -2328,7 +5245,7 @@ func file_prompt_proto_rawDescGZIP() []byte {
 	return file_prompt_proto_rawDescData
 }
 
-var file_prompt_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
+var file_prompt_proto_msgTypes = make([]protoimpl.MessageInfo, 78)
 var file_prompt_proto_goTypes = []any{
 	(*PromptSubscriptionRequest)(nil),              // 0: azdext.PromptSubscriptionRequest
 	(*PromptSubscriptionResponse)(nil),             // 1: azdext.PromptSubscriptionResponse
@@ -2336,117 +5253,220 @@ var file_prompt_proto_goTypes = []any{
 	(*PromptLocationResponse)(nil),                 // 3: azdext.PromptLocationResponse
 	(*PromptResourceGroupRequest)(nil),             // 4: azdext.PromptResourceGroupRequest
 	(*PromptResourceGroupResponse)(nil),            // 5: azdext.PromptResourceGroupResponse
-	(*ConfirmRequest)(nil),                         // 6: azdext.ConfirmRequest
-	(*ConfirmResponse)(nil),                        // 7: azdext.ConfirmResponse
-	(*PromptRequest)(nil),                          // 8: azdext.PromptRequest
-	(*PromptResponse)(nil),                         // 9: azdext.PromptResponse
-	(*SelectRequest)(nil),                          // 10: azdext.SelectRequest
-	(*SelectResponse)(nil),                         // 11: azdext.SelectResponse
-	(*MultiSelectRequest)(nil),                     // 12: azdext.MultiSelectRequest
-	(*MultiSelectResponse)(nil),                    // 13: azdext.MultiSelectResponse
-	(*PromptSubscriptionResourceRequest)(nil),      // 14: azdext.PromptSubscriptionResourceRequest
-	(*PromptSubscriptionResourceResponse)(nil),     // 15: azdext.PromptSubscriptionResourceResponse
-	(*PromptResourceGroupResourceRequest)(nil),     // 16: azdext.PromptResourceGroupResourceRequest
-	(*PromptResourceGroupResourceResponse)(nil),    // 17: azdext.PromptResourceGroupResourceResponse
-	(*ConfirmOptions)(nil),                         // 18: azdext.ConfirmOptions
-	(*PromptOptions)(nil),                          // 19: azdext.PromptOptions
-	(*SelectChoice)(nil),                           // 20: azdext.SelectChoice
-	(*MultiSelectChoice)(nil),                      // 21: azdext.MultiSelectChoice
-	(*SelectOptions)(nil),                          // 22: azdext.SelectOptions
-	(*MultiSelectOptions)(nil),                     // 23: azdext.MultiSelectOptions
-	(*PromptResourceOptions)(nil),                  // 24: azdext.PromptResourceOptions
-	(*PromptResourceSelectOptions)(nil),            // 25: azdext.PromptResourceSelectOptions
-	(*PromptResourceGroupOptions)(nil),             // 26: azdext.PromptResourceGroupOptions
-	(*PromptAiModelRequest)(nil),                   // 27: azdext.PromptAiModelRequest
-	(*PromptAiModelResponse)(nil),                  // 28: azdext.PromptAiModelResponse
-	(*PromptAiDeploymentRequest)(nil),              // 29: azdext.PromptAiDeploymentRequest
-	(*PromptAiDeploymentResponse)(nil),             // 30: azdext.PromptAiDeploymentResponse
-	(*PromptAiLocationWithQuotaRequest)(nil),       // 31: azdext.PromptAiLocationWithQuotaRequest
-	(*PromptAiLocationWithQuotaResponse)(nil),      // 32: azdext.PromptAiLocationWithQuotaResponse
-	(*PromptAiModelLocationWithQuotaRequest)(nil),  // 33: azdext.PromptAiModelLocationWithQuotaRequest
-	(*PromptAiModelLocationWithQuotaResponse)(nil), // 34: azdext.PromptAiModelLocationWithQuotaResponse
-	(*Subscription)(nil),                           // 35: azdext.Subscription
-	(*AzureContext)(nil),                           // 36: azdext.AzureContext
-	(*Location)(nil),                               // 37: azdext.Location
-	(*ResourceGroup)(nil),                          // 38: azdext.ResourceGroup
-	(*ResourceExtended)(nil),                       // 39: azdext.ResourceExtended
-	(*AiModelFilterOptions)(nil),                   // 40: azdext.AiModelFilterOptions
-	(*QuotaCheckOptions)(nil),                      // 41: azdext.QuotaCheckOptions
-	(*AiModel)(nil),                                // 42: azdext.AiModel
-	(*AiModelDeploymentOptions)(nil),               // 43: azdext.AiModelDeploymentOptions
-	(*AiModelDeployment)(nil),                      // 44: azdext.AiModelDeployment
-	(*QuotaRequirement)(nil),                       // 45: azdext.QuotaRequirement
+	(*PromptAzureScopeRequest)(nil),                // 6: azdext.PromptAzureScopeRequest
+	(*PromptAzureScopeResponse)(nil),               // 7: azdext.PromptAzureScopeResponse
+	(*GetAzureContextRequest)(nil),                 // 8: azdext.GetAzureContextRequest
+	(*GetAzureContextResponse)(nil),                // 9: azdext.GetAzureContextResponse
+	(*ConfirmRequest)(nil),                         // 10: azdext.ConfirmRequest
+	(*ConfirmResponse)(nil),                        // 11: azdext.ConfirmResponse
+	(*PromptSummaryConfirmRequest)(nil),            // 12: azdext.PromptSummaryConfirmRequest
+	(*SummaryRow)(nil),                             // 13: azdext.SummaryRow
+	(*PromptSummaryConfirmResponse)(nil),           // 14: azdext.PromptSummaryConfirmResponse
+	(*PromptDestructiveConfirmRequest)(nil),        // 15: azdext.PromptDestructiveConfirmRequest
+	(*PromptDestructiveConfirmResponse)(nil),       // 16: azdext.PromptDestructiveConfirmResponse
+	(*PromptRequest)(nil),                          // 17: azdext.PromptRequest
+	(*PromptResponse)(nil),                         // 18: azdext.PromptResponse
+	(*SelectRequest)(nil),                          // 19: azdext.SelectRequest
+	(*SelectResponse)(nil),                         // 20: azdext.SelectResponse
+	(*MultiSelectRequest)(nil),                     // 21: azdext.MultiSelectRequest
+	(*MultiSelectResponse)(nil),                    // 22: azdext.MultiSelectResponse
+	(*PromptTreeRequest)(nil),                      // 23: azdext.PromptTreeRequest
+	(*PromptTreeResponse)(nil),                     // 24: azdext.PromptTreeResponse
+	(*PromptPathRequest)(nil),                      // 25: azdext.PromptPathRequest
+	(*PromptPathResponse)(nil),                     // 26: azdext.PromptPathResponse
+	(*PromptEditorRequest)(nil),                    // 27: azdext.PromptEditorRequest
+	(*PromptEditorResponse)(nil),                   // 28: azdext.PromptEditorResponse
+	(*PromptDurationRequest)(nil),                  // 29: azdext.PromptDurationRequest
+	(*PromptDurationResponse)(nil),                 // 30: azdext.PromptDurationResponse
+	(*PromptKeyValuesRequest)(nil),                 // 31: azdext.PromptKeyValuesRequest
+	(*PromptKeyValuesResponse)(nil),                // 32: azdext.PromptKeyValuesResponse
+	(*PromptOrderedSelectRequest)(nil),             // 33: azdext.PromptOrderedSelectRequest
+	(*PromptOrderedSelectResponse)(nil),            // 34: azdext.PromptOrderedSelectResponse
+	(*PromptSearchableClientMessage)(nil),          // 35: azdext.PromptSearchableClientMessage
+	(*PromptSearchableServerMessage)(nil),          // 36: azdext.PromptSearchableServerMessage
+	(*PromptSearchableQuery)(nil),                  // 37: azdext.PromptSearchableQuery
+	(*PromptSearchableResults)(nil),                // 38: azdext.PromptSearchableResults
+	(*PromptSearchableResponse)(nil),               // 39: azdext.PromptSearchableResponse
+	(*PromptValidatedClientMessage)(nil),           // 40: azdext.PromptValidatedClientMessage
+	(*PromptValidatedServerMessage)(nil),           // 41: azdext.PromptValidatedServerMessage
+	(*PromptValidation)(nil),                       // 42: azdext.PromptValidation
+	(*PromptValidationResult)(nil),                 // 43: azdext.PromptValidationResult
+	(*PromptSubscriptionResourceRequest)(nil),      // 44: azdext.PromptSubscriptionResourceRequest
+	(*PromptSubscriptionResourceResponse)(nil),     // 45: azdext.PromptSubscriptionResourceResponse
+	(*PromptResourceGroupResourceRequest)(nil),     // 46: azdext.PromptResourceGroupResourceRequest
+	(*PromptResourceGroupResourceResponse)(nil),    // 47: azdext.PromptResourceGroupResourceResponse
+	(*ConfirmOptions)(nil),                         // 48: azdext.ConfirmOptions
+	(*PromptDestructiveConfirmOptions)(nil),        // 49: azdext.PromptDestructiveConfirmOptions
+	(*PromptOptions)(nil),                          // 50: azdext.PromptOptions
+	(*SelectChoice)(nil),                           // 51: azdext.SelectChoice
+	(*MultiSelectChoice)(nil),                      // 52: azdext.MultiSelectChoice
+	(*TreeChoice)(nil),                             // 53: azdext.TreeChoice
+	(*SelectOptions)(nil),                          // 54: azdext.SelectOptions
+	(*MultiSelectOptions)(nil),                     // 55: azdext.MultiSelectOptions
+	(*PromptTreeOptions)(nil),                      // 56: azdext.PromptTreeOptions
+	(*PromptPathOptions)(nil),                      // 57: azdext.PromptPathOptions
+	(*PromptEditorOptions)(nil),                    // 58: azdext.PromptEditorOptions
+	(*PromptDurationOptions)(nil),                  // 59: azdext.PromptDurationOptions
+	(*PromptSearchableOptions)(nil),                // 60: azdext.PromptSearchableOptions
+	(*PromptKeyValuesOptions)(nil),                 // 61: azdext.PromptKeyValuesOptions
+	(*PromptOrderedSelectOptions)(nil),             // 62: azdext.PromptOrderedSelectOptions
+	(*PromptResourceOptions)(nil),                  // 63: azdext.PromptResourceOptions
+	(*PromptResourceSelectOptions)(nil),            // 64: azdext.PromptResourceSelectOptions
+	(*PromptResourceGroupOptions)(nil),             // 65: azdext.PromptResourceGroupOptions
+	(*PromptAiModelRequest)(nil),                   // 66: azdext.PromptAiModelRequest
+	(*PromptAiModelResponse)(nil),                  // 67: azdext.PromptAiModelResponse
+	(*PromptAiDeploymentRequest)(nil),              // 68: azdext.PromptAiDeploymentRequest
+	(*PromptAiDeploymentResponse)(nil),             // 69: azdext.PromptAiDeploymentResponse
+	(*PromptAiSkuRequest)(nil),                     // 70: azdext.PromptAiSkuRequest
+	(*PromptAiSkuResponse)(nil),                    // 71: azdext.PromptAiSkuResponse
+	(*PromptAiLocationWithQuotaRequest)(nil),       // 72: azdext.PromptAiLocationWithQuotaRequest
+	(*PromptAiLocationWithQuotaResponse)(nil),      // 73: azdext.PromptAiLocationWithQuotaResponse
+	(*PromptAiModelLocationWithQuotaRequest)(nil),  // 74: azdext.PromptAiModelLocationWithQuotaRequest
+	(*PromptAiModelLocationWithQuotaResponse)(nil), // 75: azdext.PromptAiModelLocationWithQuotaResponse
+	nil,                              // 76: azdext.PromptKeyValuesResponse.ValuesEntry
+	nil,                              // 77: azdext.PromptKeyValuesOptions.DefaultValuesEntry
+	(*Subscription)(nil),             // 78: azdext.Subscription
+	(*AzureContext)(nil),             // 79: azdext.AzureContext
+	(*Location)(nil),                 // 80: azdext.Location
+	(*ResourceGroup)(nil),            // 81: azdext.ResourceGroup
+	(*ResourceExtended)(nil),         // 82: azdext.ResourceExtended
+	(*AiModelFilterOptions)(nil),     // 83: azdext.AiModelFilterOptions
+	(*QuotaCheckOptions)(nil),        // 84: azdext.QuotaCheckOptions
+	(*AiModel)(nil),                  // 85: azdext.AiModel
+	(*AiModelDeploymentOptions)(nil), // 86: azdext.AiModelDeploymentOptions
+	(*AiModelDeployment)(nil),        // 87: azdext.AiModelDeployment
+	(*AiModelSku)(nil),               // 88: azdext.AiModelSku
+	(*QuotaRequirement)(nil),         // 89: azdext.QuotaRequirement
 }
 var file_prompt_proto_depIdxs = []int32{
-	35, // 0: azdext.PromptSubscriptionResponse.subscription:type_name -> azdext.Subscription
-	36, // 1: azdext.PromptLocationRequest.azure_context:type_name -> azdext.AzureContext
-	37, // 2: azdext.PromptLocationResponse.location:type_name -> azdext.Location
-	36, // 3: azdext.PromptResourceGroupRequest.azure_context:type_name -> azdext.AzureContext
-	26, // 4: azdext.PromptResourceGroupRequest.options:type_name -> azdext.PromptResourceGroupOptions
-	38, // 5: azdext.PromptResourceGroupResponse.resource_group:type_name -> azdext.ResourceGroup
-	18, // 6: azdext.ConfirmRequest.options:type_name -> azdext.ConfirmOptions
-	19, // 7: azdext.PromptRequest.options:type_name -> azdext.PromptOptions
-	22, // 8: azdext.SelectRequest.options:type_name -> azdext.SelectOptions
-	23, // 9: azdext.MultiSelectRequest.options:type_name -> azdext.MultiSelectOptions
-	21, // 10: azdext.MultiSelectResponse.values:type_name -> azdext.MultiSelectChoice
-	36, // 11: azdext.PromptSubscriptionResourceRequest.azure_context:type_name -> azdext.AzureContext
-	24, // 12: azdext.PromptSubscriptionResourceRequest.options:type_name -> azdext.PromptResourceOptions
-	39, // 13: azdext.PromptSubscriptionResourceResponse.resource:type_name -> azdext.ResourceExtended
-	36, // 14: azdext.PromptResourceGroupResourceRequest.azure_context:type_name -> azdext.AzureContext
-	24, // 15: azdext.PromptResourceGroupResourceRequest.options:type_name -> azdext.PromptResourceOptions
-	39, // 16: azdext.PromptResourceGroupResourceResponse.resource:type_name -> azdext.ResourceExtended
-	20, // 17: azdext.SelectOptions.choices:type_name -> azdext.SelectChoice
-	21, // 18: azdext.MultiSelectOptions.choices:type_name -> azdext.MultiSelectChoice
-	25, // 19: azdext.PromptResourceOptions.select_options:type_name -> azdext.PromptResourceSelectOptions
-	25, // 20: azdext.PromptResourceGroupOptions.select_options:type_name -> azdext.PromptResourceSelectOptions
-	36, // 21: azdext.PromptAiModelRequest.azure_context:type_name -> azdext.AzureContext
-	40, // 22: azdext.PromptAiModelRequest.filter:type_name -> azdext.AiModelFilterOptions
-	22, // 23: azdext.PromptAiModelRequest.select_options:type_name -> azdext.SelectOptions
-	41, // 24: azdext.PromptAiModelRequest.quota:type_name -> azdext.QuotaCheckOptions
-	42, // 25: azdext.PromptAiModelResponse.model:type_name -> azdext.AiModel
-	36, // 26: azdext.PromptAiDeploymentRequest.azure_context:type_name -> azdext.AzureContext
-	43, // 27: azdext.PromptAiDeploymentRequest.options:type_name -> azdext.AiModelDeploymentOptions
-	41, // 28: azdext.PromptAiDeploymentRequest.quota:type_name -> azdext.QuotaCheckOptions
-	44, // 29: azdext.PromptAiDeploymentResponse.deployment:type_name -> azdext.AiModelDeployment
-	36, // 30: azdext.PromptAiLocationWithQuotaRequest.azure_context:type_name -> azdext.AzureContext
-	45, // 31: azdext.PromptAiLocationWithQuotaRequest.requirements:type_name -> azdext.QuotaRequirement
-	22, // 32: azdext.PromptAiLocationWithQuotaRequest.select_options:type_name -> azdext.SelectOptions
-	37, // 33: azdext.PromptAiLocationWithQuotaResponse.location:type_name -> azdext.Location
-	36, // 34: azdext.PromptAiModelLocationWithQuotaRequest.azure_context:type_name -> azdext.AzureContext
-	41, // 35: azdext.PromptAiModelLocationWithQuotaRequest.quota:type_name -> azdext.QuotaCheckOptions
-	22, // 36: azdext.PromptAiModelLocationWithQuotaRequest.select_options:type_name -> azdext.SelectOptions
-	37, // 37: azdext.PromptAiModelLocationWithQuotaResponse.location:type_name -> azdext.Location
-	0,  // 38: azdext.PromptService.PromptSubscription:input_type -> azdext.PromptSubscriptionRequest
-	2,  // 39: azdext.PromptService.PromptLocation:input_type -> azdext.PromptLocationRequest
-	4,  // 40: azdext.PromptService.PromptResourceGroup:input_type -> azdext.PromptResourceGroupRequest
-	6,  // 41: azdext.PromptService.Confirm:input_type -> azdext.ConfirmRequest
-	8,  // 42: azdext.PromptService.Prompt:input_type -> azdext.PromptRequest
-	10, // 43: azdext.PromptService.Select:input_type -> azdext.SelectRequest
-	12, // 44: azdext.PromptService.MultiSelect:input_type -> azdext.MultiSelectRequest
-	14, // 45: azdext.PromptService.PromptSubscriptionResource:input_type -> azdext.PromptSubscriptionResourceRequest
-	16, // 46: azdext.PromptService.PromptResourceGroupResource:input_type -> azdext.PromptResourceGroupResourceRequest
-	27, // 47: azdext.PromptService.PromptAiModel:input_type -> azdext.PromptAiModelRequest
-	29, // 48: azdext.PromptService.PromptAiDeployment:input_type -> azdext.PromptAiDeploymentRequest
-	31, // 49: azdext.PromptService.PromptAiLocationWithQuota:input_type -> azdext.PromptAiLocationWithQuotaRequest
-	33, // 50: azdext.PromptService.PromptAiModelLocationWithQuota:input_type -> azdext.PromptAiModelLocationWithQuotaRequest
-	1,  // 51: azdext.PromptService.PromptSubscription:output_type -> azdext.PromptSubscriptionResponse
-	3,  // 52: azdext.PromptService.PromptLocation:output_type -> azdext.PromptLocationResponse
-	5,  // 53: azdext.PromptService.PromptResourceGroup:output_type -> azdext.PromptResourceGroupResponse
-	7,  // 54: azdext.PromptService.Confirm:output_type -> azdext.ConfirmResponse
-	9,  // 55: azdext.PromptService.Prompt:output_type -> azdext.PromptResponse
-	11, // 56: azdext.PromptService.Select:output_type -> azdext.SelectResponse
-	13, // 57: azdext.PromptService.MultiSelect:output_type -> azdext.MultiSelectResponse
-	15, // 58: azdext.PromptService.PromptSubscriptionResource:output_type -> azdext.PromptSubscriptionResourceResponse
-	17, // 59: azdext.PromptService.PromptResourceGroupResource:output_type -> azdext.PromptResourceGroupResourceResponse
-	28, // 60: azdext.PromptService.PromptAiModel:output_type -> azdext.PromptAiModelResponse
-	30, // 61: azdext.PromptService.PromptAiDeployment:output_type -> azdext.PromptAiDeploymentResponse
-	32, // 62: azdext.PromptService.PromptAiLocationWithQuota:output_type -> azdext.PromptAiLocationWithQuotaResponse
-	34, // 63: azdext.PromptService.PromptAiModelLocationWithQuota:output_type -> azdext.PromptAiModelLocationWithQuotaResponse
-	51, // [51:64] is the sub-list for method output_type
-	38, // [38:51] is the sub-list for method input_type
-	38, // [38:38] is the sub-list for extension type_name
-	38, // [38:38] is the sub-list for extension extendee
-	0,  // [0:38] is the sub-list for field type_name
+	78, // 0: azdext.PromptSubscriptionResponse.subscription:type_name -> azdext.Subscription
+	79, // 1: azdext.PromptLocationRequest.azure_context:type_name -> azdext.AzureContext
+	80, // 2: azdext.PromptLocationResponse.location:type_name -> azdext.Location
+	79, // 3: azdext.PromptResourceGroupRequest.azure_context:type_name -> azdext.AzureContext
+	65, // 4: azdext.PromptResourceGroupRequest.options:type_name -> azdext.PromptResourceGroupOptions
+	81, // 5: azdext.PromptResourceGroupResponse.resource_group:type_name -> azdext.ResourceGroup
+	79, // 6: azdext.PromptAzureScopeRequest.azure_context:type_name -> azdext.AzureContext
+	79, // 7: azdext.PromptAzureScopeResponse.azure_context:type_name -> azdext.AzureContext
+	79, // 8: azdext.GetAzureContextResponse.azure_context:type_name -> azdext.AzureContext
+	48, // 9: azdext.ConfirmRequest.options:type_name -> azdext.ConfirmOptions
+	13, // 10: azdext.PromptSummaryConfirmRequest.rows:type_name -> azdext.SummaryRow
+	48, // 11: azdext.PromptSummaryConfirmRequest.options:type_name -> azdext.ConfirmOptions
+	49, // 12: azdext.PromptDestructiveConfirmRequest.options:type_name -> azdext.PromptDestructiveConfirmOptions
+	50, // 13: azdext.PromptRequest.options:type_name -> azdext.PromptOptions
+	54, // 14: azdext.SelectRequest.options:type_name -> azdext.SelectOptions
+	55, // 15: azdext.MultiSelectRequest.options:type_name -> azdext.MultiSelectOptions
+	52, // 16: azdext.MultiSelectResponse.values:type_name -> azdext.MultiSelectChoice
+	56, // 17: azdext.PromptTreeRequest.options:type_name -> azdext.PromptTreeOptions
+	57, // 18: azdext.PromptPathRequest.options:type_name -> azdext.PromptPathOptions
+	58, // 19: azdext.PromptEditorRequest.options:type_name -> azdext.PromptEditorOptions
+	59, // 20: azdext.PromptDurationRequest.options:type_name -> azdext.PromptDurationOptions
+	61, // 21: azdext.PromptKeyValuesRequest.options:type_name -> azdext.PromptKeyValuesOptions
+	76, // 22: azdext.PromptKeyValuesResponse.values:type_name -> azdext.PromptKeyValuesResponse.ValuesEntry
+	62, // 23: azdext.PromptOrderedSelectRequest.options:type_name -> azdext.PromptOrderedSelectOptions
+	51, // 24: azdext.PromptOrderedSelectResponse.values:type_name -> azdext.SelectChoice
+	60, // 25: azdext.PromptSearchableClientMessage.options:type_name -> azdext.PromptSearchableOptions
+	38, // 26: azdext.PromptSearchableClientMessage.results:type_name -> azdext.PromptSearchableResults
+	37, // 27: azdext.PromptSearchableServerMessage.query:type_name -> azdext.PromptSearchableQuery
+	39, // 28: azdext.PromptSearchableServerMessage.response:type_name -> azdext.PromptSearchableResponse
+	51, // 29: azdext.PromptSearchableResults.choices:type_name -> azdext.SelectChoice
+	51, // 30: azdext.PromptSearchableResponse.value:type_name -> azdext.SelectChoice
+	50, // 31: azdext.PromptValidatedClientMessage.options:type_name -> azdext.PromptOptions
+	43, // 32: azdext.PromptValidatedClientMessage.result:type_name -> azdext.PromptValidationResult
+	42, // 33: azdext.PromptValidatedServerMessage.validation:type_name -> azdext.PromptValidation
+	18, // 34: azdext.PromptValidatedServerMessage.response:type_name -> azdext.PromptResponse
+	79, // 35: azdext.PromptSubscriptionResourceRequest.azure_context:type_name -> azdext.AzureContext
+	63, // 36: azdext.PromptSubscriptionResourceRequest.options:type_name -> azdext.PromptResourceOptions
+	82, // 37: azdext.PromptSubscriptionResourceResponse.resource:type_name -> azdext.ResourceExtended
+	79, // 38: azdext.PromptResourceGroupResourceRequest.azure_context:type_name -> azdext.AzureContext
+	63, // 39: azdext.PromptResourceGroupResourceRequest.options:type_name -> azdext.PromptResourceOptions
+	82, // 40: azdext.PromptResourceGroupResourceResponse.resource:type_name -> azdext.ResourceExtended
+	53, // 41: azdext.TreeChoice.children:type_name -> azdext.TreeChoice
+	51, // 42: azdext.SelectOptions.choices:type_name -> azdext.SelectChoice
+	52, // 43: azdext.MultiSelectOptions.choices:type_name -> azdext.MultiSelectChoice
+	53, // 44: azdext.PromptTreeOptions.choices:type_name -> azdext.TreeChoice
+	77, // 45: azdext.PromptKeyValuesOptions.default_values:type_name -> azdext.PromptKeyValuesOptions.DefaultValuesEntry
+	51, // 46: azdext.PromptOrderedSelectOptions.choices:type_name -> azdext.SelectChoice
+	64, // 47: azdext.PromptResourceOptions.select_options:type_name -> azdext.PromptResourceSelectOptions
+	64, // 48: azdext.PromptResourceGroupOptions.select_options:type_name -> azdext.PromptResourceSelectOptions
+	79, // 49: azdext.PromptAiModelRequest.azure_context:type_name -> azdext.AzureContext
+	83, // 50: azdext.PromptAiModelRequest.filter:type_name -> azdext.AiModelFilterOptions
+	54, // 51: azdext.PromptAiModelRequest.select_options:type_name -> azdext.SelectOptions
+	84, // 52: azdext.PromptAiModelRequest.quota:type_name -> azdext.QuotaCheckOptions
+	85, // 53: azdext.PromptAiModelResponse.model:type_name -> azdext.AiModel
+	80, // 54: azdext.PromptAiModelResponse.location:type_name -> azdext.Location
+	79, // 55: azdext.PromptAiDeploymentRequest.azure_context:type_name -> azdext.AzureContext
+	86, // 56: azdext.PromptAiDeploymentRequest.options:type_name -> azdext.AiModelDeploymentOptions
+	84, // 57: azdext.PromptAiDeploymentRequest.quota:type_name -> azdext.QuotaCheckOptions
+	87, // 58: azdext.PromptAiDeploymentResponse.deployment:type_name -> azdext.AiModelDeployment
+	79, // 59: azdext.PromptAiSkuRequest.azure_context:type_name -> azdext.AzureContext
+	88, // 60: azdext.PromptAiSkuRequest.skus:type_name -> azdext.AiModelSku
+	84, // 61: azdext.PromptAiSkuRequest.quota:type_name -> azdext.QuotaCheckOptions
+	88, // 62: azdext.PromptAiSkuResponse.sku:type_name -> azdext.AiModelSku
+	79, // 63: azdext.PromptAiLocationWithQuotaRequest.azure_context:type_name -> azdext.AzureContext
+	89, // 64: azdext.PromptAiLocationWithQuotaRequest.requirements:type_name -> azdext.QuotaRequirement
+	54, // 65: azdext.PromptAiLocationWithQuotaRequest.select_options:type_name -> azdext.SelectOptions
+	80, // 66: azdext.PromptAiLocationWithQuotaResponse.location:type_name -> azdext.Location
+	79, // 67: azdext.PromptAiModelLocationWithQuotaRequest.azure_context:type_name -> azdext.AzureContext
+	84, // 68: azdext.PromptAiModelLocationWithQuotaRequest.quota:type_name -> azdext.QuotaCheckOptions
+	54, // 69: azdext.PromptAiModelLocationWithQuotaRequest.select_options:type_name -> azdext.SelectOptions
+	80, // 70: azdext.PromptAiModelLocationWithQuotaResponse.location:type_name -> azdext.Location
+	0,  // 71: azdext.PromptService.PromptSubscription:input_type -> azdext.PromptSubscriptionRequest
+	2,  // 72: azdext.PromptService.PromptLocation:input_type -> azdext.PromptLocationRequest
+	4,  // 73: azdext.PromptService.PromptResourceGroup:input_type -> azdext.PromptResourceGroupRequest
+	6,  // 74: azdext.PromptService.PromptAzureScope:input_type -> azdext.PromptAzureScopeRequest
+	8,  // 75: azdext.PromptService.GetAzureContext:input_type -> azdext.GetAzureContextRequest
+	10, // 76: azdext.PromptService.Confirm:input_type -> azdext.ConfirmRequest
+	12, // 77: azdext.PromptService.PromptSummaryConfirm:input_type -> azdext.PromptSummaryConfirmRequest
+	15, // 78: azdext.PromptService.PromptDestructiveConfirm:input_type -> azdext.PromptDestructiveConfirmRequest
+	17, // 79: azdext.PromptService.Prompt:input_type -> azdext.PromptRequest
+	19, // 80: azdext.PromptService.Select:input_type -> azdext.SelectRequest
+	21, // 81: azdext.PromptService.MultiSelect:input_type -> azdext.MultiSelectRequest
+	23, // 82: azdext.PromptService.PromptTree:input_type -> azdext.PromptTreeRequest
+	25, // 83: azdext.PromptService.PromptPath:input_type -> azdext.PromptPathRequest
+	27, // 84: azdext.PromptService.PromptEditor:input_type -> azdext.PromptEditorRequest
+	29, // 85: azdext.PromptService.PromptDuration:input_type -> azdext.PromptDurationRequest
+	31, // 86: azdext.PromptService.PromptKeyValues:input_type -> azdext.PromptKeyValuesRequest
+	33, // 87: azdext.PromptService.PromptOrderedSelect:input_type -> azdext.PromptOrderedSelectRequest
+	35, // 88: azdext.PromptService.PromptSearchable:input_type -> azdext.PromptSearchableClientMessage
+	40, // 89: azdext.PromptService.PromptValidated:input_type -> azdext.PromptValidatedClientMessage
+	44, // 90: azdext.PromptService.PromptSubscriptionResource:input_type -> azdext.PromptSubscriptionResourceRequest
+	46, // 91: azdext.PromptService.PromptResourceGroupResource:input_type -> azdext.PromptResourceGroupResourceRequest
+	66, // 92: azdext.PromptService.PromptAiModel:input_type -> azdext.PromptAiModelRequest
+	68, // 93: azdext.PromptService.PromptAiDeployment:input_type -> azdext.PromptAiDeploymentRequest
+	70, // 94: azdext.PromptService.PromptAiSku:input_type -> azdext.PromptAiSkuRequest
+	72, // 95: azdext.PromptService.PromptAiLocationWithQuota:input_type -> azdext.PromptAiLocationWithQuotaRequest
+	74, // 96: azdext.PromptService.PromptAiModelLocationWithQuota:input_type -> azdext.PromptAiModelLocationWithQuotaRequest
+	1,  // 97: azdext.PromptService.PromptSubscription:output_type -> azdext.PromptSubscriptionResponse
+	3,  // 98: azdext.PromptService.PromptLocation:output_type -> azdext.PromptLocationResponse
+	5,  // 99: azdext.PromptService.PromptResourceGroup:output_type -> azdext.PromptResourceGroupResponse
+	7,  // 100: azdext.PromptService.PromptAzureScope:output_type -> azdext.PromptAzureScopeResponse
+	9,  // 101: azdext.PromptService.GetAzureContext:output_type -> azdext.GetAzureContextResponse
+	11, // 102: azdext.PromptService.Confirm:output_type -> azdext.ConfirmResponse
+	14, // 103: azdext.PromptService.PromptSummaryConfirm:output_type -> azdext.PromptSummaryConfirmResponse
+	16, // 104: azdext.PromptService.PromptDestructiveConfirm:output_type -> azdext.PromptDestructiveConfirmResponse
+	18, // 105: azdext.PromptService.Prompt:output_type -> azdext.PromptResponse
+	20, // 106: azdext.PromptService.Select:output_type -> azdext.SelectResponse
+	22, // 107: azdext.PromptService.MultiSelect:output_type -> azdext.MultiSelectResponse
+	24, // 108: azdext.PromptService.PromptTree:output_type -> azdext.PromptTreeResponse
+	26, // 109: azdext.PromptService.PromptPath:output_type -> azdext.PromptPathResponse
+	28, // 110: azdext.PromptService.PromptEditor:output_type -> azdext.PromptEditorResponse
+	30, // 111: azdext.PromptService.PromptDuration:output_type -> azdext.PromptDurationResponse
+	32, // 112: azdext.PromptService.PromptKeyValues:output_type -> azdext.PromptKeyValuesResponse
+	34, // 113: azdext.PromptService.PromptOrderedSelect:output_type -> azdext.PromptOrderedSelectResponse
+	36, // 114: azdext.PromptService.PromptSearchable:output_type -> azdext.PromptSearchableServerMessage
+	41, // 115: azdext.PromptService.PromptValidated:output_type -> azdext.PromptValidatedServerMessage
+	45, // 116: azdext.PromptService.PromptSubscriptionResource:output_type -> azdext.PromptSubscriptionResourceResponse
+	47, // 117: azdext.PromptService.PromptResourceGroupResource:output_type -> azdext.PromptResourceGroupResourceResponse
+	67, // 118: azdext.PromptService.PromptAiModel:output_type -> azdext.PromptAiModelResponse
+	69, // 119: azdext.PromptService.PromptAiDeployment:output_type -> azdext.PromptAiDeploymentResponse
+	71, // 120: azdext.PromptService.PromptAiSku:output_type -> azdext.PromptAiSkuResponse
+	73, // 121: azdext.PromptService.PromptAiLocationWithQuota:output_type -> azdext.PromptAiLocationWithQuotaResponse
+	75, // 122: azdext.PromptService.PromptAiModelLocationWithQuota:output_type -> azdext.PromptAiModelLocationWithQuotaResponse
+	97, // [97:123] is the sub-list for method output_type
+	71, // [71:97] is the sub-list for method input_type
+	71, // [71:71] is the sub-list for extension type_name
+	71, // [71:71] is the sub-list for extension extendee
+	0,  // [0:71] is the sub-list for field type_name
 }
 
 func init() { file_prompt_proto_init() }
@@ -2456,19 +5476,41 @@ func file_prompt_proto_init() {
 	}
 	file_models_proto_init()
 	file_ai_model_proto_init()
-	file_prompt_proto_msgTypes[7].OneofWrappers = []any{}
 	file_prompt_proto_msgTypes[11].OneofWrappers = []any{}
-	file_prompt_proto_msgTypes[18].OneofWrappers = []any{}
-	file_prompt_proto_msgTypes[22].OneofWrappers = []any{}
-	file_prompt_proto_msgTypes[23].OneofWrappers = []any{}
-	file_prompt_proto_msgTypes[25].OneofWrappers = []any{}
+	file_prompt_proto_msgTypes[14].OneofWrappers = []any{}
+	file_prompt_proto_msgTypes[20].OneofWrappers = []any{}
+	file_prompt_proto_msgTypes[35].OneofWrappers = []any{
+		(*PromptSearchableClientMessage_Options)(nil),
+		(*PromptSearchableClientMessage_Results)(nil),
+	}
+	file_prompt_proto_msgTypes[36].OneofWrappers = []any{
+		(*PromptSearchableServerMessage_Query)(nil),
+		(*PromptSearchableServerMessage_Response)(nil),
+	}
+	file_prompt_proto_msgTypes[40].OneofWrappers = []any{
+		(*PromptValidatedClientMessage_Options)(nil),
+		(*PromptValidatedClientMessage_Result)(nil),
+	}
+	file_prompt_proto_msgTypes[41].OneofWrappers = []any{
+		(*PromptValidatedServerMessage_Validation)(nil),
+		(*PromptValidatedServerMessage_Response)(nil),
+	}
+	file_prompt_proto_msgTypes[48].OneofWrappers = []any{}
+	file_prompt_proto_msgTypes[54].OneofWrappers = []any{}
+	file_prompt_proto_msgTypes[55].OneofWrappers = []any{}
+	file_prompt_proto_msgTypes[56].OneofWrappers = []any{}
+	file_prompt_proto_msgTypes[60].OneofWrappers = []any{}
+	file_prompt_proto_msgTypes[62].OneofWrappers = []any{}
+	file_prompt_proto_msgTypes[64].OneofWrappers = []any{}
+	file_prompt_proto_msgTypes[68].OneofWrappers = []any{}
+	file_prompt_proto_msgTypes[71].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_prompt_proto_rawDesc), len(file_prompt_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   35,
+			NumMessages:   78,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
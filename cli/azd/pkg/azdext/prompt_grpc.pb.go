@@ -25,14 +25,27 @@ const (
 	PromptService_PromptSubscription_FullMethodName             = "/azdext.PromptService/PromptSubscription"
 	PromptService_PromptLocation_FullMethodName                 = "/azdext.PromptService/PromptLocation"
 	PromptService_PromptResourceGroup_FullMethodName            = "/azdext.PromptService/PromptResourceGroup"
+	PromptService_PromptAzureScope_FullMethodName               = "/azdext.PromptService/PromptAzureScope"
+	PromptService_GetAzureContext_FullMethodName                = "/azdext.PromptService/GetAzureContext"
 	PromptService_Confirm_FullMethodName                        = "/azdext.PromptService/Confirm"
+	PromptService_PromptSummaryConfirm_FullMethodName           = "/azdext.PromptService/PromptSummaryConfirm"
+	PromptService_PromptDestructiveConfirm_FullMethodName       = "/azdext.PromptService/PromptDestructiveConfirm"
 	PromptService_Prompt_FullMethodName                         = "/azdext.PromptService/Prompt"
 	PromptService_Select_FullMethodName                         = "/azdext.PromptService/Select"
 	PromptService_MultiSelect_FullMethodName                    = "/azdext.PromptService/MultiSelect"
+	PromptService_PromptTree_FullMethodName                     = "/azdext.PromptService/PromptTree"
+	PromptService_PromptPath_FullMethodName                     = "/azdext.PromptService/PromptPath"
+	PromptService_PromptEditor_FullMethodName                   = "/azdext.PromptService/PromptEditor"
+	PromptService_PromptDuration_FullMethodName                 = "/azdext.PromptService/PromptDuration"
+	PromptService_PromptKeyValues_FullMethodName                = "/azdext.PromptService/PromptKeyValues"
+	PromptService_PromptOrderedSelect_FullMethodName            = "/azdext.PromptService/PromptOrderedSelect"
+	PromptService_PromptSearchable_FullMethodName               = "/azdext.PromptService/PromptSearchable"
+	PromptService_PromptValidated_FullMethodName                = "/azdext.PromptService/PromptValidated"
 	PromptService_PromptSubscriptionResource_FullMethodName     = "/azdext.PromptService/PromptSubscriptionResource"
 	PromptService_PromptResourceGroupResource_FullMethodName    = "/azdext.PromptService/PromptResourceGroupResource"
 	PromptService_PromptAiModel_FullMethodName                  = "/azdext.PromptService/PromptAiModel"
 	PromptService_PromptAiDeployment_FullMethodName             = "/azdext.PromptService/PromptAiDeployment"
+	PromptService_PromptAiSku_FullMethodName                    = "/azdext.PromptService/PromptAiSku"
 	PromptService_PromptAiLocationWithQuota_FullMethodName      = "/azdext.PromptService/PromptAiLocationWithQuota"
 	PromptService_PromptAiModelLocationWithQuota_FullMethodName = "/azdext.PromptService/PromptAiModelLocationWithQuota"
 )
@@ -47,14 +60,62 @@ type PromptServiceClient interface {
 	PromptLocation(ctx context.Context, in *PromptLocationRequest, opts ...grpc.CallOption) (*PromptLocationResponse, error)
 	// PromptResourceGroup prompts the user to select a resource group.
 	PromptResourceGroup(ctx context.Context, in *PromptResourceGroupRequest, opts ...grpc.CallOption) (*PromptResourceGroupResponse, error)
+	// PromptAzureScope prompts for a subscription, a location and optionally a resource group in sequence,
+	// and returns the resulting Azure context. Values already set on azure_context.scope are kept and their
+	// prompts are skipped. In no-prompt mode, missing values are taken from the active azd environment.
+	PromptAzureScope(ctx context.Context, in *PromptAzureScopeRequest, opts ...grpc.CallOption) (*PromptAzureScopeResponse, error)
+	// GetAzureContext returns the subscription, tenant, location and resource group of the active azd environment
+	// without prompting. Values the environment does not set, or all of them when no environment is selected, are empty.
+	GetAzureContext(ctx context.Context, in *GetAzureContextRequest, opts ...grpc.CallOption) (*GetAzureContextResponse, error)
 	// Confirm prompts the user to confirm an action.
 	Confirm(ctx context.Context, in *ConfirmRequest, opts ...grpc.CallOption) (*ConfirmResponse, error)
+	// PromptSummaryConfirm displays a read-only summary as an aligned table, then asks the user to confirm.
+	PromptSummaryConfirm(ctx context.Context, in *PromptSummaryConfirmRequest, opts ...grpc.CallOption) (*PromptSummaryConfirmResponse, error)
+	// PromptDestructiveConfirm guards an irreversible action, such as deleting a resource, by asking the user to type
+	// options.confirmation_phrase exactly. A mismatch is re-prompted until options.max_attempts is reached, and the
+	// action is then reported as not confirmed. options.force confirms without prompting; in no-prompt mode it is
+	// required.
+	PromptDestructiveConfirm(ctx context.Context, in *PromptDestructiveConfirmRequest, opts ...grpc.CallOption) (*PromptDestructiveConfirmResponse, error)
 	// Prompt prompts the user for text input.
 	Prompt(ctx context.Context, in *PromptRequest, opts ...grpc.CallOption) (*PromptResponse, error)
 	// Select prompts the user to select an option from a list.
 	Select(ctx context.Context, in *SelectRequest, opts ...grpc.CallOption) (*SelectResponse, error)
 	// MultiSelect prompts the user to select multiple options from a list.
 	MultiSelect(ctx context.Context, in *MultiSelectRequest, opts ...grpc.CallOption) (*MultiSelectResponse, error)
+	// PromptTree prompts the user to drill down through a tree of choices one level at a time,
+	// e.g. management group -> subscription, and returns the path of selected values ending at a leaf.
+	// Levels below the root include a "Back" option that returns to the parent level.
+	// In no-prompt mode, options.selected_path must identify a leaf.
+	PromptTree(ctx context.Context, in *PromptTreeRequest, opts ...grpc.CallOption) (*PromptTreeResponse, error)
+	// PromptPath prompts the user for a file or directory path, with Tab completion of filesystem entries.
+	// The path is validated against the existence constraints in options and the user is re-prompted until it passes.
+	// In no-prompt mode, options.default_value is validated against the same constraints.
+	PromptPath(ctx context.Context, in *PromptPathRequest, opts ...grpc.CallOption) (*PromptPathResponse, error)
+	// PromptEditor lets the user edit a multi-line value in the editor named by $VISUAL or $EDITOR, seeded with
+	// options.default_value. When no editor is configured or it cannot be found, a multi-line terminal input is used
+	// instead. In no-prompt mode, options.default_value is returned unchanged.
+	PromptEditor(ctx context.Context, in *PromptEditorRequest, opts ...grpc.CallOption) (*PromptEditorResponse, error)
+	// PromptDuration prompts the user for a Go-style duration such as "30s", "5m" or "1h30m", enforcing the optional
+	// options.min_value and options.max_value bounds. In no-prompt mode, options.default_value is validated against the
+	// same bounds and returned.
+	PromptDuration(ctx context.Context, in *PromptDurationRequest, opts ...grpc.CallOption) (*PromptDurationResponse, error)
+	// PromptKeyValues prompts the user for key/value pairs, such as tags or environment variables, asking for a key
+	// then a value until the user declines to add another entry. Keys are validated against options.key_pattern.
+	// In no-prompt mode, options.default_values is validated against the same constraints and returned.
+	PromptKeyValues(ctx context.Context, in *PromptKeyValuesRequest, opts ...grpc.CallOption) (*PromptKeyValuesResponse, error)
+	// PromptOrderedSelect collects an ordered list, such as a fallback region order, by prompting the user to pick one
+	// choice at a time until they are done. Picked choices are not offered again, and the response lists them in the
+	// order they were picked. In no-prompt mode, options.default_values is returned in its order.
+	PromptOrderedSelect(ctx context.Context, in *PromptOrderedSelectRequest, opts ...grpc.CallOption) (*PromptOrderedSelectResponse, error)
+	// PromptSearchable shows a select prompt whose choices are searched by the extension as the user types, so
+	// selection works over data sets too large to send up front. The extension first sends options, then answers
+	// each query with the matching choices. azd ends the stream with the selected choice.
+	PromptSearchable(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[PromptSearchableClientMessage, PromptSearchableServerMessage], error)
+	// PromptValidated shows a text prompt whose submitted values are validated by the extension, e.g. against live
+	// data such as whether a resource name is available. The extension first sends options, then answers each
+	// validation with a result. A rejected value is shown with the result message and the user is prompted again.
+	// azd ends the stream with the accepted value.
+	PromptValidated(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[PromptValidatedClientMessage, PromptValidatedServerMessage], error)
 	// PromptSubscriptionResource prompts the user to select a resource from a subscription.
 	PromptSubscriptionResource(ctx context.Context, in *PromptSubscriptionResourceRequest, opts ...grpc.CallOption) (*PromptSubscriptionResourceResponse, error)
 	// PromptResourceGroupResource prompts the user to select a resource from a resource group.
@@ -74,8 +135,14 @@ type PromptServiceClient interface {
 	// This is the primary interactive primitive for model deployment selection.
 	// Effective location is defined by options.locations.
 	// If options.locations is empty, model catalog is considered across subscription locations.
-	// Quota requires exactly one effective location (via options.locations).
+	// Quota requires exactly one effective location (via options.locations). When options.locations is empty,
+	// quota is checked at the location of azure_context, or else of the active azd environment.
 	PromptAiDeployment(ctx context.Context, in *PromptAiDeploymentRequest, opts ...grpc.CallOption) (*PromptAiDeploymentResponse, error)
+	// PromptAiSku prompts for a SKU and its capacity in one step, for a model version whose SKUs are already known.
+	// Each SKU is listed with its capacity range, and the capacity is prompted within the selected SKU's bounds.
+	// In no-prompt mode, preferred_sku (or the first SKU) is used at its default capacity.
+	// Quota requires location, which defaults to the location of azure_context, or else of the active azd environment.
+	PromptAiSku(ctx context.Context, in *PromptAiSkuRequest, opts ...grpc.CallOption) (*PromptAiSkuResponse, error)
 	// PromptAiLocationWithQuota prompts for a location filtered by quota requirements.
 	PromptAiLocationWithQuota(ctx context.Context, in *PromptAiLocationWithQuotaRequest, opts ...grpc.CallOption) (*PromptAiLocationWithQuotaResponse, error)
 	// PromptAiModelLocationWithQuota prompts for a model location and displays remaining quota.
@@ -120,6 +187,26 @@ func (c *promptServiceClient) PromptResourceGroup(ctx context.Context, in *Promp
 	return out, nil
 }
 
+func (c *promptServiceClient) PromptAzureScope(ctx context.Context, in *PromptAzureScopeRequest, opts ...grpc.CallOption) (*PromptAzureScopeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PromptAzureScopeResponse)
+	err := c.cc.Invoke(ctx, PromptService_PromptAzureScope_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *promptServiceClient) GetAzureContext(ctx context.Context, in *GetAzureContextRequest, opts ...grpc.CallOption) (*GetAzureContextResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAzureContextResponse)
+	err := c.cc.Invoke(ctx, PromptService_GetAzureContext_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *promptServiceClient) Confirm(ctx context.Context, in *ConfirmRequest, opts ...grpc.CallOption) (*ConfirmResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ConfirmResponse)
@@ -130,6 +217,26 @@ func (c *promptServiceClient) Confirm(ctx context.Context, in *ConfirmRequest, o
 	return out, nil
 }
 
+func (c *promptServiceClient) PromptSummaryConfirm(ctx context.Context, in *PromptSummaryConfirmRequest, opts ...grpc.CallOption) (*PromptSummaryConfirmResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PromptSummaryConfirmResponse)
+	err := c.cc.Invoke(ctx, PromptService_PromptSummaryConfirm_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *promptServiceClient) PromptDestructiveConfirm(ctx context.Context, in *PromptDestructiveConfirmRequest, opts ...grpc.CallOption) (*PromptDestructiveConfirmResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PromptDestructiveConfirmResponse)
+	err := c.cc.Invoke(ctx, PromptService_PromptDestructiveConfirm_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *promptServiceClient) Prompt(ctx context.Context, in *PromptRequest, opts ...grpc.CallOption) (*PromptResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PromptResponse)
@@ -160,6 +267,92 @@ func (c *promptServiceClient) MultiSelect(ctx context.Context, in *MultiSelectRe
 	return out, nil
 }
 
+func (c *promptServiceClient) PromptTree(ctx context.Context, in *PromptTreeRequest, opts ...grpc.CallOption) (*PromptTreeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PromptTreeResponse)
+	err := c.cc.Invoke(ctx, PromptService_PromptTree_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *promptServiceClient) PromptPath(ctx context.Context, in *PromptPathRequest, opts ...grpc.CallOption) (*PromptPathResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PromptPathResponse)
+	err := c.cc.Invoke(ctx, PromptService_PromptPath_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *promptServiceClient) PromptEditor(ctx context.Context, in *PromptEditorRequest, opts ...grpc.CallOption) (*PromptEditorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PromptEditorResponse)
+	err := c.cc.Invoke(ctx, PromptService_PromptEditor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *promptServiceClient) PromptDuration(ctx context.Context, in *PromptDurationRequest, opts ...grpc.CallOption) (*PromptDurationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PromptDurationResponse)
+	err := c.cc.Invoke(ctx, PromptService_PromptDuration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *promptServiceClient) PromptKeyValues(ctx context.Context, in *PromptKeyValuesRequest, opts ...grpc.CallOption) (*PromptKeyValuesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PromptKeyValuesResponse)
+	err := c.cc.Invoke(ctx, PromptService_PromptKeyValues_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *promptServiceClient) PromptOrderedSelect(ctx context.Context, in *PromptOrderedSelectRequest, opts ...grpc.CallOption) (*PromptOrderedSelectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PromptOrderedSelectResponse)
+	err := c.cc.Invoke(ctx, PromptService_PromptOrderedSelect_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *promptServiceClient) PromptSearchable(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[PromptSearchableClientMessage, PromptSearchableServerMessage], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PromptService_ServiceDesc.Streams[0], PromptService_PromptSearchable_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[PromptSearchableClientMessage, PromptSearchableServerMessage]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PromptService_PromptSearchableClient = grpc.BidiStreamingClient[PromptSearchableClientMessage, PromptSearchableServerMessage]
+
+func (c *promptServiceClient) PromptValidated(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[PromptValidatedClientMessage, PromptValidatedServerMessage], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PromptService_ServiceDesc.Streams[1], PromptService_PromptValidated_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[PromptValidatedClientMessage, PromptValidatedServerMessage]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PromptService_PromptValidatedClient = grpc.BidiStreamingClient[PromptValidatedClientMessage, PromptValidatedServerMessage]
+
 func (c *promptServiceClient) PromptSubscriptionResource(ctx context.Context, in *PromptSubscriptionResourceRequest, opts ...grpc.CallOption) (*PromptSubscriptionResourceResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PromptSubscriptionResourceResponse)
@@ -200,6 +393,16 @@ func (c *promptServiceClient) PromptAiDeployment(ctx context.Context, in *Prompt
 	return out, nil
 }
 
+func (c *promptServiceClient) PromptAiSku(ctx context.Context, in *PromptAiSkuRequest, opts ...grpc.CallOption) (*PromptAiSkuResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PromptAiSkuResponse)
+	err := c.cc.Invoke(ctx, PromptService_PromptAiSku_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *promptServiceClient) PromptAiLocationWithQuota(ctx context.Context, in *PromptAiLocationWithQuotaRequest, opts ...grpc.CallOption) (*PromptAiLocationWithQuotaResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PromptAiLocationWithQuotaResponse)
@@ -230,14 +433,62 @@ type PromptServiceServer interface {
 	PromptLocation(context.Context, *PromptLocationRequest) (*PromptLocationResponse, error)
 	// PromptResourceGroup prompts the user to select a resource group.
 	PromptResourceGroup(context.Context, *PromptResourceGroupRequest) (*PromptResourceGroupResponse, error)
+	// PromptAzureScope prompts for a subscription, a location and optionally a resource group in sequence,
+	// and returns the resulting Azure context. Values already set on azure_context.scope are kept and their
+	// prompts are skipped. In no-prompt mode, missing values are taken from the active azd environment.
+	PromptAzureScope(context.Context, *PromptAzureScopeRequest) (*PromptAzureScopeResponse, error)
+	// GetAzureContext returns the subscription, tenant, location and resource group of the active azd environment
+	// without prompting. Values the environment does not set, or all of them when no environment is selected, are empty.
+	GetAzureContext(context.Context, *GetAzureContextRequest) (*GetAzureContextResponse, error)
 	// Confirm prompts the user to confirm an action.
 	Confirm(context.Context, *ConfirmRequest) (*ConfirmResponse, error)
+	// PromptSummaryConfirm displays a read-only summary as an aligned table, then asks the user to confirm.
+	PromptSummaryConfirm(context.Context, *PromptSummaryConfirmRequest) (*PromptSummaryConfirmResponse, error)
+	// PromptDestructiveConfirm guards an irreversible action, such as deleting a resource, by asking the user to type
+	// options.confirmation_phrase exactly. A mismatch is re-prompted until options.max_attempts is reached, and the
+	// action is then reported as not confirmed. options.force confirms without prompting; in no-prompt mode it is
+	// required.
+	PromptDestructiveConfirm(context.Context, *PromptDestructiveConfirmRequest) (*PromptDestructiveConfirmResponse, error)
 	// Prompt prompts the user for text input.
 	Prompt(context.Context, *PromptRequest) (*PromptResponse, error)
 	// Select prompts the user to select an option from a list.
 	Select(context.Context, *SelectRequest) (*SelectResponse, error)
 	// MultiSelect prompts the user to select multiple options from a list.
 	MultiSelect(context.Context, *MultiSelectRequest) (*MultiSelectResponse, error)
+	// PromptTree prompts the user to drill down through a tree of choices one level at a time,
+	// e.g. management group -> subscription, and returns the path of selected values ending at a leaf.
+	// Levels below the root include a "Back" option that returns to the parent level.
+	// In no-prompt mode, options.selected_path must identify a leaf.
+	PromptTree(context.Context, *PromptTreeRequest) (*PromptTreeResponse, error)
+	// PromptPath prompts the user for a file or directory path, with Tab completion of filesystem entries.
+	// The path is validated against the existence constraints in options and the user is re-prompted until it passes.
+	// In no-prompt mode, options.default_value is validated against the same constraints.
+	PromptPath(context.Context, *PromptPathRequest) (*PromptPathResponse, error)
+	// PromptEditor lets the user edit a multi-line value in the editor named by $VISUAL or $EDITOR, seeded with
+	// options.default_value. When no editor is configured or it cannot be found, a multi-line terminal input is used
+	// instead. In no-prompt mode, options.default_value is returned unchanged.
+	PromptEditor(context.Context, *PromptEditorRequest) (*PromptEditorResponse, error)
+	// PromptDuration prompts the user for a Go-style duration such as "30s", "5m" or "1h30m", enforcing the optional
+	// options.min_value and options.max_value bounds. In no-prompt mode, options.default_value is validated against the
+	// same bounds and returned.
+	PromptDuration(context.Context, *PromptDurationRequest) (*PromptDurationResponse, error)
+	// PromptKeyValues prompts the user for key/value pairs, such as tags or environment variables, asking for a key
+	// then a value until the user declines to add another entry. Keys are validated against options.key_pattern.
+	// In no-prompt mode, options.default_values is validated against the same constraints and returned.
+	PromptKeyValues(context.Context, *PromptKeyValuesRequest) (*PromptKeyValuesResponse, error)
+	// PromptOrderedSelect collects an ordered list, such as a fallback region order, by prompting the user to pick one
+	// choice at a time until they are done. Picked choices are not offered again, and the response lists them in the
+	// order they were picked. In no-prompt mode, options.default_values is returned in its order.
+	PromptOrderedSelect(context.Context, *PromptOrderedSelectRequest) (*PromptOrderedSelectResponse, error)
+	// PromptSearchable shows a select prompt whose choices are searched by the extension as the user types, so
+	// selection works over data sets too large to send up front. The extension first sends options, then answers
+	// each query with the matching choices. azd ends the stream with the selected choice.
+	PromptSearchable(grpc.BidiStreamingServer[PromptSearchableClientMessage, PromptSearchableServerMessage]) error
+	// PromptValidated shows a text prompt whose submitted values are validated by the extension, e.g. against live
+	// data such as whether a resource name is available. The extension first sends options, then answers each
+	// validation with a result. A rejected value is shown with the result message and the user is prompted again.
+	// azd ends the stream with the accepted value.
+	PromptValidated(grpc.BidiStreamingServer[PromptValidatedClientMessage, PromptValidatedServerMessage]) error
 	// PromptSubscriptionResource prompts the user to select a resource from a subscription.
 	PromptSubscriptionResource(context.Context, *PromptSubscriptionResourceRequest) (*PromptSubscriptionResourceResponse, error)
 	// PromptResourceGroupResource prompts the user to select a resource from a resource group.
@@ -257,8 +508,14 @@ type PromptServiceServer interface {
 	// This is the primary interactive primitive for model deployment selection.
 	// Effective location is defined by options.locations.
 	// If options.locations is empty, model catalog is considered across subscription locations.
-	// Quota requires exactly one effective location (via options.locations).
+	// Quota requires exactly one effective location (via options.locations). When options.locations is empty,
+	// quota is checked at the location of azure_context, or else of the active azd environment.
 	PromptAiDeployment(context.Context, *PromptAiDeploymentRequest) (*PromptAiDeploymentResponse, error)
+	// PromptAiSku prompts for a SKU and its capacity in one step, for a model version whose SKUs are already known.
+	// Each SKU is listed with its capacity range, and the capacity is prompted within the selected SKU's bounds.
+	// In no-prompt mode, preferred_sku (or the first SKU) is used at its default capacity.
+	// Quota requires location, which defaults to the location of azure_context, or else of the active azd environment.
+	PromptAiSku(context.Context, *PromptAiSkuRequest) (*PromptAiSkuResponse, error)
 	// PromptAiLocationWithQuota prompts for a location filtered by quota requirements.
 	PromptAiLocationWithQuota(context.Context, *PromptAiLocationWithQuotaRequest) (*PromptAiLocationWithQuotaResponse, error)
 	// PromptAiModelLocationWithQuota prompts for a model location and displays remaining quota.
@@ -282,9 +539,21 @@ func (UnimplementedPromptServiceServer) PromptLocation(context.Context, *PromptL
 func (UnimplementedPromptServiceServer) PromptResourceGroup(context.Context, *PromptResourceGroupRequest) (*PromptResourceGroupResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PromptResourceGroup not implemented")
 }
+func (UnimplementedPromptServiceServer) PromptAzureScope(context.Context, *PromptAzureScopeRequest) (*PromptAzureScopeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PromptAzureScope not implemented")
+}
+func (UnimplementedPromptServiceServer) GetAzureContext(context.Context, *GetAzureContextRequest) (*GetAzureContextResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAzureContext not implemented")
+}
 func (UnimplementedPromptServiceServer) Confirm(context.Context, *ConfirmRequest) (*ConfirmResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Confirm not implemented")
 }
+func (UnimplementedPromptServiceServer) PromptSummaryConfirm(context.Context, *PromptSummaryConfirmRequest) (*PromptSummaryConfirmResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PromptSummaryConfirm not implemented")
+}
+func (UnimplementedPromptServiceServer) PromptDestructiveConfirm(context.Context, *PromptDestructiveConfirmRequest) (*PromptDestructiveConfirmResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PromptDestructiveConfirm not implemented")
+}
 func (UnimplementedPromptServiceServer) Prompt(context.Context, *PromptRequest) (*PromptResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Prompt not implemented")
 }
@@ -294,6 +563,30 @@ func (UnimplementedPromptServiceServer) Select(context.Context, *SelectRequest)
 func (UnimplementedPromptServiceServer) MultiSelect(context.Context, *MultiSelectRequest) (*MultiSelectResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method MultiSelect not implemented")
 }
+func (UnimplementedPromptServiceServer) PromptTree(context.Context, *PromptTreeRequest) (*PromptTreeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PromptTree not implemented")
+}
+func (UnimplementedPromptServiceServer) PromptPath(context.Context, *PromptPathRequest) (*PromptPathResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PromptPath not implemented")
+}
+func (UnimplementedPromptServiceServer) PromptEditor(context.Context, *PromptEditorRequest) (*PromptEditorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PromptEditor not implemented")
+}
+func (UnimplementedPromptServiceServer) PromptDuration(context.Context, *PromptDurationRequest) (*PromptDurationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PromptDuration not implemented")
+}
+func (UnimplementedPromptServiceServer) PromptKeyValues(context.Context, *PromptKeyValuesRequest) (*PromptKeyValuesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PromptKeyValues not implemented")
+}
+func (UnimplementedPromptServiceServer) PromptOrderedSelect(context.Context, *PromptOrderedSelectRequest) (*PromptOrderedSelectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PromptOrderedSelect not implemented")
+}
+func (UnimplementedPromptServiceServer) PromptSearchable(grpc.BidiStreamingServer[PromptSearchableClientMessage, PromptSearchableServerMessage]) error {
+	return status.Errorf(codes.Unimplemented, "method PromptSearchable not implemented")
+}
+func (UnimplementedPromptServiceServer) PromptValidated(grpc.BidiStreamingServer[PromptValidatedClientMessage, PromptValidatedServerMessage]) error {
+	return status.Errorf(codes.Unimplemented, "method PromptValidated not implemented")
+}
 func (UnimplementedPromptServiceServer) PromptSubscriptionResource(context.Context, *PromptSubscriptionResourceRequest) (*PromptSubscriptionResourceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PromptSubscriptionResource not implemented")
 }
@@ -306,6 +599,9 @@ func (UnimplementedPromptServiceServer) PromptAiModel(context.Context, *PromptAi
 func (UnimplementedPromptServiceServer) PromptAiDeployment(context.Context, *PromptAiDeploymentRequest) (*PromptAiDeploymentResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PromptAiDeployment not implemented")
 }
+func (UnimplementedPromptServiceServer) PromptAiSku(context.Context, *PromptAiSkuRequest) (*PromptAiSkuResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PromptAiSku not implemented")
+}
 func (UnimplementedPromptServiceServer) PromptAiLocationWithQuota(context.Context, *PromptAiLocationWithQuotaRequest) (*PromptAiLocationWithQuotaResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PromptAiLocationWithQuota not implemented")
 }
@@ -387,6 +683,42 @@ func _PromptService_PromptResourceGroup_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PromptService_PromptAzureScope_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromptAzureScopeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromptServiceServer).PromptAzureScope(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PromptService_PromptAzureScope_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromptServiceServer).PromptAzureScope(ctx, req.(*PromptAzureScopeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PromptService_GetAzureContext_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAzureContextRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromptServiceServer).GetAzureContext(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PromptService_GetAzureContext_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromptServiceServer).GetAzureContext(ctx, req.(*GetAzureContextRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PromptService_Confirm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ConfirmRequest)
 	if err := dec(in); err != nil {
@@ -405,6 +737,42 @@ func _PromptService_Confirm_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PromptService_PromptSummaryConfirm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromptSummaryConfirmRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromptServiceServer).PromptSummaryConfirm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PromptService_PromptSummaryConfirm_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromptServiceServer).PromptSummaryConfirm(ctx, req.(*PromptSummaryConfirmRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PromptService_PromptDestructiveConfirm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromptDestructiveConfirmRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromptServiceServer).PromptDestructiveConfirm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PromptService_PromptDestructiveConfirm_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromptServiceServer).PromptDestructiveConfirm(ctx, req.(*PromptDestructiveConfirmRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PromptService_Prompt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PromptRequest)
 	if err := dec(in); err != nil {
@@ -459,6 +827,128 @@ func _PromptService_MultiSelect_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PromptService_PromptTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromptTreeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromptServiceServer).PromptTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PromptService_PromptTree_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromptServiceServer).PromptTree(ctx, req.(*PromptTreeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PromptService_PromptPath_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromptPathRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromptServiceServer).PromptPath(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PromptService_PromptPath_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromptServiceServer).PromptPath(ctx, req.(*PromptPathRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PromptService_PromptEditor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromptEditorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromptServiceServer).PromptEditor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PromptService_PromptEditor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromptServiceServer).PromptEditor(ctx, req.(*PromptEditorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PromptService_PromptDuration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromptDurationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromptServiceServer).PromptDuration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PromptService_PromptDuration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromptServiceServer).PromptDuration(ctx, req.(*PromptDurationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PromptService_PromptKeyValues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromptKeyValuesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromptServiceServer).PromptKeyValues(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PromptService_PromptKeyValues_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromptServiceServer).PromptKeyValues(ctx, req.(*PromptKeyValuesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PromptService_PromptOrderedSelect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromptOrderedSelectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromptServiceServer).PromptOrderedSelect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PromptService_PromptOrderedSelect_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromptServiceServer).PromptOrderedSelect(ctx, req.(*PromptOrderedSelectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PromptService_PromptSearchable_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PromptServiceServer).PromptSearchable(&grpc.GenericServerStream[PromptSearchableClientMessage, PromptSearchableServerMessage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PromptService_PromptSearchableServer = grpc.BidiStreamingServer[PromptSearchableClientMessage, PromptSearchableServerMessage]
+
+func _PromptService_PromptValidated_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PromptServiceServer).PromptValidated(&grpc.GenericServerStream[PromptValidatedClientMessage, PromptValidatedServerMessage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PromptService_PromptValidatedServer = grpc.BidiStreamingServer[PromptValidatedClientMessage, PromptValidatedServerMessage]
+
 func _PromptService_PromptSubscriptionResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PromptSubscriptionResourceRequest)
 	if err := dec(in); err != nil {
@@ -531,6 +1021,24 @@ func _PromptService_PromptAiDeployment_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PromptService_PromptAiSku_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromptAiSkuRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromptServiceServer).PromptAiSku(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PromptService_PromptAiSku_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromptServiceServer).PromptAiSku(ctx, req.(*PromptAiSkuRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PromptService_PromptAiLocationWithQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PromptAiLocationWithQuotaRequest)
 	if err := dec(in); err != nil {
@@ -586,10 +1094,26 @@ var PromptService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PromptResourceGroup",
 			Handler:    _PromptService_PromptResourceGroup_Handler,
 		},
+		{
+			MethodName: "PromptAzureScope",
+			Handler:    _PromptService_PromptAzureScope_Handler,
+		},
+		{
+			MethodName: "GetAzureContext",
+			Handler:    _PromptService_GetAzureContext_Handler,
+		},
 		{
 			MethodName: "Confirm",
 			Handler:    _PromptService_Confirm_Handler,
 		},
+		{
+			MethodName: "PromptSummaryConfirm",
+			Handler:    _PromptService_PromptSummaryConfirm_Handler,
+		},
+		{
+			MethodName: "PromptDestructiveConfirm",
+			Handler:    _PromptService_PromptDestructiveConfirm_Handler,
+		},
 		{
 			MethodName: "Prompt",
 			Handler:    _PromptService_Prompt_Handler,
@@ -602,6 +1126,30 @@ var PromptService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "MultiSelect",
 			Handler:    _PromptService_MultiSelect_Handler,
 		},
+		{
+			MethodName: "PromptTree",
+			Handler:    _PromptService_PromptTree_Handler,
+		},
+		{
+			MethodName: "PromptPath",
+			Handler:    _PromptService_PromptPath_Handler,
+		},
+		{
+			MethodName: "PromptEditor",
+			Handler:    _PromptService_PromptEditor_Handler,
+		},
+		{
+			MethodName: "PromptDuration",
+			Handler:    _PromptService_PromptDuration_Handler,
+		},
+		{
+			MethodName: "PromptKeyValues",
+			Handler:    _PromptService_PromptKeyValues_Handler,
+		},
+		{
+			MethodName: "PromptOrderedSelect",
+			Handler:    _PromptService_PromptOrderedSelect_Handler,
+		},
 		{
 			MethodName: "PromptSubscriptionResource",
 			Handler:    _PromptService_PromptSubscriptionResource_Handler,
@@ -618,6 +1166,10 @@ var PromptService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PromptAiDeployment",
 			Handler:    _PromptService_PromptAiDeployment_Handler,
 		},
+		{
+			MethodName: "PromptAiSku",
+			Handler:    _PromptService_PromptAiSku_Handler,
+		},
 		{
 			MethodName: "PromptAiLocationWithQuota",
 			Handler:    _PromptService_PromptAiLocationWithQuota_Handler,
@@ -627,6 +1179,19 @@ var PromptService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _PromptService_PromptAiModelLocationWithQuota_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "PromptSearchable",
+			Handler:       _PromptService_PromptSearchable_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "PromptValidated",
+			Handler:       _PromptService_PromptValidated_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "prompt.proto",
 }
@@ -0,0 +1,77 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package azdext
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// SearchFunc returns the choices matching the filter text typed by the user in a searchable prompt.
+type SearchFunc func(ctx context.Context, filter string) ([]*SelectChoice, error)
+
+// PromptSearchable shows a select prompt whose choices are produced by search as the user types, and returns the
+// selected choice.
+//
+// search is called with an empty filter before the prompt is shown and again whenever the filter text changes.
+// An error returned by search cancels the prompt and is returned to the caller.
+func PromptSearchable(
+	ctx context.Context,
+	client PromptServiceClient,
+	options *PromptSearchableOptions,
+	search SearchFunc,
+) (*SelectChoice, error) {
+	if options == nil {
+		return nil, errors.New("options are required")
+	}
+	if search == nil {
+		return nil, errors.New("search is required")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := client.PromptSearchable(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = stream.Send(&PromptSearchableClientMessage{
+		MessageType: &PromptSearchableClientMessage_Options{Options: options},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+
+		if response := msg.GetResponse(); response != nil {
+			return response.Value, nil
+		}
+
+		query := msg.GetQuery()
+		if query == nil {
+			return nil, errors.New("unexpected message from the searchable prompt")
+		}
+
+		choices, err := search(ctx, query.Filter)
+		if err != nil {
+			return nil, fmt.Errorf("searching for %q: %w", query.Filter, err)
+		}
+
+		err = stream.Send(&PromptSearchableClientMessage{
+			MessageType: &PromptSearchableClientMessage_Results{
+				Results: &PromptSearchableResults{QueryId: query.Id, Choices: choices},
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package azdext
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fakeSearchablePromptServer plays the host side of PromptSearchable: it sends a query for each filter, records the
+// results and answers with the first choice of the last results.
+type fakeSearchablePromptServer struct {
+	UnimplementedPromptServiceServer
+
+	filters []string
+	options *PromptSearchableOptions
+	results [][]*SelectChoice
+}
+
+func (s *fakeSearchablePromptServer) PromptSearchable(
+	stream grpc.BidiStreamingServer[PromptSearchableClientMessage, PromptSearchableServerMessage],
+) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	s.options = first.GetOptions()
+
+	for i, filter := range s.filters {
+		err := stream.Send(&PromptSearchableServerMessage{
+			MessageType: &PromptSearchableServerMessage_Query{
+				Query: &PromptSearchableQuery{Id: int32(i + 1), Filter: filter},
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		msg, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if msg.GetResults().GetQueryId() != int32(i+1) {
+			return errors.New("results do not answer the query")
+		}
+		s.results = append(s.results, msg.GetResults().GetChoices())
+	}
+
+	last := s.results[len(s.results)-1]
+	return stream.Send(&PromptSearchableServerMessage{
+		MessageType: &PromptSearchableServerMessage_Response{
+			Response: &PromptSearchableResponse{Value: last[0]},
+		},
+	})
+}
+
+func newSearchablePromptClient(t *testing.T, server PromptServiceServer) PromptServiceClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	RegisterPromptServiceServer(grpcServer, server)
+	go func() { _ = grpcServer.Serve(listener) }()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return NewPromptServiceClient(conn)
+}
+
+func echoSearch(_ context.Context, filter string) ([]*SelectChoice, error) {
+	return []*SelectChoice{
+		{Value: filter, Label: "echo: " + filter},
+		{Value: filter + "-2", Label: "echo: " + filter + "-2"},
+	}, nil
+}
+
+func TestPromptSearchable_EchoSearch(t *testing.T) {
+	server := &fakeSearchablePromptServer{filters: []string{"", "g", "gp"}}
+	client := newSearchablePromptClient(t, server)
+
+	choice, err := PromptSearchable(t.Context(), client, &PromptSearchableOptions{Message: "Pick a model"}, echoSearch)
+	require.NoError(t, err)
+	require.Equal(t, "gp", choice.Value)
+	require.Equal(t, "echo: gp", choice.Label)
+
+	require.Equal(t, "Pick a model", server.options.GetMessage())
+	require.Len(t, server.results, 3)
+	for i, filter := range server.filters {
+		require.Equal(t, filter, server.results[i][0].Value)
+		require.Equal(t, filter+"-2", server.results[i][1].Value)
+	}
+}
+
+func TestPromptSearchable_SearchError(t *testing.T) {
+	server := &fakeSearchablePromptServer{filters: []string{""}}
+	client := newSearchablePromptClient(t, server)
+
+	searchErr := errors.New("catalog unavailable")
+	_, err := PromptSearchable(t.Context(), client, &PromptSearchableOptions{Message: "Pick"}, func(
+		context.Context, string,
+	) ([]*SelectChoice, error) {
+		return nil, searchErr
+	})
+	require.ErrorIs(t, err, searchErr)
+}
+
+func TestPromptSearchable_InvalidArguments(t *testing.T) {
+	_, err := PromptSearchable(t.Context(), nil, nil, echoSearch)
+	require.Error(t, err)
+
+	_, err = PromptSearchable(t.Context(), nil, &PromptSearchableOptions{}, nil)
+	require.Error(t, err)
+}
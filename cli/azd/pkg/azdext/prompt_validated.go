@@ -0,0 +1,78 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package azdext
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ValidateFunc validates a value submitted to a validated prompt. It reports whether the value is valid and, when it
+// is not, the message to show the user, e.g. "The name 'web' is already taken".
+type ValidateFunc func(ctx context.Context, value string) (valid bool, message string, err error)
+
+// PromptValidated shows a text prompt whose submitted values are checked by validate, and returns the accepted value.
+//
+// validate is called for each value the user submits; a rejected value is shown with its message and the user is
+// prompted again. In no-prompt mode it is called once, for the default value. An error returned by validate cancels
+// the prompt and is returned to the caller.
+func PromptValidated(
+	ctx context.Context,
+	client PromptServiceClient,
+	options *PromptOptions,
+	validate ValidateFunc,
+) (string, error) {
+	if options == nil {
+		return "", errors.New("options are required")
+	}
+	if validate == nil {
+		return "", errors.New("validate is required")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := client.PromptValidated(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	err = stream.Send(&PromptValidatedClientMessage{
+		MessageType: &PromptValidatedClientMessage_Options{Options: options},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			return "", err
+		}
+
+		if response := msg.GetResponse(); response != nil {
+			return response.Value, nil
+		}
+
+		validation := msg.GetValidation()
+		if validation == nil {
+			return "", errors.New("unexpected message from the validated prompt")
+		}
+
+		valid, message, err := validate(ctx, validation.Value)
+		if err != nil {
+			return "", fmt.Errorf("validating the prompt value: %w", err)
+		}
+
+		err = stream.Send(&PromptValidatedClientMessage{
+			MessageType: &PromptValidatedClientMessage_Result{
+				Result: &PromptValidationResult{ValidationId: validation.Id, Valid: valid, Message: message},
+			},
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package azdext
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// fakeValidatedPromptServer plays the host side of PromptValidated: it submits each value for validation, records the
+// results and answers with the first accepted value.
+type fakeValidatedPromptServer struct {
+	UnimplementedPromptServiceServer
+
+	values  []string
+	options *PromptOptions
+	results []*PromptValidationResult
+}
+
+func (s *fakeValidatedPromptServer) PromptValidated(
+	stream grpc.BidiStreamingServer[PromptValidatedClientMessage, PromptValidatedServerMessage],
+) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	s.options = first.GetOptions()
+
+	for i, value := range s.values {
+		err := stream.Send(&PromptValidatedServerMessage{
+			MessageType: &PromptValidatedServerMessage_Validation{
+				Validation: &PromptValidation{Id: int32(i + 1), Value: value},
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		msg, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if msg.GetResult().GetValidationId() != int32(i+1) {
+			return errors.New("result does not answer the validation")
+		}
+		s.results = append(s.results, msg.GetResult())
+
+		if msg.GetResult().GetValid() {
+			return stream.Send(&PromptValidatedServerMessage{
+				MessageType: &PromptValidatedServerMessage_Response{
+					Response: &PromptResponse{Value: value},
+				},
+			})
+		}
+	}
+
+	return errors.New("no value was accepted")
+}
+
+// echoValidate rejects "taken", echoing it in the message, and accepts anything else.
+func echoValidate(_ context.Context, value string) (bool, string, error) {
+	if value == "taken" {
+		return false, "rejected: " + value, nil
+	}
+	return true, "", nil
+}
+
+func TestPromptValidated_EchoValidator(t *testing.T) {
+	server := &fakeValidatedPromptServer{values: []string{"taken", "free"}}
+	client := newSearchablePromptClient(t, server)
+
+	value, err := PromptValidated(t.Context(), client, &PromptOptions{Message: "Enter a name"}, echoValidate)
+	require.NoError(t, err)
+	require.Equal(t, "free", value)
+
+	require.Equal(t, "Enter a name", server.options.GetMessage())
+	require.Len(t, server.results, 2)
+	require.False(t, server.results[0].Valid)
+	require.Equal(t, "rejected: taken", server.results[0].Message)
+	require.True(t, server.results[1].Valid)
+}
+
+func TestPromptValidated_ValidateError(t *testing.T) {
+	server := &fakeValidatedPromptServer{values: []string{"name"}}
+	client := newSearchablePromptClient(t, server)
+
+	validateErr := errors.New("name service unavailable")
+	_, err := PromptValidated(t.Context(), client, &PromptOptions{Message: "Enter"}, func(
+		context.Context, string,
+	) (bool, string, error) {
+		return false, "", validateErr
+	})
+	require.ErrorIs(t, err, validateErr)
+}
+
+func TestPromptValidated_InvalidArguments(t *testing.T) {
+	_, err := PromptValidated(t.Context(), nil, nil, echoValidate)
+	require.Error(t, err)
+
+	_, err = PromptValidated(t.Context(), nil, &PromptOptions{}, nil)
+	require.Error(t, err)
+}
@@ -245,6 +245,7 @@ func TestAiErrorConstants(t *testing.T) {
 		AiErrorReasonLocationRequired,
 		AiErrorReasonQuotaLocation,
 		AiErrorReasonModelNotFound,
+		AiErrorReasonModelNotDeployable,
 		AiErrorReasonNoModelsMatch,
 		AiErrorReasonNoDeploymentMatch,
 		AiErrorReasonNoValidSkus,
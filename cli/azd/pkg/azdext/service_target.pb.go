@@ -47,6 +47,7 @@ type ServiceTargetMessage struct {
 	//	*ServiceTargetMessage_PublishResponse
 	//	*ServiceTargetMessage_EndpointsRequest
 	//	*ServiceTargetMessage_EndpointsResponse
+	//	*ServiceTargetMessage_CancelRequest
 	MessageType   isServiceTargetMessage_MessageType `protobuf_oneof:"message_type"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -238,6 +239,15 @@ func (x *ServiceTargetMessage) GetEndpointsResponse() *ServiceTargetEndpointsRes
 	return nil
 }
 
+func (x *ServiceTargetMessage) GetCancelRequest() *ServiceTargetCancelRequest {
+	if x != nil {
+		if x, ok := x.MessageType.(*ServiceTargetMessage_CancelRequest); ok {
+			return x.CancelRequest
+		}
+	}
+	return nil
+}
+
 type isServiceTargetMessage_MessageType interface {
 	isServiceTargetMessage_MessageType()
 }
@@ -302,6 +312,10 @@ type ServiceTargetMessage_EndpointsResponse struct {
 	EndpointsResponse *ServiceTargetEndpointsResponse `protobuf:"bytes,20,opt,name=endpoints_response,json=endpointsResponse,proto3,oneof"`
 }
 
+type ServiceTargetMessage_CancelRequest struct {
+	CancelRequest *ServiceTargetCancelRequest `protobuf:"bytes,21,opt,name=cancel_request,json=cancelRequest,proto3,oneof"`
+}
+
 func (*ServiceTargetMessage_RegisterServiceTargetRequest) isServiceTargetMessage_MessageType() {}
 
 func (*ServiceTargetMessage_RegisterServiceTargetResponse) isServiceTargetMessage_MessageType() {}
@@ -332,6 +346,55 @@ func (*ServiceTargetMessage_EndpointsRequest) isServiceTargetMessage_MessageType
 
 func (*ServiceTargetMessage_EndpointsResponse) isServiceTargetMessage_MessageType() {}
 
+func (*ServiceTargetMessage_CancelRequest) isServiceTargetMessage_MessageType() {}
+
+// ServiceTargetCancelRequest is sent by azd when the caller of an in-flight request cancelled it, so the extension
+// can abort the operation. No response is expected. Extensions that ignore it keep working until the request completes.
+type ServiceTargetCancelRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Id of the request to cancel.
+	RequestId     string `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceTargetCancelRequest) Reset() {
+	*x = ServiceTargetCancelRequest{}
+	mi := &file_service_target_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceTargetCancelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceTargetCancelRequest) ProtoMessage() {}
+
+func (x *ServiceTargetCancelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_target_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceTargetCancelRequest.ProtoReflect.Descriptor instead.
+func (*ServiceTargetCancelRequest) Descriptor() ([]byte, []int) {
+	return file_service_target_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ServiceTargetCancelRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
 // InputParameter
 type ServiceTargetInputParameter struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -344,7 +407,7 @@ type ServiceTargetInputParameter struct {
 
 func (x *ServiceTargetInputParameter) Reset() {
 	*x = ServiceTargetInputParameter{}
-	mi := &file_service_target_proto_msgTypes[1]
+	mi := &file_service_target_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -356,7 +419,7 @@ func (x *ServiceTargetInputParameter) String() string {
 func (*ServiceTargetInputParameter) ProtoMessage() {}
 
 func (x *ServiceTargetInputParameter) ProtoReflect() protoreflect.Message {
-	mi := &file_service_target_proto_msgTypes[1]
+	mi := &file_service_target_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -369,7 +432,7 @@ func (x *ServiceTargetInputParameter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceTargetInputParameter.ProtoReflect.Descriptor instead.
 func (*ServiceTargetInputParameter) Descriptor() ([]byte, []int) {
-	return file_service_target_proto_rawDescGZIP(), []int{1}
+	return file_service_target_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *ServiceTargetInputParameter) GetType() string {
@@ -404,7 +467,7 @@ type ServiceTargetOutputParameter struct {
 
 func (x *ServiceTargetOutputParameter) Reset() {
 	*x = ServiceTargetOutputParameter{}
-	mi := &file_service_target_proto_msgTypes[2]
+	mi := &file_service_target_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -416,7 +479,7 @@ func (x *ServiceTargetOutputParameter) String() string {
 func (*ServiceTargetOutputParameter) ProtoMessage() {}
 
 func (x *ServiceTargetOutputParameter) ProtoReflect() protoreflect.Message {
-	mi := &file_service_target_proto_msgTypes[2]
+	mi := &file_service_target_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -429,7 +492,7 @@ func (x *ServiceTargetOutputParameter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceTargetOutputParameter.ProtoReflect.Descriptor instead.
 func (*ServiceTargetOutputParameter) Descriptor() ([]byte, []int) {
-	return file_service_target_proto_rawDescGZIP(), []int{2}
+	return file_service_target_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *ServiceTargetOutputParameter) GetType() string {
@@ -456,7 +519,7 @@ type ServiceTargetResource struct {
 
 func (x *ServiceTargetResource) Reset() {
 	*x = ServiceTargetResource{}
-	mi := &file_service_target_proto_msgTypes[3]
+	mi := &file_service_target_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -468,7 +531,7 @@ func (x *ServiceTargetResource) String() string {
 func (*ServiceTargetResource) ProtoMessage() {}
 
 func (x *ServiceTargetResource) ProtoReflect() protoreflect.Message {
-	mi := &file_service_target_proto_msgTypes[3]
+	mi := &file_service_target_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -481,7 +544,7 @@ func (x *ServiceTargetResource) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceTargetResource.ProtoReflect.Descriptor instead.
 func (*ServiceTargetResource) Descriptor() ([]byte, []int) {
-	return file_service_target_proto_rawDescGZIP(), []int{3}
+	return file_service_target_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ServiceTargetResource) GetId() string {
@@ -500,7 +563,7 @@ type ServiceTargetInitializeRequest struct {
 
 func (x *ServiceTargetInitializeRequest) Reset() {
 	*x = ServiceTargetInitializeRequest{}
-	mi := &file_service_target_proto_msgTypes[4]
+	mi := &file_service_target_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -512,7 +575,7 @@ func (x *ServiceTargetInitializeRequest) String() string {
 func (*ServiceTargetInitializeRequest) ProtoMessage() {}
 
 func (x *ServiceTargetInitializeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_service_target_proto_msgTypes[4]
+	mi := &file_service_target_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -525,7 +588,7 @@ func (x *ServiceTargetInitializeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceTargetInitializeRequest.ProtoReflect.Descriptor instead.
 func (*ServiceTargetInitializeRequest) Descriptor() ([]byte, []int) {
-	return file_service_target_proto_rawDescGZIP(), []int{4}
+	return file_service_target_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ServiceTargetInitializeRequest) GetServiceConfig() *ServiceConfig {
@@ -543,7 +606,7 @@ type ServiceTargetInitializeResponse struct {
 
 func (x *ServiceTargetInitializeResponse) Reset() {
 	*x = ServiceTargetInitializeResponse{}
-	mi := &file_service_target_proto_msgTypes[5]
+	mi := &file_service_target_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -555,7 +618,7 @@ func (x *ServiceTargetInitializeResponse) String() string {
 func (*ServiceTargetInitializeResponse) ProtoMessage() {}
 
 func (x *ServiceTargetInitializeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_service_target_proto_msgTypes[5]
+	mi := &file_service_target_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -568,7 +631,7 @@ func (x *ServiceTargetInitializeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceTargetInitializeResponse.ProtoReflect.Descriptor instead.
 func (*ServiceTargetInitializeResponse) Descriptor() ([]byte, []int) {
-	return file_service_target_proto_rawDescGZIP(), []int{5}
+	return file_service_target_proto_rawDescGZIP(), []int{6}
 }
 
 // Core options and result wrappers
@@ -586,7 +649,7 @@ type ServiceTargetOptions struct {
 
 func (x *ServiceTargetOptions) Reset() {
 	*x = ServiceTargetOptions{}
-	mi := &file_service_target_proto_msgTypes[6]
+	mi := &file_service_target_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -598,7 +661,7 @@ func (x *ServiceTargetOptions) String() string {
 func (*ServiceTargetOptions) ProtoMessage() {}
 
 func (x *ServiceTargetOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_service_target_proto_msgTypes[6]
+	mi := &file_service_target_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -611,7 +674,7 @@ func (x *ServiceTargetOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceTargetOptions.ProtoReflect.Descriptor instead.
 func (*ServiceTargetOptions) Descriptor() ([]byte, []int) {
-	return file_service_target_proto_rawDescGZIP(), []int{6}
+	return file_service_target_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ServiceTargetOptions) GetProvider() string {
@@ -665,7 +728,7 @@ type RegisterServiceTargetRequest struct {
 
 func (x *RegisterServiceTargetRequest) Reset() {
 	*x = RegisterServiceTargetRequest{}
-	mi := &file_service_target_proto_msgTypes[7]
+	mi := &file_service_target_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -677,7 +740,7 @@ func (x *RegisterServiceTargetRequest) String() string {
 func (*RegisterServiceTargetRequest) ProtoMessage() {}
 
 func (x *RegisterServiceTargetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_service_target_proto_msgTypes[7]
+	mi := &file_service_target_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -690,7 +753,7 @@ func (x *RegisterServiceTargetRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterServiceTargetRequest.ProtoReflect.Descriptor instead.
 func (*RegisterServiceTargetRequest) Descriptor() ([]byte, []int) {
-	return file_service_target_proto_rawDescGZIP(), []int{7}
+	return file_service_target_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *RegisterServiceTargetRequest) GetHost() string {
@@ -708,7 +771,7 @@ type RegisterServiceTargetResponse struct {
 
 func (x *RegisterServiceTargetResponse) Reset() {
 	*x = RegisterServiceTargetResponse{}
-	mi := &file_service_target_proto_msgTypes[8]
+	mi := &file_service_target_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -720,7 +783,7 @@ func (x *RegisterServiceTargetResponse) String() string {
 func (*RegisterServiceTargetResponse) ProtoMessage() {}
 
 func (x *RegisterServiceTargetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_service_target_proto_msgTypes[8]
+	mi := &file_service_target_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -733,7 +796,7 @@ func (x *RegisterServiceTargetResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterServiceTargetResponse.ProtoReflect.Descriptor instead.
 func (*RegisterServiceTargetResponse) Descriptor() ([]byte, []int) {
-	return file_service_target_proto_rawDescGZIP(), []int{8}
+	return file_service_target_proto_rawDescGZIP(), []int{9}
 }
 
 // GetTargetResource request and response
@@ -753,7 +816,7 @@ type GetTargetResourceRequest struct {
 
 func (x *GetTargetResourceRequest) Reset() {
 	*x = GetTargetResourceRequest{}
-	mi := &file_service_target_proto_msgTypes[9]
+	mi := &file_service_target_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -765,7 +828,7 @@ func (x *GetTargetResourceRequest) String() string {
 func (*GetTargetResourceRequest) ProtoMessage() {}
 
 func (x *GetTargetResourceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_service_target_proto_msgTypes[9]
+	mi := &file_service_target_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -778,7 +841,7 @@ func (x *GetTargetResourceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTargetResourceRequest.ProtoReflect.Descriptor instead.
 func (*GetTargetResourceRequest) Descriptor() ([]byte, []int) {
-	return file_service_target_proto_rawDescGZIP(), []int{9}
+	return file_service_target_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GetTargetResourceRequest) GetSubscriptionId() string {
@@ -818,7 +881,7 @@ type GetTargetResourceResponse struct {
 
 func (x *GetTargetResourceResponse) Reset() {
 	*x = GetTargetResourceResponse{}
-	mi := &file_service_target_proto_msgTypes[10]
+	mi := &file_service_target_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -830,7 +893,7 @@ func (x *GetTargetResourceResponse) String() string {
 func (*GetTargetResourceResponse) ProtoMessage() {}
 
 func (x *GetTargetResourceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_service_target_proto_msgTypes[10]
+	mi := &file_service_target_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -843,7 +906,7 @@ func (x *GetTargetResourceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTargetResourceResponse.ProtoReflect.Descriptor instead.
 func (*GetTargetResourceResponse) Descriptor() ([]byte, []int) {
-	return file_service_target_proto_rawDescGZIP(), []int{10}
+	return file_service_target_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetTargetResourceResponse) GetTargetResource() *TargetResource {
@@ -867,7 +930,7 @@ type TargetResource struct {
 
 func (x *TargetResource) Reset() {
 	*x = TargetResource{}
-	mi := &file_service_target_proto_msgTypes[11]
+	mi := &file_service_target_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -879,7 +942,7 @@ func (x *TargetResource) String() string {
 func (*TargetResource) ProtoMessage() {}
 
 func (x *TargetResource) ProtoReflect() protoreflect.Message {
-	mi := &file_service_target_proto_msgTypes[11]
+	mi := &file_service_target_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -892,7 +955,7 @@ func (x *TargetResource) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TargetResource.ProtoReflect.Descriptor instead.
 func (*TargetResource) Descriptor() ([]byte, []int) {
-	return file_service_target_proto_rawDescGZIP(), []int{11}
+	return file_service_target_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *TargetResource) GetSubscriptionId() string {
@@ -942,7 +1005,7 @@ type ServiceTargetDeployRequest struct {
 
 func (x *ServiceTargetDeployRequest) Reset() {
 	*x = ServiceTargetDeployRequest{}
-	mi := &file_service_target_proto_msgTypes[12]
+	mi := &file_service_target_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -954,7 +1017,7 @@ func (x *ServiceTargetDeployRequest) String() string {
 func (*ServiceTargetDeployRequest) ProtoMessage() {}
 
 func (x *ServiceTargetDeployRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_service_target_proto_msgTypes[12]
+	mi := &file_service_target_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -967,7 +1030,7 @@ func (x *ServiceTargetDeployRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceTargetDeployRequest.ProtoReflect.Descriptor instead.
 func (*ServiceTargetDeployRequest) Descriptor() ([]byte, []int) {
-	return file_service_target_proto_rawDescGZIP(), []int{12}
+	return file_service_target_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ServiceTargetDeployRequest) GetServiceConfig() *ServiceConfig {
@@ -1000,7 +1063,7 @@ type ServiceTargetDeployResponse struct {
 
 func (x *ServiceTargetDeployResponse) Reset() {
 	*x = ServiceTargetDeployResponse{}
-	mi := &file_service_target_proto_msgTypes[13]
+	mi := &file_service_target_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1012,7 +1075,7 @@ func (x *ServiceTargetDeployResponse) String() string {
 func (*ServiceTargetDeployResponse) ProtoMessage() {}
 
 func (x *ServiceTargetDeployResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_service_target_proto_msgTypes[13]
+	mi := &file_service_target_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1025,7 +1088,7 @@ func (x *ServiceTargetDeployResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceTargetDeployResponse.ProtoReflect.Descriptor instead.
 func (*ServiceTargetDeployResponse) Descriptor() ([]byte, []int) {
-	return file_service_target_proto_rawDescGZIP(), []int{13}
+	return file_service_target_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ServiceTargetDeployResponse) GetResult() *ServiceDeployResult {
@@ -1045,7 +1108,7 @@ type ServicePackageResult struct {
 
 func (x *ServicePackageResult) Reset() {
 	*x = ServicePackageResult{}
-	mi := &file_service_target_proto_msgTypes[14]
+	mi := &file_service_target_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1057,7 +1120,7 @@ func (x *ServicePackageResult) String() string {
 func (*ServicePackageResult) ProtoMessage() {}
 
 func (x *ServicePackageResult) ProtoReflect() protoreflect.Message {
-	mi := &file_service_target_proto_msgTypes[14]
+	mi := &file_service_target_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1070,7 +1133,7 @@ func (x *ServicePackageResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServicePackageResult.ProtoReflect.Descriptor instead.
 func (*ServicePackageResult) Descriptor() ([]byte, []int) {
-	return file_service_target_proto_rawDescGZIP(), []int{14}
+	return file_service_target_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *ServicePackageResult) GetArtifacts() []*Artifact {
@@ -1090,7 +1153,7 @@ type ServicePublishResult struct {
 
 func (x *ServicePublishResult) Reset() {
 	*x = ServicePublishResult{}
-	mi := &file_service_target_proto_msgTypes[15]
+	mi := &file_service_target_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1102,7 +1165,7 @@ func (x *ServicePublishResult) String() string {
 func (*ServicePublishResult) ProtoMessage() {}
 
 func (x *ServicePublishResult) ProtoReflect() protoreflect.Message {
-	mi := &file_service_target_proto_msgTypes[15]
+	mi := &file_service_target_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1115,7 +1178,7 @@ func (x *ServicePublishResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServicePublishResult.ProtoReflect.Descriptor instead.
 func (*ServicePublishResult) Descriptor() ([]byte, []int) {
-	return file_service_target_proto_rawDescGZIP(), []int{15}
+	return file_service_target_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *ServicePublishResult) GetArtifacts() []*Artifact {
@@ -1127,15 +1190,17 @@ func (x *ServicePublishResult) GetArtifacts() []*Artifact {
 
 // ServiceDeployResult represents the result of a deployment operation
 type ServiceDeployResult struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Artifacts     []*Artifact            `protobuf:"bytes,1,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Artifacts []*Artifact            `protobuf:"bytes,1,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	// Optional: endpoints the deployment exposes, reported as endpoint artifacts.
+	Endpoints     []*ServiceEndpoint `protobuf:"bytes,2,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ServiceDeployResult) Reset() {
 	*x = ServiceDeployResult{}
-	mi := &file_service_target_proto_msgTypes[16]
+	mi := &file_service_target_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1147,7 +1212,7 @@ func (x *ServiceDeployResult) String() string {
 func (*ServiceDeployResult) ProtoMessage() {}
 
 func (x *ServiceDeployResult) ProtoReflect() protoreflect.Message {
-	mi := &file_service_target_proto_msgTypes[16]
+	mi := &file_service_target_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1160,7 +1225,7 @@ func (x *ServiceDeployResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceDeployResult.ProtoReflect.Descriptor instead.
 func (*ServiceDeployResult) Descriptor() ([]byte, []int) {
-	return file_service_target_proto_rawDescGZIP(), []int{16}
+	return file_service_target_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *ServiceDeployResult) GetArtifacts() []*Artifact {
@@ -1170,6 +1235,74 @@ func (x *ServiceDeployResult) GetArtifacts() []*Artifact {
 	return nil
 }
 
+func (x *ServiceDeployResult) GetEndpoints() []*ServiceEndpoint {
+	if x != nil {
+		return x.Endpoints
+	}
+	return nil
+}
+
+// ServiceEndpoint is an endpoint a service exposes, with an optional display label and role.
+type ServiceEndpoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`     // Required: the endpoint URL
+	Label         string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"` // Optional: display name, e.g. "Admin portal"
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`   // Optional: what the endpoint is for, e.g. "primary", "health" or "admin"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceEndpoint) Reset() {
+	*x = ServiceEndpoint{}
+	mi := &file_service_target_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceEndpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceEndpoint) ProtoMessage() {}
+
+func (x *ServiceEndpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_service_target_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceEndpoint.ProtoReflect.Descriptor instead.
+func (*ServiceEndpoint) Descriptor() ([]byte, []int) {
+	return file_service_target_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ServiceEndpoint) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ServiceEndpoint) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *ServiceEndpoint) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
 // ServiceTargetPackageRequest represents a request to package a service
 type ServiceTargetPackageRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
@@ -1181,7 +1314,7 @@ type ServiceTargetPackageRequest struct {
 
 func (x *ServiceTargetPackageRequest) Reset() {
 	*x = ServiceTargetPackageRequest{}
-	mi := &file_service_target_proto_msgTypes[17]
+	mi := &file_service_target_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1193,7 +1326,7 @@ func (x *ServiceTargetPackageRequest) String() string {
 func (*ServiceTargetPackageRequest) ProtoMessage() {}
 
 func (x *ServiceTargetPackageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_service_target_proto_msgTypes[17]
+	mi := &file_service_target_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1206,7 +1339,7 @@ func (x *ServiceTargetPackageRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceTargetPackageRequest.ProtoReflect.Descriptor instead.
 func (*ServiceTargetPackageRequest) Descriptor() ([]byte, []int) {
-	return file_service_target_proto_rawDescGZIP(), []int{17}
+	return file_service_target_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *ServiceTargetPackageRequest) GetServiceConfig() *ServiceConfig {
@@ -1232,7 +1365,7 @@ type ServiceTargetPackageResponse struct {
 
 func (x *ServiceTargetPackageResponse) Reset() {
 	*x = ServiceTargetPackageResponse{}
-	mi := &file_service_target_proto_msgTypes[18]
+	mi := &file_service_target_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1244,7 +1377,7 @@ func (x *ServiceTargetPackageResponse) String() string {
 func (*ServiceTargetPackageResponse) ProtoMessage() {}
 
 func (x *ServiceTargetPackageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_service_target_proto_msgTypes[18]
+	mi := &file_service_target_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1257,7 +1390,7 @@ func (x *ServiceTargetPackageResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceTargetPackageResponse.ProtoReflect.Descriptor instead.
 func (*ServiceTargetPackageResponse) Descriptor() ([]byte, []int) {
-	return file_service_target_proto_rawDescGZIP(), []int{18}
+	return file_service_target_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *ServiceTargetPackageResponse) GetResult() *ServicePackageResult {
@@ -1280,7 +1413,7 @@ type ServiceTargetPublishRequest struct {
 
 func (x *ServiceTargetPublishRequest) Reset() {
 	*x = ServiceTargetPublishRequest{}
-	mi := &file_service_target_proto_msgTypes[19]
+	mi := &file_service_target_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1292,7 +1425,7 @@ func (x *ServiceTargetPublishRequest) String() string {
 func (*ServiceTargetPublishRequest) ProtoMessage() {}
 
 func (x *ServiceTargetPublishRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_service_target_proto_msgTypes[19]
+	mi := &file_service_target_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1305,7 +1438,7 @@ func (x *ServiceTargetPublishRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceTargetPublishRequest.ProtoReflect.Descriptor instead.
 func (*ServiceTargetPublishRequest) Descriptor() ([]byte, []int) {
-	return file_service_target_proto_rawDescGZIP(), []int{19}
+	return file_service_target_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *ServiceTargetPublishRequest) GetServiceConfig() *ServiceConfig {
@@ -1345,7 +1478,7 @@ type ServiceTargetPublishResponse struct {
 
 func (x *ServiceTargetPublishResponse) Reset() {
 	*x = ServiceTargetPublishResponse{}
-	mi := &file_service_target_proto_msgTypes[20]
+	mi := &file_service_target_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1357,7 +1490,7 @@ func (x *ServiceTargetPublishResponse) String() string {
 func (*ServiceTargetPublishResponse) ProtoMessage() {}
 
 func (x *ServiceTargetPublishResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_service_target_proto_msgTypes[20]
+	mi := &file_service_target_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1370,7 +1503,7 @@ func (x *ServiceTargetPublishResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceTargetPublishResponse.ProtoReflect.Descriptor instead.
 func (*ServiceTargetPublishResponse) Descriptor() ([]byte, []int) {
-	return file_service_target_proto_rawDescGZIP(), []int{20}
+	return file_service_target_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *ServiceTargetPublishResponse) GetResult() *ServicePublishResult {
@@ -1391,7 +1524,7 @@ type PublishOptions struct {
 
 func (x *PublishOptions) Reset() {
 	*x = PublishOptions{}
-	mi := &file_service_target_proto_msgTypes[21]
+	mi := &file_service_target_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1403,7 +1536,7 @@ func (x *PublishOptions) String() string {
 func (*PublishOptions) ProtoMessage() {}
 
 func (x *PublishOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_service_target_proto_msgTypes[21]
+	mi := &file_service_target_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1416,7 +1549,7 @@ func (x *PublishOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PublishOptions.ProtoReflect.Descriptor instead.
 func (*PublishOptions) Descriptor() ([]byte, []int) {
-	return file_service_target_proto_rawDescGZIP(), []int{21}
+	return file_service_target_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *PublishOptions) GetImage() string {
@@ -1437,7 +1570,7 @@ type ServiceTargetEndpointsRequest struct {
 
 func (x *ServiceTargetEndpointsRequest) Reset() {
 	*x = ServiceTargetEndpointsRequest{}
-	mi := &file_service_target_proto_msgTypes[22]
+	mi := &file_service_target_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1449,7 +1582,7 @@ func (x *ServiceTargetEndpointsRequest) String() string {
 func (*ServiceTargetEndpointsRequest) ProtoMessage() {}
 
 func (x *ServiceTargetEndpointsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_service_target_proto_msgTypes[22]
+	mi := &file_service_target_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1462,7 +1595,7 @@ func (x *ServiceTargetEndpointsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceTargetEndpointsRequest.ProtoReflect.Descriptor instead.
 func (*ServiceTargetEndpointsRequest) Descriptor() ([]byte, []int) {
-	return file_service_target_proto_rawDescGZIP(), []int{22}
+	return file_service_target_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *ServiceTargetEndpointsRequest) GetServiceConfig() *ServiceConfig {
@@ -1479,16 +1612,19 @@ func (x *ServiceTargetEndpointsRequest) GetTargetResource() *TargetResource {
 	return nil
 }
 
+// ServiceTargetEndpointsResponse lists the endpoints of a service. Extensions may return bare URLs, labeled
+// endpoints, or both; the endpoint with role "primary" is treated as the service's main URL.
 type ServiceTargetEndpointsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Endpoints     []string               `protobuf:"bytes,1,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Endpoints        []string               `protobuf:"bytes,1,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	LabeledEndpoints []*ServiceEndpoint     `protobuf:"bytes,2,rep,name=labeled_endpoints,json=labeledEndpoints,proto3" json:"labeled_endpoints,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *ServiceTargetEndpointsResponse) Reset() {
 	*x = ServiceTargetEndpointsResponse{}
-	mi := &file_service_target_proto_msgTypes[23]
+	mi := &file_service_target_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1500,7 +1636,7 @@ func (x *ServiceTargetEndpointsResponse) String() string {
 func (*ServiceTargetEndpointsResponse) ProtoMessage() {}
 
 func (x *ServiceTargetEndpointsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_service_target_proto_msgTypes[23]
+	mi := &file_service_target_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1513,7 +1649,7 @@ func (x *ServiceTargetEndpointsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceTargetEndpointsResponse.ProtoReflect.Descriptor instead.
 func (*ServiceTargetEndpointsResponse) Descriptor() ([]byte, []int) {
-	return file_service_target_proto_rawDescGZIP(), []int{23}
+	return file_service_target_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *ServiceTargetEndpointsResponse) GetEndpoints() []string {
@@ -1523,19 +1659,30 @@ func (x *ServiceTargetEndpointsResponse) GetEndpoints() []string {
 	return nil
 }
 
-// ServiceTargetProgressMessage represents a progress update from an extension
+func (x *ServiceTargetEndpointsResponse) GetLabeledEndpoints() []*ServiceEndpoint {
+	if x != nil {
+		return x.LabeledEndpoints
+	}
+	return nil
+}
+
+// ServiceTargetProgressMessage represents a progress update from an extension.
+// Only message is required; phase, percent_complete and detail are optional structure for long operations.
 type ServiceTargetProgressMessage struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RequestId     string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Timestamp     int64                  `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Unix timestamp in milliseconds
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	RequestId       string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Message         string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Timestamp       int64                  `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                                          // Unix timestamp in milliseconds
+	Phase           string                 `protobuf:"bytes,4,opt,name=phase,proto3" json:"phase,omitempty"`                                                   // stage of the operation, e.g. "upload"; empty when not reported
+	PercentComplete *int32                 `protobuf:"varint,5,opt,name=percent_complete,json=percentComplete,proto3,oneof" json:"percent_complete,omitempty"` // 0-100; unset when not reported
+	Detail          string                 `protobuf:"bytes,6,opt,name=detail,proto3" json:"detail,omitempty"`                                                 // additional detail, e.g. the file being uploaded
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *ServiceTargetProgressMessage) Reset() {
 	*x = ServiceTargetProgressMessage{}
-	mi := &file_service_target_proto_msgTypes[24]
+	mi := &file_service_target_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1547,7 +1694,7 @@ func (x *ServiceTargetProgressMessage) String() string {
 func (*ServiceTargetProgressMessage) ProtoMessage() {}
 
 func (x *ServiceTargetProgressMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_service_target_proto_msgTypes[24]
+	mi := &file_service_target_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1560,7 +1707,7 @@ func (x *ServiceTargetProgressMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceTargetProgressMessage.ProtoReflect.Descriptor instead.
 func (*ServiceTargetProgressMessage) Descriptor() ([]byte, []int) {
-	return file_service_target_proto_rawDescGZIP(), []int{24}
+	return file_service_target_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *ServiceTargetProgressMessage) GetRequestId() string {
@@ -1584,11 +1731,32 @@ func (x *ServiceTargetProgressMessage) GetTimestamp() int64 {
 	return 0
 }
 
+func (x *ServiceTargetProgressMessage) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *ServiceTargetProgressMessage) GetPercentComplete() int32 {
+	if x != nil && x.PercentComplete != nil {
+		return *x.PercentComplete
+	}
+	return 0
+}
+
+func (x *ServiceTargetProgressMessage) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
 var File_service_target_proto protoreflect.FileDescriptor
 
 const file_service_target_proto_rawDesc = "" +
 	"\n" +
-	"\x14service_target.proto\x12\x06azdext\x1a$include/google/protobuf/struct.proto\x1a\fmodels.proto\x1a\ferrors.proto\"\xb7\v\n" +
+	"\x14service_target.proto\x12\x06azdext\x1a$include/google/protobuf/struct.proto\x1a\fmodels.proto\x1a\ferrors.proto\"\x84\f\n" +
 	"\x14ServiceTargetMessage\x12\x1d\n" +
 	"\n" +
 	"request_id\x18\x01 \x01(\tR\trequestId\x12,\n" +
@@ -1608,8 +1776,12 @@ const file_service_target_proto_rawDesc = "" +
 	"\x0fpublish_request\x18\x11 \x01(\v2#.azdext.ServiceTargetPublishRequestH\x00R\x0epublishRequest\x12Q\n" +
 	"\x10publish_response\x18\x12 \x01(\v2$.azdext.ServiceTargetPublishResponseH\x00R\x0fpublishResponse\x12T\n" +
 	"\x11endpoints_request\x18\x13 \x01(\v2%.azdext.ServiceTargetEndpointsRequestH\x00R\x10endpointsRequest\x12W\n" +
-	"\x12endpoints_response\x18\x14 \x01(\v2&.azdext.ServiceTargetEndpointsResponseH\x00R\x11endpointsResponseB\x0e\n" +
-	"\fmessage_type\"l\n" +
+	"\x12endpoints_response\x18\x14 \x01(\v2&.azdext.ServiceTargetEndpointsResponseH\x00R\x11endpointsResponse\x12K\n" +
+	"\x0ecancel_request\x18\x15 \x01(\v2\".azdext.ServiceTargetCancelRequestH\x00R\rcancelRequestB\x0e\n" +
+	"\fmessage_type\";\n" +
+	"\x1aServiceTargetCancelRequest\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\"l\n" +
 	"\x1bServiceTargetInputParameter\x12\x12\n" +
 	"\x04type\x18\x01 \x01(\tR\x04type\x12#\n" +
 	"\rdefault_value\x18\x02 \x01(\tR\fdefaultValue\x12\x14\n" +
@@ -1660,9 +1832,14 @@ const file_service_target_proto_rawDesc = "" +
 	"\x14ServicePackageResult\x12.\n" +
 	"\tartifacts\x18\x01 \x03(\v2\x10.azdext.ArtifactR\tartifacts\"F\n" +
 	"\x14ServicePublishResult\x12.\n" +
-	"\tartifacts\x18\x01 \x03(\v2\x10.azdext.ArtifactR\tartifacts\"E\n" +
+	"\tartifacts\x18\x01 \x03(\v2\x10.azdext.ArtifactR\tartifacts\"|\n" +
 	"\x13ServiceDeployResult\x12.\n" +
-	"\tartifacts\x18\x01 \x03(\v2\x10.azdext.ArtifactR\tartifacts\"\x9c\x01\n" +
+	"\tartifacts\x18\x01 \x03(\v2\x10.azdext.ArtifactR\tartifacts\x125\n" +
+	"\tendpoints\x18\x02 \x03(\v2\x17.azdext.ServiceEndpointR\tendpoints\"M\n" +
+	"\x0fServiceEndpoint\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\"\x9c\x01\n" +
 	"\x1bServiceTargetPackageRequest\x12<\n" +
 	"\x0eservice_config\x18\x01 \x01(\v2\x15.azdext.ServiceConfigR\rserviceConfig\x12?\n" +
 	"\x0fservice_context\x18\x02 \x01(\v2\x16.azdext.ServiceContextR\x0eserviceContext\"T\n" +
@@ -1679,14 +1856,19 @@ const file_service_target_proto_rawDesc = "" +
 	"\x05image\x18\x01 \x01(\tR\x05image\"\x9e\x01\n" +
 	"\x1dServiceTargetEndpointsRequest\x12<\n" +
 	"\x0eservice_config\x18\x01 \x01(\v2\x15.azdext.ServiceConfigR\rserviceConfig\x12?\n" +
-	"\x0ftarget_resource\x18\x02 \x01(\v2\x16.azdext.TargetResourceR\x0etargetResource\">\n" +
+	"\x0ftarget_resource\x18\x02 \x01(\v2\x16.azdext.TargetResourceR\x0etargetResource\"\x84\x01\n" +
 	"\x1eServiceTargetEndpointsResponse\x12\x1c\n" +
-	"\tendpoints\x18\x01 \x03(\tR\tendpoints\"u\n" +
+	"\tendpoints\x18\x01 \x03(\tR\tendpoints\x12D\n" +
+	"\x11labeled_endpoints\x18\x02 \x03(\v2\x17.azdext.ServiceEndpointR\x10labeledEndpoints\"\xe8\x01\n" +
 	"\x1cServiceTargetProgressMessage\x12\x1d\n" +
 	"\n" +
 	"request_id\x18\x01 \x01(\tR\trequestId\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1c\n" +
-	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp2`\n" +
+	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\x12\x14\n" +
+	"\x05phase\x18\x04 \x01(\tR\x05phase\x12.\n" +
+	"\x10percent_complete\x18\x05 \x01(\x05H\x00R\x0fpercentComplete\x88\x01\x01\x12\x16\n" +
+	"\x06detail\x18\x06 \x01(\tR\x06detailB\x13\n" +
+	"\x11_percent_complete2`\n" +
 	"\x14ServiceTargetService\x12H\n" +
 	"\x06Stream\x12\x1c.azdext.ServiceTargetMessage\x1a\x1c.azdext.ServiceTargetMessage(\x010\x01B/Z-github.com/azure/azure-dev/cli/azd/pkg/azdextb\x06proto3"
 
@@ -1702,89 +1884,94 @@ func file_service_target_proto_rawDescGZIP() []byte {
 	return file_service_target_proto_rawDescData
 }
 
-var file_service_target_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
+var file_service_target_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
 var file_service_target_proto_goTypes = []any{
 	(*ServiceTargetMessage)(nil),            // 0: azdext.ServiceTargetMessage
-	(*ServiceTargetInputParameter)(nil),     // 1: azdext.ServiceTargetInputParameter
-	(*ServiceTargetOutputParameter)(nil),    // 2: azdext.ServiceTargetOutputParameter
-	(*ServiceTargetResource)(nil),           // 3: azdext.ServiceTargetResource
-	(*ServiceTargetInitializeRequest)(nil),  // 4: azdext.ServiceTargetInitializeRequest
-	(*ServiceTargetInitializeResponse)(nil), // 5: azdext.ServiceTargetInitializeResponse
-	(*ServiceTargetOptions)(nil),            // 6: azdext.ServiceTargetOptions
-	(*RegisterServiceTargetRequest)(nil),    // 7: azdext.RegisterServiceTargetRequest
-	(*RegisterServiceTargetResponse)(nil),   // 8: azdext.RegisterServiceTargetResponse
-	(*GetTargetResourceRequest)(nil),        // 9: azdext.GetTargetResourceRequest
-	(*GetTargetResourceResponse)(nil),       // 10: azdext.GetTargetResourceResponse
-	(*TargetResource)(nil),                  // 11: azdext.TargetResource
-	(*ServiceTargetDeployRequest)(nil),      // 12: azdext.ServiceTargetDeployRequest
-	(*ServiceTargetDeployResponse)(nil),     // 13: azdext.ServiceTargetDeployResponse
-	(*ServicePackageResult)(nil),            // 14: azdext.ServicePackageResult
-	(*ServicePublishResult)(nil),            // 15: azdext.ServicePublishResult
-	(*ServiceDeployResult)(nil),             // 16: azdext.ServiceDeployResult
-	(*ServiceTargetPackageRequest)(nil),     // 17: azdext.ServiceTargetPackageRequest
-	(*ServiceTargetPackageResponse)(nil),    // 18: azdext.ServiceTargetPackageResponse
-	(*ServiceTargetPublishRequest)(nil),     // 19: azdext.ServiceTargetPublishRequest
-	(*ServiceTargetPublishResponse)(nil),    // 20: azdext.ServiceTargetPublishResponse
-	(*PublishOptions)(nil),                  // 21: azdext.PublishOptions
-	(*ServiceTargetEndpointsRequest)(nil),   // 22: azdext.ServiceTargetEndpointsRequest
-	(*ServiceTargetEndpointsResponse)(nil),  // 23: azdext.ServiceTargetEndpointsResponse
-	(*ServiceTargetProgressMessage)(nil),    // 24: azdext.ServiceTargetProgressMessage
-	nil,                                     // 25: azdext.ServiceTargetOptions.DeploymentStacksEntry
-	nil,                                     // 26: azdext.TargetResource.MetadataEntry
-	(*ExtensionError)(nil),                  // 27: azdext.ExtensionError
-	(*ServiceConfig)(nil),                   // 28: azdext.ServiceConfig
-	(*structpb.Struct)(nil),                 // 29: google.protobuf.Struct
-	(*ServiceContext)(nil),                  // 30: azdext.ServiceContext
-	(*Artifact)(nil),                        // 31: azdext.Artifact
+	(*ServiceTargetCancelRequest)(nil),      // 1: azdext.ServiceTargetCancelRequest
+	(*ServiceTargetInputParameter)(nil),     // 2: azdext.ServiceTargetInputParameter
+	(*ServiceTargetOutputParameter)(nil),    // 3: azdext.ServiceTargetOutputParameter
+	(*ServiceTargetResource)(nil),           // 4: azdext.ServiceTargetResource
+	(*ServiceTargetInitializeRequest)(nil),  // 5: azdext.ServiceTargetInitializeRequest
+	(*ServiceTargetInitializeResponse)(nil), // 6: azdext.ServiceTargetInitializeResponse
+	(*ServiceTargetOptions)(nil),            // 7: azdext.ServiceTargetOptions
+	(*RegisterServiceTargetRequest)(nil),    // 8: azdext.RegisterServiceTargetRequest
+	(*RegisterServiceTargetResponse)(nil),   // 9: azdext.RegisterServiceTargetResponse
+	(*GetTargetResourceRequest)(nil),        // 10: azdext.GetTargetResourceRequest
+	(*GetTargetResourceResponse)(nil),       // 11: azdext.GetTargetResourceResponse
+	(*TargetResource)(nil),                  // 12: azdext.TargetResource
+	(*ServiceTargetDeployRequest)(nil),      // 13: azdext.ServiceTargetDeployRequest
+	(*ServiceTargetDeployResponse)(nil),     // 14: azdext.ServiceTargetDeployResponse
+	(*ServicePackageResult)(nil),            // 15: azdext.ServicePackageResult
+	(*ServicePublishResult)(nil),            // 16: azdext.ServicePublishResult
+	(*ServiceDeployResult)(nil),             // 17: azdext.ServiceDeployResult
+	(*ServiceEndpoint)(nil),                 // 18: azdext.ServiceEndpoint
+	(*ServiceTargetPackageRequest)(nil),     // 19: azdext.ServiceTargetPackageRequest
+	(*ServiceTargetPackageResponse)(nil),    // 20: azdext.ServiceTargetPackageResponse
+	(*ServiceTargetPublishRequest)(nil),     // 21: azdext.ServiceTargetPublishRequest
+	(*ServiceTargetPublishResponse)(nil),    // 22: azdext.ServiceTargetPublishResponse
+	(*PublishOptions)(nil),                  // 23: azdext.PublishOptions
+	(*ServiceTargetEndpointsRequest)(nil),   // 24: azdext.ServiceTargetEndpointsRequest
+	(*ServiceTargetEndpointsResponse)(nil),  // 25: azdext.ServiceTargetEndpointsResponse
+	(*ServiceTargetProgressMessage)(nil),    // 26: azdext.ServiceTargetProgressMessage
+	nil,                                     // 27: azdext.ServiceTargetOptions.DeploymentStacksEntry
+	nil,                                     // 28: azdext.TargetResource.MetadataEntry
+	(*ExtensionError)(nil),                  // 29: azdext.ExtensionError
+	(*ServiceConfig)(nil),                   // 30: azdext.ServiceConfig
+	(*structpb.Struct)(nil),                 // 31: google.protobuf.Struct
+	(*ServiceContext)(nil),                  // 32: azdext.ServiceContext
+	(*Artifact)(nil),                        // 33: azdext.Artifact
 }
 var file_service_target_proto_depIdxs = []int32{
-	27, // 0: azdext.ServiceTargetMessage.error:type_name -> azdext.ExtensionError
-	7,  // 1: azdext.ServiceTargetMessage.register_service_target_request:type_name -> azdext.RegisterServiceTargetRequest
-	8,  // 2: azdext.ServiceTargetMessage.register_service_target_response:type_name -> azdext.RegisterServiceTargetResponse
-	4,  // 3: azdext.ServiceTargetMessage.initialize_request:type_name -> azdext.ServiceTargetInitializeRequest
-	5,  // 4: azdext.ServiceTargetMessage.initialize_response:type_name -> azdext.ServiceTargetInitializeResponse
-	9,  // 5: azdext.ServiceTargetMessage.get_target_resource_request:type_name -> azdext.GetTargetResourceRequest
-	10, // 6: azdext.ServiceTargetMessage.get_target_resource_response:type_name -> azdext.GetTargetResourceResponse
-	12, // 7: azdext.ServiceTargetMessage.deploy_request:type_name -> azdext.ServiceTargetDeployRequest
-	13, // 8: azdext.ServiceTargetMessage.deploy_response:type_name -> azdext.ServiceTargetDeployResponse
-	24, // 9: azdext.ServiceTargetMessage.progress_message:type_name -> azdext.ServiceTargetProgressMessage
-	17, // 10: azdext.ServiceTargetMessage.package_request:type_name -> azdext.ServiceTargetPackageRequest
-	18, // 11: azdext.ServiceTargetMessage.package_response:type_name -> azdext.ServiceTargetPackageResponse
-	19, // 12: azdext.ServiceTargetMessage.publish_request:type_name -> azdext.ServiceTargetPublishRequest
-	20, // 13: azdext.ServiceTargetMessage.publish_response:type_name -> azdext.ServiceTargetPublishResponse
-	22, // 14: azdext.ServiceTargetMessage.endpoints_request:type_name -> azdext.ServiceTargetEndpointsRequest
-	23, // 15: azdext.ServiceTargetMessage.endpoints_response:type_name -> azdext.ServiceTargetEndpointsResponse
-	28, // 16: azdext.ServiceTargetInitializeRequest.service_config:type_name -> azdext.ServiceConfig
-	25, // 17: azdext.ServiceTargetOptions.deployment_stacks:type_name -> azdext.ServiceTargetOptions.DeploymentStacksEntry
-	29, // 18: azdext.ServiceTargetOptions.config:type_name -> google.protobuf.Struct
-	28, // 19: azdext.GetTargetResourceRequest.service_config:type_name -> azdext.ServiceConfig
-	11, // 20: azdext.GetTargetResourceRequest.default_target_resource:type_name -> azdext.TargetResource
-	11, // 21: azdext.GetTargetResourceResponse.target_resource:type_name -> azdext.TargetResource
-	26, // 22: azdext.TargetResource.metadata:type_name -> azdext.TargetResource.MetadataEntry
-	28, // 23: azdext.ServiceTargetDeployRequest.service_config:type_name -> azdext.ServiceConfig
-	30, // 24: azdext.ServiceTargetDeployRequest.service_context:type_name -> azdext.ServiceContext
-	11, // 25: azdext.ServiceTargetDeployRequest.target_resource:type_name -> azdext.TargetResource
-	16, // 26: azdext.ServiceTargetDeployResponse.result:type_name -> azdext.ServiceDeployResult
-	31, // 27: azdext.ServicePackageResult.artifacts:type_name -> azdext.Artifact
-	31, // 28: azdext.ServicePublishResult.artifacts:type_name -> azdext.Artifact
-	31, // 29: azdext.ServiceDeployResult.artifacts:type_name -> azdext.Artifact
-	28, // 30: azdext.ServiceTargetPackageRequest.service_config:type_name -> azdext.ServiceConfig
-	30, // 31: azdext.ServiceTargetPackageRequest.service_context:type_name -> azdext.ServiceContext
-	14, // 32: azdext.ServiceTargetPackageResponse.result:type_name -> azdext.ServicePackageResult
-	28, // 33: azdext.ServiceTargetPublishRequest.service_config:type_name -> azdext.ServiceConfig
-	30, // 34: azdext.ServiceTargetPublishRequest.service_context:type_name -> azdext.ServiceContext
-	11, // 35: azdext.ServiceTargetPublishRequest.target_resource:type_name -> azdext.TargetResource
-	21, // 36: azdext.ServiceTargetPublishRequest.publish_options:type_name -> azdext.PublishOptions
-	15, // 37: azdext.ServiceTargetPublishResponse.result:type_name -> azdext.ServicePublishResult
-	28, // 38: azdext.ServiceTargetEndpointsRequest.service_config:type_name -> azdext.ServiceConfig
-	11, // 39: azdext.ServiceTargetEndpointsRequest.target_resource:type_name -> azdext.TargetResource
-	0,  // 40: azdext.ServiceTargetService.Stream:input_type -> azdext.ServiceTargetMessage
-	0,  // 41: azdext.ServiceTargetService.Stream:output_type -> azdext.ServiceTargetMessage
-	41, // [41:42] is the sub-list for method output_type
-	40, // [40:41] is the sub-list for method input_type
-	40, // [40:40] is the sub-list for extension type_name
-	40, // [40:40] is the sub-list for extension extendee
-	0,  // [0:40] is the sub-list for field type_name
+	29, // 0: azdext.ServiceTargetMessage.error:type_name -> azdext.ExtensionError
+	8,  // 1: azdext.ServiceTargetMessage.register_service_target_request:type_name -> azdext.RegisterServiceTargetRequest
+	9,  // 2: azdext.ServiceTargetMessage.register_service_target_response:type_name -> azdext.RegisterServiceTargetResponse
+	5,  // 3: azdext.ServiceTargetMessage.initialize_request:type_name -> azdext.ServiceTargetInitializeRequest
+	6,  // 4: azdext.ServiceTargetMessage.initialize_response:type_name -> azdext.ServiceTargetInitializeResponse
+	10, // 5: azdext.ServiceTargetMessage.get_target_resource_request:type_name -> azdext.GetTargetResourceRequest
+	11, // 6: azdext.ServiceTargetMessage.get_target_resource_response:type_name -> azdext.GetTargetResourceResponse
+	13, // 7: azdext.ServiceTargetMessage.deploy_request:type_name -> azdext.ServiceTargetDeployRequest
+	14, // 8: azdext.ServiceTargetMessage.deploy_response:type_name -> azdext.ServiceTargetDeployResponse
+	26, // 9: azdext.ServiceTargetMessage.progress_message:type_name -> azdext.ServiceTargetProgressMessage
+	19, // 10: azdext.ServiceTargetMessage.package_request:type_name -> azdext.ServiceTargetPackageRequest
+	20, // 11: azdext.ServiceTargetMessage.package_response:type_name -> azdext.ServiceTargetPackageResponse
+	21, // 12: azdext.ServiceTargetMessage.publish_request:type_name -> azdext.ServiceTargetPublishRequest
+	22, // 13: azdext.ServiceTargetMessage.publish_response:type_name -> azdext.ServiceTargetPublishResponse
+	24, // 14: azdext.ServiceTargetMessage.endpoints_request:type_name -> azdext.ServiceTargetEndpointsRequest
+	25, // 15: azdext.ServiceTargetMessage.endpoints_response:type_name -> azdext.ServiceTargetEndpointsResponse
+	1,  // 16: azdext.ServiceTargetMessage.cancel_request:type_name -> azdext.ServiceTargetCancelRequest
+	30, // 17: azdext.ServiceTargetInitializeRequest.service_config:type_name -> azdext.ServiceConfig
+	27, // 18: azdext.ServiceTargetOptions.deployment_stacks:type_name -> azdext.ServiceTargetOptions.DeploymentStacksEntry
+	31, // 19: azdext.ServiceTargetOptions.config:type_name -> google.protobuf.Struct
+	30, // 20: azdext.GetTargetResourceRequest.service_config:type_name -> azdext.ServiceConfig
+	12, // 21: azdext.GetTargetResourceRequest.default_target_resource:type_name -> azdext.TargetResource
+	12, // 22: azdext.GetTargetResourceResponse.target_resource:type_name -> azdext.TargetResource
+	28, // 23: azdext.TargetResource.metadata:type_name -> azdext.TargetResource.MetadataEntry
+	30, // 24: azdext.ServiceTargetDeployRequest.service_config:type_name -> azdext.ServiceConfig
+	32, // 25: azdext.ServiceTargetDeployRequest.service_context:type_name -> azdext.ServiceContext
+	12, // 26: azdext.ServiceTargetDeployRequest.target_resource:type_name -> azdext.TargetResource
+	17, // 27: azdext.ServiceTargetDeployResponse.result:type_name -> azdext.ServiceDeployResult
+	33, // 28: azdext.ServicePackageResult.artifacts:type_name -> azdext.Artifact
+	33, // 29: azdext.ServicePublishResult.artifacts:type_name -> azdext.Artifact
+	33, // 30: azdext.ServiceDeployResult.artifacts:type_name -> azdext.Artifact
+	18, // 31: azdext.ServiceDeployResult.endpoints:type_name -> azdext.ServiceEndpoint
+	30, // 32: azdext.ServiceTargetPackageRequest.service_config:type_name -> azdext.ServiceConfig
+	32, // 33: azdext.ServiceTargetPackageRequest.service_context:type_name -> azdext.ServiceContext
+	15, // 34: azdext.ServiceTargetPackageResponse.result:type_name -> azdext.ServicePackageResult
+	30, // 35: azdext.ServiceTargetPublishRequest.service_config:type_name -> azdext.ServiceConfig
+	32, // 36: azdext.ServiceTargetPublishRequest.service_context:type_name -> azdext.ServiceContext
+	12, // 37: azdext.ServiceTargetPublishRequest.target_resource:type_name -> azdext.TargetResource
+	23, // 38: azdext.ServiceTargetPublishRequest.publish_options:type_name -> azdext.PublishOptions
+	16, // 39: azdext.ServiceTargetPublishResponse.result:type_name -> azdext.ServicePublishResult
+	30, // 40: azdext.ServiceTargetEndpointsRequest.service_config:type_name -> azdext.ServiceConfig
+	12, // 41: azdext.ServiceTargetEndpointsRequest.target_resource:type_name -> azdext.TargetResource
+	18, // 42: azdext.ServiceTargetEndpointsResponse.labeled_endpoints:type_name -> azdext.ServiceEndpoint
+	0,  // 43: azdext.ServiceTargetService.Stream:input_type -> azdext.ServiceTargetMessage
+	0,  // 44: azdext.ServiceTargetService.Stream:output_type -> azdext.ServiceTargetMessage
+	44, // [44:45] is the sub-list for method output_type
+	43, // [43:44] is the sub-list for method input_type
+	43, // [43:43] is the sub-list for extension type_name
+	43, // [43:43] is the sub-list for extension extendee
+	0,  // [0:43] is the sub-list for field type_name
 }
 
 func init() { file_service_target_proto_init() }
@@ -1810,14 +1997,16 @@ func file_service_target_proto_init() {
 		(*ServiceTargetMessage_PublishResponse)(nil),
 		(*ServiceTargetMessage_EndpointsRequest)(nil),
 		(*ServiceTargetMessage_EndpointsResponse)(nil),
+		(*ServiceTargetMessage_CancelRequest)(nil),
 	}
+	file_service_target_proto_msgTypes[26].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_service_target_proto_rawDesc), len(file_service_target_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   27,
+			NumMessages:   29,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -77,6 +77,8 @@ func (ops *ServiceTargetEnvelope) GetInnerMessage(msg *ServiceTargetMessage) any
 		return m.EndpointsRequest
 	case *ServiceTargetMessage_EndpointsResponse:
 		return m.EndpointsResponse
+	case *ServiceTargetMessage_CancelRequest:
+		return m.CancelRequest
 	default:
 		// Return nil for unhandled message types
 		return nil
@@ -17,6 +17,13 @@ import (
 // ProgressReporter is an alias for the broker's ProgressFunc
 type ProgressReporter = grpcbroker.ProgressFunc
 
+// RequestIdFromContext returns the id of the request a provider method was invoked for, or an empty string outside
+// of a request. azd reuses the id of a deploy request when it deploys the same package to the same target again, so
+// a provider can use it to recognize a deploy it already performed.
+func RequestIdFromContext(ctx context.Context) string {
+	return grpcbroker.RequestIdFromContext(ctx)
+}
+
 var (
 	ServiceTargetFactoryKey = func(config *ServiceConfig) string {
 		return string(config.Host)
@@ -60,6 +67,17 @@ type ServiceTargetProvider interface {
 	) (*ServiceDeployResult, error)
 }
 
+// LabeledEndpointsProvider is optionally implemented by a ServiceTargetProvider to describe its endpoints beyond a
+// bare URL. When implemented, azd uses it instead of Endpoints. Give the service's main URL the role "primary" so that
+// azd reports it ahead of health or admin endpoints.
+type LabeledEndpointsProvider interface {
+	LabeledEndpoints(
+		ctx context.Context,
+		serviceConfig *ServiceConfig,
+		targetResource *TargetResource,
+	) ([]*ServiceEndpoint, error)
+}
+
 // ServiceTargetManager handles registration and provisioning request forwarding for a provider.
 type ServiceTargetManager struct {
 	extensionId      string
@@ -70,6 +88,10 @@ type ServiceTargetManager struct {
 
 	// Synchronization for concurrent access
 	mu sync.RWMutex
+
+	cancelMu sync.Mutex
+	// cancels holds the cancel funcs of the requests in progress, by request id.
+	cancels map[string]context.CancelFunc
 }
 
 // NewServiceTargetManager creates a new ServiceTargetManager for an AzdClient.
@@ -145,6 +167,9 @@ func (m *ServiceTargetManager) ensureStream(ctx context.Context) error {
 	if err := m.broker.On(m.onEndpoints); err != nil {
 		return fmt.Errorf("failed to register endpoints handler: %w", err)
 	}
+	if err := m.broker.On(m.onCancel); err != nil {
+		return fmt.Errorf("failed to register cancel handler: %w", err)
+	}
 
 	return nil
 }
@@ -206,11 +231,55 @@ func (m *ServiceTargetManager) Ready(ctx context.Context) error {
 
 // Handler methods - these are registered with the broker to handle incoming requests
 
+// withCancellation returns a context for the request ctx was passed for, that is cancelled when azd sends a cancel
+// request for it. The returned func must be called once the request completes.
+func (m *ServiceTargetManager) withCancellation(ctx context.Context) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	requestId := RequestIdFromContext(ctx)
+	if requestId == "" {
+		return ctx, cancel
+	}
+
+	m.cancelMu.Lock()
+	defer m.cancelMu.Unlock()
+
+	if m.cancels == nil {
+		m.cancels = map[string]context.CancelFunc{}
+	}
+	m.cancels[requestId] = cancel
+
+	return ctx, func() {
+		m.cancelMu.Lock()
+		defer m.cancelMu.Unlock()
+
+		delete(m.cancels, requestId)
+		cancel()
+	}
+}
+
+// onCancel handles cancel requests by cancelling the context of the request in progress they name. Requests that
+// already completed are ignored. No response is sent.
+func (m *ServiceTargetManager) onCancel(
+	ctx context.Context,
+	req *ServiceTargetCancelRequest,
+) (*ServiceTargetMessage, error) {
+	m.cancelMu.Lock()
+	cancel, has := m.cancels[req.RequestId]
+	m.cancelMu.Unlock()
+
+	if has {
+		cancel()
+	}
+
+	return nil, nil
+}
+
 // onInitialize handles initialization requests from the server
 func (m *ServiceTargetManager) onInitialize(
 	ctx context.Context,
 	req *ServiceTargetInitializeRequest,
 ) (*ServiceTargetMessage, error) {
+	// Initialize is not cancellable: providers may keep using ctx after it returns.
 	if req.ServiceConfig == nil {
 		return nil, errors.New("service config is required for initialize request")
 	}
@@ -230,6 +299,9 @@ func (m *ServiceTargetManager) onGetTargetResource(
 	ctx context.Context,
 	req *GetTargetResourceRequest,
 ) (*ServiceTargetMessage, error) {
+	ctx, done := m.withCancellation(ctx)
+	defer done()
+
 	if req.ServiceConfig == nil {
 		return nil, errors.New("service config is required for get target resource request")
 	}
@@ -270,6 +342,9 @@ func (m *ServiceTargetManager) onPackage(
 	req *ServiceTargetPackageRequest,
 	progress grpcbroker.ProgressFunc,
 ) (*ServiceTargetMessage, error) {
+	ctx, done := m.withCancellation(ctx)
+	defer done()
+
 	if req.ServiceConfig == nil {
 		return nil, errors.New("service config is required for package request")
 	}
@@ -300,6 +375,9 @@ func (m *ServiceTargetManager) onPublish(
 	req *ServiceTargetPublishRequest,
 	progress grpcbroker.ProgressFunc,
 ) (*ServiceTargetMessage, error) {
+	ctx, done := m.withCancellation(ctx)
+	defer done()
+
 	if req.ServiceConfig == nil {
 		return nil, errors.New("service config is required for publish request")
 	}
@@ -332,6 +410,9 @@ func (m *ServiceTargetManager) onDeploy(
 	req *ServiceTargetDeployRequest,
 	progress grpcbroker.ProgressFunc,
 ) (*ServiceTargetMessage, error) {
+	ctx, done := m.withCancellation(ctx)
+	defer done()
+
 	if req.ServiceConfig == nil {
 		return nil, errors.New("service config is required for deploy request")
 	}
@@ -362,6 +443,9 @@ func (m *ServiceTargetManager) onEndpoints(
 	ctx context.Context,
 	req *ServiceTargetEndpointsRequest,
 ) (*ServiceTargetMessage, error) {
+	ctx, done := m.withCancellation(ctx)
+	defer done()
+
 	if req.ServiceConfig == nil {
 		return nil, errors.New("service config is required for endpoints request")
 	}
@@ -372,6 +456,16 @@ func (m *ServiceTargetManager) onEndpoints(
 			req.ServiceConfig.Name)
 	}
 
+	if labeled, ok := provider.(LabeledEndpointsProvider); ok {
+		endpoints, err := labeled.LabeledEndpoints(ctx, req.ServiceConfig, req.TargetResource)
+
+		return &ServiceTargetMessage{
+			MessageType: &ServiceTargetMessage_EndpointsResponse{
+				EndpointsResponse: &ServiceTargetEndpointsResponse{LabeledEndpoints: endpoints},
+			},
+		}, err
+	}
+
 	endpoints, err := provider.Endpoints(
 		ctx,
 		req.ServiceConfig,
@@ -6,7 +6,11 @@ package azdext
 import (
 	"context"
 	"errors"
+	"io"
 	"testing"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/grpcbroker"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -336,6 +340,50 @@ func TestServiceTargetManager_EndpointsRequest_Success(t *testing.T) {
 	mockProvider.AssertExpectations(t)
 }
 
+// labeledEndpointsProvider adds LabeledEndpoints to the mock provider.
+type labeledEndpointsProvider struct {
+	MockServiceTargetProvider
+	endpoints []*ServiceEndpoint
+}
+
+func (p *labeledEndpointsProvider) LabeledEndpoints(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *TargetResource,
+) ([]*ServiceEndpoint, error) {
+	return p.endpoints, nil
+}
+
+func TestServiceTargetManager_EndpointsRequest_Labeled(t *testing.T) {
+	t.Parallel()
+
+	manager := createTestServiceTargetManager()
+	ctx := t.Context()
+
+	provider := &labeledEndpointsProvider{
+		endpoints: []*ServiceEndpoint{
+			{Url: "https://test-app.azurecontainerapps.io", Role: "primary"},
+			{Url: "https://test-app.azurecontainerapps.io/healthz", Label: "Health", Role: "health"},
+		},
+	}
+	provider.On("Initialize", mock.Anything, mock.Anything).Return(nil)
+	manager.componentManager.RegisterFactory("containerapp", func() ServiceTargetProvider {
+		return provider
+	})
+
+	serviceConfig := createTestServiceConfigForServiceTarget("web-service", "containerapp")
+	_, err := manager.componentManager.GetOrCreateInstance(ctx, serviceConfig)
+	require.NoError(t, err)
+
+	resp, err := manager.onEndpoints(ctx, &ServiceTargetEndpointsRequest{ServiceConfig: serviceConfig})
+	require.NoError(t, err)
+	require.Equal(t, provider.endpoints, resp.GetEndpointsResponse().LabeledEndpoints)
+	require.Empty(t, resp.GetEndpointsResponse().Endpoints)
+
+	// Endpoints is not called when the provider reports labeled endpoints.
+	provider.AssertNotCalled(t, "Endpoints", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestServiceTargetManager_PackageRequest_NilServiceConfig(t *testing.T) {
 	t.Parallel()
 
@@ -510,3 +558,85 @@ func TestServiceTargetManager_MultipleRequestTypes_SameProvider(t *testing.T) {
 	// Verify all mock expectations
 	mockProvider.AssertExpectations(t)
 }
+
+// chanServiceTargetStream is a BidiStream backed by channels, standing in for the stream to azd.
+type chanServiceTargetStream struct {
+	fromAzd chan *ServiceTargetMessage
+	toAzd   chan *ServiceTargetMessage
+}
+
+func (s *chanServiceTargetStream) Send(msg *ServiceTargetMessage) error {
+	s.toAzd <- msg
+	return nil
+}
+
+func (s *chanServiceTargetStream) Recv() (*ServiceTargetMessage, error) {
+	msg, ok := <-s.fromAzd
+	if !ok {
+		return nil, io.EOF
+	}
+	return msg, nil
+}
+
+func TestServiceTargetManager_CancelRequest_CancelsInFlightRequest(t *testing.T) {
+	t.Parallel()
+
+	stream := &chanServiceTargetStream{
+		fromAzd: make(chan *ServiceTargetMessage, 10),
+		toAzd:   make(chan *ServiceTargetMessage, 10),
+	}
+	t.Cleanup(func() { close(stream.fromAzd) })
+
+	manager := createTestServiceTargetManager()
+	manager.broker = grpcbroker.NewMessageBroker(stream, NewServiceTargetEnvelope(), "test", nil)
+	require.NoError(t, manager.broker.On(manager.onDeploy))
+	require.NoError(t, manager.broker.On(manager.onCancel))
+	go func() {
+		_ = manager.broker.Run(t.Context())
+	}()
+	require.NoError(t, manager.broker.Ready(t.Context()))
+
+	serviceConfig := createTestServiceConfigForServiceTarget("api", "containerapp")
+	provider := &MockServiceTargetProvider{}
+	provider.On("Initialize", mock.Anything, serviceConfig).Return(nil)
+	deploying := make(chan struct{})
+	provider.On("Deploy", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			ctx := args.Get(0).(context.Context)
+			assert.Equal(t, "deploy-1", RequestIdFromContext(ctx))
+			close(deploying)
+			<-ctx.Done()
+		}).
+		Return((*ServiceDeployResult)(nil), context.Canceled)
+	manager.componentManager.RegisterFactory("containerapp", func() ServiceTargetProvider { return provider })
+	_, err := manager.componentManager.GetOrCreateInstance(t.Context(), serviceConfig)
+	require.NoError(t, err)
+
+	stream.fromAzd <- &ServiceTargetMessage{
+		RequestId: "deploy-1",
+		MessageType: &ServiceTargetMessage_DeployRequest{
+			DeployRequest: &ServiceTargetDeployRequest{
+				ServiceConfig:  serviceConfig,
+				ServiceContext: &ServiceContext{},
+				TargetResource: &TargetResource{},
+			},
+		},
+	}
+
+	// Only send the cancel once the provider is running, since the broker handles each message concurrently.
+	<-deploying
+	stream.fromAzd <- &ServiceTargetMessage{
+		RequestId: "cancel-1",
+		MessageType: &ServiceTargetMessage_CancelRequest{
+			CancelRequest: &ServiceTargetCancelRequest{RequestId: "deploy-1"},
+		},
+	}
+
+	select {
+	case resp := <-stream.toAzd:
+		require.Equal(t, "deploy-1", resp.RequestId)
+		require.NotNil(t, resp.Error)
+	case <-time.After(5 * time.Second):
+		t.Fatal("deploy was not cancelled")
+	}
+}
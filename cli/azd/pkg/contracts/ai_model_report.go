@@ -0,0 +1,39 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package contracts
+
+// AiModelReport is the contract for the output of `azd ai report`. It answers where and how a model can be deployed:
+// one offer per location, version and SKU, with the quota left for the SKU.
+type AiModelReport struct {
+	Model                 string              `json:"model"`
+	MostAvailableLocation string              `json:"mostAvailableLocation,omitempty"`
+	Offers                []AiModelOffer      `json:"offers"`
+	UnavailableLocations  []string            `json:"unavailableLocations,omitempty"`
+	FailedLocations       []AiLocationFailure `json:"failedLocations,omitempty"`
+}
+
+// AiModelOffer is a SKU of a model version offered at a location. RemainingQuota is unset when the location has no
+// usage data for the SKU.
+type AiModelOffer struct {
+	Location        string   `json:"location"`
+	Version         string   `json:"version"`
+	IsDefault       bool     `json:"isDefault"`
+	LifecycleStatus string   `json:"lifecycleStatus,omitempty"`
+	Sku             string   `json:"sku"`
+	DeploymentKind  string   `json:"deploymentKind,omitempty"`
+	UsageName       string   `json:"usageName"`
+	DefaultCapacity int32    `json:"defaultCapacity"`
+	CurrentUsage    float64  `json:"currentUsage"`
+	Limit           float64  `json:"limit"`
+	RemainingQuota  *float64 `json:"remainingQuota,omitempty"`
+	Deployable      bool     `json:"deployable"`
+}
+
+// AiLocationFailure is a location whose model catalog or usages could not be fetched. Throttled is true when the
+// location was rate limited, so retrying later may succeed.
+type AiLocationFailure struct {
+	Location  string `json:"location"`
+	Reason    string `json:"reason"`
+	Throttled bool   `json:"throttled,omitempty"`
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"io"
 	"slices"
+	"strings"
 	"sync"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
@@ -95,6 +96,42 @@ func (e *Extension) HasCapability(capability ...CapabilityType) bool {
 	return true
 }
 
+// ServiceTargetKinds returns the service host kinds the extension advertises through its service-target providers.
+func (e *Extension) ServiceTargetKinds() []string {
+	kinds := []string{}
+	for _, provider := range e.Providers {
+		if provider.Type == ServiceTargetProviderType {
+			kinds = append(kinds, provider.Name)
+		}
+	}
+
+	return kinds
+}
+
+// AdvertisesServiceTarget reports whether the extension advertises the service host kind.
+func (e *Extension) AdvertisesServiceTarget(kind string) bool {
+	return slices.ContainsFunc(e.ServiceTargetKinds(), func(name string) bool {
+		return strings.EqualFold(name, kind)
+	})
+}
+
+// AddMenuProviders returns the entries the extension contributes to the `azd add` resource type menu through its
+// add-menu providers. Extensions without the add-menu-provider capability contribute none.
+func (e *Extension) AddMenuProviders() []Provider {
+	if !e.HasCapability(AddMenuProviderCapability) {
+		return nil
+	}
+
+	var providers []Provider
+	for _, provider := range e.Providers {
+		if provider.Type == AddMenuProviderType {
+			providers = append(providers, provider)
+		}
+	}
+
+	return providers
+}
+
 // StdIn returns the standard input buffer for the extension.
 func (e *Extension) StdIn() io.Reader {
 	e.ensureInit()
@@ -4,6 +4,7 @@
 package extensions
 
 import (
+	"cmp"
 	"context"
 	"crypto/sha256"
 	"crypto/sha512"
@@ -401,6 +402,57 @@ func (m *Manager) GetInstalled(options FilterOptions) (*Extension, error) {
 	return nil, ErrInstalledExtensionNotFound
 }
 
+// FindServiceTargetProvider returns the installed extension that advertises the service host kind through a
+// service-target provider. Returns ErrInstalledExtensionNotFound when no installed extension advertises it.
+func (m *Manager) FindServiceTargetProvider(kind string) (*Extension, error) {
+	extensions, err := m.ListInstalled()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(extensions)) {
+		extension := extensions[id]
+		if extension.HasCapability(ServiceTargetProviderCapability) && extension.AdvertisesServiceTarget(kind) {
+			return extension, nil
+		}
+	}
+
+	return nil, ErrInstalledExtensionNotFound
+}
+
+// AddMenuEntry is an entry an installed extension contributes to the `azd add` resource type menu.
+type AddMenuEntry struct {
+	// Extension is the extension that contributes the entry and is run when it is chosen.
+	Extension *Extension
+	// Name identifies the entry within the extension.
+	Name string
+	// Label is displayed in the menu.
+	Label string
+}
+
+// AddMenuEntries returns the entries installed extensions contribute to the `azd add` resource type menu, ordered by
+// extension id and then as each extension declares them.
+func (m *Manager) AddMenuEntries() ([]AddMenuEntry, error) {
+	extensions, err := m.ListInstalled()
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []AddMenuEntry
+	for _, id := range slices.Sorted(maps.Keys(extensions)) {
+		extension := extensions[id]
+		for _, provider := range extension.AddMenuProviders() {
+			entries = append(entries, AddMenuEntry{
+				Extension: extension,
+				Name:      provider.Name,
+				Label:     cmp.Or(provider.Description, provider.Name),
+			})
+		}
+	}
+
+	return entries, nil
+}
+
 // UpdateInstalled updates an installed extension's metadata in the config
 func (m *Manager) UpdateInstalled(extension *Extension) error {
 	extensions, err := m.ListInstalled()
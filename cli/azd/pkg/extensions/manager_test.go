@@ -3196,3 +3196,117 @@ func Test_dependencySources(t *testing.T) {
 	}
 	require.Equal(t, []string{"azd", "dev"}, dependencySources(matches))
 }
+
+func Test_FindServiceTargetProvider(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("AZD_CONFIG_DIR", tempDir)
+
+	mockContext := mocks.NewMockContext(t.Context())
+	fileConfigManager := config.NewFileConfigManager(config.NewManager())
+	userConfigManager := config.NewUserConfigManager(fileConfigManager)
+
+	sourceManager := NewSourceManager(mockContext.Container, userConfigManager, mockContext.HttpClient)
+	lazyRunner := lazy.NewLazy(func() (*Runner, error) {
+		return NewRunner(mockContext.CommandRunner), nil
+	})
+
+	manager, err := NewManager(userConfigManager, sourceManager, lazyRunner, mockContext.HttpClient)
+	require.NoError(t, err)
+
+	extensions := map[string]*Extension{
+		"test.target": {
+			Id:           "test.target",
+			Version:      "1.0.0",
+			Capabilities: []CapabilityType{ServiceTargetProviderCapability},
+			Providers: []Provider{
+				{Name: "mykind", Type: ServiceTargetProviderType},
+			},
+		},
+		"test.framework": {
+			Id:           "test.framework",
+			Version:      "1.0.0",
+			Capabilities: []CapabilityType{FrameworkServiceProviderCapability},
+			Providers: []Provider{
+				{Name: "rust", Type: ProvisioningProviderType},
+			},
+		},
+	}
+
+	err = manager.userConfig.Set(installedConfigKey, extensions)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		kind    string
+		wantId  string
+		wantErr error
+	}{
+		{name: "advertised kind", kind: "mykind", wantId: "test.target"},
+		{name: "case insensitive", kind: "MyKind", wantId: "test.target"},
+		{name: "other provider types are ignored", kind: "rust", wantErr: ErrInstalledExtensionNotFound},
+		{name: "unknown kind", kind: "otherkind", wantErr: ErrInstalledExtensionNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ext, err := manager.FindServiceTargetProvider(tt.kind)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantId, ext.Id)
+		})
+	}
+}
+
+func Test_AddMenuEntries(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("AZD_CONFIG_DIR", tempDir)
+
+	mockContext := mocks.NewMockContext(t.Context())
+	fileConfigManager := config.NewFileConfigManager(config.NewManager())
+	userConfigManager := config.NewUserConfigManager(fileConfigManager)
+
+	sourceManager := NewSourceManager(mockContext.Container, userConfigManager, mockContext.HttpClient)
+	lazyRunner := lazy.NewLazy(func() (*Runner, error) {
+		return NewRunner(mockContext.CommandRunner), nil
+	})
+
+	manager, err := NewManager(userConfigManager, sourceManager, lazyRunner, mockContext.HttpClient)
+	require.NoError(t, err)
+
+	extensions := map[string]*Extension{
+		"test.graph": {
+			Id:           "test.graph",
+			Version:      "1.0.0",
+			Capabilities: []CapabilityType{AddMenuProviderCapability},
+			Providers: []Provider{
+				{Name: "graph", Type: AddMenuProviderType, Description: "Graph database"},
+				{Name: "mykind", Type: ServiceTargetProviderType},
+				{Name: "queue", Type: AddMenuProviderType},
+			},
+		},
+		"test.undeclared": {
+			Id:      "test.undeclared",
+			Version: "1.0.0",
+			// Providers without the add-menu-provider capability are ignored.
+			Providers: []Provider{
+				{Name: "cache", Type: AddMenuProviderType, Description: "Cache"},
+			},
+		},
+	}
+
+	err = manager.userConfig.Set(installedConfigKey, extensions)
+	require.NoError(t, err)
+
+	entries, err := manager.AddMenuEntries()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, "test.graph", entries[0].Extension.Id)
+	require.Equal(t, "graph", entries[0].Name)
+	require.Equal(t, "Graph database", entries[0].Label)
+	require.Equal(t, "queue", entries[1].Name)
+	require.Equal(t, "queue", entries[1].Label)
+}
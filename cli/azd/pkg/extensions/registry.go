@@ -57,6 +57,9 @@ const (
 	// Validation provider enables extensions to contribute validation checks
 	// to azd's validation pipeline (e.g. provision checks during provisioning)
 	ValidationProviderCapability CapabilityType = "validation-provider"
+	// Add menu provider enables extensions to contribute entries to azd's built-in selection menus,
+	// starting with the resource type menu of `azd add`
+	AddMenuProviderCapability CapabilityType = "add-menu-provider"
 )
 
 type ProviderType string
@@ -66,6 +69,9 @@ const (
 	ServiceTargetProviderType ProviderType = "service-target"
 	// Provisioning provider type for custom infrastructure provisioning experiences
 	ProvisioningProviderType ProviderType = "provisioning-provider"
+	// Add menu provider type for entries contributed to the `azd add` resource type menu. The provider name
+	// identifies the entry and the description is its menu label.
+	AddMenuProviderType ProviderType = "add-menu"
 )
 
 // Extension represents an extension in the registry
@@ -130,6 +136,9 @@ type ExtensionVersion struct {
 	Examples []ExtensionExample `json:"examples"`
 	// Artifacts is a map of artifacts for the extension key on platform (os & architecture)
 	Artifacts map[string]ExtensionArtifact `json:"artifacts,omitempty"`
+	// Platforms lists the os/arch combinations, e.g. "linux/arm64", that the version was packed for. It is
+	// informational; installs select an artifact from Artifacts.
+	Platforms []string `json:"platforms,omitempty"`
 	// Dependencies is a list of dependencies for the extension
 	// An extension with dependencies and no artifacts is considered an extension pack.
 	// The dependencies are resolved and installed when the extension pack is installed.
@@ -21,6 +21,10 @@ import (
 // ErrResourceExhausted is returned when a gRPC message exceeds the size limit.
 var ErrResourceExhausted = errors.New("gRPC message size limit exceeded")
 
+// ErrBrokerClosed is returned by SendAndWait and SendAndWaitWithProgress when the broker stops
+// (for example because the remote peer closed the stream) before a response to the request arrived.
+var ErrBrokerClosed = errors.New("message broker closed before a response was received")
+
 // wrapResourceExhausted detects gRPC ResourceExhausted errors (message too large)
 // and wraps them with a clear message so callers can identify the root cause.
 // Without this, oversized messages silently kill the broker stream and surface as
@@ -78,6 +82,16 @@ type MessageEnvelope[T any] interface {
 	CreateProgressMessage(requestId string, message string) *T
 }
 
+// requestIdContextKey is the context key of the id of the request a handler was invoked for.
+type requestIdContextKey struct{}
+
+// RequestIdFromContext returns the id of the request a handler was invoked for, or an empty string when ctx was not
+// passed to a handler by the broker.
+func RequestIdFromContext(ctx context.Context) string {
+	requestId, _ := ctx.Value(requestIdContextKey{}).(string)
+	return requestId
+}
+
 // handlerWrapper wraps a registered handler function with metadata
 type handlerWrapper struct {
 	handlerFunc   reflect.Value
@@ -265,7 +279,7 @@ func (mb *MessageBroker[TMessage]) SendAndWait(ctx context.Context, msg *TMessag
 		case resp, ok := <-ch:
 			if !ok {
 				mb.logger.Printf("[%s] [RequestId=%s] Channel closed (broker stopped)", mb.name, requestId)
-				return nil, errors.New("channel closed by broker")
+				return nil, fmt.Errorf("channel closed by broker: %w", ErrBrokerClosed)
 			}
 			respInner := mb.envelope.GetInnerMessage(resp)
 			respType := reflect.TypeOf(respInner)
@@ -326,6 +340,25 @@ func (mb *MessageBroker[TMessage]) SendAndWaitWithProgress(
 	ctx context.Context,
 	msg *TMessage,
 	onProgress func(string),
+) (*TMessage, error) {
+	var onProgressMessage func(*TMessage)
+	if onProgress != nil {
+		onProgressMessage = func(resp *TMessage) {
+			if progressText := mb.envelope.GetProgressMessage(resp); progressText != "" {
+				onProgress(progressText)
+			}
+		}
+	}
+
+	return mb.SendAndWaitWithProgressMessages(ctx, msg, onProgressMessage)
+}
+
+// SendAndWaitWithProgressMessages is like SendAndWaitWithProgress, but passes each progress message envelope to
+// onProgress as received, for message types whose progress carries more than text.
+func (mb *MessageBroker[TMessage]) SendAndWaitWithProgressMessages(
+	ctx context.Context,
+	msg *TMessage,
+	onProgress func(*TMessage),
 ) (*TMessage, error) {
 	requestId := mb.envelope.GetRequestId(ctx, msg)
 	if requestId == "" {
@@ -386,7 +419,7 @@ func (mb *MessageBroker[TMessage]) SendAndWaitWithProgress(
 		case resp, ok := <-ch:
 			if !ok {
 				mb.logger.Printf("[%s] [RequestId=%s] Channel closed (dispatcher likely stopped)", mb.name, requestId)
-				return nil, errors.New("channel closed by dispatcher")
+				return nil, fmt.Errorf("channel closed by dispatcher: %w", ErrBrokerClosed)
 			}
 
 			respInner := mb.envelope.GetInnerMessage(resp)
@@ -397,10 +430,7 @@ func (mb *MessageBroker[TMessage]) SendAndWaitWithProgress(
 			if mb.envelope.IsProgressMessage(resp) {
 				mb.logger.Printf("[%s] [RequestId=%s] Progress message, MessageType=%v", mb.name, requestId, respType)
 				if onProgress != nil {
-					progressText := mb.envelope.GetProgressMessage(resp)
-					if progressText != "" {
-						onProgress(progressText)
-					}
+					onProgress(resp)
 				}
 				// Continue waiting for more messages
 				continue
@@ -619,6 +649,7 @@ func (mb *MessageBroker[TMessage]) invokeHandler(
 	innerMsg any,
 ) *TMessage {
 	requestId := mb.envelope.GetRequestId(ctx, envelope)
+	ctx = context.WithValue(ctx, requestIdContextKey{}, requestId)
 
 	// Prepare arguments for handler invocation
 	args := []reflect.Value{
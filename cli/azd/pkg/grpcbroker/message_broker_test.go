@@ -329,8 +329,10 @@ func TestEndToEnd_ClientSendsServerResponds(t *testing.T) {
 
 	// Register server handler
 	handlerCalled := make(chan *TestRequest, 1)
+	handlerRequestId := make(chan string, 1)
 	handler := func(ctx context.Context, req *TestRequest) (*TestMessage, error) {
 		handlerCalled <- req
+		handlerRequestId <- RequestIdFromContext(ctx)
 		return &TestMessage{
 			InnerMsg: &TestResponse{Result: "processed: " + req.Value},
 		}, nil
@@ -371,6 +373,9 @@ func TestEndToEnd_ClientSendsServerResponds(t *testing.T) {
 	case <-time.After(1 * time.Second):
 		t.Fatal("handler not called")
 	}
+	// The handler context carries the id of the request it was invoked for.
+	assert.Equal(t, "req-123", <-handlerRequestId)
+	assert.Empty(t, RequestIdFromContext(ctx))
 
 	// Clean up
 	cancel()
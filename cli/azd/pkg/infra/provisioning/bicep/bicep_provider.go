@@ -3612,6 +3612,17 @@ func (p *BicepProvider) ensureParameters(
 			continue
 		}
 
+		// An AI deployments location resolved when the models were added is used as-is while it still has quota.
+		// It is checked again on every run rather than saved, so that a location that loses quota is re-resolved.
+		if hasMetadata && parameterType == provisioning.ParameterTypeString && !slices.Contains(locationParameters, key) {
+			if location, has := p.resolvedAiDeploymentLocation(ctx, key, azdMetadata); has {
+				configuredParameters[key] = azure.ArmParameter{
+					Value: location,
+				}
+				continue
+			}
+		}
+
 		// No saved value for this required parameter, we'll need to prompt for it.
 		parameterPrompts = append(parameterPrompts, struct {
 			key   string
@@ -93,7 +93,7 @@ func (a *BicepProvider) locationsWithQuotaFor(
 	// Each CognitiveServices/accounts resource consumes exactly 1 unit of
 	// the OpenAI.S0.AccountCount quota regardless of subscription tier.
 	requirements := []ai.QuotaRequirement{
-		{UsageName: "OpenAI.S0.AccountCount", MinCapacity: 1},
+		{UsageName: ai.AccountCountUsageName, MinCapacity: ai.DefaultMinAccountQuota},
 	}
 
 	for _, definedUsageName := range quotaFor {
@@ -107,11 +107,22 @@ func (a *BicepProvider) locationsWithQuotaFor(
 		})
 	}
 
-	results, err := a.aiModelService.ListLocationsWithQuota(ctx, subId, locations, requirements)
+	result, err := a.aiModelService.EvaluateLocationsWithQuota(ctx, subId, locations, requirements)
 	if err != nil {
 		return nil, fmt.Errorf("getting locations with quota: %w", err)
 	}
 
+	if len(result.UnsupportedLocations) > 0 && a.console != nil {
+		description := fmt.Sprintf(
+			"%s are not AI Services locations and were ignored.", ux.ListAsText(result.UnsupportedLocations))
+		if len(result.UnsupportedLocations) == 1 {
+			description = fmt.Sprintf(
+				"%s is not an AI Services location and was ignored.", result.UnsupportedLocations[0])
+		}
+		a.console.MessageUxItem(ctx, &ux.WarningMessage{Description: description})
+	}
+
+	results := result.Locations
 	if len(results) == 0 {
 		formattedQuota := make([]string, len(quotaFor))
 		for i, quota := range quotaFor {
@@ -128,6 +139,44 @@ func (a *BicepProvider) locationsWithQuotaFor(
 	return results, nil
 }
 
+// resolvedAiDeploymentLocation returns the location recorded as the default of an AI deployments location parameter,
+// which `azd add` resolves when a model is added, as long as it still has quota for the parameter's usage names. When
+// it no longer does, it warns and returns false, so the caller prompts for a new location.
+func (p *BicepProvider) resolvedAiDeploymentLocation(
+	ctx context.Context, key string, azdMetadata azure.AzdMetadata) (string, bool) {
+	location, has := aiDeploymentLocationDefault(azdMetadata)
+	if !has {
+		return "", false
+	}
+
+	if _, err := p.locationsWithQuotaFor(
+		ctx, p.env.GetSubscriptionId(), []string{location}, azdMetadata.UsageName); err != nil {
+		p.console.MessageUxItem(ctx, &ux.WarningMessage{
+			Description: fmt.Sprintf(
+				"The AI deployments for '%s' can no longer use %s (%s). Choose a different location.",
+				key, output.WithHighLightFormat(location), err),
+		})
+		return "", false
+	}
+
+	return location, true
+}
+
+// aiDeploymentLocationDefault returns the default of a location parameter that is checked for AI model quota.
+func aiDeploymentLocationDefault(azdMetadata azure.AzdMetadata) (string, bool) {
+	if azdMetadata.Type == nil || *azdMetadata.Type != azure.AzdMetadataTypeLocation ||
+		len(azdMetadata.UsageName) == 0 || azdMetadata.Default == nil {
+		return "", false
+	}
+
+	location, ok := azdMetadata.Default.(string)
+	if !ok || strings.TrimSpace(location) == "" {
+		return "", false
+	}
+
+	return location, true
+}
+
 type usageNameDetails struct {
 	UsageName string
 	Capacity  float64
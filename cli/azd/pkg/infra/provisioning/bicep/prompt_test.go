@@ -494,3 +494,69 @@ func TestPromptForParameterNumberDefaultStringTypeError(t *testing.T) {
 
 	require.Error(t, err)
 }
+
+func TestAiDeploymentLocationDefault(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata string
+		want     string
+		wantOk   bool
+	}{
+		{
+			name:     "resolved location",
+			metadata: `{"type": "location", "usageName": ["OpenAI.GlobalStandard.gpt-4o,10"], "default": "eastus2"}`,
+			want:     "eastus2",
+			wantOk:   true,
+		},
+		{
+			name:     "no default",
+			metadata: `{"type": "location", "usageName": ["OpenAI.GlobalStandard.gpt-4o,10"]}`,
+		},
+		{
+			name:     "no usage names",
+			metadata: `{"type": "location", "default": "eastus2"}`,
+		},
+		{
+			name:     "not a location",
+			metadata: `{"type": "generate", "usageName": ["OpenAI.GlobalStandard.gpt-4o,10"], "default": "eastus2"}`,
+		},
+		{
+			name:     "empty default",
+			metadata: `{"type": "location", "usageName": ["OpenAI.GlobalStandard.gpt-4o,10"], "default": " "}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			param := azure.ArmTemplateParameterDefinition{
+				Type:     "string",
+				Metadata: map[string]json.RawMessage{"azd": json.RawMessage(tt.metadata)},
+			}
+			azdMetadata, has := param.AzdMetadata()
+			require.True(t, has)
+
+			location, ok := aiDeploymentLocationDefault(azdMetadata)
+			require.Equal(t, tt.wantOk, ok)
+			require.Equal(t, tt.want, location)
+		})
+	}
+}
+
+func TestResolvedAiDeploymentLocationWithoutQuota(t *testing.T) {
+	t.Parallel()
+
+	mockContext := mocks.NewMockContext(t.Context())
+	p := &BicepProvider{env: environment.New("test"), console: mockContext.Console}
+
+	param := azure.ArmTemplateParameterDefinition{
+		Type: "string",
+		Metadata: map[string]json.RawMessage{"azd": json.RawMessage(
+			`{"type": "location", "usageName": ["OpenAI.GlobalStandard.gpt-4o,10"], "default": "eastus2"}`)},
+	}
+	azdMetadata, _ := param.AzdMetadata()
+
+	// Without quota information the resolved location can't be confirmed, so it falls back to prompting.
+	_, ok := p.resolvedAiDeploymentLocation(*mockContext.Context, "aiDeploymentsLocation", azdMetadata)
+	require.False(t, ok)
+	require.Contains(t, strings.Join(mockContext.Console.Output(), "\n"), "can no longer use")
+}
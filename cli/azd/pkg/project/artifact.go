@@ -22,6 +22,13 @@ const (
 
 	// MetadataKeyNote adds a note line below the artifact output.
 	MetadataKeyNote = "note"
+
+	// MetadataKeyLabel replaces the default "Endpoint" label of an endpoint.
+	MetadataKeyLabel = "label"
+
+	// MetadataKeyRole records what an endpoint is for, e.g. "primary" or "health".
+	// It is shown after the endpoint when no discriminator is set.
+	MetadataKeyRole = "role"
 )
 
 // ArtifactKind represents well-known artifact types in the Azure Developer CLI
@@ -97,12 +104,14 @@ func (a *Artifact) ToString(currentIndentation string) string {
 		label := "Endpoint"
 		discriminator := ""
 
-		if customLabel, has := a.Metadata["label"]; has {
+		if customLabel, has := a.Metadata[MetadataKeyLabel]; has {
 			label = customLabel
 		}
 
 		if customDiscriminator, has := a.Metadata["discriminator"]; has {
 			discriminator = customDiscriminator
+		} else if role := a.Metadata[MetadataKeyRole]; role != "" {
+			discriminator = fmt.Sprintf("(%s)", role)
 		}
 
 		// Endpoints are clickable (hyperlinked) by default, but can be disabled via metadata
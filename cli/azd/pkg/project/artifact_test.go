@@ -107,6 +107,24 @@ func TestArtifactToString_Endpoint(t *testing.T) {
 			},
 			shouldBeClickable: true,
 		},
+		{
+			name: "endpoint role is shown when there is no discriminator",
+			artifact: &Artifact{
+				Kind:         ArtifactKindEndpoint,
+				Location:     "https://example.com/healthz",
+				LocationKind: LocationKindRemote,
+				Metadata: map[string]string{
+					MetadataKeyLabel: "Health",
+					MetadataKeyRole:  "health",
+				},
+			},
+			contains: []string{
+				"- Health:",
+				"https://example.com/healthz",
+				"(health)",
+			},
+			shouldBeClickable: true,
+		},
 		{
 			name: "clickable=FALSE is case insensitive",
 			artifact: &Artifact{
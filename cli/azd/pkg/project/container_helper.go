@@ -477,6 +477,7 @@ func (ch *ContainerHelper) Build(
 		dockerOptions.BuildSecrets,
 		dockerEnv,
 		dockerOptions.Network,
+		dockerOptions.CacheFrom,
 		previewerWriter,
 	)
 	ch.console.StopPreviewer(ctx, false)
@@ -9,8 +9,10 @@ import (
 	"fmt"
 	"io/fs"
 	"log"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 
@@ -265,15 +267,26 @@ func (ai *DotNetImporter) Services(
 			return nil, err
 		}
 
+		bArgs, err := evaluateBuildArgs(*manifest, dockerfile.BuildArgs)
+		if err != nil {
+			return nil, fmt.Errorf("evaluating build args for service %s: %w", name, err)
+		}
+		bSecrets, reqEnv, err := buildArgsArrayAndEnv(*manifest, dockerfile.BuildSecrets)
+		if err != nil {
+			return nil, fmt.Errorf("converting build secrets to array for service %s: %w", name, err)
+		}
+
 		// TODO(ellismg): Some of this code is duplicated from project.Parse, we should centralize this logic long term.
 		svc := &ServiceConfig{
 			RelativePath: relPath,
 			Language:     ServiceLanguageDocker,
 			Host:         DotNetContainerAppTarget,
 			Docker: DockerProjectOptions{
-				Path:      dockerfile.Path,
-				Context:   dockerfile.Context,
-				BuildArgs: mapToExpandableStringSlice(dockerfile.BuildArgs, "="),
+				Path:         dockerfile.Path,
+				Context:      dockerfile.Context,
+				BuildArgs:    mapToExpandableStringSlice(bArgs, "="),
+				BuildSecrets: bSecrets,
+				BuildEnv:     reqEnv,
 			},
 		}
 
@@ -380,6 +393,7 @@ func (ai *DotNetImporter) Services(
 				BuildArgs:       mapToExpandableStringSlice(bArgs, "="),
 				BuildSecrets:    bArgsArray,
 				BuildEnv:        reqEnv,
+				CacheFrom:       bContainer.Build.CacheFrom,
 				InMemDockerfile: inMemDockerfile,
 			}
 		}
@@ -489,10 +503,10 @@ func buildArgsArrayAndEnv(
 func evaluateBuildArgs(
 	manifest apphost.Manifest, args map[string]string) (map[string]string, error) {
 	result := make(map[string]string, len(args))
-	for argKey, argValue := range args {
-		evaluatedValue, err := evaluateExpressionsFromArg(argValue, manifest)
+	for _, argKey := range slices.Sorted(maps.Keys(args)) {
+		evaluatedValue, err := evaluateExpressionsFromArg(args[argKey], manifest)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("build arg %q: %w", argKey, err)
 		}
 		result[argKey] = evaluatedValue
 
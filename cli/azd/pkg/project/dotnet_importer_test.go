@@ -4,6 +4,7 @@
 package project
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -108,6 +109,35 @@ func TestEvaluateArgsWithConfig(t *testing.T) {
 	require.ElementsMatch(t, mapToStringSlice(expected, ","), mapToStringSlice(result, ","))
 }
 
+func TestEvaluateBuildArgsFromManifest(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "aspire-build-args.json"))
+	require.NoError(t, err)
+
+	var manifest apphost.Manifest
+	require.NoError(t, json.Unmarshal(data, &manifest))
+
+	t.Run("dockerfile.v0", func(t *testing.T) {
+		result, err := evaluateBuildArgs(manifest, apphost.Dockerfiles(&manifest)["worker"].BuildArgs)
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{
+			"BASE_VERSION":  "9.0",
+			"MIRROR":        "{infra.parameters.registry_mirror}",
+			"CONFIGURATION": "Release",
+		}, result)
+	})
+
+	t.Run("container.v1", func(t *testing.T) {
+		result, err := evaluateBuildArgs(manifest, manifest.Resources["api"].Build.Args)
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"BASE_VERSION": "dotnet-9.0"}, result)
+	})
+
+	t.Run("unresolvable reference", func(t *testing.T) {
+		_, err := evaluateBuildArgs(manifest, apphost.Dockerfiles(&manifest)["broken"].BuildArgs)
+		require.ErrorContains(t, err, `build arg "FEATURE_FLAG": resource "missing-param" not found in manifest`)
+	})
+}
+
 func TestBuildArgsArrayAndEnv(t *testing.T) {
 	manifest := apphost.Manifest{
 		Resources: map[string]*apphost.Resource{
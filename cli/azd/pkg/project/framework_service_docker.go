@@ -33,6 +33,9 @@ type DockerProjectOptions struct {
 	// Aspire would pass the secret keys, which are env vars that azd will set just to run docker build.
 	BuildSecrets []string `yaml:"-"                     json:"-"`
 	BuildEnv     []string `yaml:"-"                     json:"-"`
+	// not supported from azure.yaml directly yet. Aspire passes the cache sources of a container.v1 build, and
+	// each one becomes a --cache-from of docker build.
+	CacheFrom []string `yaml:"-"                     json:"-"`
 	//InMemDockerfile allow projects to specify a dockerfile contents directly instead of a path on disk.
 	// This is not supported from azure.yaml.
 	// This is used by projects like Aspire that can generate a dockerfile on the fly and don't want to write it to disk.
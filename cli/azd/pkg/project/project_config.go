@@ -39,6 +39,8 @@ type ProjectConfig struct {
 	Workflows         workflow.WorkflowMap       `yaml:"workflows,omitempty"`
 	Cloud             *cloud.Config              `yaml:"cloud,omitempty"`
 	Resources         map[string]*ResourceConfig `yaml:"resources,omitempty"`
+	// PreferredAiSkus orders the AI model deployment SKUs azd offers and picks by default, most preferred first.
+	PreferredAiSkus []string `yaml:"preferredAiSkus,omitempty"`
 
 	// AdditionalProperties captures any unknown YAML fields for extension support
 	AdditionalProperties map[string]any `yaml:",inline"`
@@ -6,6 +6,7 @@ package project
 import (
 	"fmt"
 
+	"github.com/azure/azure-dev/cli/azd/pkg/ai"
 	"github.com/braydonk/yaml"
 )
 
@@ -311,6 +312,8 @@ type AIModelProps struct {
 type AIModelPropsModel struct {
 	Name    string `yaml:"name,omitempty"`
 	Version string `yaml:"version,omitempty"`
+	// The format of the model, e.g. OpenAI. Defaults to OpenAI when empty.
+	Format string `yaml:"format,omitempty"`
 }
 
 type CosmosDBProps struct {
@@ -340,6 +343,9 @@ type AiServicesModel struct {
 	Version string             `yaml:"version,omitempty"`
 	Format  string             `yaml:"format,omitempty"`
 	Sku     AiServicesModelSku `yaml:"sku,omitempty"`
+	// Location is the region the deployment was resolved for when the model was added. Provisioning deploys there
+	// while it still has quota for the deployment.
+	Location string `yaml:"location,omitempty"`
 }
 
 type AiServicesModelSku struct {
@@ -348,6 +354,21 @@ type AiServicesModelSku struct {
 	Capacity  int32  `yaml:"capacity,omitempty"`
 }
 
+// NewAiServicesModel returns the ai.project model entry that deploys d, including the location d was resolved for.
+func NewAiServicesModel(d ai.AiModelDeployment) AiServicesModel {
+	return AiServicesModel{
+		Name:    d.ModelName,
+		Version: d.Version,
+		Format:  d.Format,
+		Sku: AiServicesModelSku{
+			Name:      d.Sku.Name,
+			UsageName: d.Sku.UsageName,
+			Capacity:  d.Capacity,
+		},
+		Location: d.Location,
+	}
+}
+
 type AiFoundryModelProps struct {
 	Models []AiServicesModel `yaml:"models,omitempty"`
 }
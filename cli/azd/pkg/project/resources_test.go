@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/azure/azure-dev/cli/azd/internal/scaffold"
+	"github.com/azure/azure-dev/cli/azd/pkg/ai"
 	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
 )
 
@@ -315,6 +316,24 @@ model:
 	require.True(t, ok)
 	assert.Equal(t, "gpt-4o", props.Model.Name)
 	assert.Equal(t, "2024-08-06", props.Model.Version)
+	assert.Empty(t, props.Model.Format)
+}
+
+func Test_ResourceConfig_UnmarshalYAML_OpenAiModelFormat(t *testing.T) {
+	yamlData := `
+type: ai.openai.model
+model:
+  name: embed-v-4-0
+  version: "1"
+  format: Cohere
+`
+	var rc ResourceConfig
+	err := yaml.Unmarshal([]byte(yamlData), &rc)
+	require.NoError(t, err)
+
+	props, ok := rc.Props.(AIModelProps)
+	require.True(t, ok)
+	assert.Equal(t, "Cohere", props.Model.Format)
 }
 
 func Test_ResourceConfig_UnmarshalYAML_Storage(t *testing.T) {
@@ -436,6 +455,39 @@ func Test_ResourceConfig_RoundTrip_ContainerApp(t *testing.T) {
 	assert.Equal(t, 9090, props.Port)
 }
 
+func Test_ResourceConfig_RoundTrip_OpenAiModelFormat(t *testing.T) {
+	original := &ResourceConfig{
+		Name: "embeddings",
+		Type: ResourceTypeOpenAiModel,
+		Props: AIModelProps{
+			Model: AIModelPropsModel{
+				Name:    "embed-v-4-0",
+				Version: "1",
+				Format:  "Cohere",
+			},
+		},
+	}
+
+	data, err := yaml.Marshal(original)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "format: Cohere")
+
+	var restored ResourceConfig
+	err = yaml.Unmarshal(data, &restored)
+	require.NoError(t, err)
+	restored.Name = original.Name
+
+	props, ok := restored.Props.(AIModelProps)
+	require.True(t, ok)
+	assert.Equal(t, original.Props, props)
+
+	// The generated infrastructure deploys the stored format rather than assuming OpenAI.
+	spec, err := infraSpec(&ProjectConfig{Resources: map[string]*ResourceConfig{"embeddings": &restored}})
+	require.NoError(t, err)
+	require.Len(t, spec.AIModels, 1)
+	assert.Equal(t, "Cohere", spec.AIModels[0].Model.Format)
+}
+
 // expandableStringTemplate extracts the template string from an ExpandableString
 // by converting it to string via its MarshalYAML/String representation.
 func expandableStringTemplate(es osutil.ExpandableString) string {
@@ -1215,3 +1267,31 @@ func Test_mapHostUses_ExistingResource(t *testing.T) {
 		assert.Equal(t, "existingStorage", svcSpec.Existing[0].Name)
 	})
 }
+
+func Test_NewAiServicesModel(t *testing.T) {
+	model := NewAiServicesModel(ai.AiModelDeployment{
+		ModelName: "gpt-4o",
+		Format:    "OpenAI",
+		Version:   "2024-08-06",
+		Location:  "eastus2",
+		Sku: ai.AiModelSku{
+			Name:         "GlobalStandard",
+			UsageName:    "OpenAI.GlobalStandard.gpt-4o",
+			CapacityStep: 10,
+		},
+		Capacity:       50,
+		RemainingQuota: new(float64(100)),
+	})
+
+	require.Equal(t, AiServicesModel{
+		Name:    "gpt-4o",
+		Version: "2024-08-06",
+		Format:  "OpenAI",
+		Sku: AiServicesModelSku{
+			Name:      "GlobalStandard",
+			UsageName: "OpenAI.GlobalStandard.gpt-4o",
+			Capacity:  50,
+		},
+		Location: "eastus2",
+	}, model)
+}
@@ -279,6 +279,7 @@ func infraSpec(projectConfig *ProjectConfig) (*scaffold.InfraSpec, error) {
 				Model: scaffold.AIModelModel{
 					Name:    props.Model.Name,
 					Version: props.Model.Version,
+					Format:  props.Model.Format,
 				},
 			})
 		case ResourceTypeMessagingEventHubs:
@@ -330,6 +331,7 @@ func infraSpec(projectConfig *ProjectConfig) (*scaffold.InfraSpec, error) {
 				})
 			}
 			foundrySpec.Models = foundryModels
+			foundrySpec.Location = aiModelsLocation(props.Models)
 			infraSpec.AiFoundryProject = &foundrySpec
 		case ResourceTypeKeyVault:
 			infraSpec.KeyVault = &scaffold.KeyVault{}
@@ -357,6 +359,24 @@ func infraSpec(projectConfig *ProjectConfig) (*scaffold.InfraSpec, error) {
 	return &infraSpec, nil
 }
 
+// aiModelsLocation returns the location the models were resolved for, or "" when none records one or they disagree.
+// All the models of an AI project deploy to a single location.
+func aiModelsLocation(models []AiServicesModel) string {
+	location := ""
+	for _, model := range models {
+		if model.Location == "" {
+			continue
+		}
+		if location == "" {
+			location = model.Location
+		} else if !strings.EqualFold(location, model.Location) {
+			return ""
+		}
+	}
+
+	return location
+}
+
 // mergeDefaultEnvVars combines default environment variables with user-provided ones.
 func mergeDefaultEnvVars(defaultEnv map[string]string, userEnv []ServiceEnvVar) []ServiceEnvVar {
 	// Map to track which env vars are provided by the user
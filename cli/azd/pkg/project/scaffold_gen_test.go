@@ -173,6 +173,38 @@ func Test_MergeDefaultEnvVars(t *testing.T) {
 	})
 }
 
+func Test_aiModelsLocation(t *testing.T) {
+	tests := []struct {
+		name   string
+		models []AiServicesModel
+		want   string
+	}{
+		{"NoModels", nil, ""},
+		{"NoLocations", []AiServicesModel{{Name: "gpt-4o"}}, ""},
+		{
+			"SameLocation",
+			[]AiServicesModel{{Name: "gpt-4o", Location: "eastus2"}, {Name: "gpt-4o-mini", Location: "EastUS2"}},
+			"eastus2",
+		},
+		{
+			"SomeWithoutLocation",
+			[]AiServicesModel{{Name: "gpt-4o"}, {Name: "gpt-4o-mini", Location: "swedencentral"}},
+			"swedencentral",
+		},
+		{
+			"DifferentLocations",
+			[]AiServicesModel{{Name: "gpt-4o", Location: "eastus2"}, {Name: "gpt-4o-mini", Location: "westus"}},
+			"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, aiModelsLocation(tt.models))
+		})
+	}
+}
+
 func Test_EmitVariable_LiteralValue(t *testing.T) {
 	emitEnv := EmitEnv{
 		FuncMap:         scaffold.BaseEmitBicepFuncMap(),
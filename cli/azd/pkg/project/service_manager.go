@@ -23,6 +23,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/ext"
+	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
 	"github.com/azure/azure-dev/cli/azd/pkg/ioc"
 	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools"
@@ -666,6 +667,22 @@ func (sm *serviceManager) GetServiceTarget(ctx context.Context, serviceConfig *S
 
 	if err := sm.serviceLocator.ResolveNamed(host, &target); err != nil {
 		if errors.Is(err, ioc.ErrResolveInstance) {
+			if extension := sm.findServiceTargetExtension(host); extension != nil {
+				return nil, &internal.ErrorWithSuggestion{
+					Err: fmt.Errorf(
+						"service host '%s' for service '%s' is provided by the '%s' extension, "+
+							"but the extension did not register it",
+						host,
+						serviceConfig.Name,
+						extension.Id,
+					),
+					Suggestion: fmt.Sprintf(
+						"Suggestion: make sure the extension is up to date by running 'azd extension upgrade %s'",
+						extension.Id,
+					),
+				}
+			}
+
 			unsupportedErr := &UnsupportedServiceHostError{
 				Host:        host,
 				ServiceName: serviceConfig.Name,
@@ -691,6 +708,22 @@ func (sm *serviceManager) GetServiceTarget(ctx context.Context, serviceConfig *S
 	return target, nil
 }
 
+// findServiceTargetExtension returns the installed extension advertising the service host, or nil when there is none
+// or the extension manager is not available.
+func (sm *serviceManager) findServiceTargetExtension(host string) *extensions.Extension {
+	var extensionManager *extensions.Manager
+	if err := sm.serviceLocator.Resolve(&extensionManager); err != nil || extensionManager == nil {
+		return nil
+	}
+
+	extension, err := extensionManager.FindServiceTargetProvider(host)
+	if err != nil {
+		return nil
+	}
+
+	return extension
+}
+
 // GetFrameworkService constructs a framework service from the underlying service configuration
 func (sm *serviceManager) GetFrameworkService(ctx context.Context, serviceConfig *ServiceConfig) (FrameworkService, error) {
 	var frameworkService FrameworkService
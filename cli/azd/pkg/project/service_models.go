@@ -4,6 +4,9 @@
 package project
 
 import (
+	"cmp"
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -43,6 +46,37 @@ type ServiceLifecycleEventArgs struct {
 type ServiceProgress struct {
 	Message   string
 	Timestamp time.Time
+	// Phase is the stage of a long operation the message belongs to, e.g. "upload". Empty when not reported.
+	Phase string
+	// PercentComplete is how far the operation is, from 0 to 100, or nil when not reported.
+	PercentComplete *int32
+	// Detail adds to Message, e.g. the file being uploaded. Empty when not reported.
+	Detail string
+}
+
+// String formats the progress for display, e.g. "Uploading artifacts: app.zip (upload, 40%)". Progress with only a
+// message is shown as the message itself.
+func (p ServiceProgress) String() string {
+	text := cmp.Or(p.Message, p.Phase)
+	switch {
+	case p.Detail != "" && text == "":
+		text = p.Detail
+	case p.Detail != "":
+		text += ": " + p.Detail
+	}
+
+	var status []string
+	if p.Phase != "" && p.Message != "" {
+		status = append(status, p.Phase)
+	}
+	if p.PercentComplete != nil {
+		status = append(status, fmt.Sprintf("%d%%", *p.PercentComplete))
+	}
+	if len(status) > 0 {
+		text += fmt.Sprintf(" (%s)", strings.Join(status, ", "))
+	}
+
+	return text
 }
 
 // NewServiceProgress is a helper method to create a new
@@ -54,6 +88,16 @@ func NewServiceProgress(message string) ServiceProgress {
 	}
 }
 
+// NewStructuredServiceProgress is a helper method to create a new progress message with a phase, completion percent and
+// detail. percentComplete may be nil when the operation can't estimate how far along it is.
+func NewStructuredServiceProgress(message, phase, detail string, percentComplete *int32) ServiceProgress {
+	progress := NewServiceProgress(message)
+	progress.Phase = phase
+	progress.Detail = detail
+	progress.PercentComplete = percentComplete
+	return progress
+}
+
 // ServiceRestoreResult is the result of a successful Restore operation
 type ServiceRestoreResult struct {
 	Artifacts ArtifactCollection `json:"artifacts"`
@@ -8,6 +8,12 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/azure/azure-dev/cli/azd/internal/mapper"
 	"github.com/azure/azure-dev/cli/azd/pkg/async"
@@ -22,6 +28,19 @@ import (
 	"github.com/google/uuid"
 )
 
+// serviceTargetReconnectTimeout bounds how long a request waits for the extension to re-establish its service target
+// stream after a disconnect, and serviceTargetMaxReconnects bounds how many times a single request is re-issued.
+var (
+	serviceTargetReconnectTimeout = 10 * time.Second
+	serviceTargetMaxReconnects    = 3
+)
+
+// endpointRolePrimary is the role an extension gives the endpoint that is the service's main URL.
+const endpointRolePrimary = "primary"
+
+// deployRequestIdNamespace is the UUID namespace deploy request ids are derived in by deployRequestId.
+var deployRequestIdNamespace = uuid.MustParse("8b5c7e0a-3f2d-5c1e-9a4b-6d7e8f901a2b")
+
 type ExternalServiceTarget struct {
 	extension  *extensions.Extension
 	targetName string
@@ -30,7 +49,14 @@ type ExternalServiceTarget struct {
 	prompters  prompt.Prompter
 	lazyEnv    *lazy.Lazy[*environment.Environment]
 
-	broker *grpcbroker.MessageBroker[azdext.ServiceTargetMessage]
+	brokerMu sync.Mutex
+	broker   *grpcbroker.MessageBroker[azdext.ServiceTargetMessage]
+	// reconnected is closed (and replaced) each time Reconnect swaps in a new broker.
+	reconnected chan struct{}
+
+	deploysMu sync.Mutex
+	// deploys holds the derived request ids of the deploys in progress.
+	deploys map[string]bool
 }
 
 type TargetResourceResolver interface {
@@ -53,18 +79,146 @@ func NewExternalServiceTarget(
 	lazyEnv *lazy.Lazy[*environment.Environment],
 ) ServiceTarget {
 	target := &ExternalServiceTarget{
-		extension:  extension,
-		targetName: name,
-		targetKind: kind,
-		console:    console,
-		prompters:  prompters,
-		lazyEnv:    lazyEnv,
-		broker:     broker,
+		extension:   extension,
+		targetName:  name,
+		targetKind:  kind,
+		console:     console,
+		prompters:   prompters,
+		lazyEnv:     lazyEnv,
+		broker:      broker,
+		reconnected: make(chan struct{}),
 	}
 
 	return target
 }
 
+// Reconnect replaces the message broker used to reach the extension, after the extension re-established its service
+// target stream. Requests that were waiting on the lost stream are re-issued on the new broker.
+func (est *ExternalServiceTarget) Reconnect(broker *grpcbroker.MessageBroker[azdext.ServiceTargetMessage]) {
+	est.brokerMu.Lock()
+	defer est.brokerMu.Unlock()
+
+	est.broker = broker
+	if est.reconnected != nil {
+		close(est.reconnected)
+	}
+	est.reconnected = make(chan struct{})
+}
+
+func (est *ExternalServiceTarget) currentBroker() (
+	*grpcbroker.MessageBroker[azdext.ServiceTargetMessage], <-chan struct{},
+) {
+	est.brokerMu.Lock()
+	defer est.brokerMu.Unlock()
+
+	return est.broker, est.reconnected
+}
+
+// sendAndWait sends req to the extension and waits for the response.
+func (est *ExternalServiceTarget) sendAndWait(
+	ctx context.Context,
+	req *azdext.ServiceTargetMessage,
+) (*azdext.ServiceTargetMessage, error) {
+	return est.send(ctx, req, func(broker *grpcbroker.MessageBroker[azdext.ServiceTargetMessage]) (
+		*azdext.ServiceTargetMessage, error,
+	) {
+		return broker.SendAndWait(ctx, req)
+	})
+}
+
+// sendAndWaitWithProgress sends req to the extension and waits for the response, reporting progress messages.
+func (est *ExternalServiceTarget) sendAndWaitWithProgress(
+	ctx context.Context,
+	req *azdext.ServiceTargetMessage,
+	onProgress func(ServiceProgress),
+) (*azdext.ServiceTargetMessage, error) {
+	return est.send(ctx, req, func(broker *grpcbroker.MessageBroker[azdext.ServiceTargetMessage]) (
+		*azdext.ServiceTargetMessage, error,
+	) {
+		return broker.SendAndWaitWithProgressMessages(ctx, req, func(msg *azdext.ServiceTargetMessage) {
+			if progress, ok := serviceProgressFromProto(msg.GetProgressMessage()); ok && onProgress != nil {
+				onProgress(progress)
+			}
+		})
+	})
+}
+
+// serviceProgressFromProto converts a progress message from an extension. Messages that only carry text have no
+// phase, percentage or detail. It reports false when the message has nothing to show.
+func serviceProgressFromProto(msg *azdext.ServiceTargetProgressMessage) (ServiceProgress, bool) {
+	if msg.GetMessage() == "" && msg.GetPhase() == "" && msg.GetDetail() == "" {
+		return ServiceProgress{}, false
+	}
+
+	var percentComplete *int32
+	if msg.PercentComplete != nil {
+		percentComplete = new(min(max(msg.GetPercentComplete(), 0), 100))
+	}
+
+	return NewStructuredServiceProgress(msg.GetMessage(), msg.GetPhase(), msg.GetDetail(), percentComplete), true
+}
+
+// send issues req using the current broker. When the stream to the extension is lost before a response arrives, it
+// waits for the extension to reconnect and re-issues req with the same RequestId, so that an idempotent extension can
+// resume the operation. Reconnection is bounded by serviceTargetReconnectTimeout and serviceTargetMaxReconnects. When
+// ctx is cancelled while waiting for the response, the extension is sent a cancel request for req.
+func (est *ExternalServiceTarget) send(
+	ctx context.Context,
+	req *azdext.ServiceTargetMessage,
+	sendFn func(*grpcbroker.MessageBroker[azdext.ServiceTargetMessage]) (*azdext.ServiceTargetMessage, error),
+) (*azdext.ServiceTargetMessage, error) {
+	for attempt := 0; ; attempt++ {
+		broker, reconnected := est.currentBroker()
+
+		resp, err := sendFn(broker)
+		if err != nil && ctx.Err() != nil && !errors.Is(err, grpcbroker.ErrBrokerClosed) {
+			est.cancelRequest(ctx, broker, req.RequestId)
+			return nil, err
+		}
+		if !errors.Is(err, grpcbroker.ErrBrokerClosed) {
+			return resp, err
+		}
+
+		if attempt >= serviceTargetMaxReconnects {
+			return nil, fmt.Errorf(
+				"service target %q disconnected %d times while handling request %s: %w",
+				est.targetName, attempt+1, req.RequestId, err)
+		}
+
+		log.Printf(
+			"service target %q disconnected while handling request %s, waiting for the extension to reconnect",
+			est.targetName, req.RequestId)
+
+		select {
+		case <-reconnected:
+		case <-time.After(serviceTargetReconnectTimeout):
+			return nil, fmt.Errorf(
+				"lost connection to service target %q and the extension did not reconnect within %s: %w",
+				est.targetName, serviceTargetReconnectTimeout, err)
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// cancelRequest tells the extension that the request with requestId was cancelled, so that it can abort the operation.
+// Failures are only logged, since the request was already given up on.
+func (est *ExternalServiceTarget) cancelRequest(
+	ctx context.Context,
+	broker *grpcbroker.MessageBroker[azdext.ServiceTargetMessage],
+	requestId string,
+) {
+	err := broker.Send(context.WithoutCancel(ctx), &azdext.ServiceTargetMessage{
+		RequestId: uuid.NewString(),
+		MessageType: &azdext.ServiceTargetMessage_CancelRequest{
+			CancelRequest: &azdext.ServiceTargetCancelRequest{RequestId: requestId},
+		},
+	})
+	if err != nil {
+		log.Printf("sending cancel request for request %s to service target %q: %v", requestId, est.targetName, err)
+	}
+}
+
 // toProtoServiceConfig converts a ServiceConfig to its proto representation, expanding
 // expandable values against the environment for the current session.
 func (est *ExternalServiceTarget) toProtoServiceConfig(serviceConfig *ServiceConfig) (*azdext.ServiceConfig, error) {
@@ -110,7 +264,7 @@ func (est *ExternalServiceTarget) Publish(
 		},
 	}
 
-	resp, err := est.broker.SendAndWaitWithProgress(ctx, req, createProgressFunc(progress))
+	resp, err := est.sendAndWaitWithProgress(ctx, req, createServiceProgressFunc(progress))
 	if err != nil {
 		return nil, err
 	}
@@ -149,7 +303,7 @@ func (est *ExternalServiceTarget) Initialize(ctx context.Context, serviceConfig
 		},
 	}
 
-	_, err = est.broker.SendAndWait(ctx, req)
+	_, err = est.sendAndWait(ctx, req)
 	return err
 }
 
@@ -188,7 +342,7 @@ func (est *ExternalServiceTarget) Package(
 		},
 	}
 
-	resp, err := est.broker.SendAndWaitWithProgress(ctx, req, createProgressFunc(progress))
+	resp, err := est.sendAndWaitWithProgress(ctx, req, createServiceProgressFunc(progress))
 	if err != nil {
 		return nil, err
 	}
@@ -231,7 +385,8 @@ func (est *ExternalServiceTarget) Deploy(
 	}
 
 	// Create Deploy request message
-	requestId := uuid.NewString()
+	requestId, release := est.claimDeployRequestId(serviceConfig, serviceContext, targetResource)
+	defer release()
 	req := &azdext.ServiceTargetMessage{
 		RequestId: requestId,
 		MessageType: &azdext.ServiceTargetMessage_DeployRequest{
@@ -244,7 +399,7 @@ func (est *ExternalServiceTarget) Deploy(
 	}
 
 	// Send request and wait for response, handling progress messages
-	resp, err := est.broker.SendAndWaitWithProgress(ctx, req, createProgressFunc(progress))
+	resp, err := est.sendAndWaitWithProgress(ctx, req, createServiceProgressFunc(progress))
 	if err != nil {
 		return nil, err
 	}
@@ -260,9 +415,113 @@ func (est *ExternalServiceTarget) Deploy(
 		return nil, fmt.Errorf("failed to convert deploy result: %w", err)
 	}
 
+	for _, endpoint := range mergeServiceEndpoints(nil, deployResponse.Result.Endpoints) {
+		if err := result.Artifacts.Add(endpointArtifact(endpoint)); err != nil {
+			return nil, fmt.Errorf("failed to add deploy endpoint: %w", err)
+		}
+	}
+
 	return result, nil
 }
 
+// claimDeployRequestId returns the request id of a deploy: the id derived by deployRequestId, so that deploying the same
+// package to the same target again reuses it, or a fresh id when the id cannot be derived or is in use by a concurrent
+// deploy. The returned func releases the id once the deploy completes.
+func (est *ExternalServiceTarget) claimDeployRequestId(
+	serviceConfig *ServiceConfig,
+	serviceContext *ServiceContext,
+	targetResource *environment.TargetResource,
+) (string, func()) {
+	extensionId := ""
+	if est.extension != nil {
+		extensionId = est.extension.Id
+	}
+
+	requestId, ok := deployRequestId(extensionId, est.targetName, serviceConfig, serviceContext, targetResource)
+	if !ok {
+		return uuid.NewString(), func() {}
+	}
+
+	est.deploysMu.Lock()
+	defer est.deploysMu.Unlock()
+
+	if est.deploys[requestId] {
+		// A concurrent deploy of the same package is a distinct operation, and the broker correlates responses by id.
+		return uuid.NewString(), func() {}
+	}
+
+	if est.deploys == nil {
+		est.deploys = map[string]bool{}
+	}
+	est.deploys[requestId] = true
+
+	return requestId, func() {
+		est.deploysMu.Lock()
+		defer est.deploysMu.Unlock()
+
+		delete(est.deploys, requestId)
+	}
+}
+
+// deployRequestId derives the request id of deploying the package of a service to targetResource: a version 5 UUID
+// of the extension id, the target name, the service name, the target resource and the package and publish artifacts.
+// Local file artifacts also contribute their size and modification time, so a rebuilt package gets a new id. It
+// reports false when serviceContext has no package or publish artifacts to identify the deploy by.
+func deployRequestId(
+	extensionId string,
+	targetName string,
+	serviceConfig *ServiceConfig,
+	serviceContext *ServiceContext,
+	targetResource *environment.TargetResource,
+) (string, bool) {
+	if serviceContext == nil || len(serviceContext.Package)+len(serviceContext.Publish) == 0 {
+		return "", false
+	}
+
+	lines := []string{
+		"extension=" + extensionId,
+		"target=" + targetName,
+	}
+	if serviceConfig != nil {
+		lines = append(lines, "service="+serviceConfig.Name)
+	}
+	if targetResource != nil {
+		lines = append(lines, fmt.Sprintf("resource=%s/%s/%s/%s",
+			targetResource.SubscriptionId(),
+			targetResource.ResourceGroupName(),
+			targetResource.ResourceType(),
+			targetResource.ResourceName()))
+	}
+
+	for phase, artifacts := range map[string]ArtifactCollection{
+		"package": serviceContext.Package,
+		"publish": serviceContext.Publish,
+	} {
+		for _, artifact := range artifacts {
+			lines = append(lines, phase+"="+artifactFingerprint(artifact))
+		}
+	}
+	slices.Sort(lines)
+
+	return uuid.NewSHA1(deployRequestIdNamespace, []byte(strings.Join(lines, "\n"))).String(), true
+}
+
+// artifactFingerprint describes artifact for deployRequestId.
+func artifactFingerprint(artifact *Artifact) string {
+	parts := []string{string(artifact.Kind), string(artifact.LocationKind), artifact.Location}
+	for _, key := range slices.Sorted(maps.Keys(artifact.Metadata)) {
+		parts = append(parts, key+"="+artifact.Metadata[key])
+	}
+
+	if artifact.LocationKind == LocationKindLocal {
+		if info, err := os.Stat(artifact.Location); err == nil && info.Mode().IsRegular() {
+			parts = append(parts, fmt.Sprintf("size=%d", info.Size()), fmt.Sprintf("modified=%d", info.ModTime().UnixNano()))
+		}
+	}
+
+	return strings.Join(parts, "|")
+}
+
 // Endpoints gets the endpoints a service exposes.
 func (est *ExternalServiceTarget) Endpoints(
 	ctx context.Context,
@@ -288,7 +547,7 @@ func (est *ExternalServiceTarget) Endpoints(
 		},
 	}
 
-	resp, err := est.broker.SendAndWait(ctx, req)
+	resp, err := est.sendAndWait(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -298,8 +557,69 @@ func (est *ExternalServiceTarget) Endpoints(
 		return []string{}, nil
 	}
 
-	return append([]string{}, endpointsResp.Endpoints...), nil
+	endpoints := mergeServiceEndpoints(endpointsResp.Endpoints, endpointsResp.LabeledEndpoints)
+	urls := make([]string, len(endpoints))
+	for i, endpoint := range endpoints {
+		urls[i] = endpoint.GetUrl()
+	}
+
+	return urls, nil
+}
+
+// mergeServiceEndpoints combines the bare URLs and labeled endpoints an extension reported, keeping their order except
+// that the primary endpoint comes first, since callers such as `azd show` treat the first endpoint as the service's URL.
+// A labeled endpoint replaces a bare URL it repeats, and labeled endpoints without a URL are dropped.
+func mergeServiceEndpoints(urls []string, labeled []*azdext.ServiceEndpoint) []*azdext.ServiceEndpoint {
+	endpoints := make([]*azdext.ServiceEndpoint, 0, len(urls)+len(labeled))
+	for _, url := range urls {
+		endpoints = append(endpoints, &azdext.ServiceEndpoint{Url: url})
+	}
+
+	for _, endpoint := range labeled {
+		if endpoint.GetUrl() == "" {
+			continue
+		}
+
+		i := slices.IndexFunc(endpoints, func(e *azdext.ServiceEndpoint) bool { return e.GetUrl() == endpoint.GetUrl() })
+		if i >= 0 {
+			endpoints[i] = endpoint
+		} else {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+
+	isPrimary := func(e *azdext.ServiceEndpoint) bool { return strings.EqualFold(e.GetRole(), endpointRolePrimary) }
+	slices.SortStableFunc(endpoints, func(a, b *azdext.ServiceEndpoint) int {
+		switch {
+		case isPrimary(a) && !isPrimary(b):
+			return -1
+		case isPrimary(b) && !isPrimary(a):
+			return 1
+		default:
+			return 0
+		}
+	})
 
+	return endpoints
+}
+
+// endpointArtifact converts an extension-reported endpoint into an endpoint artifact, carrying its label and role as
+// display metadata.
+func endpointArtifact(endpoint *azdext.ServiceEndpoint) *Artifact {
+	metadata := map[string]string{}
+	if endpoint.GetLabel() != "" {
+		metadata[MetadataKeyLabel] = endpoint.GetLabel()
+	}
+	if endpoint.GetRole() != "" {
+		metadata[MetadataKeyRole] = endpoint.GetRole()
+	}
+
+	return &Artifact{
+		Kind:         ArtifactKindEndpoint,
+		Location:     endpoint.GetUrl(),
+		LocationKind: LocationKindRemote,
+		Metadata:     metadata,
+	}
 }
 
 // ResolveTargetResource resolves the Azure target resource for the service configuration via the extension.
@@ -341,7 +661,7 @@ func (est *ExternalServiceTarget) ResolveTargetResource(
 		},
 	}
 
-	resp, err := est.broker.SendAndWait(ctx, req)
+	resp, err := est.sendAndWait(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -405,6 +725,15 @@ func serviceConfigToProto(
 	return protoConfig, nil
 }
 
+// createServiceProgressFunc returns a callback that reports each progress update to progress, which may be nil.
+func createServiceProgressFunc(progress *async.Progress[ServiceProgress]) func(ServiceProgress) {
+	return func(update ServiceProgress) {
+		if progress != nil {
+			progress.SetProgress(update)
+		}
+	}
+}
+
 func createProgressFunc(progress *async.Progress[ServiceProgress]) func(string) {
 	return func(message string) {
 		if progress != nil {
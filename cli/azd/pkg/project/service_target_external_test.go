@@ -0,0 +1,481 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/async"
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/grpcbroker"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeServiceTargetStream is the azd side of a service target stream. Messages sent by azd are delivered on
+// toExtension; closing fromExtension simulates the extension dropping the stream.
+type fakeServiceTargetStream struct {
+	toExtension   chan *azdext.ServiceTargetMessage
+	fromExtension chan *azdext.ServiceTargetMessage
+}
+
+func newFakeServiceTargetStream() *fakeServiceTargetStream {
+	return &fakeServiceTargetStream{
+		toExtension:   make(chan *azdext.ServiceTargetMessage, 10),
+		fromExtension: make(chan *azdext.ServiceTargetMessage, 10),
+	}
+}
+
+func (s *fakeServiceTargetStream) Send(msg *azdext.ServiceTargetMessage) error {
+	s.toExtension <- msg
+	return nil
+}
+
+func (s *fakeServiceTargetStream) Recv() (*azdext.ServiceTargetMessage, error) {
+	msg, ok := <-s.fromExtension
+	if !ok {
+		return nil, io.EOF
+	}
+	return msg, nil
+}
+
+func startFakeServiceTargetBroker(
+	t *testing.T,
+	stream *fakeServiceTargetStream,
+) *grpcbroker.MessageBroker[azdext.ServiceTargetMessage] {
+	broker := grpcbroker.NewMessageBroker(stream, azdext.NewServiceTargetEnvelope(), "test", nil)
+	go func() {
+		_ = broker.Run(t.Context())
+	}()
+	require.NoError(t, broker.Ready(t.Context()))
+
+	return broker
+}
+
+func endpointsRequest(requestId string) *azdext.ServiceTargetMessage {
+	return &azdext.ServiceTargetMessage{
+		RequestId: requestId,
+		MessageType: &azdext.ServiceTargetMessage_EndpointsRequest{
+			EndpointsRequest: &azdext.ServiceTargetEndpointsRequest{},
+		},
+	}
+}
+
+func Test_ExternalServiceTarget_ReissuesRequestAfterReconnect(t *testing.T) {
+	firstStream := newFakeServiceTargetStream()
+	target := NewExternalServiceTarget(
+		"test-target", ContainerAppTarget, nil, startFakeServiceTargetBroker(t, firstStream), nil, nil, nil,
+	).(*ExternalServiceTarget)
+
+	type result struct {
+		resp *azdext.ServiceTargetMessage
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		resp, err := target.sendAndWait(t.Context(), endpointsRequest("request-1"))
+		done <- result{resp, err}
+	}()
+
+	// The extension receives the request, then drops the stream before responding.
+	first := <-firstStream.toExtension
+	require.Equal(t, "request-1", first.RequestId)
+	close(firstStream.fromExtension)
+
+	// The extension reconnects on a new stream and answers the re-issued request.
+	secondStream := newFakeServiceTargetStream()
+	target.Reconnect(startFakeServiceTargetBroker(t, secondStream))
+
+	reissued := <-secondStream.toExtension
+	require.Equal(t, "request-1", reissued.RequestId)
+	secondStream.fromExtension <- &azdext.ServiceTargetMessage{
+		RequestId: reissued.RequestId,
+		MessageType: &azdext.ServiceTargetMessage_EndpointsResponse{
+			EndpointsResponse: &azdext.ServiceTargetEndpointsResponse{Endpoints: []string{"https://example.com"}},
+		},
+	}
+
+	select {
+	case r := <-done:
+		require.NoError(t, r.err)
+		require.Equal(t, []string{"https://example.com"}, r.resp.GetEndpointsResponse().Endpoints)
+	case <-time.After(5 * time.Second):
+		t.Fatal("request was not completed after reconnect")
+	}
+}
+
+func Test_ExternalServiceTarget_FailsWhenExtensionDoesNotReconnect(t *testing.T) {
+	originalTimeout := serviceTargetReconnectTimeout
+	serviceTargetReconnectTimeout = 50 * time.Millisecond
+	t.Cleanup(func() { serviceTargetReconnectTimeout = originalTimeout })
+
+	stream := newFakeServiceTargetStream()
+	target := NewExternalServiceTarget(
+		"test-target", ContainerAppTarget, nil, startFakeServiceTargetBroker(t, stream), nil, nil, nil,
+	).(*ExternalServiceTarget)
+
+	go func() {
+		<-stream.toExtension
+		close(stream.fromExtension)
+	}()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	_, err := target.sendAndWait(ctx, endpointsRequest("request-1"))
+	require.ErrorIs(t, err, grpcbroker.ErrBrokerClosed)
+	require.ErrorContains(t, err, "did not reconnect")
+}
+
+func Test_ExternalServiceTarget_SendsCancelRequest(t *testing.T) {
+	stream := newFakeServiceTargetStream()
+	target := NewExternalServiceTarget(
+		"test-target", ContainerAppTarget, nil, startFakeServiceTargetBroker(t, stream), nil, nil, nil,
+	).(*ExternalServiceTarget)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error, 1)
+	go func() {
+		_, err := target.sendAndWait(ctx, endpointsRequest("request-1"))
+		done <- err
+	}()
+
+	// The extension receives the request, then azd gives up on it before a response arrives.
+	require.Equal(t, "request-1", (<-stream.toExtension).RequestId)
+	cancel()
+
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("request was not abandoned after cancellation")
+	}
+
+	select {
+	case msg := <-stream.toExtension:
+		require.NotEqual(t, "request-1", msg.RequestId)
+		require.Equal(t, "request-1", msg.GetCancelRequest().GetRequestId())
+	case <-time.After(5 * time.Second):
+		t.Fatal("cancel request was not sent")
+	}
+}
+
+func Test_ExternalServiceTarget_PreservesExtensionErrorDetails(t *testing.T) {
+	stream := newFakeServiceTargetStream()
+	target := NewExternalServiceTarget(
+		"test-target", ContainerAppTarget, nil, startFakeServiceTargetBroker(t, stream), nil, nil, nil,
+	).(*ExternalServiceTarget)
+
+	go func() {
+		req := <-stream.toExtension
+		stream.fromExtension <- &azdext.ServiceTargetMessage{
+			RequestId: req.RequestId,
+			Error: azdext.WrapError(&azdext.LocalError{
+				Message:    "registry is throttling pushes",
+				Code:       azdext.ErrorCodeRetryable,
+				Category:   azdext.LocalErrorCategoryDependency,
+				Suggestion: "Wait a few minutes and run 'azd deploy' again.",
+			}),
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	_, err := target.sendAndWait(ctx, endpointsRequest("request-1"))
+	require.Error(t, err)
+	require.True(t, azdext.IsRetryableError(err))
+	require.Equal(t, "registry is throttling pushes", azdext.ErrorMessage(err))
+	require.Equal(t, "Wait a few minutes and run 'azd deploy' again.", azdext.ErrorSuggestion(err))
+}
+
+func Test_ExternalServiceTarget_ReportsStructuredProgress(t *testing.T) {
+	stream := newFakeServiceTargetStream()
+	target := NewExternalServiceTarget(
+		"test-target", ContainerAppTarget, nil, startFakeServiceTargetBroker(t, stream), nil, nil, nil,
+	).(*ExternalServiceTarget)
+
+	progressMessage := func(requestId string, progress *azdext.ServiceTargetProgressMessage) *azdext.ServiceTargetMessage {
+		progress.RequestId = requestId
+		return &azdext.ServiceTargetMessage{
+			RequestId:   requestId,
+			MessageType: &azdext.ServiceTargetMessage_ProgressMessage{ProgressMessage: progress},
+		}
+	}
+
+	// The broker dispatches received messages concurrently, so each message is only sent once azd has handled the
+	// previous one.
+	seen := make(chan struct{})
+	go func() {
+		req := <-stream.toExtension
+		stream.fromExtension <- progressMessage(req.RequestId, &azdext.ServiceTargetProgressMessage{
+			Message: "Preparing",
+		})
+		<-seen
+		stream.fromExtension <- progressMessage(req.RequestId, &azdext.ServiceTargetProgressMessage{
+			Message:         "Uploading artifacts",
+			Phase:           "upload",
+			PercentComplete: new(int32(40)),
+			Detail:          "app.zip",
+		})
+		<-seen
+		stream.fromExtension <- &azdext.ServiceTargetMessage{
+			RequestId: req.RequestId,
+			MessageType: &azdext.ServiceTargetMessage_EndpointsResponse{
+				EndpointsResponse: &azdext.ServiceTargetEndpointsResponse{},
+			},
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	var updates []ServiceProgress
+	_, err := target.sendAndWaitWithProgress(ctx, endpointsRequest("request-1"), func(progress ServiceProgress) {
+		updates = append(updates, progress)
+		seen <- struct{}{}
+	})
+	require.NoError(t, err)
+	require.Len(t, updates, 2)
+
+	// Message-only progress has no phase.
+	require.Equal(t, "Preparing", updates[0].Message)
+	require.Empty(t, updates[0].Phase)
+	require.Nil(t, updates[0].PercentComplete)
+	require.Equal(t, "Preparing", updates[0].String())
+
+	require.Equal(t, "upload", updates[1].Phase)
+	require.Equal(t, new(int32(40)), updates[1].PercentComplete)
+	require.Equal(t, "app.zip", updates[1].Detail)
+	require.Equal(t, "Uploading artifacts: app.zip (upload, 40%)", updates[1].String())
+}
+
+func Test_MergeServiceEndpoints(t *testing.T) {
+	tests := []struct {
+		name    string
+		urls    []string
+		labeled []*azdext.ServiceEndpoint
+		want    []string
+	}{
+		{
+			name: "bare urls only",
+			urls: []string{"https://app.example.com", "https://app-custom.example.com"},
+			want: []string{"https://app.example.com", "https://app-custom.example.com"},
+		},
+		{
+			name: "primary endpoint moves first",
+			labeled: []*azdext.ServiceEndpoint{
+				{Url: "https://app.example.com/healthz", Role: "health"},
+				{Url: "https://app.example.com", Role: "Primary"},
+				{Url: "https://admin.example.com", Role: "admin"},
+			},
+			want: []string{"https://app.example.com", "https://app.example.com/healthz", "https://admin.example.com"},
+		},
+		{
+			name: "labeled endpoint replaces a repeated bare url",
+			urls: []string{"https://app.example.com/healthz", "https://app.example.com"},
+			labeled: []*azdext.ServiceEndpoint{
+				{Url: "https://app.example.com", Label: "App", Role: "primary"},
+				{Label: "missing url"},
+			},
+			want: []string{"https://app.example.com", "https://app.example.com/healthz"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoints := mergeServiceEndpoints(tt.urls, tt.labeled)
+
+			urls := make([]string, len(endpoints))
+			for i, endpoint := range endpoints {
+				urls[i] = endpoint.GetUrl()
+			}
+			require.Equal(t, tt.want, urls)
+		})
+	}
+}
+
+func Test_ExternalServiceTarget_DeployReportsLabeledEndpoints(t *testing.T) {
+	stream := newFakeServiceTargetStream()
+	target := NewExternalServiceTarget(
+		"test-target", ContainerAppTarget, nil, startFakeServiceTargetBroker(t, stream), nil, nil, nil,
+	).(*ExternalServiceTarget)
+
+	go func() {
+		req := <-stream.toExtension
+		stream.fromExtension <- &azdext.ServiceTargetMessage{
+			RequestId: req.RequestId,
+			MessageType: &azdext.ServiceTargetMessage_DeployResponse{
+				DeployResponse: &azdext.ServiceTargetDeployResponse{
+					Result: &azdext.ServiceDeployResult{
+						Endpoints: []*azdext.ServiceEndpoint{
+							{Url: "https://app.example.com/admin", Label: "Admin", Role: "admin"},
+							{Url: "https://app.example.com", Role: "primary"},
+						},
+					},
+				},
+			},
+		}
+	}()
+
+	result, err := target.Deploy(
+		t.Context(),
+		&ServiceConfig{Name: "api", Project: &ProjectConfig{}},
+		NewServiceContext(),
+		environment.NewTargetResource("sub", "rg", "app", "Microsoft.App/containerApps"),
+		async.NewNoopProgress[ServiceProgress](),
+	)
+	require.NoError(t, err)
+
+	endpoints := result.Artifacts.Find(WithKind(ArtifactKindEndpoint))
+	require.Len(t, endpoints, 2)
+	require.Equal(t, "https://app.example.com", endpoints[0].Location)
+	require.Equal(t, "primary", endpoints[0].Metadata[MetadataKeyRole])
+	require.Equal(t, "https://app.example.com/admin", endpoints[1].Location)
+	require.Equal(t, "Admin", endpoints[1].Metadata[MetadataKeyLabel])
+}
+
+func Test_DeployRequestId(t *testing.T) {
+	packagePath := filepath.Join(t.TempDir(), "app.zip")
+	require.NoError(t, os.WriteFile(packagePath, []byte("v1"), osutil.PermissionFile))
+
+	serviceConfig := &ServiceConfig{Name: "api"}
+	targetResource := environment.NewTargetResource("sub", "rg", "app", "Microsoft.Web/sites")
+	newContext := func(location string) *ServiceContext {
+		serviceContext := NewServiceContext()
+		require.NoError(t, serviceContext.Package.Add(&Artifact{
+			Kind:         ArtifactKindArchive,
+			Location:     location,
+			LocationKind: LocationKindLocal,
+		}))
+		return serviceContext
+	}
+
+	requestId, ok := deployRequestId("ext", "demo", serviceConfig, newContext(packagePath), targetResource)
+	require.True(t, ok)
+	require.NoError(t, uuid.Validate(requestId))
+
+	t.Run("SameDeploy", func(t *testing.T) {
+		again, ok := deployRequestId("ext", "demo", serviceConfig, newContext(packagePath), targetResource)
+		require.True(t, ok)
+		require.Equal(t, requestId, again)
+	})
+
+	t.Run("OtherTarget", func(t *testing.T) {
+		other, ok := deployRequestId("ext", "demo", serviceConfig, newContext(packagePath),
+			environment.NewTargetResource("sub", "rg", "app-staging", "Microsoft.Web/sites"))
+		require.True(t, ok)
+		require.NotEqual(t, requestId, other)
+	})
+
+	t.Run("OtherService", func(t *testing.T) {
+		other, ok := deployRequestId("ext", "demo", &ServiceConfig{Name: "web"}, newContext(packagePath), targetResource)
+		require.True(t, ok)
+		require.NotEqual(t, requestId, other)
+	})
+
+	t.Run("OtherPackage", func(t *testing.T) {
+		serviceContext := newContext(packagePath)
+		require.NoError(t, serviceContext.Publish.Add(&Artifact{
+			Kind:         ArtifactKindContainer,
+			Location:     "registry.example.com/api:v2",
+			LocationKind: LocationKindRemote,
+		}))
+		other, ok := deployRequestId("ext", "demo", serviceConfig, serviceContext, targetResource)
+		require.True(t, ok)
+		require.NotEqual(t, requestId, other)
+	})
+
+	t.Run("RebuiltPackage", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(packagePath, []byte("v2-rebuilt"), osutil.PermissionFile))
+		t.Cleanup(func() {
+			require.NoError(t, os.WriteFile(packagePath, []byte("v1"), osutil.PermissionFile))
+		})
+
+		rebuilt, ok := deployRequestId("ext", "demo", serviceConfig, newContext(packagePath), targetResource)
+		require.True(t, ok)
+		require.NotEqual(t, requestId, rebuilt)
+	})
+
+	t.Run("NoPackage", func(t *testing.T) {
+		_, ok := deployRequestId("ext", "demo", serviceConfig, NewServiceContext(), targetResource)
+		require.False(t, ok)
+	})
+}
+
+func Test_ExternalServiceTarget_DeployReusesRequestId(t *testing.T) {
+	stream := newFakeServiceTargetStream()
+	target := NewExternalServiceTarget(
+		"test-target", ContainerAppTarget, nil, startFakeServiceTargetBroker(t, stream), nil, nil, nil,
+	).(*ExternalServiceTarget)
+
+	requestIds := make(chan string, 3)
+	go func() {
+		for req := range stream.toExtension {
+			requestIds <- req.RequestId
+			stream.fromExtension <- &azdext.ServiceTargetMessage{
+				RequestId: req.RequestId,
+				MessageType: &azdext.ServiceTargetMessage_DeployResponse{
+					DeployResponse: &azdext.ServiceTargetDeployResponse{Result: &azdext.ServiceDeployResult{}},
+				},
+			}
+		}
+	}()
+
+	deploy := func(serviceContext *ServiceContext) string {
+		_, err := target.Deploy(
+			t.Context(),
+			&ServiceConfig{Name: "api", Project: &ProjectConfig{}},
+			serviceContext,
+			environment.NewTargetResource("sub", "rg", "app", "Microsoft.App/containerApps"),
+			async.NewNoopProgress[ServiceProgress](),
+		)
+		require.NoError(t, err)
+		return <-requestIds
+	}
+	published := func() *ServiceContext {
+		serviceContext := NewServiceContext()
+		require.NoError(t, serviceContext.Publish.Add(&Artifact{
+			Kind:         ArtifactKindContainer,
+			Location:     "registry.example.com/api:v1",
+			LocationKind: LocationKindRemote,
+		}))
+		return serviceContext
+	}
+
+	first := deploy(published())
+	require.Equal(t, first, deploy(published()))
+	// Without a package there is nothing to identify the deploy by, so each one gets a fresh id.
+	require.NotEqual(t, first, deploy(NewServiceContext()))
+}
+
+func Test_ExternalServiceTarget_ClaimDeployRequestId(t *testing.T) {
+	target := &ExternalServiceTarget{targetName: "test-target"}
+	serviceContext := NewServiceContext()
+	require.NoError(t, serviceContext.Package.Add(&Artifact{
+		Kind:         ArtifactKindContainer,
+		Location:     "api:local",
+		LocationKind: LocationKindRemote,
+	}))
+	serviceConfig := &ServiceConfig{Name: "api"}
+	targetResource := environment.NewTargetResource("sub", "rg", "app", "Microsoft.App/containerApps")
+
+	first, release := target.claimDeployRequestId(serviceConfig, serviceContext, targetResource)
+	// A concurrent deploy of the same package is a distinct operation and gets a fresh id.
+	concurrent, releaseConcurrent := target.claimDeployRequestId(serviceConfig, serviceContext, targetResource)
+	require.NotEqual(t, first, concurrent)
+	releaseConcurrent()
+	release()
+
+	again, releaseAgain := target.claimDeployRequestId(serviceConfig, serviceContext, targetResource)
+	defer releaseAgain()
+	require.Equal(t, first, again)
+}
@@ -15,6 +15,7 @@ import (
 	"dario.cat/mergo"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/pkg/account"
 	"github.com/azure/azure-dev/cli/azd/pkg/auth"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
@@ -260,6 +261,9 @@ func (ps *promptService) PromptSubscription(
 
 	// Apply tenant filtering (after spinner is done so the prompt doesn't overlap)
 	subscriptionList = filterByTenantEnvVar(subscriptionList)
+	if len(subscriptionList) == 0 {
+		return nil, internal.ErrNoSubscriptionsFound
+	}
 	var selectedTenantId string
 	if !ps.console.IsNoPromptMode() {
 		subscriptionList, selectedTenantId, err = promptAndFilterByTenant(
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/pkg/account"
 	"github.com/azure/azure-dev/cli/azd/pkg/auth"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
@@ -72,6 +73,16 @@ func Test_PromptService_PromptSubscription(t *testing.T) {
 	require.NotNil(t, promptService)
 }
 
+func Test_PromptService_PromptSubscription_NoSubscriptions(t *testing.T) {
+	ps, _, sm, _ := newTestPromptService(t, false)
+
+	sm.On("GetSubscriptions", mock.Anything).Return([]account.Subscription{}, nil)
+
+	subscription, err := ps.PromptSubscription(t.Context(), nil)
+	require.ErrorIs(t, err, internal.ErrNoSubscriptionsFound)
+	require.Nil(t, subscription)
+}
+
 func TestFormatSubscriptionDisplayName_DemoModeHidesId(t *testing.T) {
 	displayName := FormatSubscriptionDisplay(&account.Subscription{
 		Id:   "/subscriptions/sub-1",
@@ -98,6 +98,7 @@ func Test_DockerAcceptance(t *testing.T) {
 		buildSecrets,
 		buildEnv,
 		"",
+		nil,
 		&buildOutput,
 	)
 	require.NoError(t, err, "build should succeed")
@@ -111,6 +111,7 @@ func (d *Cli) Build(
 	buildSecrets []string,
 	buildEnv []string,
 	buildNetwork string,
+	cacheFrom []string,
 	buildProgress io.Writer,
 ) (string, error) {
 	if strings.TrimSpace(platform) == "" {
@@ -154,6 +155,10 @@ func (d *Cli) Build(
 	for _, arg := range buildSecrets {
 		args = append(args, "--secret", arg)
 	}
+
+	for _, source := range cacheFrom {
+		args = append(args, "--cache-from", source)
+	}
 	args = append(args, buildContext)
 
 	// create a file with the docker img id
@@ -73,6 +73,7 @@ func Test_DockerBuild(t *testing.T) {
 			nil,
 			"",
 			nil,
+			nil,
 		)
 
 		require.Equal(t, true, ran)
@@ -133,6 +134,7 @@ func Test_DockerBuild(t *testing.T) {
 			nil,
 			"",
 			nil,
+			nil,
 		)
 
 		require.Equal(t, true, ran)
@@ -189,7 +191,7 @@ func Test_DockerBuildEmptyPlatform(t *testing.T) {
 	})
 
 	result, err := docker.Build(
-		t.Context(), cwd, dockerFile, "", "", dockerContext, imageName, buildArgs, nil, nil, "", nil)
+		t.Context(), cwd, dockerFile, "", "", dockerContext, imageName, buildArgs, nil, nil, "", nil, nil)
 
 	require.Equal(t, true, ran)
 	require.Nil(t, err)
@@ -238,7 +240,7 @@ func Test_DockerBuildArgsEmpty(t *testing.T) {
 	})
 
 	result, err := docker.Build(
-		t.Context(), cwd, dockerFile, "", "", dockerContext, imageName, buildArgs, nil, nil, "", nil)
+		t.Context(), cwd, dockerFile, "", "", dockerContext, imageName, buildArgs, nil, nil, "", nil, nil)
 
 	require.Equal(t, true, ran)
 	require.Nil(t, err)
@@ -289,13 +291,56 @@ func Test_DockerBuildArgsMultiple(t *testing.T) {
 	})
 
 	result, err := docker.Build(
-		t.Context(), cwd, dockerFile, "", "", dockerContext, imageName, buildArgs, nil, nil, "", nil)
+		t.Context(), cwd, dockerFile, "", "", dockerContext, imageName, buildArgs, nil, nil, "", nil, nil)
 
 	require.Equal(t, true, ran)
 	require.Nil(t, err)
 	require.Equal(t, mockedDockerImgId, result)
 }
 
+func Test_DockerBuildCacheFrom(t *testing.T) {
+	ran := false
+	cwd := "."
+	dockerFile := "./Dockerfile"
+	dockerContext := "../"
+	imageName := "IMAGE_NAME"
+	cacheFrom := []string{"type=registry,ref=contoso.azurecr.io/api:buildcache", "contoso.azurecr.io/api:latest"}
+
+	mockContext := mocks.NewMockContext(t.Context())
+	docker := NewCli(mockContext.CommandRunner)
+
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return strings.Contains(command, "docker build")
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		ran = true
+
+		// extract img id file arg. "--iidfile" and path args are expected always at the end
+		argsNoFile, value := args.Args[:len(args.Args)-2], args.Args[len(args.Args)-1]
+
+		require.Equal(t, []string{
+			"build",
+			"-f", dockerFile,
+			"--platform", DefaultPlatform,
+			"-t", imageName,
+			"--cache-from", cacheFrom[0],
+			"--cache-from", cacheFrom[1],
+			dockerContext,
+		}, argsNoFile)
+
+		err := os.WriteFile(value, []byte(mockedDockerImgId), 0600)
+		require.NoError(t, err)
+
+		return exec.RunResult{Stdout: mockedDockerImgId}, nil
+	})
+
+	result, err := docker.Build(
+		t.Context(), cwd, dockerFile, "", "", dockerContext, imageName, nil, nil, nil, "", cacheFrom, nil)
+
+	require.True(t, ran)
+	require.NoError(t, err)
+	require.Equal(t, mockedDockerImgId, result)
+}
+
 func Test_DockerBuildNetwork(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -346,7 +391,7 @@ func Test_DockerBuildNetwork(t *testing.T) {
 				cwd, dockerFile, "", "",
 				dockerContext, imageName,
 				nil, nil, nil,
-				tt.network, nil,
+				tt.network, nil, nil,
 			)
 
 			require.True(t, ran)
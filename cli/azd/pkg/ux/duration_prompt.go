@@ -0,0 +1,89 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// DurationPromptOptions represents the options for the DurationPrompt component.
+type DurationPromptOptions struct {
+	// The writer to use for output (default: os.Stdout)
+	Writer io.Writer
+	// The reader to use for input (default: os.Stdin)
+	Reader io.Reader
+	// The default value to use for the prompt, as a Go duration string such as "30s" (default: "")
+	DefaultValue string
+	// The message to display before the prompt
+	Message string
+	// The optional message to display when the user types ? (default: "")
+	HelpMessage string
+	// The optional smallest accepted duration (default: 0, no minimum)
+	Min time.Duration
+	// The optional largest accepted duration (default: 0, no maximum)
+	Max time.Duration
+}
+
+// DurationPrompt is a component for prompting the user for a Go-style duration such as "30s", "5m" or "1h30m".
+type DurationPrompt struct {
+	options *DurationPromptOptions
+	prompt  *Prompt
+}
+
+// NewDurationPrompt creates a new DurationPrompt instance.
+func NewDurationPrompt(options *DurationPromptOptions) *DurationPrompt {
+	return &DurationPrompt{
+		options: options,
+		prompt: NewPrompt(&PromptOptions{
+			Writer:          options.Writer,
+			Reader:          options.Reader,
+			DefaultValue:    options.DefaultValue,
+			Message:         options.Message,
+			HelpMessage:     options.HelpMessage,
+			PlaceHolder:     "e.g. 30s, 5m, 1h30m",
+			Required:        true,
+			RequiredMessage: "Enter a duration such as 30s or 5m",
+			ValidationFn: func(value string) (bool, string) {
+				if _, err := ParseDuration(value, options.Min, options.Max); err != nil {
+					return false, err.Error()
+				}
+				return true, ""
+			},
+		}),
+	}
+}
+
+// Ask prompts the user for a duration and returns the parsed value.
+func (p *DurationPrompt) Ask(ctx context.Context) (time.Duration, error) {
+	value, err := p.prompt.Ask(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return ParseDuration(value, p.options.Min, p.options.Max)
+}
+
+// ParseDuration parses value as a Go duration and checks it against the optional minimum and maximum bounds. A zero
+// bound is not enforced.
+func ParseDuration(value string, minimum time.Duration, maximum time.Duration) (time.Duration, error) {
+	duration, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil {
+		return 0, errors.New("enter a duration such as 30s or 5m")
+	}
+
+	if minimum > 0 && duration < minimum {
+		return 0, fmt.Errorf("enter a duration of at least %s", minimum)
+	}
+
+	if maximum > 0 && duration > maximum {
+		return 0, fmt.Errorf("enter a duration of at most %s", maximum)
+	}
+
+	return duration, nil
+}
@@ -0,0 +1,44 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ux
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		min     time.Duration
+		max     time.Duration
+		want    time.Duration
+		wantErr string
+	}{
+		{name: "seconds", value: "30s", want: 30 * time.Second},
+		{name: "compound", value: "1h30m", want: 90 * time.Minute},
+		{name: "surrounding space", value: " 5m ", want: 5 * time.Minute},
+		{name: "invalid", value: "5 minutes", wantErr: "enter a duration such as 30s or 5m"},
+		{name: "empty", value: "", wantErr: "enter a duration such as 30s or 5m"},
+		{name: "below min", value: "10s", min: time.Minute, wantErr: "at least 1m0s"},
+		{name: "above max", value: "2h", max: time.Hour, wantErr: "at most 1h0m0s"},
+		{name: "within bounds", value: "5m", min: time.Minute, max: time.Hour, want: 5 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDuration(tt.value, tt.min, tt.max)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
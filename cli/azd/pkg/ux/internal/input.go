@@ -58,6 +58,12 @@ func (i *Input) ResetValue() {
 	i.value = []rune{}
 }
 
+// SetValue replaces the value of the input, e.g. after completing it.
+// It must only be called from a KeyPressEventHandler, while the next key press is not yet being read.
+func (i *Input) SetValue(value string) {
+	i.value = []rune(value)
+}
+
 // ReadInput reads user input from the keyboard.
 func (i *Input) ReadInput(ctx context.Context, config *InputConfig, handler KeyPressEventHandler) error {
 	if config == nil {
@@ -190,3 +190,14 @@ func TestInput_ResetValueUnicode(t *testing.T) {
 	input.ResetValue()
 	require.Empty(t, input.value)
 }
+
+func TestInput_SetValue(t *testing.T) {
+	var buf bytes.Buffer
+	input := &Input{
+		cursor: NewCursor(&buf),
+		value:  []rune("src/ap"),
+	}
+
+	input.SetValue("src/api/")
+	require.Equal(t, []rune("src/api/"), input.value)
+}
@@ -31,7 +31,7 @@ type MultiSelectOptions struct {
 	Choices []*MultiSelectChoice
 	// The optional message to display when the user types ? (default: "")
 	HelpMessage string
-	// The maximum number of options to display at one time (default: 6)
+	// The maximum number of options to display at one time (default: console height minus a margin, at least 6)
 	DisplayCount int
 	// Whether or not to display the number prefix before each option (default: false)
 	DisplayNumbers *bool
@@ -94,6 +94,11 @@ func NewMultiSelect(options *MultiSelectOptions) *MultiSelect {
 		panic(err)
 	}
 
+	// Size the list from the console height unless the caller set an explicit count
+	if mergedOptions.DisplayCount == 0 {
+		mergedOptions.DisplayCount = autoDisplayCount(ConsoleHeight())
+	}
+
 	if err := mergo.Merge(&mergedOptions, DefaultMultiSelectOptions, mergo.WithoutDereference); err != nil {
 		panic(err)
 	}
@@ -0,0 +1,119 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ux
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+
+	"dario.cat/mergo"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+)
+
+// MultilinePromptOptions represents the options for the MultilinePrompt component.
+type MultilinePromptOptions struct {
+	// The writer to use for output (default: os.Stdout)
+	Writer io.Writer
+	// The reader to use for input (default: os.Stdin)
+	Reader io.Reader
+	// The value returned when no text is entered (default: "")
+	DefaultValue string
+	// The message to display before the prompt
+	Message string
+	// The optional message to display below the message (default: "")
+	HelpMessage string
+}
+
+var DefaultMultilinePromptOptions MultilinePromptOptions = MultilinePromptOptions{
+	Writer: os.Stdout,
+	Reader: os.Stdin,
+}
+
+// MultilinePrompt is a component for prompting the user for text spanning multiple lines. Input is read line by line
+// until the end of input (Ctrl+D, or Ctrl+Z followed by Enter on Windows).
+type MultilinePrompt struct {
+	options *MultilinePromptOptions
+}
+
+// NewMultilinePrompt creates a new MultilinePrompt instance.
+func NewMultilinePrompt(options *MultilinePromptOptions) *MultilinePrompt {
+	mergedOptions := MultilinePromptOptions{}
+	if err := mergo.Merge(&mergedOptions, options, mergo.WithoutDereference); err != nil {
+		panic(err)
+	}
+
+	if err := mergo.Merge(&mergedOptions, DefaultMultilinePromptOptions, mergo.WithoutDereference); err != nil {
+		panic(err)
+	}
+
+	return &MultilinePrompt{
+		options: &mergedOptions,
+	}
+}
+
+// Ask prompts the user for text and returns it. When no text is entered, the default value is returned.
+func (p *MultilinePrompt) Ask(ctx context.Context) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	endKey := "Ctrl+D"
+	if runtime.GOOS == "windows" {
+		endKey = "Ctrl+Z then Enter"
+	}
+
+	fmt.Fprintf(p.options.Writer, "%s%s %s\n",
+		output.WithHighLightFormat("? "),
+		BoldString("%s:", p.options.Message),
+		output.WithHighLightFormat("[Press %s on a new line to finish]", endKey),
+	)
+
+	if p.options.HelpMessage != "" {
+		fmt.Fprintln(p.options.Writer, output.WithGrayFormat("%s", p.options.HelpMessage))
+	}
+
+	if p.options.DefaultValue != "" {
+		fmt.Fprintln(p.options.Writer, output.WithGrayFormat("Finish without typing to keep the current value:"))
+		fmt.Fprintln(p.options.Writer, output.WithGrayFormat("%s", p.options.DefaultValue))
+	}
+
+	// Read on a separate goroutine so a cancelled context aborts the prompt while it waits for input. The read itself
+	// cannot be interrupted and ends with the next line or the end of input.
+	type readResult struct {
+		lines []string
+		err   error
+	}
+	readDone := make(chan readResult, 1)
+	go func() {
+		var lines []string
+		scanner := bufio.NewScanner(p.options.Reader)
+		scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), 1024*1024)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		readDone <- readResult{lines: lines, err: scanner.Err()}
+	}()
+
+	var lines []string
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case result := <-readDone:
+		if result.err != nil {
+			return "", result.err
+		}
+		lines = result.lines
+	}
+
+	if len(lines) == 0 {
+		return p.options.DefaultValue, nil
+	}
+
+	return strings.Join(lines, "\n") + "\n", nil
+}
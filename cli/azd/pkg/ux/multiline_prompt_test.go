@@ -0,0 +1,74 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ux
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultilinePrompt_Ask(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		defaultValue string
+		want         string
+	}{
+		{name: "lines", input: "name: api\nport: 8080\n", want: "name: api\nport: 8080\n"},
+		{name: "blank lines are kept", input: "a\n\nb", want: "a\n\nb\n"},
+		{name: "no input returns default", defaultValue: "name: api\n", want: "name: api\n"},
+		{name: "input replaces default", input: "name: web\n", defaultValue: "name: api\n", want: "name: web\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			prompt := NewMultilinePrompt(&MultilinePromptOptions{
+				Writer:       &out,
+				Reader:       strings.NewReader(tt.input),
+				Message:      "Edit the configuration",
+				DefaultValue: tt.defaultValue,
+			})
+
+			value, err := prompt.Ask(t.Context())
+			require.NoError(t, err)
+			require.Equal(t, tt.want, value)
+			require.Contains(t, out.String(), "Edit the configuration")
+		})
+	}
+}
+
+func TestMultilinePrompt_Ask_DeadlineExceeded(t *testing.T) {
+	// Input that never arrives keeps the prompt waiting for the user.
+	reader, writer := io.Pipe()
+	defer writer.Close()
+
+	prompt := NewMultilinePrompt(&MultilinePromptOptions{
+		Writer:  &bytes.Buffer{},
+		Reader:  reader,
+		Message: "Edit the configuration",
+	})
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := prompt.Ask(ctx)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Ask did not return after the context deadline expired")
+	}
+}
@@ -36,6 +36,8 @@ type PromptOptions struct {
 	PlaceHolder string
 	// The optional validation function to use
 	ValidationFn func(string) (bool, string)
+	// The optional function invoked when the user presses Tab; its result replaces the current value (default: nil)
+	CompletionFn func(string) string
 	// The optional validation message to display when validation fails (default: "Invalid input")
 	ValidationMessage string
 	// The optional validation message to display when the value is empty and required (default: "This field is required")
@@ -176,6 +178,12 @@ func (p *Prompt) Ask(ctx context.Context) (string, error) {
 
 		p.showHelp = args.Hint
 		p.value = args.Value
+
+		if args.Key == surveyterm.KeyTab && p.options.CompletionFn != nil {
+			p.value = p.options.CompletionFn(p.value)
+			p.input.SetValue(p.value)
+		}
+
 		p.validate()
 
 		if args.Key == surveyterm.KeyEnter {
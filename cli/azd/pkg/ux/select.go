@@ -35,17 +35,25 @@ type SelectOptions struct {
 	HelpMessage string
 	// The optional hint text that display after the message (default: "[Type ? for hint]")
 	Hint string
-	// The maximum number of options to display at one time (default: 6)
+	// The maximum number of options to display at one time (default: console height minus a margin, at least 6)
 	DisplayCount int
 	// Whether or not to display the number prefix before each option (default: false)
 	DisplayNumbers *bool
 	// Whether or not to disable filtering (default: true)
 	EnableFiltering *bool
+	// Whether to select the only choice without prompting, printing a dimmed message naming it (default: false)
+	AnnounceAutoSelect bool
+	// The optional function that produces the choices for the current filter text, replacing Choices and local
+	// filtering. It is called with an empty filter before the prompt is shown and again whenever the filter changes.
+	// The index returned by Ask refers to the choices of the latest call. (default: nil)
+	Search func(ctx context.Context, filter string) ([]*SelectChoice, error)
 }
 
 type SelectChoice struct {
 	Value string
 	Label string
+	// The optional description displayed dimmed beside the choice while it is highlighted (default: "")
+	Description string
 }
 
 type indexedSelectChoice struct {
@@ -89,17 +97,16 @@ func NewSelect(options *SelectOptions) *Select {
 		panic(err)
 	}
 
+	// Size the list from the console height unless the caller set an explicit count
+	if mergedOptions.DisplayCount == 0 {
+		mergedOptions.DisplayCount = autoDisplayCount(ConsoleHeight())
+	}
+
 	if err := mergo.Merge(&mergedOptions, DefaultSelectOptions, mergo.WithoutDereference); err != nil {
 		panic(err)
 	}
 
-	selectOptions := make([]*indexedSelectChoice, len(mergedOptions.Choices))
-	for index, value := range mergedOptions.Choices {
-		selectOptions[index] = &indexedSelectChoice{
-			Index:        index,
-			SelectChoice: value,
-		}
-	}
+	selectOptions := indexSelectChoices(mergedOptions.Choices)
 
 	// Define default hint message
 	if mergedOptions.Hint == "" {
@@ -120,6 +127,37 @@ func NewSelect(options *SelectOptions) *Select {
 	}
 }
 
+func indexSelectChoices(choices []*SelectChoice) []*indexedSelectChoice {
+	indexed := make([]*indexedSelectChoice, len(choices))
+	for index, value := range choices {
+		indexed[index] = &indexedSelectChoice{
+			Index:        index,
+			SelectChoice: value,
+		}
+	}
+
+	return indexed
+}
+
+// search replaces the choices with the results of the Search option for filter, highlighting the first result.
+func (p *Select) search(ctx context.Context, filter string) error {
+	choices, err := p.options.Search(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	p.choices = indexSelectChoices(choices)
+	p.filteredChoices = p.choices
+	p.currentIndex = nil
+	p.selectedChoice = nil
+	if len(p.choices) > 0 {
+		p.currentIndex = new(0)
+		p.selectedChoice = p.choices[0]
+	}
+
+	return nil
+}
+
 // WithCanvas sets the canvas for the select component.
 func (p *Select) WithCanvas(canvas Canvas) Visual {
 	p.canvas = canvas
@@ -128,6 +166,23 @@ func (p *Select) WithCanvas(canvas Canvas) Visual {
 
 // Ask prompts the user to select an option from a list.
 func (p *Select) Ask(ctx context.Context) (*int, error) {
+	if p.options.Search != nil {
+		if err := p.search(ctx, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.options.AnnounceAutoSelect && len(p.choices) == 1 {
+		p.selectedChoice = p.choices[0]
+		p.complete = true
+		fmt.Fprintln(
+			p.options.Writer,
+			output.WithGrayFormat("Using the only available option: %s", p.selectedChoice.Label),
+		)
+
+		return &p.selectedChoice.Index, nil
+	}
+
 	if p.canvas == nil {
 		p.canvas = NewCanvas(p).WithWriter(p.options.Writer)
 	}
@@ -167,6 +222,11 @@ func (p *Select) Ask(ctx context.Context) (*int, error) {
 		p.showHelp = args.Hint
 
 		if *p.options.EnableFiltering {
+			if p.options.Search != nil && args.Value != p.filter {
+				if err := p.search(ctx, args.Value); err != nil {
+					return false, err
+				}
+			}
 			p.filter = args.Value
 		}
 
@@ -204,7 +264,8 @@ func (p *Select) applyFilter() {
 		p.filteredChoices = p.choices
 	}
 
-	if p.cancelled || p.complete || p.filter == "" {
+	// Search results are already filtered by the Search option
+	if p.cancelled || p.complete || p.filter == "" || p.options.Search != nil {
 		return
 	}
 
@@ -236,7 +297,7 @@ func (p *Select) applyFilter() {
 
 func (p *Select) renderOptions(printer Printer, indent string) {
 	// Options
-	if p.cancelled || p.complete {
+	if p.cancelled || p.complete || len(p.filteredChoices) == 0 {
 		return
 	}
 
@@ -289,11 +350,16 @@ func (p *Select) renderOptions(printer Printer, indent string) {
 
 		if start+index == selected {
 			prefix := ">"
-			printer.Fprintf("%s%s %s%s\n",
+			description := ""
+			if option.Description != "" {
+				description = " " + output.WithGrayFormat("- %s", option.Description)
+			}
+			printer.Fprintf("%s%s %s%s%s\n",
 				indent,
 				output.WithHighLightFormat(prefix),
 				output.WithHighLightFormat(digitPrefix),
 				output.WithHighLightFormat(displayValue),
+				description,
 			)
 		} else {
 			prefix := " "
@@ -375,7 +441,7 @@ func (p *Select) renderMessage(printer Printer) {
 
 // Render renders the Select component.
 func (p *Select) Render(printer Printer) error {
-	if p.currentIndex == nil && p.options.SelectedIndex != nil {
+	if p.currentIndex == nil && p.options.SelectedIndex != nil && *p.options.SelectedIndex < len(p.choices) {
 		p.currentIndex = p.options.SelectedIndex
 		p.selectedChoice = p.choices[*p.currentIndex]
 	}
@@ -5,6 +5,8 @@ package ux
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"testing"
@@ -53,6 +55,23 @@ func TestNewSelect_hint_no_filter(t *testing.T) {
 	assert.NotContains(t, s.options.Hint, "type to filter")
 }
 
+func TestNewSelect_display_count(t *testing.T) {
+	explicit := NewSelect(&SelectOptions{
+		Writer:       io.Discard,
+		Message:      "Pick one",
+		Choices:      []*SelectChoice{{Value: "a", Label: "A"}},
+		DisplayCount: 3,
+	})
+	assert.Equal(t, 3, explicit.options.DisplayCount)
+
+	auto := NewSelect(&SelectOptions{
+		Writer:  io.Discard,
+		Message: "Pick one",
+		Choices: []*SelectChoice{{Value: "a", Label: "A"}},
+	})
+	assert.Equal(t, autoDisplayCount(ConsoleHeight()), auto.options.DisplayCount)
+}
+
 func TestNewSelect_custom_hint(t *testing.T) {
 	s := NewSelect(&SelectOptions{
 		Writer:  io.Discard,
@@ -87,6 +106,28 @@ func TestSelect_Render_initial(t *testing.T) {
 	assert.Contains(t, output, "Charlie")
 }
 
+func TestSelect_Render_description(t *testing.T) {
+	var buf bytes.Buffer
+	printer := NewPrinter(&buf)
+
+	s := NewSelect(&SelectOptions{
+		Writer:  io.Discard,
+		Message: "Choose",
+		Choices: []*SelectChoice{
+			{Value: "a", Label: "Alpha", Description: "first letter"},
+			{Value: "b", Label: "Bravo", Description: "second letter"},
+		},
+	})
+
+	err := s.Render(printer)
+	require.NoError(t, err)
+
+	output := buf.String()
+	// Only the highlighted choice shows its description
+	assert.Contains(t, output, "first letter")
+	assert.NotContains(t, output, "second letter")
+}
+
 func TestSelect_Render_complete(t *testing.T) {
 	var buf bytes.Buffer
 	printer := NewPrinter(&buf)
@@ -232,6 +273,88 @@ func TestSelect_renderValidation_no_matches(t *testing.T) {
 	assert.Contains(t, s.validationMessage, "No options found")
 }
 
+func TestSelect_AnnounceAutoSelect(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewSelect(&SelectOptions{
+		Writer:             &buf,
+		Message:            "Select a SKU",
+		Choices:            []*SelectChoice{{Value: "GlobalStandard", Label: "GlobalStandard"}},
+		AnnounceAutoSelect: true,
+	})
+
+	index, err := s.Ask(t.Context())
+	require.NoError(t, err)
+	require.NotNil(t, index)
+	assert.Equal(t, 0, *index)
+	assert.Contains(t, buf.String(), "Using the only available option: GlobalStandard")
+}
+
+func TestSelect_search(t *testing.T) {
+	var filters []string
+	s := NewSelect(&SelectOptions{
+		Writer:  io.Discard,
+		Message: "Choose",
+		Search: func(ctx context.Context, filter string) ([]*SelectChoice, error) {
+			filters = append(filters, filter)
+			if filter == "none" {
+				return nil, nil
+			}
+			return []*SelectChoice{{Value: filter + "-1", Label: "One"}, {Value: filter + "-2", Label: "Two"}}, nil
+		},
+	})
+
+	require.NoError(t, s.search(t.Context(), "gpt"))
+	require.Len(t, s.choices, 2)
+	require.Equal(t, 0, *s.currentIndex)
+	require.Equal(t, "gpt-1", s.selectedChoice.Value)
+
+	// Search results are not filtered again locally, even when labels do not contain the filter
+	s.filter = "gpt"
+	s.applyFilter()
+	assert.Len(t, s.filteredChoices, 2)
+
+	require.NoError(t, s.search(t.Context(), "none"))
+	assert.Empty(t, s.choices)
+	assert.Nil(t, s.currentIndex)
+	assert.Nil(t, s.selectedChoice)
+
+	var buf bytes.Buffer
+	s.filter = "none"
+	require.NoError(t, s.Render(NewPrinter(&buf)))
+	assert.Contains(t, buf.String(), "No options found")
+	assert.Equal(t, []string{"gpt", "none"}, filters)
+}
+
+func TestSelect_search_error(t *testing.T) {
+	s := NewSelect(&SelectOptions{
+		Writer:  io.Discard,
+		Message: "Choose",
+		Search: func(ctx context.Context, filter string) ([]*SelectChoice, error) {
+			return nil, errors.New("search failed")
+		},
+	})
+
+	_, err := s.Ask(t.Context())
+	require.EqualError(t, err, "search failed")
+}
+
+func TestSelect_search_AnnounceAutoSelect(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewSelect(&SelectOptions{
+		Writer:             &buf,
+		Message:            "Select a model",
+		AnnounceAutoSelect: true,
+		Search: func(ctx context.Context, filter string) ([]*SelectChoice, error) {
+			return []*SelectChoice{{Value: "gpt-4o", Label: "gpt-4o"}}, nil
+		},
+	})
+
+	index, err := s.Ask(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 0, *index)
+	assert.Contains(t, buf.String(), "Using the only available option: gpt-4o")
+}
+
 // --- MultiSelect tests ---
 
 func TestNewMultiSelect_with_choices(t *testing.T) {
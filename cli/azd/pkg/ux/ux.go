@@ -153,6 +153,41 @@ func TruncateVisible(s string, maxWidth int) string {
 	return result.String()
 }
 
+// ConsoleHeight returns the height of the console in rows.
+// It uses the consolesize package to get the size and falls back to check the LINES environment variable.
+// Returns 0 if the console size cannot be determined.
+func ConsoleHeight() int {
+	_, height := consolesize.GetConsoleSize()
+	if height <= 0 {
+		height = 0
+
+		if consoleHeight := os.Getenv("LINES"); consoleHeight != "" {
+			if parsedHeight, err := strconv.Atoi(consoleHeight); err == nil && parsedHeight > 0 {
+				height = parsedHeight
+			}
+		}
+	}
+
+	return height
+}
+
+const (
+	// minAutoDisplayCount is the fewest options a list prompt shows when sized from the console height.
+	minAutoDisplayCount = 6
+	// maxAutoDisplayCount is the most options a list prompt shows when sized from the console height, so a tall
+	// console does not turn the prompt into a wall of options.
+	maxAutoDisplayCount = 15
+	// displayCountMargin reserves rows for the message, filter, overflow markers and footer of a list prompt.
+	displayCountMargin = 10
+)
+
+// autoDisplayCount returns the number of options a list prompt displays when no DisplayCount is set, based on the
+// console height minus a margin for the rest of the prompt, between minAutoDisplayCount and maxAutoDisplayCount.
+// Returns minAutoDisplayCount when the height is unknown or too small.
+func autoDisplayCount(consoleHeight int) int {
+	return min(maxAutoDisplayCount, max(minAutoDisplayCount, consoleHeight-displayCountMargin))
+}
+
 // ConsoleWidth returns the width of the console in characters.
 // It uses the consolesize package to get the size and falls back to check the COLUMNS environment variable
 // Defaults to 120 if the console size cannot be determined.
@@ -244,6 +244,37 @@ func TestConsoleWidth_empty_COLUMNS_uses_default(t *testing.T) {
 	}
 }
 
+func TestConsoleHeight_from_env(t *testing.T) {
+	t.Setenv("LINES", "50")
+
+	// ConsoleHeight uses consolesize-go first; if that returns <=0 it falls back to LINES
+	height := ConsoleHeight()
+	if height <= 0 {
+		t.Fatalf("ConsoleHeight() = %d, want > 0", height)
+	}
+}
+
+func TestAutoDisplayCount(t *testing.T) {
+	tests := []struct {
+		name   string
+		height int
+		want   int
+	}{
+		{name: "unknown height", height: 0, want: minAutoDisplayCount},
+		{name: "short terminal", height: 12, want: minAutoDisplayCount},
+		{name: "medium terminal", height: 20, want: 10},
+		{name: "tall terminal", height: 50, want: maxAutoDisplayCount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := autoDisplayCount(tt.height); got != tt.want {
+				t.Fatalf("autoDisplayCount(%d) = %d, want %d", tt.height, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestPtr(t *testing.T) {
 	intVal := 42
 	p := Ptr(intVal)